@@ -0,0 +1,401 @@
+package aries
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"cosmos-app/didcomm"
+	didmodule "cosmos-app/modules/did"
+)
+
+// DID Exchange message types.
+const (
+	RequestType  = DIDExchangeProtocol + "/request"
+	ResponseType = DIDExchangeProtocol + "/response"
+	CompleteType = DIDExchangeProtocol + "/complete"
+)
+
+// Connection states.
+const (
+	StateRequestSent  = "request-sent"
+	StateResponseSent = "response-sent"
+	StateCompleted    = "completed"
+)
+
+// Thread links a message to its protocol thread and parent invitation.
+type Thread struct {
+	ThreadID       string `json:"thid,omitempty"`
+	ParentThreadID string `json:"pthid,omitempty"`
+}
+
+// Request is the invitee's DID Exchange request.
+type Request struct {
+	Type     string  `json:"@type"`
+	ID       string  `json:"@id"`
+	Thread   *Thread `json:"~thread,omitempty"`
+	Label    string  `json:"label,omitempty"`
+	GoalCode string  `json:"goal_code,omitempty"`
+	Goal     string  `json:"goal,omitempty"`
+	DID      string  `json:"did"`
+}
+
+// Response is the inviter's DID Exchange response. DIDRotate carries the
+// inviter's DID signed with the invitation key.
+type Response struct {
+	Type      string      `json:"@type"`
+	ID        string      `json:"@id"`
+	Thread    *Thread     `json:"~thread"`
+	DID       string      `json:"did"`
+	DIDRotate *Attachment `json:"did_rotate~attach,omitempty"`
+}
+
+// Complete acknowledges the response and completes the exchange.
+type Complete struct {
+	Type   string  `json:"@type"`
+	ID     string  `json:"@id"`
+	Thread *Thread `json:"~thread"`
+}
+
+// Attachment is an Aries RFC 0017 attachment with base64 data and an
+// optional JWS over it.
+type Attachment struct {
+	ID       string         `json:"@id,omitempty"`
+	MimeType string         `json:"mime-type,omitempty"`
+	Data     AttachmentData `json:"data"`
+}
+
+// AttachmentData holds the attached content.
+type AttachmentData struct {
+	Base64 string         `json:"base64"`
+	JWS    *AttachmentJWS `json:"jws,omitempty"`
+}
+
+// AttachmentJWS is a detached general JWS over an attachment's base64 data.
+type AttachmentJWS struct {
+	Header    map[string]string `json:"header"`
+	Protected string            `json:"protected"`
+	Signature string            `json:"signature"`
+}
+
+// Connection is the state of a DID Exchange between this agent and a peer,
+// identified by the exchange's thread ID.
+type Connection struct {
+	ID            string `json:"id"`
+	State         string `json:"state"`
+	InvitationID  string `json:"invitation_id"`
+	InvitationKey string `json:"invitation_key,omitempty"`
+	MyDID         string `json:"my_did"`
+	TheirDID      string `json:"their_did,omitempty"`
+	TheirLabel    string `json:"their_label,omitempty"`
+}
+
+// Agent runs both sides of DID Exchange, resolving every DID it is given
+// through resolver. Peers must present DIDs the resolver can resolve, such as
+// did:aytch, did:peer:2 or did:key; attached DID documents are not accepted.
+type Agent struct {
+	label    string
+	resolver didcomm.Resolver
+
+	mu          sync.Mutex
+	invitations map[string]Invitation
+	connections map[string]*Connection
+}
+
+// NewAgent creates an agent that introduces itself with label.
+func NewAgent(label string, resolver didcomm.Resolver) *Agent {
+	return &Agent{
+		label:       label,
+		resolver:    resolver,
+		invitations: make(map[string]Invitation),
+		connections: make(map[string]*Connection),
+	}
+}
+
+// CreateInvitation creates an out-of-band invitation to connect to did,
+// whose authentication key will sign the response to any request.
+func (a *Agent) CreateInvitation(ctx context.Context, did string) (Invitation, error) {
+	if _, err := a.resolver.ResolveDID(ctx, did); err != nil {
+		return Invitation{}, err
+	}
+	inv, err := NewInvitation(a.label, did)
+	if err != nil {
+		return Invitation{}, err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.invitations[inv.ID] = inv
+	return inv, nil
+}
+
+// AcceptInvitation answers an invitation with a request to connect as myDID.
+func (a *Agent) AcceptInvitation(ctx context.Context, inv Invitation, myDID string) (Request, error) {
+	if !inv.supportsDIDExchange() {
+		return Request{}, fmt.Errorf("invitation %s does not offer DID Exchange", inv.ID)
+	}
+	if len(inv.Services) == 0 {
+		return Request{}, fmt.Errorf("invitation %s has no services", inv.ID)
+	}
+	key, err := inv.Services[0].invitationKey()
+	if err != nil {
+		return Request{}, err
+	}
+	if _, err := a.resolver.ResolveDID(ctx, myDID); err != nil {
+		return Request{}, err
+	}
+	id, err := newID()
+	if err != nil {
+		return Request{}, err
+	}
+	req := Request{
+		Type:     RequestType,
+		ID:       id,
+		Thread:   &Thread{ThreadID: id, ParentThreadID: inv.ID},
+		Label:    a.label,
+		GoalCode: inv.GoalCode,
+		Goal:     inv.Goal,
+		DID:      myDID,
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.connections[id] = &Connection{
+		ID:            id,
+		State:         StateRequestSent,
+		InvitationID:  inv.ID,
+		InvitationKey: key,
+		MyDID:         myDID,
+		TheirLabel:    inv.Label,
+	}
+	return req, nil
+}
+
+// HandleRequest answers a request to one of the agent's invitations with a
+// response introducing myDID, signed by signer, an authentication key of the
+// invitation's DID.
+func (a *Agent) HandleRequest(ctx context.Context, req Request, myDID string, signer *didcomm.Signer) (Response, error) {
+	if req.Type != RequestType && req.Type != "https://didcomm.org/didexchange/1.0/request" {
+		return Response{}, fmt.Errorf("unexpected message type %s", req.Type)
+	}
+	if req.Thread == nil || req.Thread.ParentThreadID == "" {
+		return Response{}, fmt.Errorf("request does not reference an invitation")
+	}
+	a.mu.Lock()
+	inv, ok := a.invitations[req.Thread.ParentThreadID]
+	a.mu.Unlock()
+	if !ok {
+		return Response{}, fmt.Errorf("unknown invitation %s", req.Thread.ParentThreadID)
+	}
+	if inv.Services[0].DID != didOf(signer.KID) {
+		return Response{}, fmt.Errorf("signer %s is not a key of invitation DID %s", signer.KID, inv.Services[0].DID)
+	}
+	if _, err := a.resolver.ResolveDID(ctx, req.DID); err != nil {
+		return Response{}, fmt.Errorf("requester DID %s: %w", req.DID, err)
+	}
+	thid := req.Thread.ThreadID
+	if thid == "" {
+		thid = req.ID
+	}
+	rotate, err := signAttachment([]byte(myDID), signer)
+	if err != nil {
+		return Response{}, err
+	}
+	id, err := newID()
+	if err != nil {
+		return Response{}, err
+	}
+	resp := Response{
+		Type:      ResponseType,
+		ID:        id,
+		Thread:    &Thread{ThreadID: thid},
+		DID:       myDID,
+		DIDRotate: rotate,
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, exists := a.connections[thid]; exists {
+		return Response{}, fmt.Errorf("connection %s already exists", thid)
+	}
+	a.connections[thid] = &Connection{
+		ID:           thid,
+		State:        StateResponseSent,
+		InvitationID: inv.ID,
+		MyDID:        myDID,
+		TheirDID:     req.DID,
+		TheirLabel:   req.Label,
+	}
+	return resp, nil
+}
+
+// HandleResponse checks the inviter's response against the invitation key,
+// completes the connection and returns the complete message to send back.
+func (a *Agent) HandleResponse(ctx context.Context, resp Response) (Complete, Connection, error) {
+	if resp.Thread == nil {
+		return Complete{}, Connection{}, fmt.Errorf("response has no thread")
+	}
+	a.mu.Lock()
+	conn, ok := a.connections[resp.Thread.ThreadID]
+	var c Connection
+	if ok {
+		c = *conn
+	}
+	a.mu.Unlock()
+	if !ok || c.State != StateRequestSent {
+		return Complete{}, Connection{}, fmt.Errorf("no pending request for thread %s", resp.Thread.ThreadID)
+	}
+	if resp.DIDRotate == nil {
+		return Complete{}, Connection{}, fmt.Errorf("response is not signed by the invitation key")
+	}
+	signed, kid, err := a.verifyAttachment(ctx, *resp.DIDRotate)
+	if err != nil {
+		return Complete{}, Connection{}, err
+	}
+	if didOf(kid) != c.InvitationKey {
+		return Complete{}, Connection{}, fmt.Errorf("response signed by %s, not the invitation key %s", kid, c.InvitationKey)
+	}
+	if string(signed) != resp.DID {
+		return Complete{}, Connection{}, fmt.Errorf("signed DID %s does not match response DID %s", signed, resp.DID)
+	}
+	if _, err := a.resolver.ResolveDID(ctx, resp.DID); err != nil {
+		return Complete{}, Connection{}, fmt.Errorf("inviter DID %s: %w", resp.DID, err)
+	}
+	id, err := newID()
+	if err != nil {
+		return Complete{}, Connection{}, err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	conn.State = StateCompleted
+	conn.TheirDID = resp.DID
+	complete := Complete{
+		Type:   CompleteType,
+		ID:     id,
+		Thread: &Thread{ThreadID: conn.ID, ParentThreadID: conn.InvitationID},
+	}
+	return complete, *conn, nil
+}
+
+// HandleComplete completes a connection the agent responded to.
+func (a *Agent) HandleComplete(complete Complete) (Connection, error) {
+	if complete.Thread == nil {
+		return Connection{}, fmt.Errorf("complete message has no thread")
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	conn, ok := a.connections[complete.Thread.ThreadID]
+	if !ok || conn.State != StateResponseSent {
+		return Connection{}, fmt.Errorf("no pending response for thread %s", complete.Thread.ThreadID)
+	}
+	conn.State = StateCompleted
+	return *conn, nil
+}
+
+// Connection returns the connection with the given thread ID.
+func (a *Agent) Connection(id string) (Connection, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	conn, ok := a.connections[id]
+	if !ok {
+		return Connection{}, false
+	}
+	return *conn, true
+}
+
+// Connections lists the agent's connections.
+func (a *Agent) Connections() []Connection {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	conns := make([]Connection, 0, len(a.connections))
+	for _, conn := range a.connections {
+		conns = append(conns, *conn)
+	}
+	return conns
+}
+
+type attachmentHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+func signAttachment(data []byte, signer *didcomm.Signer) (*Attachment, error) {
+	headerBz, err := json.Marshal(attachmentHeader{Alg: "EdDSA", Kid: signer.KID})
+	if err != nil {
+		return nil, err
+	}
+	protected := base64.RawURLEncoding.EncodeToString(headerBz)
+	encoded := base64.RawURLEncoding.EncodeToString(data)
+	sig := ed25519.Sign(signer.Key, []byte(protected+"."+encoded))
+	return &Attachment{
+		MimeType: "text/string",
+		Data: AttachmentData{
+			Base64: encoded,
+			JWS: &AttachmentJWS{
+				Header:    map[string]string{"kid": signer.KID},
+				Protected: protected,
+				Signature: base64.RawURLEncoding.EncodeToString(sig),
+			},
+		},
+	}, nil
+}
+
+// verifyAttachment checks an attachment's JWS against the Ed25519 method
+// named by its kid and returns the attached data and the kid.
+func (a *Agent) verifyAttachment(ctx context.Context, att Attachment) ([]byte, string, error) {
+	if att.Data.JWS == nil {
+		return nil, "", fmt.Errorf("attachment is not signed")
+	}
+	headerBz, err := decodeBase64(att.Data.JWS.Protected)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid attachment JWS header: %w", err)
+	}
+	var header attachmentHeader
+	if err := json.Unmarshal(headerBz, &header); err != nil {
+		return nil, "", fmt.Errorf("invalid attachment JWS header: %w", err)
+	}
+	if header.Alg != "EdDSA" {
+		return nil, "", fmt.Errorf("unsupported attachment signature algorithm %s", header.Alg)
+	}
+	kid := header.Kid
+	if kid == "" {
+		kid = att.Data.JWS.Header["kid"]
+	}
+	doc, err := a.resolver.ResolveDID(ctx, didOf(kid))
+	if err != nil {
+		return nil, "", err
+	}
+	vm, ok := doc.VerificationMethod(kid)
+	if !ok && !strings.Contains(kid, "#") {
+		vm, ok = doc.VerificationMethod(doc.Authentication)
+		kid = doc.Authentication
+	}
+	if !ok || vm.Type != didmodule.Ed25519VerificationKey2020 {
+		return nil, "", fmt.Errorf("signing key %s not found", kid)
+	}
+	pub, err := vm.PublicKeyBytes()
+	if err != nil {
+		return nil, "", err
+	}
+	sig, err := decodeBase64(att.Data.JWS.Signature)
+	if err != nil {
+		return nil, "", err
+	}
+	if !ed25519.Verify(ed25519.PublicKey(pub), []byte(att.Data.JWS.Protected+"."+att.Data.Base64), sig) {
+		return nil, "", fmt.Errorf("invalid attachment signature")
+	}
+	data, err := decodeBase64(att.Data.Base64)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, kid, nil
+}
+
+// didOf strips the fragment from a DID URL.
+func didOf(didURL string) string {
+	return strings.SplitN(didURL, "#", 2)[0]
+}
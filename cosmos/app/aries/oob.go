@@ -0,0 +1,174 @@
+// Package aries implements the Hyperledger Aries protocols agents use to
+// connect with did:aytch identities: RFC 0434 out-of-band invitations and
+// RFC 0023 DID Exchange. Both sides exchange DIDs that resolve through a
+// didcomm.Resolver, typically a resolver.MultiResolver covering did:aytch,
+// did:peer and did:key, so connections can be made with a public on-chain DID
+// or a pairwise peer DID that is never registered.
+package aries
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	didmodule "cosmos-app/modules/did"
+)
+
+// Message types and protocols of the out-of-band and DID Exchange protocols.
+const (
+	InvitationType      = "https://didcomm.org/out-of-band/1.1/invitation"
+	DIDExchangeProtocol = "https://didcomm.org/didexchange/1.1"
+)
+
+// Invitation is an out-of-band invitation to connect.
+type Invitation struct {
+	Type               string              `json:"@type"`
+	ID                 string              `json:"@id"`
+	Label              string              `json:"label,omitempty"`
+	GoalCode           string              `json:"goal_code,omitempty"`
+	Goal               string              `json:"goal,omitempty"`
+	Accept             []string            `json:"accept,omitempty"`
+	HandshakeProtocols []string            `json:"handshake_protocols"`
+	Services           []InvitationService `json:"services"`
+}
+
+// InvitationService is an entry of an invitation's services: either a DID
+// whose document lists the inviter's endpoints and keys, or an inline service
+// with did:key recipient keys.
+type InvitationService struct {
+	DID             string   `json:"-"`
+	ID              string   `json:"id,omitempty"`
+	Type            string   `json:"type,omitempty"`
+	RecipientKeys   []string `json:"recipientKeys,omitempty"`
+	RoutingKeys     []string `json:"routingKeys,omitempty"`
+	ServiceEndpoint string   `json:"serviceEndpoint,omitempty"`
+}
+
+type inlineService InvitationService
+
+// MarshalJSON encodes DID services as a bare DID string.
+func (s InvitationService) MarshalJSON() ([]byte, error) {
+	if s.DID != "" {
+		return json.Marshal(s.DID)
+	}
+	return json.Marshal(inlineService(s))
+}
+
+// UnmarshalJSON accepts a DID string or an inline service object.
+func (s *InvitationService) UnmarshalJSON(bz []byte) error {
+	var did string
+	if err := json.Unmarshal(bz, &did); err == nil {
+		*s = InvitationService{DID: did}
+		return nil
+	}
+	var inline inlineService
+	if err := json.Unmarshal(bz, &inline); err != nil {
+		return err
+	}
+	*s = InvitationService(inline)
+	return nil
+}
+
+// invitationKey returns the DID whose authentication key must sign the
+// inviter's DID Exchange response.
+func (s InvitationService) invitationKey() (string, error) {
+	if s.DID != "" {
+		return s.DID, nil
+	}
+	if len(s.RecipientKeys) == 0 || !strings.HasPrefix(s.RecipientKeys[0], "did:key:") {
+		return "", fmt.Errorf("inline invitation service needs a did:key recipient key")
+	}
+	return strings.SplitN(s.RecipientKeys[0], "#", 2)[0], nil
+}
+
+// NewInvitation creates an invitation to connect to did using DID Exchange.
+func NewInvitation(label, did string) (Invitation, error) {
+	id, err := newID()
+	if err != nil {
+		return Invitation{}, err
+	}
+	return Invitation{
+		Type:               InvitationType,
+		ID:                 id,
+		Label:              label,
+		Accept:             []string{didmodule.DIDCommV2Profile, "didcomm/aip2;env=rfc19"},
+		HandshakeProtocols: []string{DIDExchangeProtocol},
+		Services:           []InvitationService{{DID: did}},
+	}, nil
+}
+
+// URL encodes the invitation in the oob query parameter of base.
+func (inv Invitation) URL(base string) (string, error) {
+	bz, err := json.Marshal(inv)
+	if err != nil {
+		return "", err
+	}
+	u, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+	q := u.Query()
+	q.Set("oob", base64.RawURLEncoding.EncodeToString(bz))
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// ParseInvitationURL decodes an invitation from the oob query parameter of an
+// invitation URL.
+func ParseInvitationURL(raw string) (Invitation, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return Invitation{}, err
+	}
+	encoded := u.Query().Get("oob")
+	if encoded == "" {
+		return Invitation{}, fmt.Errorf("invitation URL has no oob parameter")
+	}
+	bz, err := decodeBase64(encoded)
+	if err != nil {
+		return Invitation{}, fmt.Errorf("invalid invitation encoding: %w", err)
+	}
+	var inv Invitation
+	if err := json.Unmarshal(bz, &inv); err != nil {
+		return Invitation{}, fmt.Errorf("invalid invitation: %w", err)
+	}
+	if inv.Type != InvitationType && inv.Type != "https://didcomm.org/out-of-band/1.0/invitation" {
+		return Invitation{}, fmt.Errorf("unsupported invitation type %s", inv.Type)
+	}
+	if len(inv.Services) == 0 {
+		return Invitation{}, fmt.Errorf("invitation has no services")
+	}
+	return inv, nil
+}
+
+// supportsDIDExchange reports whether the invitation offers DID Exchange 1.x.
+func (inv Invitation) supportsDIDExchange() bool {
+	for _, p := range inv.HandshakeProtocols {
+		if strings.HasPrefix(p, "https://didcomm.org/didexchange/1.") {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeBase64 accepts the padded and unpadded, standard and URL-safe base64
+// variants agents use in invitations and attachments.
+func decodeBase64(s string) ([]byte, error) {
+	s = strings.TrimRight(s, "=")
+	if strings.ContainsAny(s, "+/") {
+		return base64.RawStdEncoding.DecodeString(s)
+	}
+	return base64.RawURLEncoding.DecodeString(s)
+}
+
+func newID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
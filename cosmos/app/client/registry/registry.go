@@ -0,0 +1,114 @@
+// Package registry is a thin client for the DID module's REST routes, used by
+// off-chain services that need to read the on-chain DID and revocation
+// registries.
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	didmodule "cosmos-app/modules/did"
+)
+
+// Client reads DID documents and credential statuses from a node's REST API.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClient creates a registry client for the node REST API at baseURL.
+func NewClient(baseURL string) *Client {
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// ResolveDID fetches the DID document registered under id.
+func (c *Client) ResolveDID(ctx context.Context, id string) (didmodule.DIDDocument, error) {
+	var doc didmodule.DIDDocument
+	if err := c.get(ctx, "/dids/"+url.PathEscape(id), &doc); err != nil {
+		return didmodule.DIDDocument{}, fmt.Errorf("resolve %s: %w", id, err)
+	}
+	return doc, nil
+}
+
+// CredentialStatus fetches the revocation record for a credential.
+func (c *Client) CredentialStatus(ctx context.Context, id string) (didmodule.CredentialStatus, error) {
+	var status didmodule.CredentialStatus
+	if err := c.get(ctx, "/credentials/status/"+id, &status); err != nil {
+		return didmodule.CredentialStatus{}, fmt.Errorf("credential status %s: %w", id, err)
+	}
+	return status, nil
+}
+
+// Resource dereferences a DID-linked resource URL: did/resources/{id}, or an
+// AnonCreds query URL naming the resource by name and type, optionally at a
+// resourceVersionTime.
+func (c *Client) Resource(ctx context.Context, didURL string) (didmodule.Resource, error) {
+	path, query := "", url.Values{}
+	if idx := strings.IndexByte(didURL, '?'); idx > 0 {
+		did, _, _, _, err := didmodule.ParseResourceQuery(didURL)
+		if err != nil {
+			return didmodule.Resource{}, err
+		}
+		path = "/dids/" + url.PathEscape(did) + "/resources"
+		query, _ = url.ParseQuery(didURL[idx+1:])
+	} else {
+		did, id, err := didmodule.ParseResourceURL(didURL)
+		if err != nil {
+			return didmodule.Resource{}, err
+		}
+		path = "/dids/" + url.PathEscape(did) + "/resources/" + url.PathEscape(id)
+	}
+	raw := path
+	if len(query) > 0 {
+		raw += "?" + query.Encode()
+	}
+	query.Set("metadata", "true")
+
+	var res didmodule.Resource
+	if err := c.get(ctx, path+"?"+query.Encode(), &res); err != nil {
+		return didmodule.Resource{}, fmt.Errorf("resource %s: %w", didURL, err)
+	}
+	data, err := c.getRaw(ctx, raw)
+	if err != nil {
+		return didmodule.Resource{}, fmt.Errorf("resource %s: %w", didURL, err)
+	}
+	res.Data = data
+	return res, nil
+}
+
+func (c *Client) get(ctx context.Context, path string, v interface{}) error {
+	body, err := c.getRaw(ctx, path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, v)
+}
+
+func (c *Client) getRaw(ctx context.Context, path string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
@@ -0,0 +1,78 @@
+package resolver
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	didmodule "cosmos-app/modules/did"
+)
+
+// Cache is a Resolver that keeps resolved documents for a fixed TTL. Failed
+// resolutions are not cached.
+type Cache struct {
+	next       Resolver
+	ttl        time.Duration
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	doc     didmodule.DIDDocument
+	expires time.Time
+}
+
+// WithCache caches r's documents for ttl, holding at most maxEntries.
+func WithCache(r Resolver, ttl time.Duration, maxEntries int) *Cache {
+	return &Cache{next: r, ttl: ttl, maxEntries: maxEntries, entries: make(map[string]cacheEntry)}
+}
+
+// ResolveDID returns the cached document for id or resolves and caches it.
+func (c *Cache) ResolveDID(ctx context.Context, id string) (didmodule.DIDDocument, error) {
+	now := time.Now()
+	c.mu.Lock()
+	e, ok := c.entries[id]
+	c.mu.Unlock()
+	if ok && now.Before(e.expires) {
+		return e.doc, nil
+	}
+
+	doc, err := c.next.ResolveDID(ctx, id)
+	if err != nil {
+		return didmodule.DIDDocument{}, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= c.maxEntries {
+		c.evict(now)
+	}
+	c.entries[id] = cacheEntry{doc: doc, expires: now.Add(c.ttl)}
+	return doc, nil
+}
+
+// Invalidate drops id from the cache.
+func (c *Cache) Invalidate(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, id)
+}
+
+// evict drops expired entries, or the entry closest to expiry if none have
+// expired. c.mu must be held.
+func (c *Cache) evict(now time.Time) {
+	oldest := ""
+	for id, e := range c.entries {
+		if !now.Before(e.expires) {
+			delete(c.entries, id)
+			continue
+		}
+		if oldest == "" || e.expires.Before(c.entries[oldest].expires) {
+			oldest = id
+		}
+	}
+	if len(c.entries) >= c.maxEntries && oldest != "" {
+		delete(c.entries, oldest)
+	}
+}
@@ -0,0 +1,62 @@
+package resolver
+
+import (
+	"context"
+	"fmt"
+
+	didmodule "cosmos-app/modules/did"
+
+	grpc1 "github.com/gogo/protobuf/grpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GRPC reads did:aytch documents and credential statuses from a node's gRPC
+// endpoint through the aytch.did.v1.Query service.
+type GRPC struct {
+	client didmodule.QueryClient
+}
+
+// NewGRPC creates a gRPC transport over an existing connection.
+func NewGRPC(cc grpc1.ClientConn) *GRPC {
+	return &GRPC{client: didmodule.NewQueryClient(cc)}
+}
+
+// DialGRPC connects to the node gRPC endpoint at target.
+func DialGRPC(target string, opts ...grpc.DialOption) (*GRPC, *grpc.ClientConn, error) {
+	cc, err := grpc.Dial(target, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return NewGRPC(cc), cc, nil
+}
+
+// ResolveDID fetches the DID document registered under id.
+func (c *GRPC) ResolveDID(ctx context.Context, id string) (didmodule.DIDDocument, error) {
+	res, err := c.client.DID(ctx, &didmodule.QueryDIDRequest{ID: id})
+	if err != nil {
+		return didmodule.DIDDocument{}, fmt.Errorf("resolve %s: %w", id, grpcError(err))
+	}
+	return res.Document, nil
+}
+
+// CredentialStatus fetches the revocation record for a credential.
+func (c *GRPC) CredentialStatus(ctx context.Context, id string) (didmodule.CredentialStatus, error) {
+	res, err := c.client.CredentialStatus(ctx, &didmodule.QueryCredentialStatusRequest{ID: id})
+	if err != nil {
+		return didmodule.CredentialStatus{}, fmt.Errorf("credential status %s: %w", id, grpcError(err))
+	}
+	return res.Status, nil
+}
+
+// grpcError maps gRPC status codes onto ErrNotFound and temporary errors.
+func grpcError(err error) error {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return ErrNotFound
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return Temporary(err)
+	}
+	return err
+}
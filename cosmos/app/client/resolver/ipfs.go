@@ -0,0 +1,88 @@
+package resolver
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	didmodule "cosmos-app/modules/did"
+)
+
+// maxIPFSContentSize bounds the content IPFS reads from a gateway.
+const maxIPFSContentSize = 4 << 20
+
+// IPFS is a Resolver that expands documents anchored in IPFS: when a resolved
+// document carries a ContentCID, the full document is fetched from an IPFS
+// HTTP gateway and verified against the CID before it is merged in.
+type IPFS struct {
+	next       Resolver
+	gateway    string
+	httpClient *http.Client
+}
+
+// WithIPFS expands r's IPFS-anchored documents using the gateway at
+// gatewayURL, e.g. http://127.0.0.1:8080. A nil client uses one with a ten
+// second timeout.
+func WithIPFS(r Resolver, gatewayURL string, client *http.Client) *IPFS {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &IPFS{next: r, gateway: strings.TrimRight(gatewayURL, "/"), httpClient: client}
+}
+
+// ResolveDID resolves id and, if its document lives in IPFS, fetches and
+// verifies the full document.
+func (r *IPFS) ResolveDID(ctx context.Context, id string) (didmodule.DIDDocument, error) {
+	doc, err := r.next.ResolveDID(ctx, id)
+	if err != nil || doc.ContentCID == "" {
+		return doc, err
+	}
+	content, err := r.Fetch(ctx, doc.ContentCID)
+	if err != nil {
+		return didmodule.DIDDocument{}, fmt.Errorf("resolve %s: %w", id, err)
+	}
+	doc, err = doc.WithContent(content)
+	if err != nil {
+		return didmodule.DIDDocument{}, fmt.Errorf("resolve %s: %w", id, err)
+	}
+	return doc, nil
+}
+
+// Fetch returns the content cid commits to. Content that doesn't match the
+// CID is rejected, so an untrusted gateway can be used.
+func (r *IPFS) Fetch(ctx context.Context, cid string) ([]byte, error) {
+	if err := didmodule.ValidateCID(cid); err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.gateway+"/ipfs/"+cid, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.ipld.raw")
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch %s: %w", cid, Temporary(err))
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("fetch %s: %w", cid, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		err := fmt.Errorf("fetch %s: unexpected status %d", cid, resp.StatusCode)
+		if resp.StatusCode >= http.StatusInternalServerError {
+			err = Temporary(err)
+		}
+		return nil, err
+	}
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxIPFSContentSize))
+	if err != nil {
+		return nil, fmt.Errorf("fetch %s: %w", cid, Temporary(err))
+	}
+	if err := didmodule.VerifyCID(cid, content); err != nil {
+		return nil, fmt.Errorf("fetch %s: %w", cid, err)
+	}
+	return content, nil
+}
@@ -0,0 +1,24 @@
+package resolver
+
+import (
+	"context"
+	"strings"
+
+	didmodule "cosmos-app/modules/did"
+)
+
+const keyPrefix = "did:key:"
+
+// KeyResolver resolves did:key identifiers for Ed25519 keys by deriving the
+// document from the key itself.
+type KeyResolver struct{}
+
+// ResolveDID derives the document of a did:key.
+func (KeyResolver) ResolveDID(_ context.Context, id string) (didmodule.DIDDocument, error) {
+	mb := strings.TrimPrefix(strings.SplitN(id, "#", 2)[0], keyPrefix)
+	pub, err := didmodule.DecodeEd25519Multibase(mb)
+	if err != nil {
+		return didmodule.DIDDocument{}, err
+	}
+	return didmodule.DocumentFromKey(keyPrefix+mb, mb, pub), nil
+}
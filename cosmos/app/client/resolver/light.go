@@ -0,0 +1,129 @@
+package resolver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	didmodule "cosmos-app/modules/did"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/tendermint/tendermint/light"
+	lightdb "github.com/tendermint/tendermint/light/store/db"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+	dbm "github.com/tendermint/tm-db"
+)
+
+// Light resolves did:aytch documents trust-minimally: headers are verified by
+// a Tendermint light client from a trusted root, and every document is
+// checked against the app hash of a verified header with the ICS-23 proof the
+// node returns alongside it. Neither the primary nor the witnesses need to be
+// trusted, so any public RPC endpoint can serve a wallet or edge verifier.
+type Light struct {
+	client *light.Client
+	rpc    rpcclient.ABCIClient
+	cdc    *codec.LegacyAmino
+}
+
+// LightConfig configures a Light resolver.
+type LightConfig struct {
+	ChainID string
+	// Trust is the root of trust: a header hash and height obtained out of
+	// band, and the period within which headers are trusted.
+	Trust light.TrustOptions
+	// Primary is the RPC endpoint queried for documents and headers, e.g.
+	// https://rpc.example.com:443. Witnesses cross-check the primary's headers.
+	Primary   string
+	Witnesses []string
+	// DB persists verified headers between runs. Nil keeps them in memory, so
+	// the client starts again from Trust.
+	DB dbm.DB
+}
+
+// NewLight creates a light resolver and verifies the trusted header.
+func NewLight(ctx context.Context, cfg LightConfig, options ...light.Option) (*Light, error) {
+	db := cfg.DB
+	if db == nil {
+		db = dbm.NewMemDB()
+	}
+	lc, err := light.NewHTTPClient(ctx, cfg.ChainID, cfg.Trust, cfg.Primary, cfg.Witnesses, lightdb.New(db, cfg.ChainID), options...)
+	if err != nil {
+		return nil, fmt.Errorf("light client: %w", err)
+	}
+	rpc, err := rpchttp.New(cfg.Primary, "/websocket")
+	if err != nil {
+		return nil, err
+	}
+	return &Light{
+		client: lc,
+		rpc:    rpc,
+		cdc:    codec.NewLegacyAmino(),
+	}, nil
+}
+
+// ResolveDID resolves id at the latest verified state.
+func (l *Light) ResolveDID(ctx context.Context, id string) (didmodule.DIDDocument, error) {
+	p, err := l.ResolveProven(ctx, id)
+	if err != nil {
+		return didmodule.DIDDocument{}, err
+	}
+	if p.Document == nil {
+		return didmodule.DIDDocument{}, fmt.Errorf("resolve %s: %w", id, ErrNotFound)
+	}
+	return *p.Document, nil
+}
+
+// ResolveProven advances the light client to the latest header and resolves
+// id at the state that header commits to, returning the verified proof.
+func (l *Light) ResolveProven(ctx context.Context, id string) (didmodule.ProvenResolution, error) {
+	now := time.Now()
+	header, err := l.client.Update(ctx, now)
+	if err != nil {
+		return didmodule.ProvenResolution{}, fmt.Errorf("resolve %s: update light client: %w", id, Temporary(err))
+	}
+	if header == nil {
+		if header, err = l.client.TrustedLightBlock(0); err != nil {
+			return didmodule.ProvenResolution{}, fmt.Errorf("resolve %s: %w", id, err)
+		}
+	}
+
+	key := didmodule.DIDStoreKey(id)
+	res, err := l.rpc.ABCIQueryWithOptions(ctx, "/"+didmodule.ICQStorePath, key, rpcclient.ABCIQueryOptions{
+		Height: header.Height - 1,
+		Prove:  true,
+	})
+	if err != nil {
+		return didmodule.ProvenResolution{}, fmt.Errorf("resolve %s: %w", id, Temporary(err))
+	}
+	if !res.Response.IsOK() {
+		return didmodule.ProvenResolution{}, fmt.Errorf("resolve %s: %s", id, res.Response.Log)
+	}
+	if res.Response.Height != header.Height-1 {
+		return didmodule.ProvenResolution{}, fmt.Errorf("resolve %s: node answered for height %d, not %d", id, res.Response.Height, header.Height-1)
+	}
+	if res.Response.ProofOps == nil {
+		return didmodule.ProvenResolution{}, fmt.Errorf("resolve %s: node returned no proof", id)
+	}
+
+	p := didmodule.ProvenResolution{
+		ICQResult: didmodule.ICQResult{
+			Key:      key,
+			Value:    res.Response.Value,
+			Height:   res.Response.Height,
+			ProofOps: res.Response.ProofOps,
+		},
+		AppHash: header.AppHash,
+	}
+	if len(p.Value) > 0 {
+		doc, err := didmodule.DecodeICQDocument(l.cdc, p.Value)
+		if err != nil {
+			return didmodule.ProvenResolution{}, fmt.Errorf("resolve %s: %w", id, err)
+		}
+		p.Document = &doc
+	}
+	if err := p.Verify(l.cdc); err != nil {
+		return didmodule.ProvenResolution{}, fmt.Errorf("resolve %s: %w", id, err)
+	}
+	return p, nil
+}
@@ -0,0 +1,133 @@
+package resolver
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	didmodule "cosmos-app/modules/did"
+)
+
+const peer2Prefix = "did:peer:2"
+
+// peerService is the abbreviated service encoding of did:peer:2.
+type peerService struct {
+	Type     string `json:"t"`
+	Endpoint struct {
+		URI         string   `json:"uri"`
+		Accept      []string `json:"a,omitempty"`
+		RoutingKeys []string `json:"r,omitempty"`
+	} `json:"s"`
+}
+
+// NewPeerDID creates a did:peer:2 (numalgo 2) identifier carrying an Ed25519
+// authentication key, an X25519 keyAgreement key and, if endpoint is set, a
+// DIDComm v2 messaging service. Peer DIDs are never registered on chain: the
+// document is derived from the identifier itself.
+func NewPeerDID(auth ed25519.PublicKey, agreement *ecdh.PublicKey, endpoint string, routingKeys []string) (string, error) {
+	var b strings.Builder
+	b.WriteString(peer2Prefix)
+	b.WriteString(".E" + didmodule.EncodeMultibaseKey(didmodule.MulticodecX25519Pub, agreement.Bytes()))
+	b.WriteString(".V" + didmodule.EncodeMultibaseKey(didmodule.MulticodecEd25519Pub, auth))
+	if endpoint != "" {
+		svc := peerService{Type: "dm"}
+		svc.Endpoint.URI = endpoint
+		svc.Endpoint.Accept = []string{didmodule.DIDCommV2Profile}
+		svc.Endpoint.RoutingKeys = routingKeys
+		bz, err := json.Marshal(svc)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(".S" + base64.RawURLEncoding.EncodeToString(bz))
+	}
+	return b.String(), nil
+}
+
+// PeerResolver resolves did:peer:2 identifiers by expanding the keys and
+// services encoded in them.
+type PeerResolver struct{}
+
+// ResolveDID derives the document of a did:peer:2.
+func (PeerResolver) ResolveDID(_ context.Context, id string) (didmodule.DIDDocument, error) {
+	id = strings.SplitN(id, "#", 2)[0]
+	if !strings.HasPrefix(id, peer2Prefix+".") {
+		return didmodule.DIDDocument{}, fmt.Errorf("unsupported did:peer numalgo: %s", id)
+	}
+	doc := didmodule.DIDDocument{ID: id}
+	for i, element := range strings.Split(id[len(peer2Prefix)+1:], ".") {
+		if len(element) < 2 {
+			return didmodule.DIDDocument{}, fmt.Errorf("invalid did:peer element %q", element)
+		}
+		switch purpose, value := element[0], element[1:]; purpose {
+		case 'E', 'V':
+			vm, err := peerVerificationMethod(id, fmt.Sprintf("#key-%d", i+1), value)
+			if err != nil {
+				return didmodule.DIDDocument{}, err
+			}
+			if (purpose == 'E') != (vm.Type == didmodule.X25519KeyAgreementKey2020) {
+				return didmodule.DIDDocument{}, fmt.Errorf("did:peer key %s has the wrong type for its purpose", vm.ID)
+			}
+			doc.VerificationMethods = append(doc.VerificationMethods, vm)
+			if purpose == 'E' {
+				doc.KeyAgreement = append(doc.KeyAgreement, vm.ID)
+			} else if doc.Authentication == "" {
+				pub, _ := vm.PublicKeyBytes()
+				doc.Authentication = vm.ID
+				doc.PublicKey = base64.StdEncoding.EncodeToString(pub)
+			}
+		case 'S':
+			bz, err := base64.RawURLEncoding.DecodeString(value)
+			if err != nil {
+				return didmodule.DIDDocument{}, fmt.Errorf("invalid did:peer service encoding: %w", err)
+			}
+			var svc peerService
+			if err := json.Unmarshal(bz, &svc); err != nil {
+				return didmodule.DIDDocument{}, fmt.Errorf("invalid did:peer service: %w", err)
+			}
+			if svc.Type != "dm" && svc.Type != didmodule.ServiceTypeDIDCommMessaging {
+				continue
+			}
+			serviceID := id + "#service"
+			if n := len(doc.Services); n > 0 {
+				serviceID = fmt.Sprintf("%s#service-%d", id, n)
+			}
+			doc.Services = append(doc.Services, didmodule.Service{
+				ID:              serviceID,
+				Type:            didmodule.ServiceTypeDIDCommMessaging,
+				ServiceEndpoint: svc.Endpoint.URI,
+				RoutingKeys:     svc.Endpoint.RoutingKeys,
+				Accept:          svc.Endpoint.Accept,
+			})
+		default:
+			return didmodule.DIDDocument{}, fmt.Errorf("unsupported did:peer purpose %q", purpose)
+		}
+	}
+	if doc.Authentication == "" {
+		return didmodule.DIDDocument{}, fmt.Errorf("%s has no authentication key", id)
+	}
+	return doc, nil
+}
+
+func peerVerificationMethod(did, fragment, publicKeyMultibase string) (didmodule.VerificationMethod, error) {
+	codec, _, err := didmodule.DecodeMultibaseKey(publicKeyMultibase)
+	if err != nil {
+		return didmodule.VerificationMethod{}, err
+	}
+	vm := didmodule.VerificationMethod{ID: did + fragment, Controller: did, PublicKeyMultibase: publicKeyMultibase}
+	switch codec {
+	case didmodule.MulticodecEd25519Pub:
+		vm.Type = didmodule.Ed25519VerificationKey2020
+	case didmodule.MulticodecX25519Pub:
+		vm.Type = didmodule.X25519KeyAgreementKey2020
+	default:
+		return didmodule.VerificationMethod{}, fmt.Errorf("unsupported did:peer key codec %#x", codec)
+	}
+	if _, err := vm.PublicKeyBytes(); err != nil {
+		return didmodule.VerificationMethod{}, err
+	}
+	return vm, nil
+}
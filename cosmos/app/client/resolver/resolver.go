@@ -0,0 +1,88 @@
+// Package resolver resolves DID documents across DID methods. A
+// MultiResolver dispatches each DID to the resolver registered for its method,
+// so verifiers can accept presentations that reference DIDs from outside this
+// chain as well as did:aytch. did:aytch documents are read from a node over
+// REST or gRPC; either transport can be wrapped with WithRetry and WithCache.
+// Light reads them from an untrusted RPC endpoint, verifying every document
+// against light client verified headers.
+package resolver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	didmodule "cosmos-app/modules/did"
+)
+
+// ErrNotFound is returned, possibly wrapped, when a DID or credential does not
+// exist. It is never retried.
+var ErrNotFound = errors.New("not found")
+
+// Resolver resolves a DID to its document.
+type Resolver interface {
+	ResolveDID(ctx context.Context, id string) (didmodule.DIDDocument, error)
+}
+
+// ResolverFunc adapts a function to the Resolver interface.
+type ResolverFunc func(ctx context.Context, id string) (didmodule.DIDDocument, error)
+
+// ResolveDID calls f(ctx, id).
+func (f ResolverFunc) ResolveDID(ctx context.Context, id string) (didmodule.DIDDocument, error) {
+	return f(ctx, id)
+}
+
+// MultiResolver resolves DIDs using the resolver registered for their method.
+type MultiResolver struct {
+	mu      sync.RWMutex
+	methods map[string]Resolver
+}
+
+// NewMultiResolver creates an empty MultiResolver.
+func NewMultiResolver() *MultiResolver {
+	return &MultiResolver{methods: make(map[string]Resolver)}
+}
+
+// NewDefault creates a MultiResolver for did:aytch, using aytch to read the
+// on-chain registry, did:key, did:peer and did:web.
+func NewDefault(aytch Resolver) *MultiResolver {
+	m := NewMultiResolver()
+	m.Register("aytch", aytch)
+	m.Register("key", KeyResolver{})
+	m.Register("peer", PeerResolver{})
+	m.Register("web", NewWebResolver(nil))
+	return m
+}
+
+// Register sets the resolver for a DID method, replacing any existing one.
+func (m *MultiResolver) Register(method string, r Resolver) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.methods[method] = r
+}
+
+// ResolveDID resolves id with the resolver registered for its method.
+func (m *MultiResolver) ResolveDID(ctx context.Context, id string) (didmodule.DIDDocument, error) {
+	method, err := Method(id)
+	if err != nil {
+		return didmodule.DIDDocument{}, err
+	}
+	m.mu.RLock()
+	r, ok := m.methods[method]
+	m.mu.RUnlock()
+	if !ok {
+		return didmodule.DIDDocument{}, fmt.Errorf("unsupported DID method %q", method)
+	}
+	return r.ResolveDID(ctx, id)
+}
+
+// Method returns the method name of a DID.
+func Method(id string) (string, error) {
+	parts := strings.SplitN(id, ":", 3)
+	if len(parts) != 3 || parts[0] != "did" || parts[1] == "" || parts[2] == "" {
+		return "", fmt.Errorf("invalid DID %q", id)
+	}
+	return parts[1], nil
+}
@@ -0,0 +1,113 @@
+package resolver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	didmodule "cosmos-app/modules/did"
+)
+
+// REST reads did:aytch documents and credential statuses from a node's REST
+// API.
+type REST struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewREST creates a REST transport for the node REST API at baseURL. A nil
+// client uses one with a ten second timeout.
+func NewREST(baseURL string, client *http.Client) *REST {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &REST{baseURL: strings.TrimRight(baseURL, "/"), httpClient: client}
+}
+
+// ResolveDID fetches the DID document registered under id.
+func (c *REST) ResolveDID(ctx context.Context, id string) (didmodule.DIDDocument, error) {
+	var doc didmodule.DIDDocument
+	if err := c.get(ctx, "/dids/"+url.PathEscape(id), &doc); err != nil {
+		return didmodule.DIDDocument{}, fmt.Errorf("resolve %s: %w", id, err)
+	}
+	return doc, nil
+}
+
+// CredentialStatus fetches the revocation record for a credential.
+func (c *REST) CredentialStatus(ctx context.Context, id string) (didmodule.CredentialStatus, error) {
+	var status didmodule.CredentialStatus
+	if err := c.get(ctx, "/credentials/status/"+id, &status); err != nil {
+		return didmodule.CredentialStatus{}, fmt.Errorf("credential status %s: %w", id, err)
+	}
+	return status, nil
+}
+
+// PresentationDefinition fetches a presentation definition a verifier DID
+// published on chain.
+func (c *REST) PresentationDefinition(ctx context.Context, verifier, id string) (didmodule.PresentationDefinitionRecord, error) {
+	var record didmodule.PresentationDefinitionRecord
+	path := "/dids/" + url.PathEscape(verifier) + "/presentation-definitions/" + url.PathEscape(id)
+	if err := c.get(ctx, path, &record); err != nil {
+		return didmodule.PresentationDefinitionRecord{}, fmt.Errorf("presentation definition %s of %s: %w", id, verifier, err)
+	}
+	return record, nil
+}
+
+// Resources fetches the metadata of every resource attached to a DID.
+func (c *REST) Resources(ctx context.Context, did string) ([]didmodule.Resource, error) {
+	var resources []didmodule.Resource
+	if err := c.get(ctx, "/dids/"+url.PathEscape(did)+"/resources", &resources); err != nil {
+		return nil, fmt.Errorf("resources of %s: %w", did, err)
+	}
+	return resources, nil
+}
+
+// ResourceData fetches the raw data of a resource stored on chain.
+func (c *REST) ResourceData(ctx context.Context, did, id string) ([]byte, error) {
+	body, err := c.fetch(ctx, "/dids/"+url.PathEscape(did)+"/resources/"+url.PathEscape(id))
+	if err != nil {
+		return nil, fmt.Errorf("resource %s of %s: %w", id, did, err)
+	}
+	return body, nil
+}
+
+func (c *REST) get(ctx context.Context, path string, v interface{}) error {
+	body, err := c.fetch(ctx, path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, v)
+}
+
+func (c *REST) fetch(ctx context.Context, path string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, Temporary(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, Temporary(err)
+	}
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		return body, nil
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, ErrNotFound
+	}
+	err = fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return nil, Temporary(err)
+	}
+	return nil, err
+}
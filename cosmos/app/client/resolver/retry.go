@@ -0,0 +1,77 @@
+package resolver
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+
+	didmodule "cosmos-app/modules/did"
+)
+
+// temporaryError marks a transport failure that is worth retrying.
+type temporaryError struct{ err error }
+
+func (e temporaryError) Error() string { return e.err.Error() }
+func (e temporaryError) Unwrap() error { return e.err }
+
+// Temporary marks err as a transport failure worth retrying.
+func Temporary(err error) error { return temporaryError{err: err} }
+
+// IsTemporary reports whether err is a transport failure that may succeed if
+// retried, such as a network error or an unavailable node.
+func IsTemporary(err error) bool {
+	var t temporaryError
+	return errors.As(err, &t)
+}
+
+// Backoff configures retries of temporary failures. The delay before retry n
+// is Base*2^n, capped at Max, with up to half of it randomised.
+type Backoff struct {
+	Attempts int
+	Base     time.Duration
+	Max      time.Duration
+}
+
+// DefaultBackoff retries three times, starting at 200ms.
+var DefaultBackoff = Backoff{Attempts: 4, Base: 200 * time.Millisecond, Max: 2 * time.Second}
+
+func (b Backoff) delay(n int) time.Duration {
+	d := b.Base << uint(n)
+	if d <= 0 || d > b.Max {
+		d = b.Max
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// Retry calls f until it succeeds, fails permanently, runs out of attempts or
+// ctx is done. Only errors marked with Temporary are retried.
+func (b Backoff) Retry(ctx context.Context, f func() error) error {
+	var err error
+	for n := 0; n < b.Attempts || n == 0; n++ {
+		if n > 0 {
+			select {
+			case <-ctx.Done():
+				return err
+			case <-time.After(b.delay(n - 1)):
+			}
+		}
+		if err = f(); err == nil || !IsTemporary(err) {
+			return err
+		}
+	}
+	return err
+}
+
+// WithRetry returns a Resolver that retries r's temporary failures.
+func WithRetry(r Resolver, b Backoff) Resolver {
+	return ResolverFunc(func(ctx context.Context, id string) (didmodule.DIDDocument, error) {
+		var doc didmodule.DIDDocument
+		err := b.Retry(ctx, func() error {
+			var err error
+			doc, err = r.ResolveDID(ctx, id)
+			return err
+		})
+		return doc, err
+	})
+}
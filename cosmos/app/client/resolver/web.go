@@ -0,0 +1,189 @@
+package resolver
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	didmodule "cosmos-app/modules/did"
+)
+
+const webPrefix = "did:web:"
+
+// maxWebDocumentSize bounds the did.json documents WebResolver reads.
+const maxWebDocumentSize = 1 << 20
+
+// WebResolver resolves did:web identifiers by fetching their did.json over
+// HTTPS.
+type WebResolver struct {
+	httpClient *http.Client
+}
+
+// NewWebResolver creates a did:web resolver. A nil client uses one with a ten
+// second timeout.
+func NewWebResolver(client *http.Client) *WebResolver {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &WebResolver{httpClient: client}
+}
+
+// webDocument is the subset of a W3C DID document that maps onto the DID
+// module's document.
+type webDocument struct {
+	ID                 string            `json:"id"`
+	Controller         json.RawMessage   `json:"controller,omitempty"`
+	VerificationMethod []webMethod       `json:"verificationMethod"`
+	Authentication     []json.RawMessage `json:"authentication"`
+	KeyAgreement       []json.RawMessage `json:"keyAgreement"`
+	Service            []struct {
+		ID              string          `json:"id"`
+		Type            string          `json:"type"`
+		ServiceEndpoint json.RawMessage `json:"serviceEndpoint"`
+	} `json:"service"`
+}
+
+type webMethod struct {
+	ID                 string `json:"id"`
+	Type               string `json:"type"`
+	Controller         string `json:"controller"`
+	PublicKeyMultibase string `json:"publicKeyMultibase,omitempty"`
+	PublicKeyJwk       *struct {
+		Kty string `json:"kty"`
+		Crv string `json:"crv"`
+		X   string `json:"x"`
+	} `json:"publicKeyJwk,omitempty"`
+}
+
+// WebDocumentURL returns the URL of a did:web's did.json.
+func WebDocumentURL(id string) (string, error) {
+	if !strings.HasPrefix(id, webPrefix) {
+		return "", fmt.Errorf("not a did:web: %s", id)
+	}
+	segments := strings.Split(strings.TrimPrefix(id, webPrefix), ":")
+	for i, s := range segments {
+		unescaped, err := url.PathUnescape(s)
+		if err != nil || unescaped == "" {
+			return "", fmt.Errorf("invalid did:web %s", id)
+		}
+		segments[i] = unescaped
+	}
+	if len(segments) == 1 {
+		return "https://" + segments[0] + "/.well-known/did.json", nil
+	}
+	return "https://" + strings.Join(segments, "/") + "/did.json", nil
+}
+
+// ResolveDID fetches and converts the did.json of a did:web. The document's
+// first Ed25519 authentication key becomes its PublicKey.
+func (r *WebResolver) ResolveDID(ctx context.Context, id string) (didmodule.DIDDocument, error) {
+	docURL, err := WebDocumentURL(id)
+	if err != nil {
+		return didmodule.DIDDocument{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, docURL, nil)
+	if err != nil {
+		return didmodule.DIDDocument{}, err
+	}
+	req.Header.Set("Accept", "application/did+json, application/json")
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return didmodule.DIDDocument{}, fmt.Errorf("resolve %s: %w", id, Temporary(err))
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return didmodule.DIDDocument{}, fmt.Errorf("resolve %s: %w", id, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return didmodule.DIDDocument{}, fmt.Errorf("resolve %s: unexpected status %d", id, resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxWebDocumentSize))
+	if err != nil {
+		return didmodule.DIDDocument{}, fmt.Errorf("resolve %s: %w", id, err)
+	}
+
+	var wd webDocument
+	if err := json.Unmarshal(body, &wd); err != nil {
+		return didmodule.DIDDocument{}, fmt.Errorf("resolve %s: invalid DID document: %w", id, err)
+	}
+	if wd.ID != id {
+		return didmodule.DIDDocument{}, fmt.Errorf("resolve %s: document is for %s", id, wd.ID)
+	}
+	return convertWebDocument(wd)
+}
+
+func convertWebDocument(wd webDocument) (didmodule.DIDDocument, error) {
+	doc := didmodule.DIDDocument{ID: wd.ID}
+	keys := make(map[string]ed25519.PublicKey)
+	for _, m := range wd.VerificationMethod {
+		id := absoluteID(wd.ID, m.ID)
+		vm := didmodule.VerificationMethod{ID: id, Type: m.Type, Controller: m.Controller, PublicKeyMultibase: m.PublicKeyMultibase}
+		if pub := ed25519Key(m); pub != nil {
+			keys[id] = pub
+			vm.Type = didmodule.Ed25519VerificationKey2020
+			vm.PublicKeyMultibase = didmodule.EncodeMultibaseKey(didmodule.MulticodecEd25519Pub, pub)
+		}
+		doc.VerificationMethods = append(doc.VerificationMethods, vm)
+	}
+	for _, raw := range wd.Authentication {
+		var ref string
+		if json.Unmarshal(raw, &ref) != nil {
+			continue
+		}
+		ref = absoluteID(wd.ID, ref)
+		if pub, ok := keys[ref]; ok {
+			doc.Authentication = ref
+			doc.PublicKey = base64.StdEncoding.EncodeToString(pub)
+			break
+		}
+	}
+	for _, raw := range wd.KeyAgreement {
+		var ref string
+		if json.Unmarshal(raw, &ref) == nil {
+			doc.KeyAgreement = append(doc.KeyAgreement, absoluteID(wd.ID, ref))
+		}
+	}
+	for _, s := range wd.Service {
+		var endpoint string
+		if json.Unmarshal(s.ServiceEndpoint, &endpoint) != nil {
+			continue
+		}
+		doc.Services = append(doc.Services, didmodule.Service{ID: absoluteID(wd.ID, s.ID), Type: s.Type, ServiceEndpoint: endpoint})
+	}
+	if doc.PublicKey == "" {
+		return didmodule.DIDDocument{}, fmt.Errorf("resolve %s: no Ed25519 authentication key", wd.ID)
+	}
+	return doc, nil
+}
+
+// ed25519Key extracts an Ed25519 key from a multibase or JWK verification
+// method, or returns nil.
+func ed25519Key(m webMethod) ed25519.PublicKey {
+	if m.PublicKeyMultibase != "" {
+		if pub, err := didmodule.DecodeEd25519Multibase(m.PublicKeyMultibase); err == nil {
+			return pub
+		}
+		return nil
+	}
+	if jwk := m.PublicKeyJwk; jwk != nil && jwk.Kty == "OKP" && jwk.Crv == "Ed25519" {
+		if x, err := base64.RawURLEncoding.DecodeString(jwk.X); err == nil && len(x) == ed25519.PublicKeySize {
+			return ed25519.PublicKey(x)
+		}
+	}
+	return nil
+}
+
+// absoluteID expands a relative DID URL such as "#key-1".
+func absoluteID(did, ref string) string {
+	if strings.HasPrefix(ref, "#") {
+		return did + ref
+	}
+	return ref
+}
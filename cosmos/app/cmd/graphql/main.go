@@ -0,0 +1,31 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+
+	"cosmos-app/gql"
+	didmodule "cosmos-app/modules/did"
+
+	"github.com/gorilla/mux"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func main() {
+	listen := flag.String("listen", ":8092", "address to serve the GraphQL endpoint on")
+	node := flag.String("grpc", "localhost:9090", "gRPC endpoint of an aytch node")
+	flag.Parse()
+
+	cc, err := grpc.Dial(*node, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("dial %s: %v", *node, err)
+	}
+	defer cc.Close()
+
+	r := mux.NewRouter()
+	r.Handle("/graphql", gql.NewHandler(didmodule.NewQueryClient(cc))).Methods("POST")
+	log.Printf("GraphQL endpoint listening on %s/graphql", *listen)
+	log.Fatal(http.ListenAndServe(*listen, r))
+}
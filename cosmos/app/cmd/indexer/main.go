@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"cosmos-app/indexer"
+
+	"github.com/gorilla/mux"
+	_ "github.com/lib/pq"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+)
+
+func main() {
+	node := flag.String("node", "tcp://localhost:26657", "Tendermint RPC endpoint of an aytch node")
+	dsn := flag.String("db", "postgres://localhost/aytch?sslmode=disable", "Postgres connection string")
+	start := flag.Int64("start-height", 1, "height to start from on an empty database")
+	poll := flag.Duration("poll", 2*time.Second, "interval between checks for new blocks")
+	esURL := flag.String("elasticsearch", "", "Elasticsearch URL to feed a full-text search index to, e.g. http://localhost:9200")
+	esIndex := flag.String("elasticsearch-index", "aytch-dids", "Elasticsearch index name")
+	listen := flag.String("listen", ":8094", "address to serve /search on, when --elasticsearch is set")
+	flag.Parse()
+
+	db, err := sql.Open("postgres", *dsn)
+	if err != nil {
+		log.Fatalf("open database: %v", err)
+	}
+	defer db.Close()
+	client, err := rpchttp.New(*node, "/websocket")
+	if err != nil {
+		log.Fatalf("connect %s: %v", *node, err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+	ix := indexer.New(db, client)
+	if *esURL != "" {
+		search := indexer.NewElasticsearch(*esURL, *esIndex, nil)
+		ix = ix.WithSearch(search)
+		r := mux.NewRouter()
+		indexer.RegisterSearchRoutes(r, search)
+		go func() {
+			log.Printf("search endpoint listening on %s/search", *listen)
+			log.Fatal(http.ListenAndServe(*listen, r))
+		}()
+	}
+	if err := ix.Migrate(ctx); err != nil {
+		log.Fatalf("migrate: %v", err)
+	}
+	height, err := ix.Height(ctx)
+	if err != nil {
+		log.Fatalf("read indexed height: %v", err)
+	}
+	log.Printf("indexing %s from height %d", *node, height+1)
+	if err := ix.Run(ctx, *start, *poll); err != nil && err != context.Canceled {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"cosmos-app/client/resolver"
+	"cosmos-app/mediator"
+
+	"github.com/gorilla/mux"
+)
+
+func main() {
+	listen := flag.String("listen", ":8091", "address to serve the mediator on")
+	node := flag.String("node", "http://localhost:1317", "REST endpoint of an aytch node")
+	publicURL := flag.String("public-url", "http://localhost:8091", "externally reachable URL of this mediator")
+	keyFile := flag.String("keys", "mediator-keys.json", "file holding the mediator's DID and keys, created if missing")
+	flag.Parse()
+
+	endpoint := strings.TrimRight(*publicURL, "/")
+	keys, err := mediator.LoadKeys(*keyFile, endpoint)
+	if err != nil {
+		log.Fatalf("load keys: %v", err)
+	}
+	aytch := resolver.WithCache(resolver.WithRetry(resolver.NewREST(*node, nil), resolver.DefaultBackoff), time.Minute, 10000)
+	m := mediator.New(keys, endpoint, resolver.NewDefault(aytch))
+
+	r := mux.NewRouter()
+	m.RegisterRoutes(r)
+	log.Printf("DIDComm mediator %s listening on %s, routing key %s", keys.DID, *listen, keys.AgreementKID)
+	log.Fatal(http.ListenAndServe(*listen, r))
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"cosmos-app/client/resolver"
+	didmodule "cosmos-app/modules/did"
+	"cosmos-app/resolverproxy"
+
+	"github.com/gorilla/mux"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+)
+
+func main() {
+	listen := flag.String("listen", ":8093", "address to serve the Universal Resolver API on")
+	node := flag.String("node", "http://localhost:1317", "REST endpoint of an aytch node")
+	rpc := flag.String("rpc", "tcp://localhost:26657", "Tendermint RPC endpoint of the same node, for DID events")
+	ttl := flag.Duration("ttl", time.Hour, "longest time a document is cached, should an event be missed")
+	maxEntries := flag.Int("max-entries", 100000, "maximum number of cached documents")
+	flag.Parse()
+
+	client, err := rpchttp.New(*rpc, "/websocket")
+	if err != nil {
+		log.Fatalf("connect %s: %v", *rpc, err)
+	}
+	if err := client.Start(); err != nil {
+		log.Fatalf("connect %s: %v", *rpc, err)
+	}
+	defer client.Stop()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+	aytch := resolver.WithRetry(resolver.NewREST(*node, nil), resolver.DefaultBackoff)
+	p := resolverproxy.New(aytch, didmodule.NewWatcher(client), *ttl, *maxEntries)
+	go func() {
+		if err := p.Run(ctx); err != nil && err != context.Canceled {
+			log.Fatal(err)
+		}
+	}()
+
+	r := mux.NewRouter()
+	p.RegisterRoutes(r)
+	srv := &http.Server{Addr: *listen, Handler: r}
+	go func() {
+		<-ctx.Done()
+		srv.Shutdown(context.Background())
+	}()
+	log.Printf("resolver proxy for %s listening on %s", *node, *listen)
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Fatal(err)
+	}
+}
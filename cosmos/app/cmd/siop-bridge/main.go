@@ -0,0 +1,75 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+
+	"cosmos-app/client/registry"
+	"cosmos-app/siop"
+
+	"github.com/gorilla/mux"
+)
+
+func main() {
+	listen := flag.String("listen", ":8091", "address to serve the OIDC bridge on")
+	node := flag.String("node", "http://localhost:1317", "REST endpoint of an aytch node")
+	issuer := flag.String("issuer", "http://localhost:8091", "externally reachable issuer URL")
+	clientsFile := flag.String("clients", "clients.json", "JSON file listing registered relying parties")
+	keyFile := flag.String("key", "", "file holding the base64 Ed25519 seed used to sign id_tokens (ephemeral if empty)")
+	flag.Parse()
+
+	key, err := loadKey(*keyFile)
+	if err != nil {
+		log.Fatalf("load signing key: %v", err)
+	}
+	clients, err := loadClients(*clientsFile)
+	if err != nil {
+		log.Fatalf("load clients: %v", err)
+	}
+
+	p := siop.NewProvider(*issuer, key, registry.NewClient(*node), clients)
+	r := mux.NewRouter()
+	p.RegisterRoutes(r)
+	log.Printf("SIOPv2 bridge listening on %s", *listen)
+	log.Fatal(http.ListenAndServe(*listen, r))
+}
+
+func loadKey(path string) (ed25519.PrivateKey, error) {
+	if path == "" {
+		log.Printf("no signing key configured, generating an ephemeral one")
+		_, priv, err := ed25519.GenerateKey(rand.Reader)
+		return priv, err
+	}
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	seed, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(bz)))
+	if err != nil {
+		return nil, err
+	}
+	if len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("expected a %d byte seed, got %d", ed25519.SeedSize, len(seed))
+	}
+	return ed25519.NewKeyFromSeed(seed), nil
+}
+
+func loadClients(path string) ([]siop.Client, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var clients []siop.Client
+	if err := json.Unmarshal(bz, &clients); err != nil {
+		return nil, err
+	}
+	return clients, nil
+}
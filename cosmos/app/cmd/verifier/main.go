@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"cosmos-app/client/resolver"
+	didmodule "cosmos-app/modules/did"
+	"cosmos-app/verifier"
+
+	"github.com/gorilla/mux"
+)
+
+// multiRegistry reads revocation status from the aytch registry but resolves
+// holder and issuer DIDs of any supported method.
+type multiRegistry struct {
+	*resolver.REST
+	resolver *resolver.MultiResolver
+}
+
+func (r multiRegistry) ResolveDID(ctx context.Context, id string) (didmodule.DIDDocument, error) {
+	return r.resolver.ResolveDID(ctx, id)
+}
+
+func main() {
+	listen := flag.String("listen", ":8090", "address to serve the verifier on")
+	node := flag.String("node", "http://localhost:1317", "REST endpoint of an aytch node")
+	publicURL := flag.String("public-url", "http://localhost:8090", "externally reachable base URL of this verifier")
+	flag.Parse()
+
+	base := strings.TrimRight(*publicURL, "/")
+	client := resolver.NewREST(*node, nil)
+	aytch := resolver.WithCache(resolver.WithRetry(client, resolver.DefaultBackoff), time.Minute, 10000)
+	reg := multiRegistry{REST: client, resolver: resolver.NewDefault(aytch)}
+	v := verifier.NewVerifier(base, base+"/response", reg)
+
+	r := mux.NewRouter()
+	v.RegisterRoutes(r)
+	log.Printf("OpenID4VP verifier listening on %s", *listen)
+	log.Fatal(http.ListenAndServe(*listen, r))
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+
+	didmodule "cosmos-app/modules/did"
+	"cosmos-app/webhook"
+
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+)
+
+func main() {
+	node := flag.String("node", "tcp://localhost:26657", "Tendermint RPC endpoint of an aytch node")
+	config := flag.String("config", "webhooks.json", "JSON file listing webhook subscriptions")
+	flag.Parse()
+
+	subs, err := loadSubscriptions(*config)
+	if err != nil {
+		log.Fatalf("load subscriptions: %v", err)
+	}
+	client, err := rpchttp.New(*node, "/websocket")
+	if err != nil {
+		log.Fatalf("connect %s: %v", *node, err)
+	}
+	if err := client.Start(); err != nil {
+		log.Fatalf("connect %s: %v", *node, err)
+	}
+	defer client.Stop()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+	n := webhook.NewNotifier(didmodule.NewWatcher(client), nil)
+	log.Printf("delivering DID events from %s to %d webhooks", *node, len(subs))
+	if err := n.Run(ctx, subs); err != nil && err != context.Canceled {
+		log.Fatal(err)
+	}
+}
+
+func loadSubscriptions(path string) ([]webhook.Subscription, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var subs []webhook.Subscription
+	if err := json.Unmarshal(bz, &subs); err != nil {
+		return nil, err
+	}
+	return subs, nil
+}
@@ -0,0 +1,311 @@
+package didcomm
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	didmodule "cosmos-app/modules/did"
+)
+
+const (
+	algECDHESA256KW = "ECDH-ES+A256KW"
+	encA256GCM      = "A256GCM"
+)
+
+var b64 = base64.RawURLEncoding
+
+type jweHeader struct {
+	Typ string `json:"typ"`
+	Alg string `json:"alg"`
+	Enc string `json:"enc"`
+	Apv string `json:"apv"`
+	Epk jwk    `json:"epk"`
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+}
+
+type jweRecipient struct {
+	Header       map[string]string `json:"header"`
+	EncryptedKey string            `json:"encrypted_key"`
+}
+
+type jwe struct {
+	Protected  string         `json:"protected"`
+	Recipients []jweRecipient `json:"recipients"`
+	IV         string         `json:"iv"`
+	Ciphertext string         `json:"ciphertext"`
+	Tag        string         `json:"tag"`
+}
+
+// recipientKey is an X25519 public key identified by its DID URL.
+type recipientKey struct {
+	kid string
+	key *ecdh.PublicKey
+}
+
+// Encrypt encrypts plaintext to every keyAgreement key of doc as an
+// anoncrypt JWE of media type typ, in general JSON serialization.
+func Encrypt(typ string, plaintext []byte, doc didmodule.DIDDocument) ([]byte, error) {
+	recipients, err := documentKeyAgreementKeys(doc)
+	if err != nil {
+		return nil, err
+	}
+	return encrypt(typ, plaintext, recipients)
+}
+
+// Decrypt opens an anoncrypt JWE with the first recipient key available in
+// keys.
+func Decrypt(envelope []byte, keys KeyStore) ([]byte, error) {
+	return decrypt(envelope, keys)
+}
+
+// encrypt produces an anoncrypt JWE in general JSON serialization.
+func encrypt(typ string, plaintext []byte, recipients []recipientKey) ([]byte, error) {
+	if len(recipients) == 0 {
+		return nil, fmt.Errorf("didcomm: no recipient keys")
+	}
+	kids := make([]string, len(recipients))
+	for i, r := range recipients {
+		kids[i] = r.kid
+	}
+	sort.Strings(kids)
+	apv := sha256.Sum256([]byte(strings.Join(kids, ".")))
+
+	epk, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	header := jweHeader{
+		Typ: typ,
+		Alg: algECDHESA256KW,
+		Enc: encA256GCM,
+		Apv: b64.EncodeToString(apv[:]),
+		Epk: jwk{Kty: "OKP", Crv: "X25519", X: b64.EncodeToString(epk.PublicKey().Bytes())},
+	}
+	headerBz, err := json.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+	protected := b64.EncodeToString(headerBz)
+
+	cek := make([]byte, 32)
+	if _, err := rand.Read(cek); err != nil {
+		return nil, err
+	}
+	out := jwe{Protected: protected}
+	for _, r := range recipients {
+		z, err := epk.ECDH(r.key)
+		if err != nil {
+			return nil, err
+		}
+		wrapped, err := keyWrap(concatKDF(z, algECDHESA256KW, nil, apv[:]), cek)
+		if err != nil {
+			return nil, err
+		}
+		out.Recipients = append(out.Recipients, jweRecipient{
+			Header:       map[string]string{"kid": r.kid},
+			EncryptedKey: b64.EncodeToString(wrapped),
+		})
+	}
+
+	gcm, err := newGCM(cek)
+	if err != nil {
+		return nil, err
+	}
+	iv := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(iv); err != nil {
+		return nil, err
+	}
+	sealed := gcm.Seal(nil, iv, plaintext, []byte(protected))
+	tagStart := len(sealed) - gcm.Overhead()
+	out.IV = b64.EncodeToString(iv)
+	out.Ciphertext = b64.EncodeToString(sealed[:tagStart])
+	out.Tag = b64.EncodeToString(sealed[tagStart:])
+	return json.Marshal(out)
+}
+
+// decrypt opens a JWE with the first recipient key available in keys.
+func decrypt(envelope []byte, keys KeyStore) ([]byte, error) {
+	var msg jwe
+	if err := json.Unmarshal(envelope, &msg); err != nil {
+		return nil, fmt.Errorf("didcomm: invalid JWE: %w", err)
+	}
+	headerBz, err := b64.DecodeString(msg.Protected)
+	if err != nil {
+		return nil, fmt.Errorf("didcomm: invalid protected header: %w", err)
+	}
+	var header jweHeader
+	if err := json.Unmarshal(headerBz, &header); err != nil {
+		return nil, fmt.Errorf("didcomm: invalid protected header: %w", err)
+	}
+	if header.Alg != algECDHESA256KW || header.Enc != encA256GCM {
+		return nil, fmt.Errorf("didcomm: unsupported JWE algorithms %s/%s", header.Alg, header.Enc)
+	}
+	if header.Epk.Kty != "OKP" || header.Epk.Crv != "X25519" {
+		return nil, fmt.Errorf("didcomm: unsupported ephemeral key")
+	}
+	epkBz, err := b64.DecodeString(header.Epk.X)
+	if err != nil {
+		return nil, err
+	}
+	epk, err := ecdh.X25519().NewPublicKey(epkBz)
+	if err != nil {
+		return nil, err
+	}
+	apv, err := b64.DecodeString(header.Apv)
+	if err != nil {
+		return nil, err
+	}
+
+	var cek []byte
+	for _, r := range msg.Recipients {
+		priv, ok := keys.KeyAgreementKey(r.Header["kid"])
+		if !ok {
+			continue
+		}
+		wrapped, err := b64.DecodeString(r.EncryptedKey)
+		if err != nil {
+			return nil, err
+		}
+		z, err := priv.ECDH(epk)
+		if err != nil {
+			return nil, err
+		}
+		if cek, err = keyUnwrap(concatKDF(z, algECDHESA256KW, nil, apv), wrapped); err != nil {
+			return nil, err
+		}
+		break
+	}
+	if cek == nil {
+		return nil, fmt.Errorf("didcomm: no matching recipient key")
+	}
+
+	iv, err := b64.DecodeString(msg.IV)
+	if err != nil {
+		return nil, err
+	}
+	ciphertext, err := b64.DecodeString(msg.Ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	tag, err := b64.DecodeString(msg.Tag)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(cek)
+	if err != nil {
+		return nil, err
+	}
+	if len(iv) != gcm.NonceSize() {
+		return nil, fmt.Errorf("didcomm: invalid IV length")
+	}
+	plaintext, err := gcm.Open(nil, iv, append(ciphertext, tag...), []byte(msg.Protected))
+	if err != nil {
+		return nil, fmt.Errorf("didcomm: decryption failed: %w", err)
+	}
+	return plaintext, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// concatKDF derives a 256-bit key encryption key (RFC 7518 section 4.6.2).
+func concatKDF(z []byte, alg string, apu, apv []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{0, 0, 0, 1})
+	h.Write(z)
+	writeLengthPrefixed(h, []byte(alg))
+	writeLengthPrefixed(h, apu)
+	writeLengthPrefixed(h, apv)
+	var keyLen [4]byte
+	binary.BigEndian.PutUint32(keyLen[:], 256)
+	h.Write(keyLen[:])
+	return h.Sum(nil)
+}
+
+func writeLengthPrefixed(h interface{ Write([]byte) (int, error) }, bz []byte) {
+	var l [4]byte
+	binary.BigEndian.PutUint32(l[:], uint32(len(bz)))
+	h.Write(l[:])
+	h.Write(bz)
+}
+
+var keyWrapIV = []byte{0xa6, 0xa6, 0xa6, 0xa6, 0xa6, 0xa6, 0xa6, 0xa6}
+
+// keyWrap implements AES Key Wrap (RFC 3394).
+func keyWrap(kek, key []byte) ([]byte, error) {
+	if len(key)%8 != 0 || len(key) < 16 {
+		return nil, fmt.Errorf("didcomm: invalid key length for wrapping")
+	}
+	block, err := aes.NewCipher(kek)
+	if err != nil {
+		return nil, err
+	}
+	n := len(key) / 8
+	r := make([]byte, len(key))
+	copy(r, key)
+	a := make([]byte, 8)
+	copy(a, keyWrapIV)
+	buf := make([]byte, 16)
+	for j := 0; j < 6; j++ {
+		for i := 0; i < n; i++ {
+			copy(buf[:8], a)
+			copy(buf[8:], r[i*8:i*8+8])
+			block.Encrypt(buf, buf)
+			t := uint64(n*j + i + 1)
+			binary.BigEndian.PutUint64(a, binary.BigEndian.Uint64(buf[:8])^t)
+			copy(r[i*8:i*8+8], buf[8:])
+		}
+	}
+	return append(a, r...), nil
+}
+
+// keyUnwrap reverses keyWrap and checks the integrity value.
+func keyUnwrap(kek, wrapped []byte) ([]byte, error) {
+	if len(wrapped)%8 != 0 || len(wrapped) < 24 {
+		return nil, fmt.Errorf("didcomm: invalid wrapped key length")
+	}
+	block, err := aes.NewCipher(kek)
+	if err != nil {
+		return nil, err
+	}
+	n := len(wrapped)/8 - 1
+	a := make([]byte, 8)
+	copy(a, wrapped[:8])
+	r := make([]byte, n*8)
+	copy(r, wrapped[8:])
+	buf := make([]byte, 16)
+	for j := 5; j >= 0; j-- {
+		for i := n - 1; i >= 0; i-- {
+			t := uint64(n*j + i + 1)
+			binary.BigEndian.PutUint64(buf[:8], binary.BigEndian.Uint64(a)^t)
+			copy(buf[8:], r[i*8:i*8+8])
+			block.Decrypt(buf, buf)
+			copy(a, buf[:8])
+			copy(r[i*8:i*8+8], buf[8:])
+		}
+	}
+	if subtle.ConstantTimeCompare(a, keyWrapIV) != 1 {
+		return nil, fmt.Errorf("didcomm: key unwrap integrity check failed")
+	}
+	return r, nil
+}
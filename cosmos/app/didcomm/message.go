@@ -0,0 +1,61 @@
+// Package didcomm implements DIDComm Messaging v2 envelopes for did:aytch
+// identities. Messages are optionally signed with an Ed25519 authentication
+// key and anonymously encrypted (ECDH-ES+A256KW, A256GCM) to every X25519
+// keyAgreement key of the recipients, which are looked up through a resolver.
+package didcomm
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+)
+
+// Media types of the three DIDComm message formats.
+const (
+	MediaTypePlain     = "application/didcomm-plain+json"
+	MediaTypeSigned    = "application/didcomm-signed+json"
+	MediaTypeEncrypted = "application/didcomm-encrypted+json"
+)
+
+// ForwardType is the message type used by mediators to relay envelopes.
+const ForwardType = "https://didcomm.org/routing/2.0/forward"
+
+// Message is a plaintext DIDComm message.
+type Message struct {
+	ID          string                 `json:"id"`
+	Typ         string                 `json:"typ,omitempty"`
+	Type        string                 `json:"type"`
+	From        string                 `json:"from,omitempty"`
+	To          []string               `json:"to,omitempty"`
+	ThreadID    string                 `json:"thid,omitempty"`
+	CreatedTime int64                  `json:"created_time,omitempty"`
+	ExpiresTime int64                  `json:"expires_time,omitempty"`
+	Body        map[string]interface{} `json:"body"`
+	Attachments []Attachment           `json:"attachments,omitempty"`
+}
+
+// Attachment carries embedded data, such as a forwarded envelope.
+type Attachment struct {
+	ID   string         `json:"id,omitempty"`
+	Data AttachmentData `json:"data"`
+}
+
+// AttachmentData holds the content of an attachment.
+type AttachmentData struct {
+	JSON interface{} `json:"json,omitempty"`
+}
+
+// NewMessage creates a message with a random ID and the current timestamp.
+func NewMessage(msgType, from string, to []string, body map[string]interface{}) Message {
+	id := make([]byte, 16)
+	rand.Read(id)
+	return Message{
+		ID:          hex.EncodeToString(id),
+		Typ:         MediaTypePlain,
+		Type:        msgType,
+		From:        from,
+		To:          to,
+		CreatedTime: time.Now().Unix(),
+		Body:        body,
+	}
+}
@@ -0,0 +1,288 @@
+package didcomm
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/ed25519"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	didmodule "cosmos-app/modules/did"
+)
+
+// Resolver resolves DID documents from the on-chain registry.
+type Resolver interface {
+	ResolveDID(ctx context.Context, id string) (didmodule.DIDDocument, error)
+}
+
+// KeyStore provides the private X25519 keyAgreement keys of the local agent.
+type KeyStore interface {
+	KeyAgreementKey(kid string) (*ecdh.PrivateKey, bool)
+}
+
+// Signer signs messages with an Ed25519 verification method of the sender.
+type Signer struct {
+	KID string
+	Key ed25519.PrivateKey
+}
+
+// Metadata describes how an unpacked message was protected.
+type Metadata struct {
+	Encrypted bool
+	SignedBy  string
+}
+
+// Packer packs and unpacks DIDComm v2 messages.
+type Packer struct {
+	resolver Resolver
+}
+
+// NewPacker creates a packer that looks up keys through resolver.
+func NewPacker(resolver Resolver) *Packer {
+	return &Packer{resolver: resolver}
+}
+
+// Pack signs msg with signer (if non-nil) and encrypts it to every
+// keyAgreement key of the DIDs in msg.To.
+func (p *Packer) Pack(ctx context.Context, msg Message, signer *Signer) ([]byte, error) {
+	plaintext, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	if signer != nil {
+		if didOf(signer.KID) != msg.From {
+			return nil, fmt.Errorf("didcomm: signer %s does not belong to sender %s", signer.KID, msg.From)
+		}
+		if plaintext, err = sign(plaintext, signer); err != nil {
+			return nil, err
+		}
+	}
+	var recipients []recipientKey
+	for _, to := range msg.To {
+		keys, err := p.keyAgreementKeys(ctx, to)
+		if err != nil {
+			return nil, err
+		}
+		recipients = append(recipients, keys...)
+	}
+	return encrypt(MediaTypeEncrypted, plaintext, recipients)
+}
+
+// Unpack decrypts and verifies an envelope addressed to this agent.
+func (p *Packer) Unpack(ctx context.Context, envelope []byte, keys KeyStore) (Message, Metadata, error) {
+	var meta Metadata
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(envelope, &probe); err != nil {
+		return Message{}, meta, fmt.Errorf("didcomm: invalid envelope: %w", err)
+	}
+	if _, ok := probe["ciphertext"]; ok {
+		plaintext, err := decrypt(envelope, keys)
+		if err != nil {
+			return Message{}, meta, err
+		}
+		meta.Encrypted = true
+		envelope = plaintext
+		probe = nil
+		if err := json.Unmarshal(envelope, &probe); err != nil {
+			return Message{}, meta, fmt.Errorf("didcomm: invalid decrypted payload: %w", err)
+		}
+	}
+	if _, ok := probe["signatures"]; ok {
+		payload, kid, err := p.verify(ctx, envelope)
+		if err != nil {
+			return Message{}, meta, err
+		}
+		meta.SignedBy = kid
+		envelope = payload
+	}
+	var msg Message
+	if err := json.Unmarshal(envelope, &msg); err != nil {
+		return Message{}, meta, fmt.Errorf("didcomm: invalid message: %w", err)
+	}
+	if meta.SignedBy != "" && didOf(meta.SignedBy) != msg.From {
+		return Message{}, meta, fmt.Errorf("didcomm: message signed by %s claims sender %s", meta.SignedBy, msg.From)
+	}
+	return msg, meta, nil
+}
+
+// Route prepares an already packed envelope for delivery to recipient. It
+// picks the recipient's first DIDCommMessaging service and, when the service
+// lists routing keys, wraps the envelope in forward messages for each hop.
+// It returns the endpoint to deliver to and the outermost envelope.
+func (p *Packer) Route(ctx context.Context, recipient string, envelope []byte) (string, []byte, error) {
+	doc, err := p.resolver.ResolveDID(ctx, recipient)
+	if err != nil {
+		return "", nil, err
+	}
+	services := doc.DIDCommServices()
+	if len(services) == 0 {
+		return "", nil, fmt.Errorf("didcomm: %s has no DIDCommMessaging service", recipient)
+	}
+	svc := services[0]
+	if didmodule.IsEncryptedEndpoint(svc.ServiceEndpoint) {
+		return "", nil, fmt.Errorf("didcomm: the DIDCommMessaging endpoint of %s is encrypted", recipient)
+	}
+
+	next := recipient
+	for i := len(svc.RoutingKeys) - 1; i >= 0; i-- {
+		routingKey := svc.RoutingKeys[i]
+		key, err := p.resolveKeyAgreementKey(ctx, routingKey)
+		if err != nil {
+			return "", nil, err
+		}
+		var attached interface{}
+		if err := json.Unmarshal(envelope, &attached); err != nil {
+			return "", nil, err
+		}
+		fwd := NewMessage(ForwardType, "", []string{didOf(routingKey)}, map[string]interface{}{"next": next})
+		fwd.Attachments = []Attachment{{Data: AttachmentData{JSON: attached}}}
+		plaintext, err := json.Marshal(fwd)
+		if err != nil {
+			return "", nil, err
+		}
+		if envelope, err = encrypt(MediaTypeEncrypted, plaintext, []recipientKey{key}); err != nil {
+			return "", nil, err
+		}
+		next = routingKey
+	}
+	return svc.ServiceEndpoint, envelope, nil
+}
+
+func (p *Packer) keyAgreementKeys(ctx context.Context, did string) ([]recipientKey, error) {
+	doc, err := p.resolver.ResolveDID(ctx, did)
+	if err != nil {
+		return nil, err
+	}
+	return documentKeyAgreementKeys(doc)
+}
+
+func documentKeyAgreementKeys(doc didmodule.DIDDocument) ([]recipientKey, error) {
+	var keys []recipientKey
+	for _, vm := range doc.KeyAgreementMethods() {
+		key, err := x25519Key(vm)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, recipientKey{kid: vm.ID, key: key})
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("didcomm: %s has no keyAgreement keys", doc.ID)
+	}
+	return keys, nil
+}
+
+func (p *Packer) resolveKeyAgreementKey(ctx context.Context, kid string) (recipientKey, error) {
+	doc, err := p.resolver.ResolveDID(ctx, didOf(kid))
+	if err != nil {
+		return recipientKey{}, err
+	}
+	vm, ok := doc.VerificationMethod(kid)
+	if !ok {
+		return recipientKey{}, fmt.Errorf("didcomm: key %s not found", kid)
+	}
+	key, err := x25519Key(vm)
+	if err != nil {
+		return recipientKey{}, err
+	}
+	return recipientKey{kid: kid, key: key}, nil
+}
+
+func x25519Key(vm didmodule.VerificationMethod) (*ecdh.PublicKey, error) {
+	if vm.Type != didmodule.X25519KeyAgreementKey2020 {
+		return nil, fmt.Errorf("didcomm: %s is not an X25519 key agreement key", vm.ID)
+	}
+	bz, err := vm.PublicKeyBytes()
+	if err != nil {
+		return nil, err
+	}
+	return ecdh.X25519().NewPublicKey(bz)
+}
+
+type jwsSignature struct {
+	Protected string            `json:"protected"`
+	Header    map[string]string `json:"header"`
+	Signature string            `json:"signature"`
+}
+
+type jwsJSON struct {
+	Payload    string         `json:"payload"`
+	Signatures []jwsSignature `json:"signatures"`
+}
+
+type jwsHeader struct {
+	Typ string `json:"typ"`
+	Alg string `json:"alg"`
+}
+
+func sign(payload []byte, signer *Signer) ([]byte, error) {
+	headerBz, err := json.Marshal(jwsHeader{Typ: MediaTypeSigned, Alg: "EdDSA"})
+	if err != nil {
+		return nil, err
+	}
+	protected := b64.EncodeToString(headerBz)
+	encoded := b64.EncodeToString(payload)
+	sig := ed25519.Sign(signer.Key, []byte(protected+"."+encoded))
+	return json.Marshal(jwsJSON{
+		Payload: encoded,
+		Signatures: []jwsSignature{{
+			Protected: protected,
+			Header:    map[string]string{"kid": signer.KID},
+			Signature: b64.EncodeToString(sig),
+		}},
+	})
+}
+
+// verify checks the first signature of a signed message against the signer's
+// on-chain verification method and returns the payload and signer kid.
+func (p *Packer) verify(ctx context.Context, envelope []byte) ([]byte, string, error) {
+	var msg jwsJSON
+	if err := json.Unmarshal(envelope, &msg); err != nil {
+		return nil, "", fmt.Errorf("didcomm: invalid JWS: %w", err)
+	}
+	if len(msg.Signatures) == 0 {
+		return nil, "", fmt.Errorf("didcomm: JWS has no signatures")
+	}
+	s := msg.Signatures[0]
+	headerBz, err := b64.DecodeString(s.Protected)
+	if err != nil {
+		return nil, "", err
+	}
+	var header jwsHeader
+	if err := json.Unmarshal(headerBz, &header); err != nil {
+		return nil, "", err
+	}
+	if header.Alg != "EdDSA" {
+		return nil, "", fmt.Errorf("didcomm: unsupported signature algorithm %s", header.Alg)
+	}
+	kid := s.Header["kid"]
+	doc, err := p.resolver.ResolveDID(ctx, didOf(kid))
+	if err != nil {
+		return nil, "", err
+	}
+	vm, ok := doc.VerificationMethod(kid)
+	if !ok || vm.Type != didmodule.Ed25519VerificationKey2020 {
+		return nil, "", fmt.Errorf("didcomm: signing key %s not found", kid)
+	}
+	pub, err := vm.PublicKeyBytes()
+	if err != nil {
+		return nil, "", err
+	}
+	sig, err := b64.DecodeString(s.Signature)
+	if err != nil {
+		return nil, "", err
+	}
+	if !ed25519.Verify(ed25519.PublicKey(pub), []byte(s.Protected+"."+msg.Payload), sig) {
+		return nil, "", fmt.Errorf("didcomm: invalid signature")
+	}
+	payload, err := b64.DecodeString(msg.Payload)
+	if err != nil {
+		return nil, "", err
+	}
+	return payload, kid, nil
+}
+
+// didOf strips the fragment from a DID URL.
+func didOf(didURL string) string {
+	return strings.SplitN(didURL, "#", 2)[0]
+}
@@ -0,0 +1,43 @@
+// Package docs embeds the OpenAPI document for the app's API server and
+// serves it, together with a Swagger UI page, under /swagger/.
+package docs
+
+import (
+	_ "embed"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// OpenAPI is the OpenAPI 2.0 document describing the DID and credential
+// endpoints.
+//
+//go:embed openapi.yml
+var OpenAPI []byte
+
+const swaggerUI = `<!DOCTYPE html>
+<html>
+<head>
+  <title>Aytch DID API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@4/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@4/swagger-ui-bundle.js"></script>
+  <script>SwaggerUIBundle({url: "/swagger/openapi.yml", dom_id: "#swagger-ui"});</script>
+</body>
+</html>
+`
+
+// RegisterSwaggerAPI serves the Swagger UI at /swagger/ and the raw document
+// at /swagger/openapi.yml.
+func RegisterSwaggerAPI(rtr *mux.Router) {
+	rtr.HandleFunc("/swagger/openapi.yml", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/yaml")
+		w.Write(OpenAPI)
+	}).Methods("GET")
+	rtr.HandleFunc("/swagger/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(swaggerUI))
+	}).Methods("GET")
+}
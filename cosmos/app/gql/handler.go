@@ -0,0 +1,37 @@
+package gql
+
+import (
+	"encoding/json"
+	"net/http"
+
+	didmodule "cosmos-app/modules/did"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+// Handler serves GraphQL queries over HTTP POST.
+type Handler struct {
+	schema *graphql.Schema
+}
+
+// NewHandler parses the schema against a resolver backed by client.
+func NewHandler(client didmodule.QueryClient) *Handler {
+	return &Handler{schema: graphql.MustParseSchema(Schema, NewResolver(client))}
+}
+
+// ServeHTTP executes the query in the request body. Each request gets its
+// own document cache.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Query         string                 `json:"query"`
+		OperationName string                 `json:"operationName"`
+		Variables     map[string]interface{} `json:"variables"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	res := h.schema.Exec(withLoader(r.Context()), params.Query, params.OperationName, params.Variables)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
@@ -0,0 +1,582 @@
+package gql
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"sync"
+
+	didmodule "cosmos-app/modules/did"
+
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/graph-gophers/graphql-go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Int64 is the schema's Int64 scalar, encoded as a decimal string.
+type Int64 int64
+
+// ImplementsGraphQLType maps Int64 to the Int64 scalar.
+func (Int64) ImplementsGraphQLType(name string) bool { return name == "Int64" }
+
+// UnmarshalGraphQL accepts an Int64 given as a string or a number.
+func (i *Int64) UnmarshalGraphQL(input interface{}) error {
+	switch v := input.(type) {
+	case string:
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return err
+		}
+		*i = Int64(n)
+	case int32:
+		*i = Int64(v)
+	case float64:
+		*i = Int64(v)
+	default:
+		return fmt.Errorf("invalid Int64 %v", input)
+	}
+	return nil
+}
+
+// MarshalJSON encodes the value as a string.
+func (i Int64) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(strconv.FormatInt(int64(i), 10))), nil
+}
+
+// Resolver is the root resolver. It reads everything through the
+// aytch.did.v1.Query service.
+type Resolver struct {
+	client didmodule.QueryClient
+}
+
+// NewResolver creates a root resolver backed by client.
+func NewResolver(client didmodule.QueryClient) *Resolver {
+	return &Resolver{client: client}
+}
+
+// loader caches the documents resolved while executing one request, so a DID
+// referenced from several places in the graph is only fetched once.
+type loader struct {
+	mu   sync.Mutex
+	docs map[string]*didmodule.DIDDocument
+}
+
+type loaderKey struct{}
+
+func withLoader(ctx context.Context) context.Context {
+	return context.WithValue(ctx, loaderKey{}, &loader{docs: map[string]*didmodule.DIDDocument{}})
+}
+
+// resolveDID returns the document registered under id, or nil if there is
+// none.
+func (r *Resolver) resolveDID(ctx context.Context, id string) (*didmodule.DIDDocument, error) {
+	l, _ := ctx.Value(loaderKey{}).(*loader)
+	if l != nil {
+		l.mu.Lock()
+		doc, ok := l.docs[id]
+		l.mu.Unlock()
+		if ok {
+			return doc, nil
+		}
+	}
+	var doc *didmodule.DIDDocument
+	res, err := r.client.DID(ctx, &didmodule.QueryDIDRequest{ID: id})
+	switch {
+	case status.Code(err) == codes.NotFound:
+	case err != nil:
+		return nil, err
+	default:
+		doc = &res.Document
+	}
+	if l != nil {
+		l.mu.Lock()
+		l.docs[id] = doc
+		l.mu.Unlock()
+	}
+	return doc, nil
+}
+
+// didRef resolves a reference to another DID. References to DIDs the registry
+// doesn't hold, such as did:key controllers, resolve to a bare node carrying
+// only the ID.
+func (r *Resolver) didRef(ctx context.Context, id string) (*didResolver, error) {
+	doc, err := r.resolveDID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		doc = &didmodule.DIDDocument{ID: id}
+	}
+	return &didResolver{root: r, doc: *doc}, nil
+}
+
+func (r *Resolver) didRefs(ctx context.Context, ids []string) ([]*didResolver, error) {
+	out := make([]*didResolver, 0, len(ids))
+	for _, id := range ids {
+		d, err := r.didRef(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, d)
+	}
+	return out, nil
+}
+
+// pageRequest converts a first/after pair into a page request.
+func pageRequest(first int32, after *string) (*query.PageRequest, error) {
+	if first <= 0 || first > 100 {
+		return nil, fmt.Errorf("first must be between 1 and 100")
+	}
+	req := &query.PageRequest{Limit: uint64(first)}
+	if after != nil && *after != "" {
+		key, err := base64.RawURLEncoding.DecodeString(*after)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cursor")
+		}
+		req.Key = key
+	}
+	return req, nil
+}
+
+// cursor encodes the key of the next page, or nil on the last page.
+func cursor(res *query.PageResponse) *string {
+	if res == nil || len(res.NextKey) == 0 {
+		return nil
+	}
+	c := base64.RawURLEncoding.EncodeToString(res.NextKey)
+	return &c
+}
+
+// DID resolves Query.did.
+func (r *Resolver) DID(ctx context.Context, args struct{ ID graphql.ID }) (*didResolver, error) {
+	doc, err := r.resolveDID(ctx, string(args.ID))
+	if err != nil || doc == nil {
+		return nil, err
+	}
+	return &didResolver{root: r, doc: *doc}, nil
+}
+
+// DIDs resolves Query.dids. Each round trip asks for exactly the number of
+// documents still missing, so the cursor of the last page read never skips a
+// matching document.
+func (r *Resolver) DIDs(ctx context.Context, args struct {
+	First       int32
+	After       *string
+	Controller  *graphql.ID
+	Deactivated *bool
+}) (*didConnection, error) {
+	page, err := pageRequest(args.First, args.After)
+	if err != nil {
+		return nil, err
+	}
+	want := int(page.Limit)
+	conn := &didConnection{}
+	for {
+		page.Limit = uint64(want - len(conn.nodes))
+		res, err := r.client.DIDs(ctx, &didmodule.QueryDIDsRequest{Pagination: page})
+		if err != nil {
+			return nil, err
+		}
+		for _, doc := range res.DIDs {
+			if args.Deactivated != nil && doc.Deactivated != *args.Deactivated {
+				continue
+			}
+			if args.Controller != nil && !contains(doc.Controller, string(*args.Controller)) {
+				continue
+			}
+			conn.nodes = append(conn.nodes, &didResolver{root: r, doc: doc})
+		}
+		conn.next = cursor(res.Pagination)
+		if conn.next == nil || len(conn.nodes) >= want {
+			return conn, nil
+		}
+		page = &query.PageRequest{Key: res.Pagination.NextKey}
+	}
+}
+
+// CredentialStatus resolves Query.credentialStatus.
+func (r *Resolver) CredentialStatus(ctx context.Context, args struct{ ID graphql.ID }) (*credentialStatusResolver, error) {
+	res, err := r.client.CredentialStatus(ctx, &didmodule.QueryCredentialStatusRequest{ID: string(args.ID)})
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &credentialStatusResolver{root: r, status: res.Status}, nil
+}
+
+// PendingChange resolves Query.pendingChange.
+func (r *Resolver) PendingChange(ctx context.Context, args struct{ ID Int64 }) (*pendingChangeResolver, error) {
+	res, err := r.client.PendingChange(ctx, &didmodule.QueryPendingChangeRequest{ChangeID: uint64(args.ID)})
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &pendingChangeResolver{root: r, change: res.Change}, nil
+}
+
+// Params resolves Query.params.
+func (r *Resolver) Params(ctx context.Context) (*paramsResolver, error) {
+	res, err := r.client.Params(ctx, &didmodule.QueryParamsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return &paramsResolver{res.Params}, nil
+}
+
+type didConnection struct {
+	nodes []*didResolver
+	next  *string
+}
+
+func (c *didConnection) Nodes() []*didResolver { return c.nodes }
+func (c *didConnection) NextCursor() *string   { return c.next }
+
+type didResolver struct {
+	root *Resolver
+	doc  didmodule.DIDDocument
+}
+
+func (d *didResolver) ID() graphql.ID         { return graphql.ID(d.doc.ID) }
+func (d *didResolver) VersionID() Int64       { return Int64(d.doc.Nonce) }
+func (d *didResolver) Digest() string         { return d.doc.DigestHex() }
+func (d *didResolver) Deactivated() bool      { return d.doc.Deactivated }
+func (d *didResolver) PublicKey() string      { return d.doc.PublicKey }
+func (d *didResolver) Authentication() string { return d.doc.Authentication }
+func (d *didResolver) KeyAgreement() []string { return nonNil(d.doc.KeyAgreement) }
+func (d *didResolver) ControllerThreshold() int32 {
+	return int32(d.doc.ControllerThreshold)
+}
+
+func (d *didResolver) ExpiresAt() *Int64 {
+	if d.doc.ExpiresAt == 0 {
+		return nil
+	}
+	v := Int64(d.doc.ExpiresAt)
+	return &v
+}
+
+func (d *didResolver) Controllers(ctx context.Context) ([]*didResolver, error) {
+	return d.root.didRefs(ctx, d.doc.Controller)
+}
+
+func (d *didResolver) VerificationMethods(args struct{ Type *string }) []*verificationMethodResolver {
+	out := []*verificationMethodResolver{}
+	for _, vm := range d.doc.VerificationMethods {
+		if args.Type == nil || vm.Type == *args.Type {
+			out = append(out, &verificationMethodResolver{root: d.root, vm: vm})
+		}
+	}
+	return out
+}
+
+func (d *didResolver) Services(args struct{ Type *string }) []*serviceResolver {
+	return services(d.doc.Services, args.Type)
+}
+
+func (d *didResolver) Resources(ctx context.Context, args struct {
+	First        int32
+	After        *string
+	ResourceType *string
+}) (*resourceConnection, error) {
+	page, err := pageRequest(args.First, args.After)
+	if err != nil {
+		return nil, err
+	}
+	res, err := d.root.client.Resources(ctx, &didmodule.QueryResourcesRequest{DID: d.doc.ID, Pagination: page})
+	if err != nil {
+		return nil, err
+	}
+	conn := &resourceConnection{next: cursor(res.Pagination)}
+	for _, resource := range res.Resources {
+		if args.ResourceType == nil || resource.ResourceType == *args.ResourceType {
+			conn.nodes = append(conn.nodes, &resourceResolver{root: d.root, resource: resource})
+		}
+	}
+	return conn, nil
+}
+
+func (d *didResolver) PendingChanges(ctx context.Context, args struct {
+	First int32
+	After *string
+}) (*pendingChangeConnection, error) {
+	page, err := pageRequest(args.First, args.After)
+	if err != nil {
+		return nil, err
+	}
+	res, err := d.root.client.PendingChanges(ctx, &didmodule.QueryPendingChangesRequest{DID: d.doc.ID, Pagination: page})
+	if err != nil {
+		return nil, err
+	}
+	conn := &pendingChangeConnection{next: cursor(res.Pagination)}
+	for _, change := range res.Changes {
+		conn.nodes = append(conn.nodes, &pendingChangeResolver{root: d.root, change: change})
+	}
+	return conn, nil
+}
+
+func (d *didResolver) Recovery(ctx context.Context) (*recoveryResolver, error) {
+	res, err := d.root.client.Recovery(ctx, &didmodule.QueryRecoveryRequest{DID: d.doc.ID})
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &recoveryResolver{root: d.root, config: res.Config, pending: res.Pending}, nil
+}
+
+func (d *didResolver) KeyRotation(ctx context.Context) (*keyRotationResolver, error) {
+	res, err := d.root.client.KeyRotation(ctx, &didmodule.QueryKeyRotationRequest{DID: d.doc.ID})
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &keyRotationResolver{res.Rotation}, nil
+}
+
+type verificationMethodResolver struct {
+	root *Resolver
+	vm   didmodule.VerificationMethod
+}
+
+func (v *verificationMethodResolver) ID() graphql.ID             { return graphql.ID(v.vm.ID) }
+func (v *verificationMethodResolver) Type() string               { return v.vm.Type }
+func (v *verificationMethodResolver) PublicKeyMultibase() string { return v.vm.PublicKeyMultibase }
+func (v *verificationMethodResolver) X509CertificateChain() []string {
+	return append([]string{}, v.vm.X509CertificateChain...)
+}
+func (v *verificationMethodResolver) Threshold() int32    { return int32(v.vm.Threshold) }
+func (v *verificationMethodResolver) Participants() int32 { return int32(v.vm.Participants) }
+
+func (v *verificationMethodResolver) Controller(ctx context.Context) (*didResolver, error) {
+	if v.vm.Controller == "" {
+		return nil, nil
+	}
+	return v.root.didRef(ctx, v.vm.Controller)
+}
+
+type serviceResolver struct {
+	svc didmodule.Service
+}
+
+func services(svcs []didmodule.Service, typ *string) []*serviceResolver {
+	out := []*serviceResolver{}
+	for _, svc := range svcs {
+		if typ == nil || svc.Type == *typ {
+			out = append(out, &serviceResolver{svc})
+		}
+	}
+	return out
+}
+
+func (s *serviceResolver) ID() graphql.ID          { return graphql.ID(s.svc.ID) }
+func (s *serviceResolver) Type() string            { return s.svc.Type }
+func (s *serviceResolver) ServiceEndpoint() string { return s.svc.ServiceEndpoint }
+func (s *serviceResolver) RoutingKeys() []string   { return nonNil(s.svc.RoutingKeys) }
+func (s *serviceResolver) Accept() []string        { return nonNil(s.svc.Accept) }
+
+type resourceConnection struct {
+	nodes []*resourceResolver
+	next  *string
+}
+
+func (c *resourceConnection) Nodes() []*resourceResolver {
+	if c.nodes == nil {
+		return []*resourceResolver{}
+	}
+	return c.nodes
+}
+func (c *resourceConnection) NextCursor() *string { return c.next }
+
+type resourceResolver struct {
+	root     *Resolver
+	resource didmodule.Resource
+}
+
+func (r *resourceResolver) ID() graphql.ID       { return graphql.ID(r.resource.ID) }
+func (r *resourceResolver) Name() string         { return r.resource.Name }
+func (r *resourceResolver) ResourceType() string { return r.resource.ResourceType }
+func (r *resourceResolver) MediaType() string    { return r.resource.MediaType }
+func (r *resourceResolver) Version() string      { return r.resource.Version }
+func (r *resourceResolver) Checksum() string     { return r.resource.Checksum }
+func (r *resourceResolver) Created() Int64       { return Int64(r.resource.Created) }
+
+func (r *resourceResolver) Collection(ctx context.Context) (*didResolver, error) {
+	return r.root.didRef(ctx, r.resource.CollectionID)
+}
+
+func (r *resourceResolver) PreviousVersion(ctx context.Context) (*resourceResolver, error) {
+	return r.version(ctx, r.resource.PreviousVersionID)
+}
+
+func (r *resourceResolver) NextVersion(ctx context.Context) (*resourceResolver, error) {
+	return r.version(ctx, r.resource.NextVersionID)
+}
+
+func (r *resourceResolver) version(ctx context.Context, id string) (*resourceResolver, error) {
+	if id == "" {
+		return nil, nil
+	}
+	res, err := r.root.client.Resource(ctx, &didmodule.QueryResourceRequest{DID: r.resource.CollectionID, ID: id})
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &resourceResolver{root: r.root, resource: res.Resource}, nil
+}
+
+type pendingChangeConnection struct {
+	nodes []*pendingChangeResolver
+	next  *string
+}
+
+func (c *pendingChangeConnection) Nodes() []*pendingChangeResolver {
+	if c.nodes == nil {
+		return []*pendingChangeResolver{}
+	}
+	return c.nodes
+}
+func (c *pendingChangeConnection) NextCursor() *string { return c.next }
+
+type pendingChangeResolver struct {
+	root   *Resolver
+	change didmodule.PendingDIDChange
+}
+
+func (p *pendingChangeResolver) ID() Int64         { return Int64(p.change.ID) }
+func (p *pendingChangeResolver) Operation() string { return p.change.Operation }
+func (p *pendingChangeResolver) ExpiresAt() Int64  { return Int64(p.change.ExpiresAt) }
+func (p *pendingChangeResolver) Document() *documentResolver {
+	return &documentResolver{p.change.Document}
+}
+
+func (p *pendingChangeResolver) DID(ctx context.Context) (*didResolver, error) {
+	return p.root.didRef(ctx, p.change.DID)
+}
+
+func (p *pendingChangeResolver) Approvals(ctx context.Context) ([]*didResolver, error) {
+	return p.root.didRefs(ctx, p.change.Approvals)
+}
+
+type documentResolver struct {
+	doc didmodule.DIDDocument
+}
+
+func (d *documentResolver) ID() graphql.ID    { return graphql.ID(d.doc.ID) }
+func (d *documentResolver) PublicKey() string { return d.doc.PublicKey }
+
+func (d *documentResolver) Controllers() []graphql.ID {
+	out := make([]graphql.ID, len(d.doc.Controller))
+	for i, c := range d.doc.Controller {
+		out[i] = graphql.ID(c)
+	}
+	return out
+}
+
+func (d *documentResolver) Services() []*serviceResolver {
+	return services(d.doc.Services, nil)
+}
+
+type recoveryResolver struct {
+	root    *Resolver
+	config  didmodule.RecoveryConfig
+	pending *didmodule.PendingRecovery
+}
+
+func (r *recoveryResolver) Threshold() int32 { return int32(r.config.Threshold) }
+
+func (r *recoveryResolver) Guardians(ctx context.Context) ([]*didResolver, error) {
+	return r.root.didRefs(ctx, r.config.Guardians)
+}
+
+func (r *recoveryResolver) Pending() *pendingRecoveryResolver {
+	if r.pending == nil {
+		return nil
+	}
+	return &pendingRecoveryResolver{root: r.root, pending: *r.pending}
+}
+
+type pendingRecoveryResolver struct {
+	root    *Resolver
+	pending didmodule.PendingRecovery
+}
+
+func (p *pendingRecoveryResolver) NewPublicKey() string { return p.pending.NewPublicKey }
+func (p *pendingRecoveryResolver) ExpiresAt() Int64     { return Int64(p.pending.ExpiresAt) }
+
+func (p *pendingRecoveryResolver) ExecutableAt() *Int64 {
+	if p.pending.ExecutableAt == 0 {
+		return nil
+	}
+	v := Int64(p.pending.ExecutableAt)
+	return &v
+}
+
+func (p *pendingRecoveryResolver) Approvals(ctx context.Context) ([]*didResolver, error) {
+	return p.root.didRefs(ctx, p.pending.Approvals)
+}
+
+type keyRotationResolver struct {
+	rotation didmodule.PendingKeyRotation
+}
+
+func (k *keyRotationResolver) NewPublicKey() string { return k.rotation.NewPublicKey }
+func (k *keyRotationResolver) RotationDelay() Int64 { return Int64(k.rotation.RotationDelay) }
+func (k *keyRotationResolver) EffectiveAt() Int64   { return Int64(k.rotation.EffectiveAt) }
+
+type credentialStatusResolver struct {
+	root   *Resolver
+	status didmodule.CredentialStatus
+}
+
+func (c *credentialStatusResolver) ID() graphql.ID   { return graphql.ID(c.status.ID) }
+func (c *credentialStatusResolver) Revoked() bool    { return c.status.Revoked }
+func (c *credentialStatusResolver) Reason() string   { return c.status.Reason }
+func (c *credentialStatusResolver) RevokedAt() Int64 { return Int64(c.status.RevokedAt) }
+
+func (c *credentialStatusResolver) Issuer(ctx context.Context) (*didResolver, error) {
+	if c.status.Issuer == "" {
+		return nil, nil
+	}
+	return c.root.didRef(ctx, c.status.Issuer)
+}
+
+type paramsResolver struct {
+	params didmodule.Params
+}
+
+func (p *paramsResolver) MaxDocumentSize() Int64 { return Int64(p.params.MaxDocumentSize) }
+func (p *paramsResolver) MaxVerificationMethods() Int64 {
+	return Int64(p.params.MaxVerificationMethods)
+}
+func (p *paramsResolver) MaxServiceEndpoints() Int64  { return Int64(p.params.MaxServiceEndpoints) }
+func (p *paramsResolver) AllowedKeyTypes() []string   { return nonNil(p.params.AllowedKeyTypes) }
+func (p *paramsResolver) ChangeExpiryBlocks() Int64   { return Int64(p.params.ChangeExpiryBlocks) }
+func (p *paramsResolver) RecoveryWindowBlocks() Int64 { return Int64(p.params.RecoveryWindowBlocks) }
+func (p *paramsResolver) RecoveryDelayBlocks() Int64  { return Int64(p.params.RecoveryDelayBlocks) }
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -0,0 +1,147 @@
+package gql
+
+// Schema is the GraphQL schema served by the explorer endpoint. Int64 values
+// (nonces, heights, timestamps) are encoded as strings since GraphQL's Int is
+// 32 bits.
+const Schema = `
+schema {
+  query: Query
+}
+
+scalar Int64
+
+type Query {
+  # did resolves a DID, or returns null if it isn't registered.
+  did(id: ID!): DID
+  # dids lists registered DIDs. The filters are applied to each page before
+  # first is counted, so a page may take several round trips to fill.
+  dids(first: Int = 20, after: String, controller: ID, deactivated: Boolean): DIDConnection!
+  # credentialStatus returns the revocation record of a credential, or null if
+  # it has never been revoked.
+  credentialStatus(id: ID!): CredentialStatus
+  pendingChange(id: Int64!): PendingChange
+  params: Params!
+}
+
+type DID {
+  id: ID!
+  # versionId is the document's nonce, incremented on every update.
+  versionId: Int64!
+  # digest is the hex SHA-256 of the JCS-canonicalized DID Core JSON of the
+  # document, as stored on chain with every write.
+  digest: String!
+  deactivated: Boolean!
+  expiresAt: Int64
+  publicKey: String!
+  authentication: String!
+  controllers: [DID!]!
+  controllerThreshold: Int!
+  verificationMethods(type: String): [VerificationMethod!]!
+  keyAgreement: [String!]!
+  services(type: String): [Service!]!
+  resources(first: Int = 20, after: String, resourceType: String): ResourceConnection!
+  pendingChanges(first: Int = 20, after: String): PendingChangeConnection!
+  recovery: Recovery
+  keyRotation: KeyRotation
+}
+
+type DIDConnection {
+  nodes: [DID!]!
+  nextCursor: String
+}
+
+type VerificationMethod {
+  id: ID!
+  type: String!
+  controller: DID
+  publicKeyMultibase: String!
+  x509CertificateChain: [String!]!
+  threshold: Int!
+  participants: Int!
+}
+
+type Service {
+  id: ID!
+  type: String!
+  serviceEndpoint: String!
+  routingKeys: [String!]!
+  accept: [String!]!
+}
+
+type Resource {
+  id: ID!
+  collection: DID
+  name: String!
+  resourceType: String!
+  mediaType: String!
+  version: String!
+  checksum: String!
+  created: Int64!
+  previousVersion: Resource
+  nextVersion: Resource
+}
+
+type ResourceConnection {
+  nodes: [Resource!]!
+  nextCursor: String
+}
+
+type PendingChange {
+  id: Int64!
+  did: DID
+  operation: String!
+  document: DIDDocument!
+  approvals: [DID!]!
+  expiresAt: Int64!
+}
+
+type PendingChangeConnection {
+  nodes: [PendingChange!]!
+  nextCursor: String
+}
+
+# DIDDocument is a proposed document that hasn't been written to the registry.
+type DIDDocument {
+  id: ID!
+  publicKey: String!
+  controllers: [ID!]!
+  services: [Service!]!
+}
+
+type Recovery {
+  guardians: [DID!]!
+  threshold: Int!
+  pending: PendingRecovery
+}
+
+type PendingRecovery {
+  newPublicKey: String!
+  approvals: [DID!]!
+  expiresAt: Int64!
+  executableAt: Int64
+}
+
+type KeyRotation {
+  newPublicKey: String!
+  rotationDelay: Int64!
+  effectiveAt: Int64!
+}
+
+type CredentialStatus {
+  id: ID!
+  issuer: DID
+  revoked: Boolean!
+  reason: String!
+  revokedAt: Int64!
+}
+
+type Params {
+  maxDocumentSize: Int64!
+  maxVerificationMethods: Int64!
+  maxServiceEndpoints: Int64!
+  allowedKeyTypes: [String!]!
+  changeExpiryBlocks: Int64!
+  recoveryWindowBlocks: Int64!
+  recoveryDelayBlocks: Int64!
+}
+`
@@ -0,0 +1,46 @@
+package indexer
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// Search result limits of the /search endpoint.
+const (
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 100
+)
+
+// RegisterSearchRoutes registers GET /search?q=&limit= over search.
+func RegisterSearchRoutes(r *mux.Router, search Search) {
+	r.HandleFunc("/search", searchHandler(search)).Methods("GET")
+}
+
+func searchHandler(search Search) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query().Get("q")
+		if q == "" {
+			http.Error(w, "missing q", http.StatusBadRequest)
+			return
+		}
+		limit := DefaultSearchLimit
+		if v := r.URL.Query().Get("limit"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 1 || n > MaxSearchLimit {
+				http.Error(w, "limit must be between 1 and 100", http.StatusBadRequest)
+				return
+			}
+			limit = n
+		}
+		hits, err := search.Search(r.Context(), q, limit)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"hits": hits})
+	}
+}
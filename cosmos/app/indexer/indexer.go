@@ -0,0 +1,321 @@
+// Package indexer tails a node's blocks and keeps a Postgres database of
+// DIDs, their versions and services, registered credentials and revocations,
+// so explorers and analytics can query the registry relationally. The tables
+// are documented in schema.sql. Optionally, DIDs, names and resource metadata
+// are also fed to a full-text search index for discovery queries.
+package indexer
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	didmodule "cosmos-app/modules/did"
+
+	"github.com/lib/pq"
+	abci "github.com/tendermint/tendermint/abci/types"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+)
+
+// Schema is the indexer's database schema. Migrate applies it.
+//
+//go:embed schema.sql
+var Schema string
+
+// Events indexed besides EventTypeDIDChanged. Names and resources are only
+// fed to the search index.
+const (
+	eventCredentialRegistered = "credential_status_registered"
+	eventCredentialRevoked    = "credential_revoked"
+	eventResourceCreated      = "resource_created"
+	eventNameRegistered       = "register_name"
+	eventNameTransferred      = "transfer_name"
+)
+
+// Node is the part of a Tendermint RPC client the indexer reads blocks and
+// historical documents through.
+type Node interface {
+	rpcclient.ABCIClient
+	rpcclient.SignClient
+	rpcclient.StatusClient
+}
+
+// Indexer copies the DID module's events into Postgres, one block per
+// database transaction, and optionally into a full-text search index.
+type Indexer struct {
+	db     *sql.DB
+	node   Node
+	search Search
+}
+
+// New creates an indexer writing to db and reading from node.
+func New(db *sql.DB, node Node) *Indexer {
+	return &Indexer{db: db, node: node}
+}
+
+// WithSearch returns a copy of ix that also feeds search. A block is only
+// recorded as indexed once search accepted its entries. Blocks indexed
+// before search was enabled are not fed to it; reindex from an empty
+// database to backfill.
+func (ix *Indexer) WithSearch(search Search) *Indexer {
+	cp := *ix
+	cp.search = search
+	return &cp
+}
+
+// Migrate creates the indexer's tables if they don't exist.
+func (ix *Indexer) Migrate(ctx context.Context) error {
+	_, err := ix.db.ExecContext(ctx, Schema)
+	return err
+}
+
+// Height returns the last indexed height, or 0 if nothing was indexed.
+func (ix *Indexer) Height(ctx context.Context) (int64, error) {
+	var height int64
+	err := ix.db.QueryRowContext(ctx, `SELECT height FROM indexer_state`).Scan(&height)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return height, err
+}
+
+// Run indexes blocks until ctx is done, resuming after the last indexed
+// height, or at start on an empty database, and polling for new blocks every
+// poll. The node must keep the state of the heights being indexed, so
+// catching up from an old height needs an archive node.
+func (ix *Indexer) Run(ctx context.Context, start int64, poll time.Duration) error {
+	height, err := ix.Height(ctx)
+	if err != nil {
+		return err
+	}
+	if height == 0 && start > 1 {
+		height = start - 1
+	}
+	for {
+		status, err := ix.node.Status(ctx)
+		if err != nil {
+			log.Printf("node status: %v", err)
+		} else {
+			for height < status.SyncInfo.LatestBlockHeight {
+				if err := ix.IndexBlock(ctx, height+1); err != nil {
+					return fmt.Errorf("index block %d: %w", height+1, err)
+				}
+				height++
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(poll):
+		}
+	}
+}
+
+// event is a block or transaction event with the hash of its transaction,
+// empty for BeginBlock and EndBlock events.
+type event struct {
+	abci.Event
+	txHash string
+}
+
+// IndexBlock indexes the events of the block at height.
+func (ix *Indexer) IndexBlock(ctx context.Context, height int64) error {
+	block, err := ix.node.Block(ctx, &height)
+	if err != nil {
+		return err
+	}
+	results, err := ix.node.BlockResults(ctx, &height)
+	if err != nil {
+		return err
+	}
+	var events []event
+	for _, e := range results.BeginBlockEvents {
+		events = append(events, event{Event: e})
+	}
+	for i, res := range results.TxsResults {
+		if res.Code != abci.CodeTypeOK || i >= len(block.Block.Txs) {
+			continue
+		}
+		hash := fmt.Sprintf("%X", block.Block.Txs[i].Hash())
+		for _, e := range res.Events {
+			events = append(events, event{Event: e, txHash: hash})
+		}
+	}
+	for _, e := range results.EndBlockEvents {
+		events = append(events, event{Event: e})
+	}
+
+	tx, err := ix.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	// Documents are read as of the end of the block, so one read serves
+	// every write of a DID in it.
+	docs := make(map[string]didmodule.DIDDocument)
+	var searchDocs []SearchDoc
+	for _, e := range events {
+		attrs := attributes(e.Event)
+		switch e.Type {
+		case didmodule.EventTypeDIDChanged:
+			id := attrs[didmodule.AttributeKeyDID]
+			doc, ok := docs[id]
+			if !ok {
+				if doc, err = ix.document(ctx, id, height); err != nil {
+					return fmt.Errorf("document %s: %w", id, err)
+				}
+				docs[id] = doc
+			}
+			version, _ := strconv.ParseUint(attrs[didmodule.AttributeKeyVersion], 10, 64)
+			err = indexDIDChanged(ctx, tx, doc, version, attrs[didmodule.AttributeKeyOperation], height, e.txHash)
+			if version == doc.Nonce {
+				searchDocs = append(searchDocs, didSearchDoc(doc, height))
+			}
+		case eventCredentialRegistered:
+			err = indexCredential(ctx, tx, attrs, height, e.txHash)
+		case eventCredentialRevoked:
+			err = indexRevocation(ctx, tx, attrs, height, e.txHash)
+		case eventResourceCreated:
+			searchDocs = append(searchDocs, SearchDoc{
+				ID:           SearchKindResource + ":" + attrs["did"] + didmodule.ResourcePathSegment + attrs["resource_id"],
+				Kind:         SearchKindResource,
+				DID:          attrs["did"],
+				Name:         attrs["name"],
+				ResourceID:   attrs["resource_id"],
+				ResourceType: attrs["resource_type"],
+				MediaType:    attrs["media_type"],
+				Version:      attrs["version"],
+				Height:       height,
+			})
+		case eventNameRegistered, eventNameTransferred:
+			owner := attrs["did"]
+			if e.Type == eventNameTransferred {
+				owner = attrs["to"]
+			}
+			searchDocs = append(searchDocs, SearchDoc{
+				ID:     SearchKindName + ":" + attrs["name"],
+				Kind:   SearchKindName,
+				DID:    owner,
+				Name:   attrs["name"],
+				Height: height,
+			})
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if ix.search != nil {
+		if err := ix.search.Index(ctx, searchDocs); err != nil {
+			return fmt.Errorf("search: %w", err)
+		}
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT INTO indexer_state (id, height) VALUES (TRUE, $1)
+		ON CONFLICT (id) DO UPDATE SET height = EXCLUDED.height`, height); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// document reads the DID document id as of height.
+func (ix *Indexer) document(ctx context.Context, id string, height int64) (didmodule.DIDDocument, error) {
+	res, err := ix.node.ABCIQueryWithOptions(ctx, fmt.Sprintf("custom/%s/%s", didmodule.ModuleName, id), nil,
+		rpcclient.ABCIQueryOptions{Height: height})
+	if err != nil {
+		return didmodule.DIDDocument{}, err
+	}
+	if !res.Response.IsOK() {
+		return didmodule.DIDDocument{}, fmt.Errorf("query failed: %s", res.Response.Log)
+	}
+	var doc didmodule.DIDDocument
+	if err := json.Unmarshal(res.Response.Value, &doc); err != nil {
+		return didmodule.DIDDocument{}, err
+	}
+	return doc, nil
+}
+
+// indexDIDChanged records version of doc. The current state of the DID, and
+// the version's document, are only written for the version doc is at; an
+// earlier version written in the same block keeps a NULL document.
+func indexDIDChanged(ctx context.Context, tx *sql.Tx, doc didmodule.DIDDocument, version uint64, operation string, height int64, txHash string) error {
+	var document []byte
+	if version == doc.Nonce {
+		var err error
+		if document, err = json.Marshal(doc.W3C(true)); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT INTO versions (did, version, operation, height, tx_hash, document)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6) ON CONFLICT (did, version) DO NOTHING`,
+		doc.ID, version, operation, height, txHash, document); err != nil {
+		return err
+	}
+	if document == nil {
+		return nil
+	}
+
+	var expiresAt sql.NullInt64
+	if doc.ExpiresAt != 0 {
+		expiresAt = sql.NullInt64{Int64: doc.ExpiresAt, Valid: true}
+	}
+	controllers := doc.Controller
+	if controllers == nil {
+		controllers = []string{}
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT INTO dids (did, version, controllers, deactivated, expires_at, created_height, updated_height, document)
+		VALUES ($1, $2, $3, $4, $5, $6, $6, $7)
+		ON CONFLICT (did) DO UPDATE SET version = EXCLUDED.version, controllers = EXCLUDED.controllers,
+			deactivated = EXCLUDED.deactivated, expires_at = EXCLUDED.expires_at,
+			updated_height = EXCLUDED.updated_height, document = EXCLUDED.document`,
+		doc.ID, doc.Nonce, pq.Array(controllers), doc.Deactivated, expiresAt, height, document); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM services WHERE did = $1`, doc.ID); err != nil {
+		return err
+	}
+	for _, svc := range doc.W3C(false).Service {
+		routingKeys := svc.RoutingKeys
+		if routingKeys == nil {
+			routingKeys = []string{}
+		}
+		if _, err := tx.ExecContext(ctx, `INSERT INTO services (did, id, type, endpoint, routing_keys)
+			VALUES ($1, $2, $3, $4, $5) ON CONFLICT (did, id) DO NOTHING`,
+			doc.ID, svc.ID, svc.Type, svc.ServiceEndpoint, pq.Array(routingKeys)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func indexCredential(ctx context.Context, tx *sql.Tx, attrs map[string]string, height int64, txHash string) error {
+	index, err := strconv.ParseUint(attrs["status_list_index"], 10, 64)
+	if err != nil {
+		return fmt.Errorf("credential %s: invalid status list index", attrs["credential_id"])
+	}
+	_, err = tx.ExecContext(ctx, `INSERT INTO credentials (credential_id, issuer, status_list_credential, status_list_index, height, tx_hash)
+		VALUES ($1, $2, $3, $4, $5, $6) ON CONFLICT (credential_id) DO NOTHING`,
+		attrs["credential_id"], attrs["issuer"], attrs["status_list_credential"], index, height, txHash)
+	return err
+}
+
+func indexRevocation(ctx context.Context, tx *sql.Tx, attrs map[string]string, height int64, txHash string) error {
+	_, err := tx.ExecContext(ctx, `INSERT INTO revocations (credential_id, issuer, reason, height, tx_hash)
+		VALUES ($1, $2, $3, $4, $5) ON CONFLICT (credential_id) DO NOTHING`,
+		attrs["credential_id"], attrs["issuer"], attrs["reason"], height, txHash)
+	return err
+}
+
+// attributes returns the attributes of e by key. Repeated keys, such as the
+// controllers of a DID event, keep their last value; the indexer reads those
+// from the document instead.
+func attributes(e abci.Event) map[string]string {
+	attrs := make(map[string]string, len(e.Attributes))
+	for _, attr := range e.Attributes {
+		attrs[string(attr.Key)] = string(attr.Value)
+	}
+	return attrs
+}
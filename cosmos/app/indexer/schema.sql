@@ -0,0 +1,73 @@
+-- Schema maintained by the aytch DID indexer. It is applied on startup and
+-- is safe to apply again. Heights are block heights; every row is written
+-- from the events of a committed block, so tables never run ahead of
+-- indexer_state.height.
+
+-- indexer_state holds the last fully indexed height. Blocks are indexed in
+-- one transaction each, together with this row.
+CREATE TABLE IF NOT EXISTS indexer_state (
+    id     BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    height BIGINT  NOT NULL
+);
+
+-- dids holds the current state of every DID.
+CREATE TABLE IF NOT EXISTS dids (
+    did            TEXT     PRIMARY KEY,
+    version        BIGINT   NOT NULL,   -- the document nonce, see versions
+    controllers    TEXT[]   NOT NULL DEFAULT '{}',
+    deactivated    BOOLEAN  NOT NULL DEFAULT FALSE,
+    expires_at     BIGINT,              -- unix seconds, NULL if the DID doesn't expire
+    created_height BIGINT   NOT NULL,
+    updated_height BIGINT   NOT NULL,
+    document       JSONB    NOT NULL    -- the W3C DID Core form of the document
+);
+
+CREATE INDEX IF NOT EXISTS dids_controllers_idx ON dids USING GIN (controllers);
+
+-- versions holds every written version of every DID document.
+CREATE TABLE IF NOT EXISTS versions (
+    did       TEXT   NOT NULL,
+    version   BIGINT NOT NULL,
+    operation TEXT   NOT NULL,          -- create, update or deactivate
+    height    BIGINT NOT NULL,
+    tx_hash   TEXT,                     -- NULL for writes made by the EndBlocker
+    document  JSONB,                    -- NULL if a later write in the same block replaced it
+    PRIMARY KEY (did, version)
+);
+
+CREATE INDEX IF NOT EXISTS versions_height_idx ON versions (height);
+
+-- services holds the services of the current version of every DID.
+CREATE TABLE IF NOT EXISTS services (
+    did          TEXT   NOT NULL,
+    id           TEXT   NOT NULL,
+    type         TEXT   NOT NULL,
+    endpoint     TEXT   NOT NULL,
+    routing_keys TEXT[] NOT NULL DEFAULT '{}',
+    PRIMARY KEY (did, id)
+);
+
+CREATE INDEX IF NOT EXISTS services_type_idx ON services (type);
+
+-- credentials holds the credentials registered with a status list entry.
+CREATE TABLE IF NOT EXISTS credentials (
+    credential_id          TEXT   PRIMARY KEY,
+    issuer                 TEXT   NOT NULL,
+    status_list_credential TEXT   NOT NULL,
+    status_list_index      BIGINT NOT NULL,
+    height                 BIGINT NOT NULL,
+    tx_hash                TEXT   NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS credentials_issuer_idx ON credentials (issuer);
+
+-- revocations holds every revoked credential, registered or not.
+CREATE TABLE IF NOT EXISTS revocations (
+    credential_id TEXT   PRIMARY KEY,
+    issuer        TEXT   NOT NULL,
+    reason        TEXT   NOT NULL,
+    height        BIGINT NOT NULL,
+    tx_hash       TEXT   NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS revocations_issuer_idx ON revocations (issuer);
@@ -0,0 +1,182 @@
+package indexer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	didmodule "cosmos-app/modules/did"
+)
+
+// Kinds of search documents.
+const (
+	SearchKindDID      = "did"
+	SearchKindName     = "name"
+	SearchKindResource = "resource"
+)
+
+// SearchDoc is an entry of the full-text search index. Each entry has a
+// stable ID, so indexing a block again overwrites rather than duplicates.
+type SearchDoc struct {
+	ID           string   `json:"-"`
+	Kind         string   `json:"kind"`
+	DID          string   `json:"did"`
+	Deactivated  bool     `json:"deactivated,omitempty"`
+	AlsoKnownAs  []string `json:"also_known_as,omitempty"`
+	Endpoints    []string `json:"endpoints,omitempty"`
+	ServiceTypes []string `json:"service_types,omitempty"`
+	Name         string   `json:"name,omitempty"`
+	ResourceID   string   `json:"resource_id,omitempty"`
+	ResourceType string   `json:"resource_type,omitempty"`
+	MediaType    string   `json:"media_type,omitempty"`
+	Version      string   `json:"version,omitempty"`
+	Height       int64    `json:"height"`
+}
+
+// SearchHit is a search result, best match first.
+type SearchHit struct {
+	SearchDoc
+	Score float64 `json:"score"`
+}
+
+// Search is a full-text index the indexer feeds alongside Postgres.
+type Search interface {
+	Index(ctx context.Context, docs []SearchDoc) error
+	Search(ctx context.Context, q string, limit int) ([]SearchHit, error)
+}
+
+// didSearchDoc returns the search entry of doc, with its service endpoints
+// and alsoKnownAs names.
+func didSearchDoc(doc didmodule.DIDDocument, height int64) SearchDoc {
+	sd := SearchDoc{
+		ID:          SearchKindDID + ":" + doc.ID,
+		Kind:        SearchKindDID,
+		DID:         doc.ID,
+		Deactivated: doc.Deactivated,
+		AlsoKnownAs: doc.AlsoKnownAs,
+		Height:      height,
+	}
+	for _, svc := range doc.W3C(false).Service {
+		sd.Endpoints = append(sd.Endpoints, svc.ServiceEndpoint)
+		sd.ServiceTypes = append(sd.ServiceTypes, svc.Type)
+	}
+	return sd
+}
+
+// Elasticsearch is a Search backed by an Elasticsearch (or OpenSearch) index,
+// spoken to over its REST API.
+type Elasticsearch struct {
+	baseURL    string
+	index      string
+	httpClient *http.Client
+}
+
+// NewElasticsearch creates a Search over index at baseURL. The index is
+// created on first write with Elasticsearch's dynamic mapping. A nil client
+// uses one with a ten second timeout.
+func NewElasticsearch(baseURL, index string, client *http.Client) *Elasticsearch {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &Elasticsearch{baseURL: strings.TrimRight(baseURL, "/"), index: index, httpClient: client}
+}
+
+// Index writes docs with one bulk request.
+func (es *Elasticsearch) Index(ctx context.Context, docs []SearchDoc) error {
+	if len(docs) == 0 {
+		return nil
+	}
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for _, doc := range docs {
+		action := map[string]interface{}{"index": map[string]string{"_index": es.index, "_id": doc.ID}}
+		if err := enc.Encode(action); err != nil {
+			return err
+		}
+		if err := enc.Encode(doc); err != nil {
+			return err
+		}
+	}
+	var res struct {
+		Errors bool `json:"errors"`
+		Items  []map[string]struct {
+			ID    string          `json:"_id"`
+			Error json.RawMessage `json:"error"`
+		} `json:"items"`
+	}
+	if err := es.do(ctx, http.MethodPost, "/_bulk", "application/x-ndjson", &body, &res); err != nil {
+		return err
+	}
+	if res.Errors {
+		for _, item := range res.Items {
+			for _, r := range item {
+				if len(r.Error) > 0 {
+					return fmt.Errorf("index %s: %s", r.ID, r.Error)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Search runs q as a simple query string over the text fields of the index.
+func (es *Elasticsearch) Search(ctx context.Context, q string, limit int) ([]SearchHit, error) {
+	query := map[string]interface{}{
+		"size": limit,
+		"query": map[string]interface{}{
+			"simple_query_string": map[string]interface{}{
+				"query":            q,
+				"fields":           []string{"did", "name^3", "also_known_as^2", "endpoints", "service_types", "resource_type", "media_type"},
+				"default_operator": "and",
+			},
+		},
+	}
+	bz, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		Hits struct {
+			Hits []struct {
+				ID     string    `json:"_id"`
+				Score  float64   `json:"_score"`
+				Source SearchDoc `json:"_source"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := es.do(ctx, http.MethodPost, "/"+es.index+"/_search", "application/json", bytes.NewReader(bz), &res); err != nil {
+		return nil, err
+	}
+	hits := make([]SearchHit, 0, len(res.Hits.Hits))
+	for _, h := range res.Hits.Hits {
+		h.Source.ID = h.ID
+		hits = append(hits, SearchHit{SearchDoc: h.Source, Score: h.Score})
+	}
+	return hits, nil
+}
+
+func (es *Elasticsearch) do(ctx context.Context, method, path, contentType string, body io.Reader, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, es.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	resp, err := es.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	bz, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("elasticsearch: unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(bz)))
+	}
+	return json.Unmarshal(bz, v)
+}
@@ -0,0 +1,53 @@
+package mediator
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"cosmos-app/didcomm"
+
+	"github.com/gorilla/mux"
+)
+
+// maxEnvelopeSize bounds the envelopes accepted by the mediator.
+const maxEnvelopeSize = 1 << 20
+
+// RegisterRoutes registers the mediator's HTTP endpoints.
+func (m *Mediator) RegisterRoutes(r *mux.Router) {
+	r.HandleFunc("/", m.receiveHandler).Methods("POST")
+	r.HandleFunc("/routing", m.routingHandler).Methods("GET")
+}
+
+// receiveHandler accepts a packed DIDComm message and returns the reply, if
+// any, on the same connection.
+func (m *Mediator) receiveHandler(w http.ResponseWriter, r *http.Request) {
+	envelope, err := io.ReadAll(io.LimitReader(r.Body, maxEnvelopeSize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	reply, err := m.Receive(r.Context(), envelope)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if reply == nil {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+	w.Header().Set("Content-Type", didcomm.MediaTypeEncrypted)
+	w.Write(reply)
+}
+
+// routingHandler returns the service a holder adds to its document, for the
+// holder given in the did query parameter.
+func (m *Mediator) routingHandler(w http.ResponseWriter, r *http.Request) {
+	holder := r.URL.Query().Get("did")
+	if holder == "" {
+		http.Error(w, "missing did", http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(m.RoutingService(holder))
+}
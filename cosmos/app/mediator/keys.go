@@ -0,0 +1,96 @@
+package mediator
+
+import (
+	"crypto/ecdh"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"cosmos-app/client/resolver"
+	"cosmos-app/didcomm"
+)
+
+// Keys are the mediator's DID and private keys: an Ed25519 authentication key
+// signing its replies and the X25519 keyAgreement key holders list as their
+// routing key.
+type Keys struct {
+	DID          string `json:"did"`
+	SigningKID   string `json:"signing_kid"`
+	SigningKey   []byte `json:"signing_key"`
+	AgreementKID string `json:"agreement_kid"`
+	AgreementKey []byte `json:"agreement_key"`
+
+	agreement *ecdh.PrivateKey
+}
+
+// KeyAgreementKey implements didcomm.KeyStore.
+func (k *Keys) KeyAgreementKey(kid string) (*ecdh.PrivateKey, bool) {
+	if kid != k.AgreementKID {
+		return nil, false
+	}
+	return k.agreement, true
+}
+
+// Signer returns the signer for the mediator's replies.
+func (k *Keys) Signer() *didcomm.Signer {
+	return &didcomm.Signer{KID: k.SigningKID, Key: ed25519.PrivateKey(k.SigningKey)}
+}
+
+// LoadKeys reads the mediator's keys from path. If the file does not exist,
+// new keys are generated for a did:peer:2 whose messaging service is endpoint
+// and saved to path.
+func LoadKeys(path, endpoint string) (*Keys, error) {
+	bz, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return createKeys(path, endpoint)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var k Keys
+	if err := json.Unmarshal(bz, &k); err != nil {
+		return nil, fmt.Errorf("invalid key file %s: %w", path, err)
+	}
+	if len(k.SigningKey) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("invalid signing key in %s", path)
+	}
+	if k.agreement, err = ecdh.X25519().NewPrivateKey(k.AgreementKey); err != nil {
+		return nil, fmt.Errorf("invalid agreement key in %s: %w", path, err)
+	}
+	return &k, nil
+}
+
+func createKeys(path, endpoint string) (*Keys, error) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	agreement, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	did, err := resolver.NewPeerDID(pub, agreement.PublicKey(), endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	// did:peer:2 numbers keys in the order NewPeerDID encodes them.
+	k := &Keys{
+		DID:          did,
+		SigningKID:   did + "#key-2",
+		SigningKey:   priv,
+		AgreementKID: did + "#key-1",
+		AgreementKey: agreement.Bytes(),
+		agreement:    agreement,
+	}
+	bz, err := json.MarshalIndent(k, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, bz, 0o600); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
@@ -0,0 +1,317 @@
+// Package mediator implements a DIDComm v2 mediator that holds messages for
+// holders without a reachable endpoint. Holders request mediation, register
+// the DIDs they receive messages for, and advertise the mediator in their
+// on-chain documents through a DIDCommMessaging service whose endpoint is the
+// mediator and whose routingKeys name its keyAgreement key. Senders then wrap
+// messages in forward envelopes (see didcomm.Packer.Route), which the mediator
+// queues until the holder picks them up. Queues are kept in memory.
+package mediator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"cosmos-app/didcomm"
+	didmodule "cosmos-app/modules/did"
+)
+
+// Message types of the Coordinate Mediation 3.0 and Pickup 3.0 protocols.
+const (
+	MediateRequestType        = "https://didcomm.org/coordinate-mediation/3.0/mediate-request"
+	MediateGrantType          = "https://didcomm.org/coordinate-mediation/3.0/mediate-grant"
+	KeylistUpdateType         = "https://didcomm.org/coordinate-mediation/3.0/recipient-update"
+	KeylistUpdateResponseType = "https://didcomm.org/coordinate-mediation/3.0/recipient-update-response"
+	StatusRequestType         = "https://didcomm.org/messagepickup/3.0/status-request"
+	StatusType                = "https://didcomm.org/messagepickup/3.0/status"
+	DeliveryRequestType       = "https://didcomm.org/messagepickup/3.0/delivery-request"
+	DeliveryType              = "https://didcomm.org/messagepickup/3.0/delivery"
+	MessagesReceivedType      = "https://didcomm.org/messagepickup/3.0/messages-received"
+)
+
+// MaxQueued bounds the messages held for a single recipient.
+const MaxQueued = 1000
+
+// DefaultDeliveryLimit is the number of messages delivered per pickup when the
+// holder does not set a limit.
+const DefaultDeliveryLimit = 10
+
+type queued struct {
+	id       string
+	envelope interface{}
+}
+
+// Mediator queues forwarded messages for registered recipients.
+type Mediator struct {
+	did      string
+	endpoint string
+	packer   *didcomm.Packer
+	keys     *Keys
+
+	mu         sync.Mutex
+	granted    map[string]bool
+	recipients map[string]string
+	queues     map[string][]queued
+}
+
+// New creates a mediator identified by keys.DID and reachable at endpoint.
+func New(keys *Keys, endpoint string, resolver didcomm.Resolver) *Mediator {
+	return &Mediator{
+		did:        keys.DID,
+		endpoint:   endpoint,
+		packer:     didcomm.NewPacker(resolver),
+		keys:       keys,
+		granted:    make(map[string]bool),
+		recipients: make(map[string]string),
+		queues:     make(map[string][]queued),
+	}
+}
+
+// RoutingService returns the DIDCommMessaging service a holder adds to its
+// on-chain document to receive messages through the mediator.
+func (m *Mediator) RoutingService(holder string) didmodule.Service {
+	return didmodule.Service{
+		ID:              holder + "#didcomm-mediator",
+		Type:            didmodule.ServiceTypeDIDCommMessaging,
+		ServiceEndpoint: m.endpoint,
+		RoutingKeys:     []string{m.keys.AgreementKID},
+		Accept:          []string{didmodule.DIDCommV2Profile},
+	}
+}
+
+// Receive handles an envelope posted to the mediator. Forwards are queued and
+// produce no reply; every other message must be signed by its sender and is
+// answered with an envelope for the sender, returned on the same connection.
+func (m *Mediator) Receive(ctx context.Context, envelope []byte) ([]byte, error) {
+	msg, meta, err := m.packer.Unpack(ctx, envelope, m.keys)
+	if err != nil {
+		return nil, err
+	}
+	if msg.Type == didcomm.ForwardType {
+		return nil, m.forward(msg)
+	}
+	if meta.SignedBy == "" || msg.From == "" {
+		return nil, fmt.Errorf("%s must be signed by its sender", msg.Type)
+	}
+
+	var reply didcomm.Message
+	switch msg.Type {
+	case MediateRequestType:
+		reply = m.grant(msg)
+	case KeylistUpdateType:
+		reply, err = m.updateKeylist(msg)
+	case StatusRequestType:
+		reply, err = m.status(msg)
+	case DeliveryRequestType:
+		reply, err = m.deliver(msg)
+	case MessagesReceivedType:
+		reply, err = m.received(msg)
+	default:
+		return nil, fmt.Errorf("unsupported message type %s", msg.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+	reply.ThreadID = msg.ID
+	return m.packer.Pack(ctx, reply, m.keys.Signer())
+}
+
+func (m *Mediator) forward(msg didcomm.Message) error {
+	next, _ := msg.Body["next"].(string)
+	if next == "" || len(msg.Attachments) != 1 {
+		return fmt.Errorf("forward needs a next recipient and one attachment")
+	}
+	recipient := strings.SplitN(next, "#", 2)[0]
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.recipients[recipient]; !ok {
+		return fmt.Errorf("%s is not a recipient of this mediator", recipient)
+	}
+	if len(m.queues[recipient]) >= MaxQueued {
+		return fmt.Errorf("queue for %s is full", recipient)
+	}
+	m.queues[recipient] = append(m.queues[recipient], queued{id: msg.ID, envelope: msg.Attachments[0].Data.JSON})
+	return nil
+}
+
+func (m *Mediator) grant(msg didcomm.Message) didcomm.Message {
+	m.mu.Lock()
+	m.granted[msg.From] = true
+	m.mu.Unlock()
+	return didcomm.NewMessage(MediateGrantType, m.did, []string{msg.From}, map[string]interface{}{
+		"routing_did": []string{m.keys.AgreementKID},
+		"endpoint":    m.endpoint,
+	})
+}
+
+type recipientUpdate struct {
+	RecipientDID string `json:"recipient_did"`
+	Action       string `json:"action"`
+	Result       string `json:"result,omitempty"`
+}
+
+// updateKeylist adds or removes the DIDs a granted holder receives messages
+// for. A DID can only be registered by one holder.
+func (m *Mediator) updateKeylist(msg didcomm.Message) (didcomm.Message, error) {
+	var body struct {
+		Updates []recipientUpdate `json:"updates"`
+	}
+	if err := decodeBody(msg, &body); err != nil {
+		return didcomm.Message{}, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.granted[msg.From] {
+		return didcomm.Message{}, fmt.Errorf("mediation has not been granted to %s", msg.From)
+	}
+	for i, u := range body.Updates {
+		holder, registered := m.recipients[u.RecipientDID]
+		switch {
+		case u.RecipientDID == "":
+			body.Updates[i].Result = "client_error"
+		case registered && holder != msg.From:
+			body.Updates[i].Result = "client_error"
+		case u.Action == "add":
+			m.recipients[u.RecipientDID] = msg.From
+			body.Updates[i].Result = "success"
+		case u.Action == "remove" && registered:
+			delete(m.recipients, u.RecipientDID)
+			delete(m.queues, u.RecipientDID)
+			body.Updates[i].Result = "success"
+		case u.Action == "remove":
+			body.Updates[i].Result = "no_change"
+		default:
+			body.Updates[i].Result = "client_error"
+		}
+	}
+	return didcomm.NewMessage(KeylistUpdateResponseType, m.did, []string{msg.From}, map[string]interface{}{
+		"updated": body.Updates,
+	}), nil
+}
+
+type pickupRequest struct {
+	RecipientDID  string   `json:"recipient_did"`
+	Limit         int      `json:"limit"`
+	MessageIDList []string `json:"message_id_list"`
+}
+
+// recipientsOf returns the recipients a pickup request covers: the one it
+// names, which must belong to the holder, or all of the holder's.
+func (m *Mediator) recipientsOf(holder, recipient string) ([]string, error) {
+	if recipient != "" {
+		if m.recipients[recipient] != holder {
+			return nil, fmt.Errorf("%s is not registered by %s", recipient, holder)
+		}
+		return []string{recipient}, nil
+	}
+	var dids []string
+	for did, h := range m.recipients {
+		if h == holder {
+			dids = append(dids, did)
+		}
+	}
+	return dids, nil
+}
+
+func (m *Mediator) status(msg didcomm.Message) (didcomm.Message, error) {
+	var req pickupRequest
+	if err := decodeBody(msg, &req); err != nil {
+		return didcomm.Message{}, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	dids, err := m.recipientsOf(msg.From, req.RecipientDID)
+	if err != nil {
+		return didcomm.Message{}, err
+	}
+	count := 0
+	for _, did := range dids {
+		count += len(m.queues[did])
+	}
+	body := map[string]interface{}{"message_count": count, "live_delivery": false}
+	if req.RecipientDID != "" {
+		body["recipient_did"] = req.RecipientDID
+	}
+	return didcomm.NewMessage(StatusType, m.did, []string{msg.From}, body), nil
+}
+
+// deliver returns queued messages as attachments. They stay queued until the
+// holder acknowledges them with messages-received.
+func (m *Mediator) deliver(msg didcomm.Message) (didcomm.Message, error) {
+	var req pickupRequest
+	if err := decodeBody(msg, &req); err != nil {
+		return didcomm.Message{}, err
+	}
+	if req.Limit <= 0 {
+		req.Limit = DefaultDeliveryLimit
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	dids, err := m.recipientsOf(msg.From, req.RecipientDID)
+	if err != nil {
+		return didcomm.Message{}, err
+	}
+	var attachments []didcomm.Attachment
+	for _, did := range dids {
+		for _, q := range m.queues[did] {
+			if len(attachments) == req.Limit {
+				break
+			}
+			attachments = append(attachments, didcomm.Attachment{ID: q.id, Data: didcomm.AttachmentData{JSON: q.envelope}})
+		}
+	}
+	if len(attachments) == 0 {
+		return m.status(msg)
+	}
+	body := map[string]interface{}{}
+	if req.RecipientDID != "" {
+		body["recipient_did"] = req.RecipientDID
+	}
+	reply := didcomm.NewMessage(DeliveryType, m.did, []string{msg.From}, body)
+	reply.Attachments = attachments
+	return reply, nil
+}
+
+// received drops acknowledged messages from the holder's queues and reports
+// what is left.
+func (m *Mediator) received(msg didcomm.Message) (didcomm.Message, error) {
+	var req pickupRequest
+	if err := decodeBody(msg, &req); err != nil {
+		return didcomm.Message{}, err
+	}
+	acked := make(map[string]bool, len(req.MessageIDList))
+	for _, id := range req.MessageIDList {
+		acked[id] = true
+	}
+	m.mu.Lock()
+	dids, err := m.recipientsOf(msg.From, "")
+	if err == nil {
+		for _, did := range dids {
+			kept := m.queues[did][:0]
+			for _, q := range m.queues[did] {
+				if !acked[q.id] {
+					kept = append(kept, q)
+				}
+			}
+			m.queues[did] = kept
+		}
+	}
+	m.mu.Unlock()
+	if err != nil {
+		return didcomm.Message{}, err
+	}
+	return m.status(didcomm.Message{ID: msg.ID, From: msg.From, Body: map[string]interface{}{}})
+}
+
+func decodeBody(msg didcomm.Message, v interface{}) error {
+	bz, err := json.Marshal(msg.Body)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(bz, v); err != nil {
+		return fmt.Errorf("invalid %s body: %w", msg.Type, err)
+	}
+	return nil
+}
@@ -0,0 +1,378 @@
+package attestation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	didmodule "cosmos-app/modules/did"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/spf13/cobra"
+)
+
+// Flags for the attestation module's CLI commands.
+const (
+	FlagClaim        = "claim"
+	FlagSchema       = "schema"
+	FlagExpiresAt    = "expires-at"
+	FlagTier         = "tier"
+	FlagJurisdiction = "jurisdiction"
+	FlagEvidenceHash = "evidence-hash"
+)
+
+// GetTxCmd returns the root tx command for the attestation module.
+func GetTxCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        ModuleName,
+		Short:                      "Attestation transaction subcommands",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+	cmd.AddCommand(
+		CmdAttest(),
+		CmdAttestKYC(),
+		CmdAccredit(),
+		CmdRevokeAttestation(),
+	)
+	return cmd
+}
+
+// GetQueryCmd returns the root query command for the attestation module.
+func GetQueryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        ModuleName,
+		Short:                      "Querying commands for attestations",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+	cmd.AddCommand(
+		CmdQueryAttestation(),
+		CmdAttestationsBySubject(),
+		CmdAttestationsByIssuer(),
+		CmdQueryKYC(),
+		CmdQueryTrustChain(),
+		CmdQueryParams(),
+	)
+	return cmd
+}
+
+// CmdAttest returns the command to publish an attestation.
+func CmdAttest() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "attest [issuer-did] [subject-did] [type]",
+		Short: "Publish a typed attestation by one DID about another",
+		Long: `Publish an attestation of the given type by the issuer DID about the subject
+DID, with key-value claims given as --claim key=value, replacing the issuer's
+earlier attestation of the same type about the subject. --schema references
+the schema the claims follow and --expires-at sets the unix time the
+attestation lapses at. A controller of the issuer DID authorizes the
+attestation as for a DID update: an account controller by signing the
+transaction, a DID controller with --key or --signature.`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			claims, err := parseClaims(cmd)
+			if err != nil {
+				return err
+			}
+			signer, nonce, err := didmodule.ControllerProof(cmd, clientCtx, args[0])
+			if err != nil {
+				return err
+			}
+			schema, _ := cmd.Flags().GetString(FlagSchema)
+			expiresAt, _ := cmd.Flags().GetInt64(FlagExpiresAt)
+			msg := &MsgAttest{
+				Issuer:          args[0],
+				Subject:         args[1],
+				AttestationType: args[2],
+				Claims:          claims,
+				Schema:          schema,
+				ExpiresAt:       expiresAt,
+				Nonce:           nonce,
+				Signer:          signer,
+				Creator:         clientCtx.GetFromAddress(),
+			}
+			if msg.Signature, err = didmodule.ProofSignature(cmd, clientCtx, msg.ProofSignBytes()); err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+	cmd.Flags().StringArray(FlagClaim, nil, "Claim as key=value; repeatable")
+	cmd.Flags().String(FlagSchema, "", "URI of the schema the claims follow")
+	cmd.Flags().Int64(FlagExpiresAt, 0, "Unix time at which the attestation lapses")
+	didmodule.AddControllerProofFlags(cmd)
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdAttestKYC returns the command for a KYC provider to post a KYC
+// attestation.
+func CmdAttestKYC() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "kyc [provider-did] [subject-did]",
+		Short: fmt.Sprintf("Post a %s attestation as a KYC provider", KYCAttestationType),
+		Long: fmt.Sprintf(`Post a %s attestation by a KYC provider DID, one of the kyc_providers
+parameter, for a subject DID. --tier, --jurisdiction (ISO 3166 code),
+--evidence-hash (hex SHA-256 of the off-chain evidence) and --expires-at are
+required. Authorization works as for attest; revoke the attestation with
+revoke [provider-did] [subject-did] %s.`, KYCAttestationType, KYCAttestationType),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			signer, nonce, err := didmodule.ControllerProof(cmd, clientCtx, args[0])
+			if err != nil {
+				return err
+			}
+			tier, _ := cmd.Flags().GetUint32(FlagTier)
+			jurisdiction, _ := cmd.Flags().GetString(FlagJurisdiction)
+			evidenceHash, _ := cmd.Flags().GetString(FlagEvidenceHash)
+			expiresAt, _ := cmd.Flags().GetInt64(FlagExpiresAt)
+			msg := &MsgAttest{
+				Issuer:          args[0],
+				Subject:         args[1],
+				AttestationType: KYCAttestationType,
+				Claims: []Claim{
+					{Key: KYCClaimTier, Value: strconv.FormatUint(uint64(tier), 10)},
+					{Key: KYCClaimJurisdiction, Value: jurisdiction},
+					{Key: KYCClaimEvidenceHash, Value: evidenceHash},
+				},
+				ExpiresAt: expiresAt,
+				Nonce:     nonce,
+				Signer:    signer,
+				Creator:   clientCtx.GetFromAddress(),
+			}
+			if msg.Signature, err = didmodule.ProofSignature(cmd, clientCtx, msg.ProofSignBytes()); err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+	cmd.Flags().Uint32(FlagTier, 0, "KYC tier the subject passed, from 1")
+	cmd.Flags().String(FlagJurisdiction, "", "ISO 3166 code of the jurisdiction of the checks, e.g. DE or US-NY")
+	cmd.Flags().String(FlagEvidenceHash, "", "Hex SHA-256 of the provider's off-chain evidence")
+	cmd.Flags().Int64(FlagExpiresAt, 0, "Unix time at which the KYC status lapses")
+	didmodule.AddControllerProofFlags(cmd)
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdAccredit returns the command for a trust anchor or accredited
+// accreditor to accredit another DID.
+func CmdAccredit() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "accredit [accreditor-did] [subject-did] [role]",
+		Short: fmt.Sprintf("Accredit a DID as %s or %s", RoleAccreditor, RoleIssuer),
+		Long: fmt.Sprintf(`Post an accreditation by a trust anchor, one of the trust_anchors parameter,
+or an accreditor with a valid trust chain, accrediting a subject DID as an
+%s (%s) or an %s (%s). --expires-at optionally bounds the
+accreditation. Authorization works as for attest; revoke the accreditation
+with revoke [accreditor-did] [subject-did] [type].`, RoleAccreditor, AccreditorAttestationType, RoleIssuer, IssuerAttestationType),
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			typ, err := accreditationType(args[2])
+			if err != nil {
+				return err
+			}
+			signer, nonce, err := didmodule.ControllerProof(cmd, clientCtx, args[0])
+			if err != nil {
+				return err
+			}
+			expiresAt, _ := cmd.Flags().GetInt64(FlagExpiresAt)
+			msg := &MsgAttest{
+				Issuer:          args[0],
+				Subject:         args[1],
+				AttestationType: typ,
+				ExpiresAt:       expiresAt,
+				Nonce:           nonce,
+				Signer:          signer,
+				Creator:         clientCtx.GetFromAddress(),
+			}
+			if msg.Signature, err = didmodule.ProofSignature(cmd, clientCtx, msg.ProofSignBytes()); err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+	cmd.Flags().Int64(FlagExpiresAt, 0, "Unix time at which the accreditation lapses, 0 for never")
+	didmodule.AddControllerProofFlags(cmd)
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdRevokeAttestation returns the command to withdraw an attestation.
+func CmdRevokeAttestation() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "revoke [issuer-did] [subject-did] [type]",
+		Short: "Withdraw an attestation, authorized as for attest",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			signer, nonce, err := didmodule.ControllerProof(cmd, clientCtx, args[0])
+			if err != nil {
+				return err
+			}
+			msg := &MsgRevokeAttestation{
+				Issuer:          args[0],
+				Subject:         args[1],
+				AttestationType: args[2],
+				Nonce:           nonce,
+				Signer:          signer,
+				Creator:         clientCtx.GetFromAddress(),
+			}
+			if msg.Signature, err = didmodule.ProofSignature(cmd, clientCtx, msg.ProofSignBytes()); err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+	didmodule.AddControllerProofFlags(cmd)
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+func parseClaims(cmd *cobra.Command) ([]Claim, error) {
+	pairs, _ := cmd.Flags().GetStringArray(FlagClaim)
+	claims := make([]Claim, 0, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("claim %q must be key=value", pair)
+		}
+		claims = append(claims, Claim{Key: key, Value: value})
+	}
+	return claims, nil
+}
+
+// CmdQueryAttestation returns the command to query a single attestation.
+func CmdQueryAttestation() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "attestation [subject-did] [type] [issuer-did]",
+		Short: "Query the issuer's attestation of a type about a subject, including expired ones",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return printQuery(cmd, QueryAttestation, args[0], args[1], args[2])
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdAttestationsBySubject returns the command to list the attestations
+// about a DID.
+func CmdAttestationsBySubject() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "subject [subject-did] [type]",
+		Short: "List the active attestations about a DID, optionally of one type",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return printQuery(cmd, append([]string{QueryBySubject}, args...)...)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdAttestationsByIssuer returns the command to list the attestations a
+// DID has published.
+func CmdAttestationsByIssuer() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "issuer [issuer-did]",
+		Short: "List the active attestations a DID has published",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return printQuery(cmd, QueryByIssuer, args[0])
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdQueryKYC returns the command to query a DID's KYC status.
+func CmdQueryKYC() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "kyc [subject-did]",
+		Short: "List the active KYC attestations about a DID by current KYC providers",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return printQuery(cmd, QueryKYC, args[0])
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdQueryTrustChain returns the command to validate a DID's trust chain.
+func CmdQueryTrustChain() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "trust-chain [did] [role]",
+		Short: fmt.Sprintf("Validate the accreditation chain from a trust anchor to a DID as %s (default) or %s", RoleIssuer, RoleAccreditor),
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return printQuery(cmd, append([]string{QueryTrustChain}, args...)...)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdQueryParams returns the command to query the attestation module's
+// parameters.
+func CmdQueryParams() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "params",
+		Short: "Query the attestation module's parameters, including the KYC providers and trust anchors",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return printQuery(cmd, QueryParams)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func printQuery(cmd *cobra.Command, path ...string) error {
+	clientCtx, err := client.GetClientQueryContext(cmd)
+	if err != nil {
+		return err
+	}
+	route := "custom/" + ModuleName
+	for _, p := range path {
+		route += "/" + p
+	}
+	res, _, err := clientCtx.QueryWithData(route, nil)
+	if err != nil {
+		return err
+	}
+	return clientCtx.PrintBytes(res)
+}
@@ -0,0 +1,66 @@
+package attestation
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	cdctypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/gogo/protobuf/proto"
+)
+
+var (
+	amino = codec.NewLegacyAmino()
+
+	// ModuleCdc is the amino codec used for the attestation module's legacy
+	// sign bytes.
+	ModuleCdc = codec.NewAminoCodec(amino)
+)
+
+func init() {
+	RegisterLegacyAminoCodec(amino)
+	amino.Seal()
+
+	// Like the DID module's, the attestation module's types are hand-written
+	// and registered under the names a generated aytch/attestation/v1 package
+	// would use.
+	proto.RegisterType((*Claim)(nil), "aytch.attestation.v1.Claim")
+	proto.RegisterType((*Attestation)(nil), "aytch.attestation.v1.Attestation")
+	proto.RegisterType((*MsgAttest)(nil), "aytch.attestation.v1.MsgAttest")
+	proto.RegisterType((*MsgRevokeAttestation)(nil), "aytch.attestation.v1.MsgRevokeAttestation")
+	proto.RegisterType((*GenesisState)(nil), "aytch.attestation.v1.GenesisState")
+}
+
+// RegisterLegacyAminoCodec registers the attestation module's messages on the
+// given LegacyAmino codec.
+func RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) {
+	cdc.RegisterConcrete(MsgAttest{}, "attestation/Attest", nil)
+	cdc.RegisterConcrete(MsgRevokeAttestation{}, "attestation/RevokeAttestation", nil)
+}
+
+// RegisterInterfaces registers the attestation module's messages as sdk.Msg
+// implementations. They are routed by the legacy handler.
+func RegisterInterfaces(registry cdctypes.InterfaceRegistry) {
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgAttest{},
+		&MsgRevokeAttestation{},
+	)
+}
+
+func (m *Claim) Reset()         { *m = Claim{} }
+func (m *Claim) String() string { return proto.CompactTextString(m) }
+func (*Claim) ProtoMessage()    {}
+
+func (m *Attestation) Reset()         { *m = Attestation{} }
+func (m *Attestation) String() string { return proto.CompactTextString(m) }
+func (*Attestation) ProtoMessage()    {}
+
+func (m *MsgAttest) Reset()         { *m = MsgAttest{} }
+func (m *MsgAttest) String() string { return proto.CompactTextString(m) }
+func (*MsgAttest) ProtoMessage()    {}
+
+func (m *MsgRevokeAttestation) Reset()         { *m = MsgRevokeAttestation{} }
+func (m *MsgRevokeAttestation) String() string { return proto.CompactTextString(m) }
+func (*MsgRevokeAttestation) ProtoMessage()    {}
+
+func (m *GenesisState) Reset()         { *m = GenesisState{} }
+func (m *GenesisState) String() string { return proto.CompactTextString(m) }
+func (*GenesisState) ProtoMessage()    {}
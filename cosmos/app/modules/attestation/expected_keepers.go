@@ -0,0 +1,17 @@
+package attestation
+
+import (
+	didmodule "cosmos-app/modules/did"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// DIDKeeper defines the DID registry functionality used to check that
+// subjects exist and that issuers are allowed to and authorize their
+// attestations.
+type DIDKeeper interface {
+	GetDID(ctx sdk.Context, id string) (didmodule.DIDDocument, error)
+	AuthorizeController(ctx sdk.Context, id string, nonce uint64, signer string, creator sdk.AccAddress, signBytes, signature []byte) error
+	IncrementNonce(ctx sdk.Context, id string) error
+	CheckIssuer(ctx sdk.Context, did string) error
+}
@@ -0,0 +1,55 @@
+package attestation
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState defines the attestation module's genesis state.
+type GenesisState struct {
+	Attestations []Attestation `protobuf:"bytes,1,rep,name=attestations,proto3" json:"attestations"`
+	Params       Params        `protobuf:"bytes,2,opt,name=params,proto3" json:"params"`
+}
+
+// DefaultGenesis returns the default genesis state.
+func DefaultGenesis() *GenesisState {
+	return &GenesisState{Params: DefaultParams()}
+}
+
+// ValidateGenesis performs basic genesis state validation.
+func ValidateGenesis(gs GenesisState) error {
+	if err := gs.Params.Validate(); err != nil {
+		return err
+	}
+	seen := make(map[string]bool, len(gs.Attestations))
+	for _, a := range gs.Attestations {
+		if err := a.Validate(); err != nil {
+			return fmt.Errorf("attestation %s by %s about %s: %w", a.Type, a.Issuer, a.Subject, err)
+		}
+		key := string(attestationKey(a.Subject, a.Type, a.Issuer))
+		if seen[key] {
+			return fmt.Errorf("duplicate %s attestation by %s about %s", a.Type, a.Issuer, a.Subject)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// InitGenesis initializes the attestation module's state from a genesis
+// state.
+func InitGenesis(ctx sdk.Context, k Keeper, gs GenesisState) {
+	k.SetParams(ctx, gs.Params)
+	for _, a := range gs.Attestations {
+		k.setAttestation(ctx, a)
+	}
+}
+
+// ExportGenesis exports the attestation module's state, including expired
+// attestations.
+func ExportGenesis(ctx sdk.Context, k Keeper) *GenesisState {
+	return &GenesisState{
+		Attestations: k.GetAllAttestations(ctx),
+		Params:       k.GetParams(ctx),
+	}
+}
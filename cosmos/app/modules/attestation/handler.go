@@ -0,0 +1,65 @@
+package attestation
+
+import (
+	"fmt"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// NewHandler creates a handler for attestation messages.
+func NewHandler(k Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+		ctx = ctx.WithEventManager(sdk.NewEventManager())
+		switch msg := msg.(type) {
+		case *MsgAttest:
+			return handleMsgAttest(ctx, k, *msg)
+		case *MsgRevokeAttestation:
+			return handleMsgRevokeAttestation(ctx, k, *msg)
+		default:
+			return nil, fmt.Errorf("unrecognized attestation message type: %T", msg)
+		}
+	}
+}
+
+// authorizeIssuer checks a controller proof for the issuer DID and consumes
+// its nonce, so the proof can't be replayed.
+func authorizeIssuer(ctx sdk.Context, k Keeper, issuer string, nonce uint64, signer string, creator sdk.AccAddress, signBytes, signature []byte) error {
+	if err := k.didKeeper.AuthorizeController(ctx, issuer, nonce, signer, creator, signBytes, signature); err != nil {
+		return err
+	}
+	return k.didKeeper.IncrementNonce(ctx, issuer)
+}
+
+func handleMsgAttest(ctx sdk.Context, k Keeper, msg MsgAttest) (*sdk.Result, error) {
+	if err := authorizeIssuer(ctx, k, msg.Issuer, msg.Nonce, msg.Signer, msg.Creator, msg.ProofSignBytes(), msg.Signature); err != nil {
+		return nil, err
+	}
+	if err := k.Attest(ctx, msg.Attestation()); err != nil {
+		return nil, err
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		"attest",
+		sdk.NewAttribute("issuer", msg.Issuer),
+		sdk.NewAttribute("subject", msg.Subject),
+		sdk.NewAttribute("type", msg.AttestationType),
+		sdk.NewAttribute("expires_at", strconv.FormatInt(msg.ExpiresAt, 10)),
+	))
+	return &sdk.Result{Events: ctx.EventManager().ABCIEvents()}, nil
+}
+
+func handleMsgRevokeAttestation(ctx sdk.Context, k Keeper, msg MsgRevokeAttestation) (*sdk.Result, error) {
+	if err := authorizeIssuer(ctx, k, msg.Issuer, msg.Nonce, msg.Signer, msg.Creator, msg.ProofSignBytes(), msg.Signature); err != nil {
+		return nil, err
+	}
+	if err := k.RevokeAttestation(ctx, msg.Issuer, msg.Subject, msg.AttestationType); err != nil {
+		return nil, err
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		"revoke_attestation",
+		sdk.NewAttribute("issuer", msg.Issuer),
+		sdk.NewAttribute("subject", msg.Subject),
+		sdk.NewAttribute("type", msg.AttestationType),
+	))
+	return &sdk.Result{Events: ctx.EventManager().ABCIEvents()}, nil
+}
@@ -0,0 +1,196 @@
+package attestation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
+)
+
+// Store prefixes. Attestations are stored by subject, type and issuer under
+// SubjectPrefix, so all attestations about a subject, or those of one type,
+// share a prefix, and indexed by issuer under IssuerIndexPrefix. DIDs and
+// types contain no '/', so the separators are unambiguous.
+var (
+	SubjectPrefix     = []byte("subject/")
+	IssuerIndexPrefix = []byte("issuer/")
+)
+
+func subjectPrefix(subject string) []byte {
+	return append(append([]byte{}, SubjectPrefix...), subject+"/"...)
+}
+
+func subjectTypePrefix(subject, typ string) []byte {
+	return append(subjectPrefix(subject), typ+"/"...)
+}
+
+func attestationKey(subject, typ, issuer string) []byte {
+	return append(subjectTypePrefix(subject, typ), issuer...)
+}
+
+func issuerIndexPrefix(issuer string) []byte {
+	return append(append([]byte{}, IssuerIndexPrefix...), issuer+"/"...)
+}
+
+func issuerIndexKey(issuer, subject, typ string) []byte {
+	return append(issuerIndexPrefix(issuer), subject+"/"+typ...)
+}
+
+// Keeper maintains the attestations.
+type Keeper struct {
+	storeKey   sdk.StoreKey
+	cdc        *codec.LegacyAmino
+	paramSpace paramtypes.Subspace
+	didKeeper  DIDKeeper
+}
+
+// NewKeeper creates a new attestation Keeper.
+func NewKeeper(storeKey sdk.StoreKey, cdc *codec.LegacyAmino, paramSpace paramtypes.Subspace, didKeeper DIDKeeper) Keeper {
+	if !paramSpace.HasKeyTable() {
+		paramSpace = paramSpace.WithKeyTable(ParamKeyTable())
+	}
+	return Keeper{
+		storeKey:   storeKey,
+		cdc:        cdc,
+		paramSpace: paramSpace,
+		didKeeper:  didKeeper,
+	}
+}
+
+// GetParams returns the attestation module's parameters.
+func (k Keeper) GetParams(ctx sdk.Context) Params {
+	var params Params
+	k.paramSpace.GetParamSet(ctx, &params)
+	return params
+}
+
+// SetParams sets the attestation module's parameters.
+func (k Keeper) SetParams(ctx sdk.Context, params Params) {
+	k.paramSpace.SetParamSet(ctx, &params)
+}
+
+// Attest stores an attestation about an active subject DID, replacing the
+// issuer's earlier attestation of the same type. Only KYC providers may post
+// KYC attestations and only trust anchors and accredited accreditors may post
+// accreditations; only allowed issuers of the DID registry may post any
+// while it restricts issuers. Callers must have authorized the issuer.
+func (k Keeper) Attest(ctx sdk.Context, a Attestation) error {
+	if err := a.Validate(); err != nil {
+		return err
+	}
+	if a.Type == KYCAttestationType && !k.GetParams(ctx).IsKYCProvider(a.Issuer) {
+		return fmt.Errorf("%s is not a KYC provider", a.Issuer)
+	}
+	if isAccreditation(a.Type) {
+		if err := k.checkAccreditor(ctx, a.Issuer); err != nil {
+			return err
+		}
+	}
+	if err := k.didKeeper.CheckIssuer(ctx, a.Issuer); err != nil {
+		return err
+	}
+	subject, err := k.didKeeper.GetDID(ctx, a.Subject)
+	if err != nil {
+		return err
+	}
+	if subject.Deactivated {
+		return fmt.Errorf("DID %s is deactivated", a.Subject)
+	}
+	if !a.Active(ctx.BlockTime()) {
+		return fmt.Errorf("attestation has already expired")
+	}
+	a.Created = ctx.BlockHeight()
+	k.setAttestation(ctx, a)
+	return nil
+}
+
+// RevokeAttestation deletes the issuer's attestation of a type about subject.
+func (k Keeper) RevokeAttestation(ctx sdk.Context, issuer, subject, typ string) error {
+	a, err := k.GetAttestation(ctx, issuer, subject, typ)
+	if err != nil {
+		return err
+	}
+	k.deleteAttestation(ctx, a)
+	return nil
+}
+
+// GetAttestation returns the issuer's attestation of a type about subject,
+// whether or not it has expired.
+func (k Keeper) GetAttestation(ctx sdk.Context, issuer, subject, typ string) (Attestation, error) {
+	value := ctx.KVStore(k.storeKey).Get(attestationKey(subject, typ, issuer))
+	if value == nil {
+		return Attestation{}, fmt.Errorf("%s has no %s attestation about %s", issuer, typ, subject)
+	}
+	var a Attestation
+	k.cdc.MustUnmarshal(value, &a)
+	return a, nil
+}
+
+// AttestationsBySubject returns the active attestations about subject, of
+// type typ if it isn't empty, ordered by type and issuer.
+func (k Keeper) AttestationsBySubject(ctx sdk.Context, subject, typ string) []Attestation {
+	p := subjectPrefix(subject)
+	if typ != "" {
+		p = subjectTypePrefix(subject, typ)
+	}
+	var attestations []Attestation
+	for _, a := range k.attestations(ctx, p) {
+		if a.Active(ctx.BlockTime()) {
+			attestations = append(attestations, a)
+		}
+	}
+	return attestations
+}
+
+// AttestationsByIssuer returns the active attestations issuer has published,
+// ordered by subject and type.
+func (k Keeper) AttestationsByIssuer(ctx sdk.Context, issuer string) []Attestation {
+	var attestations []Attestation
+	p := issuerIndexPrefix(issuer)
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), p)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		parts := strings.SplitN(string(iter.Key()[len(p):]), "/", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		a, err := k.GetAttestation(ctx, issuer, parts[0], parts[1])
+		if err == nil && a.Active(ctx.BlockTime()) {
+			attestations = append(attestations, a)
+		}
+	}
+	return attestations
+}
+
+// GetAllAttestations returns every attestation in the store, including
+// expired ones.
+func (k Keeper) GetAllAttestations(ctx sdk.Context) []Attestation {
+	return k.attestations(ctx, SubjectPrefix)
+}
+
+func (k Keeper) attestations(ctx sdk.Context, p []byte) []Attestation {
+	var attestations []Attestation
+	iter := prefix.NewStore(ctx.KVStore(k.storeKey), p).Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var a Attestation
+		k.cdc.MustUnmarshal(iter.Value(), &a)
+		attestations = append(attestations, a)
+	}
+	return attestations
+}
+
+func (k Keeper) setAttestation(ctx sdk.Context, a Attestation) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(attestationKey(a.Subject, a.Type, a.Issuer), k.cdc.MustMarshal(&a))
+	store.Set(issuerIndexKey(a.Issuer, a.Subject, a.Type), []byte{})
+}
+
+func (k Keeper) deleteAttestation(ctx sdk.Context, a Attestation) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(attestationKey(a.Subject, a.Type, a.Issuer))
+	store.Delete(issuerIndexKey(a.Issuer, a.Subject, a.Type))
+}
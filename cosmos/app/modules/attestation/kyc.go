@@ -0,0 +1,85 @@
+package attestation
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+)
+
+// KYCAttestationType is the attestation type KYC providers post for a
+// subject that passed their checks. Only DIDs listed in the KYCProviders
+// parameter may post it, and it must carry the KYC claims and an expiry.
+const KYCAttestationType = "kyc-passed"
+
+// Claim keys of a KYC attestation. Tier is a positive integer, higher tiers
+// meaning stronger checks; Jurisdiction is the ISO 3166 code of the
+// jurisdiction the checks were made under, e.g. DE or US-NY; EvidenceHash is
+// the hex SHA-256 of the provider's off-chain evidence, which stays private.
+const (
+	KYCClaimTier         = "tier"
+	KYCClaimJurisdiction = "jurisdiction"
+	KYCClaimEvidenceHash = "evidence_hash"
+)
+
+// KYCStatus is a subject's KYC attestation by one provider, as consumed by
+// modules gating regulated actions.
+type KYCStatus struct {
+	Subject      string `json:"subject"`
+	Provider     string `json:"provider"`
+	Tier         uint32 `json:"tier"`
+	Jurisdiction string `json:"jurisdiction"`
+	EvidenceHash string `json:"evidence_hash"`
+	ExpiresAt    int64  `json:"expires_at"`
+}
+
+// kycStatus parses the KYC claims of a KYCAttestationType attestation.
+func kycStatus(a Attestation) (KYCStatus, error) {
+	s := KYCStatus{Subject: a.Subject, Provider: a.Issuer, ExpiresAt: a.ExpiresAt}
+	if a.ExpiresAt == 0 {
+		return s, fmt.Errorf("KYC attestations must expire")
+	}
+	if len(a.Claims) != 3 {
+		return s, fmt.Errorf("KYC attestations hold exactly the %s, %s and %s claims", KYCClaimTier, KYCClaimJurisdiction, KYCClaimEvidenceHash)
+	}
+	for _, c := range a.Claims {
+		switch c.Key {
+		case KYCClaimTier:
+			tier, err := strconv.ParseUint(c.Value, 10, 32)
+			if err != nil || tier == 0 {
+				return s, fmt.Errorf("KYC tier must be a positive integer")
+			}
+			s.Tier = uint32(tier)
+		case KYCClaimJurisdiction:
+			if err := validateJurisdiction(c.Value); err != nil {
+				return s, err
+			}
+			s.Jurisdiction = c.Value
+		case KYCClaimEvidenceHash:
+			if bz, err := hex.DecodeString(c.Value); err != nil || len(bz) != 32 {
+				return s, fmt.Errorf("KYC evidence hash must be a hex SHA-256")
+			}
+			s.EvidenceHash = c.Value
+		default:
+			return s, fmt.Errorf("unexpected KYC claim %s", c.Key)
+		}
+	}
+	return s, nil
+}
+
+// validateJurisdiction checks for an ISO 3166-1 alpha-2 country code,
+// optionally followed by an ISO 3166-2 subdivision, e.g. US-NY.
+func validateJurisdiction(j string) error {
+	if len(j) != 2 && (len(j) < 4 || len(j) > 6 || j[2] != '-') {
+		return fmt.Errorf("jurisdiction %q must be an ISO 3166 code", j)
+	}
+	for i, r := range j {
+		switch {
+		case r >= 'A' && r <= 'Z':
+		case r >= '0' && r <= '9' && i > 2:
+		case r == '-' && i == 2:
+		default:
+			return fmt.Errorf("jurisdiction %q must be an ISO 3166 code", j)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package attestation
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// KYCStatuses returns the subject's active KYC attestations by current KYC
+// providers, ordered by provider.
+func (k Keeper) KYCStatuses(ctx sdk.Context, subject string) []KYCStatus {
+	params := k.GetParams(ctx)
+	var statuses []KYCStatus
+	for _, a := range k.AttestationsBySubject(ctx, subject, KYCAttestationType) {
+		if !params.IsKYCProvider(a.Issuer) {
+			continue
+		}
+		if s, err := kycStatus(a); err == nil {
+			statuses = append(statuses, s)
+		}
+	}
+	return statuses
+}
+
+// KYCPassed reports whether a current KYC provider vouches for subject at
+// minTier or above, under one of jurisdictions if any are given. Modules
+// gating regulated actions call it through their expected keeper interface.
+func (k Keeper) KYCPassed(ctx sdk.Context, subject string, minTier uint32, jurisdictions ...string) bool {
+	for _, s := range k.KYCStatuses(ctx, subject) {
+		if s.Tier < minTier {
+			continue
+		}
+		if len(jurisdictions) == 0 {
+			return true
+		}
+		for _, j := range jurisdictions {
+			if s.Jurisdiction == j {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,118 @@
+package attestation
+
+import (
+	"fmt"
+
+	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
+	"github.com/gogo/protobuf/proto"
+)
+
+// Parameter store keys.
+var (
+	KeyKYCProviders = []byte("KYCProviders")
+	KeyTrustAnchors = []byte("TrustAnchors")
+)
+
+// Params defines the governance-controlled parameters of the attestation
+// module. KYCProviders are the DIDs allowed to post KYCAttestationType
+// attestations; removing a provider withdraws the KYC status it vouched for.
+// TrustAnchors are the root DIDs of the trust framework, which accredit the
+// first accreditors and issuers; removing an anchor breaks the chains through
+// it.
+type Params struct {
+	KYCProviders []string `protobuf:"bytes,1,rep,name=kyc_providers,proto3" json:"kyc_providers"`
+	TrustAnchors []string `protobuf:"bytes,2,rep,name=trust_anchors,proto3" json:"trust_anchors"`
+}
+
+func init() {
+	proto.RegisterType((*Params)(nil), "aytch.attestation.v1.Params")
+}
+
+func (m *Params) Reset()         { *m = Params{} }
+func (m *Params) String() string { return proto.CompactTextString(m) }
+func (*Params) ProtoMessage()    {}
+
+// ParamKeyTable returns the key table for the attestation module's
+// parameters.
+func ParamKeyTable() paramtypes.KeyTable {
+	return paramtypes.NewKeyTable().RegisterParamSet(&Params{})
+}
+
+// DefaultParams returns the default parameters: no KYC providers or trust
+// anchors, so KYC attestations and accreditations are disabled until
+// governance admits one.
+func DefaultParams() Params {
+	return Params{KYCProviders: []string{}, TrustAnchors: []string{}}
+}
+
+// ParamSetPairs implements paramtypes.ParamSet.
+func (p *Params) ParamSetPairs() paramtypes.ParamSetPairs {
+	return paramtypes.ParamSetPairs{
+		paramtypes.NewParamSetPair(KeyKYCProviders, &p.KYCProviders, validateKYCProviders),
+		paramtypes.NewParamSetPair(KeyTrustAnchors, &p.TrustAnchors, validateTrustAnchors),
+	}
+}
+
+// Validate performs basic validation of the parameters.
+func (p Params) Validate() error {
+	if err := validateKYCProviders(p.KYCProviders); err != nil {
+		return err
+	}
+	return validateTrustAnchors(p.TrustAnchors)
+}
+
+// IsKYCProvider reports whether did may post KYC attestations.
+func (p Params) IsKYCProvider(did string) bool {
+	for _, provider := range p.KYCProviders {
+		if provider == did {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTrustAnchor reports whether did is a root of the trust framework.
+func (p Params) IsTrustAnchor(did string) bool {
+	for _, anchor := range p.TrustAnchors {
+		if anchor == did {
+			return true
+		}
+	}
+	return false
+}
+
+func validateKYCProviders(i interface{}) error {
+	providers, ok := i.([]string)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	seen := make(map[string]bool, len(providers))
+	for _, provider := range providers {
+		if err := validateDID(provider); err != nil {
+			return fmt.Errorf("KYC provider %s: %w", provider, err)
+		}
+		if seen[provider] {
+			return fmt.Errorf("duplicate KYC provider %s", provider)
+		}
+		seen[provider] = true
+	}
+	return nil
+}
+
+func validateTrustAnchors(i interface{}) error {
+	anchors, ok := i.([]string)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	seen := make(map[string]bool, len(anchors))
+	for _, anchor := range anchors {
+		if err := validateDID(anchor); err != nil {
+			return fmt.Errorf("trust anchor %s: %w", anchor, err)
+		}
+		if seen[anchor] {
+			return fmt.Errorf("duplicate trust anchor %s", anchor)
+		}
+		seen[anchor] = true
+	}
+	return nil
+}
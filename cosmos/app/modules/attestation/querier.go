@@ -0,0 +1,75 @@
+package attestation
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// Query endpoints supported by the attestation querier.
+const (
+	// QueryAttestation takes subject, type and issuer.
+	QueryAttestation = "attestation"
+	// QueryBySubject takes a subject and optionally a type.
+	QueryBySubject = "subject"
+	// QueryByIssuer takes an issuer.
+	QueryByIssuer = "issuer"
+	// QueryKYC takes a subject.
+	QueryKYC = "kyc"
+	// QueryTrustChain takes a DID and optionally a role, issuer by default.
+	QueryTrustChain = "trust-chain"
+
+	QueryParams = "params"
+)
+
+// NewQuerier creates a legacy querier for the attestation module. Results
+// are encoded as plain JSON, like the DID module's.
+func NewQuerier(k Keeper, _ *codec.LegacyAmino) sdk.Querier {
+	return func(ctx sdk.Context, path []string, _ abci.RequestQuery) ([]byte, error) {
+		if len(path) == 0 {
+			return nil, fmt.Errorf("empty attestation query path")
+		}
+		switch {
+		case path[0] == QueryAttestation && len(path) == 4:
+			a, err := k.GetAttestation(ctx, path[3], path[1], path[2])
+			if err != nil {
+				return nil, err
+			}
+			return json.MarshalIndent(a, "", "  ")
+		case path[0] == QueryBySubject && (len(path) == 2 || len(path) == 3):
+			var typ string
+			if len(path) == 3 {
+				typ = path[2]
+			}
+			return marshalAttestations(k.AttestationsBySubject(ctx, path[1], typ))
+		case path[0] == QueryByIssuer && len(path) == 2:
+			return marshalAttestations(k.AttestationsByIssuer(ctx, path[1]))
+		case path[0] == QueryKYC && len(path) == 2:
+			statuses := k.KYCStatuses(ctx, path[1])
+			if statuses == nil {
+				statuses = []KYCStatus{}
+			}
+			return json.MarshalIndent(statuses, "", "  ")
+		case path[0] == QueryTrustChain && (len(path) == 2 || len(path) == 3):
+			role := RoleIssuer
+			if len(path) == 3 {
+				role = path[2]
+			}
+			return json.MarshalIndent(k.ValidateTrustChain(ctx, path[1], role), "", "  ")
+		case path[0] == QueryParams:
+			return json.MarshalIndent(k.GetParams(ctx), "", "  ")
+		default:
+			return nil, fmt.Errorf("unknown attestation query path %v", path)
+		}
+	}
+}
+
+func marshalAttestations(attestations []Attestation) ([]byte, error) {
+	if attestations == nil {
+		attestations = []Attestation{}
+	}
+	return json.MarshalIndent(attestations, "", "  ")
+}
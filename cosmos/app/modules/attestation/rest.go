@@ -0,0 +1,37 @@
+package attestation
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/gorilla/mux"
+)
+
+// RegisterRoutes registers the attestation module's REST routes.
+func RegisterRoutes(cliCtx client.Context, r *mux.Router) {
+	r.HandleFunc("/attestation/subjects/{did}", queryHandler(cliCtx, QueryBySubject)).Methods("GET")
+	r.HandleFunc("/attestation/subjects/{did}/{type}", queryHandler(cliCtx, QueryBySubject, "type")).Methods("GET")
+	r.HandleFunc("/attestation/issuers/{did}", queryHandler(cliCtx, QueryByIssuer)).Methods("GET")
+	r.HandleFunc("/attestation/kyc/{did}", queryHandler(cliCtx, QueryKYC)).Methods("GET")
+	r.HandleFunc("/attestation/trust-chain/{did}", queryHandler(cliCtx, QueryTrustChain)).Methods("GET")
+	r.HandleFunc("/attestation/trust-chain/{did}/{role}", queryHandler(cliCtx, QueryTrustChain, "role")).Methods("GET")
+}
+
+// queryHandler serves the legacy query with the route's did and the given
+// further route variables as its path.
+func queryHandler(cliCtx client.Context, query string, vars ...string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		path := fmt.Sprintf("custom/%s/%s/%s", ModuleName, query, mux.Vars(r)["did"])
+		for _, v := range vars {
+			path += "/" + mux.Vars(r)[v]
+		}
+		res, _, err := cliCtx.QueryWithData(path, nil)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(res)
+	}
+}
@@ -0,0 +1,55 @@
+package attestation
+
+import "fmt"
+
+// Accreditation attestation types. A trust anchor, one of the TrustAnchors
+// parameter, or an accredited accreditor posts AccreditorAttestationType to
+// accredit another accreditor and IssuerAttestationType to accredit an issuer,
+// forming chains from a root anchor down to each issuer. Accreditations lapse
+// with their expiry and are withdrawn by revoking them like any attestation.
+const (
+	AccreditorAttestationType = "accredited-accreditor"
+	IssuerAttestationType     = "accredited-issuer"
+)
+
+// Trust chain roles, the role a chain is validated for.
+const (
+	RoleAccreditor = "accreditor"
+	RoleIssuer     = "issuer"
+)
+
+// MaxTrustChainDepth bounds the number of accreditations between a root trust
+// anchor and the DID a chain is validated for.
+const MaxTrustChainDepth = 8
+
+// accreditationType returns the attestation type accrediting role.
+func accreditationType(role string) (string, error) {
+	switch role {
+	case RoleAccreditor:
+		return AccreditorAttestationType, nil
+	case RoleIssuer:
+		return IssuerAttestationType, nil
+	default:
+		return "", fmt.Errorf("role must be %s or %s", RoleAccreditor, RoleIssuer)
+	}
+}
+
+// isAccreditation reports whether typ is an accreditation attestation type.
+func isAccreditation(typ string) bool {
+	return typ == AccreditorAttestationType || typ == IssuerAttestationType
+}
+
+// TrustChain is the result of validating DID for Role: Links lists the
+// accreditations from the root trust anchor down to DID, and ExpiresAt is the
+// earliest expiry among them (0 if none expires). When Valid is false, Error
+// tells why no chain holds. A trust anchor is valid for every role with an
+// empty chain.
+type TrustChain struct {
+	DID       string        `json:"did"`
+	Role      string        `json:"role"`
+	Valid     bool          `json:"valid"`
+	Root      string        `json:"root,omitempty"`
+	Links     []Attestation `json:"links"`
+	ExpiresAt int64         `json:"expires_at,omitempty"`
+	Error     string        `json:"error,omitempty"`
+}
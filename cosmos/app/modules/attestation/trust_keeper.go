@@ -0,0 +1,101 @@
+package attestation
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ValidateTrustChain looks for a chain of active accreditations from a
+// current, active trust anchor down to did in role, each accrediting DID an
+// accredited accreditor that is not deactivated. Revoked accreditations no
+// longer exist, so they break the chains through them.
+func (k Keeper) ValidateTrustChain(ctx sdk.Context, did, role string) TrustChain {
+	chain := TrustChain{DID: did, Role: role, Links: []Attestation{}}
+	typ, err := accreditationType(role)
+	if err != nil {
+		chain.Error = err.Error()
+		return chain
+	}
+	if err := k.checkActiveDID(ctx, did); err != nil {
+		chain.Error = err.Error()
+		return chain
+	}
+	params := k.GetParams(ctx)
+	if params.IsTrustAnchor(did) {
+		chain.Valid, chain.Root = true, did
+		return chain
+	}
+	links, root, err := k.trustPath(ctx, params, did, typ, map[string]bool{did: true})
+	if err != nil {
+		chain.Error = err.Error()
+		return chain
+	}
+	chain.Valid, chain.Root, chain.Links = true, root, links
+	for _, link := range links {
+		if link.ExpiresAt != 0 && (chain.ExpiresAt == 0 || link.ExpiresAt < chain.ExpiresAt) {
+			chain.ExpiresAt = link.ExpiresAt
+		}
+	}
+	return chain
+}
+
+// trustPath returns the accreditations, root first, of the first valid path
+// from a trust anchor to subject's accreditation of type typ.
+func (k Keeper) trustPath(ctx sdk.Context, params Params, subject, typ string, visited map[string]bool) ([]Attestation, string, error) {
+	if len(visited) > MaxTrustChainDepth {
+		return nil, "", fmt.Errorf("no trust chain of at most %d accreditations reaches %s", MaxTrustChainDepth, subject)
+	}
+	accreditations := k.AttestationsBySubject(ctx, subject, typ)
+	if len(accreditations) == 0 {
+		return nil, "", fmt.Errorf("%s holds no active %s attestation", subject, typ)
+	}
+	var lastErr error
+	for _, a := range accreditations {
+		if visited[a.Issuer] {
+			continue
+		}
+		if err := k.checkActiveDID(ctx, a.Issuer); err != nil {
+			lastErr = err
+			continue
+		}
+		if params.IsTrustAnchor(a.Issuer) {
+			return []Attestation{a}, a.Issuer, nil
+		}
+		visited[a.Issuer] = true
+		links, root, err := k.trustPath(ctx, params, a.Issuer, AccreditorAttestationType, visited)
+		delete(visited, a.Issuer)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return append(links, a), root, nil
+	}
+	if lastErr == nil {
+		lastErr = fmt.Errorf("the accreditations of %s form a cycle", subject)
+	}
+	return nil, "", lastErr
+}
+
+// checkAccreditor returns an error unless issuer may post accreditations: a
+// trust anchor, or an accreditor with a valid trust chain.
+func (k Keeper) checkAccreditor(ctx sdk.Context, issuer string) error {
+	if k.GetParams(ctx).IsTrustAnchor(issuer) {
+		return nil
+	}
+	if chain := k.ValidateTrustChain(ctx, issuer, RoleAccreditor); !chain.Valid {
+		return fmt.Errorf("%s may not accredit: %s", issuer, chain.Error)
+	}
+	return nil
+}
+
+func (k Keeper) checkActiveDID(ctx sdk.Context, id string) error {
+	did, err := k.didKeeper.GetDID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if did.Deactivated {
+		return fmt.Errorf("DID %s is deactivated", id)
+	}
+	return nil
+}
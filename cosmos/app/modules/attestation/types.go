@@ -0,0 +1,256 @@
+package attestation
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	didmodule "cosmos-app/modules/did"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// Module identifiers for the attestation module.
+const (
+	ModuleName = "attestation"
+	StoreKey   = ModuleName
+	RouterKey  = ModuleName
+
+	// MaxClaims bounds the claims of an attestation, which are stored on
+	// chain in full.
+	MaxClaims = 32
+
+	maxTypeLength       = 64
+	maxClaimKeyLength   = 64
+	maxClaimValueLength = 512
+)
+
+// Claim is a key-value pair an attestation asserts about its subject.
+type Claim struct {
+	Key   string `protobuf:"bytes,1,opt,name=key,proto3" json:"key"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value"`
+}
+
+// Attestation is a typed statement the Issuer DID publishes about the
+// Subject DID, e.g. an endorsement, without issuing a full verifiable
+// credential. Schema optionally references the schema its claims follow. An
+// issuer holds at most one attestation of each type about a subject: attesting
+// again replaces it. It stops counting once the block time reaches ExpiresAt
+// (unix seconds), if set.
+type Attestation struct {
+	Issuer    string  `protobuf:"bytes,1,opt,name=issuer,proto3" json:"issuer"`
+	Subject   string  `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject"`
+	Type      string  `protobuf:"bytes,3,opt,name=type,proto3" json:"type"`
+	Claims    []Claim `protobuf:"bytes,4,rep,name=claims,proto3" json:"claims"`
+	Schema    string  `protobuf:"bytes,5,opt,name=schema,proto3" json:"schema,omitempty"`
+	ExpiresAt int64   `protobuf:"varint,6,opt,name=expires_at,proto3" json:"expires_at,omitempty"`
+	Created   int64   `protobuf:"varint,7,opt,name=created,proto3" json:"created"`
+}
+
+// Active reports whether the attestation is in force at block time t.
+func (a Attestation) Active(t time.Time) bool {
+	return a.ExpiresAt == 0 || t.Unix() < a.ExpiresAt
+}
+
+// Validate checks the fields of an attestation.
+func (a Attestation) Validate() error {
+	if err := validateDID(a.Issuer); err != nil {
+		return fmt.Errorf("issuer: %w", err)
+	}
+	if err := validateDID(a.Subject); err != nil {
+		return fmt.Errorf("subject: %w", err)
+	}
+	if a.Issuer == a.Subject {
+		return fmt.Errorf("a DID cannot attest about itself")
+	}
+	if err := ValidateType(a.Type); err != nil {
+		return err
+	}
+	if err := validateClaims(a.Claims); err != nil {
+		return err
+	}
+	if a.Schema != "" && !strings.Contains(a.Schema, ":") {
+		return fmt.Errorf("schema %q must be a URI", a.Schema)
+	}
+	if a.ExpiresAt < 0 {
+		return fmt.Errorf("expiry cannot be negative")
+	}
+	if a.Type == KYCAttestationType {
+		if _, err := kycStatus(a); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateType checks that an attestation type is a short identifier such as
+// kyc-basic or org.example.membership.
+func ValidateType(typ string) error {
+	if typ == "" || len(typ) > maxTypeLength {
+		return fmt.Errorf("attestation type must have between 1 and %d characters", maxTypeLength)
+	}
+	for _, r := range typ {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+		default:
+			return fmt.Errorf("attestation type %q may only contain letters, digits, '-', '_' and '.'", typ)
+		}
+	}
+	return nil
+}
+
+func validateClaims(claims []Claim) error {
+	if len(claims) > MaxClaims {
+		return fmt.Errorf("an attestation holds at most %d claims", MaxClaims)
+	}
+	seen := make(map[string]bool, len(claims))
+	for _, c := range claims {
+		if c.Key == "" || len(c.Key) > maxClaimKeyLength {
+			return fmt.Errorf("claim keys must have between 1 and %d characters", maxClaimKeyLength)
+		}
+		if len(c.Value) > maxClaimValueLength {
+			return fmt.Errorf("claim %s: values hold at most %d characters", c.Key, maxClaimValueLength)
+		}
+		if seen[c.Key] {
+			return fmt.Errorf("duplicate claim %s", c.Key)
+		}
+		seen[c.Key] = true
+	}
+	return nil
+}
+
+// validateDID checks that id is a DID of this chain. DIDs are part of store
+// keys, so they can't contain '/'.
+func validateDID(id string) error {
+	if !strings.HasPrefix(id, didmodule.DIDMethodPrefix) || id == didmodule.DIDMethodPrefix {
+		return fmt.Errorf("DID must start with %s", didmodule.DIDMethodPrefix)
+	}
+	if strings.Contains(id, "/") {
+		return fmt.Errorf("DID cannot contain '/'")
+	}
+	return didmodule.ValidateChecksum(id)
+}
+
+// proofSignBytes returns the sorted JSON of a message with its controller
+// signature cleared, which the issuer's controller signs.
+func proofSignBytes(msg interface{}) []byte {
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// MsgAttest publishes an attestation by Issuer about Subject, replacing the
+// issuer's earlier attestation of the same type about the subject. Signer
+// proves control of the issuer DID as for a DID document change.
+type MsgAttest struct {
+	Issuer          string         `protobuf:"bytes,1,opt,name=issuer,proto3" json:"issuer"`
+	Subject         string         `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject"`
+	AttestationType string         `protobuf:"bytes,3,opt,name=attestation_type,proto3" json:"attestation_type"`
+	Claims          []Claim        `protobuf:"bytes,4,rep,name=claims,proto3" json:"claims"`
+	Schema          string         `protobuf:"bytes,5,opt,name=schema,proto3" json:"schema,omitempty"`
+	ExpiresAt       int64          `protobuf:"varint,6,opt,name=expires_at,proto3" json:"expires_at,omitempty"`
+	Nonce           uint64         `protobuf:"varint,7,opt,name=nonce,proto3" json:"nonce"`
+	Signer          string         `protobuf:"bytes,8,opt,name=signer,proto3" json:"signer"`
+	Signature       []byte         `protobuf:"bytes,9,opt,name=signature,proto3" json:"signature,omitempty"`
+	Creator         sdk.AccAddress `protobuf:"bytes,10,opt,name=creator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"creator"`
+}
+
+// Attestation returns the attestation the message publishes.
+func (msg MsgAttest) Attestation() Attestation {
+	return Attestation{
+		Issuer:    msg.Issuer,
+		Subject:   msg.Subject,
+		Type:      msg.AttestationType,
+		Claims:    msg.Claims,
+		Schema:    msg.Schema,
+		ExpiresAt: msg.ExpiresAt,
+	}
+}
+
+// ValidateBasic performs basic validation of MsgAttest.
+func (msg MsgAttest) ValidateBasic() error {
+	if err := msg.Attestation().Validate(); err != nil {
+		return sdkerrors.ErrUnknownRequest.Wrap(err.Error())
+	}
+	if msg.Signer == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Signer cannot be empty")
+	}
+	if msg.Creator.Empty() {
+		return sdkerrors.ErrUnknownRequest.Wrap("Creator cannot be empty")
+	}
+	return nil
+}
+
+// ProofSignBytes returns the bytes the issuer's controller signs.
+func (msg MsgAttest) ProofSignBytes() []byte {
+	msg.Signature = nil
+	return proofSignBytes(msg)
+}
+
+// Route returns the message route.
+func (msg MsgAttest) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgAttest) Type() string { return "attest" }
+
+// GetSignBytes returns the canonical bytes to sign over.
+func (msg MsgAttest) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners returns the account that must sign the message.
+func (msg MsgAttest) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
+
+// MsgRevokeAttestation withdraws the issuer's attestation of a type about a
+// subject. Authorization works as for MsgAttest.
+type MsgRevokeAttestation struct {
+	Issuer          string         `protobuf:"bytes,1,opt,name=issuer,proto3" json:"issuer"`
+	Subject         string         `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject"`
+	AttestationType string         `protobuf:"bytes,3,opt,name=attestation_type,proto3" json:"attestation_type"`
+	Nonce           uint64         `protobuf:"varint,4,opt,name=nonce,proto3" json:"nonce"`
+	Signer          string         `protobuf:"bytes,5,opt,name=signer,proto3" json:"signer"`
+	Signature       []byte         `protobuf:"bytes,6,opt,name=signature,proto3" json:"signature,omitempty"`
+	Creator         sdk.AccAddress `protobuf:"bytes,7,opt,name=creator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"creator"`
+}
+
+// ValidateBasic performs basic validation of MsgRevokeAttestation.
+func (msg MsgRevokeAttestation) ValidateBasic() error {
+	if msg.Issuer == "" || msg.Subject == "" || msg.AttestationType == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Issuer, subject and type cannot be empty")
+	}
+	if msg.Signer == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Signer cannot be empty")
+	}
+	if msg.Creator.Empty() {
+		return sdkerrors.ErrUnknownRequest.Wrap("Creator cannot be empty")
+	}
+	return nil
+}
+
+// ProofSignBytes returns the bytes the issuer's controller signs.
+func (msg MsgRevokeAttestation) ProofSignBytes() []byte {
+	msg.Signature = nil
+	return proofSignBytes(msg)
+}
+
+// Route returns the message route.
+func (msg MsgRevokeAttestation) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgRevokeAttestation) Type() string { return "revoke_attestation" }
+
+// GetSignBytes returns the canonical bytes to sign over.
+func (msg MsgRevokeAttestation) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners returns the account that must sign the message.
+func (msg MsgRevokeAttestation) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
@@ -0,0 +1,206 @@
+package consent
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// States of a data-sharing agreement. An agreement is proposed by one DID,
+// accepted by the other and terminated by either; a proposal can also be
+// terminated before it is accepted, which withdraws or declines it.
+const (
+	AgreementProposed   = "proposed"
+	AgreementAccepted   = "accepted"
+	AgreementTerminated = "terminated"
+)
+
+// Agreement anchors the SHA-256 hash of a data-sharing agreement between two
+// DIDs. The document stays off chain; once Counterparty has accepted the
+// hash Proposer signed, both parties have co-signed it. Times are Unix
+// seconds.
+type Agreement struct {
+	ID            uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id"`
+	Proposer      string `protobuf:"bytes,2,opt,name=proposer,proto3" json:"proposer"`
+	Counterparty  string `protobuf:"bytes,3,opt,name=counterparty,proto3" json:"counterparty"`
+	AgreementHash string `protobuf:"bytes,4,opt,name=agreement_hash,proto3" json:"agreement_hash"`
+	State         string `protobuf:"bytes,5,opt,name=state,proto3" json:"state"`
+	Proposed      int64  `protobuf:"varint,6,opt,name=proposed,proto3" json:"proposed"`
+	Accepted      int64  `protobuf:"varint,7,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Terminated    int64  `protobuf:"varint,8,opt,name=terminated,proto3" json:"terminated,omitempty"`
+	TerminatedBy  string `protobuf:"bytes,9,opt,name=terminated_by,proto3" json:"terminated_by,omitempty"`
+}
+
+// Validate checks the fields of an agreement other than its ID and times.
+func (a Agreement) Validate() error {
+	if err := validateDID(a.Proposer); err != nil {
+		return fmt.Errorf("proposer: %w", err)
+	}
+	if err := validateDID(a.Counterparty); err != nil {
+		return fmt.Errorf("counterparty: %w", err)
+	}
+	if a.Proposer == a.Counterparty {
+		return fmt.Errorf("a DID cannot make an agreement with itself")
+	}
+	if err := ValidatePurposeHash(a.AgreementHash); err != nil {
+		return fmt.Errorf("agreement hash must be a lowercase hex SHA-256 hash")
+	}
+	switch a.State {
+	case AgreementProposed, AgreementAccepted, AgreementTerminated:
+	default:
+		return fmt.Errorf("unknown agreement state %q", a.State)
+	}
+	return nil
+}
+
+// HasParty reports whether did is the proposer or counterparty.
+func (a Agreement) HasParty(did string) bool {
+	return did == a.Proposer || did == a.Counterparty
+}
+
+// MsgProposeAgreement proposes a data-sharing agreement with the hash
+// AgreementHash to Counterparty. Signer proves control of the proposer DID
+// as for a DID document change.
+type MsgProposeAgreement struct {
+	Proposer      string         `protobuf:"bytes,1,opt,name=proposer,proto3" json:"proposer"`
+	Counterparty  string         `protobuf:"bytes,2,opt,name=counterparty,proto3" json:"counterparty"`
+	AgreementHash string         `protobuf:"bytes,3,opt,name=agreement_hash,proto3" json:"agreement_hash"`
+	Nonce         uint64         `protobuf:"varint,4,opt,name=nonce,proto3" json:"nonce"`
+	Signer        string         `protobuf:"bytes,5,opt,name=signer,proto3" json:"signer"`
+	Signature     []byte         `protobuf:"bytes,6,opt,name=signature,proto3" json:"signature,omitempty"`
+	Creator       sdk.AccAddress `protobuf:"bytes,7,opt,name=creator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"creator"`
+}
+
+// ValidateBasic performs basic validation of MsgProposeAgreement.
+func (msg MsgProposeAgreement) ValidateBasic() error {
+	a := Agreement{Proposer: msg.Proposer, Counterparty: msg.Counterparty, AgreementHash: msg.AgreementHash, State: AgreementProposed}
+	if err := a.Validate(); err != nil {
+		return sdkerrors.ErrUnknownRequest.Wrap(err.Error())
+	}
+	if msg.Signer == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Signer cannot be empty")
+	}
+	if msg.Creator.Empty() {
+		return sdkerrors.ErrUnknownRequest.Wrap("Creator cannot be empty")
+	}
+	return nil
+}
+
+// ProofSignBytes returns the bytes the proposer's controller signs.
+func (msg MsgProposeAgreement) ProofSignBytes() []byte {
+	msg.Signature = nil
+	return proofSignBytes(msg)
+}
+
+// Route returns the message route.
+func (msg MsgProposeAgreement) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgProposeAgreement) Type() string { return "propose_agreement" }
+
+// GetSignBytes returns the canonical bytes to sign over.
+func (msg MsgProposeAgreement) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners returns the account that must sign the message.
+func (msg MsgProposeAgreement) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
+
+// MsgAcceptAgreement accepts a proposed agreement. Signer proves control of
+// its counterparty DID, and AgreementHash must repeat the proposed hash, so
+// the counterparty signs the same document the proposer did.
+type MsgAcceptAgreement struct {
+	ID            uint64         `protobuf:"varint,1,opt,name=id,proto3" json:"id"`
+	AgreementHash string         `protobuf:"bytes,2,opt,name=agreement_hash,proto3" json:"agreement_hash"`
+	Nonce         uint64         `protobuf:"varint,3,opt,name=nonce,proto3" json:"nonce"`
+	Signer        string         `protobuf:"bytes,4,opt,name=signer,proto3" json:"signer"`
+	Signature     []byte         `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
+	Creator       sdk.AccAddress `protobuf:"bytes,6,opt,name=creator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"creator"`
+}
+
+// ValidateBasic performs basic validation of MsgAcceptAgreement.
+func (msg MsgAcceptAgreement) ValidateBasic() error {
+	if err := ValidatePurposeHash(msg.AgreementHash); err != nil {
+		return sdkerrors.ErrUnknownRequest.Wrap("agreement hash must be a lowercase hex SHA-256 hash")
+	}
+	if msg.Signer == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Signer cannot be empty")
+	}
+	if msg.Creator.Empty() {
+		return sdkerrors.ErrUnknownRequest.Wrap("Creator cannot be empty")
+	}
+	return nil
+}
+
+// ProofSignBytes returns the bytes the counterparty's controller signs.
+func (msg MsgAcceptAgreement) ProofSignBytes() []byte {
+	msg.Signature = nil
+	return proofSignBytes(msg)
+}
+
+// Route returns the message route.
+func (msg MsgAcceptAgreement) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgAcceptAgreement) Type() string { return "accept_agreement" }
+
+// GetSignBytes returns the canonical bytes to sign over.
+func (msg MsgAcceptAgreement) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners returns the account that must sign the message.
+func (msg MsgAcceptAgreement) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
+
+// MsgTerminateAgreement ends an agreement, or withdraws or declines it while
+// it is only proposed. Either party may terminate it: Party names which, and
+// Signer proves control of that DID.
+type MsgTerminateAgreement struct {
+	ID        uint64         `protobuf:"varint,1,opt,name=id,proto3" json:"id"`
+	Party     string         `protobuf:"bytes,2,opt,name=party,proto3" json:"party"`
+	Nonce     uint64         `protobuf:"varint,3,opt,name=nonce,proto3" json:"nonce"`
+	Signer    string         `protobuf:"bytes,4,opt,name=signer,proto3" json:"signer"`
+	Signature []byte         `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
+	Creator   sdk.AccAddress `protobuf:"bytes,6,opt,name=creator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"creator"`
+}
+
+// ValidateBasic performs basic validation of MsgTerminateAgreement.
+func (msg MsgTerminateAgreement) ValidateBasic() error {
+	if err := validateDID(msg.Party); err != nil {
+		return sdkerrors.ErrUnknownRequest.Wrap(err.Error())
+	}
+	if msg.Signer == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Signer cannot be empty")
+	}
+	if msg.Creator.Empty() {
+		return sdkerrors.ErrUnknownRequest.Wrap("Creator cannot be empty")
+	}
+	return nil
+}
+
+// ProofSignBytes returns the bytes the terminating party's controller signs.
+func (msg MsgTerminateAgreement) ProofSignBytes() []byte {
+	msg.Signature = nil
+	return proofSignBytes(msg)
+}
+
+// Route returns the message route.
+func (msg MsgTerminateAgreement) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgTerminateAgreement) Type() string { return "terminate_agreement" }
+
+// GetSignBytes returns the canonical bytes to sign over.
+func (msg MsgTerminateAgreement) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners returns the account that must sign the message.
+func (msg MsgTerminateAgreement) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
@@ -0,0 +1,149 @@
+package consent
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Store prefixes for agreements. Agreements are stored by ID under
+// AgreementPrefix and indexed under PartyIndexPrefix by both parties;
+// NextAgreementIDKey holds the ID of the next agreement.
+var (
+	AgreementPrefix    = []byte("agreement/")
+	PartyIndexPrefix   = []byte("party/")
+	NextAgreementIDKey = []byte("next-agreement-id")
+)
+
+func agreementKey(id uint64) []byte {
+	return append(append([]byte{}, AgreementPrefix...), sdk.Uint64ToBigEndian(id)...)
+}
+
+// ProposeAgreement stores a new proposal between two active DIDs and returns
+// its ID. Callers must have authorized the proposer.
+func (k Keeper) ProposeAgreement(ctx sdk.Context, proposer, counterparty, agreementHash string) (uint64, error) {
+	a := Agreement{
+		Proposer:      proposer,
+		Counterparty:  counterparty,
+		AgreementHash: agreementHash,
+		State:         AgreementProposed,
+	}
+	if err := a.Validate(); err != nil {
+		return 0, err
+	}
+	for _, did := range []string{proposer, counterparty} {
+		doc, err := k.didKeeper.GetDID(ctx, did)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", did, err)
+		}
+		if doc.Deactivated {
+			return 0, fmt.Errorf("DID %s is deactivated", did)
+		}
+	}
+	a.ID = k.GetNextAgreementID(ctx)
+	a.Proposed = ctx.BlockTime().Unix()
+	k.setAgreement(ctx, a)
+	k.setNextAgreementID(ctx, a.ID+1)
+	return a.ID, nil
+}
+
+// AcceptAgreement moves a proposed agreement to accepted. agreementHash must
+// be the proposed hash. Callers must have authorized the counterparty.
+func (k Keeper) AcceptAgreement(ctx sdk.Context, id uint64, agreementHash string) (Agreement, error) {
+	a, err := k.GetAgreement(ctx, id)
+	if err != nil {
+		return Agreement{}, err
+	}
+	if a.State != AgreementProposed {
+		return Agreement{}, fmt.Errorf("agreement %d is %s, not %s", id, a.State, AgreementProposed)
+	}
+	if a.AgreementHash != agreementHash {
+		return Agreement{}, fmt.Errorf("agreement %d was proposed with hash %s", id, a.AgreementHash)
+	}
+	a.State = AgreementAccepted
+	a.Accepted = ctx.BlockTime().Unix()
+	k.setAgreement(ctx, a)
+	return a, nil
+}
+
+// TerminateAgreement ends an agreement that isn't terminated yet on behalf
+// of party, which must be one of its parties. Callers must have authorized
+// party.
+func (k Keeper) TerminateAgreement(ctx sdk.Context, id uint64, party string) (Agreement, error) {
+	a, err := k.GetAgreement(ctx, id)
+	if err != nil {
+		return Agreement{}, err
+	}
+	if !a.HasParty(party) {
+		return Agreement{}, fmt.Errorf("%s is not a party to agreement %d", party, id)
+	}
+	if a.State == AgreementTerminated {
+		return Agreement{}, fmt.Errorf("agreement %d is already terminated", id)
+	}
+	a.State = AgreementTerminated
+	a.Terminated = ctx.BlockTime().Unix()
+	a.TerminatedBy = party
+	k.setAgreement(ctx, a)
+	return a, nil
+}
+
+// GetAgreement returns an agreement in any state.
+func (k Keeper) GetAgreement(ctx sdk.Context, id uint64) (Agreement, error) {
+	value := ctx.KVStore(k.storeKey).Get(agreementKey(id))
+	if value == nil {
+		return Agreement{}, fmt.Errorf("agreement %d not found", id)
+	}
+	var a Agreement
+	k.cdc.MustUnmarshal(value, &a)
+	return a, nil
+}
+
+// AgreementsByParty returns the agreements a DID has proposed or been
+// offered, in any state, in ID order.
+func (k Keeper) AgreementsByParty(ctx sdk.Context, did string) []Agreement {
+	var agreements []Agreement
+	p := indexPrefix(PartyIndexPrefix, did)
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), p)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		if a, err := k.GetAgreement(ctx, sdk.BigEndianToUint64(iter.Key()[len(p):])); err == nil {
+			agreements = append(agreements, a)
+		}
+	}
+	return agreements
+}
+
+// GetAllAgreements returns every agreement in the store.
+func (k Keeper) GetAllAgreements(ctx sdk.Context) []Agreement {
+	var agreements []Agreement
+	iter := prefix.NewStore(ctx.KVStore(k.storeKey), AgreementPrefix).Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var a Agreement
+		k.cdc.MustUnmarshal(iter.Value(), &a)
+		agreements = append(agreements, a)
+	}
+	return agreements
+}
+
+// GetNextAgreementID returns the ID the next agreement will get. IDs start
+// at 1.
+func (k Keeper) GetNextAgreementID(ctx sdk.Context) uint64 {
+	bz := ctx.KVStore(k.storeKey).Get(NextAgreementIDKey)
+	if bz == nil {
+		return 1
+	}
+	return sdk.BigEndianToUint64(bz)
+}
+
+func (k Keeper) setNextAgreementID(ctx sdk.Context, id uint64) {
+	ctx.KVStore(k.storeKey).Set(NextAgreementIDKey, sdk.Uint64ToBigEndian(id))
+}
+
+func (k Keeper) setAgreement(ctx sdk.Context, a Agreement) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(agreementKey(a.ID), k.cdc.MustMarshal(&a))
+	store.Set(indexKey(PartyIndexPrefix, a.Proposer, a.ID), []byte{})
+	store.Set(indexKey(PartyIndexPrefix, a.Counterparty, a.ID), []byte{})
+}
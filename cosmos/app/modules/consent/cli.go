@@ -0,0 +1,404 @@
+package consent
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	didmodule "cosmos-app/modules/did"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/spf13/cobra"
+)
+
+// Flags for the consent registry's CLI commands.
+const (
+	FlagPurposeFile   = "purpose-file"
+	FlagPurposeHash   = "purpose-hash"
+	FlagAgreementFile = "agreement-file"
+	FlagAgreementHash = "agreement-hash"
+)
+
+// GetTxCmd returns the root tx command for the consent registry.
+func GetTxCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        ModuleName,
+		Short:                      "Consent receipt transaction subcommands",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+	cmd.AddCommand(
+		CmdGrantConsent(),
+		CmdRevokeConsent(),
+		CmdProposeAgreement(),
+		CmdAcceptAgreement(),
+		CmdTerminateAgreement(),
+	)
+	return cmd
+}
+
+// GetQueryCmd returns the root query command for the consent registry.
+func GetQueryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        ModuleName,
+		Short:                      "Querying commands for consent receipts",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+	cmd.AddCommand(
+		CmdQueryReceipt(),
+		CmdReceiptsBySubject(),
+		CmdReceiptsByController(),
+		CmdQueryAgreement(),
+		CmdAgreementsByParty(),
+	)
+	return cmd
+}
+
+// CmdGrantConsent returns the command to record a consent.
+func CmdGrantConsent() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "grant [subject-did] [controller-did]",
+		Short: "Record a data subject's consent to a data controller's processing",
+		Long: `Record that the subject DID consents to the controller DID processing its data
+for a purpose. The purpose statement, e.g. a privacy notice, stays off chain:
+--purpose-file hashes it, or --purpose-hash gives its hex SHA-256 hash.
+--expires-at sets the Unix time at which the consent lapses. A controller of
+the subject DID authorizes the consent as for a DID update: an account
+controller by signing the transaction, a DID controller with --key or
+--signature.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			purposeHash, err := readHash(cmd, FlagPurposeFile, FlagPurposeHash)
+			if err != nil {
+				return err
+			}
+			signer, nonce, err := didmodule.ControllerProof(cmd, clientCtx, args[0])
+			if err != nil {
+				return err
+			}
+			expiresAt, _ := cmd.Flags().GetInt64(didmodule.FlagExpiresAt)
+			msg := &MsgGrantConsent{
+				Subject:     args[0],
+				Controller:  args[1],
+				PurposeHash: purposeHash,
+				ExpiresAt:   expiresAt,
+				Nonce:       nonce,
+				Signer:      signer,
+				Creator:     clientCtx.GetFromAddress(),
+			}
+			if msg.Signature, err = didmodule.ProofSignature(cmd, clientCtx, msg.ProofSignBytes()); err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+	cmd.Flags().String(FlagPurposeFile, "", "File holding the purpose statement consented to")
+	cmd.Flags().String(FlagPurposeHash, "", "Hex SHA-256 hash of the purpose statement consented to")
+	cmd.Flags().Int64(didmodule.FlagExpiresAt, 0, "Unix time at which the consent lapses")
+	didmodule.AddControllerProofFlags(cmd)
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// readHash returns the hash given with hashFlag or the hash of the file named
+// with fileFlag.
+func readHash(cmd *cobra.Command, fileFlag, hashFlag string) (string, error) {
+	path, _ := cmd.Flags().GetString(fileFlag)
+	hash, _ := cmd.Flags().GetString(hashFlag)
+	switch {
+	case path != "" && hash != "":
+		return "", fmt.Errorf("--%s and --%s are mutually exclusive", fileFlag, hashFlag)
+	case path != "":
+		bz, err := os.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		return PurposeHash(bz), nil
+	case hash != "":
+		return hash, nil
+	default:
+		return "", fmt.Errorf("one of --%s and --%s is required", fileFlag, hashFlag)
+	}
+}
+
+// CmdRevokeConsent returns the command to withdraw a consent.
+func CmdRevokeConsent() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "revoke [consent-id] [subject-did]",
+		Short: "Withdraw a consent as its subject DID, authorized as for grant",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			id, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+			signer, nonce, err := didmodule.ControllerProof(cmd, clientCtx, args[1])
+			if err != nil {
+				return err
+			}
+			msg := &MsgRevokeConsent{
+				ID:      id,
+				Subject: args[1],
+				Nonce:   nonce,
+				Signer:  signer,
+				Creator: clientCtx.GetFromAddress(),
+			}
+			if msg.Signature, err = didmodule.ProofSignature(cmd, clientCtx, msg.ProofSignBytes()); err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+	didmodule.AddControllerProofFlags(cmd)
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdProposeAgreement returns the command to propose a data-sharing
+// agreement.
+func CmdProposeAgreement() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "propose-agreement [proposer-did] [counterparty-did]",
+		Short: "Propose a data-sharing agreement to another DID",
+		Long: `Anchor the hash of a data-sharing agreement proposed by the proposer DID to the
+counterparty DID. The agreement document stays off chain: --agreement-file
+hashes it, or --agreement-hash gives its hex SHA-256 hash. A controller of
+the proposer DID authorizes the proposal as for a DID update: an account
+controller by signing the transaction, a DID controller with --key or
+--signature.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			agreementHash, err := readHash(cmd, FlagAgreementFile, FlagAgreementHash)
+			if err != nil {
+				return err
+			}
+			signer, nonce, err := didmodule.ControllerProof(cmd, clientCtx, args[0])
+			if err != nil {
+				return err
+			}
+			msg := &MsgProposeAgreement{
+				Proposer:      args[0],
+				Counterparty:  args[1],
+				AgreementHash: agreementHash,
+				Nonce:         nonce,
+				Signer:        signer,
+				Creator:       clientCtx.GetFromAddress(),
+			}
+			if msg.Signature, err = didmodule.ProofSignature(cmd, clientCtx, msg.ProofSignBytes()); err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+	addAgreementFlags(cmd)
+	didmodule.AddControllerProofFlags(cmd)
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdAcceptAgreement returns the command to accept a proposed agreement.
+func CmdAcceptAgreement() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "accept-agreement [agreement-id] [counterparty-did]",
+		Short: "Accept a data-sharing agreement offered to a DID",
+		Long: `Accept a proposed data-sharing agreement as its counterparty DID, authorized as
+for propose-agreement. The counterparty hashes its own copy of the agreement
+with --agreement-file, or gives the hash with --agreement-hash; acceptance
+fails unless it matches the proposed hash.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			id, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+			agreementHash, err := readHash(cmd, FlagAgreementFile, FlagAgreementHash)
+			if err != nil {
+				return err
+			}
+			signer, nonce, err := didmodule.ControllerProof(cmd, clientCtx, args[1])
+			if err != nil {
+				return err
+			}
+			msg := &MsgAcceptAgreement{
+				ID:            id,
+				AgreementHash: agreementHash,
+				Nonce:         nonce,
+				Signer:        signer,
+				Creator:       clientCtx.GetFromAddress(),
+			}
+			if msg.Signature, err = didmodule.ProofSignature(cmd, clientCtx, msg.ProofSignBytes()); err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+	addAgreementFlags(cmd)
+	didmodule.AddControllerProofFlags(cmd)
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdTerminateAgreement returns the command to terminate an agreement.
+func CmdTerminateAgreement() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "terminate-agreement [agreement-id] [party-did]",
+		Short: "Terminate, withdraw or decline an agreement as either party, authorized as for propose-agreement",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			id, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+			signer, nonce, err := didmodule.ControllerProof(cmd, clientCtx, args[1])
+			if err != nil {
+				return err
+			}
+			msg := &MsgTerminateAgreement{
+				ID:      id,
+				Party:   args[1],
+				Nonce:   nonce,
+				Signer:  signer,
+				Creator: clientCtx.GetFromAddress(),
+			}
+			if msg.Signature, err = didmodule.ProofSignature(cmd, clientCtx, msg.ProofSignBytes()); err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+	didmodule.AddControllerProofFlags(cmd)
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+func addAgreementFlags(cmd *cobra.Command) {
+	cmd.Flags().String(FlagAgreementFile, "", "File holding the data-sharing agreement")
+	cmd.Flags().String(FlagAgreementHash, "", "Hex SHA-256 hash of the data-sharing agreement")
+}
+
+// CmdQueryReceipt returns the command to query a consent receipt.
+func CmdQueryReceipt() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "receipt [consent-id]",
+		Short: "Query a consent receipt and whether the consent is in force",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return printQuery(cmd, QueryReceipt, args[0])
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdReceiptsBySubject returns the command to list the consents a DID gave.
+func CmdReceiptsBySubject() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "subject [subject-did]",
+		Short: "List the consents a data subject DID has given",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return printQuery(cmd, QueryBySubject, args[0])
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdReceiptsByController returns the command to list the consents given to
+// a DID.
+func CmdReceiptsByController() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "controller [controller-did]",
+		Short: "List the consents given to a data controller DID",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return printQuery(cmd, QueryByController, args[0])
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdQueryAgreement returns the command to query a data-sharing agreement.
+func CmdQueryAgreement() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "agreement [agreement-id]",
+		Short: "Query a data-sharing agreement and its state",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return printQuery(cmd, QueryAgreement, args[0])
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdAgreementsByParty returns the command to list the agreements of a DID.
+func CmdAgreementsByParty() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "agreements [did]",
+		Short: "List the data-sharing agreements a DID is a party to",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return printQuery(cmd, QueryAgreementsByParty, args[0])
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func printQuery(cmd *cobra.Command, path ...string) error {
+	clientCtx, err := client.GetClientQueryContext(cmd)
+	if err != nil {
+		return err
+	}
+	route := "custom/" + ModuleName
+	for _, p := range path {
+		route += "/" + p
+	}
+	res, _, err := clientCtx.QueryWithData(route, nil)
+	if err != nil {
+		return err
+	}
+	return clientCtx.PrintBytes(res)
+}
@@ -0,0 +1,87 @@
+package consent
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	cdctypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/gogo/protobuf/proto"
+)
+
+var (
+	amino = codec.NewLegacyAmino()
+
+	// ModuleCdc is the amino codec used for the consent registry's legacy
+	// sign bytes.
+	ModuleCdc = codec.NewAminoCodec(amino)
+)
+
+func init() {
+	RegisterLegacyAminoCodec(amino)
+	amino.Seal()
+
+	// Like the DID module's, the consent registry's types are hand-written
+	// and registered under the names a generated aytch/consent/v1 package
+	// would use.
+	proto.RegisterType((*Receipt)(nil), "aytch.consent.v1.Receipt")
+	proto.RegisterType((*MsgGrantConsent)(nil), "aytch.consent.v1.MsgGrantConsent")
+	proto.RegisterType((*MsgRevokeConsent)(nil), "aytch.consent.v1.MsgRevokeConsent")
+	proto.RegisterType((*Agreement)(nil), "aytch.consent.v1.Agreement")
+	proto.RegisterType((*MsgProposeAgreement)(nil), "aytch.consent.v1.MsgProposeAgreement")
+	proto.RegisterType((*MsgAcceptAgreement)(nil), "aytch.consent.v1.MsgAcceptAgreement")
+	proto.RegisterType((*MsgTerminateAgreement)(nil), "aytch.consent.v1.MsgTerminateAgreement")
+	proto.RegisterType((*GenesisState)(nil), "aytch.consent.v1.GenesisState")
+}
+
+// RegisterLegacyAminoCodec registers the consent registry's messages on the
+// given LegacyAmino codec.
+func RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) {
+	cdc.RegisterConcrete(MsgGrantConsent{}, "consent/GrantConsent", nil)
+	cdc.RegisterConcrete(MsgRevokeConsent{}, "consent/RevokeConsent", nil)
+	cdc.RegisterConcrete(MsgProposeAgreement{}, "consent/ProposeAgreement", nil)
+	cdc.RegisterConcrete(MsgAcceptAgreement{}, "consent/AcceptAgreement", nil)
+	cdc.RegisterConcrete(MsgTerminateAgreement{}, "consent/TerminateAgreement", nil)
+}
+
+// RegisterInterfaces registers the consent registry's messages as sdk.Msg
+// implementations. They are routed by the legacy handler.
+func RegisterInterfaces(registry cdctypes.InterfaceRegistry) {
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgGrantConsent{},
+		&MsgRevokeConsent{},
+		&MsgProposeAgreement{},
+		&MsgAcceptAgreement{},
+		&MsgTerminateAgreement{},
+	)
+}
+
+func (m *Receipt) Reset()         { *m = Receipt{} }
+func (m *Receipt) String() string { return proto.CompactTextString(m) }
+func (*Receipt) ProtoMessage()    {}
+
+func (m *MsgGrantConsent) Reset()         { *m = MsgGrantConsent{} }
+func (m *MsgGrantConsent) String() string { return proto.CompactTextString(m) }
+func (*MsgGrantConsent) ProtoMessage()    {}
+
+func (m *MsgRevokeConsent) Reset()         { *m = MsgRevokeConsent{} }
+func (m *MsgRevokeConsent) String() string { return proto.CompactTextString(m) }
+func (*MsgRevokeConsent) ProtoMessage()    {}
+
+func (m *Agreement) Reset()         { *m = Agreement{} }
+func (m *Agreement) String() string { return proto.CompactTextString(m) }
+func (*Agreement) ProtoMessage()    {}
+
+func (m *MsgProposeAgreement) Reset()         { *m = MsgProposeAgreement{} }
+func (m *MsgProposeAgreement) String() string { return proto.CompactTextString(m) }
+func (*MsgProposeAgreement) ProtoMessage()    {}
+
+func (m *MsgAcceptAgreement) Reset()         { *m = MsgAcceptAgreement{} }
+func (m *MsgAcceptAgreement) String() string { return proto.CompactTextString(m) }
+func (*MsgAcceptAgreement) ProtoMessage()    {}
+
+func (m *MsgTerminateAgreement) Reset()         { *m = MsgTerminateAgreement{} }
+func (m *MsgTerminateAgreement) String() string { return proto.CompactTextString(m) }
+func (*MsgTerminateAgreement) ProtoMessage()    {}
+
+func (m *GenesisState) Reset()         { *m = GenesisState{} }
+func (m *GenesisState) String() string { return proto.CompactTextString(m) }
+func (*GenesisState) ProtoMessage()    {}
@@ -0,0 +1,15 @@
+package consent
+
+import (
+	didmodule "cosmos-app/modules/did"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// DIDKeeper defines the DID registry functionality used to check that both
+// parties are active DIDs and to authorize data subjects.
+type DIDKeeper interface {
+	GetDID(ctx sdk.Context, id string) (didmodule.DIDDocument, error)
+	AuthorizeController(ctx sdk.Context, id string, nonce uint64, signer string, creator sdk.AccAddress, signBytes, signature []byte) error
+	IncrementNonce(ctx sdk.Context, id string) error
+}
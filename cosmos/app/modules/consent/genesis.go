@@ -0,0 +1,80 @@
+package consent
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState defines the consent registry's genesis state.
+type GenesisState struct {
+	Receipts        []Receipt   `protobuf:"bytes,1,rep,name=receipts,proto3" json:"receipts"`
+	NextID          uint64      `protobuf:"varint,2,opt,name=next_id,proto3" json:"next_id"`
+	Agreements      []Agreement `protobuf:"bytes,3,rep,name=agreements,proto3" json:"agreements"`
+	NextAgreementID uint64      `protobuf:"varint,4,opt,name=next_agreement_id,proto3" json:"next_agreement_id"`
+}
+
+// DefaultGenesis returns the default genesis state.
+func DefaultGenesis() *GenesisState {
+	return &GenesisState{NextID: 1, NextAgreementID: 1}
+}
+
+// ValidateGenesis performs basic genesis state validation.
+func ValidateGenesis(gs GenesisState) error {
+	seen := make(map[uint64]bool, len(gs.Receipts))
+	for _, r := range gs.Receipts {
+		if err := r.Validate(); err != nil {
+			return fmt.Errorf("consent %d: %w", r.ID, err)
+		}
+		if r.ID == 0 || r.ID >= gs.NextID {
+			return fmt.Errorf("consent %d: ID must be between 1 and the next ID %d", r.ID, gs.NextID)
+		}
+		if seen[r.ID] {
+			return fmt.Errorf("duplicate consent %d", r.ID)
+		}
+		seen[r.ID] = true
+	}
+	seenAgreements := make(map[uint64]bool, len(gs.Agreements))
+	for _, a := range gs.Agreements {
+		if err := a.Validate(); err != nil {
+			return fmt.Errorf("agreement %d: %w", a.ID, err)
+		}
+		if a.ID == 0 || a.ID >= gs.NextAgreementID {
+			return fmt.Errorf("agreement %d: ID must be between 1 and the next agreement ID %d", a.ID, gs.NextAgreementID)
+		}
+		if seenAgreements[a.ID] {
+			return fmt.Errorf("duplicate agreement %d", a.ID)
+		}
+		seenAgreements[a.ID] = true
+	}
+	return nil
+}
+
+// InitGenesis initializes the consent registry's state from a genesis state.
+func InitGenesis(ctx sdk.Context, k Keeper, gs GenesisState) {
+	for _, r := range gs.Receipts {
+		k.setReceipt(ctx, r)
+	}
+	if gs.NextID == 0 {
+		gs.NextID = 1
+	}
+	k.setNextID(ctx, gs.NextID)
+	for _, a := range gs.Agreements {
+		k.setAgreement(ctx, a)
+	}
+	if gs.NextAgreementID == 0 {
+		gs.NextAgreementID = 1
+	}
+	k.setNextAgreementID(ctx, gs.NextAgreementID)
+}
+
+// ExportGenesis exports the consent registry's state, including revoked and
+// expired consents and terminated agreements.
+func ExportGenesis(ctx sdk.Context, k Keeper) *GenesisState {
+	return &GenesisState{
+		Receipts:        k.GetAllReceipts(ctx),
+		NextID:          k.GetNextID(ctx),
+		Agreements:      k.GetAllAgreements(ctx),
+		NextAgreementID: k.GetNextAgreementID(ctx),
+	}
+}
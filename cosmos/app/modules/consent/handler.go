@@ -0,0 +1,129 @@
+package consent
+
+import (
+	"fmt"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// NewHandler creates a handler for consent registry messages.
+func NewHandler(k Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+		ctx = ctx.WithEventManager(sdk.NewEventManager())
+		switch msg := msg.(type) {
+		case *MsgGrantConsent:
+			return handleMsgGrantConsent(ctx, k, *msg)
+		case *MsgRevokeConsent:
+			return handleMsgRevokeConsent(ctx, k, *msg)
+		case *MsgProposeAgreement:
+			return handleMsgProposeAgreement(ctx, k, *msg)
+		case *MsgAcceptAgreement:
+			return handleMsgAcceptAgreement(ctx, k, *msg)
+		case *MsgTerminateAgreement:
+			return handleMsgTerminateAgreement(ctx, k, *msg)
+		default:
+			return nil, fmt.Errorf("unrecognized consent message type: %T", msg)
+		}
+	}
+}
+
+// authorizeDID checks a controller proof for id and consumes its nonce, so
+// the proof can't be replayed.
+func authorizeDID(ctx sdk.Context, k Keeper, id string, nonce uint64, signer string, creator sdk.AccAddress, signBytes, signature []byte) error {
+	if err := k.didKeeper.AuthorizeController(ctx, id, nonce, signer, creator, signBytes, signature); err != nil {
+		return err
+	}
+	return k.didKeeper.IncrementNonce(ctx, id)
+}
+
+func handleMsgGrantConsent(ctx sdk.Context, k Keeper, msg MsgGrantConsent) (*sdk.Result, error) {
+	if err := authorizeDID(ctx, k, msg.Subject, msg.Nonce, msg.Signer, msg.Creator, msg.ProofSignBytes(), msg.Signature); err != nil {
+		return nil, err
+	}
+	id, err := k.GrantConsent(ctx, msg.Receipt())
+	if err != nil {
+		return nil, err
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		"grant_consent",
+		sdk.NewAttribute("id", strconv.FormatUint(id, 10)),
+		sdk.NewAttribute("subject", msg.Subject),
+		sdk.NewAttribute("controller", msg.Controller),
+		sdk.NewAttribute("purpose_hash", msg.PurposeHash),
+	))
+	return &sdk.Result{Events: ctx.EventManager().ABCIEvents()}, nil
+}
+
+func handleMsgRevokeConsent(ctx sdk.Context, k Keeper, msg MsgRevokeConsent) (*sdk.Result, error) {
+	if err := authorizeDID(ctx, k, msg.Subject, msg.Nonce, msg.Signer, msg.Creator, msg.ProofSignBytes(), msg.Signature); err != nil {
+		return nil, err
+	}
+	r, err := k.RevokeConsent(ctx, msg.ID, msg.Subject)
+	if err != nil {
+		return nil, err
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		"revoke_consent",
+		sdk.NewAttribute("id", strconv.FormatUint(r.ID, 10)),
+		sdk.NewAttribute("subject", r.Subject),
+		sdk.NewAttribute("controller", r.Controller),
+	))
+	return &sdk.Result{Events: ctx.EventManager().ABCIEvents()}, nil
+}
+
+func handleMsgProposeAgreement(ctx sdk.Context, k Keeper, msg MsgProposeAgreement) (*sdk.Result, error) {
+	if err := authorizeDID(ctx, k, msg.Proposer, msg.Nonce, msg.Signer, msg.Creator, msg.ProofSignBytes(), msg.Signature); err != nil {
+		return nil, err
+	}
+	id, err := k.ProposeAgreement(ctx, msg.Proposer, msg.Counterparty, msg.AgreementHash)
+	if err != nil {
+		return nil, err
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		"propose_agreement",
+		sdk.NewAttribute("id", strconv.FormatUint(id, 10)),
+		sdk.NewAttribute("proposer", msg.Proposer),
+		sdk.NewAttribute("counterparty", msg.Counterparty),
+		sdk.NewAttribute("agreement_hash", msg.AgreementHash),
+	))
+	return &sdk.Result{Events: ctx.EventManager().ABCIEvents()}, nil
+}
+
+// handleMsgAcceptAgreement authorizes the counterparty recorded in the
+// proposal, so only the DID the agreement was offered to can accept it.
+func handleMsgAcceptAgreement(ctx sdk.Context, k Keeper, msg MsgAcceptAgreement) (*sdk.Result, error) {
+	a, err := k.GetAgreement(ctx, msg.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeDID(ctx, k, a.Counterparty, msg.Nonce, msg.Signer, msg.Creator, msg.ProofSignBytes(), msg.Signature); err != nil {
+		return nil, err
+	}
+	if a, err = k.AcceptAgreement(ctx, msg.ID, msg.AgreementHash); err != nil {
+		return nil, err
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		"accept_agreement",
+		sdk.NewAttribute("id", strconv.FormatUint(a.ID, 10)),
+		sdk.NewAttribute("proposer", a.Proposer),
+		sdk.NewAttribute("counterparty", a.Counterparty),
+	))
+	return &sdk.Result{Events: ctx.EventManager().ABCIEvents()}, nil
+}
+
+func handleMsgTerminateAgreement(ctx sdk.Context, k Keeper, msg MsgTerminateAgreement) (*sdk.Result, error) {
+	if err := authorizeDID(ctx, k, msg.Party, msg.Nonce, msg.Signer, msg.Creator, msg.ProofSignBytes(), msg.Signature); err != nil {
+		return nil, err
+	}
+	a, err := k.TerminateAgreement(ctx, msg.ID, msg.Party)
+	if err != nil {
+		return nil, err
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		"terminate_agreement",
+		sdk.NewAttribute("id", strconv.FormatUint(a.ID, 10)),
+		sdk.NewAttribute("terminated_by", msg.Party),
+	))
+	return &sdk.Result{Events: ctx.EventManager().ABCIEvents()}, nil
+}
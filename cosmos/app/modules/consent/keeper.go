@@ -0,0 +1,192 @@
+package consent
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Store prefixes. Receipts are stored by ID under ReceiptPrefix and indexed
+// by subject and by controller for listing; NextIDKey holds the ID of the
+// next receipt.
+var (
+	ReceiptPrefix         = []byte("receipt/")
+	SubjectIndexPrefix    = []byte("subject/")
+	ControllerIndexPrefix = []byte("controller/")
+	NextIDKey             = []byte("next-id")
+)
+
+func receiptKey(id uint64) []byte {
+	return append(append([]byte{}, ReceiptPrefix...), sdk.Uint64ToBigEndian(id)...)
+}
+
+func indexPrefix(p []byte, did string) []byte {
+	return append(append([]byte{}, p...), did+"/"...)
+}
+
+func indexKey(p []byte, did string, id uint64) []byte {
+	return append(indexPrefix(p, did), sdk.Uint64ToBigEndian(id)...)
+}
+
+// Keeper maintains the consent receipts.
+type Keeper struct {
+	storeKey  sdk.StoreKey
+	cdc       *codec.LegacyAmino
+	didKeeper DIDKeeper
+}
+
+// NewKeeper creates a new consent registry Keeper.
+func NewKeeper(storeKey sdk.StoreKey, cdc *codec.LegacyAmino, didKeeper DIDKeeper) Keeper {
+	return Keeper{
+		storeKey:  storeKey,
+		cdc:       cdc,
+		didKeeper: didKeeper,
+	}
+}
+
+// GrantConsent stores a new receipt between two active DIDs and returns its
+// ID. Callers must have authorized the subject.
+func (k Keeper) GrantConsent(ctx sdk.Context, r Receipt) (uint64, error) {
+	if err := r.Validate(); err != nil {
+		return 0, err
+	}
+	now := ctx.BlockTime().Unix()
+	if r.ExpiresAt != 0 && r.ExpiresAt <= now {
+		return 0, fmt.Errorf("consent expiry %d is not in the future", r.ExpiresAt)
+	}
+	for _, did := range []string{r.Subject, r.Controller} {
+		doc, err := k.didKeeper.GetDID(ctx, did)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", did, err)
+		}
+		if doc.Deactivated {
+			return 0, fmt.Errorf("DID %s is deactivated", did)
+		}
+	}
+	r.ID = k.GetNextID(ctx)
+	r.Granted = now
+	r.Revoked = 0
+	k.setReceipt(ctx, r)
+	k.setNextID(ctx, r.ID+1)
+	return r.ID, nil
+}
+
+// RevokeConsent withdraws consent id on behalf of subject, which must be the
+// receipt's subject.
+func (k Keeper) RevokeConsent(ctx sdk.Context, id uint64, subject string) (Receipt, error) {
+	r, err := k.GetReceipt(ctx, id)
+	if err != nil {
+		return Receipt{}, err
+	}
+	if r.Subject != subject {
+		return Receipt{}, fmt.Errorf("only the subject of consent %d can revoke it", id)
+	}
+	if r.Revoked != 0 {
+		return Receipt{}, fmt.Errorf("consent %d is already revoked", id)
+	}
+	r.Revoked = ctx.BlockTime().Unix()
+	k.setReceipt(ctx, r)
+	return r, nil
+}
+
+// GetReceipt returns a receipt, in force or not.
+func (k Keeper) GetReceipt(ctx sdk.Context, id uint64) (Receipt, error) {
+	value := ctx.KVStore(k.storeKey).Get(receiptKey(id))
+	if value == nil {
+		return Receipt{}, fmt.Errorf("consent %d not found", id)
+	}
+	var r Receipt
+	k.cdc.MustUnmarshal(value, &r)
+	return r, nil
+}
+
+// Status returns a receipt with whether its consent is in force: it isn't
+// once revoked or expired, or while either DID is missing or deactivated.
+func (k Keeper) Status(ctx sdk.Context, r Receipt) ReceiptStatus {
+	status := ReceiptStatus{Receipt: r, Valid: true}
+	switch {
+	case r.Revoked != 0:
+		status.Valid, status.Reason = false, "consent was revoked"
+	case r.ExpiresAt != 0 && r.ExpiresAt <= ctx.BlockTime().Unix():
+		status.Valid, status.Reason = false, "consent has expired"
+	default:
+		for _, did := range []string{r.Subject, r.Controller} {
+			if doc, err := k.didKeeper.GetDID(ctx, did); err != nil || doc.Deactivated {
+				status.Valid, status.Reason = false, fmt.Sprintf("%s is not an active DID", did)
+				break
+			}
+		}
+	}
+	return status
+}
+
+// HasConsent reports whether subject has a consent in force allowing
+// controller to process its data for the purpose with purposeHash, for
+// modules gating data access on consent.
+func (k Keeper) HasConsent(ctx sdk.Context, controller, subject, purposeHash string) bool {
+	for _, r := range k.ReceiptsBySubject(ctx, subject) {
+		if r.Controller == controller && r.PurposeHash == purposeHash && k.Status(ctx, r).Valid {
+			return true
+		}
+	}
+	return false
+}
+
+// ReceiptsBySubject returns the consents a DID has given, in force or not,
+// in ID order.
+func (k Keeper) ReceiptsBySubject(ctx sdk.Context, subject string) []Receipt {
+	return k.indexedReceipts(ctx, indexPrefix(SubjectIndexPrefix, subject))
+}
+
+// ReceiptsByController returns the consents given to a DID, in force or not,
+// in ID order.
+func (k Keeper) ReceiptsByController(ctx sdk.Context, controller string) []Receipt {
+	return k.indexedReceipts(ctx, indexPrefix(ControllerIndexPrefix, controller))
+}
+
+func (k Keeper) indexedReceipts(ctx sdk.Context, p []byte) []Receipt {
+	var receipts []Receipt
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), p)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		if r, err := k.GetReceipt(ctx, sdk.BigEndianToUint64(iter.Key()[len(p):])); err == nil {
+			receipts = append(receipts, r)
+		}
+	}
+	return receipts
+}
+
+// GetAllReceipts returns every receipt in the store.
+func (k Keeper) GetAllReceipts(ctx sdk.Context) []Receipt {
+	var receipts []Receipt
+	iter := prefix.NewStore(ctx.KVStore(k.storeKey), ReceiptPrefix).Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var r Receipt
+		k.cdc.MustUnmarshal(iter.Value(), &r)
+		receipts = append(receipts, r)
+	}
+	return receipts
+}
+
+// GetNextID returns the ID the next receipt will get. IDs start at 1.
+func (k Keeper) GetNextID(ctx sdk.Context) uint64 {
+	bz := ctx.KVStore(k.storeKey).Get(NextIDKey)
+	if bz == nil {
+		return 1
+	}
+	return sdk.BigEndianToUint64(bz)
+}
+
+func (k Keeper) setNextID(ctx sdk.Context, id uint64) {
+	ctx.KVStore(k.storeKey).Set(NextIDKey, sdk.Uint64ToBigEndian(id))
+}
+
+func (k Keeper) setReceipt(ctx sdk.Context, r Receipt) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(receiptKey(r.ID), k.cdc.MustMarshal(&r))
+	store.Set(indexKey(SubjectIndexPrefix, r.Subject, r.ID), []byte{})
+	store.Set(indexKey(ControllerIndexPrefix, r.Controller, r.ID), []byte{})
+}
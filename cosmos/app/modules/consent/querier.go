@@ -0,0 +1,78 @@
+package consent
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// Query endpoints supported by the consent registry querier. Receipts are
+// returned as ReceiptStatus, with whether the consent is in force.
+const (
+	// QueryReceipt takes a receipt ID.
+	QueryReceipt = "receipt"
+	// QueryBySubject takes a data subject DID.
+	QueryBySubject = "subject"
+	// QueryByController takes a data controller DID.
+	QueryByController = "controller"
+	// QueryAgreement takes an agreement ID.
+	QueryAgreement = "agreement"
+	// QueryAgreementsByParty takes the DID of either party.
+	QueryAgreementsByParty = "agreements"
+)
+
+// NewQuerier creates a legacy querier for the consent registry. Results are
+// encoded as plain JSON, like the DID module's.
+func NewQuerier(k Keeper, _ *codec.LegacyAmino) sdk.Querier {
+	return func(ctx sdk.Context, path []string, _ abci.RequestQuery) ([]byte, error) {
+		if len(path) == 0 {
+			return nil, fmt.Errorf("empty consent query path")
+		}
+		switch {
+		case path[0] == QueryReceipt && len(path) == 2:
+			id, err := strconv.ParseUint(path[1], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid consent ID %s", path[1])
+			}
+			r, err := k.GetReceipt(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			return json.MarshalIndent(k.Status(ctx, r), "", "  ")
+		case path[0] == QueryBySubject && len(path) == 2:
+			return marshalStatuses(ctx, k, k.ReceiptsBySubject(ctx, path[1]))
+		case path[0] == QueryByController && len(path) == 2:
+			return marshalStatuses(ctx, k, k.ReceiptsByController(ctx, path[1]))
+		case path[0] == QueryAgreement && len(path) == 2:
+			id, err := strconv.ParseUint(path[1], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid agreement ID %s", path[1])
+			}
+			a, err := k.GetAgreement(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			return json.MarshalIndent(a, "", "  ")
+		case path[0] == QueryAgreementsByParty && len(path) == 2:
+			agreements := k.AgreementsByParty(ctx, path[1])
+			if agreements == nil {
+				agreements = []Agreement{}
+			}
+			return json.MarshalIndent(agreements, "", "  ")
+		default:
+			return nil, fmt.Errorf("unknown consent query path %v", path)
+		}
+	}
+}
+
+func marshalStatuses(ctx sdk.Context, k Keeper, receipts []Receipt) ([]byte, error) {
+	statuses := make([]ReceiptStatus, 0, len(receipts))
+	for _, r := range receipts {
+		statuses = append(statuses, k.Status(ctx, r))
+	}
+	return json.MarshalIndent(statuses, "", "  ")
+}
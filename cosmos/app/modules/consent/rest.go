@@ -0,0 +1,30 @@
+package consent
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/gorilla/mux"
+)
+
+// RegisterRoutes registers the consent registry's REST routes.
+func RegisterRoutes(cliCtx client.Context, r *mux.Router) {
+	r.HandleFunc("/consent/receipts/{id}", queryHandler(cliCtx, QueryReceipt, "id")).Methods("GET")
+	r.HandleFunc("/consent/subjects/{did}", queryHandler(cliCtx, QueryBySubject, "did")).Methods("GET")
+	r.HandleFunc("/consent/controllers/{did}", queryHandler(cliCtx, QueryByController, "did")).Methods("GET")
+	r.HandleFunc("/consent/agreements/{id}", queryHandler(cliCtx, QueryAgreement, "id")).Methods("GET")
+	r.HandleFunc("/consent/parties/{did}/agreements", queryHandler(cliCtx, QueryAgreementsByParty, "did")).Methods("GET")
+}
+
+func queryHandler(cliCtx client.Context, query, v string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", ModuleName, query, mux.Vars(r)[v]), nil)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(res)
+	}
+}
@@ -0,0 +1,214 @@
+package consent
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	didmodule "cosmos-app/modules/did"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// Module identifiers for the consent receipt registry.
+const (
+	ModuleName = "consent"
+	StoreKey   = ModuleName
+	RouterKey  = ModuleName
+)
+
+// Receipt records that the Subject DID consented to the Controller DID
+// processing its data for a purpose. Only the SHA-256 PurposeHash of the
+// purpose statement, e.g. a privacy notice, goes on chain; parties keep the
+// statement and prove what was agreed by hashing it. A receipt is never
+// deleted: revoking it sets Revoked, so the history of a consent stays
+// verifiable. Times are Unix seconds; an ExpiresAt of zero never expires.
+type Receipt struct {
+	ID          uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id"`
+	Controller  string `protobuf:"bytes,2,opt,name=controller,proto3" json:"controller"`
+	Subject     string `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject"`
+	PurposeHash string `protobuf:"bytes,4,opt,name=purpose_hash,proto3" json:"purpose_hash"`
+	Granted     int64  `protobuf:"varint,5,opt,name=granted,proto3" json:"granted"`
+	ExpiresAt   int64  `protobuf:"varint,6,opt,name=expires_at,proto3" json:"expires_at,omitempty"`
+	Revoked     int64  `protobuf:"varint,7,opt,name=revoked,proto3" json:"revoked,omitempty"`
+}
+
+// Validate checks the fields of a receipt other than its ID and times.
+func (r Receipt) Validate() error {
+	if err := validateDID(r.Controller); err != nil {
+		return fmt.Errorf("controller: %w", err)
+	}
+	if err := validateDID(r.Subject); err != nil {
+		return fmt.Errorf("subject: %w", err)
+	}
+	if r.Controller == r.Subject {
+		return fmt.Errorf("a DID cannot consent to itself")
+	}
+	if err := ValidatePurposeHash(r.PurposeHash); err != nil {
+		return err
+	}
+	if r.ExpiresAt < 0 {
+		return fmt.Errorf("expiry cannot be negative")
+	}
+	return nil
+}
+
+// ReceiptStatus is a receipt with whether the consent is in force at query
+// time.
+type ReceiptStatus struct {
+	Receipt
+	Valid bool `json:"valid"`
+	// Reason says why a consent is not in force.
+	Reason string `json:"reason,omitempty"`
+}
+
+// PurposeHash returns the hex SHA-256 hash of a purpose statement.
+func PurposeHash(purpose []byte) string {
+	sum := sha256.Sum256(purpose)
+	return hex.EncodeToString(sum[:])
+}
+
+// ValidatePurposeHash checks that h is a lowercase hex SHA-256 hash.
+func ValidatePurposeHash(h string) error {
+	if len(h) != 2*sha256.Size || strings.ToLower(h) != h {
+		return fmt.Errorf("purpose hash must be a lowercase hex SHA-256 hash")
+	}
+	if _, err := hex.DecodeString(h); err != nil {
+		return fmt.Errorf("purpose hash must be a lowercase hex SHA-256 hash")
+	}
+	return nil
+}
+
+// validateDID checks that id is a DID of this chain. DIDs are part of store
+// keys, so they can't contain '/'.
+func validateDID(id string) error {
+	if !strings.HasPrefix(id, didmodule.DIDMethodPrefix) || id == didmodule.DIDMethodPrefix {
+		return fmt.Errorf("DID must start with %s", didmodule.DIDMethodPrefix)
+	}
+	if strings.Contains(id, "/") {
+		return fmt.Errorf("DID cannot contain '/'")
+	}
+	return didmodule.ValidateChecksum(id)
+}
+
+// proofSignBytes returns the sorted JSON of a message with its controller
+// signature cleared, which the controller of the data subject DID signs.
+func proofSignBytes(msg interface{}) []byte {
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// MsgGrantConsent records the consent of Subject to Controller processing
+// its data for the purpose with PurposeHash until ExpiresAt. Consent is the
+// data subject's to give, so Signer proves control of the subject DID as for
+// a DID document change.
+type MsgGrantConsent struct {
+	Subject     string         `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject"`
+	Controller  string         `protobuf:"bytes,2,opt,name=controller,proto3" json:"controller"`
+	PurposeHash string         `protobuf:"bytes,3,opt,name=purpose_hash,proto3" json:"purpose_hash"`
+	ExpiresAt   int64          `protobuf:"varint,4,opt,name=expires_at,proto3" json:"expires_at,omitempty"`
+	Nonce       uint64         `protobuf:"varint,5,opt,name=nonce,proto3" json:"nonce"`
+	Signer      string         `protobuf:"bytes,6,opt,name=signer,proto3" json:"signer"`
+	Signature   []byte         `protobuf:"bytes,7,opt,name=signature,proto3" json:"signature,omitempty"`
+	Creator     sdk.AccAddress `protobuf:"bytes,8,opt,name=creator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"creator"`
+}
+
+// Receipt returns the receipt the message records, without ID and grant
+// time.
+func (msg MsgGrantConsent) Receipt() Receipt {
+	return Receipt{
+		Controller:  msg.Controller,
+		Subject:     msg.Subject,
+		PurposeHash: msg.PurposeHash,
+		ExpiresAt:   msg.ExpiresAt,
+	}
+}
+
+// ValidateBasic performs basic validation of MsgGrantConsent.
+func (msg MsgGrantConsent) ValidateBasic() error {
+	if err := msg.Receipt().Validate(); err != nil {
+		return sdkerrors.ErrUnknownRequest.Wrap(err.Error())
+	}
+	if msg.Signer == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Signer cannot be empty")
+	}
+	if msg.Creator.Empty() {
+		return sdkerrors.ErrUnknownRequest.Wrap("Creator cannot be empty")
+	}
+	return nil
+}
+
+// ProofSignBytes returns the bytes the subject's controller signs.
+func (msg MsgGrantConsent) ProofSignBytes() []byte {
+	msg.Signature = nil
+	return proofSignBytes(msg)
+}
+
+// Route returns the message route.
+func (msg MsgGrantConsent) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgGrantConsent) Type() string { return "grant_consent" }
+
+// GetSignBytes returns the canonical bytes to sign over.
+func (msg MsgGrantConsent) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners returns the account that must sign the message.
+func (msg MsgGrantConsent) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
+
+// MsgRevokeConsent withdraws a consent. Only the data subject may revoke it:
+// Signer proves control of the receipt's subject DID.
+type MsgRevokeConsent struct {
+	ID        uint64         `protobuf:"varint,1,opt,name=id,proto3" json:"id"`
+	Subject   string         `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject"`
+	Nonce     uint64         `protobuf:"varint,3,opt,name=nonce,proto3" json:"nonce"`
+	Signer    string         `protobuf:"bytes,4,opt,name=signer,proto3" json:"signer"`
+	Signature []byte         `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
+	Creator   sdk.AccAddress `protobuf:"bytes,6,opt,name=creator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"creator"`
+}
+
+// ValidateBasic performs basic validation of MsgRevokeConsent.
+func (msg MsgRevokeConsent) ValidateBasic() error {
+	if err := validateDID(msg.Subject); err != nil {
+		return sdkerrors.ErrUnknownRequest.Wrap(err.Error())
+	}
+	if msg.Signer == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Signer cannot be empty")
+	}
+	if msg.Creator.Empty() {
+		return sdkerrors.ErrUnknownRequest.Wrap("Creator cannot be empty")
+	}
+	return nil
+}
+
+// ProofSignBytes returns the bytes the subject's controller signs.
+func (msg MsgRevokeConsent) ProofSignBytes() []byte {
+	msg.Signature = nil
+	return proofSignBytes(msg)
+}
+
+// Route returns the message route.
+func (msg MsgRevokeConsent) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgRevokeConsent) Type() string { return "revoke_consent" }
+
+// GetSignBytes returns the canonical bytes to sign over.
+func (msg MsgRevokeConsent) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners returns the account that must sign the message.
+func (msg MsgRevokeConsent) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
@@ -0,0 +1,128 @@
+package did
+
+import (
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// AccountLink binds an account to the DID that identifies its owner. An
+// account links to at most one DID; a DID may have any number of accounts.
+type AccountLink struct {
+	Address sdk.AccAddress `protobuf:"bytes,1,opt,name=address,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"address"`
+	DID     string         `protobuf:"bytes,2,opt,name=did,proto3" json:"did"`
+	Created int64          `protobuf:"varint,3,opt,name=created,proto3" json:"created"`
+}
+
+// MsgLinkAccount links Account to DID, replacing any earlier link of the
+// account. Both sides consent: Account signs the transaction, and a
+// controller of the DID authorizes the link like a MsgUpdateDID.
+type MsgLinkAccount struct {
+	DID       string         `protobuf:"bytes,1,opt,name=did,proto3" json:"did"`
+	Account   sdk.AccAddress `protobuf:"bytes,2,opt,name=account,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"account"`
+	Nonce     uint64         `protobuf:"varint,3,opt,name=nonce,proto3" json:"nonce"`
+	Signer    string         `protobuf:"bytes,4,opt,name=signer,proto3" json:"signer"`
+	Signature []byte         `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
+	Creator   sdk.AccAddress `protobuf:"bytes,6,opt,name=creator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"creator"`
+}
+
+// ValidateBasic performs basic validation of MsgLinkAccount.
+func (msg MsgLinkAccount) ValidateBasic() error {
+	if err := validateChecksums(msg); err != nil {
+		return err
+	}
+	if msg.DID == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("DID cannot be empty")
+	}
+	if msg.Account.Empty() {
+		return sdkerrors.ErrUnknownRequest.Wrap("Account cannot be empty")
+	}
+	if msg.Signer == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Signer cannot be empty")
+	}
+	return nil
+}
+
+// ProofSignBytes returns the bytes a controller DID signs to authorize the
+// link.
+func (msg MsgLinkAccount) ProofSignBytes() []byte {
+	msg.Signature = nil
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// Route returns the message route.
+func (msg MsgLinkAccount) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgLinkAccount) Type() string { return "link_account" }
+
+// GetSignBytes returns the canonical bytes to sign over.
+func (msg MsgLinkAccount) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners returns the accounts that must sign the message: the creator
+// and, if it is another account, the account being linked.
+func (msg MsgLinkAccount) GetSigners() []sdk.AccAddress {
+	if msg.Account.Equals(msg.Creator) {
+		return []sdk.AccAddress{msg.Creator}
+	}
+	return []sdk.AccAddress{msg.Creator, msg.Account}
+}
+
+// MsgUnlinkAccount removes the link of Account. The account can drop its own
+// link by signing the transaction as creator with no Signer; otherwise a
+// controller of the linked DID authorizes the removal like a MsgUpdateDID.
+type MsgUnlinkAccount struct {
+	Account   sdk.AccAddress `protobuf:"bytes,1,opt,name=account,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"account"`
+	Nonce     uint64         `protobuf:"varint,2,opt,name=nonce,proto3" json:"nonce"`
+	Signer    string         `protobuf:"bytes,3,opt,name=signer,proto3" json:"signer,omitempty"`
+	Signature []byte         `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	Creator   sdk.AccAddress `protobuf:"bytes,5,opt,name=creator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"creator"`
+}
+
+// ValidateBasic performs basic validation of MsgUnlinkAccount.
+func (msg MsgUnlinkAccount) ValidateBasic() error {
+	if err := validateChecksums(msg); err != nil {
+		return err
+	}
+	if msg.Account.Empty() {
+		return sdkerrors.ErrUnknownRequest.Wrap("Account cannot be empty")
+	}
+	if msg.Signer == "" && !msg.Account.Equals(msg.Creator) {
+		return sdkerrors.ErrUnknownRequest.Wrap("Signer cannot be empty unless the account unlinks itself")
+	}
+	return nil
+}
+
+// ProofSignBytes returns the bytes a controller DID signs to authorize the
+// removal.
+func (msg MsgUnlinkAccount) ProofSignBytes() []byte {
+	msg.Signature = nil
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// Route returns the message route.
+func (msg MsgUnlinkAccount) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgUnlinkAccount) Type() string { return "unlink_account" }
+
+// GetSignBytes returns the canonical bytes to sign over.
+func (msg MsgUnlinkAccount) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners returns the account that must sign the message.
+func (msg MsgUnlinkAccount) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
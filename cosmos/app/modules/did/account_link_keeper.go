@@ -0,0 +1,113 @@
+package did
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// LinkAccount links addr to an active DID, replacing any earlier link of the
+// account and pruning the assets the earlier DID had linked through it. Callers must have checked both sides' consent.
+func (k Keeper) LinkAccount(ctx sdk.Context, id string, addr sdk.AccAddress) error {
+	did, err := k.GetDID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if did.Deactivated {
+		return fmt.Errorf("DID %s is deactivated", id)
+	}
+	if existing, found := k.GetAccountLink(ctx, addr); found {
+		if existing.DID == id {
+			return fmt.Errorf("account %s is already linked to %s", addr, id)
+		}
+		k.deleteAccountLink(ctx, existing)
+		k.recheckAccountAssets(ctx, existing)
+	}
+	k.setAccountLink(ctx, AccountLink{Address: addr, DID: id, Created: ctx.BlockHeight()})
+	ctx.EventManager().EmitEvent(sdk.NewEvent("account_linked",
+		sdk.NewAttribute("did", id),
+		sdk.NewAttribute("account", addr.String()),
+	))
+	return nil
+}
+
+// UnlinkAccount removes the link of addr, pruning the assets the DID had
+// linked through the account.
+func (k Keeper) UnlinkAccount(ctx sdk.Context, addr sdk.AccAddress) error {
+	link, found := k.GetAccountLink(ctx, addr)
+	if !found {
+		return fmt.Errorf("account %s is not linked to a DID", addr)
+	}
+	k.deleteAccountLink(ctx, link)
+	ctx.EventManager().EmitEvent(sdk.NewEvent("account_unlinked",
+		sdk.NewAttribute("did", link.DID),
+		sdk.NewAttribute("account", addr.String()),
+	))
+	k.recheckAccountAssets(ctx, link)
+	return nil
+}
+
+// GetAccountLink returns the link of addr.
+func (k Keeper) GetAccountLink(ctx sdk.Context, addr sdk.AccAddress) (AccountLink, bool) {
+	value := ctx.KVStore(k.storeKey).Get(accountLinkKey(addr))
+	if value == nil {
+		return AccountLink{}, false
+	}
+	var link AccountLink
+	k.cdc.MustUnmarshal(value, &link)
+	return link, true
+}
+
+// DIDByAddress returns the DID an account is linked to, for modules that need
+// the identity behind an address. The DID may since have been deactivated.
+func (k Keeper) DIDByAddress(ctx sdk.Context, addr sdk.AccAddress) (string, bool) {
+	link, found := k.GetAccountLink(ctx, addr)
+	return link.DID, found
+}
+
+// GetAccountLinks returns the links of the accounts linked to a DID.
+func (k Keeper) GetAccountLinks(ctx sdk.Context, id string) []AccountLink {
+	store := ctx.KVStore(k.storeKey)
+	var links []AccountLink
+	iteratePrefix(store, didAccountsPrefix(id), func(key, _ []byte) bool {
+		if link, found := k.GetAccountLink(ctx, sdk.AccAddress(key)); found {
+			links = append(links, link)
+		}
+		return false
+	})
+	return links
+}
+
+// GetAllAccountLinks returns every account link in the store.
+func (k Keeper) GetAllAccountLinks(ctx sdk.Context) []AccountLink {
+	var links []AccountLink
+	iteratePrefix(ctx.KVStore(k.storeKey), AccountLinkPrefix, func(_, value []byte) bool {
+		var link AccountLink
+		k.cdc.MustUnmarshal(value, &link)
+		links = append(links, link)
+		return false
+	})
+	return links
+}
+
+func (k Keeper) setAccountLink(ctx sdk.Context, link AccountLink) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(accountLinkKey(link.Address), k.cdc.MustMarshal(&link))
+	store.Set(didAccountKey(link.DID, link.Address), []byte{})
+}
+
+func (k Keeper) deleteAccountLink(ctx sdk.Context, link AccountLink) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(accountLinkKey(link.Address))
+	store.Delete(didAccountKey(link.DID, link.Address))
+}
+
+// recheckAccountAssets re-verifies the assets the DID of a removed link held
+// through its account.
+func (k Keeper) recheckAccountAssets(ctx sdk.Context, link AccountLink) {
+	for _, asset := range k.GetLinkedAssets(ctx, link.DID) {
+		if asset.Owner == link.Address.String() {
+			k.recheckLinkedAsset(ctx, asset)
+		}
+	}
+}
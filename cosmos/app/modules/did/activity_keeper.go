@@ -0,0 +1,79 @@
+package did
+
+import (
+	"reflect"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// recordActivity adds msg, executed in the transaction with hash txHash and
+// affecting dids, to the activity of each actor that performed it.
+func (k Keeper) recordActivity(ctx sdk.Context, msg sdk.Msg, txHash string, dids []string) {
+	msgType := sdk.MsgTypeURL(msg)
+	store := ctx.KVStore(k.storeKey)
+	for _, actor := range msgActors(msg) {
+		key := activityKey(actor, ctx.BlockHeight(), txHash)
+		record := ActivityRecord{Actor: actor, Height: ctx.BlockHeight(), TxHash: txHash}
+		if value := store.Get(key); value != nil {
+			k.cdc.MustUnmarshal(value, &record)
+		}
+		if !containsString(record.Msgs, msgType) {
+			record.Msgs = append(record.Msgs, msgType)
+		}
+		for _, did := range dids {
+			if !containsString(record.DIDs, did) {
+				record.DIDs = append(record.DIDs, did)
+			}
+		}
+		store.Set(key, k.cdc.MustMarshal(&record))
+	}
+}
+
+// GetActivity returns the activity of an actor between two heights,
+// inclusive, oldest first. A zero toHeight means no upper bound.
+func (k Keeper) GetActivity(ctx sdk.Context, actor string, fromHeight, toHeight int64) []ActivityRecord {
+	var records []ActivityRecord
+	iteratePrefix(ctx.KVStore(k.storeKey), activityPrefix(actor), func(key, value []byte) bool {
+		var record ActivityRecord
+		k.cdc.MustUnmarshal(value, &record)
+		if toHeight != 0 && record.Height > toHeight {
+			return true
+		}
+		if record.Height >= fromHeight {
+			records = append(records, record)
+		}
+		return false
+	})
+	return records
+}
+
+// msgActors returns who performed msg: the accounts that signed it and, for
+// messages authorized by a controller proof, the controller DID or address
+// in its Signer field.
+func msgActors(msg sdk.Msg) []string {
+	var actors []string
+	for _, addr := range msg.GetSigners() {
+		if s := addr.String(); !containsString(actors, s) {
+			actors = append(actors, s)
+		}
+	}
+	if signer := controllerSigner(msg); signer != "" && !containsString(actors, signer) {
+		actors = append(actors, signer)
+	}
+	return actors
+}
+
+// controllerSigner returns the Signer field of msg. The module's messages
+// name the DID or address proving control in a field of that name, without
+// a common getter.
+func controllerSigner(msg sdk.Msg) string {
+	v := reflect.Indirect(reflect.ValueOf(msg))
+	if v.Kind() != reflect.Struct {
+		return ""
+	}
+	f := v.FieldByName("Signer")
+	if !f.IsValid() || f.Kind() != reflect.String {
+		return ""
+	}
+	return f.String()
+}
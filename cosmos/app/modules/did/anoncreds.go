@@ -0,0 +1,186 @@
+package did
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Resource types of the Hyperledger AnonCreds objects did:aytch stores as
+// DID-linked resources. Object identifiers are the resources' DID URLs
+// (did/resources/{id}); revocation status lists form a version chain under
+// their revocation registry's name, resolved by time with a query DID URL
+// (see ParseResourceQuery).
+const (
+	AnonCredsSchemaType     = "anonCredsSchema"
+	AnonCredsCredDefType    = "anonCredsCredDef"
+	AnonCredsRevRegDefType  = "anonCredsRevocRegDef"
+	AnonCredsStatusListType = "anonCredsStatusList"
+)
+
+// Resource query parameters of the AnonCreds DID URLs that select a resource
+// by name and type, at the version current at a given time.
+const (
+	ResourceNameParam        = "resourceName"
+	ResourceTypeParam        = "resourceType"
+	ResourceVersionTimeParam = "resourceVersionTime"
+)
+
+type anonCredsObject struct {
+	IssuerID string `json:"issuerId"`
+
+	// Schema
+	Name      string   `json:"name"`
+	Version   string   `json:"version"`
+	AttrNames []string `json:"attrNames"`
+
+	// Credential and revocation registry definitions
+	SchemaID     string          `json:"schemaId"`
+	CredDefID    string          `json:"credDefId"`
+	Type         string          `json:"type"`
+	RevocDefType string          `json:"revocDefType"`
+	Tag          string          `json:"tag"`
+	Value        json.RawMessage `json:"value"`
+
+	// Revocation status list
+	RevRegDefID        string `json:"revRegDefId"`
+	RevocationList     []int  `json:"revocationList"`
+	CurrentAccumulator string `json:"currentAccumulator"`
+}
+
+func isAnonCredsResourceType(resourceType string) bool {
+	switch resourceType {
+	case AnonCredsSchemaType, AnonCredsCredDefType, AnonCredsRevRegDefType, AnonCredsStatusListType:
+		return true
+	}
+	return false
+}
+
+// validateAnonCredsObject checks that data is a well-formed AnonCreds object
+// of resourceType issued by issuer.
+func validateAnonCredsObject(resourceType string, data []byte, issuer string) error {
+	var obj anonCredsObject
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return fmt.Errorf("invalid %s: %w", resourceType, err)
+	}
+	if obj.IssuerID != issuer {
+		return fmt.Errorf("%s issuerId %s is not %s", resourceType, obj.IssuerID, issuer)
+	}
+	switch resourceType {
+	case AnonCredsSchemaType:
+		if obj.Name == "" || obj.Version == "" || len(obj.AttrNames) == 0 {
+			return fmt.Errorf("%s needs a name, a version and attrNames", resourceType)
+		}
+	case AnonCredsCredDefType:
+		if obj.SchemaID == "" || obj.Type != "CL" || obj.Tag == "" || len(obj.Value) == 0 {
+			return fmt.Errorf("%s needs a schemaId, type CL, a tag and a value", resourceType)
+		}
+	case AnonCredsRevRegDefType:
+		if obj.CredDefID == "" || obj.RevocDefType != "CL_ACCUM" || obj.Tag == "" || len(obj.Value) == 0 {
+			return fmt.Errorf("%s needs a credDefId, revocDefType CL_ACCUM, a tag and a value", resourceType)
+		}
+	case AnonCredsStatusListType:
+		if obj.RevRegDefID == "" || obj.CurrentAccumulator == "" {
+			return fmt.Errorf("%s needs a revRegDefId and a currentAccumulator", resourceType)
+		}
+		for _, bit := range obj.RevocationList {
+			if bit != 0 && bit != 1 {
+				return fmt.Errorf("%s revocationList entries must be 0 or 1", resourceType)
+			}
+		}
+	}
+	return nil
+}
+
+// anonCredsReference returns the identifier an AnonCreds object refers to and
+// the resource type it must resolve to.
+func anonCredsReference(resourceType string, data []byte) (string, string) {
+	var obj anonCredsObject
+	if json.Unmarshal(data, &obj) != nil {
+		return "", ""
+	}
+	switch resourceType {
+	case AnonCredsCredDefType:
+		return obj.SchemaID, AnonCredsSchemaType
+	case AnonCredsRevRegDefType:
+		return obj.CredDefID, AnonCredsCredDefType
+	case AnonCredsStatusListType:
+		return obj.RevRegDefID, AnonCredsRevRegDefType
+	}
+	return "", ""
+}
+
+// checkAnonCredsReference ensures an AnonCreds object stored on chain refers
+// to an existing object of the expected type. Schemas may live on other
+// ledgers, so credential definitions only check references to did:aytch
+// resources.
+func (k Keeper) checkAnonCredsReference(ctx sdk.Context, res Resource) error {
+	ref, refType := anonCredsReference(res.ResourceType, res.Data)
+	if ref == "" {
+		return nil
+	}
+	did, id, err := ParseResourceURL(ref)
+	if err != nil {
+		if res.ResourceType == AnonCredsCredDefType {
+			return nil
+		}
+		return err
+	}
+	if res.ResourceType == AnonCredsCredDefType && !strings.HasPrefix(did, "did:aytch:") {
+		return nil
+	}
+	target, err := k.GetResource(ctx, did, id)
+	if err != nil {
+		return fmt.Errorf("%s refers to %s: %w", res.ResourceType, ref, err)
+	}
+	if target.ResourceType != refType {
+		return fmt.Errorf("%s refers to %s of type %s, not %s", res.ResourceType, ref, target.ResourceType, refType)
+	}
+	if res.ResourceType == AnonCredsStatusListType && did != res.CollectionID {
+		return fmt.Errorf("status lists must be published by the issuer of their revocation registry")
+	}
+	return nil
+}
+
+// ParseResourceQuery splits a DID URL of the form
+// did?resourceName={name}&resourceType={type}[&resourceVersionTime={time}]
+// into the DID, resource name and type, and the Unix time the version must be
+// current at (0 for the latest). The time is RFC 3339 or Unix seconds.
+func ParseResourceQuery(didURL string) (string, string, string, int64, error) {
+	idx := strings.IndexByte(didURL, '?')
+	if idx <= 0 {
+		return "", "", "", 0, fmt.Errorf("not a resource query URL: %s", didURL)
+	}
+	q, err := url.ParseQuery(didURL[idx+1:])
+	if err != nil {
+		return "", "", "", 0, fmt.Errorf("invalid resource query in %s: %w", didURL, err)
+	}
+	name, resourceType := q.Get(ResourceNameParam), q.Get(ResourceTypeParam)
+	if name == "" || resourceType == "" {
+		return "", "", "", 0, fmt.Errorf("%s needs %s and %s", didURL, ResourceNameParam, ResourceTypeParam)
+	}
+	at, err := parseVersionTime(q.Get(ResourceVersionTimeParam))
+	if err != nil {
+		return "", "", "", 0, err
+	}
+	return didURL[:idx], name, resourceType, at, nil
+}
+
+func parseVersionTime(s string) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.Unix(), nil
+	}
+	at, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || at <= 0 {
+		return 0, fmt.Errorf("invalid %s %q", ResourceVersionTimeParam, s)
+	}
+	return at, nil
+}
@@ -0,0 +1,150 @@
+package did
+
+import (
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// Operations that can be proposed for a multi-controller DID.
+const (
+	ChangeOperationUpdate     = "update"
+	ChangeOperationDeactivate = "deactivate"
+)
+
+// PendingDIDChange is a proposed change to a DID with a ControllerThreshold
+// above one. The DID's controllers vote on it like the members of a group
+// policy: it is applied once ControllerThreshold of them have approved it,
+// and dropped once enough have rejected it that the threshold can't be met,
+// when the controllers or the threshold change, or at ExpiresAt.
+type PendingDIDChange struct {
+	ID         uint64      `protobuf:"varint,1,opt,name=id,proto3" json:"id"`
+	DID        string      `protobuf:"bytes,2,opt,name=did,proto3" json:"did"`
+	Operation  string      `protobuf:"bytes,3,opt,name=operation,proto3" json:"operation"`
+	Document   DIDDocument `protobuf:"bytes,4,opt,name=document,proto3" json:"document"`
+	Approvals  []string    `protobuf:"bytes,5,rep,name=approvals,proto3" json:"approvals"`
+	Rejections []string    `protobuf:"bytes,7,rep,name=rejections,proto3" json:"rejections,omitempty"`
+	ExpiresAt  int64       `protobuf:"varint,6,opt,name=expires_at,proto3" json:"expires_at"`
+}
+
+// MsgProposeDIDChange proposes an update or deactivation of a multi-controller
+// DID. The proposing controller's signature counts as the first approval, and
+// Nonce must be the DID's current nonce.
+type MsgProposeDIDChange struct {
+	DID       string         `protobuf:"bytes,1,opt,name=did,proto3" json:"did"`
+	Operation string         `protobuf:"bytes,2,opt,name=operation,proto3" json:"operation"`
+	Document  DIDDocument    `protobuf:"bytes,3,opt,name=document,proto3" json:"document"`
+	Nonce     uint64         `protobuf:"varint,7,opt,name=nonce,proto3" json:"nonce"`
+	Signer    string         `protobuf:"bytes,4,opt,name=signer,proto3" json:"signer"`
+	Signature []byte         `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
+	Creator   sdk.AccAddress `protobuf:"bytes,6,opt,name=creator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"creator"`
+}
+
+// ValidateBasic performs basic validation of MsgProposeDIDChange.
+func (msg MsgProposeDIDChange) ValidateBasic() error {
+	if err := validateChecksums(msg); err != nil {
+		return err
+	}
+	if msg.DID == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("DID cannot be empty")
+	}
+	if msg.Signer == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Signer cannot be empty")
+	}
+	switch msg.Operation {
+	case ChangeOperationDeactivate:
+		return nil
+	case ChangeOperationUpdate:
+		if msg.Document.ID != msg.DID {
+			return sdkerrors.ErrUnknownRequest.Wrap("Proposed document ID must match the DID")
+		}
+		if msg.Document.PublicKey == "" {
+			return sdkerrors.ErrUnknownRequest.Wrap("Public Key cannot be empty")
+		}
+		if msg.Document.Deactivated {
+			return sdkerrors.ErrUnknownRequest.Wrap("Use the deactivate operation to deactivate a DID")
+		}
+		return validateDocumentContent(msg.Document)
+	default:
+		return sdkerrors.ErrUnknownRequest.Wrapf("Unknown change operation %q", msg.Operation)
+	}
+}
+
+// ProofSignBytes returns the bytes a controller DID signs to propose the
+// change.
+func (msg MsgProposeDIDChange) ProofSignBytes() []byte {
+	msg.Signature = nil
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// Route returns the message route.
+func (msg MsgProposeDIDChange) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgProposeDIDChange) Type() string { return "propose_did_change" }
+
+// GetSignBytes returns the canonical bytes to sign over.
+func (msg MsgProposeDIDChange) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners returns the account that must sign the message.
+func (msg MsgProposeDIDChange) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
+
+// MsgApproveDIDChange adds a controller's vote to a pending change: an
+// approval, or a rejection if Reject is set. Each controller votes once.
+type MsgApproveDIDChange struct {
+	ChangeID  uint64         `protobuf:"varint,1,opt,name=change_id,proto3" json:"change_id"`
+	Reject    bool           `protobuf:"varint,5,opt,name=reject,proto3" json:"reject,omitempty"`
+	Signer    string         `protobuf:"bytes,2,opt,name=signer,proto3" json:"signer"`
+	Signature []byte         `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	Creator   sdk.AccAddress `protobuf:"bytes,4,opt,name=creator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"creator"`
+}
+
+// ValidateBasic performs basic validation of MsgApproveDIDChange.
+func (msg MsgApproveDIDChange) ValidateBasic() error {
+	if err := validateChecksums(msg); err != nil {
+		return err
+	}
+	if msg.ChangeID == 0 {
+		return sdkerrors.ErrUnknownRequest.Wrap("Change ID cannot be empty")
+	}
+	if msg.Signer == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Signer cannot be empty")
+	}
+	return nil
+}
+
+// ProofSignBytes returns the bytes a controller DID signs to vote on the
+// change.
+func (msg MsgApproveDIDChange) ProofSignBytes() []byte {
+	msg.Signature = nil
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// Route returns the message route.
+func (msg MsgApproveDIDChange) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgApproveDIDChange) Type() string { return "approve_did_change" }
+
+// GetSignBytes returns the canonical bytes to sign over.
+func (msg MsgApproveDIDChange) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners returns the account that must sign the message.
+func (msg MsgApproveDIDChange) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
@@ -0,0 +1,218 @@
+package did
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ProposeDIDChange records a change to a DID, approved by the proposing
+// controller. It returns the ID of the change. nonce must equal the DID's
+// current nonce, which the proposal advances so it can't be replayed. DIDs
+// with a ControllerThreshold of one or less are changed with MsgUpdateDID
+// and MsgDeactivateDID instead.
+func (k Keeper) ProposeDIDChange(ctx sdk.Context, change PendingDIDChange, nonce uint64, signer string, creator sdk.AccAddress, signBytes, signature []byte) (uint64, error) {
+	did, err := k.GetDID(ctx, change.DID)
+	if err != nil {
+		return 0, err
+	}
+	if did.ControllerThreshold <= 1 {
+		return 0, fmt.Errorf("DID %s needs a single controller approval; use MsgUpdateDID or MsgDeactivateDID", did.ID)
+	}
+	if err := checkNonce(did, nonce); err != nil {
+		return 0, err
+	}
+	if err := k.verifyControllerSignature(ctx, did, signer, creator, signBytes, signature); err != nil {
+		return 0, err
+	}
+	if change.Operation == ChangeOperationUpdate {
+		if err := k.CheckRotationLock(ctx, change.Document); err != nil {
+			return 0, err
+		}
+	}
+
+	change.ID = k.nextPendingChangeID(ctx)
+	change.Approvals = []string{signer}
+	change.ExpiresAt = ctx.BlockHeight() + int64(k.GetParams(ctx).ChangeExpiryBlocks)
+	k.setPendingDIDChange(ctx, change)
+	return change.ID, k.IncrementNonce(ctx, change.DID)
+}
+
+// VoteDIDChange adds a controller's approval, or its rejection if reject is
+// set, to a pending change. The change is applied once the DID's threshold
+// of approvals is met, and dropped once it no longer can be.
+func (k Keeper) VoteDIDChange(ctx sdk.Context, id uint64, reject bool, signer string, creator sdk.AccAddress, signBytes, signature []byte) error {
+	change, err := k.GetPendingDIDChange(ctx, id)
+	if err != nil {
+		return err
+	}
+	if ctx.BlockHeight() > change.ExpiresAt {
+		k.deletePendingDIDChange(ctx, change)
+		return fmt.Errorf("change %d expired at height %d", id, change.ExpiresAt)
+	}
+	did, err := k.GetDID(ctx, change.DID)
+	if err != nil {
+		return err
+	}
+	if err := k.verifyControllerSignature(ctx, did, signer, creator, signBytes, signature); err != nil {
+		return err
+	}
+	if containsString(change.Approvals, signer) || containsString(change.Rejections, signer) {
+		return fmt.Errorf("%s already voted on change %d", signer, id)
+	}
+	if reject {
+		change.Rejections = append(change.Rejections, signer)
+	} else {
+		change.Approvals = append(change.Approvals, signer)
+	}
+	return k.applyOrStoreChange(ctx, did, change)
+}
+
+// applyOrStoreChange applies the change if enough current controllers have
+// approved it, drops it if too many have rejected it for the threshold to be
+// met, and otherwise stores it as pending. Votes from parties that are no
+// longer controllers don't count.
+func (k Keeper) applyOrStoreChange(ctx sdk.Context, did DIDDocument, change PendingDIDChange) error {
+	threshold := int(did.ControllerThreshold)
+	if threshold < 1 {
+		threshold = 1
+	}
+	approvals := countControllers(did, change.Approvals)
+	if approvals < threshold {
+		if len(did.Controllers())-countControllers(did, change.Rejections) < threshold {
+			k.deletePendingDIDChange(ctx, change)
+			emitDIDChangeClosed(ctx, EventTypeDIDChangeRejected, change)
+			return nil
+		}
+		k.setPendingDIDChange(ctx, change)
+		return nil
+	}
+
+	k.deletePendingDIDChange(ctx, change)
+	switch change.Operation {
+	case ChangeOperationUpdate:
+		return k.UpdateDID(ctx, change.Document)
+	case ChangeOperationDeactivate:
+		if err := k.DeactivateDID(ctx, change.DID); err != nil {
+			return err
+		}
+		return k.RefundFeeEscrow(ctx, change.DID)
+	default:
+		return fmt.Errorf("unknown change operation %q", change.Operation)
+	}
+}
+
+// countControllers returns how many of the parties are controllers of the
+// DID.
+func countControllers(did DIDDocument, parties []string) int {
+	n := 0
+	for _, p := range parties {
+		if isController(did, p) {
+			n++
+		}
+	}
+	return n
+}
+
+// abortPendingDIDChanges drops every pending change to a DID. It is called
+// when the DID's controllers or threshold change, since the votes were cast
+// under the previous ones.
+func (k Keeper) abortPendingDIDChanges(ctx sdk.Context, did string) {
+	var changes []PendingDIDChange
+	iteratePrefix(ctx.KVStore(k.storeKey), pendingChangesKey(did), func(_, value []byte) bool {
+		var change PendingDIDChange
+		k.cdc.MustUnmarshal(value, &change)
+		changes = append(changes, change)
+		return false
+	})
+	for _, change := range changes {
+		k.deletePendingDIDChange(ctx, change)
+		emitDIDChangeClosed(ctx, EventTypeDIDChangeAborted, change)
+	}
+}
+
+// GetPendingDIDChange returns a pending change by ID.
+func (k Keeper) GetPendingDIDChange(ctx sdk.Context, id uint64) (PendingDIDChange, error) {
+	store := ctx.KVStore(k.storeKey)
+	did := store.Get(pendingChangeIDKey(id))
+	if did == nil {
+		return PendingDIDChange{}, fmt.Errorf("pending change %d not found", id)
+	}
+	value := store.Get(pendingChangeKey(string(did), id))
+	if value == nil {
+		return PendingDIDChange{}, fmt.Errorf("pending change %d not found", id)
+	}
+	var change PendingDIDChange
+	k.cdc.MustUnmarshal(value, &change)
+	return change, nil
+}
+
+// GetPendingDIDChanges returns the unexpired pending changes for a DID.
+func (k Keeper) GetPendingDIDChanges(ctx sdk.Context, did string) []PendingDIDChange {
+	var changes []PendingDIDChange
+	iteratePrefix(ctx.KVStore(k.storeKey), pendingChangesKey(did), func(_, value []byte) bool {
+		var change PendingDIDChange
+		k.cdc.MustUnmarshal(value, &change)
+		if ctx.BlockHeight() <= change.ExpiresAt {
+			changes = append(changes, change)
+		}
+		return false
+	})
+	return changes
+}
+
+// GetAllPendingDIDChanges returns every pending change in the store,
+// including expired changes the end blocker has yet to prune.
+func (k Keeper) GetAllPendingDIDChanges(ctx sdk.Context) []PendingDIDChange {
+	var changes []PendingDIDChange
+	iteratePrefix(ctx.KVStore(k.storeKey), PendingChangePrefix, func(_, value []byte) bool {
+		var change PendingDIDChange
+		k.cdc.MustUnmarshal(value, &change)
+		changes = append(changes, change)
+		return false
+	})
+	return changes
+}
+
+// PruneExpiredDIDChanges deletes pending changes whose expiry height has
+// passed.
+func (k Keeper) PruneExpiredDIDChanges(ctx sdk.Context) {
+	for _, e := range dueEntries(ctx.KVStore(k.storeKey), PendingChangeExpiryPrefix, ctx.BlockHeight()-1) {
+		if change, err := k.GetPendingDIDChange(ctx, binary.BigEndian.Uint64(e.suffix)); err == nil {
+			k.deletePendingDIDChange(ctx, change)
+		}
+	}
+}
+
+func (k Keeper) setPendingDIDChange(ctx sdk.Context, change PendingDIDChange) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(pendingChangeKey(change.DID, change.ID), k.cdc.MustMarshal(&change))
+	store.Set(pendingChangeIDKey(change.ID), []byte(change.DID))
+	store.Set(pendingChangeExpiryKey(change.ExpiresAt, change.ID), []byte{})
+}
+
+func (k Keeper) deletePendingDIDChange(ctx sdk.Context, change PendingDIDChange) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(pendingChangeKey(change.DID, change.ID))
+	store.Delete(pendingChangeIDKey(change.ID))
+	store.Delete(pendingChangeExpiryKey(change.ExpiresAt, change.ID))
+}
+
+func (k Keeper) nextPendingChangeID(ctx sdk.Context) uint64 {
+	id := k.getNextPendingChangeID(ctx)
+	k.setNextPendingChangeID(ctx, id+1)
+	return id
+}
+
+// getNextPendingChangeID returns the ID the next proposed change gets.
+func (k Keeper) getNextPendingChangeID(ctx sdk.Context) uint64 {
+	if bz := ctx.KVStore(k.storeKey).Get(PendingChangeSeqKey); bz != nil {
+		return binary.BigEndian.Uint64(bz)
+	}
+	return 1
+}
+
+func (k Keeper) setNextPendingChangeID(ctx sdk.Context, id uint64) {
+	ctx.KVStore(k.storeKey).Set(PendingChangeSeqKey, sdk.Uint64ToBigEndian(id))
+}
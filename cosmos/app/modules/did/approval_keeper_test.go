@@ -0,0 +1,165 @@
+package did
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// orgKeeper returns a keeper with a DID controlled by three accounts, two of
+// which must approve changes.
+func orgKeeper(t *testing.T) (Keeper, sdk.Context, DIDDocument, []sdk.AccAddress) {
+	t.Helper()
+	k, ctx, _, _ := testKeeper(t)
+	members := []sdk.AccAddress{testAddr("a"), testAddr("b"), testAddr("c")}
+	did := newTestSigner("org").document(members[0].String(), members[1].String(), members[2].String())
+	did.ControllerThreshold = 2
+	mustCreate(t, k, ctx, did)
+	return k, ctx, did, members
+}
+
+// propose proposes an update of the org DID by member, adding endpoint.
+func propose(t *testing.T, k Keeper, ctx sdk.Context, did DIDDocument, member sdk.AccAddress, endpoint string) uint64 {
+	t.Helper()
+	current, err := k.GetDID(ctx, did.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := did
+	doc.ServiceEndpoints = []string{endpoint}
+	change := PendingDIDChange{DID: did.ID, Operation: ChangeOperationUpdate, Document: doc}
+	id, err := k.ProposeDIDChange(ctx, change, current.Nonce, member.String(), member, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
+
+func vote(k Keeper, ctx sdk.Context, id uint64, reject bool, member sdk.AccAddress) error {
+	return k.VoteDIDChange(ctx, id, reject, member.String(), member, nil, nil)
+}
+
+func TestVoteDIDChangeAppliesAtThreshold(t *testing.T) {
+	k, ctx, did, members := orgKeeper(t)
+	id := propose(t, k, ctx, did, members[0], "https://example.com/org")
+
+	if err := vote(k, ctx, id, false, members[0]); err == nil {
+		t.Error("proposer voted twice")
+	}
+	if err := vote(k, ctx, id, false, testAddr("outsider")); err == nil {
+		t.Error("non-controller voted")
+	}
+	if err := vote(k, ctx, id, false, members[1]); err != nil {
+		t.Fatal(err)
+	}
+	got, err := k.GetDID(ctx, did.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.ServiceEndpoints) != 1 || got.ServiceEndpoints[0] != "https://example.com/org" {
+		t.Errorf("approved change not applied: endpoints %v", got.ServiceEndpoints)
+	}
+	if _, err := k.GetPendingDIDChange(ctx, id); err == nil {
+		t.Error("applied change still pending")
+	}
+}
+
+func TestVoteDIDChangeRejected(t *testing.T) {
+	k, ctx, did, members := orgKeeper(t)
+	id := propose(t, k, ctx, did, members[0], "https://example.com/org")
+
+	if err := vote(k, ctx, id, true, members[1]); err != nil {
+		t.Fatal(err)
+	}
+	change, err := k.GetPendingDIDChange(ctx, id)
+	if err != nil {
+		t.Fatal("change dropped while the threshold can still be met")
+	}
+	if len(change.Rejections) != 1 {
+		t.Errorf("rejections = %v", change.Rejections)
+	}
+	if err := vote(k, ctx, id, false, members[1]); err == nil {
+		t.Error("member voted twice")
+	}
+
+	if err := vote(k, ctx, id, true, members[2]); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := k.GetPendingDIDChange(ctx, id); err == nil {
+		t.Error("change that can no longer pass still pending")
+	}
+	if !hasEvent(ctx, EventTypeDIDChangeRejected) {
+		t.Error("no did_change_rejected event")
+	}
+	if got, _ := k.GetDID(ctx, did.ID); len(got.ServiceEndpoints) != 0 {
+		t.Error("rejected change applied")
+	}
+}
+
+// recordingHooks records the DIDs whose controllers changed.
+type recordingHooks struct {
+	changed *[]string
+}
+
+func (h recordingHooks) AfterControllersChanged(_ sdk.Context, _, current DIDDocument) {
+	*h.changed = append(*h.changed, current.ID)
+}
+
+func TestControllerChangeAbortsPendingChanges(t *testing.T) {
+	k, ctx, did, members := orgKeeper(t)
+	var changed []string
+	k.AddControllerHooks(recordingHooks{&changed})
+	stale := propose(t, k, ctx, did, members[0], "https://example.com/stale")
+
+	// Replacing a member through an approved change aborts the others.
+	current, _ := k.GetDID(ctx, did.ID)
+	doc := did
+	doc.Controller = []string{members[0].String(), members[1].String(), testAddr("d").String()}
+	change := PendingDIDChange{DID: did.ID, Operation: ChangeOperationUpdate, Document: doc}
+	id, err := k.ProposeDIDChange(ctx, change, current.Nonce, members[1].String(), members[1], nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := vote(k, ctx, id, false, members[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := k.GetPendingDIDChange(ctx, stale); err == nil {
+		t.Error("change proposed to the previous controllers still pending")
+	}
+	if !hasEvent(ctx, EventTypeDIDChangeAborted) {
+		t.Error("no did_change_aborted event")
+	}
+	if len(changed) != 1 || changed[0] != did.ID {
+		t.Errorf("hooks called for %v, want [%s]", changed, did.ID)
+	}
+
+	// Writes that keep the controllers don't call the hooks.
+	got, _ := k.GetDID(ctx, did.ID)
+	if err := k.UpdateDID(ctx, got); err != nil {
+		t.Fatal(err)
+	}
+	if len(changed) != 1 {
+		t.Errorf("hooks called for an update keeping the controllers: %v", changed)
+	}
+}
+
+func TestPruneExpiredDIDChanges(t *testing.T) {
+	k, ctx, did, members := orgKeeper(t)
+	params := k.GetParams(ctx)
+	params.ChangeExpiryBlocks = 10
+	k.SetParams(ctx, params)
+	id := propose(t, k, ctx, did, members[0], "https://example.com/org")
+
+	k.PruneExpiredDIDChanges(ctx.WithBlockHeight(11))
+	if _, err := k.GetPendingDIDChange(ctx, id); err != nil {
+		t.Fatal("change pruned before it expired")
+	}
+	if err := vote(k, ctx.WithBlockHeight(12), id, false, members[1]); err == nil {
+		t.Error("vote on an expired change accepted")
+	}
+	k.PruneExpiredDIDChanges(ctx.WithBlockHeight(12))
+	if changes := k.GetAllPendingDIDChanges(ctx); len(changes) != 0 {
+		t.Errorf("%d changes left after pruning", len(changes))
+	}
+}
@@ -0,0 +1,50 @@
+package did
+
+import (
+	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"google.golang.org/grpc"
+)
+
+// AuditEntry records one write to a DID document: what kind of change it
+// was, the message that caused it, the controller that authorized it, the
+// account that submitted it and the transaction it was included in. Signer
+// is the message's Signer, or the submitting account for messages without
+// one. Writes made by the EndBlocker, such as expiries and delayed key
+// rotations, have no Msg, Signer, Creator or TxHash.
+type AuditEntry struct {
+	DID       string `protobuf:"bytes,1,opt,name=did,proto3" json:"did"`
+	Version   uint64 `protobuf:"varint,2,opt,name=version,proto3" json:"version"`
+	Operation string `protobuf:"bytes,3,opt,name=operation,proto3" json:"operation"`
+	Msg       string `protobuf:"bytes,4,opt,name=msg,proto3" json:"msg,omitempty"`
+	Signer    string `protobuf:"bytes,5,opt,name=signer,proto3" json:"signer,omitempty"`
+	Height    int64  `protobuf:"varint,6,opt,name=height,proto3" json:"height"`
+	TxHash    string `protobuf:"bytes,7,opt,name=tx_hash,proto3" json:"tx_hash,omitempty"`
+	Creator   string `protobuf:"bytes,8,opt,name=creator,proto3" json:"creator,omitempty"`
+}
+
+type auditMsgKey struct{}
+
+// withAuditMsg attaches the message being handled to ctx so the writes it
+// causes are attributed to it in the audit log.
+func withAuditMsg(ctx sdk.Context, msg sdk.Msg) sdk.Context {
+	return ctx.WithValue(auditMsgKey{}, msg)
+}
+
+// auditMsg returns the message attached by withAuditMsg, if any.
+func auditMsg(ctx sdk.Context) (sdk.Msg, bool) {
+	msg, ok := ctx.Value(auditMsgKey{}).(sdk.Msg)
+	return msg, ok
+}
+
+// auditInterceptor attaches each Msg service request to the SDK context
+// with withAuditMsg.
+func auditInterceptor(goCtx context.Context, req interface{}, _ *grpc.UnaryServerInfo, next grpc.UnaryHandler) (interface{}, error) {
+	ctx, ok := goCtx.Value(sdk.SdkContextKey).(sdk.Context)
+	msg, isMsg := req.(sdk.Msg)
+	if !ok || !isMsg {
+		return next(goCtx, req)
+	}
+	return next(context.WithValue(goCtx, sdk.SdkContextKey, withAuditMsg(ctx, msg)), req)
+}
@@ -0,0 +1,44 @@
+package did
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+)
+
+// recordAudit appends an entry for a write of did to its audit log.
+func (k Keeper) recordAudit(ctx sdk.Context, did DIDDocument, op string) {
+	entry := AuditEntry{
+		DID:       did.ID,
+		Version:   did.Nonce,
+		Operation: op,
+		Height:    ctx.BlockHeight(),
+	}
+	if msg, ok := auditMsg(ctx); ok {
+		entry.Msg = sdk.MsgTypeURL(msg)
+		if signers := msg.GetSigners(); len(signers) > 0 {
+			entry.Creator = signers[0].String()
+		}
+		entry.Signer = controllerSigner(msg)
+		if entry.Signer == "" {
+			entry.Signer = entry.Creator
+		}
+	}
+	if txBytes := ctx.TxBytes(); len(txBytes) > 0 {
+		entry.TxHash = fmt.Sprintf("%X", tmhash.Sum(txBytes))
+	}
+	ctx.KVStore(k.storeKey).Set(auditKey(did.ID, did.Nonce), k.cdc.MustMarshal(&entry))
+}
+
+// GetAuditLog returns the audit log of a DID, oldest entry first.
+func (k Keeper) GetAuditLog(ctx sdk.Context, did string) []AuditEntry {
+	var entries []AuditEntry
+	iteratePrefix(ctx.KVStore(k.storeKey), auditPrefix(did), func(_, value []byte) bool {
+		var entry AuditEntry
+		k.cdc.MustUnmarshal(value, &entry)
+		entries = append(entries, entry)
+		return false
+	})
+	return entries
+}
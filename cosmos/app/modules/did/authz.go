@@ -0,0 +1,146 @@
+package did
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+)
+
+// Operations an UpdateDIDAuthorization can grant. Each covers a group of
+// document fields; an update is accepted only if every group it changes is
+// granted.
+const (
+	AuthzOperationRotateKeys        = "rotate_keys"
+	AuthzOperationUpdateServices    = "update_services"
+	AuthzOperationUpdateControllers = "update_controllers"
+	AuthzOperationRenew             = "renew"
+	// AuthzOperationUpdateContent covers the JSON-LD context and the content
+	// CID, which change how the whole document is read.
+	AuthzOperationUpdateContent = "update_content"
+)
+
+var _ authz.Authorization = &UpdateDIDAuthorization{}
+
+// UpdateDIDAuthorization lets a grantee submit MsgUpdateDID on behalf of an
+// address controller, limited to the listed DIDs and operations. Expiry is
+// handled by the authz grant itself.
+type UpdateDIDAuthorization struct {
+	DIDs       []string `protobuf:"bytes,1,rep,name=dids,proto3" json:"dids"`
+	Operations []string `protobuf:"bytes,2,rep,name=operations,proto3" json:"operations"`
+}
+
+// NewUpdateDIDAuthorization creates an UpdateDIDAuthorization.
+func NewUpdateDIDAuthorization(dids, operations []string) *UpdateDIDAuthorization {
+	return &UpdateDIDAuthorization{DIDs: dids, Operations: operations}
+}
+
+// MsgTypeURL implements authz.Authorization.
+func (a UpdateDIDAuthorization) MsgTypeURL() string {
+	return sdk.MsgTypeURL(&MsgUpdateDID{})
+}
+
+// Accept implements authz.Authorization. Authorizations have no access to
+// the DID store, so the grantee declares the update's Operations and Accept
+// checks them against the grant; AuthorizeUpdate then rejects the update if
+// it changes anything outside them.
+func (a UpdateDIDAuthorization) Accept(ctx sdk.Context, msg sdk.Msg) (authz.AcceptResponse, error) {
+	update, ok := msg.(*MsgUpdateDID)
+	if !ok {
+		return authz.AcceptResponse{}, fmt.Errorf("unexpected message type %T", msg)
+	}
+	if !containsString(a.DIDs, update.ID) {
+		return authz.AcceptResponse{}, fmt.Errorf("authorization does not cover %s", update.ID)
+	}
+	if len(update.Operations) == 0 {
+		return authz.AcceptResponse{}, fmt.Errorf("update of %s must declare its operations", update.ID)
+	}
+	for _, op := range update.Operations {
+		if !containsString(a.Operations, op) {
+			return authz.AcceptResponse{}, fmt.Errorf("authorization does not grant %s on %s", op, update.ID)
+		}
+	}
+	return authz.AcceptResponse{Accept: true}, nil
+}
+
+// ValidateBasic implements authz.Authorization.
+func (a UpdateDIDAuthorization) ValidateBasic() error {
+	if len(a.DIDs) == 0 {
+		return fmt.Errorf("authorization must list at least one DID")
+	}
+	for _, id := range a.DIDs {
+		if id == "" {
+			return fmt.Errorf("authorized DID cannot be empty")
+		}
+	}
+	if len(a.Operations) == 0 {
+		return fmt.Errorf("authorization must grant at least one operation")
+	}
+	for _, op := range a.Operations {
+		if !isAuthzOperation(op) {
+			return fmt.Errorf("unknown operation %q", op)
+		}
+	}
+	return nil
+}
+
+func isAuthzOperation(op string) bool {
+	switch op {
+	case AuthzOperationRotateKeys, AuthzOperationUpdateServices, AuthzOperationUpdateControllers, AuthzOperationRenew, AuthzOperationUpdateContent:
+		return true
+	default:
+		return false
+	}
+}
+
+// changedOperations returns the operations needed to turn existing into
+// updated.
+func changedOperations(existing, updated DIDDocument) []string {
+	var ops []string
+	if existing.PublicKey != updated.PublicKey ||
+		existing.Authentication != updated.Authentication ||
+		!sameJSON(existing.VerificationMethods, updated.VerificationMethods) ||
+		!sameJSON(existing.KeyAgreement, updated.KeyAgreement) ||
+		existing.RotationDelay != updated.RotationDelay {
+		ops = append(ops, AuthzOperationRotateKeys)
+	}
+	if !sameJSON(existing.ServiceEndpoints, updated.ServiceEndpoints) ||
+		!sameJSON(existing.Services, updated.Services) {
+		ops = append(ops, AuthzOperationUpdateServices)
+	}
+	if !sameJSON(existing.Controller, updated.Controller) ||
+		existing.ControllerThreshold != updated.ControllerThreshold {
+		ops = append(ops, AuthzOperationUpdateControllers)
+	}
+	if existing.ExpiresAt != updated.ExpiresAt {
+		ops = append(ops, AuthzOperationRenew)
+	}
+	if !sameJSON(existing.Context, updated.Context) || existing.ContentCID != updated.ContentCID {
+		ops = append(ops, AuthzOperationUpdateContent)
+	}
+	return ops
+}
+
+// sameJSON compares two values by their JSON encoding, treating nil and empty
+// slices as equal.
+func sameJSON(a, b interface{}) bool {
+	ab, _ := json.Marshal(a)
+	bb, _ := json.Marshal(b)
+	if string(ab) == "null" {
+		ab = []byte("[]")
+	}
+	if string(bb) == "null" {
+		bb = []byte("[]")
+	}
+	return string(ab) == string(bb)
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
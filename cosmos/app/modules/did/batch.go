@@ -0,0 +1,93 @@
+package did
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// MaxCreateDIDBatchSize is the most documents one MsgCreateDIDBatch may
+// register.
+const MaxCreateDIDBatchSize = 100
+
+// MsgCreateDIDBatch registers several DIDs at once for issuers onboarding
+// many subjects, such as device fleets. The documents are created in order
+// and atomically: if any of them can't be created, none are. The creator
+// signs once and pays CreateDIDFee for every document in a single transfer.
+type MsgCreateDIDBatch struct {
+	Documents []DIDDocument  `protobuf:"bytes,1,rep,name=documents,proto3" json:"documents"`
+	Creator   sdk.AccAddress `protobuf:"bytes,2,opt,name=creator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"creator"`
+}
+
+// CreateMsgs returns the documents as the MsgCreateDID each would have been
+// registered with individually.
+func (msg MsgCreateDIDBatch) CreateMsgs() []MsgCreateDID {
+	msgs := make([]MsgCreateDID, len(msg.Documents))
+	for i, doc := range msg.Documents {
+		msgs[i] = MsgCreateDID{
+			ID:                  doc.ID,
+			PublicKey:           doc.PublicKey,
+			ServiceEndpoints:    doc.ServiceEndpoints,
+			Authentication:      doc.Authentication,
+			VerificationMethods: doc.VerificationMethods,
+			KeyAgreement:        doc.KeyAgreement,
+			Services:            doc.Services,
+			Controller:          doc.Controller,
+			ControllerThreshold: doc.ControllerThreshold,
+			RotationDelay:       doc.RotationDelay,
+			ExpiresAt:           doc.ExpiresAt,
+			Context:             doc.Context,
+			ContentCID:          doc.ContentCID,
+			Creator:             msg.Creator,
+		}
+	}
+	return msgs
+}
+
+// ValidateBasic performs basic validation of MsgCreateDIDBatch, validating
+// each document as MsgCreateDID would.
+func (msg MsgCreateDIDBatch) ValidateBasic() error {
+	if err := validateChecksums(msg); err != nil {
+		return err
+	}
+	if msg.Creator.Empty() {
+		return sdkerrors.ErrUnknownRequest.Wrap("Creator cannot be empty")
+	}
+	if len(msg.Documents) == 0 {
+		return sdkerrors.ErrUnknownRequest.Wrap("Batch must contain at least one document")
+	}
+	if len(msg.Documents) > MaxCreateDIDBatchSize {
+		return sdkerrors.ErrUnknownRequest.Wrapf("Batch cannot contain more than %d documents", MaxCreateDIDBatchSize)
+	}
+	seen := make(map[string]bool, len(msg.Documents))
+	for i, create := range msg.CreateMsgs() {
+		if msg.Documents[i].Deactivated {
+			return sdkerrors.ErrUnknownRequest.Wrapf("document %d: Cannot create a deactivated DID", i)
+		}
+		if seen[create.ID] {
+			return sdkerrors.ErrUnknownRequest.Wrapf("document %d: %s appears more than once", i, create.ID)
+		}
+		seen[create.ID] = true
+		if err := create.ValidateBasic(); err != nil {
+			return fmt.Errorf("document %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Route returns the message route.
+func (msg MsgCreateDIDBatch) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgCreateDIDBatch) Type() string { return "create_did_batch" }
+
+// GetSignBytes returns the canonical bytes to sign over.
+func (msg MsgCreateDIDBatch) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners returns the account that must sign the message.
+func (msg MsgCreateDIDBatch) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
@@ -0,0 +1,92 @@
+package did
+
+import (
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// MaxRevokeCredentialsSize is the most credentials one MsgRevokeCredentials
+// may revoke.
+const MaxRevokeCredentialsSize = 10000
+
+// MsgRevokeCredentials revokes many credentials of Issuer at once, e.g. after
+// a key compromise: those listed by ID and those registered at
+// StatusListIndexes of the issuer's StatusListCredential. The revocations
+// are atomic, credentials already revoked are skipped, and GasPerRevocation
+// is charged per credential. A controller of Issuer authorizes it like a
+// MsgUpdateDID.
+type MsgRevokeCredentials struct {
+	Issuer               string         `protobuf:"bytes,1,opt,name=issuer,proto3" json:"issuer"`
+	CredentialIDs        []string       `protobuf:"bytes,2,rep,name=credential_ids,proto3" json:"credential_ids,omitempty"`
+	StatusListCredential string         `protobuf:"bytes,3,opt,name=status_list_credential,proto3" json:"status_list_credential,omitempty"`
+	StatusListIndexes    []uint64       `protobuf:"varint,4,rep,packed,name=status_list_indexes,proto3" json:"status_list_indexes,omitempty"`
+	Reason               string         `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason"`
+	Nonce                uint64         `protobuf:"varint,6,opt,name=nonce,proto3" json:"nonce"`
+	Signer               string         `protobuf:"bytes,7,opt,name=signer,proto3" json:"signer"`
+	Signature            []byte         `protobuf:"bytes,8,opt,name=signature,proto3" json:"signature,omitempty"`
+	Creator              sdk.AccAddress `protobuf:"bytes,9,opt,name=creator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"creator"`
+}
+
+// ValidateBasic performs basic validation of MsgRevokeCredentials.
+func (msg MsgRevokeCredentials) ValidateBasic() error {
+	if err := validateChecksums(msg); err != nil {
+		return err
+	}
+	if msg.Issuer == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Issuer DID cannot be empty")
+	}
+	if msg.Signer == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Signer cannot be empty")
+	}
+	n := len(msg.CredentialIDs) + len(msg.StatusListIndexes)
+	if n == 0 {
+		return sdkerrors.ErrUnknownRequest.Wrap("Revocation must name at least one credential")
+	}
+	if n > MaxRevokeCredentialsSize {
+		return sdkerrors.ErrUnknownRequest.Wrapf("Revocation cannot name more than %d credentials", MaxRevokeCredentialsSize)
+	}
+	for i, id := range msg.CredentialIDs {
+		if id == "" {
+			return sdkerrors.ErrUnknownRequest.Wrapf("credential %d: Credential ID cannot be empty", i)
+		}
+	}
+	if len(msg.StatusListIndexes) > 0 {
+		did, _, err := ParseResourceURL(msg.StatusListCredential)
+		if err != nil {
+			return sdkerrors.ErrUnknownRequest.Wrap(err.Error())
+		}
+		if did != msg.Issuer {
+			return sdkerrors.ErrUnknownRequest.Wrapf("status list %s is not a resource of issuer %s", msg.StatusListCredential, msg.Issuer)
+		}
+	}
+	return nil
+}
+
+// ProofSignBytes returns the bytes a controller DID signs to authorize the
+// revocations.
+func (msg MsgRevokeCredentials) ProofSignBytes() []byte {
+	msg.Signature = nil
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// Route returns the message route.
+func (msg MsgRevokeCredentials) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgRevokeCredentials) Type() string { return "revoke_credentials" }
+
+// GetSignBytes returns the canonical bytes to sign over.
+func (msg MsgRevokeCredentials) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners returns the account that must sign the message.
+func (msg MsgRevokeCredentials) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
@@ -0,0 +1,43 @@
+package did
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// RevokeCredentials revokes the credentials of issuer named by ID and those
+// registered at the given indexes of statusList, charging GasPerRevocation
+// for each. Credentials already revoked are skipped; it returns how many
+// were revoked.
+func (k Keeper) RevokeCredentials(ctx sdk.Context, issuer string, ids []string, statusList string, indexes []uint64, reason string) (int, error) {
+	ctx.GasMeter().ConsumeGas(k.GetParams(ctx).GasPerRevocation*uint64(len(ids)+len(indexes)), "credential revocations")
+	ids = append([]string{}, ids...)
+	for _, index := range indexes {
+		id, found := k.CredentialAtStatusIndex(ctx, statusList, index)
+		if !found {
+			return 0, fmt.Errorf("no credential is registered at index %d of %s", index, statusList)
+		}
+		ids = append(ids, id)
+	}
+	revoked := 0
+	for _, id := range ids {
+		status, err := k.GetCredentialStatus(ctx, id)
+		if err != nil {
+			return 0, err
+		}
+		if status.Revoked {
+			continue
+		}
+		if err := k.RevokeCredential(ctx, id, issuer, reason); err != nil {
+			return 0, fmt.Errorf("credential %s: %w", id, err)
+		}
+		revoked++
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent("credentials_revoked",
+		sdk.NewAttribute("issuer", issuer),
+		sdk.NewAttribute("count", fmt.Sprintf("%d", revoked)),
+		sdk.NewAttribute("reason", reason),
+	))
+	return revoked, nil
+}
@@ -0,0 +1,109 @@
+package did
+
+import (
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/spf13/cobra"
+)
+
+// FlagReadCacheSize sets how many resolved DID documents a node keeps in
+// memory for queries. Zero disables the cache.
+const FlagReadCacheSize = "did.read-cache-size"
+
+// AddModuleInitFlags adds the DID module's start command flags.
+func AddModuleInitFlags(startCmd *cobra.Command) {
+	startCmd.Flags().Int(FlagReadCacheSize, 0, "Number of DID documents to cache for queries (0 disables the cache)")
+	startCmd.Flags().Bool(FlagRESTAuth, false, "Require a DID-Auth token on mutating REST requests")
+}
+
+// readCache is a bounded LRU of documents as of the latest committed height.
+// It only serves queries and never affects state.
+//
+// Writes can't update the cache directly: they're made during DeliverTx,
+// while queries still read the last committed state. Instead a write evicts
+// the document and marks it dirty so queries stop caching it until the block
+// that wrote it has been committed, which BeginBlock of the next block
+// signals.
+type readCache struct {
+	docs *lru.Cache
+
+	mu        sync.Mutex
+	committed int64
+	dirty     map[string]struct{}
+}
+
+// WithReadCache returns a copy of the keeper that caches up to size
+// documents for queries. A size of zero or less leaves the cache disabled.
+func (k Keeper) WithReadCache(size int) Keeper {
+	if size <= 0 {
+		return k
+	}
+	docs, err := lru.New(size)
+	if err != nil {
+		panic(err)
+	}
+	k.cache = &readCache{docs: docs, dirty: make(map[string]struct{})}
+	return k
+}
+
+// resolveDID is GetDID for queries: it serves documents from the read cache
+// when the query is against the latest committed height. The returned
+// document may be shared with other callers and must not be modified.
+func (k Keeper) resolveDID(ctx sdk.Context, id string) (DIDDocument, error) {
+	c := k.cache
+	if c == nil {
+		return k.GetDID(ctx, id)
+	}
+
+	c.mu.Lock()
+	_, dirty := c.dirty[id]
+	usable := !dirty && ctx.BlockHeight() == c.committed
+	c.mu.Unlock()
+	if !usable {
+		return k.GetDID(ctx, id)
+	}
+
+	if did, ok := c.docs.Get(id); ok {
+		return did.(DIDDocument), nil
+	}
+	did, err := k.GetDID(ctx, id)
+	if err != nil {
+		return did, err
+	}
+
+	// A write may have landed while the document was being read.
+	c.mu.Lock()
+	if _, dirty := c.dirty[id]; !dirty && ctx.BlockHeight() == c.committed {
+		c.docs.Add(id, did)
+	}
+	c.mu.Unlock()
+	return did, nil
+}
+
+// invalidateCache evicts a written document. Writes made while checking
+// transactions don't reach committed state and are ignored.
+func (k Keeper) invalidateCache(ctx sdk.Context, id string) {
+	c := k.cache
+	if c == nil || ctx.IsCheckTx() {
+		return
+	}
+	c.mu.Lock()
+	c.dirty[id] = struct{}{}
+	c.docs.Remove(id)
+	c.mu.Unlock()
+}
+
+// commitCache records that the previous block has been committed, so the
+// documents it wrote may be cached again.
+func (k Keeper) commitCache(ctx sdk.Context) {
+	c := k.cache
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	c.committed = ctx.BlockHeight() - 1
+	c.dirty = make(map[string]struct{})
+	c.mu.Unlock()
+}
@@ -0,0 +1,208 @@
+package did
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// Capabilities a DID can delegate with MsgGrantCapability. Each lets the
+// grantee make the updates of one group of document fields, the same groups
+// UpdateDIDAuthorization grants to accounts.
+const (
+	CapabilityRotateKeys             = "rotate-keys"
+	CapabilityUpdateServiceEndpoints = "update-service-endpoints"
+	CapabilityUpdateControllers      = "update-controllers"
+	CapabilityRenew                  = "renew"
+)
+
+// capabilityOperations maps each capability to the update operation it
+// covers.
+var capabilityOperations = map[string]string{
+	CapabilityRotateKeys:             AuthzOperationRotateKeys,
+	CapabilityUpdateServiceEndpoints: AuthzOperationUpdateServices,
+	CapabilityUpdateControllers:      AuthzOperationUpdateControllers,
+	CapabilityRenew:                  AuthzOperationRenew,
+}
+
+// updateCapabilities returns the capabilities an update of existing to
+// updated needs. Content changes, which no capability covers, need the
+// update action itself, ZcapActionUpdate.
+func updateCapabilities(existing, updated DIDDocument) []string {
+	var capabilities []string
+	for _, op := range changedOperations(existing, updated) {
+		if op == AuthzOperationUpdateContent {
+			capabilities = append(capabilities, ZcapActionUpdate)
+		}
+		for capability, covered := range capabilityOperations {
+			if covered == op {
+				capabilities = append(capabilities, capability)
+			}
+		}
+	}
+	return capabilities
+}
+
+// CapabilityGrant delegates a capability over DID to the Grantee DID, which
+// can then sign MsgUpdateDID as Signer for the changes the capability covers.
+// A grant lapses after block ExpiresHeight or once the block time passes
+// ExpiresAt (unix seconds), whichever is set and comes first.
+type CapabilityGrant struct {
+	DID           string `protobuf:"bytes,1,opt,name=did,proto3" json:"did"`
+	Grantee       string `protobuf:"bytes,2,opt,name=grantee,proto3" json:"grantee"`
+	Capability    string `protobuf:"bytes,3,opt,name=capability,proto3" json:"capability"`
+	ExpiresHeight int64  `protobuf:"varint,4,opt,name=expires_height,proto3" json:"expires_height,omitempty"`
+	ExpiresAt     int64  `protobuf:"varint,5,opt,name=expires_at,proto3" json:"expires_at,omitempty"`
+	Granted       int64  `protobuf:"varint,6,opt,name=granted,proto3" json:"granted"`
+}
+
+// Active reports whether the grant is still in force in the block of ctx.
+func (g CapabilityGrant) Active(ctx sdk.Context) bool {
+	if g.ExpiresHeight != 0 && ctx.BlockHeight() > g.ExpiresHeight {
+		return false
+	}
+	if g.ExpiresAt != 0 && ctx.BlockTime().Unix() >= g.ExpiresAt {
+		return false
+	}
+	return true
+}
+
+// validateCapabilityGrant checks the fields shared by grants and the
+// messages that make them.
+func validateCapabilityGrant(did, grantee, capability string, expiresHeight, expiresAt int64) error {
+	if did == "" || grantee == "" {
+		return fmt.Errorf("DID and grantee cannot be empty")
+	}
+	if !strings.HasPrefix(grantee, "did:") {
+		return fmt.Errorf("grantee %s must be a DID", grantee)
+	}
+	if grantee == did {
+		return fmt.Errorf("a DID cannot delegate capabilities to itself")
+	}
+	if strings.Contains(did, "/") || strings.Contains(grantee, "/") {
+		return fmt.Errorf("DID and grantee cannot contain '/'")
+	}
+	if _, ok := capabilityOperations[capability]; !ok {
+		return fmt.Errorf("unknown capability %q", capability)
+	}
+	if expiresHeight < 0 || expiresAt < 0 {
+		return fmt.Errorf("expiry cannot be negative")
+	}
+	if expiresHeight == 0 && expiresAt == 0 {
+		return fmt.Errorf("a capability grant must expire at a height or a time")
+	}
+	return nil
+}
+
+// MsgGrantCapability delegates Capability over DID to Grantee until block
+// ExpiresHeight or time ExpiresAt, replacing any earlier grant of the same
+// capability to the grantee. A controller of DID authorizes the grant like a
+// MsgUpdateDID.
+type MsgGrantCapability struct {
+	DID           string         `protobuf:"bytes,1,opt,name=did,proto3" json:"did"`
+	Grantee       string         `protobuf:"bytes,2,opt,name=grantee,proto3" json:"grantee"`
+	Capability    string         `protobuf:"bytes,3,opt,name=capability,proto3" json:"capability"`
+	ExpiresHeight int64          `protobuf:"varint,4,opt,name=expires_height,proto3" json:"expires_height,omitempty"`
+	ExpiresAt     int64          `protobuf:"varint,5,opt,name=expires_at,proto3" json:"expires_at,omitempty"`
+	Nonce         uint64         `protobuf:"varint,6,opt,name=nonce,proto3" json:"nonce"`
+	Signer        string         `protobuf:"bytes,7,opt,name=signer,proto3" json:"signer"`
+	Signature     []byte         `protobuf:"bytes,8,opt,name=signature,proto3" json:"signature,omitempty"`
+	Creator       sdk.AccAddress `protobuf:"bytes,9,opt,name=creator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"creator"`
+}
+
+// ValidateBasic performs basic validation of MsgGrantCapability.
+func (msg MsgGrantCapability) ValidateBasic() error {
+	if err := validateChecksums(msg); err != nil {
+		return err
+	}
+	if err := validateCapabilityGrant(msg.DID, msg.Grantee, msg.Capability, msg.ExpiresHeight, msg.ExpiresAt); err != nil {
+		return sdkerrors.ErrUnknownRequest.Wrap(err.Error())
+	}
+	if msg.Signer == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Signer cannot be empty")
+	}
+	return nil
+}
+
+// ProofSignBytes returns the bytes a controller DID signs to authorize the
+// grant.
+func (msg MsgGrantCapability) ProofSignBytes() []byte {
+	msg.Signature = nil
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// Route returns the message route.
+func (msg MsgGrantCapability) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgGrantCapability) Type() string { return "grant_capability" }
+
+// GetSignBytes returns the canonical bytes to sign over.
+func (msg MsgGrantCapability) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners returns the account that must sign the message.
+func (msg MsgGrantCapability) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
+
+// MsgRevokeCapability withdraws a capability grant before it expires.
+// Authorization works as for MsgGrantCapability.
+type MsgRevokeCapability struct {
+	DID        string         `protobuf:"bytes,1,opt,name=did,proto3" json:"did"`
+	Grantee    string         `protobuf:"bytes,2,opt,name=grantee,proto3" json:"grantee"`
+	Capability string         `protobuf:"bytes,3,opt,name=capability,proto3" json:"capability"`
+	Nonce      uint64         `protobuf:"varint,4,opt,name=nonce,proto3" json:"nonce"`
+	Signer     string         `protobuf:"bytes,5,opt,name=signer,proto3" json:"signer"`
+	Signature  []byte         `protobuf:"bytes,6,opt,name=signature,proto3" json:"signature,omitempty"`
+	Creator    sdk.AccAddress `protobuf:"bytes,7,opt,name=creator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"creator"`
+}
+
+// ValidateBasic performs basic validation of MsgRevokeCapability.
+func (msg MsgRevokeCapability) ValidateBasic() error {
+	if err := validateChecksums(msg); err != nil {
+		return err
+	}
+	if msg.DID == "" || msg.Grantee == "" || msg.Capability == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("DID, grantee and capability cannot be empty")
+	}
+	if msg.Signer == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Signer cannot be empty")
+	}
+	return nil
+}
+
+// ProofSignBytes returns the bytes a controller DID signs to authorize the
+// revocation.
+func (msg MsgRevokeCapability) ProofSignBytes() []byte {
+	msg.Signature = nil
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// Route returns the message route.
+func (msg MsgRevokeCapability) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgRevokeCapability) Type() string { return "revoke_capability" }
+
+// GetSignBytes returns the canonical bytes to sign over.
+func (msg MsgRevokeCapability) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners returns the account that must sign the message.
+func (msg MsgRevokeCapability) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
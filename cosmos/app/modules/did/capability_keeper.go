@@ -0,0 +1,204 @@
+package did
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GrantCapability stores a capability grant over an active DID, replacing
+// any earlier grant of the same capability to the grantee. Callers must have
+// authorized the grant.
+func (k Keeper) GrantCapability(ctx sdk.Context, grant CapabilityGrant) error {
+	did, err := k.GetDID(ctx, grant.DID)
+	if err != nil {
+		return err
+	}
+	if did.Deactivated {
+		return fmt.Errorf("DID %s is deactivated", grant.DID)
+	}
+	if err := validateCapabilityGrant(grant.DID, grant.Grantee, grant.Capability, grant.ExpiresHeight, grant.ExpiresAt); err != nil {
+		return err
+	}
+	if !grant.Active(ctx) {
+		return fmt.Errorf("capability grant to %s has already expired", grant.Grantee)
+	}
+	if existing, found := k.GetCapabilityGrant(ctx, grant.DID, grant.Grantee, grant.Capability); found {
+		k.deleteCapabilityGrant(ctx, existing)
+	}
+	grant.Granted = ctx.BlockHeight()
+	k.setCapabilityGrant(ctx, grant)
+	ctx.EventManager().EmitEvent(sdk.NewEvent("capability_granted",
+		sdk.NewAttribute("did", grant.DID),
+		sdk.NewAttribute("grantee", grant.Grantee),
+		sdk.NewAttribute("capability", grant.Capability),
+	))
+	return nil
+}
+
+// RevokeCapability deletes a capability grant.
+func (k Keeper) RevokeCapability(ctx sdk.Context, id, grantee, capability string) error {
+	grant, found := k.GetCapabilityGrant(ctx, id, grantee, capability)
+	if !found {
+		return fmt.Errorf("%s holds no %s capability over %s", grantee, capability, id)
+	}
+	k.deleteCapabilityGrant(ctx, grant)
+	ctx.EventManager().EmitEvent(sdk.NewEvent("capability_revoked",
+		sdk.NewAttribute("did", id),
+		sdk.NewAttribute("grantee", grantee),
+		sdk.NewAttribute("capability", capability),
+	))
+	return nil
+}
+
+// AuthorizeUpdate authorizes a MsgUpdateDID. Updates signed with a ZCAP-LD
+// invocation go through AuthorizeInvocation. A signer that isn't a
+// controller of the DID but holds capability grants over it is a delegate: it
+// must sign like a controller DID, and every group of fields the update
+// changes must be covered by one of its active grants. Everyone else goes
+// through AuthorizeController. An update that declares its Operations may
+// change nothing outside them.
+func (k Keeper) AuthorizeUpdate(ctx sdk.Context, msg MsgUpdateDID) error {
+	did, err := k.GetDID(ctx, msg.ID)
+	if err != nil {
+		return err
+	}
+	if len(msg.Operations) > 0 {
+		for _, op := range changedOperations(did, msg.Document()) {
+			if !containsString(msg.Operations, op) {
+				return fmt.Errorf("update of %s performs undeclared operation %s", did.ID, op)
+			}
+		}
+	}
+	if _, ok := parseZcapInvocation(msg.Signature); ok {
+		return k.AuthorizeInvocation(ctx, did, msg.Nonce, msg.Signer, ZcapActionUpdate, updateCapabilities(did, msg.Document()), msg.ProofSignBytes(), msg.Signature)
+	}
+	if isController(did, msg.Signer) || len(k.GetGranteeCapabilities(ctx, msg.ID, msg.Signer)) == 0 {
+		return k.AuthorizeController(ctx, msg.ID, msg.Nonce, msg.Signer, msg.Creator, msg.ProofSignBytes(), msg.Signature)
+	}
+	if did.Deactivated {
+		return fmt.Errorf("DID %s is deactivated", did.ID)
+	}
+	if err := checkNonce(did, msg.Nonce); err != nil {
+		return err
+	}
+	updated := msg.Document()
+	if !sameJSON(did.Context, updated.Context) || did.ContentCID != updated.ContentCID {
+		return fmt.Errorf("delegate %s cannot change the context or content CID of %s", msg.Signer, did.ID)
+	}
+	ops := changedOperations(did, updated)
+	if len(ops) == 0 {
+		return fmt.Errorf("update of %s by delegate %s changes nothing", did.ID, msg.Signer)
+	}
+	granted := make(map[string]bool)
+	for _, grant := range k.GetGranteeCapabilities(ctx, msg.ID, msg.Signer) {
+		if grant.Active(ctx) {
+			granted[capabilityOperations[grant.Capability]] = true
+		}
+	}
+	for _, op := range ops {
+		if !granted[op] {
+			return fmt.Errorf("delegate %s holds no capability for %s on %s", msg.Signer, op, did.ID)
+		}
+	}
+	return k.verifyDIDSignature(ctx, msg.Signer, msg.ProofSignBytes(), msg.Signature)
+}
+
+// AuthorizeDeactivation authorizes a MsgDeactivateDID, signed by a
+// controller or with a ZCAP-LD invocation.
+func (k Keeper) AuthorizeDeactivation(ctx sdk.Context, msg MsgDeactivateDID) error {
+	if _, ok := parseZcapInvocation(msg.Signature); ok {
+		did, err := k.GetDID(ctx, msg.ID)
+		if err != nil {
+			return err
+		}
+		return k.AuthorizeInvocation(ctx, did, msg.Nonce, msg.Signer, ZcapActionDeactivate, nil, msg.ProofSignBytes(), msg.Signature)
+	}
+	return k.AuthorizeController(ctx, msg.ID, msg.Nonce, msg.Signer, msg.Creator, msg.ProofSignBytes(), msg.Signature)
+}
+
+// GetCapabilityGrant returns the grant of a capability over a DID to grantee.
+func (k Keeper) GetCapabilityGrant(ctx sdk.Context, id, grantee, capability string) (CapabilityGrant, bool) {
+	value := ctx.KVStore(k.storeKey).Get(capabilityGrantKey(id, grantee, capability))
+	if value == nil {
+		return CapabilityGrant{}, false
+	}
+	var grant CapabilityGrant
+	k.cdc.MustUnmarshal(value, &grant)
+	return grant, true
+}
+
+// GetCapabilityGrants returns the grants over a DID, including expired grants
+// the end blocker hasn't pruned yet.
+func (k Keeper) GetCapabilityGrants(ctx sdk.Context, id string) []CapabilityGrant {
+	return k.capabilityGrants(ctx, capabilityGrantsPrefix(id))
+}
+
+// GetActiveCapabilityGrants returns the grants over a DID still in force.
+func (k Keeper) GetActiveCapabilityGrants(ctx sdk.Context, id string) []CapabilityGrant {
+	var active []CapabilityGrant
+	for _, grant := range k.GetCapabilityGrants(ctx, id) {
+		if grant.Active(ctx) {
+			active = append(active, grant)
+		}
+	}
+	return active
+}
+
+// GetGranteeCapabilities returns the grants over a DID to grantee.
+func (k Keeper) GetGranteeCapabilities(ctx sdk.Context, id, grantee string) []CapabilityGrant {
+	return k.capabilityGrants(ctx, granteeCapabilitiesPrefix(id, grantee))
+}
+
+// GetAllCapabilityGrants returns every capability grant in the store.
+func (k Keeper) GetAllCapabilityGrants(ctx sdk.Context) []CapabilityGrant {
+	return k.capabilityGrants(ctx, CapabilityGrantPrefix)
+}
+
+func (k Keeper) capabilityGrants(ctx sdk.Context, prefix []byte) []CapabilityGrant {
+	var grants []CapabilityGrant
+	iteratePrefix(ctx.KVStore(k.storeKey), prefix, func(_, value []byte) bool {
+		var grant CapabilityGrant
+		k.cdc.MustUnmarshal(value, &grant)
+		grants = append(grants, grant)
+		return false
+	})
+	return grants
+}
+
+// PruneExpiredCapabilities deletes the grants whose expiry height or time
+// has passed.
+func (k Keeper) PruneExpiredCapabilities(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	due := append(
+		dueEntries(store, CapabilityHeightQueuePrefix, ctx.BlockHeight()),
+		dueEntries(store, CapabilityTimeQueuePrefix, ctx.BlockTime().Unix())...,
+	)
+	for _, e := range due {
+		value := store.Get(section(CapabilityGrantPrefix, string(e.suffix)))
+		if value == nil {
+			continue
+		}
+		var grant CapabilityGrant
+		k.cdc.MustUnmarshal(value, &grant)
+		k.deleteCapabilityGrant(ctx, grant)
+	}
+}
+
+func (k Keeper) setCapabilityGrant(ctx sdk.Context, grant CapabilityGrant) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(capabilityGrantKey(grant.DID, grant.Grantee, grant.Capability), k.cdc.MustMarshal(&grant))
+	if grant.ExpiresHeight != 0 {
+		store.Set(capabilityHeightQueueKey(grant), []byte{})
+	}
+	if grant.ExpiresAt != 0 {
+		store.Set(capabilityTimeQueueKey(grant), []byte{})
+	}
+}
+
+func (k Keeper) deleteCapabilityGrant(ctx sdk.Context, grant CapabilityGrant) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(capabilityGrantKey(grant.DID, grant.Grantee, grant.Capability))
+	store.Delete(capabilityHeightQueueKey(grant))
+	store.Delete(capabilityTimeQueueKey(grant))
+}
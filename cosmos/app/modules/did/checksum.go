@@ -0,0 +1,112 @@
+package did
+
+import (
+	"fmt"
+	"hash/crc32"
+	"reflect"
+	"strings"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// ChecksumSeparator separates a generated identifier from its checksum, as in
+// did:aytch:z6Mk...-1a2b3c4d. It is not in the base58btc alphabet, so it
+// can't occur in the key.
+const ChecksumSeparator = "-"
+
+// checksumLength is the length of a checksum: a CRC-32 in lowercase hex.
+const checksumLength = 8
+
+// AppendChecksum returns id with the checksum of id appended.
+func AppendChecksum(id string) string {
+	return id + ChecksumSeparator + didChecksum(id)
+}
+
+func didChecksum(id string) string {
+	return fmt.Sprintf("%08x", crc32.ChecksumIEEE([]byte(id)))
+}
+
+// SplitChecksum splits a checksum-bearing identifier into the identifier it
+// was generated from and its checksum. Only generated identifiers, whose last
+// segment is a multibase Ed25519 key or a UUID, carry one; ok is false for
+// any other identifier, which is left to the registry as it is.
+func SplitChecksum(id string) (base, checksum string, ok bool) {
+	i := strings.LastIndex(id, ChecksumSeparator)
+	if i < 0 {
+		return id, "", false
+	}
+	base, checksum = id[:i], id[i+len(ChecksumSeparator):]
+	if segmentStyle(base[strings.LastIndex(base, ":")+1:]) == IdentifierStyleCustom {
+		return id, "", false
+	}
+	return base, checksum, true
+}
+
+// ValidateChecksum checks the checksum of a DID, or of the DID of a DID URL.
+// Identifiers without a checksum pass, so DIDs registered before generated
+// identifiers carried one keep working.
+func ValidateChecksum(id string) error {
+	did := id
+	if i := strings.IndexAny(did, "#?/"); i >= 0 {
+		did = did[:i]
+	}
+	if !strings.HasPrefix(did, DIDMethodPrefix) {
+		return nil
+	}
+	base, checksum, ok := SplitChecksum(did)
+	if !ok {
+		return nil
+	}
+	if len(checksum) != checksumLength || checksum != didChecksum(base) {
+		return fmt.Errorf("%s has an invalid checksum; check the identifier for typos", did)
+	}
+	return nil
+}
+
+// validateChecksums checks the checksum of every did:aytch identifier in the
+// string fields of msg, including those of nested documents, so a mistyped
+// DID is rejected before it reaches the store.
+func validateChecksums(msg interface{}) error {
+	if err := walkStrings(reflect.ValueOf(msg), ValidateChecksum); err != nil {
+		return sdkerrors.ErrUnknownRequest.Wrap(err.Error())
+	}
+	return nil
+}
+
+// walkStrings calls f on every string reachable from v through structs,
+// pointers, slices and maps, stopping at the first error.
+func walkStrings(v reflect.Value, f func(string) error) error {
+	switch v.Kind() {
+	case reflect.String:
+		return f(v.String())
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return walkStrings(v.Elem(), f)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if err := walkStrings(v.Field(i), f); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		// Byte slices hold keys, signatures and addresses.
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return nil
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := walkStrings(v.Index(i), f); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			if err := walkStrings(iter.Value(), f); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
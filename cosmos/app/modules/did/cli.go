@@ -0,0 +1,2634 @@
+package did
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"cosmos-app/signer"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/spf13/cobra"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	tmcli "github.com/tendermint/tendermint/libs/cli"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// Flags for the DID module's CLI commands.
+const (
+	FlagServiceEndpoint     = "service-endpoint"
+	FlagAuthentication      = "authentication"
+	FlagController          = "controller"
+	FlagContext             = "context"
+	FlagControllerThreshold = "controller-threshold"
+	FlagRotationDelay       = "rotation-delay"
+	FlagExpiresAt           = "expires-at"
+	FlagExpiresHeight       = "expires-height"
+	FlagKey                 = "key"
+	FlagExternalSigner      = "external-signer"
+	FlagSigningMethod       = "signing-method"
+	FlagSigner              = "signer"
+	FlagSignature           = "signature"
+	FlagNonce               = "nonce"
+	FlagFile                = "file"
+	FlagBatchSize           = "batch-size"
+	FlagContentCID          = "content-cid"
+	FlagIPFSGateway         = "ipfs-gateway"
+	FlagSalt                = "salt"
+	FlagProve               = "prove"
+	FlagVersionID           = "version-id"
+	FlagFormat              = "format"
+	FlagVersions            = "versions"
+	FlagFromHeight          = "from-height"
+	FlagNetwork             = "network"
+	FlagIDStyle             = "id-style"
+	FlagToHeight            = "to-height"
+	FlagEthChainID          = "eth-chain-id"
+	FlagEIP712              = "eip712"
+	FlagOwner               = "owner"
+	FlagProofTx             = "proof-tx"
+	FlagStatusPurpose       = "status-purpose"
+	FlagStatusList          = "status-list"
+	FlagIndexes             = "indexes"
+	FlagReason              = "reason"
+	FlagOperations          = "operations"
+
+	// FlagFeeGranter names an account that has granted the signer a fee
+	// allowance, so users without tokens can create DIDs. It is an alias for
+	// the SDK's --fee-account flag.
+	FlagFeeGranter = "fee-granter"
+)
+
+// GetTxCmd returns the root tx command for the DID module.
+func GetTxCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        ModuleName,
+		Short:                      "DID transaction subcommands",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+	cmd.AddCommand(
+		CmdCreateDID(),
+		CmdCreateDIDFromKey(),
+		CmdCreateDIDBatch(),
+		CmdSponsorDID(),
+		CmdUpdateDID(),
+		CmdDeactivateDID(),
+		CmdImportDIDs(),
+		CmdCommitDID(),
+		CmdLinkAccount(),
+		CmdUnlinkAccount(),
+		CmdLinkEthereumAccount(),
+		CmdUnlinkEthereumAccount(),
+		CmdGrantCapability(),
+		CmdRevokeCapability(),
+		CmdLinkAsset(),
+		CmdUnlinkAsset(),
+		CmdRegisterCredentialStatus(),
+		CmdRevokeCredentials(),
+		CmdSetPresentationDefinition(),
+		CmdDeletePresentationDefinition(),
+		CmdAnchorHash(),
+		CmdAnchorCredentialRoot(),
+	)
+	return cmd
+}
+
+// GetQueryCmd returns the root query command for the DID module.
+func GetQueryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        ModuleName,
+		Short:                      "Querying commands for the DID module",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+	cmd.AddCommand(
+		CmdResolveDID(),
+		CmdQueryParams(),
+		CmdQueryStats(),
+		CmdQueryHashAnchors(),
+		CmdCredentialProof(),
+		CmdVerifyCredentialInclusion(),
+		CmdQueryAudit(),
+		CmdQueryDiff(),
+		CmdQueryTxHistory(),
+		CmdVerifyContexts(),
+		CmdVerifyDocument(),
+		CmdExportRegistry(),
+		CmdExportActivity(),
+		CmdQueryByAddress(),
+		CmdQueryAccounts(),
+		CmdQueryCapabilities(),
+		CmdQueryLinkedAssets(),
+		CmdQueryDeposit(),
+		CmdQueryPresentationDefinitions(),
+		CmdEthereumLinkMessage(),
+		CmdWebAuthnKey(),
+		CmdAuthToken(),
+	)
+	return cmd
+}
+
+// CmdCreateDID returns the command to register a new DID.
+func CmdCreateDID() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create [id] [public-key]",
+		Short: "Register a new DID with a base64 Ed25519 public key",
+		Long: `Register a new DID with a base64 Ed25519 public key, or with --key register
+the DID derived from an Ed25519 key in the local keyring, with the key as its
+verification and authentication method:
+
+  create --key mykey --from myaccount
+
+With --id-style the identifier is generated rather than given: key derives it
+from the key, uuid makes a random UUID. Pass only the public key, or --key:
+
+  create --id-style uuid --key mykey --from myaccount
+  create --id-style key <base64-public-key> --from myaccount
+
+The chain's IdentifierStyles param decides which styles it accepts. On a
+chain whose Network param is set, every new DID must start with
+did:aytch:<network>:; pass the network with --network when generating the
+identifier.`,
+		Args: cobra.RangeArgs(0, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			clientCtx, err = withFeeGranter(cmd, clientCtx)
+			if err != nil {
+				return err
+			}
+
+			endpoints, _ := cmd.Flags().GetStringSlice(FlagServiceEndpoint)
+			authentication, _ := cmd.Flags().GetString(FlagAuthentication)
+			controllers, _ := cmd.Flags().GetStringSlice(FlagController)
+			contexts, _ := cmd.Flags().GetStringSlice(FlagContext)
+			contentCID, _ := cmd.Flags().GetString(FlagContentCID)
+			threshold, _ := cmd.Flags().GetUint32(FlagControllerThreshold)
+			rotationDelay, _ := cmd.Flags().GetUint64(FlagRotationDelay)
+			expiresAt, _ := cmd.Flags().GetInt64(FlagExpiresAt)
+			keyName, _ := cmd.Flags().GetString(FlagKey)
+			network, _ := cmd.Flags().GetString(FlagNetwork)
+			idStyle, _ := cmd.Flags().GetString(FlagIDStyle)
+			if idStyle != "" && idStyle != IdentifierStyleKey && idStyle != IdentifierStyleUUID {
+				return fmt.Errorf("unsupported --%s %q: must be %s or %s", FlagIDStyle, idStyle, IdentifierStyleKey, IdentifierStyleUUID)
+			}
+
+			var doc DIDDocument
+			switch {
+			case keyName != "" && len(args) == 0:
+				pub, err := keyringEd25519Key(clientCtx.Keyring, keyName)
+				if err != nil {
+					return err
+				}
+				if idStyle == "" {
+					idStyle = IdentifierStyleKey
+				}
+				if doc, err = generatedDocument(idStyle, network, pub); err != nil {
+					return err
+				}
+				if authentication == "" {
+					authentication = doc.Authentication
+				}
+			case keyName == "" && idStyle != "" && len(args) == 1:
+				bz, err := base64.StdEncoding.DecodeString(args[0])
+				if err != nil || len(bz) != ed25519.PublicKeySize {
+					return fmt.Errorf("public key must be a base64 Ed25519 key")
+				}
+				if doc, err = generatedDocument(idStyle, network, ed25519.PublicKey(bz)); err != nil {
+					return err
+				}
+				if authentication == "" {
+					authentication = doc.Authentication
+				}
+			case keyName == "" && idStyle == "" && len(args) == 2:
+				doc = DIDDocument{ID: args[0], PublicKey: args[1]}
+			default:
+				return fmt.Errorf("provide [id] [public-key], --%s [public-key] or --%s", FlagIDStyle, FlagKey)
+			}
+
+			msg := &MsgCreateDID{
+				ID:                  doc.ID,
+				PublicKey:           doc.PublicKey,
+				ServiceEndpoints:    endpoints,
+				Authentication:      authentication,
+				VerificationMethods: doc.VerificationMethods,
+				Controller:          controllers,
+				ControllerThreshold: threshold,
+				RotationDelay:       rotationDelay,
+				ExpiresAt:           expiresAt,
+				Context:             contexts,
+				ContentCID:          contentCID,
+				Creator:             clientCtx.GetFromAddress(),
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+	cmd.Flags().StringSlice(FlagServiceEndpoint, nil, "Service endpoint URLs of the DID")
+	cmd.Flags().String(FlagAuthentication, "", "Authentication method of the DID")
+	cmd.Flags().StringSlice(FlagController, nil, "Controllers of the DID (addresses or DIDs); defaults to the DID itself")
+	cmd.Flags().StringSlice(FlagContext, nil, "Additional JSON-LD contexts of the DID; must be allowed by the AllowedContexts param")
+	cmd.Flags().String(FlagContentCID, "", "CID (v1, raw, sha2-256) of the full document stored in IPFS")
+	cmd.Flags().Uint32(FlagControllerThreshold, 0, "Number of controllers that must approve changes")
+	cmd.Flags().Int64(FlagExpiresAt, 0, "Unix time at which the DID is deactivated unless renewed")
+	cmd.Flags().Uint64(FlagRotationDelay, 0, "Blocks a key rotation waits before taking effect, during which it can be cancelled")
+	cmd.Flags().String(FlagFeeGranter, "", "Account that pays the transaction fee through a fee grant")
+	cmd.Flags().String(FlagKey, "", "Name of an Ed25519 keyring key to derive the DID and its verification method from")
+	cmd.Flags().String(FlagNetwork, "", "Network namespace of a generated identifier; must match the chain's Network param")
+	cmd.Flags().String(FlagIDStyle, "", "Generate the identifier: key (derived from the key) or uuid; the default with --key is key")
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// documentFromKeyring derives the key-derived document of an Ed25519 key in
+// the keyring, on network; see NetworkDIDFromKey.
+func documentFromKeyring(kr keyring.Keyring, name, network string) (DIDDocument, error) {
+	key, err := keyringEd25519Key(kr, name)
+	if err != nil {
+		return DIDDocument{}, err
+	}
+	return generatedDocument(IdentifierStyleKey, network, key)
+}
+
+// keyringEd25519Key returns the public key of an Ed25519 key in the keyring.
+// keys add only creates secp256k1 keys, which can't authenticate a DID, so
+// Ed25519 keys have to be imported.
+func keyringEd25519Key(kr keyring.Keyring, name string) (ed25519.PublicKey, error) {
+	if kr == nil {
+		return nil, fmt.Errorf("no keyring available")
+	}
+	info, err := kr.Key(name)
+	if err != nil {
+		return nil, err
+	}
+	pub := info.GetPubKey()
+	if pub.Type() != "ed25519" {
+		return nil, fmt.Errorf("key %s is a %s key, but DIDs are authenticated by Ed25519 keys, which keys add can't create; "+
+			"import an ASCII-armored Ed25519 private key with `keys import <name> <key-file>` and pass --%s <name>", name, pub.Type(), FlagKey)
+	}
+	return ed25519.PublicKey(pub.Bytes()), nil
+}
+
+// generatedDocument builds the document of a DID on network whose identifier
+// is generated in style, with key as its verification and authentication
+// method.
+func generatedDocument(style, network string, key ed25519.PublicKey) (DIDDocument, error) {
+	id := NetworkDIDFromKey(network, key)
+	if style == IdentifierStyleUUID {
+		var err error
+		if id, err = NewUUIDDID(network); err != nil {
+			return DIDDocument{}, err
+		}
+	}
+	return DocumentFromKey(id, EncodeMultibaseKey(MulticodecEd25519Pub, key), key), nil
+}
+
+// CmdCreateDIDFromKey returns the command to register the canonical DID of
+// an Ed25519 key.
+func CmdCreateDIDFromKey() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create-from-key [public-key-multibase] [signature]",
+		Short: "Register the DID derived from an Ed25519 public key",
+		Long: `Register did:aytch:<public-key-multibase>-<checksum>, whose document is
+derived from the key, or did:aytch:<network>:<public-key-multibase>-<checksum>
+on a chain whose Network param is set. The checksum is a CRC-32 of the rest of
+the identifier, so a mistyped DID is rejected rather than resolving to nothing. The signature is the key's base64 Ed25519 signature over the message's
+proof sign bytes, proving possession of the key.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			clientCtx, err = withFeeGranter(cmd, clientCtx)
+			if err != nil {
+				return err
+			}
+			sig, err := base64.StdEncoding.DecodeString(args[1])
+			if err != nil {
+				return fmt.Errorf("invalid signature: %w", err)
+			}
+
+			msg := &MsgCreateDIDFromKey{
+				PublicKeyMultibase: args[0],
+				Signature:          sig,
+				Creator:            clientCtx.GetFromAddress(),
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+	cmd.Flags().String(FlagFeeGranter, "", "Account that pays the transaction fee through a fee grant")
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdCreateDIDBatch returns the command to register several DIDs in one
+// transaction.
+func CmdCreateDIDBatch() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create-batch [batch-file]",
+		Short: "Register up to 100 DIDs atomically in one transaction",
+		Long: `Register the DID documents in batch-file, a JSON object of the form
+{"documents": [...]}, in one transaction. Either every document is registered
+or none is, and the creation fee for all of them is paid in one transfer.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			clientCtx, err = withFeeGranter(cmd, clientCtx)
+			if err != nil {
+				return err
+			}
+
+			bz, err := os.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			var msg MsgCreateDIDBatch
+			if err := clientCtx.Codec.UnmarshalJSON(bz, &msg); err != nil {
+				return fmt.Errorf("invalid batch file: %w", err)
+			}
+			msg.Creator = clientCtx.GetFromAddress()
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+	}
+	cmd.Flags().String(FlagFeeGranter, "", "Account that pays the transaction fee through a fee grant")
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdCommitDID returns the command to register a DID in hash-only mode.
+func CmdCommitDID() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "commit [document-file]",
+		Short: "Register a DID by a salted hash of its document, keeping the document off-chain",
+		Long: `Register the DID of the document in document-file in hash-only mode: only a
+salted hash commitment of the document is stored on chain. Without --salt a
+random salt is generated and printed; keep it with the document, since
+verifiers need both to check the document with the verify-document query.
+Committing again with the same account replaces the commitment.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			clientCtx, err = withFeeGranter(cmd, clientCtx)
+			if err != nil {
+				return err
+			}
+
+			bz, err := os.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			var doc DIDDocument
+			if err := clientCtx.Codec.UnmarshalJSON(bz, &doc); err != nil {
+				return fmt.Errorf("invalid DID document: %w", err)
+			}
+			salt, err := commitmentSalt(cmd)
+			if err != nil {
+				return err
+			}
+			commitment, err := CommitDocument(doc, salt)
+			if err != nil {
+				return err
+			}
+
+			msg := &MsgCommitDID{
+				ID:         doc.ID,
+				Commitment: commitment,
+				Creator:    clientCtx.GetFromAddress(),
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			if s, _ := cmd.Flags().GetString(FlagSalt); s == "" {
+				cmd.PrintErrf("salt: %s\n", hex.EncodeToString(salt))
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+	cmd.Flags().String(FlagSalt, "", "Hex salt of the commitment; a random one is generated if unset")
+	cmd.Flags().String(FlagFeeGranter, "", "Account that pays the transaction fee through a fee grant")
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// commitmentSalt decodes --salt, or generates a random salt if it's unset.
+func commitmentSalt(cmd *cobra.Command) ([]byte, error) {
+	if s, _ := cmd.Flags().GetString(FlagSalt); s != "" {
+		salt, err := hex.DecodeString(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid salt: %w", err)
+		}
+		return salt, nil
+	}
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	return salt, nil
+}
+
+// CmdSponsorDID returns the command an onboarding service uses to register a
+// user's DID and pay its creation fee.
+func CmdSponsorDID() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sponsor [document-file] [signature]",
+		Short: "Register a user's DID document, paying its creation fee",
+		Long: `Register the DID document in document-file on behalf of its owner. The
+signature is the owner's base64 Ed25519 signature over the message's proof sign
+bytes, made with the document's public key.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			clientCtx, err = withFeeGranter(cmd, clientCtx)
+			if err != nil {
+				return err
+			}
+
+			bz, err := os.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			var doc DIDDocument
+			if err := clientCtx.Codec.UnmarshalJSON(bz, &doc); err != nil {
+				return fmt.Errorf("invalid DID document: %w", err)
+			}
+			sig, err := base64.StdEncoding.DecodeString(args[1])
+			if err != nil {
+				return fmt.Errorf("invalid signature: %w", err)
+			}
+
+			msg := &MsgSponsorDID{
+				Document:  doc,
+				Signature: sig,
+				Sponsor:   clientCtx.GetFromAddress(),
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+	cmd.Flags().String(FlagFeeGranter, "", "Account that pays the transaction fee through a fee grant")
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdUpdateDID returns the command to replace the contents of a DID document.
+func CmdUpdateDID() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update [document-file]",
+		Short: "Replace the contents of a DID document",
+		Long: `Replace the DID document with the one in document-file. --signer names the
+authorizing controller (the DID itself by default). A controller DID must sign
+the update: either with --key, an Ed25519 keyring key, or by passing its
+base64 signature over the proof sign bytes with --signature. A DID holding
+capabilities granted with grant-capability signs as --signer like a
+controller DID, and may only change the fields its capabilities cover. An
+agent holding a ZCAP-LD capability over the DID instead passes its base64
+capability invocation (see ZcapInvocation) as --signature, with --signer set
+to the invoking DID.
+
+Together with --generate-only or --offline the update can be prepared and
+signed without a node; --nonce must then be set to the document's current
+nonce.
+
+--operations limits the update to the listed groups of fields (rotate_keys,
+update_services, update_controllers, renew, update_content). An account
+acting under an UpdateDIDAuthorization through authz must set it to
+operations it was granted.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			clientCtx, err = withFeeGranter(cmd, clientCtx)
+			if err != nil {
+				return err
+			}
+
+			bz, err := os.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			var doc DIDDocument
+			if err := clientCtx.Codec.UnmarshalJSON(bz, &doc); err != nil {
+				return fmt.Errorf("invalid DID document: %w", err)
+			}
+			nonce, err := documentNonce(cmd, clientCtx, doc.ID)
+			if err != nil {
+				return err
+			}
+			operations, _ := cmd.Flags().GetStringSlice(FlagOperations)
+
+			msg := &MsgUpdateDID{
+				ID:                  doc.ID,
+				PublicKey:           doc.PublicKey,
+				ServiceEndpoints:    doc.ServiceEndpoints,
+				Authentication:      doc.Authentication,
+				VerificationMethods: doc.VerificationMethods,
+				KeyAgreement:        doc.KeyAgreement,
+				Services:            doc.Services,
+				Controller:          doc.Controller,
+				ControllerThreshold: doc.ControllerThreshold,
+				RotationDelay:       doc.RotationDelay,
+				ExpiresAt:           doc.ExpiresAt,
+				Context:             doc.Context,
+				ContentCID:          doc.ContentCID,
+				Operations:          operations,
+				Nonce:               nonce,
+				Signer:              proofSigner(cmd, doc.ID),
+				Creator:             clientCtx.GetFromAddress(),
+			}
+			if msg.Signature, err = proofSignature(cmd, clientCtx, msg.ProofSignBytes()); err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+	addProofFlags(cmd)
+	cmd.Flags().StringSlice(FlagOperations, nil, "Groups of fields the update is limited to")
+	return cmd
+}
+
+// CmdDeactivateDID returns the command to permanently deactivate a DID.
+func CmdDeactivateDID() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "deactivate [did]",
+		Short: "Permanently deactivate a DID",
+		Long: `Permanently deactivate a DID. Authorization and offline signing work as for
+the update command, except that granted capabilities don't cover
+deactivation; a ZCAP-LD capability must allow the deactivate action.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			clientCtx, err = withFeeGranter(cmd, clientCtx)
+			if err != nil {
+				return err
+			}
+			nonce, err := documentNonce(cmd, clientCtx, args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := &MsgDeactivateDID{
+				ID:      args[0],
+				Nonce:   nonce,
+				Signer:  proofSigner(cmd, args[0]),
+				Creator: clientCtx.GetFromAddress(),
+			}
+			if msg.Signature, err = proofSignature(cmd, clientCtx, msg.ProofSignBytes()); err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+	addProofFlags(cmd)
+	return cmd
+}
+
+// CmdLinkAccount returns the command to link an account to a DID.
+func CmdLinkAccount() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "link-account [did] [account]",
+		Short: "Link an account, by default the --from account, to a DID",
+		Long: `Link an account to a DID so the DID can be looked up by address. The account
+must sign the transaction, and a controller of the DID authorizes the link as
+for the update command. Linking an account to another DID replaces its link.`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			clientCtx, err = withFeeGranter(cmd, clientCtx)
+			if err != nil {
+				return err
+			}
+			account := clientCtx.GetFromAddress()
+			if len(args) == 2 {
+				if account, err = sdk.AccAddressFromBech32(args[1]); err != nil {
+					return err
+				}
+			}
+			nonce, err := documentNonce(cmd, clientCtx, args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := &MsgLinkAccount{
+				DID:     args[0],
+				Account: account,
+				Nonce:   nonce,
+				Signer:  proofSigner(cmd, args[0]),
+				Creator: clientCtx.GetFromAddress(),
+			}
+			if msg.Signature, err = proofSignature(cmd, clientCtx, msg.ProofSignBytes()); err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+	addProofFlags(cmd)
+	return cmd
+}
+
+// CmdUnlinkAccount returns the command to remove the DID link of an account.
+func CmdUnlinkAccount() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unlink-account [account]",
+		Short: "Remove the DID link of an account, by default the --from account",
+		Long: `Remove the DID link of an account. An account unlinks itself by signing the
+transaction; with --signer, --key or --signature a controller of the linked
+DID removes the link instead, as for the update command.`,
+		Args: cobra.RangeArgs(0, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			clientCtx, err = withFeeGranter(cmd, clientCtx)
+			if err != nil {
+				return err
+			}
+			account := clientCtx.GetFromAddress()
+			if len(args) == 1 {
+				if account, err = sdk.AccAddressFromBech32(args[0]); err != nil {
+					return err
+				}
+			}
+
+			msg := &MsgUnlinkAccount{Account: account, Creator: clientCtx.GetFromAddress()}
+			signer, _ := cmd.Flags().GetString(FlagSigner)
+			keyName, _ := cmd.Flags().GetString(FlagKey)
+			externalSigner, _ := cmd.Flags().GetString(FlagExternalSigner)
+			sig, _ := cmd.Flags().GetString(FlagSignature)
+			if signer != "" || keyName != "" || externalSigner != "" || sig != "" {
+				var id string
+				if !clientCtx.Offline && !clientCtx.GenerateOnly {
+					if id, err = linkedDID(clientCtx, account); err != nil {
+						return err
+					}
+				}
+				if msg.Signer, msg.Nonce, err = ControllerProof(cmd, clientCtx, id); err != nil {
+					return err
+				}
+				if msg.Signature, err = proofSignature(cmd, clientCtx, msg.ProofSignBytes()); err != nil {
+					return err
+				}
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+	addProofFlags(cmd)
+	return cmd
+}
+
+// linkedDID queries the DID an account is linked to.
+func linkedDID(clientCtx client.Context, account sdk.AccAddress) (string, error) {
+	res, _, err := clientCtx.QueryWithData(fmt.Sprintf("custom/did/%s/%s", QueryByAddress, account), nil)
+	if err != nil {
+		return "", err
+	}
+	var link AccountLink
+	if err := json.Unmarshal(res, &link); err != nil {
+		return "", err
+	}
+	return link.DID, nil
+}
+
+// CmdLinkEthereumAccount returns the command to link an Ethereum account to
+// a DID.
+func CmdLinkEthereumAccount() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "link-ethereum [did] [address] [account-signature]",
+		Short: "Record an Ethereum account in a DID's alsoKnownAs",
+		Long: `Record an Ethereum account in a DID's alsoKnownAs. account-signature is the
+hex signature the Ethereum account made with personal_sign over the message
+printed by "query did ethereum-link-message", or with --eip712 made with
+eth_signTypedData_v4 over the typed data it prints. A controller of the DID
+authorizes the link as for the update command; the nonce must be the one the
+account signed.`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			clientCtx, err = withFeeGranter(cmd, clientCtx)
+			if err != nil {
+				return err
+			}
+			accountSig, err := hex.DecodeString(strings.TrimPrefix(args[2], "0x"))
+			if err != nil {
+				return fmt.Errorf("invalid account signature: %w", err)
+			}
+			chainID, _ := cmd.Flags().GetUint64(FlagEthChainID)
+			proofType := ProofTypeEIP191
+			if eip712, _ := cmd.Flags().GetBool(FlagEIP712); eip712 {
+				proofType = ProofTypeEIP712
+			}
+			nonce, err := documentNonce(cmd, clientCtx, args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := &MsgLinkEthereumAccount{
+				DID:              args[0],
+				Address:          args[1],
+				ChainID:          chainID,
+				ProofType:        proofType,
+				AccountSignature: accountSig,
+				Nonce:            nonce,
+				Signer:           proofSigner(cmd, args[0]),
+				Creator:          clientCtx.GetFromAddress(),
+			}
+			if msg.Signature, err = proofSignature(cmd, clientCtx, msg.ProofSignBytes()); err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+	cmd.Flags().Uint64(FlagEthChainID, 1, "EVM chain ID of the Ethereum account")
+	cmd.Flags().Bool(FlagEIP712, false, "The account signature is an EIP-712 typed data signature")
+	addProofFlags(cmd)
+	return cmd
+}
+
+// CmdUnlinkEthereumAccount returns the command to remove an Ethereum account
+// from a DID.
+func CmdUnlinkEthereumAccount() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unlink-ethereum [did] [address]",
+		Short: "Remove an Ethereum account from a DID's alsoKnownAs",
+		Long: `Remove an Ethereum account from a DID's alsoKnownAs. Authorization and offline
+signing work as for the update command.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			clientCtx, err = withFeeGranter(cmd, clientCtx)
+			if err != nil {
+				return err
+			}
+			chainID, _ := cmd.Flags().GetUint64(FlagEthChainID)
+			nonce, err := documentNonce(cmd, clientCtx, args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := &MsgUnlinkEthereumAccount{
+				DID:     args[0],
+				Address: args[1],
+				ChainID: chainID,
+				Nonce:   nonce,
+				Signer:  proofSigner(cmd, args[0]),
+				Creator: clientCtx.GetFromAddress(),
+			}
+			if msg.Signature, err = proofSignature(cmd, clientCtx, msg.ProofSignBytes()); err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+	cmd.Flags().Uint64(FlagEthChainID, 1, "EVM chain ID of the Ethereum account")
+	addProofFlags(cmd)
+	return cmd
+}
+
+// CmdGrantCapability returns the command to delegate a capability over a DID
+// to another DID.
+func CmdGrantCapability() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "grant-capability [did] [grantee-did] [capability]",
+		Short: "Delegate a capability over a DID to another DID until a height or time",
+		Long: fmt.Sprintf(`Let grantee-did update the fields of did that capability covers, signing
+the update as --signer like a controller DID. Capabilities are %s,
+%s, %s and %s. The grant lapses after
+--%s or at --%s (unix seconds), whichever is set and comes first;
+at least one is required. A controller of did authorizes the grant as for the
+update command.`, CapabilityRotateKeys, CapabilityUpdateServiceEndpoints, CapabilityUpdateControllers, CapabilityRenew, FlagExpiresHeight, FlagExpiresAt),
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			clientCtx, err = withFeeGranter(cmd, clientCtx)
+			if err != nil {
+				return err
+			}
+			expiresHeight, _ := cmd.Flags().GetInt64(FlagExpiresHeight)
+			expiresAt, _ := cmd.Flags().GetInt64(FlagExpiresAt)
+			nonce, err := documentNonce(cmd, clientCtx, args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := &MsgGrantCapability{
+				DID:           args[0],
+				Grantee:       args[1],
+				Capability:    args[2],
+				ExpiresHeight: expiresHeight,
+				ExpiresAt:     expiresAt,
+				Nonce:         nonce,
+				Signer:        proofSigner(cmd, args[0]),
+				Creator:       clientCtx.GetFromAddress(),
+			}
+			if msg.Signature, err = proofSignature(cmd, clientCtx, msg.ProofSignBytes()); err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+	cmd.Flags().Int64(FlagExpiresHeight, 0, "Last block height at which the grant is in force")
+	cmd.Flags().Int64(FlagExpiresAt, 0, "Unix time at which the grant lapses")
+	addProofFlags(cmd)
+	return cmd
+}
+
+// CmdRevokeCapability returns the command to withdraw a capability grant.
+func CmdRevokeCapability() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "revoke-capability [did] [grantee-did] [capability]",
+		Short: "Withdraw a capability grant before it expires",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			clientCtx, err = withFeeGranter(cmd, clientCtx)
+			if err != nil {
+				return err
+			}
+			nonce, err := documentNonce(cmd, clientCtx, args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := &MsgRevokeCapability{
+				DID:        args[0],
+				Grantee:    args[1],
+				Capability: args[2],
+				Nonce:      nonce,
+				Signer:     proofSigner(cmd, args[0]),
+				Creator:    clientCtx.GetFromAddress(),
+			}
+			if msg.Signature, err = proofSignature(cmd, clientCtx, msg.ProofSignBytes()); err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+	addProofFlags(cmd)
+	return cmd
+}
+
+// CmdRegisterCredentialStatus returns the command to index a credential
+// under its status list entry.
+func CmdRegisterCredentialStatus() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "register-credential-status [credential-id] [issuer-did] [status-list-url] [index]",
+		Short: "Index an issued credential under its status list entry",
+		Long: fmt.Sprintf(`Record that the status of a credential issued by issuer-did is the bit at
+index of the status list at status-list-url, a DID URL of the form
+issuer-did/resources/{resourceId} naming a status list resource of the
+issuer. Verifiers then query the credential status by credential ID alone.
+--%s is %s (default) or %s. A controller of issuer-did authorizes the
+registration as for the update command.`, FlagStatusPurpose, StatusPurposeRevocation, StatusPurposeSuspension),
+		Args: cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			clientCtx, err = withFeeGranter(cmd, clientCtx)
+			if err != nil {
+				return err
+			}
+			index, err := strconv.ParseUint(args[3], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid status list index %s: %w", args[3], err)
+			}
+			purpose, _ := cmd.Flags().GetString(FlagStatusPurpose)
+			nonce, err := documentNonce(cmd, clientCtx, args[1])
+			if err != nil {
+				return err
+			}
+
+			msg := &MsgRegisterCredentialStatus{
+				CredentialID:         args[0],
+				Issuer:               args[1],
+				StatusListCredential: args[2],
+				StatusListIndex:      index,
+				StatusPurpose:        purpose,
+				Nonce:                nonce,
+				Signer:               proofSigner(cmd, args[1]),
+				Creator:              clientCtx.GetFromAddress(),
+			}
+			if msg.Signature, err = proofSignature(cmd, clientCtx, msg.ProofSignBytes()); err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+	cmd.Flags().String(FlagStatusPurpose, StatusPurposeRevocation, "Purpose of the status list entry")
+	addProofFlags(cmd)
+	return cmd
+}
+
+// CmdRevokeCredentials returns the command to revoke many credentials of an
+// issuer in one transaction.
+func CmdRevokeCredentials() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "revoke-credentials [issuer-did] [credential-id]...",
+		Short: "Revoke many credentials of an issuer at once, e.g. after a key compromise",
+		Long: fmt.Sprintf(`Revoke the listed credentials of issuer-did, those in --%s (a JSON array of
+credential IDs) and those registered at --%s of the issuer's --%s, in one
+transaction of at most %d credentials. Credentials already revoked are
+skipped; if any other revocation fails, none apply. Gas grows with the
+number of credentials. A controller of issuer-did authorizes the revocations
+as for the update command.`, FlagFile, FlagIndexes, FlagStatusList, MaxRevokeCredentialsSize),
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			clientCtx, err = withFeeGranter(cmd, clientCtx)
+			if err != nil {
+				return err
+			}
+			ids := args[1:]
+			if file, _ := cmd.Flags().GetString(FlagFile); file != "" {
+				bz, err := os.ReadFile(file)
+				if err != nil {
+					return err
+				}
+				var more []string
+				if err := json.Unmarshal(bz, &more); err != nil {
+					return fmt.Errorf("parse %s: %w", file, err)
+				}
+				ids = append(ids, more...)
+			}
+			statusList, _ := cmd.Flags().GetString(FlagStatusList)
+			indexes, _ := cmd.Flags().GetUintSlice(FlagIndexes)
+			reason, _ := cmd.Flags().GetString(FlagReason)
+			nonce, err := documentNonce(cmd, clientCtx, args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := &MsgRevokeCredentials{
+				Issuer:               args[0],
+				CredentialIDs:        ids,
+				StatusListCredential: statusList,
+				Reason:               reason,
+				Nonce:                nonce,
+				Signer:               proofSigner(cmd, args[0]),
+				Creator:              clientCtx.GetFromAddress(),
+			}
+			for _, index := range indexes {
+				msg.StatusListIndexes = append(msg.StatusListIndexes, uint64(index))
+			}
+			if msg.Signature, err = proofSignature(cmd, clientCtx, msg.ProofSignBytes()); err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+	cmd.Flags().String(FlagFile, "", "JSON file holding an array of further credential IDs")
+	cmd.Flags().String(FlagStatusList, "", "DID URL of the issuer's status list the indexes refer to")
+	cmd.Flags().UintSlice(FlagIndexes, nil, "Comma-separated indexes of the credentials to revoke in the status list")
+	cmd.Flags().String(FlagReason, "", "Reason recorded with every revocation")
+	addProofFlags(cmd)
+	return cmd
+}
+
+// CmdSetPresentationDefinition returns the command to publish a verifier's
+// presentation definition.
+func CmdSetPresentationDefinition() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-presentation-definition [verifier-did] [definition-file]",
+		Short: "Publish a DIF Presentation Exchange definition of a verifier DID",
+		Long: `Publish the presentation_definition in definition-file, a JSON file, under
+verifier-did, replacing any earlier definition with the same id. Wallets
+fetch it from the chain to see which credentials the verifier requires. A
+controller of verifier-did authorizes the publication as for the update
+command.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			clientCtx, err = withFeeGranter(cmd, clientCtx)
+			if err != nil {
+				return err
+			}
+			bz, err := os.ReadFile(args[1])
+			if err != nil {
+				return err
+			}
+			var pd struct {
+				ID string `json:"id"`
+			}
+			if err := json.Unmarshal(bz, &pd); err != nil {
+				return fmt.Errorf("parse %s: %w", args[1], err)
+			}
+			nonce, err := documentNonce(cmd, clientCtx, args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := &MsgSetPresentationDefinition{
+				Verifier:   args[0],
+				ID:         pd.ID,
+				Definition: string(bz),
+				Nonce:      nonce,
+				Signer:     proofSigner(cmd, args[0]),
+				Creator:    clientCtx.GetFromAddress(),
+			}
+			if msg.Signature, err = proofSignature(cmd, clientCtx, msg.ProofSignBytes()); err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+	addProofFlags(cmd)
+	return cmd
+}
+
+// CmdDeletePresentationDefinition returns the command to withdraw a
+// verifier's presentation definition.
+func CmdDeletePresentationDefinition() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete-presentation-definition [verifier-did] [definition-id]",
+		Short: "Withdraw a published presentation definition, authorized as for set-presentation-definition",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			clientCtx, err = withFeeGranter(cmd, clientCtx)
+			if err != nil {
+				return err
+			}
+			nonce, err := documentNonce(cmd, clientCtx, args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := &MsgDeletePresentationDefinition{
+				Verifier: args[0],
+				ID:       args[1],
+				Nonce:    nonce,
+				Signer:   proofSigner(cmd, args[0]),
+				Creator:  clientCtx.GetFromAddress(),
+			}
+			if msg.Signature, err = proofSignature(cmd, clientCtx, msg.ProofSignBytes()); err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+	addProofFlags(cmd)
+	return cmd
+}
+
+// CmdAnchorHash returns the command to timestamp a hash under a DID.
+func CmdAnchorHash() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "anchor-hash [did] [hex-hash] [label]",
+		Short: "Timestamp a 32-byte hash, such as a document digest, under a DID",
+		Long: `Anchor hex-hash, a 32-byte hash such as the SHA-256 digest of a document, under
+did with an optional label, proving that the document existed at the current
+block. The hash is stored apart from the DID document and can be looked up
+with the hash-anchors query. A controller of did authorizes the anchor as for
+the update command.`,
+		Args: cobra.RangeArgs(2, 3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			clientCtx, err = withFeeGranter(cmd, clientCtx)
+			if err != nil {
+				return err
+			}
+			hash, err := parseAnchorHash(args[1])
+			if err != nil {
+				return err
+			}
+			nonce, err := documentNonce(cmd, clientCtx, args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := &MsgAnchorHash{
+				DID:     args[0],
+				Hash:    hash,
+				Nonce:   nonce,
+				Signer:  proofSigner(cmd, args[0]),
+				Creator: clientCtx.GetFromAddress(),
+			}
+			if len(args) == 3 {
+				msg.Label = args[2]
+			}
+			if msg.Signature, err = proofSignature(cmd, clientCtx, msg.ProofSignBytes()); err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+	addProofFlags(cmd)
+	return cmd
+}
+
+// CmdAnchorCredentialRoot returns the command to anchor the Merkle root of a
+// batch of issued credentials.
+func CmdAnchorCredentialRoot() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "anchor-credential-root [issuer-did] [hashes-file]",
+		Short: "Anchor the Merkle root of a batch of credentials instead of registering each one",
+		Long: `Build the Merkle tree of the credential hashes in hashes-file, one hex-encoded
+32-byte hash per line in issuance order, and anchor its root under
+issuer-did. Holders prove a credential belongs to the batch with the path the
+credential-proof command computes from the same file. A controller of
+issuer-did authorizes the anchor as for the update command.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			clientCtx, err = withFeeGranter(cmd, clientCtx)
+			if err != nil {
+				return err
+			}
+			hashes, err := readCredentialHashes(args[1])
+			if err != nil {
+				return err
+			}
+			nonce, err := documentNonce(cmd, clientCtx, args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := &MsgAnchorCredentialRoot{
+				Issuer:  args[0],
+				Root:    merkle.HashFromByteSlices(hashes),
+				Count:   uint64(len(hashes)),
+				Nonce:   nonce,
+				Signer:  proofSigner(cmd, args[0]),
+				Creator: clientCtx.GetFromAddress(),
+			}
+			if msg.Signature, err = proofSignature(cmd, clientCtx, msg.ProofSignBytes()); err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+	addProofFlags(cmd)
+	return cmd
+}
+
+// readCredentialHashes reads a batch of hex-encoded credential hashes, one
+// per line, skipping blank lines.
+func readCredentialHashes(path string) ([][]byte, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var hashes [][]byte
+	for i, line := range strings.Split(string(bz), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		hash, err := parseAnchorHash(line)
+		if err != nil {
+			return nil, fmt.Errorf("%s line %d: %w", path, i+1, err)
+		}
+		hashes = append(hashes, hash)
+	}
+	if len(hashes) == 0 {
+		return nil, fmt.Errorf("%s has no credential hashes", path)
+	}
+	return hashes, nil
+}
+
+// CmdLinkAsset returns the command to link an owned on-chain asset to a DID.
+func CmdLinkAsset() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "link-asset [did] [class] [asset-id]",
+		Short: "Link an on-chain asset the DID owns, such as a token, to the DID",
+		Long: fmt.Sprintf(`Link an asset of class to did after checking on chain that --%s
+owns it. The owner is did itself, the default, or an account linked to did.
+Class %s links bank coins by denom; modules holding assets register their
+own classes, such as sbt for soulbound tokens by token ID. --%s records the
+hash of the transaction the asset was acquired in. Links are re-verified as
+assets change and pruned once the owner no longer holds the asset. A
+controller of did authorizes the link as for the update command.`, FlagOwner, AssetClassBank, FlagProofTx),
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			clientCtx, err = withFeeGranter(cmd, clientCtx)
+			if err != nil {
+				return err
+			}
+			owner, _ := cmd.Flags().GetString(FlagOwner)
+			if owner == "" {
+				owner = args[0]
+			}
+			proofTx, _ := cmd.Flags().GetString(FlagProofTx)
+			nonce, err := documentNonce(cmd, clientCtx, args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := &MsgLinkAsset{
+				DID:     args[0],
+				Class:   args[1],
+				AssetID: args[2],
+				Owner:   owner,
+				ProofTx: strings.ToLower(proofTx),
+				Nonce:   nonce,
+				Signer:  proofSigner(cmd, args[0]),
+				Creator: clientCtx.GetFromAddress(),
+			}
+			if msg.Signature, err = proofSignature(cmd, clientCtx, msg.ProofSignBytes()); err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+	cmd.Flags().String(FlagOwner, "", "DID or linked account address owning the asset (default: the DID)")
+	cmd.Flags().String(FlagProofTx, "", "Hex hash of the transaction the asset was acquired in")
+	addProofFlags(cmd)
+	return cmd
+}
+
+// CmdUnlinkAsset returns the command to remove a linked asset from a DID.
+func CmdUnlinkAsset() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unlink-asset [did] [class] [asset-id]",
+		Short: "Remove a linked asset from a DID",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			clientCtx, err = withFeeGranter(cmd, clientCtx)
+			if err != nil {
+				return err
+			}
+			nonce, err := documentNonce(cmd, clientCtx, args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := &MsgUnlinkAsset{
+				DID:     args[0],
+				Class:   args[1],
+				AssetID: args[2],
+				Nonce:   nonce,
+				Signer:  proofSigner(cmd, args[0]),
+				Creator: clientCtx.GetFromAddress(),
+			}
+			if msg.Signature, err = proofSignature(cmd, clientCtx, msg.ProofSignBytes()); err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+	addProofFlags(cmd)
+	return cmd
+}
+
+// CmdImportDIDs returns the command to register many DID documents from a
+// file in batched transactions.
+func CmdImportDIDs() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import --file [dids.json]",
+		Short: "Register the DID documents in a file in batched transactions",
+		Long: `Register every DID document in a JSON array file, paid for by the --from
+account. All documents are validated before anything is broadcast; the file is
+then split into transactions of --batch-size documents, broadcast one after
+another with consecutive sequence numbers. Progress is reported on stderr.
+Pass --yes to skip the confirmation prompt for each transaction.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			clientCtx, err = withFeeGranter(cmd, clientCtx)
+			if err != nil {
+				return err
+			}
+			file, _ := cmd.Flags().GetString(FlagFile)
+			if file == "" {
+				return fmt.Errorf("--%s is required", FlagFile)
+			}
+			batchSize, _ := cmd.Flags().GetInt(FlagBatchSize)
+			if batchSize <= 0 {
+				return fmt.Errorf("--%s must be positive", FlagBatchSize)
+			}
+
+			bz, err := os.ReadFile(file)
+			if err != nil {
+				return err
+			}
+			var docs []DIDDocument
+			if err := json.Unmarshal(bz, &docs); err != nil {
+				return fmt.Errorf("invalid DID document file: %w", err)
+			}
+			msgs, err := importMsgs(docs, clientCtx.GetFromAddress())
+			if err != nil {
+				return err
+			}
+
+			txf := tx.NewFactoryCLI(clientCtx, cmd.Flags())
+			if !clientCtx.GenerateOnly && !clientCtx.Offline {
+				if txf, err = txf.Prepare(clientCtx); err != nil {
+					return err
+				}
+			}
+			batches := (len(msgs) + batchSize - 1) / batchSize
+			for b := 0; b < batches; b++ {
+				end := (b + 1) * batchSize
+				if end > len(msgs) {
+					end = len(msgs)
+				}
+				fmt.Fprintf(os.Stderr, "batch %d/%d: DIDs %d-%d of %d\n", b+1, batches, b*batchSize+1, end, len(msgs))
+				if err := tx.GenerateOrBroadcastTxWithFactory(clientCtx, txf, msgs[b*batchSize:end]...); err != nil {
+					return fmt.Errorf("batch %d: %w", b+1, err)
+				}
+				txf = txf.WithSequence(txf.Sequence() + 1)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().String(FlagFile, "", "JSON file holding an array of DID documents")
+	cmd.Flags().Int(FlagBatchSize, 50, "Number of DIDs registered per transaction")
+	cmd.Flags().String(FlagFeeGranter, "", "Account that pays the transaction fee through a fee grant")
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// importMsgs converts imported documents into create messages, rejecting the
+// whole import if any document is invalid or repeated.
+func importMsgs(docs []DIDDocument, creator sdk.AccAddress) ([]sdk.Msg, error) {
+	if len(docs) == 0 {
+		return nil, fmt.Errorf("no DID documents to import")
+	}
+	seen := make(map[string]int, len(docs))
+	msgs := make([]sdk.Msg, 0, len(docs))
+	for i, doc := range docs {
+		if j, ok := seen[doc.ID]; ok {
+			return nil, fmt.Errorf("document %d: %s repeats document %d", i, doc.ID, j)
+		}
+		seen[doc.ID] = i
+		msg := &MsgCreateDID{
+			ID:                  doc.ID,
+			PublicKey:           doc.PublicKey,
+			ServiceEndpoints:    doc.ServiceEndpoints,
+			Authentication:      doc.Authentication,
+			VerificationMethods: doc.VerificationMethods,
+			KeyAgreement:        doc.KeyAgreement,
+			Services:            doc.Services,
+			Controller:          doc.Controller,
+			ControllerThreshold: doc.ControllerThreshold,
+			RotationDelay:       doc.RotationDelay,
+			ExpiresAt:           doc.ExpiresAt,
+			Creator:             creator,
+		}
+		if err := msg.ValidateBasic(); err != nil {
+			return nil, fmt.Errorf("document %d (%s): %w", i, doc.ID, err)
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, nil
+}
+
+// addProofFlags adds the flags of commands authorized by a DID controller.
+func addProofFlags(cmd *cobra.Command) {
+	AddControllerProofFlags(cmd)
+	cmd.Flags().String(FlagFeeGranter, "", "Account that pays the transaction fee through a fee grant")
+	flags.AddTxFlagsToCmd(cmd)
+}
+
+// AddControllerProofFlags adds the flags with which a command proves control
+// of a DID, for commands of other modules that act on behalf of a DID. See
+// ControllerProof.
+func AddControllerProofFlags(cmd *cobra.Command) {
+	cmd.Flags().String(FlagSigner, "", "Controller authorizing the operation (DID or account address); defaults to the DID itself")
+	cmd.Flags().String(FlagKey, "", "Name of the Ed25519 keyring key that signs for a controller DID")
+	cmd.Flags().String(FlagExternalSigner, "", "URI of a remote signer holding the controller DID's Ed25519 key (vault://<mount>/<key>, awskms://<key-id>)")
+	cmd.Flags().String(FlagSignature, "", "Base64 controller signature over the proof sign bytes, made elsewhere, base64 WebAuthn assertion JSON over their SHA-256, or base64 ZCAP-LD capability invocation JSON")
+	cmd.Flags().String(FlagSigningMethod, "", "Verification method (full DID URL) of a threshold key that made the signature, e.g. the group key of an MPC cluster")
+	cmd.Flags().Uint64(FlagNonce, 0, "Current nonce of the DID document; required with --offline or --generate-only")
+}
+
+// ControllerProof returns the signer and nonce with which a command proves
+// control of id, from the flags added by AddControllerProofFlags. The caller
+// builds its message with them and then signs its proof sign bytes with
+// ProofSignature.
+func ControllerProof(cmd *cobra.Command, clientCtx client.Context, id string) (string, uint64, error) {
+	nonce, err := documentNonce(cmd, clientCtx, id)
+	if err != nil {
+		return "", 0, err
+	}
+	return proofSigner(cmd, id), nonce, nil
+}
+
+// ProofSignature returns the controller signature over signBytes, see
+// ControllerProof.
+func ProofSignature(cmd *cobra.Command, clientCtx client.Context, signBytes []byte) ([]byte, error) {
+	return proofSignature(cmd, clientCtx, signBytes)
+}
+
+func proofSigner(cmd *cobra.Command, id string) string {
+	if signer, _ := cmd.Flags().GetString(FlagSigner); signer != "" {
+		return signer
+	}
+	return id
+}
+
+// documentNonce returns the --nonce flag or, when connected to a node, the
+// document's current nonce.
+func documentNonce(cmd *cobra.Command, clientCtx client.Context, id string) (uint64, error) {
+	if cmd.Flags().Changed(FlagNonce) {
+		return cmd.Flags().GetUint64(FlagNonce)
+	}
+	if clientCtx.Offline || clientCtx.GenerateOnly {
+		return 0, fmt.Errorf("--%s is required with --offline or --generate-only", FlagNonce)
+	}
+	res, _, err := clientCtx.QueryWithData(fmt.Sprintf("custom/did/%s", id), nil)
+	if err != nil {
+		return 0, err
+	}
+	var doc DIDDocument
+	if err := json.Unmarshal(res, &doc); err != nil {
+		return 0, err
+	}
+	return doc.Nonce, nil
+}
+
+// proofSignature returns the controller signature over signBytes from
+// --signature, or makes it with the remote signer named by --external-signer
+// or the keyring key named by --key. Account controllers authorize through
+// the tx signature and need none of them. With --signing-method the signature
+// is sent as a MethodSignature of that threshold method.
+func proofSignature(cmd *cobra.Command, clientCtx client.Context, signBytes []byte) ([]byte, error) {
+	sig, err := controllerSignature(cmd, clientCtx, signBytes)
+	method, _ := cmd.Flags().GetString(FlagSigningMethod)
+	if err != nil || method == "" {
+		return sig, err
+	}
+	if sig == nil {
+		return nil, fmt.Errorf("--%s requires a signature", FlagSigningMethod)
+	}
+	return json.Marshal(MethodSignature{VerificationMethod: method, Signature: sig})
+}
+
+func controllerSignature(cmd *cobra.Command, clientCtx client.Context, signBytes []byte) ([]byte, error) {
+	if sig, _ := cmd.Flags().GetString(FlagSignature); sig != "" {
+		bz, err := base64.StdEncoding.DecodeString(sig)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signature: %w", err)
+		}
+		return bz, nil
+	}
+	if uri, _ := cmd.Flags().GetString(FlagExternalSigner); uri != "" {
+		s, err := signer.Open(uri)
+		if err != nil {
+			return nil, err
+		}
+		return s.Sign(signBytes)
+	}
+	keyName, _ := cmd.Flags().GetString(FlagKey)
+	if keyName == "" {
+		return nil, nil
+	}
+	if clientCtx.Keyring == nil {
+		return nil, fmt.Errorf("no keyring available")
+	}
+	if info, err := clientCtx.Keyring.Key(keyName); err == nil && info.GetType() == keyring.TypeLedger {
+		return nil, fmt.Errorf("ledger key %s can't sign controller proofs; list its account as a controller and sign the transaction with --%s %s --%s", keyName, flags.FlagFrom, keyName, flags.FlagUseLedger)
+	}
+	if _, err := documentFromKeyring(clientCtx.Keyring, keyName, ""); err != nil {
+		return nil, err
+	}
+	sig, _, err := clientCtx.Keyring.Sign(keyName, signBytes)
+	return sig, err
+}
+
+// CmdResolveDID returns the command to resolve a DID document.
+func CmdResolveDID() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "resolve [did-url]",
+		Short: "Resolve a DID document or dereference a DID URL fragment",
+		Long: `Resolve a DID and print the DID resolution result: the document in W3C DID
+Core form together with resolution and document metadata, which lists the
+verified assets linked to the DID. If the DID URL has a
+fragment (did:aytch:...#key-1) the matching verification method or service is
+printed instead. --output selects the representation: json (the default),
+jsonld or cbor. Documents stored in IPFS are expanded from --ipfs-gateway,
+after checking the content against the CID anchored on chain.
+
+With --height the document is resolved as it existed after that block, and
+with --version-id as it was at that version, looked up in the DID's audit
+log. Both read past state, which needs an archive node or one that hasn't
+pruned it. The document metadata's nextVersionId names the version that
+replaced a historical one.
+
+With --prove the stored document is printed together with its ICS-23 proof
+and the app hash of the block that commits to it, so the result can be checked
+without trusting the node. --height selects the proven state; by default it is
+the latest state a committed block vouches for.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			output, _ := cmd.Flags().GetString(tmcli.OutputFlag)
+			var contentType string
+			switch output {
+			case "", "text", "json":
+				contentType = ContentTypeDIDJSON
+			case "jsonld":
+				contentType = ContentTypeDIDJSONLD
+			case "cbor":
+				contentType = ContentTypeDIDCBOR
+			default:
+				return fmt.Errorf("unsupported output format %q: must be json, jsonld or cbor", output)
+			}
+
+			id, fragment := args[0], ""
+			if i := strings.Index(id, "#"); i >= 0 {
+				id, fragment = id[:i], id[i+1:]
+			}
+			if cmd.Flags().Changed(FlagVersionID) {
+				if clientCtx.Height != 0 {
+					return fmt.Errorf("--%s and --%s are mutually exclusive", FlagVersionID, flags.FlagHeight)
+				}
+				version, _ := cmd.Flags().GetUint64(FlagVersionID)
+				height, err := VersionHeight(clientCtx, id, version)
+				if err != nil {
+					return err
+				}
+				clientCtx = clientCtx.WithHeight(height)
+			}
+			if prove, _ := cmd.Flags().GetBool(FlagProve); prove {
+				if fragment != "" {
+					return fmt.Errorf("--%s resolves whole documents, not DID URL fragments", FlagProve)
+				}
+				proven, err := ResolveProven(clientCtx, id, clientCtx.Height)
+				if err != nil {
+					return err
+				}
+				bz, err := json.MarshalIndent(proven, "", "  ")
+				if err != nil {
+					return err
+				}
+				return clientCtx.PrintBytes(bz)
+			}
+			var doc DIDDocument
+			if clientCtx.Height != 0 {
+				if doc, err = ResolveAt(clientCtx, id, clientCtx.Height); err != nil {
+					return err
+				}
+			} else {
+				res, _, err := clientCtx.QueryWithData(fmt.Sprintf("custom/did/%s", id), nil)
+				if err != nil {
+					return err
+				}
+				if err := json.Unmarshal(res, &doc); err != nil {
+					return err
+				}
+			}
+			if gateway, _ := cmd.Flags().GetString(FlagIPFSGateway); gateway != "" && doc.ContentCID != "" {
+				content, err := fetch(cmd.Context(), strings.TrimRight(gateway, "/")+"/ipfs/"+doc.ContentCID, "application/vnd.ipld.raw")
+				if err != nil {
+					return fmt.Errorf("fetch %s: %w", doc.ContentCID, err)
+				}
+				if doc, err = doc.WithContent(content); err != nil {
+					return err
+				}
+			}
+
+			resolution := NewResolutionResult(doc, contentType)
+			if clientCtx.Height != 0 {
+				if resolution.DIDDocumentMetadata.NextVersionID, err = NextVersionID(clientCtx, id, doc.Nonce); err != nil {
+					return err
+				}
+			}
+			if assets, _, err := clientCtx.QueryWithData(fmt.Sprintf("custom/did/%s/%s", QueryLinkedAssets, id), nil); err == nil {
+				if err := json.Unmarshal(assets, &resolution.DIDDocumentMetadata.LinkedAssets); err != nil {
+					return err
+				}
+			}
+			var result interface{} = resolution
+			if fragment != "" {
+				if result, err = doc.Dereference(fragment, contentType); err != nil {
+					return err
+				}
+			}
+			if contentType == ContentTypeDIDCBOR {
+				bz, err := MarshalCBOR(result)
+				if err != nil {
+					return err
+				}
+				_, err = cmd.OutOrStdout().Write(bz)
+				return err
+			}
+			bz, err := json.MarshalIndent(result, "", "  ")
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintBytes(bz)
+		},
+	}
+	cmd.Flags().String(FlagIPFSGateway, "", "IPFS HTTP gateway used to expand documents stored in IPFS, e.g. http://127.0.0.1:8080")
+	cmd.Flags().Bool(FlagProve, false, "Print the stored document with its ICS-23 proof and app hash")
+	cmd.Flags().Uint64(FlagVersionID, 0, "Resolve the document as it was at this version (its nonce)")
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdQueryParams returns the command to query the DID module parameters.
+func CmdQueryParams() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "params",
+		Short: "Query the DID module parameters",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			res, _, err := clientCtx.QueryWithData(fmt.Sprintf("custom/did/%s", QueryParams), nil)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintBytes(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdQueryAudit returns the command to query the audit log of a DID.
+func CmdQueryAudit() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "audit [did]",
+		Short: "Query every change made to a DID, with its signer, height and transaction hash",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			res, _, err := clientCtx.QueryWithData(fmt.Sprintf("custom/did/%s/%s", QueryAudit, args[0]), nil)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintBytes(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdQueryDiff returns the command to diff two versions of a DID document.
+func CmdQueryDiff() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff [did] [from-version] [to-version]",
+		Short: "Show the changes between two versions of a DID document as a JSON Patch",
+		Long: `Show the changes between two versions of a DID document as a JSON Patch
+(RFC 6902) over its W3C DID Core form. Versions are document nonces, as listed
+by the audit command; both are read from past state, so the node must have
+kept the heights that wrote them.`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			from, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid from-version %s", args[1])
+			}
+			to, err := strconv.ParseUint(args[2], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid to-version %s", args[2])
+			}
+			diff, err := DiffVersions(clientCtx, args[0], from, to)
+			if err != nil {
+				return err
+			}
+			bz, err := json.MarshalIndent(diff, "", "  ")
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintBytes(bz)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdQueryTxHistory returns the command to list the transactions that
+// affected a DID.
+func CmdQueryTxHistory() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tx-history [did]",
+		Short: "List the transactions that affected a DID, with their heights and messages",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			pageReq, err := client.ReadPageRequest(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			res, err := NewQueryClient(clientCtx).TxHistory(cmd.Context(), &QueryTxHistoryRequest{DID: args[0], Pagination: pageReq})
+			if err != nil {
+				return err
+			}
+			bz, err := json.MarshalIndent(res, "", "  ")
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintBytes(bz)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddPaginationFlagsToCmd(cmd, "tx-history")
+	return cmd
+}
+
+// CmdQueryStats returns the command to query the registry counters.
+func CmdQueryStats() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Query the number of DIDs, active and deactivated, anchored credentials and revocations",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			res, err := NewQueryClient(clientCtx).Stats(cmd.Context(), &QueryStatsRequest{})
+			if err != nil {
+				return err
+			}
+			bz, err := json.MarshalIndent(res.Stats, "", "  ")
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintBytes(bz)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdQueryHashAnchors returns the command to look up the anchors of a hash.
+func CmdQueryHashAnchors() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "hash-anchors [hex-hash]",
+		Short: "Query which DIDs anchored a hash, with the label, height and time of each anchor",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			res, err := NewQueryClient(clientCtx).HashAnchors(cmd.Context(), &QueryHashAnchorsRequest{Hash: args[0]})
+			if err != nil {
+				return err
+			}
+			bz, err := json.MarshalIndent(res.Anchors, "", "  ")
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintBytes(bz)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdCredentialProof returns the command that computes the Merkle path of a
+// credential in a batch, without querying the chain.
+func CmdCredentialProof() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "credential-proof [hashes-file] [credential-hash]",
+		Short: "Compute the Merkle path of a credential hash in a batch anchored with anchor-credential-root",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			hashes, err := readCredentialHashes(args[0])
+			if err != nil {
+				return err
+			}
+			hash, err := parseAnchorHash(args[1])
+			if err != nil {
+				return err
+			}
+			_, proofs := merkle.ProofsFromByteSlices(hashes)
+			for i, h := range hashes {
+				if bytes.Equal(h, hash) {
+					bz, err := json.MarshalIndent(CredentialInclusionProof{Index: uint64(i), Aunts: proofs[i].Aunts}, "", "  ")
+					if err != nil {
+						return err
+					}
+					_, err = fmt.Fprintln(cmd.OutOrStdout(), string(bz))
+					return err
+				}
+			}
+			return fmt.Errorf("credential hash %s is not in %s", args[1], args[0])
+		},
+	}
+	return cmd
+}
+
+// CmdVerifyCredentialInclusion returns the command to check a credential
+// against an anchored credential root.
+func CmdVerifyCredentialInclusion() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify-credential-inclusion [issuer-did] [root] [credential-hash] [proof-file]",
+		Short: "Check that a credential hash is in a batch whose Merkle root the issuer anchored",
+		Long: `Check credential-hash against root, both hex-encoded, using the Merkle path in
+proof-file, the JSON output of the credential-proof command.`,
+		Args: cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			root, err := parseAnchorHash(args[1])
+			if err != nil {
+				return err
+			}
+			hash, err := parseAnchorHash(args[2])
+			if err != nil {
+				return err
+			}
+			bz, err := os.ReadFile(args[3])
+			if err != nil {
+				return err
+			}
+			var proof CredentialInclusionProof
+			if err := json.Unmarshal(bz, &proof); err != nil {
+				return fmt.Errorf("invalid proof: %w", err)
+			}
+			res, err := NewQueryClient(clientCtx).VerifyCredentialInclusion(cmd.Context(), &QueryVerifyCredentialInclusionRequest{
+				Issuer:         args[0],
+				Root:           root,
+				CredentialHash: hash,
+				Proof:          proof,
+			})
+			if err != nil {
+				return err
+			}
+			bz, err = json.MarshalIndent(res, "", "  ")
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintBytes(bz)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdQueryByAddress returns the command to find the DID linked to an
+// account.
+func CmdQueryByAddress() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "by-address [address]",
+		Short: "Query the DID an account is linked to",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			res, _, err := clientCtx.QueryWithData(fmt.Sprintf("custom/did/%s/%s", QueryByAddress, args[0]), nil)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintBytes(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdQueryAccounts returns the command to list the accounts linked to a DID.
+func CmdQueryAccounts() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "accounts [did]",
+		Short: "Query the accounts linked to a DID",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			res, _, err := clientCtx.QueryWithData(fmt.Sprintf("custom/did/%s/%s", QueryAccounts, args[0]), nil)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintBytes(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdQueryCapabilities returns the command to list the capabilities
+// delegated over a DID.
+func CmdQueryCapabilities() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "capabilities [did]",
+		Short: "Query the active capability grants over a DID",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			res, _, err := clientCtx.QueryWithData(fmt.Sprintf("custom/did/%s/%s", QueryCapabilities, args[0]), nil)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintBytes(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdQueryLinkedAssets returns the command to list the assets linked to a
+// DID.
+func CmdQueryLinkedAssets() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "linked-assets [did]",
+		Short: "Query the verified on-chain assets linked to a DID",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			res, _, err := clientCtx.QueryWithData(fmt.Sprintf("custom/did/%s/%s", QueryLinkedAssets, args[0]), nil)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintBytes(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdQueryPresentationDefinitions returns the command to query the
+// presentation definitions a verifier DID has published.
+func CmdQueryPresentationDefinitions() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "presentation-definitions [verifier-did] [definition-id]",
+		Short: "List the presentation definitions a verifier has published, or show one",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			res, _, err := clientCtx.QueryWithData(fmt.Sprintf("custom/did/%s/%s", QueryPresentationDefinitions, strings.Join(args, "/")), nil)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintBytes(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdQueryDeposit returns the command to query the registration deposit
+// locked for a DID.
+func CmdQueryDeposit() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "deposit [did]",
+		Short: "Query the registration deposit locked for a DID and when it is refunded",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			res, _, err := clientCtx.QueryWithData(fmt.Sprintf("custom/did/%s/%s", QueryDeposit, args[0]), nil)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintBytes(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdAuthToken returns the command that signs a DID-Auth token for a REST
+// request.
+func CmdAuthToken() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "auth-token [did] [method] [url]",
+		Short: "Sign a DID-Auth token for one REST request to a node that requires it",
+		Long: fmt.Sprintf(`Sign a single-use DID-Auth token for a request with method to url, for
+nodes started with --%s. Send it as "Authorization: %s <token>". The token
+is bound to the request body read from --%s, empty if not given, and is
+signed with --%s or --%s by the DID's own key, or by the verification method
+given as --%s, and is valid for %s. A transaction request must carry
+messages sent on behalf of the DID.`, FlagRESTAuth, AuthScheme, FlagFile, FlagKey, FlagExternalSigner, FlagSigningMethod, MaxAuthTokenLifetime),
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			kid := args[0]
+			if method, _ := cmd.Flags().GetString(FlagSigningMethod); method != "" {
+				kid = method
+			}
+			var body []byte
+			if file, _ := cmd.Flags().GetString(FlagFile); file != "" {
+				if body, err = os.ReadFile(file); err != nil {
+					return err
+				}
+			}
+			token, err := NewAuthToken(kid, args[1], args[2], body, MaxAuthTokenLifetime, func(signingInput []byte) ([]byte, error) {
+				sig, err := controllerSignature(cmd, clientCtx, signingInput)
+				if err == nil && sig == nil {
+					err = fmt.Errorf("--%s or --%s is required", FlagKey, FlagExternalSigner)
+				}
+				return sig, err
+			})
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), token)
+			return err
+		},
+	}
+	cmd.Flags().String(FlagKey, "", "Name of the Ed25519 keyring key of the DID")
+	cmd.Flags().String(FlagExternalSigner, "", "URI of a remote signer holding the DID's Ed25519 key (vault://<mount>/<key>, awskms://<key-id>)")
+	cmd.Flags().String(FlagSigningMethod, "", "Ed25519 verification method (full DID URL) that signs, instead of the DID's own key")
+	cmd.Flags().String(FlagFile, "", "Path of the request body the token is bound to")
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdWebAuthnKey returns the command that converts a passkey's public key
+// into a verification method key.
+func CmdWebAuthnKey() *cobra.Command {
+	return &cobra.Command{
+		Use:   "webauthn-key [cose-key]",
+		Short: "Print the publicKeyMultibase of a WebAuthnP256Key method for a passkey",
+		Long: `Print the publicKeyMultibase of a WebAuthnP256Key verification method for a
+passkey, given the base64url COSE_Key from the credential's attested
+credential data. Add the method to the DID with the update command; the
+passkey can then sign the DID's operations by passing its WebAuthn assertion
+as --signature. The assertion is the JSON object
+{"verificationMethod", "authenticatorData", "clientDataJSON", "signature"}
+with base64 byte fields, and its challenge is the SHA-256 of the proof sign
+bytes.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cose, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(args[0], "="))
+			if err != nil {
+				return fmt.Errorf("invalid COSE key: %w", err)
+			}
+			key, err := P256KeyFromCOSE(cose)
+			if err != nil {
+				return err
+			}
+			cmd.Println(key)
+			return nil
+		},
+	}
+}
+
+// CmdEthereumLinkMessage returns the command that prints what an Ethereum
+// account signs to link itself to a DID.
+func CmdEthereumLinkMessage() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ethereum-link-message [did] [address]",
+		Short: "Print the message and EIP-712 typed data an Ethereum account signs to link itself to a DID",
+		Long: `Print the personal_sign message and the eth_signTypedData_v4 typed data with
+which an Ethereum account consents to being linked to a DID at the DID's
+current nonce, or at --nonce. Sign either one in the account's wallet and
+pass the signature to "tx did link-ethereum".`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			addr, err := ParseEthereumAddress(args[1])
+			if err != nil {
+				return err
+			}
+			chainID, _ := cmd.Flags().GetUint64(FlagEthChainID)
+			nonce, err := documentNonce(cmd, clientCtx, args[0])
+			if err != nil {
+				return err
+			}
+			bz, err := json.MarshalIndent(map[string]interface{}{
+				"personal_sign": EthereumLinkMessage(args[0], addr, chainID, nonce),
+				"typed_data":    EthereumLinkTypedData(args[0], addr, chainID, nonce),
+			}, "", "  ")
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintBytes(bz)
+		},
+	}
+	cmd.Flags().Uint64(FlagEthChainID, 1, "EVM chain ID of the Ethereum account")
+	cmd.Flags().Uint64(FlagNonce, 0, "Nonce of the DID document to sign for; defaults to its current nonce")
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// exportPageSize is the number of documents CmdExportRegistry fetches per
+// query.
+const exportPageSize = 200
+
+// CmdExportRegistry returns the command that streams every DID document from
+// a node.
+func CmdExportRegistry() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export-registry",
+		Short: "Stream every DID document in the registry as JSON lines or CSV",
+		Long: `Stream every DID document from the node to stdout, one JSON object per line
+(--format json) or one CSV row per document (--format csv). All pages are read
+at the same height: --height if given, otherwise the latest height when the
+export starts, so the export is a consistent snapshot. With --versions each
+document is accompanied by its change history from the audit log.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			format, _ := cmd.Flags().GetString(FlagFormat)
+			if format != "json" && format != "csv" {
+				return fmt.Errorf("unsupported format %q: must be json or csv", format)
+			}
+			versions, _ := cmd.Flags().GetBool(FlagVersions)
+			if clientCtx.Height == 0 {
+				node, err := clientCtx.GetNode()
+				if err != nil {
+					return err
+				}
+				status, err := node.Status(cmd.Context())
+				if err != nil {
+					return err
+				}
+				clientCtx = clientCtx.WithHeight(status.SyncInfo.LatestBlockHeight)
+			}
+
+			out := cmd.OutOrStdout()
+			var csvw *csv.Writer
+			if format == "csv" {
+				csvw = csv.NewWriter(out)
+				if err := csvw.Write(exportCSVHeader(versions)); err != nil {
+					return err
+				}
+			}
+			enc := json.NewEncoder(out)
+			queryClient := NewQueryClient(clientCtx)
+			req := &QueryDIDsRequest{Pagination: &query.PageRequest{Limit: exportPageSize}}
+			count := 0
+			for {
+				res, err := queryClient.DIDs(cmd.Context(), req)
+				if err != nil {
+					return err
+				}
+				for _, doc := range res.DIDs {
+					var history []AuditEntry
+					if versions {
+						if history, err = queryAuditLog(clientCtx, doc.ID); err != nil {
+							return err
+						}
+					}
+					if csvw != nil {
+						err = csvw.Write(exportCSVRow(doc, history, versions))
+					} else {
+						err = enc.Encode(exportRecord{Height: clientCtx.Height, Document: doc, Versions: history})
+					}
+					if err != nil {
+						return err
+					}
+				}
+				count += len(res.DIDs)
+				if csvw != nil {
+					csvw.Flush()
+					if err := csvw.Error(); err != nil {
+						return err
+					}
+				}
+				if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+					break
+				}
+				req.Pagination.Key = res.Pagination.NextKey
+			}
+			fmt.Fprintf(os.Stderr, "exported %d DIDs at height %d\n", count, clientCtx.Height)
+			return nil
+		},
+	}
+	cmd.Flags().String(FlagFormat, "json", "Output format: json (one object per line) or csv")
+	cmd.Flags().Bool(FlagVersions, false, "Include each DID's change history")
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdExportActivity returns the command that exports the operations an
+// account or controller performed.
+func CmdExportActivity() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export-activity [address-or-did]",
+		Short: "Export the operations an account or controller performed, as JSON lines or CSV",
+		Long: `Export every transaction in which an account signed a DID module message, or
+a controller DID or address authorized one, between --from-height and
+--to-height inclusive, for audit reporting. Each record lists the messages
+performed and the DIDs they affected, one JSON object per line (--format json)
+or one CSV row per transaction (--format csv). All pages are read at the same
+height: --height if given, otherwise the latest height when the export
+starts.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			format, _ := cmd.Flags().GetString(FlagFormat)
+			fromHeight, _ := cmd.Flags().GetInt64(FlagFromHeight)
+			toHeight, _ := cmd.Flags().GetInt64(FlagToHeight)
+			if clientCtx.Height == 0 {
+				node, err := clientCtx.GetNode()
+				if err != nil {
+					return err
+				}
+				status, err := node.Status(cmd.Context())
+				if err != nil {
+					return err
+				}
+				clientCtx = clientCtx.WithHeight(status.SyncInfo.LatestBlockHeight)
+			}
+			count, err := ExportActivity(cmd.Context(), clientCtx, args[0], fromHeight, toHeight, format, cmd.OutOrStdout())
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "exported %d transactions of %s at height %d\n", count, args[0], clientCtx.Height)
+			return nil
+		},
+	}
+	cmd.Flags().String(FlagFormat, "json", "Output format: json (one object per line) or csv")
+	cmd.Flags().Int64(FlagFromHeight, 0, "First height to export")
+	cmd.Flags().Int64(FlagToHeight, 0, "Last height to export; 0 exports up to the query height")
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// exportRecord is one line of a JSON registry export.
+type exportRecord struct {
+	Height   int64        `json:"height"`
+	Document DIDDocument  `json:"document"`
+	Versions []AuditEntry `json:"versions,omitempty"`
+}
+
+func exportCSVHeader(versions bool) []string {
+	header := []string{"id", "controller", "authentication", "verification_methods", "services", "deactivated", "expires_at", "version", "digest", "content_cid"}
+	if versions {
+		header = append(header, "versions", "last_changed_height")
+	}
+	return header
+}
+
+func exportCSVRow(doc DIDDocument, history []AuditEntry, versions bool) []string {
+	methods := make([]string, len(doc.VerificationMethods))
+	for i, vm := range doc.VerificationMethods {
+		methods[i] = vm.ID
+	}
+	services := append([]string{}, doc.ServiceEndpoints...)
+	for _, svc := range doc.Services {
+		services = append(services, svc.ServiceEndpoint)
+	}
+	row := []string{
+		doc.ID,
+		strings.Join(doc.Controller, " "),
+		doc.Authentication,
+		strings.Join(methods, " "),
+		strings.Join(services, " "),
+		strconv.FormatBool(doc.Deactivated),
+		strconv.FormatInt(doc.ExpiresAt, 10),
+		strconv.FormatUint(doc.Nonce, 10),
+		doc.DigestHex(),
+		doc.ContentCID,
+	}
+	if versions {
+		var last int64
+		if len(history) > 0 {
+			last = history[len(history)-1].Height
+		}
+		row = append(row, strconv.Itoa(len(history)), strconv.FormatInt(last, 10))
+	}
+	return row
+}
+
+// queryAuditLog fetches the audit log of a DID.
+func queryAuditLog(clientCtx client.Context, id string) ([]AuditEntry, error) {
+	res, _, err := clientCtx.QueryWithData(fmt.Sprintf("custom/did/%s/%s", QueryAudit, id), nil)
+	if err != nil {
+		return nil, err
+	}
+	var entries []AuditEntry
+	if err := json.Unmarshal(res, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// withFeeGranter applies --fee-granter to the client context.
+func withFeeGranter(cmd *cobra.Command, clientCtx client.Context) (client.Context, error) {
+	granter, _ := cmd.Flags().GetString(FlagFeeGranter)
+	if granter == "" {
+		return clientCtx, nil
+	}
+	addr, err := sdk.AccAddressFromBech32(granter)
+	if err != nil {
+		return clientCtx, fmt.Errorf("invalid fee granter: %w", err)
+	}
+	return clientCtx.WithFeeGranterAddress(addr), nil
+}
+
+// CmdVerifyDocument returns the command that checks a document disclosed
+// off-chain against the commitment of its DID.
+func CmdVerifyDocument() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify-document [document-file] [salt]",
+		Short: "Check a DID document against the hash commitment registered for its DID",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			bz, err := os.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			var doc DIDDocument
+			if err := clientCtx.Codec.UnmarshalJSON(bz, &doc); err != nil {
+				return fmt.Errorf("invalid DID document: %w", err)
+			}
+			salt, err := hex.DecodeString(args[1])
+			if err != nil {
+				return fmt.Errorf("invalid salt: %w", err)
+			}
+			data, err := json.Marshal(QueryVerifyDocumentRequest{Document: doc, Salt: salt})
+			if err != nil {
+				return err
+			}
+			res, _, err := clientCtx.QueryWithData(fmt.Sprintf("custom/did/%s", QueryVerifyDocument), data)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintBytes(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdVerifyContexts returns the command that fetches every pinned JSON-LD
+// context allowed by the params and checks it against its pinned hash.
+func CmdVerifyContexts() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify-contexts",
+		Short: "Check that pinned JSON-LD contexts still serve the content governance approved",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			res, _, err := clientCtx.QueryWithData(fmt.Sprintf("custom/did/%s", QueryParams), nil)
+			if err != nil {
+				return err
+			}
+			var params Params
+			if err := json.Unmarshal(res, &params); err != nil {
+				return err
+			}
+
+			var failed int
+			for _, pin := range params.AllowedContexts {
+				if pin.Hash == "" {
+					continue
+				}
+				content, err := fetch(cmd.Context(), pin.URI, "application/ld+json, application/json")
+				if err == nil {
+					err = pin.Verify(content)
+				}
+				if err != nil {
+					failed++
+					cmd.PrintErrf("FAIL %s: %v\n", pin.URI, err)
+					continue
+				}
+				cmd.Printf("OK   %s\n", pin.URI)
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d pinned contexts failed verification", failed)
+			}
+			return nil
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// fetch downloads uri, asking for the given media types.
+func fetch(ctx context.Context, uri, accept string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", accept)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// CmdMigrateState returns the offline command that rewrites an exported
+// genesis from the single-key DID schema to the W3C document schema.
+func CmdMigrateState() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate-did-state [genesis-file]",
+		Short: "Migrate the DID documents of an exported genesis to the W3C document schema",
+		Long: `Rewrite the did section of a genesis exported by a chain on the single-key
+schema: each public key becomes the DID's first verification method, each
+service endpoint becomes a service, and params added since take their defaults.
+The rest of the genesis is left unchanged. The migrated genesis is printed, or
+written to --output-document.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+
+			genDoc, err := tmtypes.GenesisDocFromFile(args[0])
+			if err != nil {
+				return err
+			}
+			var appState map[string]json.RawMessage
+			if err := json.Unmarshal(genDoc.AppState, &appState); err != nil {
+				return fmt.Errorf("app_state: %w", err)
+			}
+			if appState[ModuleName] == nil {
+				return fmt.Errorf("genesis has no %s state", ModuleName)
+			}
+			if appState[ModuleName], err = MigrateGenesis(clientCtx.Codec, appState[ModuleName]); err != nil {
+				return err
+			}
+			if genDoc.AppState, err = json.Marshal(appState); err != nil {
+				return err
+			}
+
+			bz, err := tmjson.Marshal(genDoc)
+			if err != nil {
+				return err
+			}
+			bz, err = sdk.SortJSON(bz)
+			if err != nil {
+				return err
+			}
+			if out, _ := cmd.Flags().GetString(flags.FlagOutputDocument); out != "" {
+				return os.WriteFile(out, append(bz, '\n'), 0o644)
+			}
+			cmd.Println(string(bz))
+			return nil
+		},
+	}
+	cmd.Flags().String(flags.FlagOutputDocument, "", "Write the migrated genesis to this file instead of stdout")
+	return cmd
+}
@@ -0,0 +1,485 @@
+package did
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	cdctypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/msgservice"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	"github.com/gogo/protobuf/proto"
+)
+
+var (
+	amino = codec.NewLegacyAmino()
+
+	// ModuleCdc is the amino codec used for the DID module's legacy sign bytes.
+	ModuleCdc = codec.NewAminoCodec(amino)
+)
+
+func init() {
+	RegisterLegacyAminoCodec(amino)
+	amino.Seal()
+
+	// The module's types are hand-written rather than generated from .proto
+	// files, so they are registered here under the names a generated
+	// aytch/did/v1 package would use. This gives them stable type URLs
+	// (e.g. /aytch.did.v1.MsgCreateDID) for Any packing, which interchain
+	// accounts and the Msg service router rely on.
+	proto.RegisterType((*DIDDocument)(nil), "aytch.did.v1.DIDDocument")
+	proto.RegisterType((*VerificationMethod)(nil), "aytch.did.v1.VerificationMethod")
+	proto.RegisterType((*Service)(nil), "aytch.did.v1.Service")
+	proto.RegisterType((*CredentialStatus)(nil), "aytch.did.v1.CredentialStatus")
+	proto.RegisterType((*Resource)(nil), "aytch.did.v1.Resource")
+	proto.RegisterType((*MsgCreateDID)(nil), "aytch.did.v1.MsgCreateDID")
+	proto.RegisterType((*MsgCreateDIDResponse)(nil), "aytch.did.v1.MsgCreateDIDResponse")
+	proto.RegisterType((*MsgRevokeCredential)(nil), "aytch.did.v1.MsgRevokeCredential")
+	proto.RegisterType((*MsgRevokeCredentialResponse)(nil), "aytch.did.v1.MsgRevokeCredentialResponse")
+	proto.RegisterType((*MsgCreateResource)(nil), "aytch.did.v1.MsgCreateResource")
+	proto.RegisterType((*MsgCreateResourceResponse)(nil), "aytch.did.v1.MsgCreateResourceResponse")
+	proto.RegisterType((*MsgUpdateDID)(nil), "aytch.did.v1.MsgUpdateDID")
+	proto.RegisterType((*MsgUpdateDIDResponse)(nil), "aytch.did.v1.MsgUpdateDIDResponse")
+	proto.RegisterType((*MsgDeactivateDID)(nil), "aytch.did.v1.MsgDeactivateDID")
+	proto.RegisterType((*MsgDeactivateDIDResponse)(nil), "aytch.did.v1.MsgDeactivateDIDResponse")
+	proto.RegisterType((*MsgSponsorDID)(nil), "aytch.did.v1.MsgSponsorDID")
+	proto.RegisterType((*MsgSponsorDIDResponse)(nil), "aytch.did.v1.MsgSponsorDIDResponse")
+	proto.RegisterType((*UpdateDIDAuthorization)(nil), "aytch.did.v1.UpdateDIDAuthorization")
+	proto.RegisterType((*QueryDIDRequest)(nil), "aytch.did.v1.QueryDIDRequest")
+	proto.RegisterType((*QueryDIDResponse)(nil), "aytch.did.v1.QueryDIDResponse")
+	proto.RegisterType((*QueryDIDsRequest)(nil), "aytch.did.v1.QueryDIDsRequest")
+	proto.RegisterType((*QueryDIDsResponse)(nil), "aytch.did.v1.QueryDIDsResponse")
+	proto.RegisterType((*QueryCredentialStatusRequest)(nil), "aytch.did.v1.QueryCredentialStatusRequest")
+	proto.RegisterType((*QueryCredentialStatusResponse)(nil), "aytch.did.v1.QueryCredentialStatusResponse")
+	proto.RegisterType((*QueryResourceRequest)(nil), "aytch.did.v1.QueryResourceRequest")
+	proto.RegisterType((*QueryResourceResponse)(nil), "aytch.did.v1.QueryResourceResponse")
+	proto.RegisterType((*QueryResourcesRequest)(nil), "aytch.did.v1.QueryResourcesRequest")
+	proto.RegisterType((*QueryResourcesResponse)(nil), "aytch.did.v1.QueryResourcesResponse")
+	proto.RegisterType((*QueryPendingChangesRequest)(nil), "aytch.did.v1.QueryPendingChangesRequest")
+	proto.RegisterType((*QueryPendingChangesResponse)(nil), "aytch.did.v1.QueryPendingChangesResponse")
+	proto.RegisterType((*QueryPendingChangeRequest)(nil), "aytch.did.v1.QueryPendingChangeRequest")
+	proto.RegisterType((*QueryPendingChangeResponse)(nil), "aytch.did.v1.QueryPendingChangeResponse")
+	proto.RegisterType((*QueryRecoveryRequest)(nil), "aytch.did.v1.QueryRecoveryRequest")
+	proto.RegisterType((*QueryRecoveryResponse)(nil), "aytch.did.v1.QueryRecoveryResponse")
+	proto.RegisterType((*QueryKeyRotationRequest)(nil), "aytch.did.v1.QueryKeyRotationRequest")
+	proto.RegisterType((*QueryKeyRotationResponse)(nil), "aytch.did.v1.QueryKeyRotationResponse")
+	proto.RegisterType((*QueryParamsRequest)(nil), "aytch.did.v1.QueryParamsRequest")
+	proto.RegisterType((*QueryParamsResponse)(nil), "aytch.did.v1.QueryParamsResponse")
+	proto.RegisterType((*QueryVerifyDocumentRequest)(nil), "aytch.did.v1.QueryVerifyDocumentRequest")
+	proto.RegisterType((*QueryVerifyDocumentResponse)(nil), "aytch.did.v1.QueryVerifyDocumentResponse")
+	proto.RegisterType((*DIDEvent)(nil), "aytch.did.v1.DIDEvent")
+	proto.RegisterType((*WatchDIDRequest)(nil), "aytch.did.v1.WatchDIDRequest")
+	proto.RegisterType((*PendingDIDChange)(nil), "aytch.did.v1.PendingDIDChange")
+	proto.RegisterType((*AuditEntry)(nil), "aytch.did.v1.AuditEntry")
+	proto.RegisterType((*TxRecord)(nil), "aytch.did.v1.TxRecord")
+	proto.RegisterType((*ActivityRecord)(nil), "aytch.did.v1.ActivityRecord")
+	proto.RegisterType((*RegistryStats)(nil), "aytch.did.v1.RegistryStats")
+	proto.RegisterType((*RecoveryConfig)(nil), "aytch.did.v1.RecoveryConfig")
+	proto.RegisterType((*PendingRecovery)(nil), "aytch.did.v1.PendingRecovery")
+	proto.RegisterType((*PendingKeyRotation)(nil), "aytch.did.v1.PendingKeyRotation")
+	proto.RegisterType((*MsgProposeDIDChange)(nil), "aytch.did.v1.MsgProposeDIDChange")
+	proto.RegisterType((*MsgProposeDIDChangeResponse)(nil), "aytch.did.v1.MsgProposeDIDChangeResponse")
+	proto.RegisterType((*MsgApproveDIDChange)(nil), "aytch.did.v1.MsgApproveDIDChange")
+	proto.RegisterType((*MsgApproveDIDChangeResponse)(nil), "aytch.did.v1.MsgApproveDIDChangeResponse")
+	proto.RegisterType((*MsgSetGuardians)(nil), "aytch.did.v1.MsgSetGuardians")
+	proto.RegisterType((*MsgSetGuardiansResponse)(nil), "aytch.did.v1.MsgSetGuardiansResponse")
+	proto.RegisterType((*MsgRecoverDID)(nil), "aytch.did.v1.MsgRecoverDID")
+	proto.RegisterType((*MsgRecoverDIDResponse)(nil), "aytch.did.v1.MsgRecoverDIDResponse")
+	proto.RegisterType((*MsgCancelRecovery)(nil), "aytch.did.v1.MsgCancelRecovery")
+	proto.RegisterType((*MsgCancelRecoveryResponse)(nil), "aytch.did.v1.MsgCancelRecoveryResponse")
+	proto.RegisterType((*MsgRotateKey)(nil), "aytch.did.v1.MsgRotateKey")
+	proto.RegisterType((*MsgRotateKeyResponse)(nil), "aytch.did.v1.MsgRotateKeyResponse")
+	proto.RegisterType((*MsgCancelKeyRotation)(nil), "aytch.did.v1.MsgCancelKeyRotation")
+	proto.RegisterType((*MsgCancelKeyRotationResponse)(nil), "aytch.did.v1.MsgCancelKeyRotationResponse")
+	proto.RegisterType((*MsgRenewDID)(nil), "aytch.did.v1.MsgRenewDID")
+	proto.RegisterType((*MsgRenewDIDResponse)(nil), "aytch.did.v1.MsgRenewDIDResponse")
+	proto.RegisterType((*MsgCreateDIDFromKey)(nil), "aytch.did.v1.MsgCreateDIDFromKey")
+	proto.RegisterType((*MsgCreateDIDFromKeyResponse)(nil), "aytch.did.v1.MsgCreateDIDFromKeyResponse")
+	proto.RegisterType((*MsgCreateDIDBatch)(nil), "aytch.did.v1.MsgCreateDIDBatch")
+	proto.RegisterType((*MsgCreateDIDBatchResponse)(nil), "aytch.did.v1.MsgCreateDIDBatchResponse")
+	proto.RegisterType((*DIDCommitment)(nil), "aytch.did.v1.DIDCommitment")
+	proto.RegisterType((*MsgCommitDID)(nil), "aytch.did.v1.MsgCommitDID")
+	proto.RegisterType((*MsgCommitDIDResponse)(nil), "aytch.did.v1.MsgCommitDIDResponse")
+	proto.RegisterType((*AccountLink)(nil), "aytch.did.v1.AccountLink")
+	proto.RegisterType((*MsgLinkAccount)(nil), "aytch.did.v1.MsgLinkAccount")
+	proto.RegisterType((*MsgLinkAccountResponse)(nil), "aytch.did.v1.MsgLinkAccountResponse")
+	proto.RegisterType((*MsgUnlinkAccount)(nil), "aytch.did.v1.MsgUnlinkAccount")
+	proto.RegisterType((*MsgUnlinkAccountResponse)(nil), "aytch.did.v1.MsgUnlinkAccountResponse")
+	proto.RegisterType((*MsgLinkEthereumAccount)(nil), "aytch.did.v1.MsgLinkEthereumAccount")
+	proto.RegisterType((*MsgLinkEthereumAccountResponse)(nil), "aytch.did.v1.MsgLinkEthereumAccountResponse")
+	proto.RegisterType((*MsgUnlinkEthereumAccount)(nil), "aytch.did.v1.MsgUnlinkEthereumAccount")
+	proto.RegisterType((*MsgUnlinkEthereumAccountResponse)(nil), "aytch.did.v1.MsgUnlinkEthereumAccountResponse")
+	proto.RegisterType((*QueryDIDByAddressRequest)(nil), "aytch.did.v1.QueryDIDByAddressRequest")
+	proto.RegisterType((*QueryDIDByAddressResponse)(nil), "aytch.did.v1.QueryDIDByAddressResponse")
+	proto.RegisterType((*QueryAccountsRequest)(nil), "aytch.did.v1.QueryAccountsRequest")
+	proto.RegisterType((*QueryAccountsResponse)(nil), "aytch.did.v1.QueryAccountsResponse")
+	proto.RegisterType((*CapabilityGrant)(nil), "aytch.did.v1.CapabilityGrant")
+	proto.RegisterType((*MsgGrantCapability)(nil), "aytch.did.v1.MsgGrantCapability")
+	proto.RegisterType((*MsgGrantCapabilityResponse)(nil), "aytch.did.v1.MsgGrantCapabilityResponse")
+	proto.RegisterType((*MsgRevokeCapability)(nil), "aytch.did.v1.MsgRevokeCapability")
+	proto.RegisterType((*MsgRevokeCapabilityResponse)(nil), "aytch.did.v1.MsgRevokeCapabilityResponse")
+	proto.RegisterType((*QueryCapabilitiesRequest)(nil), "aytch.did.v1.QueryCapabilitiesRequest")
+	proto.RegisterType((*QueryCapabilitiesResponse)(nil), "aytch.did.v1.QueryCapabilitiesResponse")
+	proto.RegisterType((*LinkedAsset)(nil), "aytch.did.v1.LinkedAsset")
+	proto.RegisterType((*MsgLinkAsset)(nil), "aytch.did.v1.MsgLinkAsset")
+	proto.RegisterType((*MsgLinkAssetResponse)(nil), "aytch.did.v1.MsgLinkAssetResponse")
+	proto.RegisterType((*MsgUnlinkAsset)(nil), "aytch.did.v1.MsgUnlinkAsset")
+	proto.RegisterType((*MsgUnlinkAssetResponse)(nil), "aytch.did.v1.MsgUnlinkAssetResponse")
+	proto.RegisterType((*QueryLinkedAssetsRequest)(nil), "aytch.did.v1.QueryLinkedAssetsRequest")
+	proto.RegisterType((*QueryLinkedAssetsResponse)(nil), "aytch.did.v1.QueryLinkedAssetsResponse")
+	proto.RegisterType((*DIDDeposit)(nil), "aytch.did.v1.DIDDeposit")
+	proto.RegisterType((*FeeEscrow)(nil), "aytch.did.v1.FeeEscrow")
+	proto.RegisterType((*SlashDepositProposal)(nil), "aytch.did.v1.SlashDepositProposal")
+	proto.RegisterType((*QueryDepositRequest)(nil), "aytch.did.v1.QueryDepositRequest")
+	proto.RegisterType((*QueryDepositResponse)(nil), "aytch.did.v1.QueryDepositResponse")
+	proto.RegisterType((*DeactivateDIDProposal)(nil), "aytch.did.v1.DeactivateDIDProposal")
+	proto.RegisterType((*StatusListEntry)(nil), "aytch.did.v1.StatusListEntry")
+	proto.RegisterType((*MsgRegisterCredentialStatus)(nil), "aytch.did.v1.MsgRegisterCredentialStatus")
+	proto.RegisterType((*MsgRegisterCredentialStatusResponse)(nil), "aytch.did.v1.MsgRegisterCredentialStatusResponse")
+	proto.RegisterType((*MsgRevokeCredentials)(nil), "aytch.did.v1.MsgRevokeCredentials")
+	proto.RegisterType((*MsgRevokeCredentialsResponse)(nil), "aytch.did.v1.MsgRevokeCredentialsResponse")
+	proto.RegisterType((*QueryResourceVersionsRequest)(nil), "aytch.did.v1.QueryResourceVersionsRequest")
+	proto.RegisterType((*QueryResourceVersionsResponse)(nil), "aytch.did.v1.QueryResourceVersionsResponse")
+	proto.RegisterType((*PresentationDefinitionRecord)(nil), "aytch.did.v1.PresentationDefinitionRecord")
+	proto.RegisterType((*MsgSetPresentationDefinition)(nil), "aytch.did.v1.MsgSetPresentationDefinition")
+	proto.RegisterType((*MsgSetPresentationDefinitionResponse)(nil), "aytch.did.v1.MsgSetPresentationDefinitionResponse")
+	proto.RegisterType((*MsgDeletePresentationDefinition)(nil), "aytch.did.v1.MsgDeletePresentationDefinition")
+	proto.RegisterType((*MsgDeletePresentationDefinitionResponse)(nil), "aytch.did.v1.MsgDeletePresentationDefinitionResponse")
+	proto.RegisterType((*QueryPresentationDefinitionsRequest)(nil), "aytch.did.v1.QueryPresentationDefinitionsRequest")
+	proto.RegisterType((*QueryPresentationDefinitionsResponse)(nil), "aytch.did.v1.QueryPresentationDefinitionsResponse")
+	proto.RegisterType((*QueryPresentationDefinitionRequest)(nil), "aytch.did.v1.QueryPresentationDefinitionRequest")
+	proto.RegisterType((*QueryPresentationDefinitionResponse)(nil), "aytch.did.v1.QueryPresentationDefinitionResponse")
+	proto.RegisterType((*HashAnchor)(nil), "aytch.did.v1.HashAnchor")
+	proto.RegisterType((*MsgAnchorHash)(nil), "aytch.did.v1.MsgAnchorHash")
+	proto.RegisterType((*MsgAnchorHashResponse)(nil), "aytch.did.v1.MsgAnchorHashResponse")
+	proto.RegisterType((*QueryHashAnchorsRequest)(nil), "aytch.did.v1.QueryHashAnchorsRequest")
+	proto.RegisterType((*QueryHashAnchorsResponse)(nil), "aytch.did.v1.QueryHashAnchorsResponse")
+	proto.RegisterType((*CredentialRoot)(nil), "aytch.did.v1.CredentialRoot")
+	proto.RegisterType((*CredentialInclusionProof)(nil), "aytch.did.v1.CredentialInclusionProof")
+	proto.RegisterType((*MsgAnchorCredentialRoot)(nil), "aytch.did.v1.MsgAnchorCredentialRoot")
+	proto.RegisterType((*MsgAnchorCredentialRootResponse)(nil), "aytch.did.v1.MsgAnchorCredentialRootResponse")
+	proto.RegisterType((*QueryVerifyCredentialInclusionRequest)(nil), "aytch.did.v1.QueryVerifyCredentialInclusionRequest")
+	proto.RegisterType((*QueryVerifyCredentialInclusionResponse)(nil), "aytch.did.v1.QueryVerifyCredentialInclusionResponse")
+	proto.RegisterType((*QueryResourceByNameRequest)(nil), "aytch.did.v1.QueryResourceByNameRequest")
+	proto.RegisterType((*QueryResourceByNameResponse)(nil), "aytch.did.v1.QueryResourceByNameResponse")
+	proto.RegisterType((*QueryTxHistoryRequest)(nil), "aytch.did.v1.QueryTxHistoryRequest")
+	proto.RegisterType((*QueryTxHistoryResponse)(nil), "aytch.did.v1.QueryTxHistoryResponse")
+	proto.RegisterType((*QueryActivityRequest)(nil), "aytch.did.v1.QueryActivityRequest")
+	proto.RegisterType((*QueryActivityResponse)(nil), "aytch.did.v1.QueryActivityResponse")
+	proto.RegisterType((*QueryStatsRequest)(nil), "aytch.did.v1.QueryStatsRequest")
+	proto.RegisterType((*QueryStatsResponse)(nil), "aytch.did.v1.QueryStatsResponse")
+}
+
+// RegisterLegacyAminoCodec registers the DID module's messages on the given LegacyAmino codec.
+func RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) {
+	cdc.RegisterConcrete(MsgCreateDID{}, "did/CreateDID", nil)
+	cdc.RegisterConcrete(MsgRevokeCredential{}, "did/RevokeCredential", nil)
+	cdc.RegisterConcrete(MsgCreateResource{}, "did/CreateResource", nil)
+	cdc.RegisterConcrete(MsgUpdateDID{}, "did/UpdateDID", nil)
+	cdc.RegisterConcrete(MsgDeactivateDID{}, "did/DeactivateDID", nil)
+	cdc.RegisterConcrete(MsgProposeDIDChange{}, "did/ProposeDIDChange", nil)
+	cdc.RegisterConcrete(MsgApproveDIDChange{}, "did/ApproveDIDChange", nil)
+	cdc.RegisterConcrete(MsgSponsorDID{}, "did/SponsorDID", nil)
+	cdc.RegisterConcrete(MsgSetGuardians{}, "did/SetGuardians", nil)
+	cdc.RegisterConcrete(MsgRecoverDID{}, "did/RecoverDID", nil)
+	cdc.RegisterConcrete(MsgCancelRecovery{}, "did/CancelRecovery", nil)
+	cdc.RegisterConcrete(MsgRotateKey{}, "did/RotateKey", nil)
+	cdc.RegisterConcrete(MsgCancelKeyRotation{}, "did/CancelKeyRotation", nil)
+	cdc.RegisterConcrete(MsgRenewDID{}, "did/RenewDID", nil)
+	cdc.RegisterConcrete(MsgCreateDIDFromKey{}, "did/CreateDIDFromKey", nil)
+	cdc.RegisterConcrete(MsgCreateDIDBatch{}, "did/CreateDIDBatch", nil)
+	cdc.RegisterConcrete(MsgCommitDID{}, "did/CommitDID", nil)
+	cdc.RegisterConcrete(MsgLinkAccount{}, "did/LinkAccount", nil)
+	cdc.RegisterConcrete(MsgUnlinkAccount{}, "did/UnlinkAccount", nil)
+	cdc.RegisterConcrete(MsgLinkEthereumAccount{}, "did/LinkEthereumAccount", nil)
+	cdc.RegisterConcrete(MsgUnlinkEthereumAccount{}, "did/UnlinkEthereumAccount", nil)
+	cdc.RegisterConcrete(MsgGrantCapability{}, "did/GrantCapability", nil)
+	cdc.RegisterConcrete(MsgRevokeCapability{}, "did/RevokeCapability", nil)
+	cdc.RegisterConcrete(MsgLinkAsset{}, "did/LinkAsset", nil)
+	cdc.RegisterConcrete(MsgUnlinkAsset{}, "did/UnlinkAsset", nil)
+	cdc.RegisterConcrete(MsgRegisterCredentialStatus{}, "did/RegisterCredentialStatus", nil)
+	cdc.RegisterConcrete(MsgRevokeCredentials{}, "did/RevokeCredentials", nil)
+	cdc.RegisterConcrete(MsgSetPresentationDefinition{}, "did/SetPresentationDefinition", nil)
+	cdc.RegisterConcrete(MsgDeletePresentationDefinition{}, "did/DeletePresentationDefinition", nil)
+	cdc.RegisterConcrete(MsgAnchorHash{}, "did/AnchorHash", nil)
+	cdc.RegisterConcrete(MsgAnchorCredentialRoot{}, "did/AnchorCredentialRoot", nil)
+	cdc.RegisterConcrete(&UpdateDIDAuthorization{}, "did/UpdateDIDAuthorization", nil)
+}
+
+// RegisterInterfaces registers the DID module's messages as sdk.Msg
+// implementations along with the Msg service that handles them, and its authz
+// authorizations.
+func RegisterInterfaces(registry cdctypes.InterfaceRegistry) {
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgCreateDID{},
+		&MsgRevokeCredential{},
+		&MsgCreateResource{},
+		&MsgUpdateDID{},
+		&MsgDeactivateDID{},
+		&MsgProposeDIDChange{},
+		&MsgApproveDIDChange{},
+		&MsgSponsorDID{},
+		&MsgSetGuardians{},
+		&MsgRecoverDID{},
+		&MsgCancelRecovery{},
+		&MsgRotateKey{},
+		&MsgCancelKeyRotation{},
+		&MsgRenewDID{},
+		&MsgCreateDIDFromKey{},
+		&MsgCreateDIDBatch{},
+		&MsgCommitDID{},
+		&MsgLinkAccount{},
+		&MsgUnlinkAccount{},
+		&MsgLinkEthereumAccount{},
+		&MsgUnlinkEthereumAccount{},
+		&MsgGrantCapability{},
+		&MsgRevokeCapability{},
+		&MsgLinkAsset{},
+		&MsgUnlinkAsset{},
+		&MsgRegisterCredentialStatus{},
+		&MsgRevokeCredentials{},
+		&MsgSetPresentationDefinition{},
+		&MsgDeletePresentationDefinition{},
+		&MsgAnchorHash{},
+		&MsgAnchorCredentialRoot{},
+	)
+	registry.RegisterImplementations((*authz.Authorization)(nil),
+		&UpdateDIDAuthorization{},
+	)
+	registry.RegisterImplementations((*govtypes.Content)(nil),
+		&SlashDepositProposal{},
+		&DeactivateDIDProposal{},
+	)
+	msgservice.RegisterMsgServiceDesc(registry, &_Msg_serviceDesc)
+}
+
+func (m *DIDDocument) Reset()         { *m = DIDDocument{} }
+func (m *DIDDocument) String() string { return proto.CompactTextString(m) }
+func (*DIDDocument) ProtoMessage()    {}
+
+func (m *VerificationMethod) Reset()         { *m = VerificationMethod{} }
+func (m *VerificationMethod) String() string { return proto.CompactTextString(m) }
+func (*VerificationMethod) ProtoMessage()    {}
+
+func (m *Service) Reset()         { *m = Service{} }
+func (m *Service) String() string { return proto.CompactTextString(m) }
+func (*Service) ProtoMessage()    {}
+
+func (m *CredentialStatus) Reset()         { *m = CredentialStatus{} }
+func (m *CredentialStatus) String() string { return proto.CompactTextString(m) }
+func (*CredentialStatus) ProtoMessage()    {}
+
+func (m *Resource) Reset()         { *m = Resource{} }
+func (m *Resource) String() string { return proto.CompactTextString(m) }
+func (*Resource) ProtoMessage()    {}
+
+func (m *MsgCreateDID) Reset()         { *m = MsgCreateDID{} }
+func (m *MsgCreateDID) String() string { return proto.CompactTextString(m) }
+func (*MsgCreateDID) ProtoMessage()    {}
+
+func (m *MsgRevokeCredential) Reset()         { *m = MsgRevokeCredential{} }
+func (m *MsgRevokeCredential) String() string { return proto.CompactTextString(m) }
+func (*MsgRevokeCredential) ProtoMessage()    {}
+
+func (m *MsgCreateResource) Reset()         { *m = MsgCreateResource{} }
+func (m *MsgCreateResource) String() string { return proto.CompactTextString(m) }
+func (*MsgCreateResource) ProtoMessage()    {}
+
+func (m *MsgUpdateDID) Reset()         { *m = MsgUpdateDID{} }
+func (m *MsgUpdateDID) String() string { return proto.CompactTextString(m) }
+func (*MsgUpdateDID) ProtoMessage()    {}
+
+func (m *MsgDeactivateDID) Reset()         { *m = MsgDeactivateDID{} }
+func (m *MsgDeactivateDID) String() string { return proto.CompactTextString(m) }
+func (*MsgDeactivateDID) ProtoMessage()    {}
+
+func (m *PendingDIDChange) Reset()         { *m = PendingDIDChange{} }
+func (m *PendingDIDChange) String() string { return proto.CompactTextString(m) }
+func (*PendingDIDChange) ProtoMessage()    {}
+
+func (m *AuditEntry) Reset()         { *m = AuditEntry{} }
+func (m *AuditEntry) String() string { return proto.CompactTextString(m) }
+func (*AuditEntry) ProtoMessage()    {}
+
+func (m *TxRecord) Reset()         { *m = TxRecord{} }
+func (m *TxRecord) String() string { return proto.CompactTextString(m) }
+func (*TxRecord) ProtoMessage()    {}
+
+func (m *ActivityRecord) Reset()         { *m = ActivityRecord{} }
+func (m *ActivityRecord) String() string { return proto.CompactTextString(m) }
+func (*ActivityRecord) ProtoMessage()    {}
+
+func (m *RegistryStats) Reset()         { *m = RegistryStats{} }
+func (m *RegistryStats) String() string { return proto.CompactTextString(m) }
+func (*RegistryStats) ProtoMessage()    {}
+
+func (m *MsgProposeDIDChange) Reset()         { *m = MsgProposeDIDChange{} }
+func (m *MsgProposeDIDChange) String() string { return proto.CompactTextString(m) }
+func (*MsgProposeDIDChange) ProtoMessage()    {}
+
+func (m *MsgApproveDIDChange) Reset()         { *m = MsgApproveDIDChange{} }
+func (m *MsgApproveDIDChange) String() string { return proto.CompactTextString(m) }
+func (*MsgApproveDIDChange) ProtoMessage()    {}
+
+func (m *UpdateDIDAuthorization) Reset()         { *m = UpdateDIDAuthorization{} }
+func (m *UpdateDIDAuthorization) String() string { return proto.CompactTextString(m) }
+func (*UpdateDIDAuthorization) ProtoMessage()    {}
+
+func (m *MsgSponsorDID) Reset()         { *m = MsgSponsorDID{} }
+func (m *MsgSponsorDID) String() string { return proto.CompactTextString(m) }
+func (*MsgSponsorDID) ProtoMessage()    {}
+
+func (m *MsgSetGuardians) Reset()         { *m = MsgSetGuardians{} }
+func (m *MsgSetGuardians) String() string { return proto.CompactTextString(m) }
+func (*MsgSetGuardians) ProtoMessage()    {}
+
+func (m *MsgRecoverDID) Reset()         { *m = MsgRecoverDID{} }
+func (m *MsgRecoverDID) String() string { return proto.CompactTextString(m) }
+func (*MsgRecoverDID) ProtoMessage()    {}
+
+func (m *MsgCancelRecovery) Reset()         { *m = MsgCancelRecovery{} }
+func (m *MsgCancelRecovery) String() string { return proto.CompactTextString(m) }
+func (*MsgCancelRecovery) ProtoMessage()    {}
+
+func (m *RecoveryConfig) Reset()         { *m = RecoveryConfig{} }
+func (m *RecoveryConfig) String() string { return proto.CompactTextString(m) }
+func (*RecoveryConfig) ProtoMessage()    {}
+
+func (m *PendingRecovery) Reset()         { *m = PendingRecovery{} }
+func (m *PendingRecovery) String() string { return proto.CompactTextString(m) }
+func (*PendingRecovery) ProtoMessage()    {}
+
+func (m *MsgRotateKey) Reset()         { *m = MsgRotateKey{} }
+func (m *MsgRotateKey) String() string { return proto.CompactTextString(m) }
+func (*MsgRotateKey) ProtoMessage()    {}
+
+func (m *MsgCancelKeyRotation) Reset()         { *m = MsgCancelKeyRotation{} }
+func (m *MsgCancelKeyRotation) String() string { return proto.CompactTextString(m) }
+func (*MsgCancelKeyRotation) ProtoMessage()    {}
+
+func (m *PendingKeyRotation) Reset()         { *m = PendingKeyRotation{} }
+func (m *PendingKeyRotation) String() string { return proto.CompactTextString(m) }
+func (*PendingKeyRotation) ProtoMessage()    {}
+
+func (m *MsgRenewDID) Reset()         { *m = MsgRenewDID{} }
+func (m *MsgRenewDID) String() string { return proto.CompactTextString(m) }
+func (*MsgRenewDID) ProtoMessage()    {}
+
+func (m *MsgCreateDIDFromKey) Reset()         { *m = MsgCreateDIDFromKey{} }
+func (m *MsgCreateDIDFromKey) String() string { return proto.CompactTextString(m) }
+func (*MsgCreateDIDFromKey) ProtoMessage()    {}
+
+func (m *MsgCreateDIDBatch) Reset()         { *m = MsgCreateDIDBatch{} }
+func (m *MsgCreateDIDBatch) String() string { return proto.CompactTextString(m) }
+func (*MsgCreateDIDBatch) ProtoMessage()    {}
+
+func (m *DIDCommitment) Reset()         { *m = DIDCommitment{} }
+func (m *DIDCommitment) String() string { return proto.CompactTextString(m) }
+func (*DIDCommitment) ProtoMessage()    {}
+
+func (m *MsgCommitDID) Reset()         { *m = MsgCommitDID{} }
+func (m *MsgCommitDID) String() string { return proto.CompactTextString(m) }
+func (*MsgCommitDID) ProtoMessage()    {}
+
+func (m *AccountLink) Reset()         { *m = AccountLink{} }
+func (m *AccountLink) String() string { return proto.CompactTextString(m) }
+func (*AccountLink) ProtoMessage()    {}
+
+func (m *MsgLinkAccount) Reset()         { *m = MsgLinkAccount{} }
+func (m *MsgLinkAccount) String() string { return proto.CompactTextString(m) }
+func (*MsgLinkAccount) ProtoMessage()    {}
+
+func (m *MsgUnlinkAccount) Reset()         { *m = MsgUnlinkAccount{} }
+func (m *MsgUnlinkAccount) String() string { return proto.CompactTextString(m) }
+func (*MsgUnlinkAccount) ProtoMessage()    {}
+
+func (m *MsgLinkEthereumAccount) Reset()         { *m = MsgLinkEthereumAccount{} }
+func (m *MsgLinkEthereumAccount) String() string { return proto.CompactTextString(m) }
+func (*MsgLinkEthereumAccount) ProtoMessage()    {}
+
+func (m *MsgUnlinkEthereumAccount) Reset()         { *m = MsgUnlinkEthereumAccount{} }
+func (m *MsgUnlinkEthereumAccount) String() string { return proto.CompactTextString(m) }
+func (*MsgUnlinkEthereumAccount) ProtoMessage()    {}
+
+func (m *CapabilityGrant) Reset()         { *m = CapabilityGrant{} }
+func (m *CapabilityGrant) String() string { return proto.CompactTextString(m) }
+func (*CapabilityGrant) ProtoMessage()    {}
+
+func (m *MsgGrantCapability) Reset()         { *m = MsgGrantCapability{} }
+func (m *MsgGrantCapability) String() string { return proto.CompactTextString(m) }
+func (*MsgGrantCapability) ProtoMessage()    {}
+
+func (m *MsgRevokeCapability) Reset()         { *m = MsgRevokeCapability{} }
+func (m *MsgRevokeCapability) String() string { return proto.CompactTextString(m) }
+func (*MsgRevokeCapability) ProtoMessage()    {}
+
+func (m *LinkedAsset) Reset()         { *m = LinkedAsset{} }
+func (m *LinkedAsset) String() string { return proto.CompactTextString(m) }
+func (*LinkedAsset) ProtoMessage()    {}
+
+func (m *MsgLinkAsset) Reset()         { *m = MsgLinkAsset{} }
+func (m *MsgLinkAsset) String() string { return proto.CompactTextString(m) }
+func (*MsgLinkAsset) ProtoMessage()    {}
+
+func (m *MsgUnlinkAsset) Reset()         { *m = MsgUnlinkAsset{} }
+func (m *MsgUnlinkAsset) String() string { return proto.CompactTextString(m) }
+func (*MsgUnlinkAsset) ProtoMessage()    {}
+
+func (m *DIDDeposit) Reset()         { *m = DIDDeposit{} }
+func (m *DIDDeposit) String() string { return proto.CompactTextString(m) }
+func (*DIDDeposit) ProtoMessage()    {}
+
+func (m *FeeEscrow) Reset()         { *m = FeeEscrow{} }
+func (m *FeeEscrow) String() string { return proto.CompactTextString(m) }
+func (*FeeEscrow) ProtoMessage()    {}
+
+func (m *SlashDepositProposal) Reset()         { *m = SlashDepositProposal{} }
+func (m *SlashDepositProposal) String() string { return proto.CompactTextString(m) }
+func (*SlashDepositProposal) ProtoMessage()    {}
+
+func (m *DeactivateDIDProposal) Reset()         { *m = DeactivateDIDProposal{} }
+func (m *DeactivateDIDProposal) String() string { return proto.CompactTextString(m) }
+func (*DeactivateDIDProposal) ProtoMessage()    {}
+
+func (m *StatusListEntry) Reset()         { *m = StatusListEntry{} }
+func (m *StatusListEntry) String() string { return proto.CompactTextString(m) }
+func (*StatusListEntry) ProtoMessage()    {}
+
+func (m *MsgRegisterCredentialStatus) Reset()         { *m = MsgRegisterCredentialStatus{} }
+func (m *MsgRegisterCredentialStatus) String() string { return proto.CompactTextString(m) }
+func (*MsgRegisterCredentialStatus) ProtoMessage()    {}
+
+func (m *MsgRevokeCredentials) Reset()         { *m = MsgRevokeCredentials{} }
+func (m *MsgRevokeCredentials) String() string { return proto.CompactTextString(m) }
+func (*MsgRevokeCredentials) ProtoMessage()    {}
+
+func (m *PresentationDefinitionRecord) Reset()         { *m = PresentationDefinitionRecord{} }
+func (m *PresentationDefinitionRecord) String() string { return proto.CompactTextString(m) }
+func (*PresentationDefinitionRecord) ProtoMessage()    {}
+
+func (m *MsgSetPresentationDefinition) Reset()         { *m = MsgSetPresentationDefinition{} }
+func (m *MsgSetPresentationDefinition) String() string { return proto.CompactTextString(m) }
+func (*MsgSetPresentationDefinition) ProtoMessage()    {}
+
+func (m *MsgDeletePresentationDefinition) Reset()         { *m = MsgDeletePresentationDefinition{} }
+func (m *MsgDeletePresentationDefinition) String() string { return proto.CompactTextString(m) }
+func (*MsgDeletePresentationDefinition) ProtoMessage()    {}
+
+func (m *HashAnchor) Reset()         { *m = HashAnchor{} }
+func (m *HashAnchor) String() string { return proto.CompactTextString(m) }
+func (*HashAnchor) ProtoMessage()    {}
+
+func (m *MsgAnchorHash) Reset()         { *m = MsgAnchorHash{} }
+func (m *MsgAnchorHash) String() string { return proto.CompactTextString(m) }
+func (*MsgAnchorHash) ProtoMessage()    {}
+
+func (m *CredentialRoot) Reset()         { *m = CredentialRoot{} }
+func (m *CredentialRoot) String() string { return proto.CompactTextString(m) }
+func (*CredentialRoot) ProtoMessage()    {}
+
+func (m *CredentialInclusionProof) Reset()         { *m = CredentialInclusionProof{} }
+func (m *CredentialInclusionProof) String() string { return proto.CompactTextString(m) }
+func (*CredentialInclusionProof) ProtoMessage()    {}
+
+func (m *MsgAnchorCredentialRoot) Reset()         { *m = MsgAnchorCredentialRoot{} }
+func (m *MsgAnchorCredentialRoot) String() string { return proto.CompactTextString(m) }
+func (*MsgAnchorCredentialRoot) ProtoMessage()    {}
@@ -0,0 +1,86 @@
+package did
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// MinCommitmentSaltSize is the smallest salt accepted for a document
+// commitment. The salt keeps low-entropy documents from being recovered by
+// hashing guesses against the commitment, so it must be random and kept with
+// the document.
+const MinCommitmentSaltSize = 16
+
+// DIDCommitment registers a DID in hash-only mode: the chain holds a salted
+// hash of the document rather than the document, which the holder discloses
+// off-chain to whoever needs it. The DID's identifier is reserved like any
+// other DID, and a disclosed document is checked with the VerifyDocument query.
+type DIDCommitment struct {
+	ID         string         `protobuf:"bytes,1,opt,name=id,proto3" json:"id"`
+	Commitment []byte         `protobuf:"bytes,2,opt,name=commitment,proto3" json:"commitment"`
+	Owner      sdk.AccAddress `protobuf:"bytes,3,opt,name=owner,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"owner"`
+	Created    int64          `protobuf:"varint,4,opt,name=created,proto3" json:"created"`
+	Updated    int64          `protobuf:"varint,5,opt,name=updated,proto3" json:"updated"`
+}
+
+// CommitDocument returns the commitment to doc under salt: the SHA-256 of
+// the salt followed by the JCS canonical form of the document's W3C
+// representation, so any serialization of the same document commits alike.
+func CommitDocument(doc DIDDocument, salt []byte) ([]byte, error) {
+	if len(salt) < MinCommitmentSaltSize {
+		return nil, fmt.Errorf("commitment salt must be at least %d bytes", MinCommitmentSaltSize)
+	}
+	bz, err := CanonicalJSON(doc.W3C(false))
+	if err != nil {
+		return nil, err
+	}
+	h := sha256.New()
+	h.Write(salt)
+	h.Write(bz)
+	return h.Sum(nil), nil
+}
+
+// MsgCommitDID registers a DID in hash-only mode, or replaces the commitment
+// of a DID the creator registered that way.
+type MsgCommitDID struct {
+	ID         string         `protobuf:"bytes,1,opt,name=id,proto3" json:"id"`
+	Commitment []byte         `protobuf:"bytes,2,opt,name=commitment,proto3" json:"commitment"`
+	Creator    sdk.AccAddress `protobuf:"bytes,3,opt,name=creator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"creator"`
+}
+
+// ValidateBasic performs basic validation of MsgCommitDID.
+func (msg MsgCommitDID) ValidateBasic() error {
+	if err := validateChecksums(msg); err != nil {
+		return err
+	}
+	if !strings.HasPrefix(msg.ID, DIDMethodPrefix) || msg.ID == DIDMethodPrefix {
+		return sdkerrors.ErrUnknownRequest.Wrapf("DID must start with %s", DIDMethodPrefix)
+	}
+	if len(msg.Commitment) != sha256.Size {
+		return sdkerrors.ErrUnknownRequest.Wrap("Commitment must be a SHA-256 digest")
+	}
+	if msg.Creator.Empty() {
+		return sdkerrors.ErrUnknownRequest.Wrap("Creator cannot be empty")
+	}
+	return nil
+}
+
+// Route returns the message route.
+func (msg MsgCommitDID) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgCommitDID) Type() string { return "commit_did" }
+
+// GetSignBytes returns the canonical bytes to sign over.
+func (msg MsgCommitDID) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners returns the account that must sign the message.
+func (msg MsgCommitDID) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
@@ -0,0 +1,75 @@
+package did
+
+import (
+	"bytes"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// CommitDID registers id in hash-only mode with the given commitment, or
+// replaces the commitment if owner registered it that way before. An
+// identifier can't hold both a commitment and a document.
+func (k Keeper) CommitDID(ctx sdk.Context, id string, commitment []byte, owner sdk.AccAddress) error {
+	store := ctx.KVStore(k.storeKey)
+	if store.Has(didKey(id)) {
+		return fmt.Errorf("DID already exists")
+	}
+	c, err := k.GetDIDCommitment(ctx, id)
+	if err != nil {
+		c = DIDCommitment{ID: id, Owner: owner, Created: ctx.BlockHeight()}
+	} else if !c.Owner.Equals(owner) {
+		return fmt.Errorf("commitment of %s is owned by %s", id, c.Owner)
+	}
+	c.Commitment = commitment
+	c.Updated = ctx.BlockHeight()
+	k.setDIDCommitment(ctx, c)
+	return nil
+}
+
+func (k Keeper) setDIDCommitment(ctx sdk.Context, c DIDCommitment) {
+	ctx.KVStore(k.storeKey).Set(commitmentKey(c.ID), k.cdc.MustMarshal(&c))
+}
+
+// GetDIDCommitment returns the commitment of a DID registered in hash-only
+// mode.
+func (k Keeper) GetDIDCommitment(ctx sdk.Context, id string) (DIDCommitment, error) {
+	value := ctx.KVStore(k.storeKey).Get(commitmentKey(id))
+	if value == nil {
+		return DIDCommitment{}, fmt.Errorf("no commitment for %s", id)
+	}
+	var c DIDCommitment
+	k.cdc.MustUnmarshal(value, &c)
+	return c, nil
+}
+
+// GetAllDIDCommitments returns every commitment in the store.
+func (k Keeper) GetAllDIDCommitments(ctx sdk.Context) []DIDCommitment {
+	var commitments []DIDCommitment
+	iteratePrefix(ctx.KVStore(k.storeKey), CommitmentPrefix, func(_, value []byte) bool {
+		var c DIDCommitment
+		k.cdc.MustUnmarshal(value, &c)
+		commitments = append(commitments, c)
+		return false
+	})
+	return commitments
+}
+
+// VerifyDocument checks a document disclosed off-chain, with the salt it was
+// committed under, against the commitment stored for its DID. It returns an
+// error only when the DID has no commitment or the salt is unusable; a
+// document that doesn't match yields false.
+func (k Keeper) VerifyDocument(ctx sdk.Context, doc DIDDocument, salt []byte) (bool, error) {
+	c, err := k.GetDIDCommitment(ctx, doc.ID)
+	if err != nil {
+		return false, err
+	}
+	if checkKeyDerivedID(doc.ID, doc.PublicKey) != nil {
+		return false, nil
+	}
+	commitment, err := CommitDocument(doc, salt)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(commitment, c.Commitment), nil
+}
@@ -0,0 +1,97 @@
+package did
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// defaultContexts are the JSON-LD contexts every document is rendered with.
+// Documents may list further contexts, which must be allowed by the
+// AllowedContexts param.
+var defaultContexts = []string{
+	DIDContextV1,
+	"https://w3id.org/security/suites/ed25519-2020/v1",
+	"https://w3id.org/security/suites/x25519-2020/v1",
+}
+
+// defaultContextPins allows the default contexts without pinning their
+// content.
+func defaultContextPins() []ContextPin {
+	pins := make([]ContextPin, len(defaultContexts))
+	for i, uri := range defaultContexts {
+		pins[i] = ContextPin{URI: uri}
+	}
+	return pins
+}
+
+// ContextPin is a JSON-LD context documents may reference. When Hash is set
+// it is the hex SHA-256 of the context's content: the chain can't fetch
+// contexts itself, so resolvers and operators use it to check that the URI
+// still serves the context governance approved.
+type ContextPin struct {
+	URI  string `protobuf:"bytes,1,opt,name=uri,proto3" json:"uri"`
+	Hash string `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+}
+
+// Verify checks content fetched from the pin's URI against its hash. Pins
+// without a hash accept any content.
+func (p ContextPin) Verify(content []byte) error {
+	if p.Hash == "" {
+		return nil
+	}
+	sum := sha256.Sum256(content)
+	if got := hex.EncodeToString(sum[:]); got != p.Hash {
+		return fmt.Errorf("context %s has hash %s, pinned %s", p.URI, got, p.Hash)
+	}
+	return nil
+}
+
+// IsContextAllowed reports whether documents may reference the context uri.
+func (p Params) IsContextAllowed(uri string) bool {
+	for _, pin := range p.AllowedContexts {
+		if pin.URI == uri {
+			return true
+		}
+	}
+	return false
+}
+
+// checkContexts rejects documents referencing contexts outside the
+// AllowedContexts param.
+func (k Keeper) checkContexts(ctx sdk.Context, did DIDDocument) error {
+	params := k.GetParams(ctx)
+	for _, uri := range did.Context {
+		if !params.IsContextAllowed(uri) {
+			return sdkerrors.Wrap(ErrContextNotAllowed, uri)
+		}
+	}
+	return nil
+}
+
+func validateAllowedContexts(i interface{}) error {
+	pins, ok := i.([]ContextPin)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	seen := make(map[string]bool, len(pins))
+	for _, pin := range pins {
+		if pin.URI == "" {
+			return fmt.Errorf("context URI cannot be empty")
+		}
+		if seen[pin.URI] {
+			return fmt.Errorf("context %s is listed more than once", pin.URI)
+		}
+		seen[pin.URI] = true
+		if pin.Hash == "" {
+			continue
+		}
+		if b, err := hex.DecodeString(pin.Hash); err != nil || len(b) != sha256.Size || hex.EncodeToString(b) != pin.Hash {
+			return fmt.Errorf("context %s hash must be a lowercase hex SHA-256 digest", pin.URI)
+		}
+	}
+	return nil
+}
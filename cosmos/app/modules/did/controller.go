@@ -0,0 +1,160 @@
+package did
+
+import (
+	"encoding/json"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// MsgUpdateDID replaces the contents of an existing DID document. Signer names
+// the controller entry authorizing the update: an account address must match
+// Creator, while a controller DID must produce Signature over ProofSignBytes
+// with its registered Ed25519 authentication key. Nonce must equal the
+// document's current nonce.
+type MsgUpdateDID struct {
+	ID                  string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id"`
+	PublicKey           string               `protobuf:"bytes,2,opt,name=public_key,proto3" json:"public_key"`
+	ServiceEndpoints    []string             `protobuf:"bytes,3,rep,name=service_endpoints,proto3" json:"service_endpoints"`
+	Authentication      string               `protobuf:"bytes,4,opt,name=authentication,proto3" json:"authentication"`
+	VerificationMethods []VerificationMethod `protobuf:"bytes,5,rep,name=verification_methods,proto3" json:"verification_methods"`
+	KeyAgreement        []string             `protobuf:"bytes,6,rep,name=key_agreement,proto3" json:"key_agreement"`
+	Services            []Service            `protobuf:"bytes,7,rep,name=services,proto3" json:"services"`
+	Controller          []string             `protobuf:"bytes,8,rep,name=controller,proto3" json:"controller,omitempty"`
+	ControllerThreshold uint32               `protobuf:"varint,12,opt,name=controller_threshold,proto3" json:"controller_threshold,omitempty"`
+	RotationDelay       uint64               `protobuf:"varint,13,opt,name=rotation_delay,proto3" json:"rotation_delay,omitempty"`
+	ExpiresAt           int64                `protobuf:"varint,14,opt,name=expires_at,proto3" json:"expires_at,omitempty"`
+	Nonce               uint64               `protobuf:"varint,15,opt,name=nonce,proto3" json:"nonce"`
+	Signer              string               `protobuf:"bytes,9,opt,name=signer,proto3" json:"signer"`
+	Signature           []byte               `protobuf:"bytes,10,opt,name=signature,proto3" json:"signature,omitempty"`
+	Creator             sdk.AccAddress       `protobuf:"bytes,11,opt,name=creator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"creator"`
+	Context             []string             `protobuf:"bytes,16,rep,name=context,proto3" json:"context,omitempty"`
+	ContentCID          string               `protobuf:"bytes,17,opt,name=content_cid,proto3" json:"content_cid,omitempty"`
+	// Operations, if set, limits the update to the listed AuthzOperation
+	// groups of fields. An UpdateDIDAuthorization grantee must declare them.
+	Operations []string `protobuf:"bytes,18,rep,name=operations,proto3" json:"operations,omitempty"`
+}
+
+// ValidateBasic performs basic validation of MsgUpdateDID.
+func (msg MsgUpdateDID) ValidateBasic() error {
+	if err := validateChecksums(msg); err != nil {
+		return err
+	}
+	if msg.ID == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("DID ID cannot be empty")
+	}
+	if msg.PublicKey == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Public Key cannot be empty")
+	}
+	if msg.Signer == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Signer cannot be empty")
+	}
+	for _, op := range msg.Operations {
+		if !isAuthzOperation(op) {
+			return sdkerrors.ErrUnknownRequest.Wrapf("Unknown operation %q", op)
+		}
+	}
+	return validateDocumentContent(msg.Document())
+}
+
+// Document returns the DID document the update would store.
+func (msg MsgUpdateDID) Document() DIDDocument {
+	return DIDDocument{
+		ID:                  msg.ID,
+		PublicKey:           msg.PublicKey,
+		ServiceEndpoints:    msg.ServiceEndpoints,
+		Authentication:      msg.Authentication,
+		VerificationMethods: msg.VerificationMethods,
+		KeyAgreement:        msg.KeyAgreement,
+		Services:            msg.Services,
+		Controller:          msg.Controller,
+		ControllerThreshold: msg.ControllerThreshold,
+		RotationDelay:       msg.RotationDelay,
+		ExpiresAt:           msg.ExpiresAt,
+		Context:             msg.Context,
+		ContentCID:          msg.ContentCID,
+	}
+}
+
+// ProofSignBytes returns the bytes a controller DID signs to authorize the
+// update: the sorted JSON encoding of the message without its signature.
+func (msg MsgUpdateDID) ProofSignBytes() []byte {
+	msg.Signature = nil
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// Route returns the message route.
+func (msg MsgUpdateDID) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgUpdateDID) Type() string { return "update_did" }
+
+// GetSignBytes returns the canonical bytes to sign over, summarized for
+// hardware wallets like those of MsgCreateDID.
+func (msg MsgUpdateDID) GetSignBytes() []byte {
+	sd := newDocumentSignDoc(msg.Document(), msg.Creator, sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg)))
+	sd.Nonce = strconv.FormatUint(msg.Nonce, 10)
+	sd.Signer = msg.Signer
+	return documentSignBytes("did/UpdateDID", sd)
+}
+
+// GetSigners returns the account that must sign the message.
+func (msg MsgUpdateDID) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
+
+// MsgDeactivateDID permanently deactivates a DID document. Authorization works
+// as for MsgUpdateDID.
+type MsgDeactivateDID struct {
+	ID        string         `protobuf:"bytes,1,opt,name=id,proto3" json:"id"`
+	Nonce     uint64         `protobuf:"varint,5,opt,name=nonce,proto3" json:"nonce"`
+	Signer    string         `protobuf:"bytes,2,opt,name=signer,proto3" json:"signer"`
+	Signature []byte         `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	Creator   sdk.AccAddress `protobuf:"bytes,4,opt,name=creator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"creator"`
+}
+
+// ValidateBasic performs basic validation of MsgDeactivateDID.
+func (msg MsgDeactivateDID) ValidateBasic() error {
+	if err := validateChecksums(msg); err != nil {
+		return err
+	}
+	if msg.ID == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("DID ID cannot be empty")
+	}
+	if msg.Signer == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Signer cannot be empty")
+	}
+	return nil
+}
+
+// ProofSignBytes returns the bytes a controller DID signs to authorize the
+// deactivation.
+func (msg MsgDeactivateDID) ProofSignBytes() []byte {
+	msg.Signature = nil
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// Route returns the message route.
+func (msg MsgDeactivateDID) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgDeactivateDID) Type() string { return "deactivate_did" }
+
+// GetSignBytes returns the canonical bytes to sign over.
+func (msg MsgDeactivateDID) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners returns the account that must sign the message.
+func (msg MsgDeactivateDID) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
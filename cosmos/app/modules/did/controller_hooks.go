@@ -0,0 +1,49 @@
+package did
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ControllerHooks lets other modules react when the controllers of a DID or
+// its ControllerThreshold change, the way x/group reports changes to a
+// group's members or decision policy. A module that keeps a DID's
+// controllers in sync with its own membership, or acts on the DID's behalf,
+// registers them with AddControllerHooks.
+type ControllerHooks interface {
+	AfterControllersChanged(ctx sdk.Context, previous, current DIDDocument)
+}
+
+// AddControllerHooks registers hooks called after a DID's controllers or
+// threshold change. It must be called while the app is wired, before any
+// block is processed; copies of the keeper share the registration.
+func (k Keeper) AddControllerHooks(h ControllerHooks) {
+	*k.controllerHooks = append(*k.controllerHooks, h)
+}
+
+// afterControllersChanged aborts the pending changes to a DID whose
+// controllers or threshold differ between previous and current, then calls
+// the registered hooks.
+func (k Keeper) afterControllersChanged(ctx sdk.Context, previous, current DIDDocument) {
+	if previous.ControllerThreshold == current.ControllerThreshold && sameControllers(previous, current) {
+		return
+	}
+	k.abortPendingDIDChanges(ctx, current.ID)
+	for _, h := range *k.controllerHooks {
+		h.AfterControllersChanged(ctx, previous, current)
+	}
+}
+
+// sameControllers reports whether two versions of a DID have the same
+// controllers, in any order.
+func sameControllers(a, b DIDDocument) bool {
+	ac, bc := a.Controllers(), b.Controllers()
+	if len(ac) != len(bc) {
+		return false
+	}
+	for _, c := range ac {
+		if !containsString(bc, c) {
+			return false
+		}
+	}
+	return true
+}
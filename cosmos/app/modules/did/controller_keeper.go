@@ -0,0 +1,145 @@
+package did
+
+import (
+	"crypto/ed25519"
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// AuthorizeController checks that signer is a controller of the DID and that
+// the transaction proves control of it: an address controller must be the
+// transaction creator, a DID controller must have signed signBytes with its
+// registered Ed25519 authentication key. nonce must equal the document's
+// current nonce. DIDs with a ControllerThreshold above one cannot be changed by
+// a single controller and must go through MsgProposeDIDChange instead.
+func (k Keeper) AuthorizeController(ctx sdk.Context, id string, nonce uint64, signer string, creator sdk.AccAddress, signBytes, signature []byte) error {
+	did, err := k.GetDID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := checkNonce(did, nonce); err != nil {
+		return err
+	}
+	if did.ControllerThreshold > 1 {
+		return fmt.Errorf("DID %s requires %d controller approvals", id, did.ControllerThreshold)
+	}
+	return k.verifyControllerSignature(ctx, did, signer, creator, signBytes, signature)
+}
+
+// verifyControllerSignature checks a single controller's proof of control
+// over an active DID.
+func (k Keeper) verifyControllerSignature(ctx sdk.Context, did DIDDocument, signer string, creator sdk.AccAddress, signBytes, signature []byte) error {
+	if did.Deactivated {
+		return fmt.Errorf("DID %s is deactivated", did.ID)
+	}
+	if !isController(did, signer) {
+		return fmt.Errorf("%s is not a controller of %s", signer, did.ID)
+	}
+
+	if addr, err := sdk.AccAddressFromBech32(signer); err == nil {
+		if !addr.Equals(creator) {
+			return fmt.Errorf("controller address %s did not sign the transaction", signer)
+		}
+		return nil
+	}
+
+	return k.verifyDIDSignature(ctx, signer, signBytes, signature)
+}
+
+// verifyDIDSignature checks that signature is a valid signature over
+// signBytes by the registered Ed25519 authentication key of an active DID,
+// or a MethodSignature over them by one of its passkeys or threshold keys.
+func (k Keeper) verifyDIDSignature(ctx sdk.Context, id string, signBytes, signature []byte) error {
+	did, err := k.GetDID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("DID %s not found", id)
+	}
+	if did.Deactivated {
+		return fmt.Errorf("DID %s is deactivated", id)
+	}
+	if ms, ok := parseMethodSignature(signature); ok {
+		vm, err := signingMethod(did, ms.VerificationMethod)
+		if err != nil {
+			return err
+		}
+		return verifyMethodSignature(vm, signBytes, signature)
+	}
+	pub, err := did.Ed25519PublicKey()
+	if err != nil {
+		return fmt.Errorf("DID %s: %w", id, err)
+	}
+	if !ed25519.Verify(pub, signBytes, signature) {
+		return fmt.Errorf("invalid signature from %s", id)
+	}
+	return nil
+}
+
+// CheckControllers rejects documents naming a did:aytch controller that isn't
+// registered. Address controllers and DIDs of other methods aren't checked.
+// DIDs are never deleted, so a reference that resolves once always will.
+func (k Keeper) CheckControllers(ctx sdk.Context, did DIDDocument) error {
+	store := ctx.KVStore(k.storeKey)
+	for _, c := range did.Controller {
+		if c != did.ID && strings.HasPrefix(c, DIDMethodPrefix) && !store.Has(didKey(c)) {
+			return fmt.Errorf("controller %s of %s not found", c, did.ID)
+		}
+	}
+	return nil
+}
+
+func isController(did DIDDocument, signer string) bool {
+	for _, c := range did.Controllers() {
+		if c == signer {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateDID replaces an existing, active DID document.
+func (k Keeper) UpdateDID(ctx sdk.Context, did DIDDocument) error {
+	existing, err := k.GetDID(ctx, did.ID)
+	if err != nil {
+		return err
+	}
+	if existing.Deactivated {
+		return fmt.Errorf("DID %s is deactivated", did.ID)
+	}
+	if err := k.CheckExpiry(ctx, did); err != nil {
+		return err
+	}
+	return k.setDID(ctx, did)
+}
+
+// DeactivateDID marks a DID document as deactivated. Deactivation is
+// permanent: the document still resolves but can no longer be updated.
+func (k Keeper) DeactivateDID(ctx sdk.Context, id string) error {
+	did, err := k.GetDID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if did.Deactivated {
+		return fmt.Errorf("DID %s is already deactivated", id)
+	}
+	did.Deactivated = true
+	return k.setDID(ctx, did)
+}
+
+func checkNonce(did DIDDocument, nonce uint64) error {
+	if nonce != did.Nonce {
+		return fmt.Errorf("invalid nonce for %s: expected %d, got %d", did.ID, did.Nonce, nonce)
+	}
+	return nil
+}
+
+// IncrementNonce advances a DID's nonce after an authorized operation that
+// doesn't otherwise write the document.
+func (k Keeper) IncrementNonce(ctx sdk.Context, id string) error {
+	did, err := k.GetDID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return k.setDID(ctx, did)
+}
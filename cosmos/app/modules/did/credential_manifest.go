@@ -0,0 +1,78 @@
+package did
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// CredentialManifestResourceType is the resource type under which issuers
+// publish DIF Credential Manifests, describing the credentials they issue and
+// the inputs they require from applicants.
+const CredentialManifestResourceType = "CredentialManifest"
+
+// CredentialManifest is a DIF Credential Manifest. The format and
+// presentation_definition objects are kept as raw JSON; wallets decode the
+// presentation definition to find the credentials an application needs.
+type CredentialManifest struct {
+	ID                     string             `json:"id"`
+	SpecVersion            string             `json:"spec_version,omitempty"`
+	Issuer                 ManifestIssuer     `json:"issuer"`
+	OutputDescriptors      []OutputDescriptor `json:"output_descriptors"`
+	Format                 json.RawMessage    `json:"format,omitempty"`
+	PresentationDefinition json.RawMessage    `json:"presentation_definition,omitempty"`
+}
+
+// ManifestIssuer identifies the issuer of a manifest's credentials.
+type ManifestIssuer struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+// OutputDescriptor describes one credential issued against a manifest.
+type OutputDescriptor struct {
+	ID          string `json:"id"`
+	Schema      string `json:"schema"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// ParseCredentialManifest decodes and validates a manifest published by
+// issuer.
+func ParseCredentialManifest(data []byte, issuer string) (CredentialManifest, error) {
+	var m CredentialManifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return CredentialManifest{}, fmt.Errorf("invalid credential manifest: %w", err)
+	}
+	if m.ID == "" {
+		return CredentialManifest{}, fmt.Errorf("credential manifest has no id")
+	}
+	if m.Issuer.ID != issuer {
+		return CredentialManifest{}, fmt.Errorf("credential manifest issuer %s is not %s", m.Issuer.ID, issuer)
+	}
+	if len(m.OutputDescriptors) == 0 {
+		return CredentialManifest{}, fmt.Errorf("credential manifest has no output descriptors")
+	}
+	seen := make(map[string]bool, len(m.OutputDescriptors))
+	for _, od := range m.OutputDescriptors {
+		if od.ID == "" || od.Schema == "" {
+			return CredentialManifest{}, fmt.Errorf("output descriptors need an id and a schema")
+		}
+		if seen[od.ID] {
+			return CredentialManifest{}, fmt.Errorf("duplicate output descriptor %s", od.ID)
+		}
+		seen[od.ID] = true
+	}
+	if len(m.PresentationDefinition) > 0 {
+		var pd struct {
+			ID               string            `json:"id"`
+			InputDescriptors []json.RawMessage `json:"input_descriptors"`
+		}
+		if err := json.Unmarshal(m.PresentationDefinition, &pd); err != nil {
+			return CredentialManifest{}, fmt.Errorf("invalid presentation definition: %w", err)
+		}
+		if pd.ID == "" || len(pd.InputDescriptors) == 0 {
+			return CredentialManifest{}, fmt.Errorf("presentation definition needs an id and input descriptors")
+		}
+	}
+	return m, nil
+}
@@ -0,0 +1,89 @@
+package did
+
+import (
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// CredentialRoot is the Merkle root of a batch of credentials an issuer DID
+// anchored in one transaction instead of registering each credential. The
+// tree is the RFC 6962 tree Tendermint builds with crypto/merkle over the
+// 32-byte credential hashes in batch order, and Count is the number of
+// credentials in it.
+type CredentialRoot struct {
+	Issuer string `protobuf:"bytes,1,opt,name=issuer,proto3" json:"issuer"`
+	Root   []byte `protobuf:"bytes,2,opt,name=root,proto3" json:"root"`
+	Count  uint64 `protobuf:"varint,3,opt,name=count,proto3" json:"count"`
+	Height int64  `protobuf:"varint,4,opt,name=height,proto3" json:"height"`
+	Time   int64  `protobuf:"varint,5,opt,name=time,proto3" json:"time"`
+}
+
+// CredentialInclusionProof is the Merkle path of a credential hash in an
+// anchored batch: the hash's position in the batch and the sibling hashes
+// from the leaf up to the root.
+type CredentialInclusionProof struct {
+	Index uint64   `protobuf:"varint,1,opt,name=index,proto3" json:"index"`
+	Aunts [][]byte `protobuf:"bytes,2,rep,name=aunts,proto3" json:"aunts"`
+}
+
+// MsgAnchorCredentialRoot anchors the Merkle root of a batch of credentials
+// issued by Issuer. A controller of Issuer authorizes it like a
+// MsgUpdateDID.
+type MsgAnchorCredentialRoot struct {
+	Issuer    string         `protobuf:"bytes,1,opt,name=issuer,proto3" json:"issuer"`
+	Root      []byte         `protobuf:"bytes,2,opt,name=root,proto3" json:"root"`
+	Count     uint64         `protobuf:"varint,3,opt,name=count,proto3" json:"count"`
+	Nonce     uint64         `protobuf:"varint,4,opt,name=nonce,proto3" json:"nonce"`
+	Signer    string         `protobuf:"bytes,5,opt,name=signer,proto3" json:"signer"`
+	Signature []byte         `protobuf:"bytes,6,opt,name=signature,proto3" json:"signature,omitempty"`
+	Creator   sdk.AccAddress `protobuf:"bytes,7,opt,name=creator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"creator"`
+}
+
+// ValidateBasic performs basic validation of MsgAnchorCredentialRoot.
+func (msg MsgAnchorCredentialRoot) ValidateBasic() error {
+	if err := validateChecksums(msg); err != nil {
+		return err
+	}
+	if msg.Issuer == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Issuer cannot be empty")
+	}
+	if len(msg.Root) != HashAnchorLength {
+		return sdkerrors.ErrUnknownRequest.Wrapf("Root must be %d bytes", HashAnchorLength)
+	}
+	if msg.Count == 0 {
+		return sdkerrors.ErrUnknownRequest.Wrap("Count must be positive")
+	}
+	if msg.Signer == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Signer cannot be empty")
+	}
+	return nil
+}
+
+// ProofSignBytes returns the bytes a controller DID signs to authorize the
+// anchor.
+func (msg MsgAnchorCredentialRoot) ProofSignBytes() []byte {
+	msg.Signature = nil
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// Route returns the message route.
+func (msg MsgAnchorCredentialRoot) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgAnchorCredentialRoot) Type() string { return "anchor_credential_root" }
+
+// GetSignBytes returns the canonical bytes to sign over.
+func (msg MsgAnchorCredentialRoot) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners returns the account that must sign the message.
+func (msg MsgAnchorCredentialRoot) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
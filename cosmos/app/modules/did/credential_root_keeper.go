@@ -0,0 +1,93 @@
+package did
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+)
+
+// AnchorCredentialRoot records the Merkle root of a batch of credentials
+// issued by an active issuer DID. An issuer can anchor a root only once.
+// Callers must have authorized the anchor.
+func (k Keeper) AnchorCredentialRoot(ctx sdk.Context, root CredentialRoot) error {
+	issuer, err := k.GetDID(ctx, root.Issuer)
+	if err != nil {
+		return err
+	}
+	if issuer.Deactivated {
+		return fmt.Errorf("DID %s is deactivated", root.Issuer)
+	}
+	if len(root.Root) != HashAnchorLength || root.Count == 0 {
+		return fmt.Errorf("credential root must be %d bytes and cover at least one credential", HashAnchorLength)
+	}
+	if _, found := k.GetCredentialRoot(ctx, root.Issuer, root.Root); found {
+		return fmt.Errorf("%s has already anchored credential root %X", root.Issuer, root.Root)
+	}
+	root.Height = ctx.BlockHeight()
+	root.Time = ctx.BlockTime().Unix()
+	k.setCredentialRoot(ctx, root)
+	ctx.EventManager().EmitEvent(sdk.NewEvent("credential_root_anchored",
+		sdk.NewAttribute("issuer", root.Issuer),
+		sdk.NewAttribute("root", hex.EncodeToString(root.Root)),
+		sdk.NewAttribute("count", strconv.FormatUint(root.Count, 10)),
+	))
+	return nil
+}
+
+// VerifyCredentialInclusion reports whether proof places credentialHash in
+// the batch under a root the issuer anchored. It fails if the root was not
+// anchored or the proof is malformed.
+func (k Keeper) VerifyCredentialInclusion(ctx sdk.Context, issuer string, root, credentialHash []byte, proof CredentialInclusionProof) (bool, error) {
+	anchored, found := k.GetCredentialRoot(ctx, issuer, root)
+	if !found {
+		return false, fmt.Errorf("%s has not anchored credential root %X", issuer, root)
+	}
+	if len(credentialHash) != HashAnchorLength {
+		return false, fmt.Errorf("credential hash must be %d bytes", HashAnchorLength)
+	}
+	if proof.Index >= anchored.Count {
+		return false, fmt.Errorf("index %d is outside the batch of %d credentials", proof.Index, anchored.Count)
+	}
+	// The leaf hash is RFC 6962's, which crypto/merkle does not export.
+	p := merkle.Proof{
+		Total:    int64(anchored.Count),
+		Index:    int64(proof.Index),
+		LeafHash: tmhash.Sum(append([]byte{0}, credentialHash...)),
+		Aunts:    proof.Aunts,
+	}
+	if err := p.ValidateBasic(); err != nil {
+		return false, err
+	}
+	return p.Verify(anchored.Root, credentialHash) == nil, nil
+}
+
+// GetCredentialRoot returns a credential root an issuer anchored.
+func (k Keeper) GetCredentialRoot(ctx sdk.Context, issuer string, root []byte) (CredentialRoot, bool) {
+	value := ctx.KVStore(k.storeKey).Get(credentialRootKey(issuer, root))
+	if value == nil {
+		return CredentialRoot{}, false
+	}
+	var record CredentialRoot
+	k.cdc.MustUnmarshal(value, &record)
+	return record, true
+}
+
+// GetAllCredentialRoots returns every credential root in the store.
+func (k Keeper) GetAllCredentialRoots(ctx sdk.Context) []CredentialRoot {
+	var roots []CredentialRoot
+	iteratePrefix(ctx.KVStore(k.storeKey), CredentialRootPrefix, func(_, value []byte) bool {
+		var root CredentialRoot
+		k.cdc.MustUnmarshal(value, &root)
+		roots = append(roots, root)
+		return false
+	})
+	return roots
+}
+
+func (k Keeper) setCredentialRoot(ctx sdk.Context, root CredentialRoot) {
+	ctx.KVStore(k.storeKey).Set(credentialRootKey(root.Issuer, root.Root), k.cdc.MustMarshal(&root))
+}
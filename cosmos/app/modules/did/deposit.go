@@ -0,0 +1,77 @@
+package did
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+)
+
+// ProposalTypeSlashDeposit is the governance proposal type that slashes the
+// registration deposit of an abusive DID.
+const ProposalTypeSlashDeposit = "SlashDIDDeposit"
+
+func init() {
+	govtypes.RegisterProposalType(ProposalTypeSlashDeposit)
+	govtypes.RegisterProposalTypeCodec(&SlashDepositProposal{}, "did/SlashDepositProposal")
+}
+
+// DIDDeposit is the RegistrationDeposit locked from Depositor when the DID was
+// registered. It is refunded at MaturesHeight unless a SlashDepositProposal
+// burns it first. Each failed refund moves MaturesHeight to the next attempt
+// and counts in RefundAttempts.
+type DIDDeposit struct {
+	DID            string         `protobuf:"bytes,1,opt,name=did,proto3" json:"did"`
+	Depositor      sdk.AccAddress `protobuf:"bytes,2,opt,name=depositor,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"depositor"`
+	Amount         sdk.Coins      `protobuf:"bytes,3,rep,name=amount,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"amount"`
+	MaturesHeight  int64          `protobuf:"varint,4,opt,name=matures_height,proto3" json:"matures_height"`
+	RefundAttempts uint32         `protobuf:"varint,5,opt,name=refund_attempts,proto3" json:"refund_attempts,omitempty"`
+}
+
+// FeeEscrow is the DeactivationRefund part of a DID's CreateDIDFee, held in
+// the module account instead of being collected. It is refunded to Payer when
+// the DID's controllers deactivate it, and burned if the DID is deactivated
+// by expiry or governance instead.
+type FeeEscrow struct {
+	DID    string         `protobuf:"bytes,1,opt,name=did,proto3" json:"did"`
+	Payer  sdk.AccAddress `protobuf:"bytes,2,opt,name=payer,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"payer"`
+	Amount sdk.Coins      `protobuf:"bytes,3,rep,name=amount,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"amount"`
+}
+
+// SlashDepositProposal asks governance to rule a DID's registration abusive,
+// e.g. impersonation or squatting, and burn its deposit. The DID itself is
+// left in place.
+type SlashDepositProposal struct {
+	Title       string `protobuf:"bytes,1,opt,name=title,proto3" json:"title"`
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description"`
+	DID         string `protobuf:"bytes,3,opt,name=did,proto3" json:"did"`
+	Reason      string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason"`
+}
+
+var _ govtypes.Content = &SlashDepositProposal{}
+
+// GetTitle returns the title of the proposal.
+func (p *SlashDepositProposal) GetTitle() string { return p.Title }
+
+// GetDescription returns the description of the proposal.
+func (p *SlashDepositProposal) GetDescription() string { return p.Description }
+
+// ProposalRoute returns the router key of the proposal's handler.
+func (p *SlashDepositProposal) ProposalRoute() string { return RouterKey }
+
+// ProposalType returns the type of the proposal.
+func (p *SlashDepositProposal) ProposalType() string { return ProposalTypeSlashDeposit }
+
+// ValidateBasic performs basic validation of SlashDepositProposal.
+func (p *SlashDepositProposal) ValidateBasic() error {
+	if err := govtypes.ValidateAbstract(p); err != nil {
+		return err
+	}
+	if p.DID == "" {
+		return fmt.Errorf("DID cannot be empty")
+	}
+	if p.Reason == "" {
+		return fmt.Errorf("reason cannot be empty")
+	}
+	return nil
+}
@@ -0,0 +1,239 @@
+package did
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+)
+
+// LockDeposit moves the RegistrationDeposit of a new DID from depositor into
+// the module account until it matures. It does nothing while the deposit is
+// zero.
+func (k Keeper) LockDeposit(ctx sdk.Context, id string, depositor sdk.AccAddress) error {
+	params := k.GetParams(ctx)
+	if params.RegistrationDeposit.IsZero() {
+		return nil
+	}
+	if err := k.bankKeeper.SendCoinsFromAccountToModule(ctx, depositor, ModuleName, params.RegistrationDeposit); err != nil {
+		return err
+	}
+	deposit := DIDDeposit{
+		DID:           id,
+		Depositor:     depositor,
+		Amount:        params.RegistrationDeposit,
+		MaturesHeight: ctx.BlockHeight() + int64(params.DepositMaturityBlocks),
+	}
+	k.setDeposit(ctx, deposit)
+	ctx.EventManager().EmitEvent(sdk.NewEvent("deposit_locked",
+		sdk.NewAttribute("did", id),
+		sdk.NewAttribute("depositor", depositor.String()),
+		sdk.NewAttribute("amount", deposit.Amount.String()),
+		sdk.NewAttribute("matures_height", fmt.Sprintf("%d", deposit.MaturesHeight)),
+	))
+	return nil
+}
+
+// MaxDepositRefundAttempts is the number of times a deposit's refund is
+// tried, each attempt twice as many blocks after the previous one, before the
+// deposit is given to the community pool, from which governance can return
+// it to the depositor.
+const MaxDepositRefundAttempts = 8
+
+// RefundMatureDeposits returns the deposits that matured by the current block
+// to their depositors. A deposit that can't be sent is retried later, up to
+// MaxDepositRefundAttempts times.
+func (k Keeper) RefundMatureDeposits(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	for _, e := range dueEntries(store, DepositQueuePrefix, ctx.BlockHeight()) {
+		deposit, found := k.GetDeposit(ctx, string(e.suffix))
+		if !found {
+			continue
+		}
+		// A failed multi-denom send can leave some coins moved.
+		sendCtx, write := ctx.CacheContext()
+		if err := k.bankKeeper.SendCoinsFromModuleToAccount(sendCtx, ModuleName, deposit.Depositor, deposit.Amount); err != nil {
+			k.refundFailed(ctx, deposit, err)
+			continue
+		}
+		write()
+		ctx.EventManager().EmitEvents(sendCtx.EventManager().Events())
+		k.deleteDeposit(ctx, deposit)
+		ctx.EventManager().EmitEvent(sdk.NewEvent("deposit_refunded",
+			sdk.NewAttribute("did", deposit.DID),
+			sdk.NewAttribute("depositor", deposit.Depositor.String()),
+			sdk.NewAttribute("amount", deposit.Amount.String()),
+		))
+	}
+}
+
+// refundFailed requeues a deposit whose refund failed with err for its next
+// attempt, or gives it to the community pool after the last attempt. If even
+// that fails, the deposit is kept out of the queue, where governance can
+// still slash it.
+func (k Keeper) refundFailed(ctx sdk.Context, deposit DIDDeposit, err error) {
+	k.deleteDeposit(ctx, deposit)
+	deposit.RefundAttempts++
+	ctx.Logger().Error("failed to refund DID deposit", "did", deposit.DID, "depositor", deposit.Depositor.String(), "attempt", deposit.RefundAttempts, "err", err)
+	ctx.EventManager().EmitEvent(sdk.NewEvent("deposit_refund_failed",
+		sdk.NewAttribute("did", deposit.DID),
+		sdk.NewAttribute("depositor", deposit.Depositor.String()),
+		sdk.NewAttribute("amount", deposit.Amount.String()),
+		sdk.NewAttribute("attempt", fmt.Sprintf("%d", deposit.RefundAttempts)),
+		sdk.NewAttribute("error", err.Error()),
+	))
+	if deposit.RefundAttempts < MaxDepositRefundAttempts {
+		deposit.MaturesHeight = ctx.BlockHeight() + int64(1)<<(deposit.RefundAttempts-1)
+		k.setDeposit(ctx, deposit)
+		return
+	}
+
+	poolCtx, write := ctx.CacheContext()
+	if err := k.distrKeeper.FundCommunityPool(poolCtx, deposit.Amount, authtypes.NewModuleAddress(ModuleName)); err != nil {
+		ctx.Logger().Error("failed to give unrefundable DID deposit to the community pool", "did", deposit.DID, "err", err)
+		ctx.KVStore(k.storeKey).Set(depositKey(deposit.DID), k.cdc.MustMarshal(&deposit))
+		return
+	}
+	write()
+	ctx.EventManager().EmitEvent(sdk.NewEvent("deposit_refund_abandoned",
+		sdk.NewAttribute("did", deposit.DID),
+		sdk.NewAttribute("depositor", deposit.Depositor.String()),
+		sdk.NewAttribute("amount", deposit.Amount.String()),
+	))
+}
+
+// SlashDeposit burns the deposit of a DID ruled abusive by governance.
+func (k Keeper) SlashDeposit(ctx sdk.Context, id, reason string) error {
+	deposit, found := k.GetDeposit(ctx, id)
+	if !found {
+		return fmt.Errorf("DID %s has no deposit to slash", id)
+	}
+	if err := k.bankKeeper.BurnCoins(ctx, ModuleName, deposit.Amount); err != nil {
+		return err
+	}
+	k.deleteDeposit(ctx, deposit)
+	ctx.EventManager().EmitEvent(sdk.NewEvent("deposit_slashed",
+		sdk.NewAttribute("did", id),
+		sdk.NewAttribute("depositor", deposit.Depositor.String()),
+		sdk.NewAttribute("amount", deposit.Amount.String()),
+		sdk.NewAttribute("reason", reason),
+	))
+	return nil
+}
+
+// GetDeposit returns the locked registration deposit of a DID.
+func (k Keeper) GetDeposit(ctx sdk.Context, id string) (DIDDeposit, bool) {
+	value := ctx.KVStore(k.storeKey).Get(depositKey(id))
+	if value == nil {
+		return DIDDeposit{}, false
+	}
+	var deposit DIDDeposit
+	k.cdc.MustUnmarshal(value, &deposit)
+	return deposit, true
+}
+
+// GetAllDeposits returns every locked registration deposit in the store.
+func (k Keeper) GetAllDeposits(ctx sdk.Context) []DIDDeposit {
+	var deposits []DIDDeposit
+	iteratePrefix(ctx.KVStore(k.storeKey), DepositPrefix, func(_, value []byte) bool {
+		var deposit DIDDeposit
+		k.cdc.MustUnmarshal(value, &deposit)
+		deposits = append(deposits, deposit)
+		return false
+	})
+	return deposits
+}
+
+func (k Keeper) setDeposit(ctx sdk.Context, deposit DIDDeposit) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(depositKey(deposit.DID), k.cdc.MustMarshal(&deposit))
+	store.Set(depositQueueKey(deposit.MaturesHeight, deposit.DID), []byte{})
+}
+
+func (k Keeper) deleteDeposit(ctx sdk.Context, deposit DIDDeposit) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(depositKey(deposit.DID))
+	store.Delete(depositQueueKey(deposit.MaturesHeight, deposit.DID))
+}
+
+// escrowFee records the part of a new DID's CreateDIDFee already moved into
+// the module account.
+func (k Keeper) escrowFee(ctx sdk.Context, escrow FeeEscrow) {
+	k.setFeeEscrow(ctx, escrow)
+	ctx.EventManager().EmitEvent(sdk.NewEvent("fee_escrowed",
+		sdk.NewAttribute("did", escrow.DID),
+		sdk.NewAttribute("payer", escrow.Payer.String()),
+		sdk.NewAttribute("amount", escrow.Amount.String()),
+	))
+}
+
+// RefundFeeEscrow returns the escrowed fee of a DID its controllers
+// deactivated to the account that paid it. It does nothing for DIDs without
+// an escrow.
+func (k Keeper) RefundFeeEscrow(ctx sdk.Context, id string) error {
+	escrow, found := k.GetFeeEscrow(ctx, id)
+	if !found {
+		return nil
+	}
+	if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, ModuleName, escrow.Payer, escrow.Amount); err != nil {
+		return err
+	}
+	k.deleteFeeEscrow(ctx, id)
+	ctx.EventManager().EmitEvent(sdk.NewEvent("fee_escrow_refunded",
+		sdk.NewAttribute("did", id),
+		sdk.NewAttribute("payer", escrow.Payer.String()),
+		sdk.NewAttribute("amount", escrow.Amount.String()),
+	))
+	return nil
+}
+
+// ForfeitFeeEscrow burns the escrowed fee of a DID deactivated other than by
+// its controllers, by expiry or by governance.
+func (k Keeper) ForfeitFeeEscrow(ctx sdk.Context, id, reason string) error {
+	escrow, found := k.GetFeeEscrow(ctx, id)
+	if !found {
+		return nil
+	}
+	if err := k.bankKeeper.BurnCoins(ctx, ModuleName, escrow.Amount); err != nil {
+		return err
+	}
+	k.deleteFeeEscrow(ctx, id)
+	ctx.EventManager().EmitEvent(sdk.NewEvent("fee_escrow_forfeited",
+		sdk.NewAttribute("did", id),
+		sdk.NewAttribute("payer", escrow.Payer.String()),
+		sdk.NewAttribute("amount", escrow.Amount.String()),
+		sdk.NewAttribute("reason", reason),
+	))
+	return nil
+}
+
+// GetFeeEscrow returns the escrowed creation fee of a DID.
+func (k Keeper) GetFeeEscrow(ctx sdk.Context, id string) (FeeEscrow, bool) {
+	value := ctx.KVStore(k.storeKey).Get(feeEscrowKey(id))
+	if value == nil {
+		return FeeEscrow{}, false
+	}
+	var escrow FeeEscrow
+	k.cdc.MustUnmarshal(value, &escrow)
+	return escrow, true
+}
+
+// GetAllFeeEscrows returns every escrowed creation fee in the store.
+func (k Keeper) GetAllFeeEscrows(ctx sdk.Context) []FeeEscrow {
+	var escrows []FeeEscrow
+	iteratePrefix(ctx.KVStore(k.storeKey), FeeEscrowPrefix, func(_, value []byte) bool {
+		var escrow FeeEscrow
+		k.cdc.MustUnmarshal(value, &escrow)
+		escrows = append(escrows, escrow)
+		return false
+	})
+	return escrows
+}
+
+func (k Keeper) setFeeEscrow(ctx sdk.Context, escrow FeeEscrow) {
+	ctx.KVStore(k.storeKey).Set(feeEscrowKey(escrow.DID), k.cdc.MustMarshal(&escrow))
+}
+
+func (k Keeper) deleteFeeEscrow(ctx sdk.Context, id string) {
+	ctx.KVStore(k.storeKey).Delete(feeEscrowKey(id))
+}
@@ -0,0 +1,143 @@
+package did
+
+import (
+	"errors"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+)
+
+// depositKeeper returns a keeper with a RegistrationDeposit of 50uaytch
+// maturing after 10 blocks, and a depositor holding 100uaytch.
+func depositKeeper(t *testing.T) (Keeper, sdk.Context, *mockBankKeeper, *mockDistrKeeper, sdk.AccAddress) {
+	t.Helper()
+	k, ctx, bank, distr := testKeeper(t)
+	params := k.GetParams(ctx)
+	params.RegistrationDeposit = sdk.NewCoins(sdk.NewInt64Coin("uaytch", 50))
+	params.DepositMaturityBlocks = 10
+	k.SetParams(ctx, params)
+	depositor := testAddr("depositor")
+	bank.balances[depositor.String()] = sdk.NewCoins(sdk.NewInt64Coin("uaytch", 100))
+	return k, ctx, bank, distr, depositor
+}
+
+func TestRefundMatureDeposits(t *testing.T) {
+	k, ctx, bank, _, depositor := depositKeeper(t)
+	if err := k.LockDeposit(ctx, "did:aytch:one", depositor); err != nil {
+		t.Fatal(err)
+	}
+	if got := bank.balance(depositor, "uaytch"); got != 50 {
+		t.Fatalf("depositor balance after locking = %d, want 50", got)
+	}
+
+	k.RefundMatureDeposits(ctx.WithBlockHeight(10))
+	if _, found := k.GetDeposit(ctx, "did:aytch:one"); !found {
+		t.Fatal("deposit refunded before maturing")
+	}
+
+	ctx = ctx.WithBlockHeight(11)
+	k.RefundMatureDeposits(ctx)
+	if _, found := k.GetDeposit(ctx, "did:aytch:one"); found {
+		t.Error("matured deposit still stored")
+	}
+	if got := bank.balance(depositor, "uaytch"); got != 100 {
+		t.Errorf("depositor balance after refund = %d, want 100", got)
+	}
+	if !hasEvent(ctx, "deposit_refunded") {
+		t.Error("no deposit_refunded event")
+	}
+}
+
+func TestRefundMatureDepositsBacksOff(t *testing.T) {
+	k, ctx, bank, distr, depositor := depositKeeper(t)
+	if err := k.LockDeposit(ctx, "did:aytch:one", depositor); err != nil {
+		t.Fatal(err)
+	}
+	bank.refundErr = errors.New("blocked address")
+
+	height := int64(11)
+	for attempt := uint32(1); attempt < MaxDepositRefundAttempts; attempt++ {
+		k.RefundMatureDeposits(ctx.WithBlockHeight(height))
+		deposit, found := k.GetDeposit(ctx, "did:aytch:one")
+		if !found {
+			t.Fatalf("deposit dropped after attempt %d", attempt)
+		}
+		if deposit.RefundAttempts != attempt {
+			t.Fatalf("RefundAttempts = %d, want %d", deposit.RefundAttempts, attempt)
+		}
+		next := height + int64(1)<<(attempt-1)
+		if deposit.MaturesHeight != next {
+			t.Fatalf("attempt %d requeued at %d, want %d", attempt, deposit.MaturesHeight, next)
+		}
+		// Nothing happens before the next attempt is due.
+		k.RefundMatureDeposits(ctx.WithBlockHeight(next - 1))
+		if d, _ := k.GetDeposit(ctx, "did:aytch:one"); d.RefundAttempts != attempt {
+			t.Fatalf("attempt %d retried early", attempt)
+		}
+		height = next
+	}
+
+	ctx = ctx.WithBlockHeight(height)
+	k.RefundMatureDeposits(ctx)
+	if _, found := k.GetDeposit(ctx, "did:aytch:one"); found {
+		t.Error("deposit still stored after the last attempt")
+	}
+	if got := distr.communityPool.AmountOf("uaytch").Int64(); got != 50 {
+		t.Errorf("community pool = %d, want 50", got)
+	}
+	if got := bank.balance(authtypes.NewModuleAddress(ModuleName), "uaytch"); got != 0 {
+		t.Errorf("module account still holds %d", got)
+	}
+	if !hasEvent(ctx, "deposit_refund_abandoned") {
+		t.Error("no deposit_refund_abandoned event")
+	}
+}
+
+func TestRefundMatureDepositsKeepsUnpayableDeposit(t *testing.T) {
+	k, ctx, bank, distr, depositor := depositKeeper(t)
+	if err := k.LockDeposit(ctx, "did:aytch:one", depositor); err != nil {
+		t.Fatal(err)
+	}
+	bank.refundErr = errors.New("blocked address")
+	distr.err = errors.New("community pool unavailable")
+	k.setDeposit(ctx, DIDDeposit{
+		DID:            "did:aytch:one",
+		Depositor:      depositor,
+		Amount:         sdk.NewCoins(sdk.NewInt64Coin("uaytch", 50)),
+		MaturesHeight:  11,
+		RefundAttempts: MaxDepositRefundAttempts - 1,
+	})
+
+	k.RefundMatureDeposits(ctx.WithBlockHeight(11))
+	deposit, found := k.GetDeposit(ctx, "did:aytch:one")
+	if !found {
+		t.Fatal("deposit lost when the community pool refused it")
+	}
+	if deposit.RefundAttempts != MaxDepositRefundAttempts {
+		t.Errorf("RefundAttempts = %d, want %d", deposit.RefundAttempts, MaxDepositRefundAttempts)
+	}
+	if due := dueEntries(ctx.KVStore(k.storeKey), DepositQueuePrefix, 1<<40); len(due) != 0 {
+		t.Errorf("deposit still queued: %d entries", len(due))
+	}
+}
+
+func TestSlashDeposit(t *testing.T) {
+	k, ctx, bank, _, depositor := depositKeeper(t)
+	if err := k.LockDeposit(ctx, "did:aytch:one", depositor); err != nil {
+		t.Fatal(err)
+	}
+	if err := k.SlashDeposit(ctx, "did:aytch:one", "spam"); err != nil {
+		t.Fatal(err)
+	}
+	if got := bank.burned.AmountOf("uaytch").Int64(); got != 50 {
+		t.Errorf("burned = %d, want 50", got)
+	}
+	k.RefundMatureDeposits(ctx.WithBlockHeight(11))
+	if got := bank.balance(depositor, "uaytch"); got != 50 {
+		t.Errorf("slashed deposit refunded: depositor holds %d", got)
+	}
+	if err := k.SlashDeposit(ctx, "did:aytch:one", "spam"); err == nil {
+		t.Error("deposit slashed twice")
+	}
+}
@@ -0,0 +1,164 @@
+package did
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/client"
+)
+
+// PatchOperation is a JSON Patch (RFC 6902) operation.
+type PatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// VersionDiff is the change between two versions of a DID document, as a
+// JSON Patch that turns the W3C form of the first into the second.
+type VersionDiff struct {
+	DID         string           `json:"did"`
+	FromVersion uint64           `json:"fromVersion"`
+	ToVersion   uint64           `json:"toVersion"`
+	FromHeight  int64            `json:"fromHeight"`
+	ToHeight    int64            `json:"toHeight"`
+	Patch       []PatchOperation `json:"patch"`
+}
+
+// DiffDocuments returns the JSON Patch turning the W3C form of from into the
+// W3C form of to. Arrays are compared element by element, so a changed key
+// shows up as operations on that verification method alone.
+func DiffDocuments(from, to DIDDocument) ([]PatchOperation, error) {
+	a, err := jsonValue(from.W3C(false))
+	if err != nil {
+		return nil, err
+	}
+	b, err := jsonValue(to.W3C(false))
+	if err != nil {
+		return nil, err
+	}
+	patch := []PatchOperation{}
+	diffValues("", a, b, &patch)
+	return patch, nil
+}
+
+// DiffVersions reads two versions of a DID document from past state, see
+// ResolveAt, and returns their diff.
+func DiffVersions(clientCtx client.Context, id string, fromVersion, toVersion uint64) (VersionDiff, error) {
+	fromHeight, err := VersionHeight(clientCtx, id, fromVersion)
+	if err != nil {
+		return VersionDiff{}, err
+	}
+	toHeight, err := VersionHeight(clientCtx, id, toVersion)
+	if err != nil {
+		return VersionDiff{}, err
+	}
+	from, err := ResolveAt(clientCtx, id, fromHeight)
+	if err != nil {
+		return VersionDiff{}, err
+	}
+	to, err := ResolveAt(clientCtx, id, toHeight)
+	if err != nil {
+		return VersionDiff{}, err
+	}
+	// A version written earlier in the same block as another is replaced
+	// by the end of it, so its state can't be read back.
+	if from.Nonce != fromVersion || to.Nonce != toVersion {
+		return VersionDiff{}, fmt.Errorf("version %d or %d of %s was replaced within its block", fromVersion, toVersion, id)
+	}
+	patch, err := DiffDocuments(from, to)
+	if err != nil {
+		return VersionDiff{}, err
+	}
+	return VersionDiff{
+		DID:         id,
+		FromVersion: fromVersion,
+		ToVersion:   toVersion,
+		FromHeight:  fromHeight,
+		ToHeight:    toHeight,
+		Patch:       patch,
+	}, nil
+}
+
+func jsonValue(v interface{}) (interface{}, error) {
+	bz, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out interface{}
+	err = json.Unmarshal(bz, &out)
+	return out, err
+}
+
+func diffValues(path string, a, b interface{}, patch *[]PatchOperation) {
+	if reflect.DeepEqual(a, b) {
+		return
+	}
+	switch a := a.(type) {
+	case map[string]interface{}:
+		if b, ok := b.(map[string]interface{}); ok {
+			diffObjects(path, a, b, patch)
+			return
+		}
+	case []interface{}:
+		if b, ok := b.([]interface{}); ok {
+			diffArrays(path, a, b, patch)
+			return
+		}
+	}
+	*patch = append(*patch, PatchOperation{Op: "replace", Path: path, Value: b})
+}
+
+func diffObjects(path string, a, b map[string]interface{}, patch *[]PatchOperation) {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		p := path + "/" + escapePointer(k)
+		av, inA := a[k]
+		bv, inB := b[k]
+		switch {
+		case !inB:
+			*patch = append(*patch, PatchOperation{Op: "remove", Path: p})
+		case !inA:
+			*patch = append(*patch, PatchOperation{Op: "add", Path: p, Value: bv})
+		default:
+			diffValues(p, av, bv, patch)
+		}
+	}
+}
+
+// diffArrays compares the common prefix element by element, then removes
+// surplus elements from the end, so earlier indexes stay valid, or appends
+// the new ones.
+func diffArrays(path string, a, b []interface{}, patch *[]PatchOperation) {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	for i := 0; i < n; i++ {
+		diffValues(path+"/"+strconv.Itoa(i), a[i], b[i], patch)
+	}
+	for i := len(a) - 1; i >= n; i-- {
+		*patch = append(*patch, PatchOperation{Op: "remove", Path: path + "/" + strconv.Itoa(i)})
+	}
+	for i := n; i < len(b); i++ {
+		*patch = append(*patch, PatchOperation{Op: "add", Path: path + "/-", Value: b[i]})
+	}
+}
+
+// escapePointer escapes a key for use in a JSON Pointer (RFC 6901).
+func escapePointer(key string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+}
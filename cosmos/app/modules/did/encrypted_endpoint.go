@@ -0,0 +1,96 @@
+package did
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// MediaTypeEncryptedEndpoint is the typ of the JWE an encrypted service
+// endpoint is stored as.
+const MediaTypeEncryptedEndpoint = "application/aytch-service-endpoint+jwe"
+
+// EncryptedEndpoint is a service endpoint stored as a JWE in general JSON
+// serialization, encrypted to keyAgreement keys of the DID. The document stays
+// public, but only the holders of those keys can read where the service is;
+// to let another party contact the DID, add their X25519 key as a keyAgreement
+// method controlled by their DID and encrypt to it too. The wallet package
+// packs and unpacks endpoints.
+type EncryptedEndpoint struct {
+	Protected  string                       `json:"protected"`
+	Recipients []EncryptedEndpointRecipient `json:"recipients"`
+	IV         string                       `json:"iv"`
+	Ciphertext string                       `json:"ciphertext"`
+	Tag        string                       `json:"tag"`
+}
+
+// EncryptedEndpointRecipient is a JWE recipient: the content key wrapped for
+// one keyAgreement key.
+type EncryptedEndpointRecipient struct {
+	Header struct {
+		KID string `json:"kid"`
+	} `json:"header"`
+	EncryptedKey string `json:"encrypted_key"`
+}
+
+// IsEncryptedEndpoint reports whether a service endpoint holds a JWE rather
+// than a URI. URIs can't start with a brace, so the two can't be confused.
+func IsEncryptedEndpoint(endpoint string) bool {
+	return strings.HasPrefix(endpoint, "{")
+}
+
+// ParseEncryptedEndpoint decodes an encrypted service endpoint and checks
+// that it is well formed. It can't check the ciphertext, which only the
+// recipients can open.
+func ParseEncryptedEndpoint(endpoint string) (EncryptedEndpoint, error) {
+	var e EncryptedEndpoint
+	if err := json.Unmarshal([]byte(endpoint), &e); err != nil {
+		return EncryptedEndpoint{}, fmt.Errorf("invalid encrypted endpoint: %w", err)
+	}
+	header, err := base64.RawURLEncoding.DecodeString(e.Protected)
+	if err != nil {
+		return EncryptedEndpoint{}, fmt.Errorf("invalid encrypted endpoint header: %w", err)
+	}
+	var h struct {
+		Typ string `json:"typ"`
+	}
+	if err := json.Unmarshal(header, &h); err != nil {
+		return EncryptedEndpoint{}, fmt.Errorf("invalid encrypted endpoint header: %w", err)
+	}
+	if h.Typ != MediaTypeEncryptedEndpoint {
+		return EncryptedEndpoint{}, fmt.Errorf("encrypted endpoint has type %q, not %s", h.Typ, MediaTypeEncryptedEndpoint)
+	}
+	if len(e.Recipients) == 0 {
+		return EncryptedEndpoint{}, fmt.Errorf("encrypted endpoint has no recipients")
+	}
+	for _, r := range e.Recipients {
+		if r.Header.KID == "" {
+			return EncryptedEndpoint{}, fmt.Errorf("encrypted endpoint recipient has no kid")
+		}
+		if _, err := base64.RawURLEncoding.DecodeString(r.EncryptedKey); err != nil {
+			return EncryptedEndpoint{}, fmt.Errorf("invalid encrypted key for %s: %w", r.Header.KID, err)
+		}
+	}
+	for _, part := range []string{e.IV, e.Ciphertext, e.Tag} {
+		if _, err := base64.RawURLEncoding.DecodeString(part); err != nil || part == "" {
+			return EncryptedEndpoint{}, fmt.Errorf("encrypted endpoint must have a base64url iv, ciphertext and tag")
+		}
+	}
+	return e, nil
+}
+
+// checkEncryptedEndpoint checks that an encrypted endpoint of doc is
+// encrypted only to doc's keyAgreement keys.
+func checkEncryptedEndpoint(doc DIDDocument, endpoint string) error {
+	e, err := ParseEncryptedEndpoint(endpoint)
+	if err != nil {
+		return err
+	}
+	for _, r := range e.Recipients {
+		if !containsString(doc.KeyAgreement, r.Header.KID) {
+			return fmt.Errorf("encrypted endpoint recipient %s is not a key agreement method", r.Header.KID)
+		}
+	}
+	return nil
+}
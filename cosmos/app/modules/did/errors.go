@@ -0,0 +1,21 @@
+package did
+
+import (
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// Errors returned when a document breaks the limits set by the module
+// params. They're registered under the module's codespace so clients can tell
+// them apart by ABCI code.
+var (
+	ErrDocumentTooLarge           = sdkerrors.Register(ModuleName, 2, "DID document too large")
+	ErrTooManyVerificationMethods = sdkerrors.Register(ModuleName, 3, "too many verification methods")
+	ErrTooManyServices            = sdkerrors.Register(ModuleName, 4, "too many service endpoints")
+	ErrFieldTooLong               = sdkerrors.Register(ModuleName, 5, "DID document field too long")
+	ErrServiceEndpointTooLong     = sdkerrors.Register(ModuleName, 6, "service endpoint URL too long")
+	ErrKeyTypeNotAllowed          = sdkerrors.Register(ModuleName, 7, "verification method type not allowed")
+	ErrContextNotAllowed          = sdkerrors.Register(ModuleName, 8, "JSON-LD context not allowed")
+	ErrPersonalData               = sdkerrors.Register(ModuleName, 9, "DID document contains personal data")
+	ErrInvalidServiceEndpoint     = sdkerrors.Register(ModuleName, 10, "invalid service endpoint")
+	ErrUntrustedCertificate       = sdkerrors.Register(ModuleName, 11, "certificate chain not trusted")
+)
@@ -0,0 +1,193 @@
+package did
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec"
+	"golang.org/x/crypto/sha3"
+)
+
+// Ethereum proof types: an EIP-191 personal_sign signature over
+// EthereumLinkMessage, or an EIP-712 eth_signTypedData_v4 signature over
+// EthereumLinkTypedData.
+const (
+	ProofTypeEIP191 = "EIP191"
+	ProofTypeEIP712 = "EIP712"
+)
+
+// EIP-712 domain of account link proofs. The domain carries the Ethereum
+// chain ID, so a signature is only good for the chain it names.
+const (
+	eip712DomainName    = "Aytch DID"
+	eip712DomainVersion = "1"
+	eip712DomainType    = "EIP712Domain(string name,string version,uint256 chainId)"
+	eip712LinkType      = "LinkEthereumAccount(string did,address account,uint256 nonce)"
+)
+
+// ParseEthereumAddress parses a 0x-prefixed hex Ethereum address. Mixed-case
+// addresses must carry a valid EIP-55 checksum.
+func ParseEthereumAddress(s string) ([20]byte, error) {
+	var addr [20]byte
+	if !strings.HasPrefix(s, "0x") || len(s) != 42 {
+		return addr, fmt.Errorf("invalid Ethereum address %q", s)
+	}
+	bz, err := hex.DecodeString(s[2:])
+	if err != nil {
+		return addr, fmt.Errorf("invalid Ethereum address %q", s)
+	}
+	copy(addr[:], bz)
+	hexPart := s[2:]
+	if hexPart != strings.ToLower(hexPart) && hexPart != strings.ToUpper(hexPart) && ChecksumEthereumAddress(addr) != s {
+		return addr, fmt.Errorf("invalid EIP-55 checksum in Ethereum address %s", s)
+	}
+	return addr, nil
+}
+
+// ChecksumEthereumAddress returns addr in its EIP-55 mixed-case form.
+func ChecksumEthereumAddress(addr [20]byte) string {
+	lower := hex.EncodeToString(addr[:])
+	hash := keccak256([]byte(lower))
+	out := []byte(lower)
+	for i, c := range out {
+		nibble := hash[i/2] >> 4
+		if i%2 == 1 {
+			nibble = hash[i/2] & 0x0f
+		}
+		if c >= 'a' && nibble >= 8 {
+			out[i] = c - 'a' + 'A'
+		}
+	}
+	return "0x" + string(out)
+}
+
+// EthereumAccountID returns the CAIP-10 account ID of addr on an EVM chain,
+// the form in which linked accounts appear in alsoKnownAs.
+func EthereumAccountID(chainID uint64, addr [20]byte) string {
+	return "eip155:" + strconv.FormatUint(chainID, 10) + ":" + ChecksumEthereumAddress(addr)
+}
+
+// EthereumLinkMessage returns the text an Ethereum account signs with
+// personal_sign to link itself to a DID. It names the DID's current nonce so
+// the signature can't be replayed once the link is made.
+func EthereumLinkMessage(did string, addr [20]byte, chainID, nonce uint64) string {
+	return fmt.Sprintf("Link Ethereum account %s to %s\n\nChain ID: %d\nNonce: %d",
+		ChecksumEthereumAddress(addr), did, chainID, nonce)
+}
+
+// EthereumLinkTypedData returns the EIP-712 typed data an Ethereum account
+// signs with eth_signTypedData_v4 to link itself to a DID, in the JSON shape
+// wallets expect.
+func EthereumLinkTypedData(did string, addr [20]byte, chainID, nonce uint64) map[string]interface{} {
+	return map[string]interface{}{
+		"types": map[string]interface{}{
+			"EIP712Domain": []map[string]string{
+				{"name": "name", "type": "string"},
+				{"name": "version", "type": "string"},
+				{"name": "chainId", "type": "uint256"},
+			},
+			"LinkEthereumAccount": []map[string]string{
+				{"name": "did", "type": "string"},
+				{"name": "account", "type": "address"},
+				{"name": "nonce", "type": "uint256"},
+			},
+		},
+		"primaryType": "LinkEthereumAccount",
+		"domain": map[string]interface{}{
+			"name":    eip712DomainName,
+			"version": eip712DomainVersion,
+			"chainId": chainID,
+		},
+		"message": map[string]interface{}{
+			"did":     did,
+			"account": ChecksumEthereumAddress(addr),
+			"nonce":   strconv.FormatUint(nonce, 10),
+		},
+	}
+}
+
+// eip191Hash returns the EIP-191 version 0x45 (personal_sign) hash of msg.
+func eip191Hash(msg string) []byte {
+	return keccak256([]byte("\x19Ethereum Signed Message:\n" + strconv.Itoa(len(msg)) + msg))
+}
+
+// eip712LinkHash returns the EIP-712 hash of EthereumLinkTypedData.
+func eip712LinkHash(did string, addr [20]byte, chainID, nonce uint64) []byte {
+	domain := keccak256(
+		keccak256([]byte(eip712DomainType)),
+		keccak256([]byte(eip712DomainName)),
+		keccak256([]byte(eip712DomainVersion)),
+		uint256(chainID),
+	)
+	var account [32]byte
+	copy(account[12:], addr[:])
+	message := keccak256(
+		keccak256([]byte(eip712LinkType)),
+		keccak256([]byte(did)),
+		account[:],
+		uint256(nonce),
+	)
+	return keccak256([]byte("\x19\x01"), domain, message)
+}
+
+// VerifyEthereumLinkProof checks that sig, of the given proof type, was made
+// by addr over the link of addr on chainID to did at the DID's nonce.
+func VerifyEthereumLinkProof(proofType, did string, addr [20]byte, chainID, nonce uint64, sig []byte) error {
+	var hash []byte
+	switch proofType {
+	case ProofTypeEIP191:
+		hash = eip191Hash(EthereumLinkMessage(did, addr, chainID, nonce))
+	case ProofTypeEIP712:
+		hash = eip712LinkHash(did, addr, chainID, nonce)
+	default:
+		return fmt.Errorf("unknown Ethereum proof type %q", proofType)
+	}
+	signer, err := recoverEthereumAddress(hash, sig)
+	if err != nil {
+		return err
+	}
+	if signer != addr {
+		return fmt.Errorf("Ethereum signature is from %s, not %s", ChecksumEthereumAddress(signer), ChecksumEthereumAddress(addr))
+	}
+	return nil
+}
+
+// recoverEthereumAddress recovers the address that made a 65-byte
+// r || s || v secp256k1 signature over hash. v may be 0/1 or 27/28.
+func recoverEthereumAddress(hash, sig []byte) ([20]byte, error) {
+	var addr [20]byte
+	if len(sig) != 65 {
+		return addr, fmt.Errorf("Ethereum signature must be 65 bytes, got %d", len(sig))
+	}
+	v := sig[64]
+	if v >= 27 {
+		v -= 27
+	}
+	if v > 1 {
+		return addr, fmt.Errorf("invalid Ethereum signature recovery ID %d", sig[64])
+	}
+	compact := append([]byte{27 + v}, sig[:64]...)
+	pub, _, err := btcec.RecoverCompact(btcec.S256(), compact, hash)
+	if err != nil {
+		return addr, fmt.Errorf("invalid Ethereum signature: %w", err)
+	}
+	copy(addr[:], keccak256(pub.SerializeUncompressed()[1:])[12:])
+	return addr, nil
+}
+
+func keccak256(data ...[]byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	for _, d := range data {
+		h.Write(d)
+	}
+	return h.Sum(nil)
+}
+
+func uint256(n uint64) []byte {
+	var bz [32]byte
+	binary.BigEndian.PutUint64(bz[24:], n)
+	return bz[:]
+}
@@ -0,0 +1,134 @@
+package did
+
+import (
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// MsgLinkEthereumAccount records an Ethereum account in the alsoKnownAs of a
+// DID. A controller of the DID authorizes the link like a MsgUpdateDID, and
+// the Ethereum account proves its consent with AccountSignature, an EIP-191
+// or EIP-712 signature (see ProofType) over the link at the DID's current
+// nonce.
+type MsgLinkEthereumAccount struct {
+	DID              string         `protobuf:"bytes,1,opt,name=did,proto3" json:"did"`
+	Address          string         `protobuf:"bytes,2,opt,name=address,proto3" json:"address"`
+	ChainID          uint64         `protobuf:"varint,3,opt,name=chain_id,proto3" json:"chain_id"`
+	ProofType        string         `protobuf:"bytes,4,opt,name=proof_type,proto3" json:"proof_type"`
+	AccountSignature []byte         `protobuf:"bytes,5,opt,name=account_signature,proto3" json:"account_signature"`
+	Nonce            uint64         `protobuf:"varint,6,opt,name=nonce,proto3" json:"nonce"`
+	Signer           string         `protobuf:"bytes,7,opt,name=signer,proto3" json:"signer"`
+	Signature        []byte         `protobuf:"bytes,8,opt,name=signature,proto3" json:"signature,omitempty"`
+	Creator          sdk.AccAddress `protobuf:"bytes,9,opt,name=creator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"creator"`
+}
+
+// ValidateBasic performs basic validation of MsgLinkEthereumAccount.
+func (msg MsgLinkEthereumAccount) ValidateBasic() error {
+	if err := validateChecksums(msg); err != nil {
+		return err
+	}
+	if msg.DID == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("DID cannot be empty")
+	}
+	if _, err := ParseEthereumAddress(msg.Address); err != nil {
+		return sdkerrors.ErrUnknownRequest.Wrap(err.Error())
+	}
+	if msg.ChainID == 0 {
+		return sdkerrors.ErrUnknownRequest.Wrap("Chain ID cannot be zero")
+	}
+	if msg.ProofType != ProofTypeEIP191 && msg.ProofType != ProofTypeEIP712 {
+		return sdkerrors.ErrUnknownRequest.Wrap("Proof type must be " + ProofTypeEIP191 + " or " + ProofTypeEIP712)
+	}
+	if len(msg.AccountSignature) != 65 {
+		return sdkerrors.ErrUnknownRequest.Wrap("Account signature must be 65 bytes")
+	}
+	if msg.Signer == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Signer cannot be empty")
+	}
+	return nil
+}
+
+// ProofSignBytes returns the bytes a controller DID signs to authorize the
+// link.
+func (msg MsgLinkEthereumAccount) ProofSignBytes() []byte {
+	msg.Signature = nil
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// Route returns the message route.
+func (msg MsgLinkEthereumAccount) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgLinkEthereumAccount) Type() string { return "link_ethereum_account" }
+
+// GetSignBytes returns the canonical bytes to sign over.
+func (msg MsgLinkEthereumAccount) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners returns the account that must sign the message.
+func (msg MsgLinkEthereumAccount) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
+
+// MsgUnlinkEthereumAccount removes an Ethereum account from the alsoKnownAs
+// of a DID. It is authorized by a controller like MsgUpdateDID.
+type MsgUnlinkEthereumAccount struct {
+	DID       string         `protobuf:"bytes,1,opt,name=did,proto3" json:"did"`
+	Address   string         `protobuf:"bytes,2,opt,name=address,proto3" json:"address"`
+	ChainID   uint64         `protobuf:"varint,3,opt,name=chain_id,proto3" json:"chain_id"`
+	Nonce     uint64         `protobuf:"varint,4,opt,name=nonce,proto3" json:"nonce"`
+	Signer    string         `protobuf:"bytes,5,opt,name=signer,proto3" json:"signer"`
+	Signature []byte         `protobuf:"bytes,6,opt,name=signature,proto3" json:"signature,omitempty"`
+	Creator   sdk.AccAddress `protobuf:"bytes,7,opt,name=creator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"creator"`
+}
+
+// ValidateBasic performs basic validation of MsgUnlinkEthereumAccount.
+func (msg MsgUnlinkEthereumAccount) ValidateBasic() error {
+	if err := validateChecksums(msg); err != nil {
+		return err
+	}
+	if msg.DID == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("DID cannot be empty")
+	}
+	if _, err := ParseEthereumAddress(msg.Address); err != nil {
+		return sdkerrors.ErrUnknownRequest.Wrap(err.Error())
+	}
+	if msg.Signer == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Signer cannot be empty")
+	}
+	return nil
+}
+
+// ProofSignBytes returns the bytes a controller DID signs to authorize the
+// removal.
+func (msg MsgUnlinkEthereumAccount) ProofSignBytes() []byte {
+	msg.Signature = nil
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// Route returns the message route.
+func (msg MsgUnlinkEthereumAccount) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgUnlinkEthereumAccount) Type() string { return "unlink_ethereum_account" }
+
+// GetSignBytes returns the canonical bytes to sign over.
+func (msg MsgUnlinkEthereumAccount) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners returns the account that must sign the message.
+func (msg MsgUnlinkEthereumAccount) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
@@ -0,0 +1,50 @@
+package did
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// LinkEthereumAccount adds the CAIP-10 account ID of an Ethereum account to
+// the alsoKnownAs of an active DID. Callers must have verified the account's
+// proof.
+func (k Keeper) LinkEthereumAccount(ctx sdk.Context, id string, chainID uint64, addr [20]byte) error {
+	did, err := k.GetDID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if did.Deactivated {
+		return fmt.Errorf("DID %s is deactivated", id)
+	}
+	account := EthereumAccountID(chainID, addr)
+	if containsString(did.AlsoKnownAs, account) {
+		return fmt.Errorf("%s is already linked to %s", account, id)
+	}
+	did.AlsoKnownAs = append(did.AlsoKnownAs, account)
+	return k.setAlsoKnownAs(ctx, did)
+}
+
+// UnlinkEthereumAccount removes the CAIP-10 account ID of an Ethereum account
+// from the alsoKnownAs of an active DID.
+func (k Keeper) UnlinkEthereumAccount(ctx sdk.Context, id string, chainID uint64, addr [20]byte) error {
+	did, err := k.GetDID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if did.Deactivated {
+		return fmt.Errorf("DID %s is deactivated", id)
+	}
+	account := EthereumAccountID(chainID, addr)
+	var kept []string
+	for _, aka := range did.AlsoKnownAs {
+		if aka != account {
+			kept = append(kept, aka)
+		}
+	}
+	if len(kept) == len(did.AlsoKnownAs) {
+		return fmt.Errorf("%s is not linked to %s", account, id)
+	}
+	did.AlsoKnownAs = kept
+	return k.setAlsoKnownAs(ctx, did)
+}
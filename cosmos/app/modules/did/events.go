@@ -0,0 +1,63 @@
+package did
+
+import (
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// EventTypeDIDChanged is emitted whenever a DID document is written, from a
+// transaction or from the EndBlocker. It carries the DID, the operation, the
+// document's new version and one controller attribute per controller, so
+// subscribers can filter on either the DID or a controller.
+const (
+	EventTypeDIDChanged = "did_changed"
+
+	AttributeKeyDID        = "did"
+	AttributeKeyOperation  = "operation"
+	AttributeKeyVersion    = "version"
+	AttributeKeyController = "controller"
+
+	OperationCreate     = "create"
+	OperationUpdate     = "update"
+	OperationDeactivate = "deactivate"
+)
+
+// emitDIDChanged emits EventTypeDIDChanged for a document about to be
+// written over existing, which is nil for a new DID, and returns the
+// operation.
+func emitDIDChanged(ctx sdk.Context, existing *DIDDocument, did DIDDocument) string {
+	op := OperationUpdate
+	switch {
+	case existing == nil:
+		op = OperationCreate
+	case did.Deactivated && !existing.Deactivated:
+		op = OperationDeactivate
+	}
+	attrs := []sdk.Attribute{
+		sdk.NewAttribute(AttributeKeyDID, did.ID),
+		sdk.NewAttribute(AttributeKeyOperation, op),
+		sdk.NewAttribute(AttributeKeyVersion, strconv.FormatUint(did.Nonce, 10)),
+	}
+	for _, c := range did.Controller {
+		attrs = append(attrs, sdk.NewAttribute(AttributeKeyController, c))
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(EventTypeDIDChanged, attrs...))
+	return op
+}
+
+// Events emitted when a pending change is dropped before being applied:
+// EventTypeDIDChangeRejected once its threshold can no longer be met, and
+// EventTypeDIDChangeAborted when the DID's controllers or threshold change.
+const (
+	EventTypeDIDChangeRejected = "did_change_rejected"
+	EventTypeDIDChangeAborted  = "did_change_aborted"
+)
+
+// emitDIDChangeClosed emits an event of the given type for a pending change.
+func emitDIDChangeClosed(ctx sdk.Context, eventType string, change PendingDIDChange) {
+	ctx.EventManager().EmitEvent(sdk.NewEvent(eventType,
+		sdk.NewAttribute(AttributeKeyDID, change.DID),
+		sdk.NewAttribute("change_id", strconv.FormatUint(change.ID, 10)),
+	))
+}
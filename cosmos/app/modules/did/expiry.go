@@ -0,0 +1,63 @@
+package did
+
+import (
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// MsgRenewDID moves a DID's expiry to ExpiresAt (unix seconds). It must be
+// sent before the DID expires; expired DIDs are deactivated for good.
+type MsgRenewDID struct {
+	DID       string         `protobuf:"bytes,1,opt,name=did,proto3" json:"did"`
+	ExpiresAt int64          `protobuf:"varint,2,opt,name=expires_at,proto3" json:"expires_at"`
+	Nonce     uint64         `protobuf:"varint,6,opt,name=nonce,proto3" json:"nonce"`
+	Signer    string         `protobuf:"bytes,3,opt,name=signer,proto3" json:"signer"`
+	Signature []byte         `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	Creator   sdk.AccAddress `protobuf:"bytes,5,opt,name=creator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"creator"`
+}
+
+// ValidateBasic performs basic validation of MsgRenewDID.
+func (msg MsgRenewDID) ValidateBasic() error {
+	if err := validateChecksums(msg); err != nil {
+		return err
+	}
+	if msg.DID == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("DID cannot be empty")
+	}
+	if msg.Signer == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Signer cannot be empty")
+	}
+	if msg.ExpiresAt < 0 {
+		return sdkerrors.ErrUnknownRequest.Wrap("Expiry cannot be negative")
+	}
+	return nil
+}
+
+// ProofSignBytes returns the bytes a controller DID signs to authorize the
+// renewal.
+func (msg MsgRenewDID) ProofSignBytes() []byte {
+	msg.Signature = nil
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// Route returns the message route.
+func (msg MsgRenewDID) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgRenewDID) Type() string { return "renew_did" }
+
+// GetSignBytes returns the canonical bytes to sign over.
+func (msg MsgRenewDID) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners returns the account that must sign the message.
+func (msg MsgRenewDID) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
@@ -0,0 +1,54 @@
+package did
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// CheckExpiry rejects documents whose expiry has already passed.
+func (k Keeper) CheckExpiry(ctx sdk.Context, did DIDDocument) error {
+	if did.ExpiresAt != 0 && did.ExpiresAt <= ctx.BlockTime().Unix() {
+		return fmt.Errorf("DID %s expiry %d is not in the future", did.ID, did.ExpiresAt)
+	}
+	return nil
+}
+
+// RenewDID sets a new expiry on an active DID; zero removes the expiry.
+func (k Keeper) RenewDID(ctx sdk.Context, id string, expiresAt int64) error {
+	did, err := k.GetDID(ctx, id)
+	if err != nil {
+		return err
+	}
+	did.ExpiresAt = expiresAt
+	return k.UpdateDID(ctx, did)
+}
+
+// ExpireDIDs deactivates the DIDs whose expiry has passed.
+func (k Keeper) ExpireDIDs(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	for _, e := range dueEntries(store, ExpiryQueuePrefix, ctx.BlockTime().Unix()) {
+		id := string(e.suffix)
+		store.Delete(expiryQueueKey(e.at, id))
+		if err := k.DeactivateDID(ctx, id); err != nil {
+			ctx.Logger().Error("failed to deactivate expired DID", "did", id, "err", err)
+			continue
+		}
+		if err := k.ForfeitFeeEscrow(ctx, id, "expired"); err != nil {
+			ctx.Logger().Error("failed to forfeit fee escrow of expired DID", "did", id, "err", err)
+		}
+		ctx.EventManager().EmitEvent(sdk.NewEvent("did_expired", sdk.NewAttribute("did", id)))
+	}
+}
+
+// indexExpiry moves a document's entry in the expiry queue from its stored
+// version, if any, to the version being written.
+func (k Keeper) indexExpiry(ctx sdk.Context, existing *DIDDocument, did DIDDocument) {
+	store := ctx.KVStore(k.storeKey)
+	if existing != nil && existing.ExpiresAt != 0 {
+		store.Delete(expiryQueueKey(existing.ExpiresAt, did.ID))
+	}
+	if did.ExpiresAt != 0 && !did.Deactivated {
+		store.Set(expiryQueueKey(did.ExpiresAt, did.ID), []byte{})
+	}
+}
@@ -0,0 +1,88 @@
+package did
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+)
+
+// FeeSplit is how a collected fee is divided: a FeeCommunityPoolShare to the
+// community pool, a FeeBurnShare burned and the rest to the fee collector.
+type FeeSplit struct {
+	CommunityPool sdk.Coins `json:"community_pool"`
+	Burn          sdk.Coins `json:"burn"`
+	FeeCollector  sdk.Coins `json:"fee_collector"`
+}
+
+// SplitFee divides fee by the given shares. The shares are rounded down and
+// the remainder goes to the fee collector, so the parts always add up to fee;
+// shares adding up to more than 1 burn what the community pool leaves.
+func SplitFee(fee sdk.Coins, communityPoolShare, burnShare sdk.Dec) FeeSplit {
+	var split FeeSplit
+	for _, coin := range fee {
+		community := communityPoolShare.MulInt(coin.Amount).TruncateInt()
+		burn := sdk.MinInt(burnShare.MulInt(coin.Amount).TruncateInt(), coin.Amount.Sub(community))
+		split.CommunityPool = appendPositive(split.CommunityPool, coin.Denom, community)
+		split.Burn = appendPositive(split.Burn, coin.Denom, burn)
+		split.FeeCollector = appendPositive(split.FeeCollector, coin.Denom, coin.Amount.Sub(community).Sub(burn))
+	}
+	return split
+}
+
+// appendPositive appends a coin to coins unless its amount is zero. Coins
+// appended in the order of a sorted fee stay sorted.
+func appendPositive(coins sdk.Coins, denom string, amount sdk.Int) sdk.Coins {
+	if !amount.IsPositive() {
+		return coins
+	}
+	return append(coins, sdk.NewCoin(denom, amount))
+}
+
+// CollectFee collects the fee for an identity operation from the payer and
+// splits it by the FeeCommunityPoolShare and FeeBurnShare params. The name
+// service and mailbox collect their fees through it too.
+func (k Keeper) CollectFee(ctx sdk.Context, payer sdk.AccAddress, fee sdk.Coins) error {
+	if fee.IsZero() {
+		return nil
+	}
+	params := k.GetParams(ctx)
+	split := SplitFee(fee, params.FeeCommunityPoolShare, params.FeeBurnShare)
+	if !split.CommunityPool.IsZero() {
+		if err := k.distrKeeper.FundCommunityPool(ctx, split.CommunityPool, payer); err != nil {
+			return err
+		}
+	}
+	if !split.Burn.IsZero() {
+		if err := k.bankKeeper.SendCoinsFromAccountToModule(ctx, payer, ModuleName, split.Burn); err != nil {
+			return err
+		}
+		if err := k.bankKeeper.BurnCoins(ctx, ModuleName, split.Burn); err != nil {
+			return err
+		}
+	}
+	if !split.FeeCollector.IsZero() {
+		if err := k.bankKeeper.SendCoinsFromAccountToModule(ctx, payer, authtypes.FeeCollectorName, split.FeeCollector); err != nil {
+			return err
+		}
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent("fee_collected",
+		sdk.NewAttribute("payer", payer.String()),
+		sdk.NewAttribute("amount", fee.String()),
+		sdk.NewAttribute("community_pool", split.CommunityPool.String()),
+		sdk.NewAttribute("burned", split.Burn.String()),
+		sdk.NewAttribute("fee_collector", split.FeeCollector.String()),
+	))
+	return nil
+}
+
+func validateFeeShare(i interface{}) error {
+	share, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if share.IsNil() || share.IsNegative() || share.GT(sdk.OneDec()) {
+		return fmt.Errorf("fee share must be between 0 and 1: %s", share)
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package did
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+)
+
+func TestSplitFee(t *testing.T) {
+	fee := sdk.NewCoins(sdk.NewInt64Coin("uaytch", 1000), sdk.NewInt64Coin("uatom", 7))
+	cases := []struct {
+		name                     string
+		community, burn          sdk.Dec
+		wantPool, wantBurn, rest sdk.Coins
+	}{
+		{
+			name:      "all to the fee collector",
+			community: sdk.ZeroDec(), burn: sdk.ZeroDec(),
+			rest: fee,
+		},
+		{
+			name:      "shares round down",
+			community: sdk.NewDecWithPrec(25, 2), burn: sdk.NewDecWithPrec(5, 1),
+			wantPool: sdk.NewCoins(sdk.NewInt64Coin("uaytch", 250), sdk.NewInt64Coin("uatom", 1)),
+			wantBurn: sdk.NewCoins(sdk.NewInt64Coin("uaytch", 500), sdk.NewInt64Coin("uatom", 3)),
+			rest:     sdk.NewCoins(sdk.NewInt64Coin("uaytch", 250), sdk.NewInt64Coin("uatom", 3)),
+		},
+		{
+			name:      "burn capped by what the community pool leaves",
+			community: sdk.NewDecWithPrec(75, 2), burn: sdk.NewDecWithPrec(75, 2),
+			wantPool: sdk.NewCoins(sdk.NewInt64Coin("uaytch", 750), sdk.NewInt64Coin("uatom", 5)),
+			wantBurn: sdk.NewCoins(sdk.NewInt64Coin("uaytch", 250), sdk.NewInt64Coin("uatom", 2)),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			split := SplitFee(fee, tc.community, tc.burn)
+			if !split.CommunityPool.IsEqual(tc.wantPool) || !split.Burn.IsEqual(tc.wantBurn) || !split.FeeCollector.IsEqual(tc.rest) {
+				t.Errorf("SplitFee = %+v, want pool %s burn %s rest %s", split, tc.wantPool, tc.wantBurn, tc.rest)
+			}
+			total := split.CommunityPool.Add(split.Burn...).Add(split.FeeCollector...)
+			if !total.IsEqual(fee) {
+				t.Errorf("parts add up to %s, want %s", total, fee)
+			}
+		})
+	}
+}
+
+func TestCollectFee(t *testing.T) {
+	k, ctx, bank, distr := testKeeper(t)
+	params := k.GetParams(ctx)
+	params.FeeCommunityPoolShare = sdk.NewDecWithPrec(2, 1)
+	params.FeeBurnShare = sdk.NewDecWithPrec(3, 1)
+	k.SetParams(ctx, params)
+	payer := testAddr("payer")
+	bank.balances[payer.String()] = sdk.NewCoins(sdk.NewInt64Coin("uaytch", 1000))
+
+	if err := k.CollectFee(ctx, payer, sdk.NewCoins(sdk.NewInt64Coin("uaytch", 100))); err != nil {
+		t.Fatal(err)
+	}
+	if got := bank.balance(payer, "uaytch"); got != 900 {
+		t.Errorf("payer balance = %d, want 900", got)
+	}
+	if got := distr.communityPool.AmountOf("uaytch").Int64(); got != 20 {
+		t.Errorf("community pool = %d, want 20", got)
+	}
+	if got := bank.burned.AmountOf("uaytch").Int64(); got != 30 {
+		t.Errorf("burned = %d, want 30", got)
+	}
+	if got := bank.balance(authtypes.NewModuleAddress(authtypes.FeeCollectorName), "uaytch"); got != 50 {
+		t.Errorf("fee collector = %d, want 50", got)
+	}
+	if !hasEvent(ctx, "fee_collected") {
+		t.Error("no fee_collected event")
+	}
+
+	if err := k.CollectFee(ctx, payer, sdk.NewCoins(sdk.NewInt64Coin("uaytch", 5000))); err == nil {
+		t.Error("fee above the payer's balance collected")
+	}
+}
+
+func TestChargeCreateDIDFeesEscrow(t *testing.T) {
+	k, ctx, bank, _ := testKeeper(t)
+	params := k.GetParams(ctx)
+	params.CreateDIDFee = sdk.NewCoins(sdk.NewInt64Coin("uaytch", 100))
+	params.DeactivationRefund = sdk.NewCoins(sdk.NewInt64Coin("uaytch", 40))
+	k.SetParams(ctx, params)
+	payer := testAddr("payer")
+	bank.balances[payer.String()] = sdk.NewCoins(sdk.NewInt64Coin("uaytch", 1000))
+	ids := []string{"did:aytch:one", "did:aytch:two", "did:aytch:three"}
+
+	if err := k.ChargeCreateDIDFees(ctx, payer, ids); err != nil {
+		t.Fatal(err)
+	}
+	if got := bank.balance(payer, "uaytch"); got != 700 {
+		t.Errorf("payer balance = %d, want 700", got)
+	}
+	if got := bank.balance(authtypes.NewModuleAddress(authtypes.FeeCollectorName), "uaytch"); got != 180 {
+		t.Errorf("fee collector = %d, want 180", got)
+	}
+	if got := bank.balance(authtypes.NewModuleAddress(ModuleName), "uaytch"); got != 120 {
+		t.Errorf("escrowed in the module account = %d, want 120", got)
+	}
+	for _, id := range ids {
+		escrow, found := k.GetFeeEscrow(ctx, id)
+		if !found || !escrow.Payer.Equals(payer) || escrow.Amount.AmountOf("uaytch").Int64() != 40 {
+			t.Errorf("escrow of %s = %+v, %t", id, escrow, found)
+		}
+	}
+
+	if err := k.RefundFeeEscrow(ctx, ids[0]); err != nil {
+		t.Fatal(err)
+	}
+	if got := bank.balance(payer, "uaytch"); got != 740 {
+		t.Errorf("payer balance after refund = %d, want 740", got)
+	}
+	if _, found := k.GetFeeEscrow(ctx, ids[0]); found {
+		t.Error("refunded escrow still stored")
+	}
+
+	if err := k.ForfeitFeeEscrow(ctx, ids[1], "expired"); err != nil {
+		t.Fatal(err)
+	}
+	if got := bank.burned.AmountOf("uaytch").Int64(); got != 40 {
+		t.Errorf("burned after forfeit = %d, want 40", got)
+	}
+	if _, found := k.GetFeeEscrow(ctx, ids[1]); found {
+		t.Error("forfeited escrow still stored")
+	}
+}
+
+func TestChargeCreateDIDFeeBurn(t *testing.T) {
+	k, ctx, bank, _ := testKeeper(t)
+	params := k.GetParams(ctx)
+	params.CreateDIDFee = sdk.NewCoins(sdk.NewInt64Coin("uaytch", 100))
+	params.BurnCreateDIDFee = true
+	k.SetParams(ctx, params)
+	payer := testAddr("payer")
+	bank.balances[payer.String()] = sdk.NewCoins(sdk.NewInt64Coin("uaytch", 150))
+
+	if err := k.ChargeCreateDIDFee(ctx, payer, "did:aytch:one"); err != nil {
+		t.Fatal(err)
+	}
+	if got := bank.burned.AmountOf("uaytch").Int64(); got != 100 {
+		t.Errorf("burned = %d, want 100", got)
+	}
+	if _, found := k.GetFeeEscrow(ctx, "did:aytch:one"); found {
+		t.Error("escrow stored without a DeactivationRefund")
+	}
+	if err := k.ChargeCreateDIDFee(ctx, payer, "did:aytch:two"); err == nil {
+		t.Error("fee above the payer's balance charged")
+	}
+}
@@ -0,0 +1,197 @@
+package did
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/utilities"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The gateway is written by hand, like the rest of the module's protobuf
+// plumbing, in the form protoc-gen-grpc-gateway would generate for the Query
+// service. Routes live under /aytch/did/v1; list routes accept the standard
+// pagination.key, pagination.offset, pagination.limit, pagination.count_total
+// and pagination.reverse query parameters.
+
+// gatewayRoute binds an HTTP GET path to a Query method. A {name} segment
+// captures one path segment, {name=**} the rest of the path.
+type gatewayRoute struct {
+	path string
+	call func(ctx context.Context, client QueryClient, req *http.Request, params map[string]string) (proto.Message, error)
+}
+
+var gatewayRoutes = []gatewayRoute{
+	{"/aytch/did/v1/dids", func(ctx context.Context, c QueryClient, req *http.Request, _ map[string]string) (proto.Message, error) {
+		in := &QueryDIDsRequest{}
+		if err := populateQuery(in, req); err != nil {
+			return nil, err
+		}
+		return c.DIDs(ctx, in)
+	}},
+	{"/aytch/did/v1/dids/{id}", func(ctx context.Context, c QueryClient, _ *http.Request, p map[string]string) (proto.Message, error) {
+		return c.DID(ctx, &QueryDIDRequest{ID: p["id"]})
+	}},
+	{"/aytch/did/v1/dids/{did}/resources", func(ctx context.Context, c QueryClient, req *http.Request, p map[string]string) (proto.Message, error) {
+		in := &QueryResourcesRequest{DID: p["did"]}
+		if err := populateQuery(in, req, "did"); err != nil {
+			return nil, err
+		}
+		return c.Resources(ctx, in)
+	}},
+	{"/aytch/did/v1/dids/{did}/resources/{id}", func(ctx context.Context, c QueryClient, _ *http.Request, p map[string]string) (proto.Message, error) {
+		return c.Resource(ctx, &QueryResourceRequest{DID: p["did"], ID: p["id"]})
+	}},
+	{"/aytch/did/v1/dids/{did}/resources/by_name/{name}/{resource_type}", func(ctx context.Context, c QueryClient, req *http.Request, p map[string]string) (proto.Message, error) {
+		in := &QueryResourceByNameRequest{DID: p["did"], Name: p["name"], ResourceType: p["resource_type"]}
+		if err := populateQuery(in, req, "did", "name", "resource_type"); err != nil {
+			return nil, err
+		}
+		return c.ResourceByName(ctx, in)
+	}},
+	{"/aytch/did/v1/dids/{did}/resources/{id}/versions", func(ctx context.Context, c QueryClient, _ *http.Request, p map[string]string) (proto.Message, error) {
+		return c.ResourceVersions(ctx, &QueryResourceVersionsRequest{DID: p["did"], ID: p["id"]})
+	}},
+	{"/aytch/did/v1/dids/{did}/pending_changes", func(ctx context.Context, c QueryClient, req *http.Request, p map[string]string) (proto.Message, error) {
+		in := &QueryPendingChangesRequest{DID: p["did"]}
+		if err := populateQuery(in, req, "did"); err != nil {
+			return nil, err
+		}
+		return c.PendingChanges(ctx, in)
+	}},
+	{"/aytch/did/v1/dids/{did}/tx_history", func(ctx context.Context, c QueryClient, req *http.Request, p map[string]string) (proto.Message, error) {
+		in := &QueryTxHistoryRequest{DID: p["did"]}
+		if err := populateQuery(in, req, "did"); err != nil {
+			return nil, err
+		}
+		return c.TxHistory(ctx, in)
+	}},
+	{"/aytch/did/v1/dids/{did}/recovery", func(ctx context.Context, c QueryClient, _ *http.Request, p map[string]string) (proto.Message, error) {
+		return c.Recovery(ctx, &QueryRecoveryRequest{DID: p["did"]})
+	}},
+	{"/aytch/did/v1/dids/{did}/key_rotation", func(ctx context.Context, c QueryClient, _ *http.Request, p map[string]string) (proto.Message, error) {
+		return c.KeyRotation(ctx, &QueryKeyRotationRequest{DID: p["did"]})
+	}},
+	{"/aytch/did/v1/dids/{did}/accounts", func(ctx context.Context, c QueryClient, _ *http.Request, p map[string]string) (proto.Message, error) {
+		return c.Accounts(ctx, &QueryAccountsRequest{DID: p["did"]})
+	}},
+	{"/aytch/did/v1/dids/{did}/capabilities", func(ctx context.Context, c QueryClient, _ *http.Request, p map[string]string) (proto.Message, error) {
+		return c.Capabilities(ctx, &QueryCapabilitiesRequest{DID: p["did"]})
+	}},
+	{"/aytch/did/v1/dids/{did}/linked_assets", func(ctx context.Context, c QueryClient, _ *http.Request, p map[string]string) (proto.Message, error) {
+		return c.LinkedAssets(ctx, &QueryLinkedAssetsRequest{DID: p["did"]})
+	}},
+	{"/aytch/did/v1/dids/{did}/presentation_definitions", func(ctx context.Context, c QueryClient, _ *http.Request, p map[string]string) (proto.Message, error) {
+		return c.PresentationDefinitions(ctx, &QueryPresentationDefinitionsRequest{Verifier: p["did"]})
+	}},
+	{"/aytch/did/v1/dids/{did}/presentation_definitions/{id}", func(ctx context.Context, c QueryClient, _ *http.Request, p map[string]string) (proto.Message, error) {
+		return c.PresentationDefinition(ctx, &QueryPresentationDefinitionRequest{Verifier: p["did"], ID: p["id"]})
+	}},
+	{"/aytch/did/v1/dids/{did}/deposit", func(ctx context.Context, c QueryClient, _ *http.Request, p map[string]string) (proto.Message, error) {
+		return c.Deposit(ctx, &QueryDepositRequest{DID: p["did"]})
+	}},
+	{"/aytch/did/v1/activity/{actor}", func(ctx context.Context, c QueryClient, req *http.Request, p map[string]string) (proto.Message, error) {
+		in := &QueryActivityRequest{Actor: p["actor"]}
+		if err := populateQuery(in, req, "actor"); err != nil {
+			return nil, err
+		}
+		return c.Activity(ctx, in)
+	}},
+	{"/aytch/did/v1/accounts/{address}/did", func(ctx context.Context, c QueryClient, _ *http.Request, p map[string]string) (proto.Message, error) {
+		return c.DIDByAddress(ctx, &QueryDIDByAddressRequest{Address: p["address"]})
+	}},
+	{"/aytch/did/v1/pending_changes/{change_id}", func(ctx context.Context, c QueryClient, _ *http.Request, p map[string]string) (proto.Message, error) {
+		id, err := strconv.ParseUint(p["change_id"], 10, 64)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid change ID %q", p["change_id"])
+		}
+		return c.PendingChange(ctx, &QueryPendingChangeRequest{ChangeID: id})
+	}},
+	// Credential IDs are often URLs, so the id captures the rest of the path.
+	{"/aytch/did/v1/credential_status/{id=**}", func(ctx context.Context, c QueryClient, _ *http.Request, p map[string]string) (proto.Message, error) {
+		return c.CredentialStatus(ctx, &QueryCredentialStatusRequest{ID: p["id"]})
+	}},
+	{"/aytch/did/v1/params", func(ctx context.Context, c QueryClient, _ *http.Request, _ map[string]string) (proto.Message, error) {
+		return c.Params(ctx, &QueryParamsRequest{})
+	}},
+	{"/aytch/did/v1/stats", func(ctx context.Context, c QueryClient, _ *http.Request, _ map[string]string) (proto.Message, error) {
+		return c.Stats(ctx, &QueryStatsRequest{})
+	}},
+	{"/aytch/did/v1/hash_anchors/{hash}", func(ctx context.Context, c QueryClient, _ *http.Request, p map[string]string) (proto.Message, error) {
+		return c.HashAnchors(ctx, &QueryHashAnchorsRequest{Hash: p["hash"]})
+	}},
+}
+
+// RegisterQueryHandlerClient registers the gateway routes of the Query
+// service on mux, forwarding requests to client.
+func RegisterQueryHandlerClient(ctx context.Context, mux *runtime.ServeMux, client QueryClient) error {
+	for _, route := range gatewayRoutes {
+		route := route
+		pattern, err := gatewayPattern(route.path)
+		if err != nil {
+			return err
+		}
+		mux.Handle(http.MethodGet, pattern, func(w http.ResponseWriter, req *http.Request, params map[string]string) {
+			ctx, cancel := context.WithCancel(req.Context())
+			defer cancel()
+			_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+			rctx, err := runtime.AnnotateContext(ctx, mux, req)
+			if err != nil {
+				runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+				return
+			}
+			resp, err := route.call(rctx, client, req, params)
+			if err != nil {
+				runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+				return
+			}
+			runtime.ForwardResponseMessage(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
+		})
+	}
+	return nil
+}
+
+// gatewayPattern compiles a route path into a gateway pattern. DIDs contain
+// colons, so the last segment is never split into a custom verb.
+func gatewayPattern(path string) (runtime.Pattern, error) {
+	var ops []int
+	var pool []string
+	intern := func(s string) int {
+		pool = append(pool, s)
+		return len(pool) - 1
+	}
+	for _, seg := range strings.Split(strings.TrimPrefix(path, "/"), "/") {
+		switch {
+		case strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "=**}"):
+			name := strings.TrimSuffix(strings.TrimPrefix(seg, "{"), "=**}")
+			ops = append(ops, int(utilities.OpPushM), 0, int(utilities.OpConcatN), 1, int(utilities.OpCapture), intern(name))
+		case strings.HasPrefix(seg, "{"):
+			name := strings.TrimSuffix(strings.TrimPrefix(seg, "{"), "}")
+			ops = append(ops, int(utilities.OpPush), 0, int(utilities.OpConcatN), 1, int(utilities.OpCapture), intern(name))
+		default:
+			ops = append(ops, int(utilities.OpLitPush), intern(seg))
+		}
+	}
+	return runtime.NewPattern(1, ops, pool, "", runtime.AssumeColonVerbOpt(false))
+}
+
+// populateQuery fills in from the request's query parameters, ignoring the
+// fields bound from the path.
+func populateQuery(in proto.Message, req *http.Request, pathFields ...string) error {
+	if err := req.ParseForm(); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	var seqs [][]string
+	for _, f := range pathFields {
+		seqs = append(seqs, []string{f})
+	}
+	if err := runtime.PopulateQueryParameters(in, req.Form, utilities.NewDoubleArray(seqs)); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,429 @@
+package did
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/gogo/protobuf/proto"
+)
+
+// GenesisState defines the DID module's genesis state.
+type GenesisState struct {
+	DIDs                    []DIDDocument                  `protobuf:"bytes,1,rep,name=dids,proto3" json:"dids"`
+	Params                  Params                         `protobuf:"bytes,2,opt,name=params,proto3" json:"params"`
+	Commitments             []DIDCommitment                `protobuf:"bytes,3,rep,name=commitments,proto3" json:"commitments,omitempty"`
+	AccountLinks            []AccountLink                  `protobuf:"bytes,4,rep,name=account_links,proto3" json:"account_links,omitempty"`
+	Capabilities            []CapabilityGrant              `protobuf:"bytes,5,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	LinkedAssets            []LinkedAsset                  `protobuf:"bytes,6,rep,name=linked_assets,proto3" json:"linked_assets,omitempty"`
+	Deposits                []DIDDeposit                   `protobuf:"bytes,7,rep,name=deposits,proto3" json:"deposits,omitempty"`
+	StatusListEntries       []StatusListEntry              `protobuf:"bytes,8,rep,name=status_list_entries,proto3" json:"status_list_entries,omitempty"`
+	PresentationDefinitions []PresentationDefinitionRecord `protobuf:"bytes,9,rep,name=presentation_definitions,proto3" json:"presentation_definitions,omitempty"`
+	FeeEscrows              []FeeEscrow                    `protobuf:"bytes,10,rep,name=fee_escrows,proto3" json:"fee_escrows,omitempty"`
+	HashAnchors             []HashAnchor                   `protobuf:"bytes,11,rep,name=hash_anchors,proto3" json:"hash_anchors,omitempty"`
+	CredentialRoots         []CredentialRoot               `protobuf:"bytes,12,rep,name=credential_roots,proto3" json:"credential_roots,omitempty"`
+	CredentialStatuses      []CredentialStatus             `protobuf:"bytes,13,rep,name=credential_statuses,proto3" json:"credential_statuses,omitempty"`
+	Resources               []Resource                     `protobuf:"bytes,14,rep,name=resources,proto3" json:"resources,omitempty"`
+	PendingChanges          []PendingDIDChange             `protobuf:"bytes,15,rep,name=pending_changes,proto3" json:"pending_changes,omitempty"`
+	NextPendingChangeID     uint64                         `protobuf:"varint,16,opt,name=next_pending_change_id,proto3" json:"next_pending_change_id,omitempty"`
+	RecoveryConfigs         []RecoveryConfig               `protobuf:"bytes,17,rep,name=recovery_configs,proto3" json:"recovery_configs,omitempty"`
+	PendingRecoveries       []PendingRecovery              `protobuf:"bytes,18,rep,name=pending_recoveries,proto3" json:"pending_recoveries,omitempty"`
+	PendingKeyRotations     []PendingKeyRotation           `protobuf:"bytes,19,rep,name=pending_key_rotations,proto3" json:"pending_key_rotations,omitempty"`
+}
+
+func init() {
+	proto.RegisterType((*GenesisState)(nil), "aytch.did.v1.GenesisState")
+}
+
+func (m *GenesisState) Reset()         { *m = GenesisState{} }
+func (m *GenesisState) String() string { return proto.CompactTextString(m) }
+func (*GenesisState) ProtoMessage()    {}
+
+// DefaultGenesis returns the default genesis state.
+func DefaultGenesis() *GenesisState {
+	return &GenesisState{Params: DefaultParams()}
+}
+
+// ValidateGenesis performs basic genesis state validation.
+func ValidateGenesis(gs GenesisState) error {
+	if err := gs.Params.Validate(); err != nil {
+		return err
+	}
+	seen := make(map[string]bool)
+	for _, did := range gs.DIDs {
+		if did.ID == "" {
+			return fmt.Errorf("DID ID cannot be empty")
+		}
+		if seen[did.ID] {
+			return fmt.Errorf("duplicate DID %s", did.ID)
+		}
+		seen[did.ID] = true
+		if err := ValidateChecksum(did.ID); err != nil {
+			return err
+		}
+		// Linked accounts are proven when linked, not supplied by messages.
+		content := did
+		content.AlsoKnownAs = nil
+		if err := validateDocumentContent(content); err != nil {
+			return fmt.Errorf("DID %s: %w", did.ID, err)
+		}
+	}
+	for _, c := range gs.Commitments {
+		if c.ID == "" {
+			return fmt.Errorf("DID ID cannot be empty")
+		}
+		if seen[c.ID] {
+			return fmt.Errorf("duplicate DID %s", c.ID)
+		}
+		seen[c.ID] = true
+		if len(c.Commitment) != sha256.Size {
+			return fmt.Errorf("commitment of %s must be a SHA-256 digest", c.ID)
+		}
+	}
+	for _, did := range gs.DIDs {
+		for _, c := range did.Controller {
+			if strings.HasPrefix(c, DIDMethodPrefix) && !seen[c] {
+				return fmt.Errorf("controller %s of %s not found", c, did.ID)
+			}
+		}
+	}
+	linked := make(map[string]bool)
+	for _, link := range gs.AccountLinks {
+		if link.Address.Empty() {
+			return fmt.Errorf("account link of %s has no address", link.DID)
+		}
+		if linked[link.Address.String()] {
+			return fmt.Errorf("duplicate account link of %s", link.Address)
+		}
+		linked[link.Address.String()] = true
+		if !seen[link.DID] {
+			return fmt.Errorf("DID %s linked to %s not found", link.DID, link.Address)
+		}
+	}
+	granted := make(map[string]bool)
+	for _, grant := range gs.Capabilities {
+		if err := validateCapabilityGrant(grant.DID, grant.Grantee, grant.Capability, grant.ExpiresHeight, grant.ExpiresAt); err != nil {
+			return err
+		}
+		key := capabilityGrantSuffix(grant.DID, grant.Grantee, grant.Capability)
+		if granted[key] {
+			return fmt.Errorf("duplicate %s capability grant over %s to %s", grant.Capability, grant.DID, grant.Grantee)
+		}
+		granted[key] = true
+		if !seen[grant.DID] {
+			return fmt.Errorf("DID %s of capability grant not found", grant.DID)
+		}
+	}
+	assets := make(map[string]bool)
+	for _, asset := range gs.LinkedAssets {
+		if err := validateLinkedAsset(asset.DID, asset.Class, asset.AssetID, asset.Owner, asset.ProofTx); err != nil {
+			return err
+		}
+		key := string(linkedAssetKey(asset.DID, asset.Class, asset.AssetID))
+		if assets[key] {
+			return fmt.Errorf("duplicate %s asset %s linked to %s", asset.Class, asset.AssetID, asset.DID)
+		}
+		assets[key] = true
+		if !seen[asset.DID] {
+			return fmt.Errorf("DID %s of linked asset not found", asset.DID)
+		}
+	}
+	deposited := make(map[string]bool)
+	for _, deposit := range gs.Deposits {
+		if deposit.Depositor.Empty() || !deposit.Amount.IsValid() {
+			return fmt.Errorf("deposit of %s needs a depositor and a valid amount", deposit.DID)
+		}
+		if deposited[deposit.DID] {
+			return fmt.Errorf("duplicate deposit of %s", deposit.DID)
+		}
+		deposited[deposit.DID] = true
+		if !seen[deposit.DID] {
+			return fmt.Errorf("DID %s of deposit not found", deposit.DID)
+		}
+	}
+	escrowed := make(map[string]bool)
+	for _, escrow := range gs.FeeEscrows {
+		if escrow.Payer.Empty() || !escrow.Amount.IsValid() {
+			return fmt.Errorf("fee escrow of %s needs a payer and a valid amount", escrow.DID)
+		}
+		if escrowed[escrow.DID] {
+			return fmt.Errorf("duplicate fee escrow of %s", escrow.DID)
+		}
+		escrowed[escrow.DID] = true
+		if !seen[escrow.DID] {
+			return fmt.Errorf("DID %s of fee escrow not found", escrow.DID)
+		}
+	}
+	credentials := make(map[string]bool)
+	for _, entry := range gs.StatusListEntries {
+		if err := validateStatusListEntry(entry.CredentialID, entry.Issuer, entry.StatusListCredential, entry.StatusPurpose); err != nil {
+			return err
+		}
+		if credentials[entry.CredentialID] {
+			return fmt.Errorf("duplicate status list entry of %s", entry.CredentialID)
+		}
+		credentials[entry.CredentialID] = true
+		if !seen[entry.Issuer] {
+			return fmt.Errorf("issuer %s of status list entry not found", entry.Issuer)
+		}
+	}
+	definitions := make(map[string]bool)
+	for _, record := range gs.PresentationDefinitions {
+		if err := validatePresentationDefinition(record.Verifier, record.ID, record.Definition); err != nil {
+			return err
+		}
+		key := string(presentationDefinitionKey(record.Verifier, record.ID))
+		if definitions[key] {
+			return fmt.Errorf("duplicate presentation definition %s of %s", record.ID, record.Verifier)
+		}
+		definitions[key] = true
+		if !seen[record.Verifier] {
+			return fmt.Errorf("verifier %s of presentation definition not found", record.Verifier)
+		}
+	}
+	anchors := make(map[string]bool)
+	for _, anchor := range gs.HashAnchors {
+		if len(anchor.Hash) != HashAnchorLength {
+			return fmt.Errorf("hash anchor of %s must have a %d-byte hash", anchor.DID, HashAnchorLength)
+		}
+		key := string(hashAnchorKey(anchor.Hash, anchor.DID))
+		if anchors[key] {
+			return fmt.Errorf("duplicate anchor of hash %X by %s", anchor.Hash, anchor.DID)
+		}
+		anchors[key] = true
+		if !seen[anchor.DID] {
+			return fmt.Errorf("DID %s of hash anchor not found", anchor.DID)
+		}
+	}
+	roots := make(map[string]bool)
+	for _, root := range gs.CredentialRoots {
+		if len(root.Root) != HashAnchorLength || root.Count == 0 {
+			return fmt.Errorf("credential root of %s must be %d bytes and cover at least one credential", root.Issuer, HashAnchorLength)
+		}
+		key := string(credentialRootKey(root.Issuer, root.Root))
+		if roots[key] {
+			return fmt.Errorf("duplicate credential root %X of %s", root.Root, root.Issuer)
+		}
+		roots[key] = true
+		if !seen[root.Issuer] {
+			return fmt.Errorf("issuer %s of credential root not found", root.Issuer)
+		}
+	}
+	statuses := make(map[string]bool)
+	for _, status := range gs.CredentialStatuses {
+		if status.ID == "" {
+			return fmt.Errorf("credential ID cannot be empty")
+		}
+		if statuses[status.ID] {
+			return fmt.Errorf("duplicate status of credential %s", status.ID)
+		}
+		statuses[status.ID] = true
+		if !seen[status.Issuer] {
+			return fmt.Errorf("issuer %s of credential %s not found", status.Issuer, status.ID)
+		}
+	}
+	resources := make(map[string]bool)
+	latest := make(map[string]bool)
+	for _, res := range gs.Resources {
+		if res.ID == "" || strings.Contains(res.ID, "/") || res.Name == "" || res.ResourceType == "" {
+			return fmt.Errorf("resource %q of %s needs an ID without '/', a name and a type", res.ID, res.CollectionID)
+		}
+		key := string(resourceKey(res.CollectionID, res.ID))
+		if resources[key] {
+			return fmt.Errorf("duplicate resource %s of %s", res.ID, res.CollectionID)
+		}
+		resources[key] = true
+		if res.NextVersionID == "" {
+			chain := string(resourceLatestKey(res.CollectionID, res.Name, res.ResourceType))
+			if latest[chain] {
+				return fmt.Errorf("resource %s of %s has more than one latest version", res.Name, res.CollectionID)
+			}
+			latest[chain] = true
+		}
+		if !seen[res.CollectionID] {
+			return fmt.Errorf("DID %s of resource %s not found", res.CollectionID, res.ID)
+		}
+	}
+	nextChangeID := gs.NextPendingChangeID
+	if nextChangeID == 0 {
+		nextChangeID = 1
+	}
+	changes := make(map[uint64]bool)
+	for _, change := range gs.PendingChanges {
+		if change.ID == 0 || change.ID >= nextChangeID {
+			return fmt.Errorf("pending change %d of %s must have an ID below the next change ID %d", change.ID, change.DID, nextChangeID)
+		}
+		if changes[change.ID] {
+			return fmt.Errorf("duplicate pending change %d", change.ID)
+		}
+		changes[change.ID] = true
+		switch change.Operation {
+		case ChangeOperationDeactivate:
+		case ChangeOperationUpdate:
+			if change.Document.ID != change.DID {
+				return fmt.Errorf("pending change %d proposes a document for %s instead of %s", change.ID, change.Document.ID, change.DID)
+			}
+		default:
+			return fmt.Errorf("pending change %d has unknown operation %q", change.ID, change.Operation)
+		}
+		if !seen[change.DID] {
+			return fmt.Errorf("DID %s of pending change %d not found", change.DID, change.ID)
+		}
+	}
+	guardians := make(map[string][]string)
+	for _, config := range gs.RecoveryConfigs {
+		if _, ok := guardians[config.DID]; ok {
+			return fmt.Errorf("duplicate recovery guardians of %s", config.DID)
+		}
+		if config.Threshold == 0 || int(config.Threshold) > len(config.Guardians) {
+			return fmt.Errorf("recovery threshold of %s must be between 1 and %d", config.DID, len(config.Guardians))
+		}
+		if !seen[config.DID] {
+			return fmt.Errorf("DID %s of recovery guardians not found", config.DID)
+		}
+		for i, g := range config.Guardians {
+			if g == config.DID || containsString(config.Guardians[:i], g) {
+				return fmt.Errorf("guardians of %s must be other, distinct DIDs", config.DID)
+			}
+			if !seen[g] {
+				return fmt.Errorf("guardian %s of %s not found", g, config.DID)
+			}
+		}
+		guardians[config.DID] = config.Guardians
+	}
+	recovering := make(map[string]bool)
+	for _, rec := range gs.PendingRecoveries {
+		if recovering[rec.DID] {
+			return fmt.Errorf("duplicate pending recovery of %s", rec.DID)
+		}
+		recovering[rec.DID] = true
+		if rec.NewPublicKey == "" {
+			return fmt.Errorf("pending recovery of %s has no new key", rec.DID)
+		}
+		g, ok := guardians[rec.DID]
+		if !ok {
+			return fmt.Errorf("pending recovery of %s has no recovery guardians", rec.DID)
+		}
+		for _, a := range rec.Approvals {
+			if !containsString(g, a) {
+				return fmt.Errorf("%s approved the recovery of %s but is not its guardian", a, rec.DID)
+			}
+		}
+	}
+	rotating := make(map[string]bool)
+	for _, rot := range gs.PendingKeyRotations {
+		if rotating[rot.DID] {
+			return fmt.Errorf("duplicate pending key rotation of %s", rot.DID)
+		}
+		rotating[rot.DID] = true
+		if rot.NewPublicKey == "" {
+			return fmt.Errorf("pending key rotation of %s has no new key", rot.DID)
+		}
+		if !seen[rot.DID] {
+			return fmt.Errorf("DID %s of pending key rotation not found", rot.DID)
+		}
+	}
+	return nil
+}
+
+// InitGenesis sets the parameters and stores the DID documents, commitments,
+// account links, capability grants, linked assets, registration deposits,
+// status list entries, presentation definitions, hash anchors, credential
+// roots, credential revocations, resources, pending multi-controller
+// changes, recovery guardians, pending recoveries and pending key rotations
+// from the genesis state. Documents are imported as exported, without the
+// write-time checks of the current params. Linked assets are re-verified by
+// the end blocker like any other link. The coins of the deposits must
+// already be in the module account's genesis balance.
+func InitGenesis(ctx sdk.Context, k Keeper, gs GenesisState) {
+	k.SetParams(ctx, gs.Params)
+	for _, did := range gs.DIDs {
+		k.importDID(ctx, did)
+	}
+	for _, c := range gs.Commitments {
+		k.setDIDCommitment(ctx, c)
+	}
+	for _, link := range gs.AccountLinks {
+		k.setAccountLink(ctx, link)
+	}
+	for _, grant := range gs.Capabilities {
+		k.setCapabilityGrant(ctx, grant)
+	}
+	for _, asset := range gs.LinkedAssets {
+		k.setLinkedAsset(ctx, asset)
+	}
+	for _, deposit := range gs.Deposits {
+		k.setDeposit(ctx, deposit)
+	}
+	for _, escrow := range gs.FeeEscrows {
+		k.setFeeEscrow(ctx, escrow)
+	}
+	for _, entry := range gs.StatusListEntries {
+		k.setStatusListEntry(ctx, entry)
+	}
+	for _, record := range gs.PresentationDefinitions {
+		k.setPresentationDefinition(ctx, record)
+	}
+	for _, anchor := range gs.HashAnchors {
+		k.setHashAnchor(ctx, anchor)
+	}
+	for _, root := range gs.CredentialRoots {
+		k.setCredentialRoot(ctx, root)
+	}
+	for _, status := range gs.CredentialStatuses {
+		k.setCredentialStatus(ctx, status)
+		if status.Revoked {
+			k.updateStats(ctx, func(stats *RegistryStats) { stats.Revocations++ })
+		}
+	}
+	for _, res := range gs.Resources {
+		k.importResource(ctx, res)
+	}
+	for _, change := range gs.PendingChanges {
+		k.setPendingDIDChange(ctx, change)
+	}
+	if gs.NextPendingChangeID != 0 {
+		k.setNextPendingChangeID(ctx, gs.NextPendingChangeID)
+	}
+	for _, config := range gs.RecoveryConfigs {
+		k.setRecoveryConfig(ctx, config)
+	}
+	for _, rec := range gs.PendingRecoveries {
+		k.setPendingRecovery(ctx, rec)
+	}
+	for _, rot := range gs.PendingKeyRotations {
+		k.setPendingKeyRotation(ctx, rot)
+	}
+}
+
+// ExportGenesis exports the parameters, the DID documents, the commitments,
+// the account links, the capability grants, the linked assets, the
+// registration deposits, the status list entries, the presentation
+// definitions, the fee escrows, the hash anchors, the credential roots, the
+// credential revocations, the resources, the pending multi-controller
+// changes, the recovery guardians, the pending recoveries and the pending key
+// rotations in the store.
+func ExportGenesis(ctx sdk.Context, k Keeper) *GenesisState {
+	return &GenesisState{
+		DIDs:                    k.GetAllDIDs(ctx),
+		Params:                  k.GetParams(ctx),
+		Commitments:             k.GetAllDIDCommitments(ctx),
+		AccountLinks:            k.GetAllAccountLinks(ctx),
+		Capabilities:            k.GetAllCapabilityGrants(ctx),
+		LinkedAssets:            k.GetAllLinkedAssets(ctx),
+		Deposits:                k.GetAllDeposits(ctx),
+		StatusListEntries:       k.GetAllStatusListEntries(ctx),
+		PresentationDefinitions: k.GetAllPresentationDefinitions(ctx),
+		FeeEscrows:              k.GetAllFeeEscrows(ctx),
+		HashAnchors:             k.GetAllHashAnchors(ctx),
+		CredentialRoots:         k.GetAllCredentialRoots(ctx),
+		CredentialStatuses:      k.GetAllCredentialStatuses(ctx),
+		Resources:               k.GetAllResources(ctx),
+		PendingChanges:          k.GetAllPendingDIDChanges(ctx),
+		NextPendingChangeID:     k.getNextPendingChangeID(ctx),
+		RecoveryConfigs:         k.GetAllRecoveryConfigs(ctx),
+		PendingRecoveries:       k.GetAllPendingRecoveries(ctx),
+		PendingKeyRotations:     k.GetAllPendingKeyRotations(ctx),
+	}
+}
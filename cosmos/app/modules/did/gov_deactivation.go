@@ -0,0 +1,55 @@
+package did
+
+import (
+	"fmt"
+
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+)
+
+// ProposalTypeDeactivateDID is the governance proposal type that forcibly
+// deactivates a DID.
+const ProposalTypeDeactivateDID = "DeactivateDID"
+
+func init() {
+	govtypes.RegisterProposalType(ProposalTypeDeactivateDID)
+	govtypes.RegisterProposalTypeCodec(&DeactivateDIDProposal{}, "did/DeactivateDIDProposal")
+}
+
+// DeactivateDIDProposal asks governance to deactivate a DID proven fraudulent
+// or compromised, without its controllers' consent. Once the proposal passes
+// the document is deactivated and records the proposal's ID in
+// DeactivatedByProposal.
+type DeactivateDIDProposal struct {
+	Title       string `protobuf:"bytes,1,opt,name=title,proto3" json:"title"`
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description"`
+	DID         string `protobuf:"bytes,3,opt,name=did,proto3" json:"did"`
+	Reason      string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason"`
+}
+
+var _ govtypes.Content = &DeactivateDIDProposal{}
+
+// GetTitle returns the title of the proposal.
+func (p *DeactivateDIDProposal) GetTitle() string { return p.Title }
+
+// GetDescription returns the description of the proposal.
+func (p *DeactivateDIDProposal) GetDescription() string { return p.Description }
+
+// ProposalRoute returns the router key of the proposal's handler.
+func (p *DeactivateDIDProposal) ProposalRoute() string { return RouterKey }
+
+// ProposalType returns the type of the proposal.
+func (p *DeactivateDIDProposal) ProposalType() string { return ProposalTypeDeactivateDID }
+
+// ValidateBasic performs basic validation of DeactivateDIDProposal.
+func (p *DeactivateDIDProposal) ValidateBasic() error {
+	if err := govtypes.ValidateAbstract(p); err != nil {
+		return err
+	}
+	if p.DID == "" {
+		return fmt.Errorf("DID cannot be empty")
+	}
+	if p.Reason == "" {
+		return fmt.Errorf("reason cannot be empty")
+	}
+	return nil
+}
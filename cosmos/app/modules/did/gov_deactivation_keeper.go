@@ -0,0 +1,77 @@
+package did
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+)
+
+// ScheduleGovDeactivation accepts a passed DeactivateDIDProposal. The gov
+// module doesn't pass proposal IDs to proposal handlers, so the DID is
+// deactivated by the AfterProposalVotingPeriodEnded hook that follows the
+// handler, which knows the ID.
+func (k Keeper) ScheduleGovDeactivation(ctx sdk.Context, p DeactivateDIDProposal) error {
+	did, err := k.GetDID(ctx, p.DID)
+	if err != nil {
+		return err
+	}
+	if did.Deactivated {
+		return fmt.Errorf("DID %s is already deactivated", p.DID)
+	}
+	ctx.KVStore(k.storeKey).Set(GovDeactivationPendingKey, k.cdc.MustMarshal(&p))
+	return nil
+}
+
+// applyGovDeactivation deactivates the DID of the proposal accepted by
+// ScheduleGovDeactivation, if any, marking it with proposalID.
+func (k Keeper) applyGovDeactivation(ctx sdk.Context, proposalID uint64) {
+	store := ctx.KVStore(k.storeKey)
+	value := store.Get(GovDeactivationPendingKey)
+	if value == nil {
+		return
+	}
+	store.Delete(GovDeactivationPendingKey)
+	var p DeactivateDIDProposal
+	k.cdc.MustUnmarshal(value, &p)
+
+	did, err := k.GetDID(ctx, p.DID)
+	if err == nil {
+		did.Deactivated = true
+		did.DeactivatedByProposal = proposalID
+		err = k.setDID(ctx, did)
+	}
+	if err != nil {
+		ctx.Logger().Error("failed to deactivate DID by governance", "did", p.DID, "proposal", proposalID, "err", err)
+		return
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent("did_deactivated_by_governance",
+		sdk.NewAttribute("did", p.DID),
+		sdk.NewAttribute("proposal_id", fmt.Sprintf("%d", proposalID)),
+		sdk.NewAttribute("reason", p.Reason),
+	))
+	if err := k.ForfeitFeeEscrow(ctx, p.DID, p.Reason); err != nil {
+		ctx.Logger().Error("failed to forfeit fee escrow of DID deactivated by governance", "did", p.DID, "err", err)
+	}
+}
+
+// GovHooks returns the governance hooks of the DID module, which the gov
+// keeper must be wired with for DeactivateDIDProposals to take effect.
+func (k Keeper) GovHooks() govtypes.GovHooks {
+	return govHooks{k}
+}
+
+type govHooks struct {
+	k Keeper
+}
+
+var _ govtypes.GovHooks = govHooks{}
+
+func (h govHooks) AfterProposalVotingPeriodEnded(ctx sdk.Context, proposalID uint64) {
+	h.k.applyGovDeactivation(ctx, proposalID)
+}
+
+func (govHooks) AfterProposalSubmission(sdk.Context, uint64)              {}
+func (govHooks) AfterProposalDeposit(sdk.Context, uint64, sdk.AccAddress) {}
+func (govHooks) AfterProposalVote(sdk.Context, uint64, sdk.AccAddress)    {}
+func (govHooks) AfterProposalFailedMinDeposit(sdk.Context, uint64)        {}
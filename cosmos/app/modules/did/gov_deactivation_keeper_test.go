@@ -0,0 +1,54 @@
+package did
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestGovDeactivationHook(t *testing.T) {
+	k, ctx, bank, _ := testKeeper(t)
+	params := k.GetParams(ctx)
+	params.CreateDIDFee = sdk.NewCoins(sdk.NewInt64Coin("uaytch", 100))
+	params.DeactivationRefund = sdk.NewCoins(sdk.NewInt64Coin("uaytch", 40))
+	k.SetParams(ctx, params)
+	payer := testAddr("payer")
+	bank.balances[payer.String()] = sdk.NewCoins(sdk.NewInt64Coin("uaytch", 100))
+	did := newTestSigner("spam").document()
+	if err := k.ChargeCreateDIDFee(ctx, payer, did.ID); err != nil {
+		t.Fatal(err)
+	}
+	mustCreate(t, k, ctx, did)
+	hooks := k.GovHooks()
+
+	// A proposal that didn't pass leaves nothing scheduled.
+	hooks.AfterProposalVotingPeriodEnded(ctx, 6)
+	if got, _ := k.GetDID(ctx, did.ID); got.Deactivated {
+		t.Fatal("DID deactivated without a passed proposal")
+	}
+
+	if err := k.ScheduleGovDeactivation(ctx, DeactivateDIDProposal{DID: did.ID, Reason: "spam"}); err != nil {
+		t.Fatal(err)
+	}
+	hooks.AfterProposalVotingPeriodEnded(ctx, 7)
+	got, err := k.GetDID(ctx, did.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Deactivated || got.DeactivatedByProposal != 7 {
+		t.Errorf("after the hook: deactivated %t by proposal %d, want true and 7", got.Deactivated, got.DeactivatedByProposal)
+	}
+	if _, found := k.GetFeeEscrow(ctx, did.ID); found {
+		t.Error("fee escrow of a DID deactivated by governance not forfeited")
+	}
+	if burned := bank.burned.AmountOf("uaytch").Int64(); burned != 40 {
+		t.Errorf("burned = %d, want 40", burned)
+	}
+	if !hasEvent(ctx, "did_deactivated_by_governance") {
+		t.Error("no did_deactivated_by_governance event")
+	}
+
+	if err := k.ScheduleGovDeactivation(ctx, DeactivateDIDProposal{DID: did.ID}); err == nil {
+		t.Error("deactivation of a deactivated DID scheduled")
+	}
+}
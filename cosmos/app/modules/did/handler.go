@@ -4,14 +4,104 @@ import (
 	"fmt"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	"go.opentelemetry.io/otel/attribute"
 )
 
+// NewProposalHandler creates a governance handler for DID proposals.
+func NewProposalHandler(k Keeper) govtypes.Handler {
+	return func(ctx sdk.Context, content govtypes.Content) error {
+		switch c := content.(type) {
+		case *SlashDepositProposal:
+			return k.SlashDeposit(ctx, c.DID, c.Reason)
+		case *DeactivateDIDProposal:
+			return k.ScheduleGovDeactivation(ctx, *c)
+		default:
+			return fmt.Errorf("unrecognized DID proposal content type: %T", c)
+		}
+	}
+}
+
 // NewHandler creates a handler for DID messages.
 func NewHandler(k Keeper) sdk.Handler {
-	return func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+	return func(ctx sdk.Context, msg sdk.Msg) (res *sdk.Result, err error) {
+		ctx, span := startSpan(ctx, "did.Handler", attribute.String("msg.type", sdk.MsgTypeURL(msg)))
+		defer func() {
+			// Handlers emit their events on ctx, but the legacy router
+			// only keeps the events returned in the result.
+			if err == nil {
+				k.recordTxHistory(ctx)
+			}
+			if res != nil && res.Events == nil {
+				res.Events = ctx.EventManager().ABCIEvents()
+			}
+			endSpan(span, err)
+		}()
+		ctx = withAuditMsg(ctx, msg)
+
 		switch msg := msg.(type) {
-		case MsgCreateDID:
-			return handleMsgCreateDID(ctx, k, msg)
+		case *MsgCreateDID:
+			return handleMsgCreateDID(ctx, k, *msg)
+		case *MsgRevokeCredential:
+			return handleMsgRevokeCredential(ctx, k, *msg)
+		case *MsgCreateResource:
+			return handleMsgCreateResource(ctx, k, *msg)
+		case *MsgUpdateDID:
+			return handleMsgUpdateDID(ctx, k, *msg)
+		case *MsgDeactivateDID:
+			return handleMsgDeactivateDID(ctx, k, *msg)
+		case *MsgProposeDIDChange:
+			return handleMsgProposeDIDChange(ctx, k, *msg)
+		case *MsgApproveDIDChange:
+			return handleMsgApproveDIDChange(ctx, k, *msg)
+		case *MsgSponsorDID:
+			return handleMsgSponsorDID(ctx, k, *msg)
+		case *MsgSetGuardians:
+			return handleMsgSetGuardians(ctx, k, *msg)
+		case *MsgRecoverDID:
+			return handleMsgRecoverDID(ctx, k, *msg)
+		case *MsgCancelRecovery:
+			return handleMsgCancelRecovery(ctx, k, *msg)
+		case *MsgRotateKey:
+			return handleMsgRotateKey(ctx, k, *msg)
+		case *MsgCancelKeyRotation:
+			return handleMsgCancelKeyRotation(ctx, k, *msg)
+		case *MsgRenewDID:
+			return handleMsgRenewDID(ctx, k, *msg)
+		case *MsgCreateDIDFromKey:
+			return handleMsgCreateDIDFromKey(ctx, k, *msg)
+		case *MsgCreateDIDBatch:
+			return handleMsgCreateDIDBatch(ctx, k, *msg)
+		case *MsgCommitDID:
+			return handleMsgCommitDID(ctx, k, *msg)
+		case *MsgLinkAccount:
+			return handleMsgLinkAccount(ctx, k, *msg)
+		case *MsgUnlinkAccount:
+			return handleMsgUnlinkAccount(ctx, k, *msg)
+		case *MsgLinkEthereumAccount:
+			return handleMsgLinkEthereumAccount(ctx, k, *msg)
+		case *MsgUnlinkEthereumAccount:
+			return handleMsgUnlinkEthereumAccount(ctx, k, *msg)
+		case *MsgGrantCapability:
+			return handleMsgGrantCapability(ctx, k, *msg)
+		case *MsgRevokeCapability:
+			return handleMsgRevokeCapability(ctx, k, *msg)
+		case *MsgRevokeCredentials:
+			return handleMsgRevokeCredentials(ctx, k, *msg)
+		case *MsgRegisterCredentialStatus:
+			return handleMsgRegisterCredentialStatus(ctx, k, *msg)
+		case *MsgSetPresentationDefinition:
+			return handleMsgSetPresentationDefinition(ctx, k, *msg)
+		case *MsgDeletePresentationDefinition:
+			return handleMsgDeletePresentationDefinition(ctx, k, *msg)
+		case *MsgLinkAsset:
+			return handleMsgLinkAsset(ctx, k, *msg)
+		case *MsgUnlinkAsset:
+			return handleMsgUnlinkAsset(ctx, k, *msg)
+		case *MsgAnchorHash:
+			return handleMsgAnchorHash(ctx, k, *msg)
+		case *MsgAnchorCredentialRoot:
+			return handleMsgAnchorCredentialRoot(ctx, k, *msg)
 		default:
 			return nil, fmt.Errorf("unrecognized DID message type: %T", msg)
 		}
@@ -19,14 +109,509 @@ func NewHandler(k Keeper) sdk.Handler {
 }
 
 func handleMsgCreateDID(ctx sdk.Context, k Keeper, msg MsgCreateDID) (*sdk.Result, error) {
-	did := DIDDocument{
-		ID:               msg.ID,
-		PublicKey:        msg.PublicKey,
-		ServiceEndpoints: msg.ServiceEndpoints,
-		Authentication:   msg.Authentication,
+	did := msg.Document()
+	if err := k.CheckIdentifier(ctx, did.ID); err != nil {
+		return nil, err
+	}
+	if err := k.CheckExpiry(ctx, did); err != nil {
+		return nil, err
+	}
+	if err := k.CheckControllers(ctx, did); err != nil {
+		return nil, err
+	}
+	if err := k.ChargeCreateDIDFee(ctx, msg.Creator, did.ID); err != nil {
+		return nil, err
+	}
+	if err := k.CreateDID(ctx, did); err != nil {
+		return nil, err
+	}
+	if err := k.LockDeposit(ctx, did.ID, msg.Creator); err != nil {
+		return nil, err
+	}
+	return &sdk.Result{}, nil
+}
+
+func handleMsgRevokeCredential(ctx sdk.Context, k Keeper, msg MsgRevokeCredential) (*sdk.Result, error) {
+	if err := k.AuthorizeController(ctx, msg.Issuer, msg.Nonce, msg.Signer, msg.Creator, msg.ProofSignBytes(), msg.Signature); err != nil {
+		return nil, err
+	}
+	if err := k.RevokeCredential(ctx, msg.ID, msg.Issuer, msg.Reason); err != nil {
+		return nil, err
+	}
+	if err := k.IncrementNonce(ctx, msg.Issuer); err != nil {
+		return nil, err
+	}
+	return &sdk.Result{}, nil
+}
+
+func handleMsgRevokeCredentials(ctx sdk.Context, k Keeper, msg MsgRevokeCredentials) (*sdk.Result, error) {
+	if err := k.AuthorizeController(ctx, msg.Issuer, msg.Nonce, msg.Signer, msg.Creator, msg.ProofSignBytes(), msg.Signature); err != nil {
+		return nil, err
+	}
+	if _, err := k.RevokeCredentials(ctx, msg.Issuer, msg.CredentialIDs, msg.StatusListCredential, msg.StatusListIndexes, msg.Reason); err != nil {
+		return nil, err
+	}
+	if err := k.IncrementNonce(ctx, msg.Issuer); err != nil {
+		return nil, err
+	}
+	return &sdk.Result{}, nil
+}
+
+func handleMsgRegisterCredentialStatus(ctx sdk.Context, k Keeper, msg MsgRegisterCredentialStatus) (*sdk.Result, error) {
+	if err := k.AuthorizeController(ctx, msg.Issuer, msg.Nonce, msg.Signer, msg.Creator, msg.ProofSignBytes(), msg.Signature); err != nil {
+		return nil, err
+	}
+	entry := StatusListEntry{
+		CredentialID:         msg.CredentialID,
+		Issuer:               msg.Issuer,
+		StatusListCredential: msg.StatusListCredential,
+		StatusListIndex:      msg.StatusListIndex,
+		StatusPurpose:        msg.StatusPurpose,
+	}
+	if err := k.RegisterStatusListEntry(ctx, entry); err != nil {
+		return nil, err
+	}
+	if err := k.IncrementNonce(ctx, msg.Issuer); err != nil {
+		return nil, err
+	}
+	return &sdk.Result{}, nil
+}
+
+func handleMsgCreateResource(ctx sdk.Context, k Keeper, msg MsgCreateResource) (*sdk.Result, error) {
+	if err := k.AuthorizeController(ctx, msg.CollectionID, msg.Nonce, msg.Signer, msg.Creator, msg.ProofSignBytes(), msg.Signature); err != nil {
+		return nil, err
+	}
+	res := Resource{
+		CollectionID:  msg.CollectionID,
+		ID:            msg.ID,
+		Name:          msg.Name,
+		ResourceType:  msg.ResourceType,
+		MediaType:     msg.MediaType,
+		Version:       msg.Version,
+		Data:          msg.Data,
+		ContentCID:    msg.ContentCID,
+		Compatibility: msg.Compatibility,
+	}
+	if err := k.CreateResource(ctx, res); err != nil {
+		return nil, err
+	}
+	if err := k.IncrementNonce(ctx, msg.CollectionID); err != nil {
+		return nil, err
+	}
+	return &sdk.Result{}, nil
+}
+
+func handleMsgUpdateDID(ctx sdk.Context, k Keeper, msg MsgUpdateDID) (*sdk.Result, error) {
+	if err := k.AuthorizeUpdate(ctx, msg); err != nil {
+		return nil, err
+	}
+	if err := k.CheckRotationLock(ctx, msg.Document()); err != nil {
+		return nil, err
+	}
+	if err := k.CheckControllers(ctx, msg.Document()); err != nil {
+		return nil, err
+	}
+	if err := k.UpdateDID(ctx, msg.Document()); err != nil {
+		return nil, err
+	}
+	return &sdk.Result{}, nil
+}
+
+func handleMsgDeactivateDID(ctx sdk.Context, k Keeper, msg MsgDeactivateDID) (*sdk.Result, error) {
+	if err := k.AuthorizeDeactivation(ctx, msg); err != nil {
+		return nil, err
+	}
+	if err := k.DeactivateDID(ctx, msg.ID); err != nil {
+		return nil, err
+	}
+	if err := k.RefundFeeEscrow(ctx, msg.ID); err != nil {
+		return nil, err
+	}
+	return &sdk.Result{}, nil
+}
+
+func handleMsgProposeDIDChange(ctx sdk.Context, k Keeper, msg MsgProposeDIDChange) (*sdk.Result, error) {
+	if _, err := proposeDIDChange(ctx, k, msg); err != nil {
+		return nil, err
+	}
+	return &sdk.Result{}, nil
+}
+
+// proposeDIDChange records the proposed change and emits its ID so the other
+// controllers know what to approve.
+func proposeDIDChange(ctx sdk.Context, k Keeper, msg MsgProposeDIDChange) (uint64, error) {
+	if msg.Operation == ChangeOperationUpdate {
+		if err := k.CheckControllers(ctx, msg.Document); err != nil {
+			return 0, err
+		}
+	}
+	change := PendingDIDChange{
+		DID:       msg.DID,
+		Operation: msg.Operation,
+		Document:  msg.Document,
+	}
+	id, err := k.ProposeDIDChange(ctx, change, msg.Nonce, msg.Signer, msg.Creator, msg.ProofSignBytes(), msg.Signature)
+	if err != nil {
+		return 0, err
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent("did_change_proposed",
+		sdk.NewAttribute("did", msg.DID),
+		sdk.NewAttribute("change_id", fmt.Sprintf("%d", id)),
+	))
+	return id, nil
+}
+
+func handleMsgApproveDIDChange(ctx sdk.Context, k Keeper, msg MsgApproveDIDChange) (*sdk.Result, error) {
+	if err := k.VoteDIDChange(ctx, msg.ChangeID, msg.Reject, msg.Signer, msg.Creator, msg.ProofSignBytes(), msg.Signature); err != nil {
+		return nil, err
+	}
+	return &sdk.Result{}, nil
+}
+
+func handleMsgSponsorDID(ctx sdk.Context, k Keeper, msg MsgSponsorDID) (*sdk.Result, error) {
+	if err := k.CheckIdentifier(ctx, msg.Document.ID); err != nil {
+		return nil, err
+	}
+	if err := k.CheckExpiry(ctx, msg.Document); err != nil {
+		return nil, err
+	}
+	if err := k.CheckControllers(ctx, msg.Document); err != nil {
+		return nil, err
+	}
+	if err := k.ChargeCreateDIDFee(ctx, msg.Sponsor, msg.Document.ID); err != nil {
+		return nil, err
+	}
+	if err := k.CreateDID(ctx, msg.Document); err != nil {
+		return nil, err
+	}
+	if err := k.LockDeposit(ctx, msg.Document.ID, msg.Sponsor); err != nil {
+		return nil, err
+	}
+	return &sdk.Result{}, nil
+}
+
+func handleMsgSetGuardians(ctx sdk.Context, k Keeper, msg MsgSetGuardians) (*sdk.Result, error) {
+	if err := k.AuthorizeController(ctx, msg.DID, msg.Nonce, msg.Signer, msg.Creator, msg.ProofSignBytes(), msg.Signature); err != nil {
+		return nil, err
+	}
+	config := RecoveryConfig{DID: msg.DID, Guardians: msg.Guardians, Threshold: msg.Threshold}
+	if err := k.SetGuardians(ctx, config); err != nil {
+		return nil, err
+	}
+	if err := k.IncrementNonce(ctx, msg.DID); err != nil {
+		return nil, err
+	}
+	return &sdk.Result{}, nil
+}
+
+func handleMsgRecoverDID(ctx sdk.Context, k Keeper, msg MsgRecoverDID) (*sdk.Result, error) {
+	if err := k.RecoverDID(ctx, msg.DID, msg.Nonce, msg.NewPublicKey, msg.Guardian, msg.ProofSignBytes(), msg.Signature); err != nil {
+		return nil, err
+	}
+	return &sdk.Result{}, nil
+}
+
+func handleMsgCancelRecovery(ctx sdk.Context, k Keeper, msg MsgCancelRecovery) (*sdk.Result, error) {
+	if err := k.CancelRecovery(ctx, msg.DID, msg.Nonce, msg.Signer, msg.Creator, msg.ProofSignBytes(), msg.Signature); err != nil {
+		return nil, err
+	}
+	return &sdk.Result{}, nil
+}
+
+func handleMsgRotateKey(ctx sdk.Context, k Keeper, msg MsgRotateKey) (*sdk.Result, error) {
+	if err := k.AuthorizeController(ctx, msg.DID, msg.Nonce, msg.Signer, msg.Creator, msg.ProofSignBytes(), msg.Signature); err != nil {
+		return nil, err
+	}
+	if err := k.RotateKey(ctx, msg.DID, msg.NewPublicKey, msg.RotationDelay); err != nil {
+		return nil, err
+	}
+	return &sdk.Result{}, nil
+}
+
+func handleMsgCancelKeyRotation(ctx sdk.Context, k Keeper, msg MsgCancelKeyRotation) (*sdk.Result, error) {
+	if err := k.CancelKeyRotation(ctx, msg.DID, msg.Nonce, msg.ProofSignBytes(), msg.Signature); err != nil {
+		return nil, err
+	}
+	return &sdk.Result{}, nil
+}
+
+func handleMsgRenewDID(ctx sdk.Context, k Keeper, msg MsgRenewDID) (*sdk.Result, error) {
+	if err := k.AuthorizeController(ctx, msg.DID, msg.Nonce, msg.Signer, msg.Creator, msg.ProofSignBytes(), msg.Signature); err != nil {
+		return nil, err
+	}
+	if err := k.RenewDID(ctx, msg.DID, msg.ExpiresAt); err != nil {
+		return nil, err
+	}
+	return &sdk.Result{}, nil
+}
+
+func handleMsgCreateDIDFromKey(ctx sdk.Context, k Keeper, msg MsgCreateDIDFromKey) (*sdk.Result, error) {
+	did, err := msg.NetworkDocument(k.GetParams(ctx).Network)
+	if err != nil {
+		return nil, err
+	}
+	if err := k.CheckIdentifier(ctx, did.ID); err != nil {
+		return nil, err
+	}
+	if err := k.ChargeCreateDIDFee(ctx, msg.Creator, did.ID); err != nil {
+		return nil, err
 	}
 	if err := k.CreateDID(ctx, did); err != nil {
 		return nil, err
 	}
+	if err := k.LockDeposit(ctx, did.ID, msg.Creator); err != nil {
+		return nil, err
+	}
+	return &sdk.Result{}, nil
+}
+
+func handleMsgCreateDIDBatch(ctx sdk.Context, k Keeper, msg MsgCreateDIDBatch) (*sdk.Result, error) {
+	creates := msg.CreateMsgs()
+	ids := make([]string, len(creates))
+	for i, create := range creates {
+		ids[i] = create.ID
+	}
+	if err := k.ChargeCreateDIDFees(ctx, msg.Creator, ids); err != nil {
+		return nil, err
+	}
+	for i, create := range creates {
+		did := create.Document()
+		if err := k.CheckIdentifier(ctx, did.ID); err != nil {
+			return nil, fmt.Errorf("document %d: %w", i, err)
+		}
+		if err := k.CheckExpiry(ctx, did); err != nil {
+			return nil, fmt.Errorf("document %d: %w", i, err)
+		}
+		if err := k.CheckControllers(ctx, did); err != nil {
+			return nil, fmt.Errorf("document %d: %w", i, err)
+		}
+		if err := k.CreateDID(ctx, did); err != nil {
+			return nil, fmt.Errorf("document %d: %w", i, err)
+		}
+		if err := k.LockDeposit(ctx, did.ID, msg.Creator); err != nil {
+			return nil, fmt.Errorf("document %d: %w", i, err)
+		}
+	}
+	return &sdk.Result{}, nil
+}
+
+// handleMsgCommitDID charges the creation fee and locks the registration
+// deposit when a commitment first registers its DID; replacing a commitment
+// is free, like updating a document.
+func handleMsgCommitDID(ctx sdk.Context, k Keeper, msg MsgCommitDID) (*sdk.Result, error) {
+	if err := k.CheckIdentifier(ctx, msg.ID); err != nil {
+		return nil, err
+	}
+	if _, err := k.GetDIDCommitment(ctx, msg.ID); err != nil {
+		if err := k.ChargeCreateDIDFee(ctx, msg.Creator, msg.ID); err != nil {
+			return nil, err
+		}
+		if err := k.LockDeposit(ctx, msg.ID, msg.Creator); err != nil {
+			return nil, err
+		}
+	}
+	if err := k.CommitDID(ctx, msg.ID, msg.Commitment, msg.Creator); err != nil {
+		return nil, err
+	}
+	return &sdk.Result{}, nil
+}
+
+func handleMsgLinkAccount(ctx sdk.Context, k Keeper, msg MsgLinkAccount) (*sdk.Result, error) {
+	if err := k.AuthorizeController(ctx, msg.DID, msg.Nonce, msg.Signer, msg.Creator, msg.ProofSignBytes(), msg.Signature); err != nil {
+		return nil, err
+	}
+	if err := k.LinkAccount(ctx, msg.DID, msg.Account); err != nil {
+		return nil, err
+	}
+	if err := k.IncrementNonce(ctx, msg.DID); err != nil {
+		return nil, err
+	}
+	return &sdk.Result{}, nil
+}
+
+// handleMsgUnlinkAccount lets an account drop its own link without the DID's
+// consent; anyone else needs a controller of the linked DID.
+func handleMsgUnlinkAccount(ctx sdk.Context, k Keeper, msg MsgUnlinkAccount) (*sdk.Result, error) {
+	if msg.Signer == "" {
+		if !msg.Account.Equals(msg.Creator) {
+			return nil, fmt.Errorf("account %s did not sign the transaction", msg.Account)
+		}
+		if err := k.UnlinkAccount(ctx, msg.Account); err != nil {
+			return nil, err
+		}
+		return &sdk.Result{}, nil
+	}
+	id, found := k.DIDByAddress(ctx, msg.Account)
+	if !found {
+		return nil, fmt.Errorf("account %s is not linked to a DID", msg.Account)
+	}
+	if err := k.AuthorizeController(ctx, id, msg.Nonce, msg.Signer, msg.Creator, msg.ProofSignBytes(), msg.Signature); err != nil {
+		return nil, err
+	}
+	if err := k.UnlinkAccount(ctx, msg.Account); err != nil {
+		return nil, err
+	}
+	if err := k.IncrementNonce(ctx, id); err != nil {
+		return nil, err
+	}
+	return &sdk.Result{}, nil
+}
+
+func handleMsgLinkEthereumAccount(ctx sdk.Context, k Keeper, msg MsgLinkEthereumAccount) (*sdk.Result, error) {
+	addr, err := ParseEthereumAddress(msg.Address)
+	if err != nil {
+		return nil, err
+	}
+	if err := k.AuthorizeController(ctx, msg.DID, msg.Nonce, msg.Signer, msg.Creator, msg.ProofSignBytes(), msg.Signature); err != nil {
+		return nil, err
+	}
+	if err := VerifyEthereumLinkProof(msg.ProofType, msg.DID, addr, msg.ChainID, msg.Nonce, msg.AccountSignature); err != nil {
+		return nil, err
+	}
+	if err := k.LinkEthereumAccount(ctx, msg.DID, msg.ChainID, addr); err != nil {
+		return nil, err
+	}
+	return &sdk.Result{}, nil
+}
+
+func handleMsgUnlinkEthereumAccount(ctx sdk.Context, k Keeper, msg MsgUnlinkEthereumAccount) (*sdk.Result, error) {
+	addr, err := ParseEthereumAddress(msg.Address)
+	if err != nil {
+		return nil, err
+	}
+	if err := k.AuthorizeController(ctx, msg.DID, msg.Nonce, msg.Signer, msg.Creator, msg.ProofSignBytes(), msg.Signature); err != nil {
+		return nil, err
+	}
+	if err := k.UnlinkEthereumAccount(ctx, msg.DID, msg.ChainID, addr); err != nil {
+		return nil, err
+	}
+	return &sdk.Result{}, nil
+}
+
+func handleMsgGrantCapability(ctx sdk.Context, k Keeper, msg MsgGrantCapability) (*sdk.Result, error) {
+	if err := k.AuthorizeController(ctx, msg.DID, msg.Nonce, msg.Signer, msg.Creator, msg.ProofSignBytes(), msg.Signature); err != nil {
+		return nil, err
+	}
+	grant := CapabilityGrant{
+		DID:           msg.DID,
+		Grantee:       msg.Grantee,
+		Capability:    msg.Capability,
+		ExpiresHeight: msg.ExpiresHeight,
+		ExpiresAt:     msg.ExpiresAt,
+	}
+	if err := k.GrantCapability(ctx, grant); err != nil {
+		return nil, err
+	}
+	if err := k.IncrementNonce(ctx, msg.DID); err != nil {
+		return nil, err
+	}
+	return &sdk.Result{}, nil
+}
+
+func handleMsgRevokeCapability(ctx sdk.Context, k Keeper, msg MsgRevokeCapability) (*sdk.Result, error) {
+	if err := k.AuthorizeController(ctx, msg.DID, msg.Nonce, msg.Signer, msg.Creator, msg.ProofSignBytes(), msg.Signature); err != nil {
+		return nil, err
+	}
+	if err := k.RevokeCapability(ctx, msg.DID, msg.Grantee, msg.Capability); err != nil {
+		return nil, err
+	}
+	if err := k.IncrementNonce(ctx, msg.DID); err != nil {
+		return nil, err
+	}
+	return &sdk.Result{}, nil
+}
+
+func handleMsgSetPresentationDefinition(ctx sdk.Context, k Keeper, msg MsgSetPresentationDefinition) (*sdk.Result, error) {
+	if err := k.AuthorizeController(ctx, msg.Verifier, msg.Nonce, msg.Signer, msg.Creator, msg.ProofSignBytes(), msg.Signature); err != nil {
+		return nil, err
+	}
+	record := PresentationDefinitionRecord{
+		Verifier:   msg.Verifier,
+		ID:         msg.ID,
+		Definition: msg.Definition,
+	}
+	if err := k.SetPresentationDefinition(ctx, record); err != nil {
+		return nil, err
+	}
+	if err := k.IncrementNonce(ctx, msg.Verifier); err != nil {
+		return nil, err
+	}
+	return &sdk.Result{}, nil
+}
+
+func handleMsgDeletePresentationDefinition(ctx sdk.Context, k Keeper, msg MsgDeletePresentationDefinition) (*sdk.Result, error) {
+	if err := k.AuthorizeController(ctx, msg.Verifier, msg.Nonce, msg.Signer, msg.Creator, msg.ProofSignBytes(), msg.Signature); err != nil {
+		return nil, err
+	}
+	if err := k.DeletePresentationDefinition(ctx, msg.Verifier, msg.ID); err != nil {
+		return nil, err
+	}
+	if err := k.IncrementNonce(ctx, msg.Verifier); err != nil {
+		return nil, err
+	}
+	return &sdk.Result{}, nil
+}
+
+func handleMsgAnchorHash(ctx sdk.Context, k Keeper, msg MsgAnchorHash) (*sdk.Result, error) {
+	if err := k.AuthorizeController(ctx, msg.DID, msg.Nonce, msg.Signer, msg.Creator, msg.ProofSignBytes(), msg.Signature); err != nil {
+		return nil, err
+	}
+	if err := k.AnchorHash(ctx, msg.DID, msg.Hash, msg.Label); err != nil {
+		return nil, err
+	}
+	if err := k.IncrementNonce(ctx, msg.DID); err != nil {
+		return nil, err
+	}
+	return &sdk.Result{}, nil
+}
+
+func handleMsgAnchorCredentialRoot(ctx sdk.Context, k Keeper, msg MsgAnchorCredentialRoot) (*sdk.Result, error) {
+	if err := k.AuthorizeController(ctx, msg.Issuer, msg.Nonce, msg.Signer, msg.Creator, msg.ProofSignBytes(), msg.Signature); err != nil {
+		return nil, err
+	}
+	root := CredentialRoot{
+		Issuer: msg.Issuer,
+		Root:   msg.Root,
+		Count:  msg.Count,
+	}
+	if err := k.AnchorCredentialRoot(ctx, root); err != nil {
+		return nil, err
+	}
+	if err := k.IncrementNonce(ctx, msg.Issuer); err != nil {
+		return nil, err
+	}
+	return &sdk.Result{}, nil
+}
+
+func handleMsgLinkAsset(ctx sdk.Context, k Keeper, msg MsgLinkAsset) (*sdk.Result, error) {
+	if err := k.AuthorizeController(ctx, msg.DID, msg.Nonce, msg.Signer, msg.Creator, msg.ProofSignBytes(), msg.Signature); err != nil {
+		return nil, err
+	}
+	asset := LinkedAsset{
+		DID:     msg.DID,
+		Class:   msg.Class,
+		AssetID: msg.AssetID,
+		Owner:   msg.Owner,
+		ProofTx: msg.ProofTx,
+	}
+	if err := k.LinkAsset(ctx, asset); err != nil {
+		return nil, err
+	}
+	if err := k.IncrementNonce(ctx, msg.DID); err != nil {
+		return nil, err
+	}
+	return &sdk.Result{}, nil
+}
+
+func handleMsgUnlinkAsset(ctx sdk.Context, k Keeper, msg MsgUnlinkAsset) (*sdk.Result, error) {
+	if err := k.AuthorizeController(ctx, msg.DID, msg.Nonce, msg.Signer, msg.Creator, msg.ProofSignBytes(), msg.Signature); err != nil {
+		return nil, err
+	}
+	if err := k.UnlinkAsset(ctx, msg.DID, msg.Class, msg.AssetID); err != nil {
+		return nil, err
+	}
+	if err := k.IncrementNonce(ctx, msg.DID); err != nil {
+		return nil, err
+	}
 	return &sdk.Result{}, nil
 }
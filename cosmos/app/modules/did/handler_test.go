@@ -0,0 +1,87 @@
+package did
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+)
+
+func TestHandlerDIDLifecycle(t *testing.T) {
+	k, ctx, bank, _ := testKeeper(t)
+	params := k.GetParams(ctx)
+	params.CreateDIDFee = sdk.NewCoins(sdk.NewInt64Coin("uaytch", 100))
+	params.DeactivationRefund = sdk.NewCoins(sdk.NewInt64Coin("uaytch", 40))
+	params.RegistrationDeposit = sdk.NewCoins(sdk.NewInt64Coin("uaytch", 50))
+	k.SetParams(ctx, params)
+	owner := testAddr("owner")
+	bank.balances[owner.String()] = sdk.NewCoins(sdk.NewInt64Coin("uaytch", 1000))
+	handler := NewHandler(k)
+	doc := newTestSigner("alice").document(owner.String())
+
+	create := &MsgCreateDID{
+		ID:             doc.ID,
+		PublicKey:      doc.PublicKey,
+		Authentication: doc.Authentication,
+		Controller:     doc.Controller,
+		Creator:        owner,
+	}
+	res, err := handler(ctx, create)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Events) == 0 {
+		t.Error("create returned no events")
+	}
+	if got := bank.balance(owner, "uaytch"); got != 850 {
+		t.Errorf("owner balance after create = %d, want 850", got)
+	}
+	if _, found := k.GetDeposit(ctx, doc.ID); !found {
+		t.Error("no deposit locked")
+	}
+	update := &MsgUpdateDID{
+		ID:               doc.ID,
+		PublicKey:        doc.PublicKey,
+		Authentication:   doc.Authentication,
+		ServiceEndpoints: []string{"https://example.com/alice"},
+		Controller:       doc.Controller,
+		Nonce:            0,
+		Signer:           owner.String(),
+		Creator:          owner,
+	}
+	if _, err := handler(ctx, update); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := handler(ctx, update); err == nil {
+		t.Error("update replayed with a used nonce")
+	}
+	update.Creator = testAddr("other")
+	update.Nonce = 1
+	if _, err := handler(ctx, update); err == nil {
+		t.Error("update by an account that isn't a controller accepted")
+	}
+
+	deactivate := &MsgDeactivateDID{ID: doc.ID, Nonce: 1, Signer: owner.String(), Creator: owner}
+	if _, err := handler(ctx, deactivate); err != nil {
+		t.Fatal(err)
+	}
+	got, err := k.GetDID(ctx, doc.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Deactivated || len(got.ServiceEndpoints) != 1 {
+		t.Errorf("after deactivation: %+v", got)
+	}
+	if got := bank.balance(owner, "uaytch"); got != 890 {
+		t.Errorf("owner balance after deactivation = %d, want 890 with the escrow refunded", got)
+	}
+	if got := bank.balance(authtypes.NewModuleAddress(ModuleName), "uaytch"); got != 50 {
+		t.Errorf("module account = %d, want the 50 deposit", got)
+	}
+
+	// Failed messages leave the balances of the mock bank as they are, so
+	// this runs last.
+	if _, err := handler(ctx, create); err == nil {
+		t.Error("DID created twice")
+	}
+}
@@ -0,0 +1,94 @@
+package did
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// HashAnchorLength is the length of an anchored hash, that of a SHA-256 or
+// Keccak-256 digest.
+const HashAnchorLength = 32
+
+// HashAnchor records that a DID anchored a hash at a block, proving that the
+// hashed document existed by then without putting it or its hash in the DID
+// document. Several DIDs may anchor the same hash, each once.
+type HashAnchor struct {
+	Hash   []byte `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash"`
+	DID    string `protobuf:"bytes,2,opt,name=did,proto3" json:"did"`
+	Label  string `protobuf:"bytes,3,opt,name=label,proto3" json:"label,omitempty"`
+	Height int64  `protobuf:"varint,4,opt,name=height,proto3" json:"height"`
+	Time   int64  `protobuf:"varint,5,opt,name=time,proto3" json:"time"`
+}
+
+// parseAnchorHash decodes a hex-encoded anchored hash.
+func parseAnchorHash(s string) ([]byte, error) {
+	hash, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hash %q: %w", s, err)
+	}
+	if len(hash) != HashAnchorLength {
+		return nil, fmt.Errorf("hash must be %d bytes, got %d", HashAnchorLength, len(hash))
+	}
+	return hash, nil
+}
+
+// MsgAnchorHash timestamps a 32-byte hash, such as the digest of a notarized
+// document, under a DID with an optional label. A controller of DID
+// authorizes it like a MsgUpdateDID.
+type MsgAnchorHash struct {
+	DID       string         `protobuf:"bytes,1,opt,name=did,proto3" json:"did"`
+	Hash      []byte         `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash"`
+	Label     string         `protobuf:"bytes,3,opt,name=label,proto3" json:"label,omitempty"`
+	Nonce     uint64         `protobuf:"varint,4,opt,name=nonce,proto3" json:"nonce"`
+	Signer    string         `protobuf:"bytes,5,opt,name=signer,proto3" json:"signer"`
+	Signature []byte         `protobuf:"bytes,6,opt,name=signature,proto3" json:"signature,omitempty"`
+	Creator   sdk.AccAddress `protobuf:"bytes,7,opt,name=creator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"creator"`
+}
+
+// ValidateBasic performs basic validation of MsgAnchorHash.
+func (msg MsgAnchorHash) ValidateBasic() error {
+	if err := validateChecksums(msg); err != nil {
+		return err
+	}
+	if msg.DID == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("DID cannot be empty")
+	}
+	if len(msg.Hash) != HashAnchorLength {
+		return sdkerrors.ErrUnknownRequest.Wrapf("Hash must be %d bytes", HashAnchorLength)
+	}
+	if msg.Signer == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Signer cannot be empty")
+	}
+	return nil
+}
+
+// ProofSignBytes returns the bytes a controller DID signs to authorize the
+// anchor.
+func (msg MsgAnchorHash) ProofSignBytes() []byte {
+	msg.Signature = nil
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// Route returns the message route.
+func (msg MsgAnchorHash) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgAnchorHash) Type() string { return "anchor_hash" }
+
+// GetSignBytes returns the canonical bytes to sign over.
+func (msg MsgAnchorHash) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners returns the account that must sign the message.
+func (msg MsgAnchorHash) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
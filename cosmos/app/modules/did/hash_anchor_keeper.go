@@ -0,0 +1,69 @@
+package did
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// AnchorHash records that an active DID anchored a hash at the current block.
+// Labels count against MaxFieldLength. A DID can anchor a hash only once, so
+// the first anchor keeps its timestamp. Callers must have authorized the
+// anchor.
+func (k Keeper) AnchorHash(ctx sdk.Context, did string, hash []byte, label string) error {
+	doc, err := k.GetDID(ctx, did)
+	if err != nil {
+		return err
+	}
+	if doc.Deactivated {
+		return fmt.Errorf("DID %s is deactivated", did)
+	}
+	if len(hash) != HashAnchorLength {
+		return fmt.Errorf("hash must be %d bytes", HashAnchorLength)
+	}
+	if max := k.GetParams(ctx).MaxFieldLength; uint64(len(label)) > max {
+		return fmt.Errorf("label exceeds %d bytes", max)
+	}
+	if ctx.KVStore(k.storeKey).Has(hashAnchorKey(hash, did)) {
+		return fmt.Errorf("%s has already anchored hash %X", did, hash)
+	}
+	k.setHashAnchor(ctx, HashAnchor{
+		Hash:   hash,
+		DID:    did,
+		Label:  label,
+		Height: ctx.BlockHeight(),
+		Time:   ctx.BlockTime().Unix(),
+	})
+	ctx.EventManager().EmitEvent(sdk.NewEvent("hash_anchored",
+		sdk.NewAttribute("did", did),
+		sdk.NewAttribute("hash", hex.EncodeToString(hash)),
+		sdk.NewAttribute("label", label),
+	))
+	return nil
+}
+
+// GetHashAnchors returns the anchors of a hash, one per DID that anchored it.
+func (k Keeper) GetHashAnchors(ctx sdk.Context, hash []byte) []HashAnchor {
+	return k.hashAnchors(ctx, hashAnchorsPrefix(hash))
+}
+
+// GetAllHashAnchors returns every hash anchor in the store.
+func (k Keeper) GetAllHashAnchors(ctx sdk.Context) []HashAnchor {
+	return k.hashAnchors(ctx, HashAnchorPrefix)
+}
+
+func (k Keeper) hashAnchors(ctx sdk.Context, prefix []byte) []HashAnchor {
+	var anchors []HashAnchor
+	iteratePrefix(ctx.KVStore(k.storeKey), prefix, func(_, value []byte) bool {
+		var anchor HashAnchor
+		k.cdc.MustUnmarshal(value, &anchor)
+		anchors = append(anchors, anchor)
+		return false
+	})
+	return anchors
+}
+
+func (k Keeper) setHashAnchor(ctx sdk.Context, anchor HashAnchor) {
+	ctx.KVStore(k.storeKey).Set(hashAnchorKey(anchor.Hash, anchor.DID), k.cdc.MustMarshal(&anchor))
+}
@@ -0,0 +1,57 @@
+package did
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/client"
+)
+
+// Historical resolution reads a document from the node's state at a past
+// height, so it needs a node that kept that state: an archive node, or one
+// whose pruning settings still cover the height. The audit log, which is
+// never pruned, maps versions to the heights that wrote them.
+
+// ResolveAt returns the document id as it existed after the block at height.
+func ResolveAt(clientCtx client.Context, id string, height int64) (DIDDocument, error) {
+	res, _, err := clientCtx.WithHeight(height).QueryWithData(fmt.Sprintf("custom/did/%s", id), nil)
+	if err != nil {
+		return DIDDocument{}, fmt.Errorf("resolve %s at height %d: %w (the node may have pruned that state; query an archive node)", id, height, err)
+	}
+	var doc DIDDocument
+	if err := json.Unmarshal(res, &doc); err != nil {
+		return DIDDocument{}, err
+	}
+	return doc, nil
+}
+
+// VersionHeight returns the height at which version of id was written,
+// according to the current audit log.
+func VersionHeight(clientCtx client.Context, id string, version uint64) (int64, error) {
+	history, err := queryAuditLog(clientCtx.WithHeight(0), id)
+	if err != nil {
+		return 0, err
+	}
+	for _, entry := range history {
+		if entry.Version == version {
+			return entry.Height, nil
+		}
+	}
+	return 0, fmt.Errorf("%s has no version %d", id, version)
+}
+
+// NextVersionID returns the version that replaced version of id, or "" if
+// version is the current one.
+func NextVersionID(clientCtx client.Context, id string, version uint64) (string, error) {
+	history, err := queryAuditLog(clientCtx.WithHeight(0), id)
+	if err != nil {
+		return "", err
+	}
+	for _, entry := range history {
+		if entry.Version > version {
+			return strconv.FormatUint(entry.Version, 10), nil
+		}
+	}
+	return "", nil
+}
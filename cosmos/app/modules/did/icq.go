@@ -0,0 +1,152 @@
+package did
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/codec"
+	commitmenttypes "github.com/cosmos/ibc-go/v4/modules/core/23-commitment/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	tmcrypto "github.com/tendermint/tendermint/proto/tendermint/crypto"
+)
+
+// Interchain queries (ICQ) read the DID store directly: a relayer runs a
+// proven ABCI query against ICQStorePath with one of the store keys below and
+// submits the value and ICS-23 proof to the consumer chain, which checks it
+// against a light client of this chain with VerifyICQResult.
+const ICQStorePath = "store/" + StoreKey + "/key"
+
+// DIDStoreKey returns the key a DID document is stored under.
+func DIDStoreKey(id string) []byte {
+	return didKey(id)
+}
+
+// CredentialStatusStoreKey returns the key a credential's revocation record
+// is stored under.
+func CredentialStatusStoreKey(id string) []byte {
+	return credentialStatusKey(id)
+}
+
+// ICQResult is a proven read of a single key in the DID store. An empty Value
+// means the key is absent and ProofOps prove non-membership.
+type ICQResult struct {
+	Key      []byte             `json:"key"`
+	Value    []byte             `json:"value"`
+	Height   int64              `json:"height"`
+	ProofOps *tmcrypto.ProofOps `json:"proof_ops"`
+}
+
+// QueryICQ performs a proven query of key against the DID store at the given
+// height (0 for latest).
+func QueryICQ(clientCtx client.Context, key []byte, height int64) (ICQResult, error) {
+	res, err := clientCtx.QueryABCI(abci.RequestQuery{
+		Path:   ICQStorePath,
+		Data:   key,
+		Height: height,
+		Prove:  true,
+	})
+	if err != nil {
+		return ICQResult{}, err
+	}
+	if res.ProofOps == nil {
+		return ICQResult{}, fmt.Errorf("node returned no proof for height %d", res.Height)
+	}
+	return ICQResult{Key: key, Value: res.Value, Height: res.Height, ProofOps: res.ProofOps}, nil
+}
+
+// VerifyICQResult checks res against appHash, which must be the app hash of
+// the block at res.Height+1 (the header that commits to the state at
+// res.Height).
+func VerifyICQResult(appHash []byte, res ICQResult) error {
+	proof, err := commitmenttypes.ConvertProofs(res.ProofOps)
+	if err != nil {
+		return err
+	}
+	root := commitmenttypes.NewMerkleRoot(appHash)
+	path := commitmenttypes.NewMerklePath(StoreKey, string(res.Key))
+	if len(res.Value) == 0 {
+		return proof.VerifyNonMembership(commitmenttypes.GetSDKSpecs(), root, path)
+	}
+	return proof.VerifyMembership(commitmenttypes.GetSDKSpecs(), root, path, res.Value)
+}
+
+// DecodeICQDocument decodes a proven DID store value into a DID document.
+func DecodeICQDocument(cdc *codec.LegacyAmino, value []byte) (DIDDocument, error) {
+	var did DIDDocument
+	if err := cdc.Unmarshal(value, &did); err != nil {
+		return DIDDocument{}, err
+	}
+	return did, nil
+}
+
+// ProvenResolution is a DID document resolved together with the ICS-23 proof
+// of its store entry and the app hash the proof verifies against, so clients
+// can check the result without trusting the node that served it. Document is
+// nil when the proof shows the DID doesn't exist.
+type ProvenResolution struct {
+	Document *DIDDocument `json:"document"`
+	ICQResult
+	// AppHash is the app hash of the block at Height+1. A client must check
+	// that it belongs to a header it trusts, e.g. through a light client.
+	AppHash tmbytes.HexBytes `json:"app_hash"`
+}
+
+// ResolveProven resolves id with a proof at the given height. With height 0
+// it proves the latest state that a committed header already vouches for,
+// i.e. the state at the latest height minus one.
+func ResolveProven(clientCtx client.Context, id string, height int64) (ProvenResolution, error) {
+	node, err := clientCtx.GetNode()
+	if err != nil {
+		return ProvenResolution{}, err
+	}
+	ctx := context.Background()
+	if height == 0 {
+		status, err := node.Status(ctx)
+		if err != nil {
+			return ProvenResolution{}, err
+		}
+		height = status.SyncInfo.LatestBlockHeight - 1
+	}
+	res, err := QueryICQ(clientCtx, DIDStoreKey(id), height)
+	if err != nil {
+		return ProvenResolution{}, err
+	}
+	next := res.Height + 1
+	commit, err := node.Commit(ctx, &next)
+	if err != nil {
+		return ProvenResolution{}, fmt.Errorf("fetch header %d: %w", next, err)
+	}
+	p := ProvenResolution{ICQResult: res, AppHash: commit.Header.AppHash}
+	if len(res.Value) > 0 {
+		did, err := DecodeICQDocument(clientCtx.LegacyAmino, res.Value)
+		if err != nil {
+			return ProvenResolution{}, err
+		}
+		p.Document = &did
+	}
+	if err := p.Verify(clientCtx.LegacyAmino); err != nil {
+		return ProvenResolution{}, err
+	}
+	return p, nil
+}
+
+// Verify checks the proof against AppHash and that Document is the proven
+// value.
+func (p ProvenResolution) Verify(cdc *codec.LegacyAmino) error {
+	if err := VerifyICQResult(p.AppHash, p.ICQResult); err != nil {
+		return fmt.Errorf("invalid proof at height %d: %w", p.Height, err)
+	}
+	if len(p.Value) == 0 {
+		if p.Document != nil {
+			return fmt.Errorf("document given for a DID proven absent")
+		}
+		return nil
+	}
+	if p.Document == nil || !bytes.Equal(cdc.MustMarshal(p.Document), p.Value) {
+		return fmt.Errorf("document does not match the proven value")
+	}
+	return nil
+}
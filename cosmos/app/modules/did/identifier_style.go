@@ -0,0 +1,94 @@
+package did
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+	"regexp"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Identifier styles, the shapes of method-specific identifier the
+// IdentifierStyles param can accept.
+const (
+	// IdentifierStyleKey is the multibase Ed25519 key of DIDFromKey.
+	IdentifierStyleKey = "key"
+	// IdentifierStyleUUID is a lowercase RFC 4122 UUID, as made by
+	// NewUUIDDID.
+	IdentifierStyleUUID = "uuid"
+	// IdentifierStyleCustom is any other identifier, chosen by the creator.
+	IdentifierStyleCustom = "custom"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[1-5][0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+// NewUUIDDID generates a DID on network whose method-specific identifier is
+// a random (version 4) UUID, followed by a checksum; see AppendChecksum.
+func NewUUIDDID(network string) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	uuid := fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+	return AppendChecksum(NetworkPrefix(network) + uuid), nil
+}
+
+// IdentifierStyle returns the style of a DID's method-specific identifier,
+// its last segment without any checksum.
+func IdentifierStyle(id string) string {
+	base, _, _ := SplitChecksum(id)
+	return segmentStyle(base[strings.LastIndex(base, ":")+1:])
+}
+
+func segmentStyle(segment string) string {
+	if uuidPattern.MatchString(segment) {
+		return IdentifierStyleUUID
+	}
+	if codec, key, err := DecodeMultibaseKey(segment); err == nil && codec == MulticodecEd25519Pub && len(key) == ed25519.PublicKeySize {
+		return IdentifierStyleKey
+	}
+	return IdentifierStyleCustom
+}
+
+// IsIdentifierStyleAllowed reports whether new DIDs may use style.
+func (p Params) IsIdentifierStyleAllowed(style string) bool {
+	return containsString(p.IdentifierStyles, style)
+}
+
+// CheckIdentifier checks the identifier of a new DID against the chain's
+// network namespace and accepted identifier styles.
+func (k Keeper) CheckIdentifier(ctx sdk.Context, id string) error {
+	if err := k.CheckNetwork(ctx, id); err != nil {
+		return err
+	}
+	if style := IdentifierStyle(id); !k.GetParams(ctx).IsIdentifierStyleAllowed(style) {
+		return fmt.Errorf("DID %s has a %s identifier, which this chain doesn't accept", id, style)
+	}
+	return nil
+}
+
+func defaultIdentifierStyles() []string {
+	return []string{IdentifierStyleKey, IdentifierStyleUUID, IdentifierStyleCustom}
+}
+
+func validateIdentifierStyles(i interface{}) error {
+	styles, ok := i.([]string)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if len(styles) == 0 {
+		return fmt.Errorf("at least one identifier style must be allowed")
+	}
+	for _, s := range styles {
+		switch s {
+		case IdentifierStyleKey, IdentifierStyleUUID, IdentifierStyleCustom:
+		default:
+			return fmt.Errorf("unknown identifier style %q", s)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package did
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// withInterceptors returns a copy of desc whose unary methods run inner,
+// outermost first, after any interceptor the msg router, query router or
+// gRPC server installs. That interceptor attaches the SDK context, so inner
+// interceptors can read and replace it.
+func withInterceptors(desc *grpc.ServiceDesc, inner ...grpc.UnaryServerInterceptor) *grpc.ServiceDesc {
+	wrapped := *desc
+	wrapped.Methods = make([]grpc.MethodDesc, len(desc.Methods))
+	for i, m := range desc.Methods {
+		handler := m.Handler
+		wrapped.Methods[i] = grpc.MethodDesc{
+			MethodName: m.MethodName,
+			Handler: func(srv interface{}, goCtx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				if interceptor == nil {
+					return handler(srv, goCtx, dec, chainInterceptors(inner))
+				}
+				return handler(srv, goCtx, dec, func(goCtx context.Context, req interface{}, info *grpc.UnaryServerInfo, next grpc.UnaryHandler) (interface{}, error) {
+					return interceptor(goCtx, req, info, func(goCtx context.Context, req interface{}) (interface{}, error) {
+						return chainInterceptors(inner)(goCtx, req, info, next)
+					})
+				})
+			},
+		}
+	}
+	return &wrapped
+}
+
+// chainInterceptors combines interceptors into one that runs them in order.
+func chainInterceptors(interceptors []grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(goCtx context.Context, req interface{}, info *grpc.UnaryServerInfo, next grpc.UnaryHandler) (interface{}, error) {
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, handler := interceptors[i], next
+			next = func(goCtx context.Context, req interface{}) (interface{}, error) {
+				return interceptor(goCtx, req, info, handler)
+			}
+		}
+		return next(goCtx, req)
+	}
+}
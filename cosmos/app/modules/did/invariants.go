@@ -0,0 +1,300 @@
+package did
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+)
+
+// RegisterInvariants registers the DID module invariants with the crisis
+// module.
+func RegisterInvariants(ir sdk.InvariantRegistry, k Keeper) {
+	ir.RegisterRoute(ModuleName, "indexes", IndexInvariant(k))
+	ir.RegisterRoute(ModuleName, "controllers", ControllerInvariant(k))
+	ir.RegisterRoute(ModuleName, "versions", VersionInvariant(k))
+	ir.RegisterRoute(ModuleName, "balance", BalanceInvariant(k))
+	ir.RegisterRoute(ModuleName, "stats", StatsInvariant(k))
+}
+
+// AllInvariants runs all the DID module invariants.
+func AllInvariants(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		for _, inv := range []sdk.Invariant{IndexInvariant(k), ControllerInvariant(k), VersionInvariant(k), BalanceInvariant(k), StatsInvariant(k)} {
+			if msg, broken := inv(ctx); broken {
+				return msg, broken
+			}
+		}
+		return "", false
+	}
+}
+
+// IndexInvariant checks that documents are stored under their own ID with
+// a matching digest and that the expiry, key rotation, recovery, pending
+// change, capability, linked asset, deposit and audit indexes agree with the
+// records they point at.
+func IndexInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		store := ctx.KVStore(k.storeKey)
+		var problems []string
+		report := func(format string, args ...interface{}) {
+			problems = append(problems, fmt.Sprintf(format, args...))
+		}
+
+		docs := make(map[string]DIDDocument)
+		iteratePrefix(store, DocumentPrefix, func(key, value []byte) bool {
+			var did DIDDocument
+			k.cdc.MustUnmarshal(value, &did)
+			if did.ID != string(key) {
+				report("document %s is stored under %s", did.ID, key)
+			}
+			docs[did.ID] = did
+			return false
+		})
+		for id, did := range docs {
+			if digest := store.Get(digestKey(id)); !bytes.Equal(digest, did.Digest()) {
+				report("stored digest of %s does not match the document", id)
+			}
+		}
+
+		expiries := make(map[string]bool)
+		iteratePrefix(store, ExpiryQueuePrefix, func(key, _ []byte) bool {
+			e := splitQueueKey(key)
+			id := string(e.suffix)
+			expiries[id] = true
+			if did, ok := docs[id]; !ok || did.Deactivated || did.ExpiresAt != e.at {
+				report("expiry queue entry %d for %s does not match an active document expiring then", e.at, id)
+			}
+			return false
+		})
+		for id, did := range docs {
+			if did.ExpiresAt != 0 && !did.Deactivated && !expiries[id] {
+				report("%s expires at %d but is not in the expiry queue", id, did.ExpiresAt)
+			}
+		}
+
+		iteratePrefix(store, PendingKeyRotationPrefix, func(_, value []byte) bool {
+			var rot PendingKeyRotation
+			k.cdc.MustUnmarshal(value, &rot)
+			if _, ok := docs[rot.DID]; !ok {
+				report("pending key rotation for missing DID %s", rot.DID)
+			}
+			if !store.Has(keyRotationQueueKey(rot.EffectiveAt, rot.DID)) {
+				report("pending key rotation of %s is not queued at height %d", rot.DID, rot.EffectiveAt)
+			}
+			return false
+		})
+		iteratePrefix(store, KeyRotationQueuePrefix, func(key, _ []byte) bool {
+			e := splitQueueKey(key)
+			if rot, found := k.GetPendingKeyRotation(ctx, string(e.suffix)); !found || rot.EffectiveAt != e.at {
+				report("key rotation queue entry %d for %s has no matching pending rotation", e.at, e.suffix)
+			}
+			return false
+		})
+
+		iteratePrefix(store, PendingRecoveryPrefix, func(_, value []byte) bool {
+			var rec PendingRecovery
+			k.cdc.MustUnmarshal(value, &rec)
+			if _, ok := docs[rec.DID]; !ok {
+				report("pending recovery for missing DID %s", rec.DID)
+			}
+			if !store.Has(recoveryQueueKey(recoveryDueHeight(rec), rec.DID)) {
+				report("pending recovery of %s is not queued at height %d", rec.DID, recoveryDueHeight(rec))
+			}
+			return false
+		})
+		iteratePrefix(store, RecoveryQueuePrefix, func(key, _ []byte) bool {
+			e := splitQueueKey(key)
+			if rec, found := k.GetPendingRecovery(ctx, string(e.suffix)); !found || recoveryDueHeight(rec) != e.at {
+				report("recovery queue entry %d for %s has no matching pending recovery", e.at, e.suffix)
+			}
+			return false
+		})
+
+		nextChangeID := uint64(1)
+		if bz := store.Get(PendingChangeSeqKey); bz != nil {
+			nextChangeID = binary.BigEndian.Uint64(bz)
+		}
+		iteratePrefix(store, PendingChangePrefix, func(_, value []byte) bool {
+			var change PendingDIDChange
+			k.cdc.MustUnmarshal(value, &change)
+			if _, ok := docs[change.DID]; !ok {
+				report("pending change %d for missing DID %s", change.ID, change.DID)
+			}
+			if change.ID >= nextChangeID {
+				report("pending change %d is not below the next change ID %d", change.ID, nextChangeID)
+			}
+			if did := store.Get(pendingChangeIDKey(change.ID)); string(did) != change.DID {
+				report("pending change %d of %s is not indexed by ID", change.ID, change.DID)
+			}
+			if !store.Has(pendingChangeExpiryKey(change.ExpiresAt, change.ID)) {
+				report("pending change %d is not queued for expiry at height %d", change.ID, change.ExpiresAt)
+			}
+			return false
+		})
+		iteratePrefix(store, PendingChangeExpiryPrefix, func(key, _ []byte) bool {
+			e := splitQueueKey(key)
+			id := binary.BigEndian.Uint64(e.suffix)
+			if change, err := k.GetPendingDIDChange(ctx, id); err != nil || change.ExpiresAt != e.at {
+				report("pending change expiry entry %d for change %d has no matching change", e.at, id)
+			}
+			return false
+		})
+
+		iteratePrefix(store, CapabilityGrantPrefix, func(_, value []byte) bool {
+			var grant CapabilityGrant
+			k.cdc.MustUnmarshal(value, &grant)
+			if _, ok := docs[grant.DID]; !ok {
+				report("capability grant over missing DID %s", grant.DID)
+			}
+			if grant.ExpiresHeight != 0 && !store.Has(capabilityHeightQueueKey(grant)) {
+				report("%s capability of %s over %s is not queued at height %d", grant.Capability, grant.Grantee, grant.DID, grant.ExpiresHeight)
+			}
+			if grant.ExpiresAt != 0 && !store.Has(capabilityTimeQueueKey(grant)) {
+				report("%s capability of %s over %s is not queued at time %d", grant.Capability, grant.Grantee, grant.DID, grant.ExpiresAt)
+			}
+			return false
+		})
+		for _, prefix := range [][]byte{CapabilityHeightQueuePrefix, CapabilityTimeQueuePrefix} {
+			iteratePrefix(store, prefix, func(key, _ []byte) bool {
+				e := splitQueueKey(key)
+				if !store.Has(section(CapabilityGrantPrefix, string(e.suffix))) {
+					report("capability queue entry %d for %s has no matching grant", e.at, e.suffix)
+				}
+				return false
+			})
+		}
+
+		iteratePrefix(store, LinkedAssetPrefix, func(_, value []byte) bool {
+			var asset LinkedAsset
+			k.cdc.MustUnmarshal(value, &asset)
+			if _, ok := docs[asset.DID]; !ok {
+				report("%s asset %s linked to missing DID %s", asset.Class, asset.AssetID, asset.DID)
+			}
+			if !store.Has(assetLinkKey(asset.Class, asset.AssetID, asset.DID)) {
+				report("%s asset %s linked to %s is not indexed", asset.Class, asset.AssetID, asset.DID)
+			}
+			return false
+		})
+		iteratePrefix(store, AssetLinksPrefix, func(key, _ []byte) bool {
+			// The key is <class>/<asset ID>/<DID>; only the asset ID can
+			// contain '/'.
+			s := string(key)
+			first, last := strings.Index(s, "/"), strings.LastIndex(s, "/")
+			if first < 0 || first == last {
+				report("malformed asset link index entry %s", s)
+				return false
+			}
+			if !store.Has(linkedAssetKey(s[last+1:], s[:first], s[first+1:last])) {
+				report("asset link index entry %s has no matching linked asset", s)
+			}
+			return false
+		})
+
+		iteratePrefix(store, DepositPrefix, func(_, value []byte) bool {
+			var deposit DIDDeposit
+			k.cdc.MustUnmarshal(value, &deposit)
+			if !store.Has(depositQueueKey(deposit.MaturesHeight, deposit.DID)) {
+				report("deposit of %s is not queued at height %d", deposit.DID, deposit.MaturesHeight)
+			}
+			return false
+		})
+		iteratePrefix(store, DepositQueuePrefix, func(key, _ []byte) bool {
+			e := splitQueueKey(key)
+			if deposit, found := k.GetDeposit(ctx, string(e.suffix)); !found || deposit.MaturesHeight != e.at {
+				report("deposit queue entry %d for %s has no matching deposit", e.at, e.suffix)
+			}
+			return false
+		})
+
+		iteratePrefix(store, AuditPrefix, func(_, value []byte) bool {
+			var entry AuditEntry
+			k.cdc.MustUnmarshal(value, &entry)
+			if _, ok := docs[entry.DID]; !ok {
+				report("audit entry for missing DID %s", entry.DID)
+			}
+			return false
+		})
+
+		return sdk.FormatInvariant(ModuleName, "indexes",
+			fmt.Sprintf("found %d inconsistent index entries\n%s", len(problems), strings.Join(problems, "\n"))), len(problems) > 0
+	}
+}
+
+// ControllerInvariant checks that every did:aytch controller named by a
+// document is a registered DID.
+func ControllerInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		store := ctx.KVStore(k.storeKey)
+		var problems []string
+		for _, did := range k.GetAllDIDs(ctx) {
+			for _, c := range did.Controller {
+				if c != did.ID && strings.HasPrefix(c, DIDMethodPrefix) && !store.Has(didKey(c)) {
+					problems = append(problems, fmt.Sprintf("%s names unregistered controller %s", did.ID, c))
+				}
+			}
+		}
+		return sdk.FormatInvariant(ModuleName, "controllers",
+			fmt.Sprintf("found %d unresolved controllers\n%s", len(problems), strings.Join(problems, "\n"))), len(problems) > 0
+	}
+}
+
+// VersionInvariant checks that each document's nonce matches the version of
+// the latest entry in its audit log. Documents written before the audit log
+// existed have no entries and are skipped.
+func VersionInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		store := ctx.KVStore(k.storeKey)
+		var problems []string
+		for _, did := range k.GetAllDIDs(ctx) {
+			iterator := sdk.KVStoreReversePrefixIterator(store, auditPrefix(did.ID))
+			if iterator.Valid() {
+				var latest AuditEntry
+				k.cdc.MustUnmarshal(iterator.Value(), &latest)
+				if latest.Version != did.Nonce {
+					problems = append(problems, fmt.Sprintf("%s is at version %d but its audit log ends at %d", did.ID, did.Nonce, latest.Version))
+				}
+			}
+			iterator.Close()
+		}
+		return sdk.FormatInvariant(ModuleName, "versions",
+			fmt.Sprintf("found %d documents out of step with their audit log\n%s", len(problems), strings.Join(problems, "\n"))), len(problems) > 0
+	}
+}
+
+// BalanceInvariant checks that the module account holds the registration
+// deposits and fee escrows it owes. The burned share of collected fees only
+// passes through the account, so it must never be spent from these funds.
+func BalanceInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var problems []string
+		owed := sdk.NewCoins()
+		for _, deposit := range k.GetAllDeposits(ctx) {
+			owed = owed.Add(deposit.Amount...)
+		}
+		for _, escrow := range k.GetAllFeeEscrows(ctx) {
+			owed = owed.Add(escrow.Amount...)
+		}
+		addr := authtypes.NewModuleAddress(ModuleName)
+		for _, coin := range owed {
+			if balance := k.bankKeeper.GetBalance(ctx, addr, coin.Denom); balance.Amount.LT(coin.Amount) {
+				problems = append(problems, fmt.Sprintf("module account holds %s but owes %s in deposits and fee escrows", balance, coin))
+			}
+		}
+		return sdk.FormatInvariant(ModuleName, "balance",
+			fmt.Sprintf("found %d denoms short of what the module owes\n%s", len(problems), strings.Join(problems, "\n"))), len(problems) > 0
+	}
+}
+
+// StatsInvariant checks that the registry counters match the records they
+// count.
+func StatsInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		stored, counted := k.GetStats(ctx), k.countStats(ctx)
+		return sdk.FormatInvariant(ModuleName, "stats",
+			fmt.Sprintf("stored counters %+v do not match the registry's %+v", stored, counted)), stored != counted
+	}
+}
@@ -0,0 +1,119 @@
+package did
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Content stored in IPFS is anchored on chain by its CID. Only CIDv1 with the
+// raw codec and a SHA-256 multihash is accepted, since the CID then commits
+// directly to the content's bytes and anyone can verify fetched content
+// without an IPFS node. Such CIDs are produced by adding the content as a
+// single block, e.g. ipfs block put --cid-codec=raw --mhtype=sha2-256.
+const (
+	cidVersion1    = 0x01
+	multicodecRaw  = 0x55
+	multihashSHA2  = 0x12
+	multibaseLower = 'b'
+)
+
+var cidEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// ContentCID returns the CIDv1 (raw, SHA-256, base32) of content.
+func ContentCID(content []byte) string {
+	sum := sha256.Sum256(content)
+	return cidFromDigest(sum[:])
+}
+
+func cidFromDigest(digest []byte) string {
+	bz := append([]byte{cidVersion1, multicodecRaw, multihashSHA2, byte(len(digest))}, digest...)
+	return string(multibaseLower) + strings.ToLower(cidEncoding.EncodeToString(bz))
+}
+
+// cidDigest returns the SHA-256 digest a CID commits to.
+func cidDigest(cid string) ([]byte, error) {
+	if len(cid) < 2 || cid[0] != multibaseLower {
+		return nil, fmt.Errorf("CID %s must be a base32 CIDv1", cid)
+	}
+	bz, err := cidEncoding.DecodeString(strings.ToUpper(cid[1:]))
+	if err != nil {
+		return nil, fmt.Errorf("invalid CID %s: %w", cid, err)
+	}
+	if len(bz) != 4+sha256.Size || bz[0] != cidVersion1 || bz[1] != multicodecRaw || bz[2] != multihashSHA2 || bz[3] != sha256.Size {
+		return nil, fmt.Errorf("CID %s must use the raw codec and a SHA-256 multihash", cid)
+	}
+	if cidFromDigest(bz[4:]) != cid {
+		return nil, fmt.Errorf("CID %s is not in canonical form", cid)
+	}
+	return bz[4:], nil
+}
+
+// ValidateCID checks that cid is a CID content can be verified against.
+func ValidateCID(cid string) error {
+	_, err := cidDigest(cid)
+	return err
+}
+
+// VerifyCID checks that content is what cid commits to.
+func VerifyCID(cid string, content []byte) error {
+	digest, err := cidDigest(cid)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(content)
+	if !bytes.Equal(sum[:], digest) {
+		return fmt.Errorf("content does not match CID %s", cid)
+	}
+	return nil
+}
+
+// cidChecksum returns the hex SHA-256 of the content a CID commits to, the
+// form resource checksums take.
+func cidChecksum(cid string) (string, error) {
+	digest, err := cidDigest(cid)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(digest), nil
+}
+
+// WithContent verifies content, the JSON encoding of the full document,
+// against the document's ContentCID and fills in the parts kept off chain.
+// Fields stored on chain always win: the content only supplies verification
+// methods, key agreement, services and contexts the chain doesn't hold.
+func (d DIDDocument) WithContent(content []byte) (DIDDocument, error) {
+	if d.ContentCID == "" {
+		return d, nil
+	}
+	if err := VerifyCID(d.ContentCID, content); err != nil {
+		return DIDDocument{}, err
+	}
+	var full DIDDocument
+	if err := json.Unmarshal(content, &full); err != nil {
+		return DIDDocument{}, fmt.Errorf("invalid document content: %w", err)
+	}
+	if full.ID != d.ID {
+		return DIDDocument{}, fmt.Errorf("content at %s describes %s, not %s", d.ContentCID, full.ID, d.ID)
+	}
+	if len(d.VerificationMethods) == 0 {
+		d.VerificationMethods = full.VerificationMethods
+	}
+	if len(d.KeyAgreement) == 0 {
+		d.KeyAgreement = full.KeyAgreement
+	}
+	if len(d.Services) == 0 {
+		d.Services = full.Services
+	}
+	if len(d.ServiceEndpoints) == 0 {
+		d.ServiceEndpoints = full.ServiceEndpoints
+	}
+	if len(d.Context) == 0 {
+		d.Context = full.Context
+	}
+	return d, nil
+}
@@ -0,0 +1,89 @@
+package did
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// defaultIssuerResourceTypes are the resource types that count as issuance
+// artefacts: credential schemas, credential and revocation registry
+// definitions, status lists and credential manifests.
+func defaultIssuerResourceTypes() []string {
+	return []string{
+		"JsonSchema",
+		"StatusList2021",
+		"BitstringStatusList",
+		AnonCredsSchemaType,
+		AnonCredsCredDefType,
+		AnonCredsRevRegDefType,
+		AnonCredsStatusListType,
+		CredentialManifestResourceType,
+	}
+}
+
+// IsAllowedIssuer reports whether did may act as an issuer: create issuer
+// resources, post attestations or issue credential-backed tokens. Every DID
+// may while RestrictIssuers is off.
+func (p Params) IsAllowedIssuer(did string) bool {
+	if !p.RestrictIssuers {
+		return true
+	}
+	for _, issuer := range p.AllowedIssuers {
+		if issuer == did {
+			return true
+		}
+	}
+	return false
+}
+
+// IsIssuerResourceType reports whether resources of resourceType may only be
+// created by allowed issuers.
+func (p Params) IsIssuerResourceType(resourceType string) bool {
+	for _, t := range p.IssuerResourceTypes {
+		if t == resourceType {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckIssuer returns an error unless did is an allowed issuer.
+func (k Keeper) CheckIssuer(ctx sdk.Context, did string) error {
+	if !k.GetParams(ctx).IsAllowedIssuer(did) {
+		return fmt.Errorf("%s is not on the issuer allowlist", did)
+	}
+	return nil
+}
+
+func validateAllowedIssuers(i interface{}) error {
+	issuers, ok := i.([]string)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	seen := make(map[string]bool, len(issuers))
+	for _, issuer := range issuers {
+		if !strings.HasPrefix(issuer, DIDMethodPrefix) {
+			return fmt.Errorf("allowed issuer %q must be a %s DID", issuer, DIDMethodPrefix)
+		}
+		if seen[issuer] {
+			return fmt.Errorf("duplicate allowed issuer %s", issuer)
+		}
+		seen[issuer] = true
+	}
+	return nil
+}
+
+func validateIssuerResourceTypes(i interface{}) error {
+	types, ok := i.([]string)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	for _, t := range types {
+		if t == "" {
+			return fmt.Errorf("issuer resource type cannot be empty")
+		}
+	}
+	return nil
+}
@@ -5,42 +5,379 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // Keeper handles state interactions for the DID module.
 type Keeper struct {
-	storeKey sdk.StoreKey
-	cdc      codec.BinaryCodec
+	storeKey    sdk.StoreKey
+	cdc         *codec.LegacyAmino
+	paramSpace  paramtypes.Subspace
+	bankKeeper  BankKeeper
+	distrKeeper DistrKeeper
+	stats       *blockStats
+	cache       *readCache
+	// assetVerifiers holds the verifier of each linkable asset class.
+	assetVerifiers map[string]AssetVerifier
+	// controllerHooks holds the hooks added with AddControllerHooks.
+	controllerHooks *[]ControllerHooks
 }
 
 // NewKeeper creates a new DID Keeper.
-func NewKeeper(storeKey sdk.StoreKey, cdc codec.BinaryCodec) Keeper {
+func NewKeeper(storeKey sdk.StoreKey, cdc *codec.LegacyAmino, paramSpace paramtypes.Subspace, bankKeeper BankKeeper, distrKeeper DistrKeeper) Keeper {
+	if !paramSpace.HasKeyTable() {
+		paramSpace = paramSpace.WithKeyTable(ParamKeyTable())
+	}
 	return Keeper{
-		storeKey: storeKey,
-		cdc:      cdc,
+		storeKey:    storeKey,
+		cdc:         cdc,
+		paramSpace:  paramSpace,
+		bankKeeper:  bankKeeper,
+		distrKeeper: distrKeeper,
+		stats:       &blockStats{},
+
+		assetVerifiers:  map[string]AssetVerifier{AssetClassBank: bankAssetVerifier{bankKeeper}},
+		controllerHooks: new([]ControllerHooks),
+	}
+}
+
+// GetParams returns the current DID module parameters.
+func (k Keeper) GetParams(ctx sdk.Context) Params {
+	var params Params
+	k.paramSpace.GetParamSet(ctx, &params)
+	return params
+}
+
+// SetParams sets the DID module parameters.
+func (k Keeper) SetParams(ctx sdk.Context, params Params) {
+	k.paramSpace.SetParamSet(ctx, &params)
+}
+
+// ChargeCreateDIDFee collects the CreateDIDFee for the new DID id from the
+// payer, splitting it with CollectFee or burning it all if BurnCreateDIDFee
+// is set. The DeactivationRefund part is held in escrow instead.
+func (k Keeper) ChargeCreateDIDFee(ctx sdk.Context, payer sdk.AccAddress, id string) error {
+	return k.ChargeCreateDIDFees(ctx, payer, []string{id})
+}
+
+// ChargeCreateDIDFees collects the CreateDIDFee for the new DIDs ids from the
+// payer in a single transfer, and a single escrow transfer.
+func (k Keeper) ChargeCreateDIDFees(ctx sdk.Context, payer sdk.AccAddress, ids []string) error {
+	params := k.GetParams(ctx)
+	if params.CreateDIDFee.IsZero() || len(ids) == 0 {
+		return nil
+	}
+	escrow := escrowedFee(params)
+	if err := k.chargeCollectedFee(ctx, payer, params, mulCoins(params.CreateDIDFee.Sub(escrow), len(ids))); err != nil {
+		return err
+	}
+	if escrow.IsZero() {
+		return nil
+	}
+	if err := k.bankKeeper.SendCoinsFromAccountToModule(ctx, payer, ModuleName, mulCoins(escrow, len(ids))); err != nil {
+		return err
+	}
+	for _, id := range ids {
+		k.escrowFee(ctx, FeeEscrow{DID: id, Payer: payer, Amount: escrow})
+	}
+	return nil
+}
+
+func (k Keeper) chargeCollectedFee(ctx sdk.Context, payer sdk.AccAddress, params Params, fee sdk.Coins) error {
+	if fee.IsZero() {
+		return nil
+	}
+	if !params.BurnCreateDIDFee {
+		return k.CollectFee(ctx, payer, fee)
+	}
+	if err := k.bankKeeper.SendCoinsFromAccountToModule(ctx, payer, ModuleName, fee); err != nil {
+		return err
+	}
+	return k.bankKeeper.BurnCoins(ctx, ModuleName, fee)
+}
+
+// escrowedFee returns the part of the CreateDIDFee held in escrow. Validate
+// keeps DeactivationRefund within the fee, but the two can be changed by
+// separate proposals, so it is capped here too.
+func escrowedFee(params Params) sdk.Coins {
+	var escrow sdk.Coins
+	for _, coin := range params.DeactivationRefund {
+		amount := sdk.MinInt(coin.Amount, params.CreateDIDFee.AmountOf(coin.Denom))
+		if amount.IsPositive() {
+			escrow = append(escrow, sdk.NewCoin(coin.Denom, amount))
+		}
+	}
+	return escrow
+}
+
+func mulCoins(coins sdk.Coins, n int) sdk.Coins {
+	if n == 1 {
+		return coins
+	}
+	product := make(sdk.Coins, len(coins))
+	for i, coin := range coins {
+		product[i] = sdk.NewCoin(coin.Denom, coin.Amount.MulRaw(int64(n)))
 	}
+	return product
 }
 
 // CreateDID stores a new DID document in the blockchain state.
 func (k Keeper) CreateDID(ctx sdk.Context, did DIDDocument) error {
 	store := ctx.KVStore(k.storeKey)
-	key := []byte(did.ID)
-	if store.Has(key) {
+	if store.Has(didKey(did.ID)) || store.Has(commitmentKey(did.ID)) {
 		return fmt.Errorf("DID already exists")
 	}
-	value := k.cdc.MustMarshalBinaryLengthPrefixed(&did)
-	store.Set(key, value)
+	return k.setDID(ctx, did)
+}
+
+// setDID enforces the document limits, charges gas and writes the document,
+// advancing its nonce if it already exists. Every write emits
+// EventTypeDIDChanged and is appended to the DID's audit log. The linked
+// accounts in AlsoKnownAs carry over from the stored document, so writes built
+// from messages can't add unproven accounts; see setAlsoKnownAs.
+func (k Keeper) setDID(ctx sdk.Context, did DIDDocument) error {
+	return k.writeDID(ctx, did, false)
+}
+
+// importDID writes a document exported to genesis as it is, nonce and linked
+// accounts included, along with its digest, expiry index and counters. It
+// skips setDID's write-time checks, gas, events and audit log, so documents
+// written under earlier params, or whose X.509 certificates have since
+// expired, survive an export and import.
+func (k Keeper) importDID(ctx sdk.Context, did DIDDocument) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(didKey(did.ID), k.cdc.MustMarshal(&did))
+	store.Set(digestKey(did.ID), did.Digest())
+	k.indexExpiry(ctx, nil, did)
+	k.countDIDWrite(ctx, nil, did)
+}
+
+// setAlsoKnownAs writes a document whose AlsoKnownAs has changed.
+func (k Keeper) setAlsoKnownAs(ctx sdk.Context, did DIDDocument) error {
+	return k.writeDID(ctx, did, true)
+}
+
+func (k Keeper) writeDID(ctx sdk.Context, did DIDDocument, alsoKnownAs bool) (err error) {
+	ctx, span := startSpan(ctx, "did.Keeper/setDID", attribute.String("did", did.ID))
+	defer func() { endSpan(span, err) }()
+
+	store := ctx.KVStore(k.storeKey)
+	var existing *DIDDocument
+	if value := store.Get(didKey(did.ID)); value != nil {
+		existing = new(DIDDocument)
+		k.cdc.MustUnmarshal(value, existing)
+		did.Nonce = existing.Nonce + 1
+		if !alsoKnownAs {
+			did.AlsoKnownAs = existing.AlsoKnownAs
+		}
+	}
+
+	value := k.cdc.MustMarshal(&did)
+	if err := k.checkDocumentLimits(ctx, did, len(value)); err != nil {
+		return err
+	}
+	if err := k.checkContexts(ctx, did); err != nil {
+		return err
+	}
+	if err := k.checkServiceEndpoints(ctx, did); err != nil {
+		return err
+	}
+	if err := k.checkX509Chains(ctx, existing, did); err != nil {
+		return err
+	}
+	if err := k.checkPersonalData(ctx, did); err != nil {
+		return err
+	}
+	k.consumeDocumentGas(ctx, did, len(value))
+	k.indexExpiry(ctx, existing, did)
+	store.Set(didKey(did.ID), value)
+	store.Set(digestKey(did.ID), did.Digest())
+	k.invalidateCache(ctx, did.ID)
+	k.countDIDWrite(ctx, existing, did)
+	if existing != nil {
+		k.afterControllersChanged(ctx, *existing, did)
+	}
+	op := emitDIDChanged(ctx, existing, did)
+	span.SetAttributes(attribute.String("operation", op), attribute.Int("size", len(value)))
+	k.recordAudit(ctx, did, op)
+	k.recordWrite(ctx, op, len(value))
+	return nil
+}
+
+// checkDocumentLimits enforces the size, count, length and key type params
+// on a document about to be written.
+func (k Keeper) checkDocumentLimits(ctx sdk.Context, did DIDDocument, size int) error {
+	params := k.GetParams(ctx)
+	if uint64(size) > params.MaxDocumentSize {
+		return sdkerrors.Wrapf(ErrDocumentTooLarge, "%d bytes exceeds maximum of %d", size, params.MaxDocumentSize)
+	}
+	if uint64(len(did.VerificationMethods)) > params.MaxVerificationMethods {
+		return sdkerrors.Wrapf(ErrTooManyVerificationMethods, "%d exceeds maximum of %d", len(did.VerificationMethods), params.MaxVerificationMethods)
+	}
+	if n := len(did.Services) + len(did.ServiceEndpoints); uint64(n) > params.MaxServiceEndpoints {
+		return sdkerrors.Wrapf(ErrTooManyServices, "%d exceeds maximum of %d", n, params.MaxServiceEndpoints)
+	}
+	for _, vm := range did.VerificationMethods {
+		if !params.IsKeyTypeAllowed(vm.Type) {
+			return sdkerrors.Wrap(ErrKeyTypeNotAllowed, vm.Type)
+		}
+	}
+	if err := checkFieldLengths(did, params.MaxFieldLength); err != nil {
+		return err
+	}
+	endpoints := append([]string{}, did.ServiceEndpoints...)
+	for _, svc := range did.Services {
+		endpoints = append(endpoints, svc.ServiceEndpoint)
+	}
+	for _, endpoint := range endpoints {
+		if uint64(len(endpoint)) > params.MaxServiceEndpointLength {
+			return sdkerrors.Wrapf(ErrServiceEndpointTooLong, "%d characters exceeds maximum of %d", len(endpoint), params.MaxServiceEndpointLength)
+		}
+	}
 	return nil
 }
 
+// checkFieldLengths checks every identifier, type and key string of a
+// document against max, in a fixed order so every node reports the same
+// field.
+func checkFieldLengths(did DIDDocument, max uint64) error {
+	type field struct {
+		name   string
+		values []string
+	}
+	fields := []field{
+		{"id", []string{did.ID}},
+		{"public key", []string{did.PublicKey}},
+		{"authentication", []string{did.Authentication}},
+		{"controller", did.Controller},
+		{"key agreement", did.KeyAgreement},
+		{"context", did.Context},
+	}
+	for _, vm := range did.VerificationMethods {
+		fields = append(fields, field{"verification method", []string{vm.ID, vm.Type, vm.Controller, vm.PublicKeyMultibase}})
+	}
+	for _, svc := range did.Services {
+		fields = append(fields,
+			field{"service", []string{svc.ID, svc.Type}},
+			field{"service routing key", svc.RoutingKeys},
+			field{"service media type", svc.Accept},
+		)
+	}
+	for _, f := range fields {
+		for _, v := range f.values {
+			if uint64(len(v)) > max {
+				return sdkerrors.Wrapf(ErrFieldTooLong, "%s is %d characters, exceeds maximum of %d", f.name, len(v), max)
+			}
+		}
+	}
+	return nil
+}
+
+// consumeDocumentGas charges gas proportional to the encoded size of a
+// document and the number of verification methods and services it carries.
+func (k Keeper) consumeDocumentGas(ctx sdk.Context, did DIDDocument, size int) {
+	params := k.GetParams(ctx)
+	ctx.GasMeter().ConsumeGas(params.DocumentGasPerByte*uint64(size), "DID document size")
+	ctx.GasMeter().ConsumeGas(params.GasPerVerificationMethod*uint64(len(did.VerificationMethods)), "DID verification methods")
+	ctx.GasMeter().ConsumeGas(params.GasPerService*uint64(len(did.Services)+len(did.ServiceEndpoints)), "DID services")
+}
+
 // GetDID retrieves a DID document from the blockchain state.
 func (k Keeper) GetDID(ctx sdk.Context, id string) (DIDDocument, error) {
+	_, span := startSpan(ctx, "did.Keeper/GetDID", attribute.String("did", id))
+	defer span.End()
+
 	store := ctx.KVStore(k.storeKey)
-	value := store.Get([]byte(id))
+	value := store.Get(didKey(id))
 	if value == nil {
+		span.SetAttributes(attribute.Bool("found", false))
 		return DIDDocument{}, fmt.Errorf("DID not found")
 	}
+	span.SetAttributes(attribute.Int("size", len(value)))
 	var did DIDDocument
-	k.cdc.MustUnmarshalBinaryLengthPrefixed(value, &did)
+	k.cdc.MustUnmarshal(value, &did)
 	return did, nil
 }
+
+// GetDigest returns the integrity digest stored with a DID document, see
+// DIDDocument.Digest.
+func (k Keeper) GetDigest(ctx sdk.Context, id string) ([]byte, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(digestKey(id))
+	return bz, bz != nil
+}
+
+// GetAllDIDs returns every DID document in the store.
+func (k Keeper) GetAllDIDs(ctx sdk.Context) []DIDDocument {
+	var dids []DIDDocument
+	iteratePrefix(ctx.KVStore(k.storeKey), DocumentPrefix, func(_, value []byte) bool {
+		var did DIDDocument
+		k.cdc.MustUnmarshal(value, &did)
+		dids = append(dids, did)
+		return false
+	})
+	return dids
+}
+
+// RevokeCredential marks a credential as revoked by its issuer. A credential
+// with a status list entry can only be revoked by the issuer that registered
+// it. Callers must have authorized the revocation.
+func (k Keeper) RevokeCredential(ctx sdk.Context, id, issuer, reason string) error {
+	if _, err := k.GetDID(ctx, issuer); err != nil {
+		return fmt.Errorf("issuer DID not found")
+	}
+	if entry, found := k.GetStatusListEntry(ctx, id); found && entry.Issuer != issuer {
+		return fmt.Errorf("credential %s was issued by %s", id, entry.Issuer)
+	}
+	status, err := k.GetCredentialStatus(ctx, id)
+	if err != nil {
+		return err
+	}
+	if status.Revoked {
+		return fmt.Errorf("credential already revoked")
+	}
+	status.Issuer = issuer
+	status.Revoked = true
+	status.Reason = reason
+	status.RevokedAt = ctx.BlockHeight()
+	k.setCredentialStatus(ctx, status)
+	k.updateStats(ctx, func(stats *RegistryStats) { stats.Revocations++ })
+	ctx.EventManager().EmitEvent(sdk.NewEvent("credential_revoked",
+		sdk.NewAttribute("credential_id", id),
+		sdk.NewAttribute("issuer", issuer),
+		sdk.NewAttribute("reason", reason),
+	))
+	return nil
+}
+
+// GetCredentialStatus retrieves the revocation record of a credential.
+// Credentials without a record are considered active, issued by the issuer of
+// their status list entry if they have one.
+func (k Keeper) GetCredentialStatus(ctx sdk.Context, id string) (CredentialStatus, error) {
+	store := ctx.KVStore(k.storeKey)
+	value := store.Get(credentialStatusKey(id))
+	if value == nil {
+		entry, _ := k.GetStatusListEntry(ctx, id)
+		return CredentialStatus{ID: id, Issuer: entry.Issuer}, nil
+	}
+	var status CredentialStatus
+	k.cdc.MustUnmarshal(value, &status)
+	return status, nil
+}
+
+// GetAllCredentialStatuses returns every revocation record in the store.
+func (k Keeper) GetAllCredentialStatuses(ctx sdk.Context) []CredentialStatus {
+	var statuses []CredentialStatus
+	iteratePrefix(ctx.KVStore(k.storeKey), CredentialStatusPrefix, func(_, value []byte) bool {
+		var status CredentialStatus
+		k.cdc.MustUnmarshal(value, &status)
+		statuses = append(statuses, status)
+		return false
+	})
+	return statuses
+}
+
+func (k Keeper) setCredentialStatus(ctx sdk.Context, status CredentialStatus) {
+	ctx.KVStore(k.storeKey).Set(credentialStatusKey(status.ID), k.cdc.MustMarshal(&status))
+}
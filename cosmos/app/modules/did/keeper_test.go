@@ -0,0 +1,284 @@
+package did
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+)
+
+// setupKeeper returns a keeper with default params over an in-memory IAVL
+// store, using the given bank and distribution keepers.
+func setupKeeper(tb testing.TB, bank BankKeeper, distr DistrKeeper) (Keeper, sdk.Context, sdk.CommitMultiStore) {
+	tb.Helper()
+	storeKey := sdk.NewKVStoreKey(StoreKey)
+	paramsKey := sdk.NewKVStoreKey(paramtypes.StoreKey)
+	paramsTKey := sdk.NewTransientStoreKey(paramtypes.TStoreKey)
+
+	db := dbm.NewMemDB()
+	cms := store.NewCommitMultiStore(db)
+	cms.MountStoreWithDB(storeKey, sdk.StoreTypeIAVL, nil)
+	cms.MountStoreWithDB(paramsKey, sdk.StoreTypeIAVL, nil)
+	cms.MountStoreWithDB(paramsTKey, sdk.StoreTypeTransient, nil)
+	if err := cms.LoadLatestVersion(); err != nil {
+		tb.Fatal(err)
+	}
+	ctx := sdk.NewContext(cms, tmproto.Header{Height: 1}, false, log.NewNopLogger())
+
+	cdc := codec.NewProtoCodec(codectypes.NewInterfaceRegistry())
+	amino := codec.NewLegacyAmino()
+	subspace := paramtypes.NewSubspace(cdc, amino, paramsKey, paramsTKey, ModuleName)
+	k := NewKeeper(storeKey, amino, subspace, bank, distr)
+	k.SetParams(ctx, DefaultParams())
+	return k, ctx, cms
+}
+
+// testKeeper returns a keeper wired to in-memory bank and distribution
+// keepers.
+func testKeeper(t *testing.T) (Keeper, sdk.Context, *mockBankKeeper, *mockDistrKeeper) {
+	t.Helper()
+	bank := &mockBankKeeper{balances: make(map[string]sdk.Coins)}
+	distr := &mockDistrKeeper{bank: bank}
+	k, ctx, _ := setupKeeper(t, bank, distr)
+	return k, ctx, bank, distr
+}
+
+// mockBankKeeper keeps balances in memory. Module accounts are addressed by
+// their module addresses.
+type mockBankKeeper struct {
+	balances map[string]sdk.Coins
+	burned   sdk.Coins
+	// refundErr, if set, fails every send from a module to an account.
+	refundErr error
+}
+
+var _ BankKeeper = (*mockBankKeeper)(nil)
+
+func (b *mockBankKeeper) send(from, to sdk.AccAddress, amt sdk.Coins) error {
+	balance := b.balances[from.String()]
+	rest, negative := balance.SafeSub(amt)
+	if negative {
+		return sdkerrors.ErrInsufficientFunds.Wrapf("%s is smaller than %s", balance, amt)
+	}
+	b.balances[from.String()] = rest
+	b.balances[to.String()] = b.balances[to.String()].Add(amt...)
+	return nil
+}
+
+func (b *mockBankKeeper) SendCoinsFromAccountToModule(_ sdk.Context, sender sdk.AccAddress, module string, amt sdk.Coins) error {
+	return b.send(sender, authtypes.NewModuleAddress(module), amt)
+}
+
+func (b *mockBankKeeper) SendCoinsFromModuleToAccount(_ sdk.Context, module string, recipient sdk.AccAddress, amt sdk.Coins) error {
+	if b.refundErr != nil {
+		return b.refundErr
+	}
+	return b.send(authtypes.NewModuleAddress(module), recipient, amt)
+}
+
+func (b *mockBankKeeper) BurnCoins(_ sdk.Context, module string, amt sdk.Coins) error {
+	addr := authtypes.NewModuleAddress(module)
+	rest, negative := b.balances[addr.String()].SafeSub(amt)
+	if negative {
+		return sdkerrors.ErrInsufficientFunds.Wrapf("cannot burn %s", amt)
+	}
+	b.balances[addr.String()] = rest
+	b.burned = b.burned.Add(amt...)
+	return nil
+}
+
+func (b *mockBankKeeper) SpendableCoins(_ sdk.Context, addr sdk.AccAddress) sdk.Coins {
+	return b.balances[addr.String()]
+}
+
+func (b *mockBankKeeper) GetBalance(_ sdk.Context, addr sdk.AccAddress, denom string) sdk.Coin {
+	return sdk.NewCoin(denom, b.balances[addr.String()].AmountOf(denom))
+}
+
+// balance returns the amount of denom held by addr.
+func (b *mockBankKeeper) balance(addr sdk.AccAddress, denom string) int64 {
+	return b.balances[addr.String()].AmountOf(denom).Int64()
+}
+
+// mockDistrKeeper moves community pool funds out of the mock bank.
+type mockDistrKeeper struct {
+	bank          *mockBankKeeper
+	communityPool sdk.Coins
+	err           error
+}
+
+var _ DistrKeeper = (*mockDistrKeeper)(nil)
+
+func (d *mockDistrKeeper) FundCommunityPool(_ sdk.Context, amount sdk.Coins, sender sdk.AccAddress) error {
+	if d.err != nil {
+		return d.err
+	}
+	if err := d.bank.send(sender, authtypes.NewModuleAddress("distribution"), amount); err != nil {
+		return err
+	}
+	d.communityPool = d.communityPool.Add(amount...)
+	return nil
+}
+
+// testAddr returns a deterministic account address.
+func testAddr(name string) sdk.AccAddress {
+	return authtypes.NewModuleAddress("test/" + name)
+}
+
+// testSigner is a DID with a deterministic Ed25519 key.
+type testSigner struct {
+	id   string
+	priv ed25519.PrivateKey
+}
+
+func newTestSigner(name string) testSigner {
+	seed := make([]byte, ed25519.SeedSize)
+	copy(seed, name)
+	return testSigner{id: DIDMethodPrefix + name, priv: ed25519.NewKeyFromSeed(seed)}
+}
+
+// document returns the signer's DID document, controlled by controllers or
+// by itself if none are given.
+func (s testSigner) document(controllers ...string) DIDDocument {
+	return DIDDocument{
+		ID:             s.id,
+		PublicKey:      base64.StdEncoding.EncodeToString(s.priv.Public().(ed25519.PublicKey)),
+		Authentication: s.id + "#key-1",
+		Controller:     controllers,
+	}
+}
+
+func (s testSigner) sign(bz []byte) []byte {
+	return ed25519.Sign(s.priv, bz)
+}
+
+// mustCreate stores documents, failing the test on error.
+func mustCreate(t *testing.T, k Keeper, ctx sdk.Context, dids ...DIDDocument) {
+	t.Helper()
+	for _, did := range dids {
+		if err := k.CreateDID(ctx, did); err != nil {
+			t.Fatalf("create %s: %v", did.ID, err)
+		}
+	}
+}
+
+// hasEvent reports whether ctx emitted an event of the given type.
+func hasEvent(ctx sdk.Context, eventType string) bool {
+	for _, e := range ctx.EventManager().Events() {
+		if e.Type == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+func TestAuthorizeControllerAddress(t *testing.T) {
+	k, ctx, _, _ := testKeeper(t)
+	owner, other := testAddr("owner"), testAddr("other")
+	did := newTestSigner("alice").document(owner.String())
+	mustCreate(t, k, ctx, did)
+
+	if err := k.AuthorizeController(ctx, did.ID, 0, owner.String(), owner, nil, nil); err != nil {
+		t.Fatalf("controller address signing its own tx: %v", err)
+	}
+	if err := k.AuthorizeController(ctx, did.ID, 0, owner.String(), other, nil, nil); err == nil {
+		t.Error("controller address accepted for a tx it didn't sign")
+	}
+	if err := k.AuthorizeController(ctx, did.ID, 0, other.String(), other, nil, nil); err == nil {
+		t.Error("non-controller accepted")
+	}
+	if err := k.AuthorizeController(ctx, "did:aytch:missing", 0, owner.String(), owner, nil, nil); err == nil {
+		t.Error("unknown DID accepted")
+	}
+}
+
+func TestAuthorizeControllerNonce(t *testing.T) {
+	k, ctx, _, _ := testKeeper(t)
+	owner := testAddr("owner")
+	did := newTestSigner("alice").document(owner.String())
+	mustCreate(t, k, ctx, did)
+
+	if err := k.AuthorizeController(ctx, did.ID, 1, owner.String(), owner, nil, nil); err == nil {
+		t.Error("future nonce accepted")
+	}
+	if err := k.IncrementNonce(ctx, did.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := k.AuthorizeController(ctx, did.ID, 0, owner.String(), owner, nil, nil); err == nil {
+		t.Error("replayed nonce accepted")
+	}
+	if err := k.AuthorizeController(ctx, did.ID, 1, owner.String(), owner, nil, nil); err != nil {
+		t.Errorf("current nonce rejected: %v", err)
+	}
+}
+
+func TestAuthorizeControllerDIDSignature(t *testing.T) {
+	k, ctx, _, _ := testKeeper(t)
+	ctrl, mallory := newTestSigner("ctrl"), newTestSigner("mallory")
+	did := newTestSigner("alice").document(ctrl.id)
+	mustCreate(t, k, ctx, ctrl.document(), mallory.document(), did)
+	signBytes := []byte("update alice")
+
+	if err := k.AuthorizeController(ctx, did.ID, 0, ctrl.id, nil, signBytes, ctrl.sign(signBytes)); err != nil {
+		t.Fatalf("controller DID signature rejected: %v", err)
+	}
+	if err := k.AuthorizeController(ctx, did.ID, 0, ctrl.id, nil, signBytes, mallory.sign(signBytes)); err == nil {
+		t.Error("signature by another key accepted")
+	}
+	if err := k.AuthorizeController(ctx, did.ID, 0, ctrl.id, nil, []byte("other"), ctrl.sign(signBytes)); err == nil {
+		t.Error("signature over other bytes accepted")
+	}
+
+	if err := k.DeactivateDID(ctx, ctrl.id); err != nil {
+		t.Fatal(err)
+	}
+	if err := k.AuthorizeController(ctx, did.ID, 0, ctrl.id, nil, signBytes, ctrl.sign(signBytes)); err == nil {
+		t.Error("deactivated controller DID accepted")
+	}
+}
+
+func TestAuthorizeControllerThreshold(t *testing.T) {
+	k, ctx, _, _ := testKeeper(t)
+	a, b := testAddr("a"), testAddr("b")
+	did := newTestSigner("org").document(a.String(), b.String())
+	did.ControllerThreshold = 2
+	mustCreate(t, k, ctx, did)
+
+	err := k.AuthorizeController(ctx, did.ID, 0, a.String(), a, nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "requires 2 controller approvals") {
+		t.Errorf("single controller of a threshold DID: got %v", err)
+	}
+}
+
+func TestWriteDIDAdvancesNonce(t *testing.T) {
+	k, ctx, _, _ := testKeeper(t)
+	did := newTestSigner("alice").document()
+	mustCreate(t, k, ctx, did)
+
+	for want := uint64(1); want <= 3; want++ {
+		if err := k.UpdateDID(ctx, did); err != nil {
+			t.Fatal(err)
+		}
+		got, err := k.GetDID(ctx, did.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Nonce != want {
+			t.Fatalf("nonce after %d updates = %d", want, got.Nonce)
+		}
+	}
+	if err := k.CreateDID(ctx, did); err == nil {
+		t.Errorf("%s created twice", did.ID)
+	}
+}
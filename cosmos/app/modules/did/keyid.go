@@ -0,0 +1,153 @@
+package did
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// DIDMethodPrefix is the prefix of every did:aytch identifier.
+const DIDMethodPrefix = "did:aytch:"
+
+// DIDFromKey derives the canonical did:aytch identifier of an Ed25519 public
+// key: the method-specific id is the key's multicodec multibase encoding, as
+// in did:key, followed by a checksum; see AppendChecksum.
+func DIDFromKey(pub ed25519.PublicKey) string {
+	return NetworkDIDFromKey("", pub)
+}
+
+// DocumentFromKey builds the minimal document of a DID identified by a single
+// Ed25519 key given in both multibase and raw form: the key is its only
+// verification method and authenticates the DID. It is used for key-derived
+// did:aytch identifiers and for did:key.
+func DocumentFromKey(id, publicKeyMultibase string, pub ed25519.PublicKey) DIDDocument {
+	vm := VerificationMethod{
+		ID:                 id + "#" + publicKeyMultibase,
+		Type:               Ed25519VerificationKey2020,
+		Controller:         id,
+		PublicKeyMultibase: publicKeyMultibase,
+	}
+	return DIDDocument{
+		ID:                  id,
+		PublicKey:           base64.StdEncoding.EncodeToString(pub),
+		Authentication:      vm.ID,
+		VerificationMethods: []VerificationMethod{vm},
+	}
+}
+
+// DecodeEd25519Multibase decodes a multicodec-prefixed multibase Ed25519
+// public key.
+func DecodeEd25519Multibase(s string) (ed25519.PublicKey, error) {
+	vm := VerificationMethod{Type: Ed25519VerificationKey2020, PublicKeyMultibase: s}
+	pub, err := vm.PublicKeyBytes()
+	if err != nil {
+		return nil, fmt.Errorf("public key must be a multibase Ed25519 key: %w", err)
+	}
+	return pub, nil
+}
+
+// checkKeyDerivedID rejects documents that claim a key-derived identifier for
+// a different key, so only the key's holder can register its canonical DID.
+// The key is the last segment of the identifier, after any network
+// namespace and before any checksum. The binding is only enforced at creation; the key can be
+// rotated later.
+func checkKeyDerivedID(id, publicKey string) error {
+	if !strings.HasPrefix(id, DIDMethodPrefix) {
+		return nil
+	}
+	id, _, _ = SplitChecksum(id)
+	msid := strings.TrimPrefix(id, DIDMethodPrefix)
+	if i := strings.LastIndex(msid, ":"); i >= 0 {
+		msid = msid[i+1:]
+	}
+	if !strings.HasPrefix(msid, "z") {
+		return nil
+	}
+	codec, key, err := DecodeMultibaseKey(msid)
+	if err != nil || codec != MulticodecEd25519Pub || len(key) != ed25519.PublicKeySize {
+		return nil
+	}
+	if base64.StdEncoding.EncodeToString(key) != publicKey {
+		return fmt.Errorf("DID %s is derived from a different public key", id)
+	}
+	return nil
+}
+
+// MsgCreateDIDFromKey registers the canonical DID of an Ed25519 key. The
+// identifier, public key and verification method are all derived from
+// PublicKeyMultibase, and Signature over ProofSignBytes proves the creator
+// holds the key.
+type MsgCreateDIDFromKey struct {
+	PublicKeyMultibase string         `protobuf:"bytes,1,opt,name=public_key_multibase,proto3" json:"public_key_multibase"`
+	Services           []Service      `protobuf:"bytes,2,rep,name=services,proto3" json:"services"`
+	Signature          []byte         `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	Creator            sdk.AccAddress `protobuf:"bytes,4,opt,name=creator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"creator"`
+}
+
+// Document returns the DID document derived from the key.
+func (msg MsgCreateDIDFromKey) Document() (DIDDocument, error) {
+	return msg.NetworkDocument("")
+}
+
+// NetworkDocument returns the DID document derived from the key on network,
+// whose identifier carries the network namespace; see Params.Network.
+func (msg MsgCreateDIDFromKey) NetworkDocument(network string) (DIDDocument, error) {
+	pub, err := DecodeEd25519Multibase(msg.PublicKeyMultibase)
+	if err != nil {
+		return DIDDocument{}, err
+	}
+	doc := DocumentFromKey(NetworkDIDFromKey(network, pub), msg.PublicKeyMultibase, pub)
+	doc.Services = msg.Services
+	return doc, nil
+}
+
+// ValidateBasic performs basic validation of MsgCreateDIDFromKey, including
+// the signature by the key.
+func (msg MsgCreateDIDFromKey) ValidateBasic() error {
+	if err := validateChecksums(msg); err != nil {
+		return err
+	}
+	doc, err := msg.Document()
+	if err != nil {
+		return sdkerrors.ErrUnknownRequest.Wrap(err.Error())
+	}
+	if err := validateDocumentContent(doc); err != nil {
+		return err
+	}
+	pub, _ := doc.Ed25519PublicKey()
+	if !ed25519.Verify(pub, msg.ProofSignBytes(), msg.Signature) {
+		return sdkerrors.ErrUnauthorized.Wrap("invalid signature by the DID key")
+	}
+	return nil
+}
+
+// ProofSignBytes returns the bytes the key signs to prove possession.
+func (msg MsgCreateDIDFromKey) ProofSignBytes() []byte {
+	msg.Signature = nil
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// Route returns the message route.
+func (msg MsgCreateDIDFromKey) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgCreateDIDFromKey) Type() string { return "create_did_from_key" }
+
+// GetSignBytes returns the canonical bytes to sign over.
+func (msg MsgCreateDIDFromKey) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners returns the account that must sign the message.
+func (msg MsgCreateDIDFromKey) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
@@ -0,0 +1,86 @@
+package did
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// documentSignDoc is the amino JSON sign doc of the messages that write a
+// whole document. Hardware wallets such as the Ledger Cosmos app display
+// every field of the sign doc, so instead of the raw message it lists the
+// document one line per method and service, and binds the rest of the
+// message through Digest, the hex SHA-256 of its full amino JSON.
+type documentSignDoc struct {
+	DID                 string   `json:"did"`
+	Nonce               string   `json:"nonce,omitempty"`
+	Signer              string   `json:"signer,omitempty"`
+	Creator             string   `json:"creator"`
+	PublicKey           string   `json:"public_key"`
+	VerificationMethods []string `json:"verification_methods,omitempty"`
+	Services            []string `json:"services,omitempty"`
+	Controllers         []string `json:"controllers,omitempty"`
+	ControllerThreshold string   `json:"controller_threshold,omitempty"`
+	RotationDelay       string   `json:"rotation_delay,omitempty"`
+	ExpiresAt           string   `json:"expires_at,omitempty"`
+	ContentCID          string   `json:"content_cid,omitempty"`
+	Digest              string   `json:"digest"`
+}
+
+// newDocumentSignDoc summarizes doc for a sign doc. full is the message's
+// amino JSON.
+func newDocumentSignDoc(doc DIDDocument, creator sdk.AccAddress, full []byte) documentSignDoc {
+	sum := sha256.Sum256(full)
+	sd := documentSignDoc{
+		DID:         doc.ID,
+		Creator:     creator.String(),
+		PublicKey:   doc.PublicKey,
+		Controllers: doc.Controller,
+		ContentCID:  doc.ContentCID,
+		Digest:      hex.EncodeToString(sum[:]),
+	}
+	for _, vm := range doc.VerificationMethods {
+		key := vm.PublicKeyMultibase
+		if vm.Type == X509CertificateChainKey {
+			key = fmt.Sprintf("(%d certificates)", len(vm.X509CertificateChain))
+		}
+		if vm.Threshold > 0 {
+			key += fmt.Sprintf(" (%d of %d)", vm.Threshold, vm.Participants)
+		}
+		sd.VerificationMethods = append(sd.VerificationMethods, strings.TrimPrefix(vm.ID, doc.ID)+" "+vm.Type+" "+key)
+	}
+	for i, endpoint := range doc.ServiceEndpoints {
+		sd.Services = append(sd.Services, "#endpoint-"+strconv.Itoa(i+1)+" "+endpoint)
+	}
+	for _, svc := range doc.Services {
+		sd.Services = append(sd.Services, strings.TrimPrefix(svc.ID, doc.ID)+" "+svc.Type+" "+svc.ServiceEndpoint)
+	}
+	if doc.ControllerThreshold > 0 {
+		sd.ControllerThreshold = strconv.FormatUint(uint64(doc.ControllerThreshold), 10)
+	}
+	if doc.RotationDelay > 0 {
+		sd.RotationDelay = strconv.FormatUint(doc.RotationDelay, 10) + " blocks"
+	}
+	if doc.ExpiresAt > 0 {
+		sd.ExpiresAt = strconv.FormatInt(doc.ExpiresAt, 10)
+	}
+	return sd
+}
+
+// documentSignBytes returns the sorted amino JSON sign bytes of a document
+// sign doc under the message's amino name.
+func documentSignBytes(aminoName string, sd documentSignDoc) []byte {
+	bz, err := json.Marshal(struct {
+		Type  string          `json:"type"`
+		Value documentSignDoc `json:"value"`
+	}{aminoName, sd})
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
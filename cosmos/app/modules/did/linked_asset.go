@@ -0,0 +1,176 @@
+package did
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// AssetClassBank is the built-in asset class of bank coins: the asset ID is a
+// denom and the owner an account linked to the DID holding a positive
+// balance of it.
+const AssetClassBank = "bank"
+
+// LinkedAssetRecheckLimit bounds how many linked assets the end blocker
+// re-verifies per block.
+const LinkedAssetRecheckLimit = 50
+
+// AssetVerifier checks on-chain ownership of the assets of one class. Modules
+// holding assets, such as tokens, register a verifier with
+// Keeper.RegisterAssetVerifier so DIDs can link their assets, and call
+// Keeper.AfterAssetChanged when an asset changes hands or is destroyed.
+type AssetVerifier interface {
+	// OwnsAsset reports whether owner, a DID or a bech32 account address,
+	// currently owns the asset.
+	OwnsAsset(ctx sdk.Context, owner, assetID string) bool
+}
+
+// LinkedAsset records that the DID owns an on-chain asset of Class through
+// Owner, the DID itself or an account linked to it. ProofTx is the hash of
+// the transaction the asset was acquired in. Ownership is checked when the
+// asset is linked and re-checked as the asset changes; links whose ownership
+// no longer holds are pruned, so the linked assets of a DID can back a
+// portable reputation.
+type LinkedAsset struct {
+	DID     string `protobuf:"bytes,1,opt,name=did,proto3" json:"did"`
+	Class   string `protobuf:"bytes,2,opt,name=class,proto3" json:"class"`
+	AssetID string `protobuf:"bytes,3,opt,name=asset_id,proto3" json:"asset_id"`
+	Owner   string `protobuf:"bytes,4,opt,name=owner,proto3" json:"owner"`
+	ProofTx string `protobuf:"bytes,5,opt,name=proof_tx,proto3" json:"proof_tx,omitempty"`
+	Linked  int64  `protobuf:"varint,6,opt,name=linked,proto3" json:"linked"`
+}
+
+// validateLinkedAsset checks the fields shared by linked assets and the
+// messages that link them.
+func validateLinkedAsset(did, class, assetID, owner, proofTx string) error {
+	if did == "" || class == "" || assetID == "" || owner == "" {
+		return fmt.Errorf("DID, class, asset ID and owner cannot be empty")
+	}
+	if strings.Contains(did, "/") || strings.Contains(class, "/") {
+		return fmt.Errorf("DID and class cannot contain '/'")
+	}
+	if owner != did {
+		if _, err := sdk.AccAddressFromBech32(owner); err != nil {
+			return fmt.Errorf("owner %s must be the DID or an account address", owner)
+		}
+	}
+	if proofTx != "" {
+		if bz, err := hex.DecodeString(proofTx); err != nil || len(bz) != 32 {
+			return fmt.Errorf("proof tx %s must be a hex SHA-256 transaction hash", proofTx)
+		}
+	}
+	return nil
+}
+
+// MsgLinkAsset links an asset owned by the DID, or by an account linked to
+// it, to the DID. A controller of DID authorizes the link like a
+// MsgUpdateDID.
+type MsgLinkAsset struct {
+	DID       string         `protobuf:"bytes,1,opt,name=did,proto3" json:"did"`
+	Class     string         `protobuf:"bytes,2,opt,name=class,proto3" json:"class"`
+	AssetID   string         `protobuf:"bytes,3,opt,name=asset_id,proto3" json:"asset_id"`
+	Owner     string         `protobuf:"bytes,4,opt,name=owner,proto3" json:"owner"`
+	ProofTx   string         `protobuf:"bytes,5,opt,name=proof_tx,proto3" json:"proof_tx,omitempty"`
+	Nonce     uint64         `protobuf:"varint,6,opt,name=nonce,proto3" json:"nonce"`
+	Signer    string         `protobuf:"bytes,7,opt,name=signer,proto3" json:"signer"`
+	Signature []byte         `protobuf:"bytes,8,opt,name=signature,proto3" json:"signature,omitempty"`
+	Creator   sdk.AccAddress `protobuf:"bytes,9,opt,name=creator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"creator"`
+}
+
+// ValidateBasic performs basic validation of MsgLinkAsset.
+func (msg MsgLinkAsset) ValidateBasic() error {
+	if err := validateChecksums(msg); err != nil {
+		return err
+	}
+	if err := validateLinkedAsset(msg.DID, msg.Class, msg.AssetID, msg.Owner, msg.ProofTx); err != nil {
+		return sdkerrors.ErrUnknownRequest.Wrap(err.Error())
+	}
+	if msg.Signer == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Signer cannot be empty")
+	}
+	return nil
+}
+
+// ProofSignBytes returns the bytes a controller DID signs to authorize the
+// link.
+func (msg MsgLinkAsset) ProofSignBytes() []byte {
+	msg.Signature = nil
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// Route returns the message route.
+func (msg MsgLinkAsset) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgLinkAsset) Type() string { return "link_asset" }
+
+// GetSignBytes returns the canonical bytes to sign over.
+func (msg MsgLinkAsset) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners returns the account that must sign the message.
+func (msg MsgLinkAsset) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
+
+// MsgUnlinkAsset removes a linked asset from a DID. Authorization works as
+// for MsgLinkAsset.
+type MsgUnlinkAsset struct {
+	DID       string         `protobuf:"bytes,1,opt,name=did,proto3" json:"did"`
+	Class     string         `protobuf:"bytes,2,opt,name=class,proto3" json:"class"`
+	AssetID   string         `protobuf:"bytes,3,opt,name=asset_id,proto3" json:"asset_id"`
+	Nonce     uint64         `protobuf:"varint,4,opt,name=nonce,proto3" json:"nonce"`
+	Signer    string         `protobuf:"bytes,5,opt,name=signer,proto3" json:"signer"`
+	Signature []byte         `protobuf:"bytes,6,opt,name=signature,proto3" json:"signature,omitempty"`
+	Creator   sdk.AccAddress `protobuf:"bytes,7,opt,name=creator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"creator"`
+}
+
+// ValidateBasic performs basic validation of MsgUnlinkAsset.
+func (msg MsgUnlinkAsset) ValidateBasic() error {
+	if err := validateChecksums(msg); err != nil {
+		return err
+	}
+	if msg.DID == "" || msg.Class == "" || msg.AssetID == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("DID, class and asset ID cannot be empty")
+	}
+	if msg.Signer == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Signer cannot be empty")
+	}
+	return nil
+}
+
+// ProofSignBytes returns the bytes a controller DID signs to authorize the
+// removal.
+func (msg MsgUnlinkAsset) ProofSignBytes() []byte {
+	msg.Signature = nil
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// Route returns the message route.
+func (msg MsgUnlinkAsset) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgUnlinkAsset) Type() string { return "unlink_asset" }
+
+// GetSignBytes returns the canonical bytes to sign over.
+func (msg MsgUnlinkAsset) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners returns the account that must sign the message.
+func (msg MsgUnlinkAsset) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
@@ -0,0 +1,207 @@
+package did
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// bankAssetVerifier verifies AssetClassBank assets: the owner account must
+// hold a positive balance of the denom.
+type bankAssetVerifier struct {
+	bankKeeper BankKeeper
+}
+
+func (v bankAssetVerifier) OwnsAsset(ctx sdk.Context, owner, denom string) bool {
+	addr, err := sdk.AccAddressFromBech32(owner)
+	if err != nil {
+		return false
+	}
+	return v.bankKeeper.GetBalance(ctx, addr, denom).IsPositive()
+}
+
+// RegisterAssetVerifier registers the verifier of an asset class. It must be
+// called while the app is wired, before any block is processed; copies of the
+// keeper share the registration.
+func (k Keeper) RegisterAssetVerifier(class string, v AssetVerifier) {
+	if _, found := k.assetVerifiers[class]; found {
+		panic(fmt.Sprintf("asset verifier for class %s already registered", class))
+	}
+	k.assetVerifiers[class] = v
+}
+
+// verifyLinkedAsset checks that the owner of a linked asset is the DID or an
+// account linked to it, and that it still owns the asset.
+func (k Keeper) verifyLinkedAsset(ctx sdk.Context, asset LinkedAsset) error {
+	v, found := k.assetVerifiers[asset.Class]
+	if !found {
+		return fmt.Errorf("unknown asset class %s", asset.Class)
+	}
+	if asset.Owner != asset.DID {
+		addr, err := sdk.AccAddressFromBech32(asset.Owner)
+		if err != nil {
+			return err
+		}
+		if linked, found := k.DIDByAddress(ctx, addr); !found || linked != asset.DID {
+			return fmt.Errorf("account %s is not linked to %s", asset.Owner, asset.DID)
+		}
+	}
+	if !v.OwnsAsset(ctx, asset.Owner, asset.AssetID) {
+		return fmt.Errorf("%s does not own %s asset %s", asset.Owner, asset.Class, asset.AssetID)
+	}
+	return nil
+}
+
+// LinkAsset verifies ownership of an asset and links it to an active DID,
+// replacing any earlier link of the same asset. Callers must have authorized
+// the link.
+func (k Keeper) LinkAsset(ctx sdk.Context, asset LinkedAsset) error {
+	did, err := k.GetDID(ctx, asset.DID)
+	if err != nil {
+		return err
+	}
+	if did.Deactivated {
+		return fmt.Errorf("DID %s is deactivated", asset.DID)
+	}
+	if err := validateLinkedAsset(asset.DID, asset.Class, asset.AssetID, asset.Owner, asset.ProofTx); err != nil {
+		return err
+	}
+	if err := k.verifyLinkedAsset(ctx, asset); err != nil {
+		return err
+	}
+	asset.Linked = ctx.BlockHeight()
+	k.setLinkedAsset(ctx, asset)
+	ctx.EventManager().EmitEvent(sdk.NewEvent("asset_linked",
+		sdk.NewAttribute("did", asset.DID),
+		sdk.NewAttribute("class", asset.Class),
+		sdk.NewAttribute("asset_id", asset.AssetID),
+		sdk.NewAttribute("owner", asset.Owner),
+	))
+	return nil
+}
+
+// UnlinkAsset removes a linked asset from a DID.
+func (k Keeper) UnlinkAsset(ctx sdk.Context, id, class, assetID string) error {
+	asset, found := k.GetLinkedAsset(ctx, id, class, assetID)
+	if !found {
+		return fmt.Errorf("%s asset %s is not linked to %s", class, assetID, id)
+	}
+	k.deleteLinkedAsset(ctx, asset)
+	ctx.EventManager().EmitEvent(sdk.NewEvent("asset_unlinked",
+		sdk.NewAttribute("did", id),
+		sdk.NewAttribute("class", class),
+		sdk.NewAttribute("asset_id", assetID),
+	))
+	return nil
+}
+
+// AfterAssetChanged re-verifies every link of an asset and prunes those
+// whose ownership no longer holds. Modules call it when an asset of a class
+// they verify changes hands or is destroyed.
+func (k Keeper) AfterAssetChanged(ctx sdk.Context, class, assetID string) {
+	prefix := assetLinksPrefix(class, assetID)
+	var dids []string
+	iteratePrefix(ctx.KVStore(k.storeKey), prefix, func(key, _ []byte) bool {
+		// Skip links of longer asset IDs sharing this one as a prefix; DIDs
+		// cannot contain '/'.
+		if !strings.Contains(string(key), "/") {
+			dids = append(dids, string(key))
+		}
+		return false
+	})
+	for _, id := range dids {
+		if asset, found := k.GetLinkedAsset(ctx, id, class, assetID); found {
+			k.recheckLinkedAsset(ctx, asset)
+		}
+	}
+}
+
+// RecheckLinkedAssets re-verifies up to LinkedAssetRecheckLimit linked
+// assets, resuming after the last one checked, and prunes stale links. It
+// catches changes no hook reports, such as bank transfers and unlinked
+// accounts, within a bounded number of blocks.
+func (k Keeper) RecheckLinkedAssets(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	start := LinkedAssetPrefix
+	if cursor := store.Get(LinkedAssetCursorKey); cursor != nil {
+		start = append(cursor, 0)
+	}
+	var assets []LinkedAsset
+	var last []byte
+	iter := store.Iterator(start, sdk.PrefixEndBytes(LinkedAssetPrefix))
+	for ; iter.Valid() && len(assets) < LinkedAssetRecheckLimit; iter.Next() {
+		var asset LinkedAsset
+		k.cdc.MustUnmarshal(iter.Value(), &asset)
+		assets = append(assets, asset)
+		last = append([]byte{}, iter.Key()...)
+	}
+	iter.Close()
+
+	if len(assets) < LinkedAssetRecheckLimit {
+		store.Delete(LinkedAssetCursorKey)
+	} else {
+		store.Set(LinkedAssetCursorKey, last)
+	}
+	for _, asset := range assets {
+		k.recheckLinkedAsset(ctx, asset)
+	}
+}
+
+func (k Keeper) recheckLinkedAsset(ctx sdk.Context, asset LinkedAsset) {
+	err := k.verifyLinkedAsset(ctx, asset)
+	if err == nil {
+		return
+	}
+	k.deleteLinkedAsset(ctx, asset)
+	ctx.EventManager().EmitEvent(sdk.NewEvent("linked_asset_pruned",
+		sdk.NewAttribute("did", asset.DID),
+		sdk.NewAttribute("class", asset.Class),
+		sdk.NewAttribute("asset_id", asset.AssetID),
+		sdk.NewAttribute("reason", err.Error()),
+	))
+}
+
+// GetLinkedAsset returns a linked asset of a DID.
+func (k Keeper) GetLinkedAsset(ctx sdk.Context, id, class, assetID string) (LinkedAsset, bool) {
+	value := ctx.KVStore(k.storeKey).Get(linkedAssetKey(id, class, assetID))
+	if value == nil {
+		return LinkedAsset{}, false
+	}
+	var asset LinkedAsset
+	k.cdc.MustUnmarshal(value, &asset)
+	return asset, true
+}
+
+// GetLinkedAssets returns the assets linked to a DID.
+func (k Keeper) GetLinkedAssets(ctx sdk.Context, id string) []LinkedAsset {
+	return k.linkedAssets(ctx, linkedAssetsPrefix(id))
+}
+
+// GetAllLinkedAssets returns every linked asset in the store.
+func (k Keeper) GetAllLinkedAssets(ctx sdk.Context) []LinkedAsset {
+	return k.linkedAssets(ctx, LinkedAssetPrefix)
+}
+
+func (k Keeper) linkedAssets(ctx sdk.Context, prefix []byte) []LinkedAsset {
+	var assets []LinkedAsset
+	iteratePrefix(ctx.KVStore(k.storeKey), prefix, func(_, value []byte) bool {
+		var asset LinkedAsset
+		k.cdc.MustUnmarshal(value, &asset)
+		assets = append(assets, asset)
+		return false
+	})
+	return assets
+}
+
+func (k Keeper) setLinkedAsset(ctx sdk.Context, asset LinkedAsset) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(linkedAssetKey(asset.DID, asset.Class, asset.AssetID), k.cdc.MustMarshal(&asset))
+	store.Set(assetLinkKey(asset.Class, asset.AssetID, asset.DID), []byte{})
+}
+
+func (k Keeper) deleteLinkedAsset(ctx sdk.Context, asset LinkedAsset) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(linkedAssetKey(asset.DID, asset.Class, asset.AssetID))
+	store.Delete(assetLinkKey(asset.Class, asset.AssetID, asset.DID))
+}
@@ -0,0 +1,69 @@
+package did
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// MethodSignature is a signature by a named verification method of a DID,
+// used in place of a raw signature by the DID's authentication key wherever
+// a DID signs an operation. It is sent as its JSON encoding, which can't be
+// mistaken for a raw Ed25519 signature. Threshold keys put their aggregated
+// signature in Signature; passkeys send a WebAuthnAssertion.
+type MethodSignature struct {
+	VerificationMethod string `json:"verificationMethod"`
+	Signature          []byte `json:"signature"`
+}
+
+// parseMethodSignature decodes signature as a MethodSignature. Ed25519
+// signatures are raw bytes, so only JSON objects naming a method are taken.
+func parseMethodSignature(signature []byte) (MethodSignature, bool) {
+	var ms MethodSignature
+	if len(signature) == 0 || signature[0] != '{' {
+		return ms, false
+	}
+	if err := json.Unmarshal(signature, &ms); err != nil || ms.VerificationMethod == "" {
+		return ms, false
+	}
+	return ms, true
+}
+
+// signingMethod returns the method of did a MethodSignature names. Methods
+// are identified by their full DID URL.
+func signingMethod(did DIDDocument, id string) (VerificationMethod, error) {
+	if !strings.HasPrefix(id, did.ID+"#") {
+		return VerificationMethod{}, fmt.Errorf("%s is not a verification method of %s", id, did.ID)
+	}
+	vm, ok := did.VerificationMethod(id)
+	if !ok {
+		return VerificationMethod{}, fmt.Errorf("verification method %s not found", id)
+	}
+	return vm, nil
+}
+
+// canSignDIDOperations reports whether verification methods of keyType can
+// sign for their DID with a MethodSignature.
+func canSignDIDOperations(keyType string) bool {
+	return keyType == WebAuthnP256Key || isThresholdKey(keyType)
+}
+
+// verifyMethodSignature checks the encoded MethodSignature signature over
+// signBytes by vm, in the form its type signs in.
+func verifyMethodSignature(vm VerificationMethod, signBytes, signature []byte) error {
+	switch {
+	case vm.Type == WebAuthnP256Key:
+		var a WebAuthnAssertion
+		if err := json.Unmarshal(signature, &a); err != nil {
+			return fmt.Errorf("invalid WebAuthn assertion: %w", err)
+		}
+		return VerifyWebAuthnAssertion(vm, signBytes, a)
+	case isThresholdKey(vm.Type):
+		var ms MethodSignature
+		if err := json.Unmarshal(signature, &ms); err != nil {
+			return fmt.Errorf("invalid method signature: %w", err)
+		}
+		return VerifyThresholdSignature(vm, signBytes, ms.Signature)
+	}
+	return fmt.Errorf("verification method %s of type %s can't sign DID operations", vm.ID, vm.Type)
+}
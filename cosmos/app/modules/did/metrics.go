@@ -0,0 +1,53 @@
+package did
+
+import (
+	"sync/atomic"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Telemetry keys. With telemetry enabled in app.toml they're exported on the
+// node's Prometheus endpoint prefixed with the module name, e.g. did_writes.
+const (
+	MetricKeyWrites       = "writes"
+	MetricKeyDocumentSize = "document_size"
+	MetricKeyOpsPerBlock  = "ops_per_block"
+	MetricKeyResolve      = "resolve"
+)
+
+// blockStats counts the document writes of the block being executed. It is
+// only used for telemetry and never affects state.
+type blockStats struct {
+	ops uint64
+}
+
+// recordWrite counts a document write and samples its encoded size. Writes
+// made while checking or simulating transactions are ignored.
+func (k Keeper) recordWrite(ctx sdk.Context, op string, size int) {
+	if ctx.IsCheckTx() {
+		return
+	}
+	labels := []metrics.Label{telemetry.NewLabel("operation", op)}
+	telemetry.IncrCounterWithLabels([]string{ModuleName, MetricKeyWrites}, 1, labels)
+	metrics.AddSampleWithLabels([]string{ModuleName, MetricKeyDocumentSize}, float32(size), labels)
+	if k.stats != nil {
+		atomic.AddUint64(&k.stats.ops, 1)
+	}
+}
+
+// recordBlockStats reports the number of writes in the block and resets the
+// count. It runs last in the EndBlocker so writes made there are included.
+func (k Keeper) recordBlockStats() {
+	if k.stats == nil {
+		return
+	}
+	telemetry.ModuleSetGauge(ModuleName, float32(atomic.SwapUint64(&k.stats.ops, 0)), MetricKeyOpsPerBlock)
+}
+
+// measureResolve records the latency of a query, labelled by query name.
+func measureResolve(query string, start time.Time) {
+	telemetry.ModuleMeasureSince(ModuleName, start, ModuleName, MetricKeyResolve, query)
+}
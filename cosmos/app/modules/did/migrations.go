@@ -0,0 +1,129 @@
+package did
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Migrator moves the DID store between consensus versions.
+type Migrator struct {
+	keeper Keeper
+}
+
+// NewMigrator returns a Migrator for the given keeper.
+func NewMigrator(k Keeper) Migrator {
+	return Migrator{keeper: k}
+}
+
+// Migrate1to2 moves the documents of consensus version 1, which stored each
+// document under its bare DID in the single-key schema and nothing else,
+// into the documents section in the W3C schema, along with their integrity
+// digests, expiry index and the registry counters.
+func (m Migrator) Migrate1to2(ctx sdk.Context) error {
+	store := ctx.KVStore(m.keeper.storeKey)
+	var dids []DIDDocument
+	iteratePrefix(store, []byte("did:"), func(_, value []byte) bool {
+		var did DIDDocument
+		m.keeper.cdc.MustUnmarshal(value, &did)
+		dids = append(dids, did)
+		return false
+	})
+	for _, did := range dids {
+		store.Delete([]byte(did.ID))
+		migrated, err := MigrateLegacyDocument(did)
+		if err != nil {
+			return err
+		}
+		m.keeper.importDID(ctx, migrated)
+	}
+	return nil
+}
+
+// MigrateLegacyDocument rewrites a document in the original single-key
+// schema, which described the DID by a base64 PublicKey, an Authentication
+// string and bare ServiceEndpoints, into the W3C schema of verification
+// methods and services. The key becomes the DID's first verification method
+// and authenticates it, and each endpoint becomes a service with the ID and
+// type it was already resolved with, so the W3C rendering doesn't change.
+// Documents already in the W3C schema are returned as they are.
+func MigrateLegacyDocument(did DIDDocument) (DIDDocument, error) {
+	if len(did.VerificationMethods) == 0 && did.PublicKey != "" {
+		pub, err := did.Ed25519PublicKey()
+		if err != nil {
+			return DIDDocument{}, fmt.Errorf("%s: %w", did.ID, err)
+		}
+		keyDoc := DocumentFromKey(did.ID, EncodeMultibaseKey(MulticodecEd25519Pub, pub), pub)
+		did.VerificationMethods = keyDoc.VerificationMethods
+		did.Authentication = keyDoc.Authentication
+	}
+	for i, endpoint := range did.ServiceEndpoints {
+		did.Services = append(did.Services, Service{
+			ID:              did.ID + "#endpoint-" + strconv.Itoa(i+1),
+			Type:            "ServiceEndpoint",
+			ServiceEndpoint: endpoint,
+		})
+	}
+	did.ServiceEndpoints = nil
+	return did, nil
+}
+
+// MigrateGenesis rewrites the DID module's exported genesis state from the
+// single-key schema: every document goes through MigrateLegacyDocument, and
+// params added since the export take their defaults.
+func MigrateGenesis(cdc codec.JSONCodec, bz json.RawMessage) (json.RawMessage, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(bz, &raw); err != nil {
+		return nil, err
+	}
+	params, err := withDefaultParams(cdc, raw["params"])
+	if err != nil {
+		return nil, fmt.Errorf("params: %w", err)
+	}
+	raw["params"] = params
+	merged, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	var gs GenesisState
+	if err := cdc.UnmarshalJSON(merged, &gs); err != nil {
+		return nil, err
+	}
+	for i, did := range gs.DIDs {
+		if gs.DIDs[i], err = MigrateLegacyDocument(did); err != nil {
+			return nil, err
+		}
+	}
+	if err := ValidateGenesis(gs); err != nil {
+		return nil, fmt.Errorf("migrated genesis is invalid: %w", err)
+	}
+	return cdc.MarshalJSON(&gs)
+}
+
+// withDefaultParams fills the params missing from an exported params object
+// with their defaults.
+func withDefaultParams(cdc codec.JSONCodec, bz json.RawMessage) (json.RawMessage, error) {
+	defaults := DefaultParams()
+	defaultsJSON, err := cdc.MarshalJSON(&defaults)
+	if err != nil {
+		return nil, err
+	}
+	params := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(defaultsJSON, &params); err != nil {
+		return nil, err
+	}
+	if len(bz) > 0 {
+		var exported map[string]json.RawMessage
+		if err := json.Unmarshal(bz, &exported); err != nil {
+			return nil, err
+		}
+		for k, v := range exported {
+			params[k] = v
+		}
+	}
+	return json.Marshal(params)
+}
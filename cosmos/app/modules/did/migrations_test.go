@@ -0,0 +1,51 @@
+package did
+
+import (
+	"testing"
+)
+
+func TestMigrate1to2(t *testing.T) {
+	k, ctx, _, _ := testKeeper(t)
+	legacy := newTestSigner("alice").document()
+	legacy.ServiceEndpoints = []string{"https://example.com/alice"}
+	store := ctx.KVStore(k.storeKey)
+	store.Set([]byte(legacy.ID), k.cdc.MustMarshal(&legacy))
+
+	if err := NewMigrator(k).Migrate1to2(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if store.Has([]byte(legacy.ID)) {
+		t.Error("legacy key not deleted")
+	}
+	got, err := k.GetDID(ctx, legacy.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.VerificationMethods) != 1 || got.VerificationMethods[0].Type != Ed25519VerificationKey2020 {
+		t.Errorf("verification methods = %+v, want the legacy key", got.VerificationMethods)
+	}
+	if len(got.ServiceEndpoints) != 0 || len(got.Services) != 1 || got.Services[0].ServiceEndpoint != legacy.ServiceEndpoints[0] {
+		t.Errorf("services = %+v, endpoints %v", got.Services, got.ServiceEndpoints)
+	}
+	if got.Services[0].ID != legacy.ID+"#endpoint-1" {
+		t.Errorf("service ID = %s", got.Services[0].ID)
+	}
+	if _, found := k.GetDigest(ctx, legacy.ID); !found {
+		t.Error("no digest stored for the migrated document")
+	}
+
+	// Running it again finds nothing left to move.
+	if err := NewMigrator(k).Migrate1to2(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(k.GetAllDIDs(ctx)); n != 1 {
+		t.Errorf("%d documents after a second run, want 1", n)
+	}
+}
+
+func TestMigrateLegacyDocumentRejectsBadKey(t *testing.T) {
+	legacy := DIDDocument{ID: "did:aytch:bad", PublicKey: "not base64!"}
+	if _, err := MigrateLegacyDocument(legacy); err == nil {
+		t.Error("legacy document with an undecodable key migrated")
+	}
+}
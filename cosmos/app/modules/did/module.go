@@ -1,15 +1,21 @@
 package did
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/codec"
 	cdctypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/telemetry"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/module"
 	"github.com/gorilla/mux"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
 	"github.com/spf13/cobra"
+	abci "github.com/tendermint/tendermint/abci/types"
 )
 
 var (
@@ -27,12 +33,12 @@ func (AppModuleBasic) Name() string {
 
 // RegisterLegacyAminoCodec registers the DID module's types on the given LegacyAmino codec.
 func (AppModuleBasic) RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) {
-	cdc.RegisterConcrete(MsgCreateDID{}, "did/CreateDID", nil)
+	RegisterLegacyAminoCodec(cdc)
 }
 
 // RegisterInterfaces registers the DID module's interface types
 func (AppModuleBasic) RegisterInterfaces(registry cdctypes.InterfaceRegistry) {
-	// registry.RegisterImplementations((*sdk.Msg)(nil), &MsgCreateDID{})
+	RegisterInterfaces(registry)
 }
 
 // DefaultGenesis returns default genesis state as raw bytes for the DID module.
@@ -56,7 +62,9 @@ func (AppModuleBasic) RegisterRESTRoutes(clientCtx client.Context, rtr *mux.Rout
 
 // RegisterGRPCGatewayRoutes registers the gRPC Gateway routes for the DID module.
 func (AppModuleBasic) RegisterGRPCGatewayRoutes(clientCtx client.Context, mux *runtime.ServeMux) {
-	// Not implemented for this simple example
+	if err := RegisterQueryHandlerClient(context.Background(), mux, NewQueryClient(clientCtx)); err != nil {
+		panic(err)
+	}
 }
 
 // GetTxCmd returns the root tx command for the DID module.
@@ -72,14 +80,16 @@ func (AppModuleBasic) GetQueryCmd() *cobra.Command {
 // AppModule implements an application module for the DID module.
 type AppModule struct {
 	AppModuleBasic
-	keeper Keeper
+	keeper        Keeper
+	accountKeeper AccountKeeper
 }
 
 // NewAppModule creates a new AppModule object
-func NewAppModule(k Keeper) AppModule {
+func NewAppModule(k Keeper, ak AccountKeeper) AppModule {
 	return AppModule{
 		AppModuleBasic: AppModuleBasic{},
 		keeper:         k,
+		accountKeeper:  ak,
 	}
 }
 
@@ -89,7 +99,9 @@ func (AppModule) Name() string {
 }
 
 // RegisterInvariants registers the DID module invariants.
-func (AppModule) RegisterInvariants(_ sdk.InvariantRegistry) {}
+func (am AppModule) RegisterInvariants(ir sdk.InvariantRegistry) {
+	RegisterInvariants(ir, am.keeper)
+}
 
 // Route returns the message routing key for the DID module.
 func (am AppModule) Route() sdk.Route {
@@ -101,6 +113,21 @@ func (AppModule) QuerierRoute() string {
 	return "did"
 }
 
+// LegacyQuerierHandler returns the DID module's legacy querier.
+func (am AppModule) LegacyQuerierHandler(legacyQuerierCdc *codec.LegacyAmino) sdk.Querier {
+	return NewQuerier(am.keeper, legacyQuerierCdc)
+}
+
+// RegisterServices registers the DID module's Msg and Query services.
+func (am AppModule) RegisterServices(cfg module.Configurator) {
+	RegisterMsgServer(cfg.MsgServer(), NewMsgServerImpl(am.keeper))
+	RegisterQueryServer(cfg.QueryServer(), NewQueryServerImpl(am.keeper))
+
+	if err := cfg.RegisterMigration(ModuleName, 1, NewMigrator(am.keeper).Migrate1to2); err != nil {
+		panic(fmt.Sprintf("failed to register %s migration from version 1: %v", ModuleName, err))
+	}
+}
+
 // InitGenesis performs genesis initialization for the DID module.
 func (am AppModule) InitGenesis(ctx sdk.Context, cdc codec.JSONCodec, data json.RawMessage) []abci.ValidatorUpdate {
 	var genesisState GenesisState
@@ -115,10 +142,31 @@ func (am AppModule) ExportGenesis(ctx sdk.Context, cdc codec.JSONCodec) json.Raw
 	return cdc.MustMarshalJSON(gs)
 }
 
-// BeginBlock returns the begin blocker for the DID module.
-func (AppModule) BeginBlock(_ sdk.Context, _ abci.RequestBeginBlock) {}
-
-// EndBlock returns the end blocker for the DID module.
-func (AppModule) EndBlock(_ sdk.Context, _ abci.RequestEndBlock) []abci.ValidatorUpdate {
+// ConsensusVersion implements AppModule/ConsensusVersion.
+func (AppModule) ConsensusVersion() uint64 { return 2 }
+
+// BeginBlock returns the begin blocker for the DID module. The previous block
+// has been committed by now, so documents it wrote may be cached again.
+func (am AppModule) BeginBlock(ctx sdk.Context, _ abci.RequestBeginBlock) {
+	am.keeper.commitCache(ctx)
+}
+
+// EndBlock returns the end blocker for the DID module. It prunes pending
+// multi-controller changes that expired without enough approvals and
+// capability grants past their expiry, applies or drops social recoveries and
+// time-locked key rotations that are due, deactivates expired DIDs,
+// re-verifies a batch of linked assets and refunds matured registration
+// deposits.
+func (am AppModule) EndBlock(ctx sdk.Context, _ abci.RequestEndBlock) []abci.ValidatorUpdate {
+	defer telemetry.ModuleMeasureSince(ModuleName, time.Now(), telemetry.MetricKeyEndBlocker)
+
+	am.keeper.PruneExpiredDIDChanges(ctx)
+	am.keeper.PruneExpiredCapabilities(ctx)
+	am.keeper.ProcessRecoveries(ctx)
+	am.keeper.ApplyKeyRotations(ctx)
+	am.keeper.ExpireDIDs(ctx)
+	am.keeper.RecheckLinkedAssets(ctx)
+	am.keeper.RefundMatureDeposits(ctx)
+	am.keeper.recordBlockStats()
 	return []abci.ValidatorUpdate{}
 }
@@ -0,0 +1,1037 @@
+package did
+
+import (
+	"context"
+	"errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	grpc1 "github.com/gogo/protobuf/grpc"
+	"google.golang.org/grpc"
+)
+
+// MsgServer is the server API for the aytch.did.v1.Msg service. Messages
+// routed through the Msg service router (including those executed by an
+// interchain account) are handled here.
+type MsgServer interface {
+	CreateDID(context.Context, *MsgCreateDID) (*MsgCreateDIDResponse, error)
+	RevokeCredential(context.Context, *MsgRevokeCredential) (*MsgRevokeCredentialResponse, error)
+	CreateResource(context.Context, *MsgCreateResource) (*MsgCreateResourceResponse, error)
+	UpdateDID(context.Context, *MsgUpdateDID) (*MsgUpdateDIDResponse, error)
+	DeactivateDID(context.Context, *MsgDeactivateDID) (*MsgDeactivateDIDResponse, error)
+	ProposeDIDChange(context.Context, *MsgProposeDIDChange) (*MsgProposeDIDChangeResponse, error)
+	ApproveDIDChange(context.Context, *MsgApproveDIDChange) (*MsgApproveDIDChangeResponse, error)
+	SponsorDID(context.Context, *MsgSponsorDID) (*MsgSponsorDIDResponse, error)
+	SetGuardians(context.Context, *MsgSetGuardians) (*MsgSetGuardiansResponse, error)
+	RecoverDID(context.Context, *MsgRecoverDID) (*MsgRecoverDIDResponse, error)
+	CancelRecovery(context.Context, *MsgCancelRecovery) (*MsgCancelRecoveryResponse, error)
+	RotateKey(context.Context, *MsgRotateKey) (*MsgRotateKeyResponse, error)
+	CancelKeyRotation(context.Context, *MsgCancelKeyRotation) (*MsgCancelKeyRotationResponse, error)
+	RenewDID(context.Context, *MsgRenewDID) (*MsgRenewDIDResponse, error)
+	CreateDIDFromKey(context.Context, *MsgCreateDIDFromKey) (*MsgCreateDIDFromKeyResponse, error)
+	CreateDIDBatch(context.Context, *MsgCreateDIDBatch) (*MsgCreateDIDBatchResponse, error)
+	CommitDID(context.Context, *MsgCommitDID) (*MsgCommitDIDResponse, error)
+	LinkAccount(context.Context, *MsgLinkAccount) (*MsgLinkAccountResponse, error)
+	UnlinkAccount(context.Context, *MsgUnlinkAccount) (*MsgUnlinkAccountResponse, error)
+	LinkEthereumAccount(context.Context, *MsgLinkEthereumAccount) (*MsgLinkEthereumAccountResponse, error)
+	UnlinkEthereumAccount(context.Context, *MsgUnlinkEthereumAccount) (*MsgUnlinkEthereumAccountResponse, error)
+	GrantCapability(context.Context, *MsgGrantCapability) (*MsgGrantCapabilityResponse, error)
+	RevokeCapability(context.Context, *MsgRevokeCapability) (*MsgRevokeCapabilityResponse, error)
+	LinkAsset(context.Context, *MsgLinkAsset) (*MsgLinkAssetResponse, error)
+	UnlinkAsset(context.Context, *MsgUnlinkAsset) (*MsgUnlinkAssetResponse, error)
+	RegisterCredentialStatus(context.Context, *MsgRegisterCredentialStatus) (*MsgRegisterCredentialStatusResponse, error)
+	RevokeCredentials(context.Context, *MsgRevokeCredentials) (*MsgRevokeCredentialsResponse, error)
+	SetPresentationDefinition(context.Context, *MsgSetPresentationDefinition) (*MsgSetPresentationDefinitionResponse, error)
+	DeletePresentationDefinition(context.Context, *MsgDeletePresentationDefinition) (*MsgDeletePresentationDefinitionResponse, error)
+	AnchorHash(context.Context, *MsgAnchorHash) (*MsgAnchorHashResponse, error)
+	AnchorCredentialRoot(context.Context, *MsgAnchorCredentialRoot) (*MsgAnchorCredentialRootResponse, error)
+}
+
+// MsgCreateDIDResponse is the response type for Msg/CreateDID.
+type MsgCreateDIDResponse struct{}
+
+func (m *MsgCreateDIDResponse) Reset()         { *m = MsgCreateDIDResponse{} }
+func (m *MsgCreateDIDResponse) String() string { return "MsgCreateDIDResponse" }
+func (*MsgCreateDIDResponse) ProtoMessage()    {}
+
+// MsgRevokeCredentialResponse is the response type for Msg/RevokeCredential.
+type MsgRevokeCredentialResponse struct{}
+
+func (m *MsgRevokeCredentialResponse) Reset()         { *m = MsgRevokeCredentialResponse{} }
+func (m *MsgRevokeCredentialResponse) String() string { return "MsgRevokeCredentialResponse" }
+func (*MsgRevokeCredentialResponse) ProtoMessage()    {}
+
+// MsgCreateResourceResponse is the response type for Msg/CreateResource.
+type MsgCreateResourceResponse struct{}
+
+func (m *MsgCreateResourceResponse) Reset()         { *m = MsgCreateResourceResponse{} }
+func (m *MsgCreateResourceResponse) String() string { return "MsgCreateResourceResponse" }
+func (*MsgCreateResourceResponse) ProtoMessage()    {}
+
+// MsgUpdateDIDResponse is the response type for Msg/UpdateDID.
+type MsgUpdateDIDResponse struct{}
+
+func (m *MsgUpdateDIDResponse) Reset()         { *m = MsgUpdateDIDResponse{} }
+func (m *MsgUpdateDIDResponse) String() string { return "MsgUpdateDIDResponse" }
+func (*MsgUpdateDIDResponse) ProtoMessage()    {}
+
+// MsgDeactivateDIDResponse is the response type for Msg/DeactivateDID.
+type MsgDeactivateDIDResponse struct{}
+
+func (m *MsgDeactivateDIDResponse) Reset()         { *m = MsgDeactivateDIDResponse{} }
+func (m *MsgDeactivateDIDResponse) String() string { return "MsgDeactivateDIDResponse" }
+func (*MsgDeactivateDIDResponse) ProtoMessage()    {}
+
+// MsgProposeDIDChangeResponse is the response type for Msg/ProposeDIDChange.
+type MsgProposeDIDChangeResponse struct {
+	ChangeID uint64 `protobuf:"varint,1,opt,name=change_id,proto3" json:"change_id"`
+}
+
+func (m *MsgProposeDIDChangeResponse) Reset()         { *m = MsgProposeDIDChangeResponse{} }
+func (m *MsgProposeDIDChangeResponse) String() string { return "MsgProposeDIDChangeResponse" }
+func (*MsgProposeDIDChangeResponse) ProtoMessage()    {}
+
+// MsgApproveDIDChangeResponse is the response type for Msg/ApproveDIDChange.
+type MsgApproveDIDChangeResponse struct{}
+
+func (m *MsgApproveDIDChangeResponse) Reset()         { *m = MsgApproveDIDChangeResponse{} }
+func (m *MsgApproveDIDChangeResponse) String() string { return "MsgApproveDIDChangeResponse" }
+func (*MsgApproveDIDChangeResponse) ProtoMessage()    {}
+
+// MsgSponsorDIDResponse is the response type for Msg/SponsorDID.
+type MsgSponsorDIDResponse struct{}
+
+func (m *MsgSponsorDIDResponse) Reset()         { *m = MsgSponsorDIDResponse{} }
+func (m *MsgSponsorDIDResponse) String() string { return "MsgSponsorDIDResponse" }
+func (*MsgSponsorDIDResponse) ProtoMessage()    {}
+
+// MsgSetGuardiansResponse is the response type for Msg/SetGuardians.
+type MsgSetGuardiansResponse struct{}
+
+func (m *MsgSetGuardiansResponse) Reset()         { *m = MsgSetGuardiansResponse{} }
+func (m *MsgSetGuardiansResponse) String() string { return "MsgSetGuardiansResponse" }
+func (*MsgSetGuardiansResponse) ProtoMessage()    {}
+
+// MsgRecoverDIDResponse is the response type for Msg/RecoverDID.
+type MsgRecoverDIDResponse struct{}
+
+func (m *MsgRecoverDIDResponse) Reset()         { *m = MsgRecoverDIDResponse{} }
+func (m *MsgRecoverDIDResponse) String() string { return "MsgRecoverDIDResponse" }
+func (*MsgRecoverDIDResponse) ProtoMessage()    {}
+
+// MsgCancelRecoveryResponse is the response type for Msg/CancelRecovery.
+type MsgCancelRecoveryResponse struct{}
+
+func (m *MsgCancelRecoveryResponse) Reset()         { *m = MsgCancelRecoveryResponse{} }
+func (m *MsgCancelRecoveryResponse) String() string { return "MsgCancelRecoveryResponse" }
+func (*MsgCancelRecoveryResponse) ProtoMessage()    {}
+
+// MsgRotateKeyResponse is the response type for Msg/RotateKey.
+type MsgRotateKeyResponse struct{}
+
+func (m *MsgRotateKeyResponse) Reset()         { *m = MsgRotateKeyResponse{} }
+func (m *MsgRotateKeyResponse) String() string { return "MsgRotateKeyResponse" }
+func (*MsgRotateKeyResponse) ProtoMessage()    {}
+
+// MsgCancelKeyRotationResponse is the response type for Msg/CancelKeyRotation.
+type MsgCancelKeyRotationResponse struct{}
+
+func (m *MsgCancelKeyRotationResponse) Reset()         { *m = MsgCancelKeyRotationResponse{} }
+func (m *MsgCancelKeyRotationResponse) String() string { return "MsgCancelKeyRotationResponse" }
+func (*MsgCancelKeyRotationResponse) ProtoMessage()    {}
+
+// MsgRenewDIDResponse is the response type for Msg/RenewDID.
+type MsgRenewDIDResponse struct{}
+
+func (m *MsgRenewDIDResponse) Reset()         { *m = MsgRenewDIDResponse{} }
+func (m *MsgRenewDIDResponse) String() string { return "MsgRenewDIDResponse" }
+func (*MsgRenewDIDResponse) ProtoMessage()    {}
+
+// MsgCreateDIDFromKeyResponse is the response type for Msg/CreateDIDFromKey.
+type MsgCreateDIDFromKeyResponse struct{}
+
+func (m *MsgCreateDIDFromKeyResponse) Reset()         { *m = MsgCreateDIDFromKeyResponse{} }
+func (m *MsgCreateDIDFromKeyResponse) String() string { return "MsgCreateDIDFromKeyResponse" }
+func (*MsgCreateDIDFromKeyResponse) ProtoMessage()    {}
+
+// MsgCreateDIDBatchResponse is the response type for Msg/CreateDIDBatch.
+type MsgCreateDIDBatchResponse struct{}
+
+func (m *MsgCreateDIDBatchResponse) Reset()         { *m = MsgCreateDIDBatchResponse{} }
+func (m *MsgCreateDIDBatchResponse) String() string { return "MsgCreateDIDBatchResponse" }
+func (*MsgCreateDIDBatchResponse) ProtoMessage()    {}
+
+// MsgCommitDIDResponse is the response type for Msg/CommitDID.
+type MsgCommitDIDResponse struct{}
+
+func (m *MsgCommitDIDResponse) Reset()         { *m = MsgCommitDIDResponse{} }
+func (m *MsgCommitDIDResponse) String() string { return "MsgCommitDIDResponse" }
+func (*MsgCommitDIDResponse) ProtoMessage()    {}
+
+// MsgLinkAccountResponse is the response type for Msg/LinkAccount.
+type MsgLinkAccountResponse struct{}
+
+func (m *MsgLinkAccountResponse) Reset()         { *m = MsgLinkAccountResponse{} }
+func (m *MsgLinkAccountResponse) String() string { return "MsgLinkAccountResponse" }
+func (*MsgLinkAccountResponse) ProtoMessage()    {}
+
+// MsgUnlinkAccountResponse is the response type for Msg/UnlinkAccount.
+type MsgUnlinkAccountResponse struct{}
+
+func (m *MsgUnlinkAccountResponse) Reset()         { *m = MsgUnlinkAccountResponse{} }
+func (m *MsgUnlinkAccountResponse) String() string { return "MsgUnlinkAccountResponse" }
+func (*MsgUnlinkAccountResponse) ProtoMessage()    {}
+
+// MsgLinkEthereumAccountResponse is the response type for Msg/LinkEthereumAccount.
+type MsgLinkEthereumAccountResponse struct{}
+
+func (m *MsgLinkEthereumAccountResponse) Reset()         { *m = MsgLinkEthereumAccountResponse{} }
+func (m *MsgLinkEthereumAccountResponse) String() string { return "MsgLinkEthereumAccountResponse" }
+func (*MsgLinkEthereumAccountResponse) ProtoMessage()    {}
+
+// MsgUnlinkEthereumAccountResponse is the response type for Msg/UnlinkEthereumAccount.
+type MsgUnlinkEthereumAccountResponse struct{}
+
+func (m *MsgUnlinkEthereumAccountResponse) Reset()         { *m = MsgUnlinkEthereumAccountResponse{} }
+func (m *MsgUnlinkEthereumAccountResponse) String() string { return "MsgUnlinkEthereumAccountResponse" }
+func (*MsgUnlinkEthereumAccountResponse) ProtoMessage()    {}
+
+// MsgGrantCapabilityResponse is the response type for Msg/GrantCapability.
+type MsgGrantCapabilityResponse struct{}
+
+func (m *MsgGrantCapabilityResponse) Reset()         { *m = MsgGrantCapabilityResponse{} }
+func (m *MsgGrantCapabilityResponse) String() string { return "MsgGrantCapabilityResponse" }
+func (*MsgGrantCapabilityResponse) ProtoMessage()    {}
+
+// MsgRevokeCapabilityResponse is the response type for Msg/RevokeCapability.
+type MsgRevokeCapabilityResponse struct{}
+
+func (m *MsgRevokeCapabilityResponse) Reset()         { *m = MsgRevokeCapabilityResponse{} }
+func (m *MsgRevokeCapabilityResponse) String() string { return "MsgRevokeCapabilityResponse" }
+func (*MsgRevokeCapabilityResponse) ProtoMessage()    {}
+
+// MsgLinkAssetResponse is the response type for Msg/LinkAsset.
+type MsgLinkAssetResponse struct{}
+
+func (m *MsgLinkAssetResponse) Reset()         { *m = MsgLinkAssetResponse{} }
+func (m *MsgLinkAssetResponse) String() string { return "MsgLinkAssetResponse" }
+func (*MsgLinkAssetResponse) ProtoMessage()    {}
+
+// MsgUnlinkAssetResponse is the response type for Msg/UnlinkAsset.
+type MsgUnlinkAssetResponse struct{}
+
+func (m *MsgUnlinkAssetResponse) Reset()         { *m = MsgUnlinkAssetResponse{} }
+func (m *MsgUnlinkAssetResponse) String() string { return "MsgUnlinkAssetResponse" }
+func (*MsgUnlinkAssetResponse) ProtoMessage()    {}
+
+// MsgRegisterCredentialStatusResponse is the response type for Msg/RegisterCredentialStatus.
+type MsgRegisterCredentialStatusResponse struct{}
+
+func (m *MsgRegisterCredentialStatusResponse) Reset() { *m = MsgRegisterCredentialStatusResponse{} }
+func (m *MsgRegisterCredentialStatusResponse) String() string {
+	return "MsgRegisterCredentialStatusResponse"
+}
+func (*MsgRegisterCredentialStatusResponse) ProtoMessage() {}
+
+// MsgRevokeCredentialsResponse is the response type for Msg/RevokeCredentials.
+type MsgRevokeCredentialsResponse struct{}
+
+func (m *MsgRevokeCredentialsResponse) Reset()         { *m = MsgRevokeCredentialsResponse{} }
+func (m *MsgRevokeCredentialsResponse) String() string { return "MsgRevokeCredentialsResponse" }
+func (*MsgRevokeCredentialsResponse) ProtoMessage()    {}
+
+// MsgSetPresentationDefinitionResponse is the response type for Msg/SetPresentationDefinition.
+type MsgSetPresentationDefinitionResponse struct{}
+
+func (m *MsgSetPresentationDefinitionResponse) Reset() { *m = MsgSetPresentationDefinitionResponse{} }
+func (m *MsgSetPresentationDefinitionResponse) String() string {
+	return "MsgSetPresentationDefinitionResponse"
+}
+func (*MsgSetPresentationDefinitionResponse) ProtoMessage() {}
+
+// MsgDeletePresentationDefinitionResponse is the response type for Msg/DeletePresentationDefinition.
+type MsgDeletePresentationDefinitionResponse struct{}
+
+func (m *MsgDeletePresentationDefinitionResponse) Reset() {
+	*m = MsgDeletePresentationDefinitionResponse{}
+}
+func (m *MsgDeletePresentationDefinitionResponse) String() string {
+	return "MsgDeletePresentationDefinitionResponse"
+}
+func (*MsgDeletePresentationDefinitionResponse) ProtoMessage() {}
+
+// MsgAnchorHashResponse is the response type for Msg/AnchorHash.
+type MsgAnchorHashResponse struct{}
+
+func (m *MsgAnchorHashResponse) Reset()         { *m = MsgAnchorHashResponse{} }
+func (m *MsgAnchorHashResponse) String() string { return "MsgAnchorHashResponse" }
+func (*MsgAnchorHashResponse) ProtoMessage()    {}
+
+// MsgAnchorCredentialRootResponse is the response type for Msg/AnchorCredentialRoot.
+type MsgAnchorCredentialRootResponse struct{}
+
+func (m *MsgAnchorCredentialRootResponse) Reset() { *m = MsgAnchorCredentialRootResponse{} }
+func (m *MsgAnchorCredentialRootResponse) String() string {
+	return "MsgAnchorCredentialRootResponse"
+}
+func (*MsgAnchorCredentialRootResponse) ProtoMessage() {}
+
+type msgServer struct {
+	keeper Keeper
+}
+
+// NewMsgServerImpl returns an implementation of MsgServer backed by the keeper.
+func NewMsgServerImpl(k Keeper) MsgServer {
+	return msgServer{keeper: k}
+}
+
+var _ MsgServer = msgServer{}
+
+func (s msgServer) CreateDID(goCtx context.Context, msg *MsgCreateDID) (*MsgCreateDIDResponse, error) {
+	if _, err := handleMsgCreateDID(sdk.UnwrapSDKContext(goCtx), s.keeper, *msg); err != nil {
+		return nil, err
+	}
+	return &MsgCreateDIDResponse{}, nil
+}
+
+func (s msgServer) RevokeCredential(goCtx context.Context, msg *MsgRevokeCredential) (*MsgRevokeCredentialResponse, error) {
+	if _, err := handleMsgRevokeCredential(sdk.UnwrapSDKContext(goCtx), s.keeper, *msg); err != nil {
+		return nil, err
+	}
+	return &MsgRevokeCredentialResponse{}, nil
+}
+
+func (s msgServer) CreateResource(goCtx context.Context, msg *MsgCreateResource) (*MsgCreateResourceResponse, error) {
+	if _, err := handleMsgCreateResource(sdk.UnwrapSDKContext(goCtx), s.keeper, *msg); err != nil {
+		return nil, err
+	}
+	return &MsgCreateResourceResponse{}, nil
+}
+
+func (s msgServer) UpdateDID(goCtx context.Context, msg *MsgUpdateDID) (*MsgUpdateDIDResponse, error) {
+	if _, err := handleMsgUpdateDID(sdk.UnwrapSDKContext(goCtx), s.keeper, *msg); err != nil {
+		return nil, err
+	}
+	return &MsgUpdateDIDResponse{}, nil
+}
+
+func (s msgServer) DeactivateDID(goCtx context.Context, msg *MsgDeactivateDID) (*MsgDeactivateDIDResponse, error) {
+	if _, err := handleMsgDeactivateDID(sdk.UnwrapSDKContext(goCtx), s.keeper, *msg); err != nil {
+		return nil, err
+	}
+	return &MsgDeactivateDIDResponse{}, nil
+}
+
+func (s msgServer) ProposeDIDChange(goCtx context.Context, msg *MsgProposeDIDChange) (*MsgProposeDIDChangeResponse, error) {
+	id, err := proposeDIDChange(sdk.UnwrapSDKContext(goCtx), s.keeper, *msg)
+	if err != nil {
+		return nil, err
+	}
+	return &MsgProposeDIDChangeResponse{ChangeID: id}, nil
+}
+
+func (s msgServer) ApproveDIDChange(goCtx context.Context, msg *MsgApproveDIDChange) (*MsgApproveDIDChangeResponse, error) {
+	if _, err := handleMsgApproveDIDChange(sdk.UnwrapSDKContext(goCtx), s.keeper, *msg); err != nil {
+		return nil, err
+	}
+	return &MsgApproveDIDChangeResponse{}, nil
+}
+
+func (s msgServer) SponsorDID(goCtx context.Context, msg *MsgSponsorDID) (*MsgSponsorDIDResponse, error) {
+	if _, err := handleMsgSponsorDID(sdk.UnwrapSDKContext(goCtx), s.keeper, *msg); err != nil {
+		return nil, err
+	}
+	return &MsgSponsorDIDResponse{}, nil
+}
+
+func (s msgServer) SetGuardians(goCtx context.Context, msg *MsgSetGuardians) (*MsgSetGuardiansResponse, error) {
+	if _, err := handleMsgSetGuardians(sdk.UnwrapSDKContext(goCtx), s.keeper, *msg); err != nil {
+		return nil, err
+	}
+	return &MsgSetGuardiansResponse{}, nil
+}
+
+func (s msgServer) RecoverDID(goCtx context.Context, msg *MsgRecoverDID) (*MsgRecoverDIDResponse, error) {
+	if _, err := handleMsgRecoverDID(sdk.UnwrapSDKContext(goCtx), s.keeper, *msg); err != nil {
+		return nil, err
+	}
+	return &MsgRecoverDIDResponse{}, nil
+}
+
+func (s msgServer) CancelRecovery(goCtx context.Context, msg *MsgCancelRecovery) (*MsgCancelRecoveryResponse, error) {
+	if _, err := handleMsgCancelRecovery(sdk.UnwrapSDKContext(goCtx), s.keeper, *msg); err != nil {
+		return nil, err
+	}
+	return &MsgCancelRecoveryResponse{}, nil
+}
+
+func (s msgServer) RotateKey(goCtx context.Context, msg *MsgRotateKey) (*MsgRotateKeyResponse, error) {
+	if _, err := handleMsgRotateKey(sdk.UnwrapSDKContext(goCtx), s.keeper, *msg); err != nil {
+		return nil, err
+	}
+	return &MsgRotateKeyResponse{}, nil
+}
+
+func (s msgServer) CancelKeyRotation(goCtx context.Context, msg *MsgCancelKeyRotation) (*MsgCancelKeyRotationResponse, error) {
+	if _, err := handleMsgCancelKeyRotation(sdk.UnwrapSDKContext(goCtx), s.keeper, *msg); err != nil {
+		return nil, err
+	}
+	return &MsgCancelKeyRotationResponse{}, nil
+}
+
+func (s msgServer) RenewDID(goCtx context.Context, msg *MsgRenewDID) (*MsgRenewDIDResponse, error) {
+	if _, err := handleMsgRenewDID(sdk.UnwrapSDKContext(goCtx), s.keeper, *msg); err != nil {
+		return nil, err
+	}
+	return &MsgRenewDIDResponse{}, nil
+}
+
+func (s msgServer) CreateDIDFromKey(goCtx context.Context, msg *MsgCreateDIDFromKey) (*MsgCreateDIDFromKeyResponse, error) {
+	if _, err := handleMsgCreateDIDFromKey(sdk.UnwrapSDKContext(goCtx), s.keeper, *msg); err != nil {
+		return nil, err
+	}
+	return &MsgCreateDIDFromKeyResponse{}, nil
+}
+
+func (s msgServer) CreateDIDBatch(goCtx context.Context, msg *MsgCreateDIDBatch) (*MsgCreateDIDBatchResponse, error) {
+	if _, err := handleMsgCreateDIDBatch(sdk.UnwrapSDKContext(goCtx), s.keeper, *msg); err != nil {
+		return nil, err
+	}
+	return &MsgCreateDIDBatchResponse{}, nil
+}
+
+func (s msgServer) CommitDID(goCtx context.Context, msg *MsgCommitDID) (*MsgCommitDIDResponse, error) {
+	if _, err := handleMsgCommitDID(sdk.UnwrapSDKContext(goCtx), s.keeper, *msg); err != nil {
+		return nil, err
+	}
+	return &MsgCommitDIDResponse{}, nil
+}
+
+func (s msgServer) LinkAccount(goCtx context.Context, msg *MsgLinkAccount) (*MsgLinkAccountResponse, error) {
+	if _, err := handleMsgLinkAccount(sdk.UnwrapSDKContext(goCtx), s.keeper, *msg); err != nil {
+		return nil, err
+	}
+	return &MsgLinkAccountResponse{}, nil
+}
+
+func (s msgServer) UnlinkAccount(goCtx context.Context, msg *MsgUnlinkAccount) (*MsgUnlinkAccountResponse, error) {
+	if _, err := handleMsgUnlinkAccount(sdk.UnwrapSDKContext(goCtx), s.keeper, *msg); err != nil {
+		return nil, err
+	}
+	return &MsgUnlinkAccountResponse{}, nil
+}
+
+func (s msgServer) LinkEthereumAccount(goCtx context.Context, msg *MsgLinkEthereumAccount) (*MsgLinkEthereumAccountResponse, error) {
+	if _, err := handleMsgLinkEthereumAccount(sdk.UnwrapSDKContext(goCtx), s.keeper, *msg); err != nil {
+		return nil, err
+	}
+	return &MsgLinkEthereumAccountResponse{}, nil
+}
+
+func (s msgServer) UnlinkEthereumAccount(goCtx context.Context, msg *MsgUnlinkEthereumAccount) (*MsgUnlinkEthereumAccountResponse, error) {
+	if _, err := handleMsgUnlinkEthereumAccount(sdk.UnwrapSDKContext(goCtx), s.keeper, *msg); err != nil {
+		return nil, err
+	}
+	return &MsgUnlinkEthereumAccountResponse{}, nil
+}
+
+func (s msgServer) GrantCapability(goCtx context.Context, msg *MsgGrantCapability) (*MsgGrantCapabilityResponse, error) {
+	if _, err := handleMsgGrantCapability(sdk.UnwrapSDKContext(goCtx), s.keeper, *msg); err != nil {
+		return nil, err
+	}
+	return &MsgGrantCapabilityResponse{}, nil
+}
+
+func (s msgServer) RevokeCapability(goCtx context.Context, msg *MsgRevokeCapability) (*MsgRevokeCapabilityResponse, error) {
+	if _, err := handleMsgRevokeCapability(sdk.UnwrapSDKContext(goCtx), s.keeper, *msg); err != nil {
+		return nil, err
+	}
+	return &MsgRevokeCapabilityResponse{}, nil
+}
+
+func (s msgServer) LinkAsset(goCtx context.Context, msg *MsgLinkAsset) (*MsgLinkAssetResponse, error) {
+	if _, err := handleMsgLinkAsset(sdk.UnwrapSDKContext(goCtx), s.keeper, *msg); err != nil {
+		return nil, err
+	}
+	return &MsgLinkAssetResponse{}, nil
+}
+
+func (s msgServer) UnlinkAsset(goCtx context.Context, msg *MsgUnlinkAsset) (*MsgUnlinkAssetResponse, error) {
+	if _, err := handleMsgUnlinkAsset(sdk.UnwrapSDKContext(goCtx), s.keeper, *msg); err != nil {
+		return nil, err
+	}
+	return &MsgUnlinkAssetResponse{}, nil
+}
+
+func (s msgServer) RegisterCredentialStatus(goCtx context.Context, msg *MsgRegisterCredentialStatus) (*MsgRegisterCredentialStatusResponse, error) {
+	if _, err := handleMsgRegisterCredentialStatus(sdk.UnwrapSDKContext(goCtx), s.keeper, *msg); err != nil {
+		return nil, err
+	}
+	return &MsgRegisterCredentialStatusResponse{}, nil
+}
+
+func (s msgServer) RevokeCredentials(goCtx context.Context, msg *MsgRevokeCredentials) (*MsgRevokeCredentialsResponse, error) {
+	if _, err := handleMsgRevokeCredentials(sdk.UnwrapSDKContext(goCtx), s.keeper, *msg); err != nil {
+		return nil, err
+	}
+	return &MsgRevokeCredentialsResponse{}, nil
+}
+
+func (s msgServer) SetPresentationDefinition(goCtx context.Context, msg *MsgSetPresentationDefinition) (*MsgSetPresentationDefinitionResponse, error) {
+	if _, err := handleMsgSetPresentationDefinition(sdk.UnwrapSDKContext(goCtx), s.keeper, *msg); err != nil {
+		return nil, err
+	}
+	return &MsgSetPresentationDefinitionResponse{}, nil
+}
+
+func (s msgServer) DeletePresentationDefinition(goCtx context.Context, msg *MsgDeletePresentationDefinition) (*MsgDeletePresentationDefinitionResponse, error) {
+	if _, err := handleMsgDeletePresentationDefinition(sdk.UnwrapSDKContext(goCtx), s.keeper, *msg); err != nil {
+		return nil, err
+	}
+	return &MsgDeletePresentationDefinitionResponse{}, nil
+}
+
+func (s msgServer) AnchorHash(goCtx context.Context, msg *MsgAnchorHash) (*MsgAnchorHashResponse, error) {
+	if _, err := handleMsgAnchorHash(sdk.UnwrapSDKContext(goCtx), s.keeper, *msg); err != nil {
+		return nil, err
+	}
+	return &MsgAnchorHashResponse{}, nil
+}
+
+func (s msgServer) AnchorCredentialRoot(goCtx context.Context, msg *MsgAnchorCredentialRoot) (*MsgAnchorCredentialRootResponse, error) {
+	if _, err := handleMsgAnchorCredentialRoot(sdk.UnwrapSDKContext(goCtx), s.keeper, *msg); err != nil {
+		return nil, err
+	}
+	return &MsgAnchorCredentialRootResponse{}, nil
+}
+
+// RegisterMsgServer registers srv as the aytch.did.v1.Msg service. The
+// module's own implementation also records each DID's transaction history.
+func RegisterMsgServer(s grpc1.Server, srv MsgServer) {
+	interceptors := []grpc.UnaryServerInterceptor{traceInterceptor, auditInterceptor}
+	if ms, ok := srv.(msgServer); ok {
+		interceptors = append(interceptors, ms.keeper.txHistoryInterceptor)
+	}
+	s.RegisterService(withInterceptors(&_Msg_serviceDesc, interceptors...), srv)
+}
+
+func _Msg_CreateDID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgCreateDID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).CreateDID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Msg/CreateDID"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).CreateDID(ctx, req.(*MsgCreateDID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_RevokeCredential_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgRevokeCredential)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).RevokeCredential(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Msg/RevokeCredential"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).RevokeCredential(ctx, req.(*MsgRevokeCredential))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_CreateResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgCreateResource)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).CreateResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Msg/CreateResource"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).CreateResource(ctx, req.(*MsgCreateResource))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_UpdateDID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgUpdateDID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).UpdateDID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Msg/UpdateDID"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).UpdateDID(ctx, req.(*MsgUpdateDID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_DeactivateDID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgDeactivateDID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).DeactivateDID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Msg/DeactivateDID"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).DeactivateDID(ctx, req.(*MsgDeactivateDID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_ProposeDIDChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgProposeDIDChange)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).ProposeDIDChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Msg/ProposeDIDChange"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).ProposeDIDChange(ctx, req.(*MsgProposeDIDChange))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_ApproveDIDChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgApproveDIDChange)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).ApproveDIDChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Msg/ApproveDIDChange"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).ApproveDIDChange(ctx, req.(*MsgApproveDIDChange))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_SponsorDID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgSponsorDID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).SponsorDID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Msg/SponsorDID"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).SponsorDID(ctx, req.(*MsgSponsorDID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_SetGuardians_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgSetGuardians)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).SetGuardians(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Msg/SetGuardians"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).SetGuardians(ctx, req.(*MsgSetGuardians))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_RecoverDID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgRecoverDID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).RecoverDID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Msg/RecoverDID"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).RecoverDID(ctx, req.(*MsgRecoverDID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_CancelRecovery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgCancelRecovery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).CancelRecovery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Msg/CancelRecovery"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).CancelRecovery(ctx, req.(*MsgCancelRecovery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_RotateKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgRotateKey)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).RotateKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Msg/RotateKey"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).RotateKey(ctx, req.(*MsgRotateKey))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_CancelKeyRotation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgCancelKeyRotation)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).CancelKeyRotation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Msg/CancelKeyRotation"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).CancelKeyRotation(ctx, req.(*MsgCancelKeyRotation))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_RenewDID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgRenewDID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).RenewDID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Msg/RenewDID"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).RenewDID(ctx, req.(*MsgRenewDID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_CreateDIDFromKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgCreateDIDFromKey)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).CreateDIDFromKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Msg/CreateDIDFromKey"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).CreateDIDFromKey(ctx, req.(*MsgCreateDIDFromKey))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_CreateDIDBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgCreateDIDBatch)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).CreateDIDBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Msg/CreateDIDBatch"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).CreateDIDBatch(ctx, req.(*MsgCreateDIDBatch))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_CommitDID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgCommitDID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).CommitDID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Msg/CommitDID"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).CommitDID(ctx, req.(*MsgCommitDID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_LinkAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgLinkAccount)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).LinkAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Msg/LinkAccount"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).LinkAccount(ctx, req.(*MsgLinkAccount))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_UnlinkAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgUnlinkAccount)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).UnlinkAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Msg/UnlinkAccount"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).UnlinkAccount(ctx, req.(*MsgUnlinkAccount))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_LinkEthereumAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgLinkEthereumAccount)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).LinkEthereumAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Msg/LinkEthereumAccount"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).LinkEthereumAccount(ctx, req.(*MsgLinkEthereumAccount))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_UnlinkEthereumAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgUnlinkEthereumAccount)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).UnlinkEthereumAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Msg/UnlinkEthereumAccount"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).UnlinkEthereumAccount(ctx, req.(*MsgUnlinkEthereumAccount))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_GrantCapability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgGrantCapability)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).GrantCapability(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Msg/GrantCapability"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).GrantCapability(ctx, req.(*MsgGrantCapability))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_RevokeCapability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgRevokeCapability)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).RevokeCapability(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Msg/RevokeCapability"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).RevokeCapability(ctx, req.(*MsgRevokeCapability))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_LinkAsset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgLinkAsset)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).LinkAsset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Msg/LinkAsset"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).LinkAsset(ctx, req.(*MsgLinkAsset))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_UnlinkAsset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgUnlinkAsset)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).UnlinkAsset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Msg/UnlinkAsset"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).UnlinkAsset(ctx, req.(*MsgUnlinkAsset))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_RegisterCredentialStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgRegisterCredentialStatus)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).RegisterCredentialStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Msg/RegisterCredentialStatus"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).RegisterCredentialStatus(ctx, req.(*MsgRegisterCredentialStatus))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_RevokeCredentials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgRevokeCredentials)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).RevokeCredentials(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Msg/RevokeCredentials"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).RevokeCredentials(ctx, req.(*MsgRevokeCredentials))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_SetPresentationDefinition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgSetPresentationDefinition)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).SetPresentationDefinition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Msg/SetPresentationDefinition"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).SetPresentationDefinition(ctx, req.(*MsgSetPresentationDefinition))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_DeletePresentationDefinition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgDeletePresentationDefinition)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).DeletePresentationDefinition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Msg/DeletePresentationDefinition"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).DeletePresentationDefinition(ctx, req.(*MsgDeletePresentationDefinition))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_AnchorHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgAnchorHash)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).AnchorHash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Msg/AnchorHash"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).AnchorHash(ctx, req.(*MsgAnchorHash))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_AnchorCredentialRoot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgAnchorCredentialRoot)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).AnchorCredentialRoot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Msg/AnchorCredentialRoot"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).AnchorCredentialRoot(ctx, req.(*MsgAnchorCredentialRoot))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Msg_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "aytch.did.v1.Msg",
+	HandlerType: (*MsgServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "CreateDID", Handler: _Msg_CreateDID_Handler},
+		{MethodName: "RevokeCredential", Handler: _Msg_RevokeCredential_Handler},
+		{MethodName: "CreateResource", Handler: _Msg_CreateResource_Handler},
+		{MethodName: "UpdateDID", Handler: _Msg_UpdateDID_Handler},
+		{MethodName: "DeactivateDID", Handler: _Msg_DeactivateDID_Handler},
+		{MethodName: "ProposeDIDChange", Handler: _Msg_ProposeDIDChange_Handler},
+		{MethodName: "ApproveDIDChange", Handler: _Msg_ApproveDIDChange_Handler},
+		{MethodName: "SponsorDID", Handler: _Msg_SponsorDID_Handler},
+		{MethodName: "SetGuardians", Handler: _Msg_SetGuardians_Handler},
+		{MethodName: "RecoverDID", Handler: _Msg_RecoverDID_Handler},
+		{MethodName: "CancelRecovery", Handler: _Msg_CancelRecovery_Handler},
+		{MethodName: "RotateKey", Handler: _Msg_RotateKey_Handler},
+		{MethodName: "CancelKeyRotation", Handler: _Msg_CancelKeyRotation_Handler},
+		{MethodName: "RenewDID", Handler: _Msg_RenewDID_Handler},
+		{MethodName: "CreateDIDFromKey", Handler: _Msg_CreateDIDFromKey_Handler},
+		{MethodName: "CreateDIDBatch", Handler: _Msg_CreateDIDBatch_Handler},
+		{MethodName: "CommitDID", Handler: _Msg_CommitDID_Handler},
+		{MethodName: "LinkAccount", Handler: _Msg_LinkAccount_Handler},
+		{MethodName: "UnlinkAccount", Handler: _Msg_UnlinkAccount_Handler},
+		{MethodName: "LinkEthereumAccount", Handler: _Msg_LinkEthereumAccount_Handler},
+		{MethodName: "UnlinkEthereumAccount", Handler: _Msg_UnlinkEthereumAccount_Handler},
+		{MethodName: "GrantCapability", Handler: _Msg_GrantCapability_Handler},
+		{MethodName: "RevokeCapability", Handler: _Msg_RevokeCapability_Handler},
+		{MethodName: "LinkAsset", Handler: _Msg_LinkAsset_Handler},
+		{MethodName: "UnlinkAsset", Handler: _Msg_UnlinkAsset_Handler},
+		{MethodName: "RegisterCredentialStatus", Handler: _Msg_RegisterCredentialStatus_Handler},
+		{MethodName: "RevokeCredentials", Handler: _Msg_RevokeCredentials_Handler},
+		{MethodName: "SetPresentationDefinition", Handler: _Msg_SetPresentationDefinition_Handler},
+		{MethodName: "DeletePresentationDefinition", Handler: _Msg_DeletePresentationDefinition_Handler},
+		{MethodName: "AnchorHash", Handler: _Msg_AnchorHash_Handler},
+		{MethodName: "AnchorCredentialRoot", Handler: _Msg_AnchorCredentialRoot_Handler},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+// MsgTypeURLs returns the type URLs of the messages handled by the Msg
+// service, in method order. Each method handler is called with a decoder
+// that records the request it is asked to fill and stops there.
+func MsgTypeURLs() []string {
+	urls := make([]string, 0, len(_Msg_serviceDesc.Methods))
+	for _, method := range _Msg_serviceDesc.Methods {
+		var msg sdk.Msg
+		method.Handler(nil, context.Background(), func(req interface{}) error {
+			msg = req.(sdk.Msg)
+			return errStopDecode
+		}, nil)
+		urls = append(urls, sdk.MsgTypeURL(msg))
+	}
+	return urls
+}
+
+var errStopDecode = errors.New("stop decoding")
@@ -0,0 +1,37 @@
+package did
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/cosmos/btcutil/base58"
+)
+
+// Multicodec prefixes for the public key types carried in verification methods.
+const (
+	MulticodecEd25519Pub uint64 = 0xed
+	MulticodecX25519Pub  uint64 = 0xec
+	MulticodecP256Pub    uint64 = 0x1200
+)
+
+// EncodeMultibaseKey encodes a public key as a base58btc multibase string with
+// a multicodec prefix, as used by publicKeyMultibase.
+func EncodeMultibaseKey(codec uint64, key []byte) string {
+	prefix := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(prefix, codec)
+	return "z" + base58.Encode(append(prefix[:n], key...))
+}
+
+// DecodeMultibaseKey decodes a base58btc multibase string into its multicodec
+// code and raw key bytes.
+func DecodeMultibaseKey(s string) (uint64, []byte, error) {
+	if len(s) < 2 || s[0] != 'z' {
+		return 0, nil, fmt.Errorf("unsupported multibase encoding")
+	}
+	bz := base58.Decode(s[1:])
+	codec, n := binary.Uvarint(bz)
+	if n <= 0 {
+		return 0, nil, fmt.Errorf("invalid multicodec prefix")
+	}
+	return codec, bz[n:], nil
+}
@@ -0,0 +1,54 @@
+package did
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// networkPattern is the shape of a network namespace, such as mainnet or
+// testnet: lowercase letters, digits and dashes.
+var networkPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,31}$`)
+
+// NetworkPrefix returns the prefix of the DIDs registered on network:
+// did:aytch:<network>:, or did:aytch: for a chain without a namespace.
+func NetworkPrefix(network string) string {
+	if network == "" {
+		return DIDMethodPrefix
+	}
+	return DIDMethodPrefix + network + ":"
+}
+
+// NetworkDIDFromKey derives the canonical identifier of an Ed25519 public
+// key on network, see DIDFromKey.
+func NetworkDIDFromKey(network string, pub []byte) string {
+	return AppendChecksum(NetworkPrefix(network) + EncodeMultibaseKey(MulticodecEd25519Pub, pub))
+}
+
+// CheckNetwork rejects new DIDs outside the chain's network namespace, so a
+// document created on one network can't be registered on another. DIDs
+// registered before the Network param was set are left alone.
+func (k Keeper) CheckNetwork(ctx sdk.Context, id string) error {
+	network := k.GetParams(ctx).Network
+	if network == "" {
+		return nil
+	}
+	prefix := NetworkPrefix(network)
+	if !strings.HasPrefix(id, prefix) || id == prefix {
+		return fmt.Errorf("DID %s is not in this chain's network namespace: it must start with %s", id, prefix)
+	}
+	return nil
+}
+
+func validateNetwork(i interface{}) error {
+	network, ok := i.(string)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if network != "" && !networkPattern.MatchString(network) {
+		return fmt.Errorf("invalid network %q: must be up to 32 lowercase letters, digits and dashes", network)
+	}
+	return nil
+}
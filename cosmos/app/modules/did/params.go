@@ -0,0 +1,341 @@
+package did
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
+	"github.com/gogo/protobuf/proto"
+)
+
+// Parameter store keys.
+var (
+	KeyCreateDIDFee     = []byte("CreateDIDFee")
+	KeyBurnCreateDIDFee = []byte("BurnCreateDIDFee")
+
+	KeyDocumentGasPerByte       = []byte("DocumentGasPerByte")
+	KeyGasPerVerificationMethod = []byte("GasPerVerificationMethod")
+	KeyGasPerService            = []byte("GasPerService")
+	KeyGasPerRevocation         = []byte("GasPerRevocation")
+
+	KeyMaxDocumentSize        = []byte("MaxDocumentSize")
+	KeyMaxVerificationMethods = []byte("MaxVerificationMethods")
+	KeyMaxServiceEndpoints    = []byte("MaxServiceEndpoints")
+	KeyAllowedKeyTypes        = []byte("AllowedKeyTypes")
+	KeyMaxFieldLength         = []byte("MaxFieldLength")
+	KeyMaxServiceEndpointLen  = []byte("MaxServiceEndpointLength")
+	KeyAllowedContexts        = []byte("AllowedContexts")
+	KeyPIIPatterns            = []byte("PIIPatterns")
+	KeyServiceTypes           = []byte("ServiceTypes")
+	KeyX509TrustedRoots       = []byte("X509TrustedRoots")
+
+	KeyChangeExpiryBlocks = []byte("ChangeExpiryBlocks")
+
+	KeyRecoveryWindowBlocks = []byte("RecoveryWindowBlocks")
+	KeyRecoveryDelayBlocks  = []byte("RecoveryDelayBlocks")
+
+	KeyRegistrationDeposit   = []byte("RegistrationDeposit")
+	KeyDepositMaturityBlocks = []byte("DepositMaturityBlocks")
+
+	KeyRestrictIssuers     = []byte("RestrictIssuers")
+	KeyAllowedIssuers      = []byte("AllowedIssuers")
+	KeyIssuerResourceTypes = []byte("IssuerResourceTypes")
+
+	KeyNetwork          = []byte("Network")
+	KeyIdentifierStyles = []byte("IdentifierStyles")
+
+	KeyDeactivationRefund = []byte("DeactivationRefund")
+
+	KeyFeeCommunityPoolShare = []byte("FeeCommunityPoolShare")
+	KeyFeeBurnShare          = []byte("FeeBurnShare")
+)
+
+// Params defines the governance-controlled parameters of the DID module.
+// CreateDIDFee is charged to the creator of every new DID and is split or
+// burned as described below. The gas parameters are charged on every
+// document write, on top of the store's own read/write costs, and
+// GasPerRevocation on every credential a bulk revocation revokes. The limits are
+// enforced on every write so the registry can't be used as generic storage:
+// MaxFieldLength caps every identifier, type and key string in a document and
+// MaxServiceEndpointLength caps service endpoint URLs. Documents may only
+// reference the JSON-LD contexts in AllowedContexts, and may not carry
+// personal data matching PIIPatterns; an empty list turns the check off.
+// ServiceTypes is the registry of recognized service types and the shape of
+// their endpoints. X.509 verification methods must chain to one of
+// X509TrustedRoots when registered.
+// ChangeExpiryBlocks is how long a change to a multi-controller DID stays
+// open for approval. Guardians have RecoveryWindowBlocks to approve a social
+// recovery, which then takes effect after RecoveryDelayBlocks.
+// RegistrationDeposit is locked from the creator of every new DID and
+// refunded after DepositMaturityBlocks unless governance slashes it first for
+// an abusive registration; an empty deposit turns the scheme off.
+// With RestrictIssuers on, only the DIDs in AllowedIssuers may create
+// resources of IssuerResourceTypes, such as schemas and status lists, post
+// attestations or issue credential-backed tokens.
+// Network, when set, is the namespace every new DID must carry, as in
+// did:aytch:testnet:..., so documents can't cross between networks.
+// IdentifierStyles are the shapes of identifier new DIDs may use: key-derived,
+// UUID or custom.
+// DeactivationRefund is the part of CreateDIDFee held in escrow instead of
+// collected, and refunded to the payer when the DID's controllers deactivate
+// it, so retiring unused DIDs pays.
+// Collected fees go FeeCommunityPoolShare to the community pool and
+// FeeBurnShare to be burned, the rest to the fee collector; BurnCreateDIDFee
+// burns the whole creation fee instead.
+type Params struct {
+	CreateDIDFee             sdk.Coins         `protobuf:"bytes,1,rep,name=create_did_fee,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"create_did_fee"`
+	BurnCreateDIDFee         bool              `protobuf:"varint,2,opt,name=burn_create_did_fee,proto3" json:"burn_create_did_fee"`
+	DocumentGasPerByte       uint64            `protobuf:"varint,3,opt,name=document_gas_per_byte,proto3" json:"document_gas_per_byte"`
+	GasPerVerificationMethod uint64            `protobuf:"varint,4,opt,name=gas_per_verification_method,proto3" json:"gas_per_verification_method"`
+	GasPerService            uint64            `protobuf:"varint,5,opt,name=gas_per_service,proto3" json:"gas_per_service"`
+	MaxDocumentSize          uint64            `protobuf:"varint,6,opt,name=max_document_size,proto3" json:"max_document_size"`
+	MaxVerificationMethods   uint64            `protobuf:"varint,7,opt,name=max_verification_methods,proto3" json:"max_verification_methods"`
+	MaxServiceEndpoints      uint64            `protobuf:"varint,8,opt,name=max_service_endpoints,proto3" json:"max_service_endpoints"`
+	AllowedKeyTypes          []string          `protobuf:"bytes,9,rep,name=allowed_key_types,proto3" json:"allowed_key_types"`
+	ChangeExpiryBlocks       uint64            `protobuf:"varint,10,opt,name=change_expiry_blocks,proto3" json:"change_expiry_blocks"`
+	RecoveryWindowBlocks     uint64            `protobuf:"varint,11,opt,name=recovery_window_blocks,proto3" json:"recovery_window_blocks"`
+	RecoveryDelayBlocks      uint64            `protobuf:"varint,12,opt,name=recovery_delay_blocks,proto3" json:"recovery_delay_blocks"`
+	MaxFieldLength           uint64            `protobuf:"varint,13,opt,name=max_field_length,proto3" json:"max_field_length"`
+	MaxServiceEndpointLength uint64            `protobuf:"varint,14,opt,name=max_service_endpoint_length,proto3" json:"max_service_endpoint_length"`
+	AllowedContexts          []ContextPin      `protobuf:"bytes,15,rep,name=allowed_contexts,proto3" json:"allowed_contexts"`
+	PIIPatterns              []PIIPattern      `protobuf:"bytes,16,rep,name=pii_patterns,proto3" json:"pii_patterns"`
+	ServiceTypes             []ServiceTypeRule `protobuf:"bytes,17,rep,name=service_types,proto3" json:"service_types"`
+	X509TrustedRoots         []X509TrustRoot   `protobuf:"bytes,18,rep,name=x509_trusted_roots,proto3" json:"x509_trusted_roots"`
+	RegistrationDeposit      sdk.Coins         `protobuf:"bytes,19,rep,name=registration_deposit,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"registration_deposit"`
+	DepositMaturityBlocks    uint64            `protobuf:"varint,20,opt,name=deposit_maturity_blocks,proto3" json:"deposit_maturity_blocks"`
+	RestrictIssuers          bool              `protobuf:"varint,21,opt,name=restrict_issuers,proto3" json:"restrict_issuers"`
+	AllowedIssuers           []string          `protobuf:"bytes,22,rep,name=allowed_issuers,proto3" json:"allowed_issuers"`
+	IssuerResourceTypes      []string          `protobuf:"bytes,23,rep,name=issuer_resource_types,proto3" json:"issuer_resource_types"`
+	GasPerRevocation         uint64            `protobuf:"varint,24,opt,name=gas_per_revocation,proto3" json:"gas_per_revocation"`
+	Network                  string            `protobuf:"bytes,25,opt,name=network,proto3" json:"network"`
+	IdentifierStyles         []string          `protobuf:"bytes,26,rep,name=identifier_styles,proto3" json:"identifier_styles"`
+	DeactivationRefund       sdk.Coins         `protobuf:"bytes,27,rep,name=deactivation_refund,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"deactivation_refund"`
+	FeeCommunityPoolShare    sdk.Dec           `protobuf:"bytes,28,opt,name=fee_community_pool_share,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Dec" json:"fee_community_pool_share"`
+	FeeBurnShare             sdk.Dec           `protobuf:"bytes,29,opt,name=fee_burn_share,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Dec" json:"fee_burn_share"`
+}
+
+func init() {
+	proto.RegisterType((*Params)(nil), "aytch.did.v1.Params")
+	proto.RegisterType((*ContextPin)(nil), "aytch.did.v1.ContextPin")
+	proto.RegisterType((*PIIPattern)(nil), "aytch.did.v1.PIIPattern")
+	proto.RegisterType((*ServiceTypeRule)(nil), "aytch.did.v1.ServiceTypeRule")
+	proto.RegisterType((*X509TrustRoot)(nil), "aytch.did.v1.X509TrustRoot")
+}
+
+func (m *Params) Reset()         { *m = Params{} }
+func (m *Params) String() string { return proto.CompactTextString(m) }
+func (*Params) ProtoMessage()    {}
+
+func (m *ContextPin) Reset()         { *m = ContextPin{} }
+func (m *ContextPin) String() string { return proto.CompactTextString(m) }
+func (*ContextPin) ProtoMessage()    {}
+
+func (m *PIIPattern) Reset()         { *m = PIIPattern{} }
+func (m *PIIPattern) String() string { return proto.CompactTextString(m) }
+func (*PIIPattern) ProtoMessage()    {}
+
+func (m *ServiceTypeRule) Reset()         { *m = ServiceTypeRule{} }
+func (m *ServiceTypeRule) String() string { return proto.CompactTextString(m) }
+func (*ServiceTypeRule) ProtoMessage()    {}
+
+func (m *X509TrustRoot) Reset()         { *m = X509TrustRoot{} }
+func (m *X509TrustRoot) String() string { return proto.CompactTextString(m) }
+func (*X509TrustRoot) ProtoMessage()    {}
+
+// ParamKeyTable returns the key table for the DID module's parameters.
+func ParamKeyTable() paramtypes.KeyTable {
+	return paramtypes.NewKeyTable().RegisterParamSet(&Params{})
+}
+
+// DefaultParams returns the default parameters: DID creation is free until
+// governance sets a fee.
+func DefaultParams() Params {
+	return Params{
+		CreateDIDFee:             sdk.NewCoins(),
+		BurnCreateDIDFee:         false,
+		DocumentGasPerByte:       20,
+		GasPerVerificationMethod: 2000,
+		GasPerService:            1000,
+		MaxDocumentSize:          16 * 1024,
+		MaxVerificationMethods:   20,
+		MaxServiceEndpoints:      20,
+		AllowedKeyTypes:          []string{Ed25519VerificationKey2020, X25519KeyAgreementKey2020, X509CertificateChainKey, WebAuthnP256Key, FROSTEd25519Key, BN256BLSThresholdKey},
+		ChangeExpiryBlocks:       100800,
+		RecoveryWindowBlocks:     100800,
+		RecoveryDelayBlocks:      28800,
+		MaxFieldLength:           512,
+		MaxServiceEndpointLength: 2048,
+		AllowedContexts:          defaultContextPins(),
+		PIIPatterns:              defaultPIIPatterns(),
+		ServiceTypes:             defaultServiceTypeRules(),
+		X509TrustedRoots:         []X509TrustRoot{},
+		RegistrationDeposit:      sdk.NewCoins(),
+		DepositMaturityBlocks:    201600,
+		RestrictIssuers:          false,
+		AllowedIssuers:           []string{},
+		IssuerResourceTypes:      defaultIssuerResourceTypes(),
+		GasPerRevocation:         2000,
+		Network:                  "",
+		IdentifierStyles:         defaultIdentifierStyles(),
+		DeactivationRefund:       sdk.NewCoins(),
+		FeeCommunityPoolShare:    sdk.ZeroDec(),
+		FeeBurnShare:             sdk.ZeroDec(),
+	}
+}
+
+// ParamSetPairs implements paramtypes.ParamSet.
+func (p *Params) ParamSetPairs() paramtypes.ParamSetPairs {
+	return paramtypes.ParamSetPairs{
+		paramtypes.NewParamSetPair(KeyCreateDIDFee, &p.CreateDIDFee, validateCreateDIDFee),
+		paramtypes.NewParamSetPair(KeyBurnCreateDIDFee, &p.BurnCreateDIDFee, validateBool),
+		paramtypes.NewParamSetPair(KeyDocumentGasPerByte, &p.DocumentGasPerByte, validateUint64),
+		paramtypes.NewParamSetPair(KeyGasPerVerificationMethod, &p.GasPerVerificationMethod, validateUint64),
+		paramtypes.NewParamSetPair(KeyGasPerService, &p.GasPerService, validateUint64),
+		paramtypes.NewParamSetPair(KeyMaxDocumentSize, &p.MaxDocumentSize, validatePositiveUint64),
+		paramtypes.NewParamSetPair(KeyMaxVerificationMethods, &p.MaxVerificationMethods, validatePositiveUint64),
+		paramtypes.NewParamSetPair(KeyMaxServiceEndpoints, &p.MaxServiceEndpoints, validateUint64),
+		paramtypes.NewParamSetPair(KeyAllowedKeyTypes, &p.AllowedKeyTypes, validateAllowedKeyTypes),
+		paramtypes.NewParamSetPair(KeyChangeExpiryBlocks, &p.ChangeExpiryBlocks, validatePositiveUint64),
+		paramtypes.NewParamSetPair(KeyRecoveryWindowBlocks, &p.RecoveryWindowBlocks, validatePositiveUint64),
+		paramtypes.NewParamSetPair(KeyRecoveryDelayBlocks, &p.RecoveryDelayBlocks, validatePositiveUint64),
+		paramtypes.NewParamSetPair(KeyMaxFieldLength, &p.MaxFieldLength, validatePositiveUint64),
+		paramtypes.NewParamSetPair(KeyMaxServiceEndpointLen, &p.MaxServiceEndpointLength, validatePositiveUint64),
+		paramtypes.NewParamSetPair(KeyAllowedContexts, &p.AllowedContexts, validateAllowedContexts),
+		paramtypes.NewParamSetPair(KeyPIIPatterns, &p.PIIPatterns, validatePIIPatterns),
+		paramtypes.NewParamSetPair(KeyServiceTypes, &p.ServiceTypes, validateServiceTypes),
+		paramtypes.NewParamSetPair(KeyX509TrustedRoots, &p.X509TrustedRoots, validateX509TrustedRoots),
+		paramtypes.NewParamSetPair(KeyRegistrationDeposit, &p.RegistrationDeposit, validateCreateDIDFee),
+		paramtypes.NewParamSetPair(KeyDepositMaturityBlocks, &p.DepositMaturityBlocks, validatePositiveUint64),
+		paramtypes.NewParamSetPair(KeyRestrictIssuers, &p.RestrictIssuers, validateBool),
+		paramtypes.NewParamSetPair(KeyAllowedIssuers, &p.AllowedIssuers, validateAllowedIssuers),
+		paramtypes.NewParamSetPair(KeyIssuerResourceTypes, &p.IssuerResourceTypes, validateIssuerResourceTypes),
+		paramtypes.NewParamSetPair(KeyGasPerRevocation, &p.GasPerRevocation, validateUint64),
+		paramtypes.NewParamSetPair(KeyNetwork, &p.Network, validateNetwork),
+		paramtypes.NewParamSetPair(KeyIdentifierStyles, &p.IdentifierStyles, validateIdentifierStyles),
+		paramtypes.NewParamSetPair(KeyDeactivationRefund, &p.DeactivationRefund, validateCreateDIDFee),
+		paramtypes.NewParamSetPair(KeyFeeCommunityPoolShare, &p.FeeCommunityPoolShare, validateFeeShare),
+		paramtypes.NewParamSetPair(KeyFeeBurnShare, &p.FeeBurnShare, validateFeeShare),
+	}
+}
+
+// Validate performs basic validation of the parameters.
+func (p Params) Validate() error {
+	if err := validateCreateDIDFee(p.CreateDIDFee); err != nil {
+		return err
+	}
+	if err := validateBool(p.BurnCreateDIDFee); err != nil {
+		return err
+	}
+	if err := validateCreateDIDFee(p.RegistrationDeposit); err != nil {
+		return err
+	}
+	if err := validateCreateDIDFee(p.DeactivationRefund); err != nil {
+		return err
+	}
+	if !p.DeactivationRefund.IsAllLTE(p.CreateDIDFee) {
+		return fmt.Errorf("deactivation refund %s exceeds the create DID fee %s", p.DeactivationRefund, p.CreateDIDFee)
+	}
+	for _, share := range []sdk.Dec{p.FeeCommunityPoolShare, p.FeeBurnShare} {
+		if err := validateFeeShare(share); err != nil {
+			return err
+		}
+	}
+	if p.FeeCommunityPoolShare.Add(p.FeeBurnShare).GT(sdk.OneDec()) {
+		return fmt.Errorf("fee community pool share %s and burn share %s add up to more than 1", p.FeeCommunityPoolShare, p.FeeBurnShare)
+	}
+	for _, v := range []uint64{p.DocumentGasPerByte, p.GasPerVerificationMethod, p.GasPerService, p.GasPerRevocation, p.MaxServiceEndpoints} {
+		if err := validateUint64(v); err != nil {
+			return err
+		}
+	}
+	for _, v := range []uint64{p.MaxDocumentSize, p.MaxVerificationMethods, p.ChangeExpiryBlocks, p.RecoveryWindowBlocks, p.RecoveryDelayBlocks, p.MaxFieldLength, p.MaxServiceEndpointLength, p.DepositMaturityBlocks} {
+		if err := validatePositiveUint64(v); err != nil {
+			return err
+		}
+	}
+	if err := validateAllowedContexts(p.AllowedContexts); err != nil {
+		return err
+	}
+	if err := validatePIIPatterns(p.PIIPatterns); err != nil {
+		return err
+	}
+	if err := validateServiceTypes(p.ServiceTypes); err != nil {
+		return err
+	}
+	if err := validateX509TrustedRoots(p.X509TrustedRoots); err != nil {
+		return err
+	}
+	if err := validateAllowedIssuers(p.AllowedIssuers); err != nil {
+		return err
+	}
+	if err := validateIssuerResourceTypes(p.IssuerResourceTypes); err != nil {
+		return err
+	}
+	if err := validateNetwork(p.Network); err != nil {
+		return err
+	}
+	if err := validateIdentifierStyles(p.IdentifierStyles); err != nil {
+		return err
+	}
+	return validateAllowedKeyTypes(p.AllowedKeyTypes)
+}
+
+// IsKeyTypeAllowed reports whether verification methods of the given type may
+// be registered.
+func (p Params) IsKeyTypeAllowed(keyType string) bool {
+	for _, t := range p.AllowedKeyTypes {
+		if t == keyType {
+			return true
+		}
+	}
+	return false
+}
+
+func validateCreateDIDFee(i interface{}) error {
+	fee, ok := i.(sdk.Coins)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return fee.Validate()
+}
+
+func validateBool(i interface{}) error {
+	if _, ok := i.(bool); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil
+}
+
+func validateUint64(i interface{}) error {
+	if _, ok := i.(uint64); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil
+}
+
+func validatePositiveUint64(i interface{}) error {
+	v, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if v == 0 {
+		return fmt.Errorf("parameter must be positive")
+	}
+	return nil
+}
+
+func validateAllowedKeyTypes(i interface{}) error {
+	types, ok := i.([]string)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if len(types) == 0 {
+		return fmt.Errorf("at least one key type must be allowed")
+	}
+	for _, t := range types {
+		if t == "" {
+			return fmt.Errorf("key type cannot be empty")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package did
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// PIIPattern is a pattern of personal data documents may not contain. Data
+// written to the chain can't be erased, so obvious personal data is rejected
+// before it gets there rather than left to a later erasure request.
+type PIIPattern struct {
+	Name    string `protobuf:"bytes,1,opt,name=name,proto3" json:"name"`
+	Pattern string `protobuf:"bytes,2,opt,name=pattern,proto3" json:"pattern"`
+}
+
+// defaultPIIPatterns catches email addresses, international phone numbers and
+// the common national identifier formats. Patterns use RE2 syntax, which runs
+// in time linear in the input, so governance can't add one that stalls block
+// execution.
+func defaultPIIPatterns() []PIIPattern {
+	return []PIIPattern{
+		{Name: "email", Pattern: `(?i)[a-z0-9._%+-]+@[a-z0-9-]+(\.[a-z0-9-]+)*\.[a-z]{2,}`},
+		{Name: "phone", Pattern: `(?i)(tel:|(^|[^0-9a-z])\+)[1-9][0-9 ().-]{6,18}[0-9]`},
+		{Name: "us-ssn", Pattern: `\b[0-9]{3}-[0-9]{2}-[0-9]{4}\b`},
+		{Name: "uk-nino", Pattern: `\b[A-CEGHJ-PR-TW-Z][A-CEGHJ-NPR-TW-Z] ?[0-9]{2} ?[0-9]{2} ?[0-9]{2} ?[A-D]\b`},
+	}
+}
+
+// checkPersonalData rejects documents whose service endpoints or free-form
+// service fields match one of the PIIPatterns param. Encrypted endpoints are
+// skipped: their ciphertext can't reveal anything. The error names the
+// pattern and field but not the match, so the rejected data isn't echoed into
+// the transaction log.
+func (k Keeper) checkPersonalData(ctx sdk.Context, did DIDDocument) error {
+	patterns := k.GetParams(ctx).PIIPatterns
+	if len(patterns) == 0 {
+		return nil
+	}
+	type field struct {
+		name   string
+		values []string
+	}
+	fields := []field{{"service endpoint", did.ServiceEndpoints}}
+	for i, svc := range did.Services {
+		n := strconv.Itoa(i)
+		if !IsEncryptedEndpoint(svc.ServiceEndpoint) {
+			fields = append(fields, field{"service " + n + " endpoint", []string{svc.ServiceEndpoint}})
+		}
+		fields = append(fields,
+			field{"service " + n + " id or type", []string{svc.ID, svc.Type}},
+			field{"service " + n + " media type", svc.Accept},
+		)
+	}
+	for _, p := range patterns {
+		re, err := regexp.Compile(p.Pattern)
+		if err != nil {
+			return fmt.Errorf("PII pattern %s: %w", p.Name, err)
+		}
+		for _, f := range fields {
+			for _, v := range f.values {
+				if re.MatchString(v) {
+					return sdkerrors.Wrapf(ErrPersonalData, "%s matches %s", f.name, p.Name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func validatePIIPatterns(i interface{}) error {
+	patterns, ok := i.([]PIIPattern)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	seen := make(map[string]bool, len(patterns))
+	for _, p := range patterns {
+		if p.Name == "" {
+			return fmt.Errorf("PII pattern name cannot be empty")
+		}
+		if seen[p.Name] {
+			return fmt.Errorf("PII pattern %s is listed more than once", p.Name)
+		}
+		seen[p.Name] = true
+		if _, err := regexp.Compile(p.Pattern); err != nil || p.Pattern == "" {
+			return fmt.Errorf("PII pattern %s is not a valid regular expression", p.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package did
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// PresentationDefinitionRecord is a DIF Presentation Exchange
+// presentation_definition published by a verifier DID, so wallets can fetch
+// the verifier's requirements from the chain for OpenID4VP requests.
+// Definition holds the definition's JSON, whose id is ID.
+type PresentationDefinitionRecord struct {
+	Verifier   string `protobuf:"bytes,1,opt,name=verifier,proto3" json:"verifier"`
+	ID         string `protobuf:"bytes,2,opt,name=id,proto3" json:"id"`
+	Definition string `protobuf:"bytes,3,opt,name=definition,proto3" json:"definition"`
+	Updated    int64  `protobuf:"varint,4,opt,name=updated,proto3" json:"updated"`
+}
+
+// validatePresentationDefinition checks that definition is a presentation
+// definition with the given id and at least one input descriptor, each with
+// an id.
+func validatePresentationDefinition(verifier, id, definition string) error {
+	if verifier == "" || id == "" {
+		return fmt.Errorf("verifier DID and definition ID cannot be empty")
+	}
+	if strings.Contains(verifier, "/") || strings.Contains(id, "/") {
+		return fmt.Errorf("verifier DID and definition ID cannot contain '/'")
+	}
+	var pd struct {
+		ID               string `json:"id"`
+		InputDescriptors []struct {
+			ID string `json:"id"`
+		} `json:"input_descriptors"`
+	}
+	if err := json.Unmarshal([]byte(definition), &pd); err != nil {
+		return fmt.Errorf("invalid presentation definition: %w", err)
+	}
+	if pd.ID != id {
+		return fmt.Errorf("presentation definition id %q does not match %q", pd.ID, id)
+	}
+	if len(pd.InputDescriptors) == 0 {
+		return fmt.Errorf("presentation definition has no input descriptors")
+	}
+	for i, d := range pd.InputDescriptors {
+		if d.ID == "" {
+			return fmt.Errorf("input descriptor %d has no id", i)
+		}
+	}
+	return nil
+}
+
+// MsgSetPresentationDefinition publishes a presentation definition under the
+// verifier DID, replacing any earlier definition with the same ID. A
+// controller of Verifier authorizes it like a MsgUpdateDID.
+type MsgSetPresentationDefinition struct {
+	Verifier   string         `protobuf:"bytes,1,opt,name=verifier,proto3" json:"verifier"`
+	ID         string         `protobuf:"bytes,2,opt,name=id,proto3" json:"id"`
+	Definition string         `protobuf:"bytes,3,opt,name=definition,proto3" json:"definition"`
+	Nonce      uint64         `protobuf:"varint,4,opt,name=nonce,proto3" json:"nonce"`
+	Signer     string         `protobuf:"bytes,5,opt,name=signer,proto3" json:"signer"`
+	Signature  []byte         `protobuf:"bytes,6,opt,name=signature,proto3" json:"signature,omitempty"`
+	Creator    sdk.AccAddress `protobuf:"bytes,7,opt,name=creator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"creator"`
+}
+
+// ValidateBasic performs basic validation of MsgSetPresentationDefinition.
+func (msg MsgSetPresentationDefinition) ValidateBasic() error {
+	if err := validateChecksums(msg); err != nil {
+		return err
+	}
+	if err := validatePresentationDefinition(msg.Verifier, msg.ID, msg.Definition); err != nil {
+		return sdkerrors.ErrUnknownRequest.Wrap(err.Error())
+	}
+	if msg.Signer == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Signer cannot be empty")
+	}
+	return nil
+}
+
+// ProofSignBytes returns the bytes a controller DID signs to authorize the
+// publication.
+func (msg MsgSetPresentationDefinition) ProofSignBytes() []byte {
+	msg.Signature = nil
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// Route returns the message route.
+func (msg MsgSetPresentationDefinition) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgSetPresentationDefinition) Type() string { return "set_presentation_definition" }
+
+// GetSignBytes returns the canonical bytes to sign over.
+func (msg MsgSetPresentationDefinition) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners returns the account that must sign the message.
+func (msg MsgSetPresentationDefinition) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
+
+// MsgDeletePresentationDefinition withdraws a published presentation
+// definition. Authorization works as for MsgSetPresentationDefinition.
+type MsgDeletePresentationDefinition struct {
+	Verifier  string         `protobuf:"bytes,1,opt,name=verifier,proto3" json:"verifier"`
+	ID        string         `protobuf:"bytes,2,opt,name=id,proto3" json:"id"`
+	Nonce     uint64         `protobuf:"varint,3,opt,name=nonce,proto3" json:"nonce"`
+	Signer    string         `protobuf:"bytes,4,opt,name=signer,proto3" json:"signer"`
+	Signature []byte         `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
+	Creator   sdk.AccAddress `protobuf:"bytes,6,opt,name=creator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"creator"`
+}
+
+// ValidateBasic performs basic validation of MsgDeletePresentationDefinition.
+func (msg MsgDeletePresentationDefinition) ValidateBasic() error {
+	if err := validateChecksums(msg); err != nil {
+		return err
+	}
+	if msg.Verifier == "" || msg.ID == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Verifier DID and definition ID cannot be empty")
+	}
+	if msg.Signer == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Signer cannot be empty")
+	}
+	return nil
+}
+
+// ProofSignBytes returns the bytes a controller DID signs to authorize the
+// removal.
+func (msg MsgDeletePresentationDefinition) ProofSignBytes() []byte {
+	msg.Signature = nil
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// Route returns the message route.
+func (msg MsgDeletePresentationDefinition) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgDeletePresentationDefinition) Type() string { return "delete_presentation_definition" }
+
+// GetSignBytes returns the canonical bytes to sign over.
+func (msg MsgDeletePresentationDefinition) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners returns the account that must sign the message.
+func (msg MsgDeletePresentationDefinition) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
@@ -0,0 +1,84 @@
+package did
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// SetPresentationDefinition publishes a presentation definition under an
+// active verifier DID. Definitions count against MaxDocumentSize like
+// documents do. Callers must have authorized the publication.
+func (k Keeper) SetPresentationDefinition(ctx sdk.Context, record PresentationDefinitionRecord) error {
+	did, err := k.GetDID(ctx, record.Verifier)
+	if err != nil {
+		return err
+	}
+	if did.Deactivated {
+		return fmt.Errorf("DID %s is deactivated", record.Verifier)
+	}
+	if err := validatePresentationDefinition(record.Verifier, record.ID, record.Definition); err != nil {
+		return err
+	}
+	if max := k.GetParams(ctx).MaxDocumentSize; uint64(len(record.Definition)) > max {
+		return fmt.Errorf("presentation definition exceeds %d bytes", max)
+	}
+	record.Updated = ctx.BlockTime().Unix()
+	k.setPresentationDefinition(ctx, record)
+	ctx.EventManager().EmitEvent(sdk.NewEvent("presentation_definition_set",
+		sdk.NewAttribute("verifier", record.Verifier),
+		sdk.NewAttribute("id", record.ID),
+	))
+	return nil
+}
+
+// DeletePresentationDefinition withdraws a published presentation definition.
+func (k Keeper) DeletePresentationDefinition(ctx sdk.Context, verifier, id string) error {
+	if _, found := k.GetPresentationDefinition(ctx, verifier, id); !found {
+		return fmt.Errorf("%s has no presentation definition %s", verifier, id)
+	}
+	ctx.KVStore(k.storeKey).Delete(presentationDefinitionKey(verifier, id))
+	ctx.EventManager().EmitEvent(sdk.NewEvent("presentation_definition_deleted",
+		sdk.NewAttribute("verifier", verifier),
+		sdk.NewAttribute("id", id),
+	))
+	return nil
+}
+
+// GetPresentationDefinition returns a presentation definition of a verifier.
+func (k Keeper) GetPresentationDefinition(ctx sdk.Context, verifier, id string) (PresentationDefinitionRecord, bool) {
+	value := ctx.KVStore(k.storeKey).Get(presentationDefinitionKey(verifier, id))
+	if value == nil {
+		return PresentationDefinitionRecord{}, false
+	}
+	var record PresentationDefinitionRecord
+	k.cdc.MustUnmarshal(value, &record)
+	return record, true
+}
+
+// GetPresentationDefinitions returns the presentation definitions a verifier
+// has published.
+func (k Keeper) GetPresentationDefinitions(ctx sdk.Context, verifier string) []PresentationDefinitionRecord {
+	return k.presentationDefinitions(ctx, presentationDefinitionsPrefix(verifier))
+}
+
+// GetAllPresentationDefinitions returns every presentation definition in the
+// store.
+func (k Keeper) GetAllPresentationDefinitions(ctx sdk.Context) []PresentationDefinitionRecord {
+	return k.presentationDefinitions(ctx, PresentationDefinitionPrefix)
+}
+
+func (k Keeper) presentationDefinitions(ctx sdk.Context, prefix []byte) []PresentationDefinitionRecord {
+	var records []PresentationDefinitionRecord
+	iteratePrefix(ctx.KVStore(k.storeKey), prefix, func(_, value []byte) bool {
+		var record PresentationDefinitionRecord
+		k.cdc.MustUnmarshal(value, &record)
+		records = append(records, record)
+		return false
+	})
+	return records
+}
+
+func (k Keeper) setPresentationDefinition(ctx sdk.Context, record PresentationDefinitionRecord) {
+	ctx.KVStore(k.storeKey).Set(presentationDefinitionKey(record.Verifier, record.ID), k.cdc.MustMarshal(&record))
+}
@@ -0,0 +1,133 @@
+package did
+
+import (
+	"net/http"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/rest"
+	govclient "github.com/cosmos/cosmos-sdk/x/gov/client"
+	govcli "github.com/cosmos/cosmos-sdk/x/gov/client/cli"
+	govrest "github.com/cosmos/cosmos-sdk/x/gov/client/rest"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	"github.com/spf13/cobra"
+)
+
+// Gov client handlers that submit the DID module's proposals, for the gov
+// module's ModuleBasics entry.
+var (
+	SlashDepositProposalHandler  = govclient.NewProposalHandler(CmdSubmitSlashDepositProposal, slashDepositProposalRESTHandler)
+	DeactivateDIDProposalHandler = govclient.NewProposalHandler(CmdSubmitDeactivateDIDProposal, deactivateDIDProposalRESTHandler)
+)
+
+// didProposal builds the content of a DID proposal from its common fields.
+type didProposal func(title, description, did, reason string) govtypes.Content
+
+func newSlashDepositProposal(title, description, did, reason string) govtypes.Content {
+	return &SlashDepositProposal{Title: title, Description: description, DID: did, Reason: reason}
+}
+
+func newDeactivateDIDProposal(title, description, did, reason string) govtypes.Content {
+	return &DeactivateDIDProposal{Title: title, Description: description, DID: did, Reason: reason}
+}
+
+// CmdSubmitSlashDepositProposal returns the gov submit-proposal subcommand
+// that proposes slashing a DID's registration deposit.
+func CmdSubmitSlashDepositProposal() *cobra.Command {
+	return proposalCmd(&cobra.Command{
+		Use:   "slash-did-deposit [did] [reason]",
+		Short: "Propose burning the registration deposit of an abusive DID",
+		Long: `Submit a governance proposal ruling the registration of a DID abusive, e.g.
+impersonation or squatting. If the proposal passes, the DID's registration
+deposit is burned instead of being refunded to its depositor when it matures.`,
+	}, newSlashDepositProposal)
+}
+
+// CmdSubmitDeactivateDIDProposal returns the gov submit-proposal subcommand
+// that proposes deactivating a DID.
+func CmdSubmitDeactivateDIDProposal() *cobra.Command {
+	return proposalCmd(&cobra.Command{
+		Use:   "deactivate-did [did] [reason]",
+		Short: "Propose deactivating a fraudulent or compromised DID",
+		Long: `Submit a governance proposal deactivating a DID proven fraudulent or
+compromised, without its controllers' consent. If the proposal passes, the
+DID is deactivated at the end of the voting period and its document metadata
+records the proposal's ID.`,
+	}, newDeactivateDIDProposal)
+}
+
+func proposalCmd(cmd *cobra.Command, content didProposal) *cobra.Command {
+	cmd.Args = cobra.ExactArgs(2)
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		clientCtx, err := client.GetClientTxContext(cmd)
+		if err != nil {
+			return err
+		}
+		title, _ := cmd.Flags().GetString(govcli.FlagTitle)
+		description, _ := cmd.Flags().GetString(govcli.FlagDescription)
+		depositStr, _ := cmd.Flags().GetString(govcli.FlagDeposit)
+		deposit, err := sdk.ParseCoinsNormalized(depositStr)
+		if err != nil {
+			return err
+		}
+		msg, err := govtypes.NewMsgSubmitProposal(content(title, description, args[0], args[1]), deposit, clientCtx.GetFromAddress())
+		if err != nil {
+			return err
+		}
+		if err := msg.ValidateBasic(); err != nil {
+			return err
+		}
+		return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+	}
+	cmd.Flags().String(govcli.FlagTitle, "", "title of the proposal")
+	cmd.Flags().String(govcli.FlagDescription, "", "description of the proposal")
+	cmd.Flags().String(govcli.FlagDeposit, "", "initial deposit of the proposal")
+	return cmd
+}
+
+// DIDProposalReq is the REST request body of the DID module's proposals.
+type DIDProposalReq struct {
+	BaseReq     rest.BaseReq   `json:"base_req"`
+	Title       string         `json:"title"`
+	Description string         `json:"description"`
+	DID         string         `json:"did"`
+	Reason      string         `json:"reason"`
+	Proposer    sdk.AccAddress `json:"proposer"`
+	Deposit     sdk.Coins      `json:"deposit"`
+}
+
+func slashDepositProposalRESTHandler(cliCtx client.Context) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "slash_did_deposit",
+		Handler:  proposalRESTHandler(cliCtx, newSlashDepositProposal),
+	}
+}
+
+func deactivateDIDProposalRESTHandler(cliCtx client.Context) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "deactivate_did",
+		Handler:  proposalRESTHandler(cliCtx, newDeactivateDIDProposal),
+	}
+}
+
+func proposalRESTHandler(cliCtx client.Context, content didProposal) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req DIDProposalReq
+		if !rest.ReadRESTReq(w, r, cliCtx.LegacyAmino, &req) {
+			return
+		}
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+		msg, err := govtypes.NewMsgSubmitProposal(content(req.Title, req.Description, req.DID, req.Reason), req.Deposit, req.Proposer)
+		if rest.CheckBadRequestError(w, err) {
+			return
+		}
+		if rest.CheckBadRequestError(w, msg.ValidateBasic()) {
+			return
+		}
+		tx.WriteGeneratedTxResponse(cliCtx, w, req.BaseReq, msg)
+	}
+}
@@ -0,0 +1,367 @@
+package did
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// Query endpoints supported by the DID querier. Any other path is treated
+// as a DID and resolved directly.
+const (
+	QueryCredentialStatus = "credential-status"
+	QueryResource         = "resource"
+	QueryResources        = "resources"
+	QueryResourceVersions = "resource-versions"
+	QueryResourceByName   = "resource-by-name"
+	QueryParams           = "params"
+	QueryPendingChanges   = "pending-changes"
+	QueryPendingChange    = "pending-change"
+	QueryRecovery         = "recovery"
+	QueryKeyRotation      = "key-rotation"
+	QueryAudit            = "audit"
+	QueryVerifyDocument   = "verify-document"
+	QueryByAddress        = "by-address"
+	QueryAccounts         = "accounts"
+	QueryCapabilities     = "capabilities"
+	QueryLinkedAssets     = "linked-assets"
+	QueryDeposit          = "deposit"
+	QueryStats            = "stats"
+	QueryHashAnchors      = "hash-anchors"
+	// QueryVerifyCredentialInclusion takes a JSON
+	// QueryVerifyCredentialInclusionRequest as data.
+	QueryVerifyCredentialInclusion = "verify-credential-inclusion"
+	// QueryPresentationDefinitions takes a verifier DID and optionally a
+	// definition ID.
+	QueryPresentationDefinitions = "presentation-definitions"
+)
+
+// NewQuerier creates a legacy querier for the DID module. Results are encoded
+// as plain JSON so off-chain clients can decode them with encoding/json.
+func NewQuerier(k Keeper, _ *codec.LegacyAmino) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, error) {
+		if len(path) == 0 {
+			return nil, fmt.Errorf("empty DID query path")
+		}
+		switch path[0] {
+		case QueryCredentialStatus:
+			return queryCredentialStatus(ctx, path[1:], k)
+		case QueryResource:
+			return queryResource(ctx, path[1:], k)
+		case QueryResources:
+			return queryResources(ctx, path[1:], k)
+		case QueryResourceVersions:
+			return queryResourceVersions(ctx, path[1:], k)
+		case QueryResourceByName:
+			return queryResourceByName(ctx, path[1:], k)
+		case QueryPendingChanges:
+			return queryPendingChanges(ctx, path[1:], k)
+		case QueryPendingChange:
+			return queryPendingChange(ctx, path[1:], k)
+		case QueryRecovery:
+			return queryRecovery(ctx, path[1:], k)
+		case QueryKeyRotation:
+			return queryKeyRotation(ctx, path[1:], k)
+		case QueryAudit:
+			return queryAudit(ctx, path[1:], k)
+		case QueryVerifyDocument:
+			return queryVerifyDocument(ctx, req.Data, k)
+		case QueryVerifyCredentialInclusion:
+			return queryVerifyCredentialInclusion(ctx, req.Data, k)
+		case QueryByAddress:
+			return queryByAddress(ctx, path[1:], k)
+		case QueryAccounts:
+			return queryAccounts(ctx, path[1:], k)
+		case QueryCapabilities:
+			return queryCapabilities(ctx, path[1:], k)
+		case QueryLinkedAssets:
+			return queryLinkedAssets(ctx, path[1:], k)
+		case QueryDeposit:
+			return queryDeposit(ctx, path[1:], k)
+		case QueryPresentationDefinitions:
+			return queryPresentationDefinitions(ctx, path[1:], k)
+		case QueryHashAnchors:
+			return queryHashAnchors(ctx, path[1:], k)
+		case QueryParams:
+			return json.MarshalIndent(k.GetParams(ctx), "", "  ")
+		case QueryStats:
+			return json.MarshalIndent(k.GetStats(ctx), "", "  ")
+		default:
+			return queryDID(ctx, path, k)
+		}
+	}
+}
+
+func queryDID(ctx sdk.Context, path []string, k Keeper) ([]byte, error) {
+	defer measureResolve("did", time.Now())
+	id := strings.Join(path, "/")
+	if err := ValidateChecksum(id); err != nil {
+		return nil, err
+	}
+	did, err := k.resolveDID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(did, "", "  ")
+}
+
+func queryCredentialStatus(ctx sdk.Context, path []string, k Keeper) ([]byte, error) {
+	defer measureResolve("credential_status", time.Now())
+	if len(path) == 0 {
+		return nil, fmt.Errorf("credential ID cannot be empty")
+	}
+	// Credential IDs are often URLs, so re-join any segments split on "/".
+	id := strings.Join(path, "/")
+	status, err := k.GetCredentialStatus(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// The status list entry is inlined next to the status, so the result
+	// stays a superset of the bare CredentialStatus.
+	res := struct {
+		CredentialStatus
+		Entry *StatusListEntry `json:"entry,omitempty"`
+	}{CredentialStatus: status}
+	if entry, found := k.GetStatusListEntry(ctx, id); found {
+		res.Entry = &entry
+	}
+	return json.MarshalIndent(res, "", "  ")
+}
+
+func queryResource(ctx sdk.Context, path []string, k Keeper) ([]byte, error) {
+	if len(path) != 2 {
+		return nil, fmt.Errorf("expected resource query path <did>/<resource-id>")
+	}
+	res, err := k.GetResource(ctx, path[0], path[1])
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(res, "", "  ")
+}
+
+func queryResourceVersions(ctx sdk.Context, path []string, k Keeper) ([]byte, error) {
+	if len(path) != 2 {
+		return nil, fmt.Errorf("expected resource versions query path <did>/<resource-id>")
+	}
+	versions, err := k.ResourceVersions(ctx, path[0], path[1])
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(versions, "", "  ")
+}
+
+func queryResourceByName(ctx sdk.Context, path []string, k Keeper) ([]byte, error) {
+	if len(path) != 3 && len(path) != 4 {
+		return nil, fmt.Errorf("expected resource by name query path <did>/<name>/<type>[/<unix-time>]")
+	}
+	var at int64
+	if len(path) == 4 {
+		var err error
+		if at, err = strconv.ParseInt(path[3], 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid time %q", path[3])
+		}
+	}
+	res, err := k.ResourceAtTime(ctx, path[0], path[1], path[2], at)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(res, "", "  ")
+}
+
+func queryResources(ctx sdk.Context, path []string, k Keeper) ([]byte, error) {
+	if len(path) != 1 {
+		return nil, fmt.Errorf("expected resources query path <did>")
+	}
+	return json.MarshalIndent(k.GetResourceCollection(ctx, path[0]), "", "  ")
+}
+
+func queryPendingChanges(ctx sdk.Context, path []string, k Keeper) ([]byte, error) {
+	if len(path) != 1 {
+		return nil, fmt.Errorf("expected pending changes query path <did>")
+	}
+	return json.MarshalIndent(k.GetPendingDIDChanges(ctx, path[0]), "", "  ")
+}
+
+func queryPendingChange(ctx sdk.Context, path []string, k Keeper) ([]byte, error) {
+	if len(path) != 1 {
+		return nil, fmt.Errorf("expected pending change query path <change-id>")
+	}
+	id, err := strconv.ParseUint(path[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid change ID %q", path[0])
+	}
+	change, err := k.GetPendingDIDChange(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(change, "", "  ")
+}
+
+func queryRecovery(ctx sdk.Context, path []string, k Keeper) ([]byte, error) {
+	if len(path) != 1 {
+		return nil, fmt.Errorf("expected recovery query path <did>")
+	}
+	config, found := k.GetRecoveryConfig(ctx, path[0])
+	if !found {
+		return nil, fmt.Errorf("DID %s has no recovery guardians", path[0])
+	}
+	status := RecoveryStatus{Config: config}
+	if rec, found := k.GetPendingRecovery(ctx, path[0]); found {
+		status.Pending = &rec
+	}
+	return json.MarshalIndent(status, "", "  ")
+}
+
+func queryKeyRotation(ctx sdk.Context, path []string, k Keeper) ([]byte, error) {
+	if len(path) != 1 {
+		return nil, fmt.Errorf("expected key rotation query path <did>")
+	}
+	rot, found := k.GetPendingKeyRotation(ctx, path[0])
+	if !found {
+		return nil, fmt.Errorf("no key rotation of %s is pending", path[0])
+	}
+	return json.MarshalIndent(rot, "", "  ")
+}
+
+func queryAudit(ctx sdk.Context, path []string, k Keeper) ([]byte, error) {
+	if len(path) != 1 {
+		return nil, fmt.Errorf("expected audit query path <did>")
+	}
+	return json.MarshalIndent(k.GetAuditLog(ctx, path[0]), "", "  ")
+}
+
+func queryByAddress(ctx sdk.Context, path []string, k Keeper) ([]byte, error) {
+	if len(path) != 1 {
+		return nil, fmt.Errorf("expected by-address query path <address>")
+	}
+	addr, err := sdk.AccAddressFromBech32(path[0])
+	if err != nil {
+		return nil, err
+	}
+	link, found := k.GetAccountLink(ctx, addr)
+	if !found {
+		return nil, fmt.Errorf("account %s is not linked to a DID", path[0])
+	}
+	return json.MarshalIndent(link, "", "  ")
+}
+
+func queryAccounts(ctx sdk.Context, path []string, k Keeper) ([]byte, error) {
+	if len(path) != 1 {
+		return nil, fmt.Errorf("expected accounts query path <did>")
+	}
+	links := k.GetAccountLinks(ctx, path[0])
+	if links == nil {
+		links = []AccountLink{}
+	}
+	return json.MarshalIndent(links, "", "  ")
+}
+
+func queryCapabilities(ctx sdk.Context, path []string, k Keeper) ([]byte, error) {
+	if len(path) != 1 {
+		return nil, fmt.Errorf("expected capabilities query path <did>")
+	}
+	grants := k.GetActiveCapabilityGrants(ctx, path[0])
+	if grants == nil {
+		grants = []CapabilityGrant{}
+	}
+	return json.MarshalIndent(grants, "", "  ")
+}
+
+func queryLinkedAssets(ctx sdk.Context, path []string, k Keeper) ([]byte, error) {
+	if len(path) != 1 {
+		return nil, fmt.Errorf("expected linked-assets query path <did>")
+	}
+	assets := k.GetLinkedAssets(ctx, path[0])
+	if assets == nil {
+		assets = []LinkedAsset{}
+	}
+	return json.MarshalIndent(assets, "", "  ")
+}
+
+func queryDeposit(ctx sdk.Context, path []string, k Keeper) ([]byte, error) {
+	if len(path) != 1 {
+		return nil, fmt.Errorf("expected deposit query path <did>")
+	}
+	deposit, found := k.GetDeposit(ctx, path[0])
+	if !found {
+		return nil, fmt.Errorf("DID %s has no locked deposit", path[0])
+	}
+	return json.MarshalIndent(deposit, "", "  ")
+}
+
+func queryPresentationDefinitions(ctx sdk.Context, path []string, k Keeper) ([]byte, error) {
+	switch len(path) {
+	case 1:
+		records := k.GetPresentationDefinitions(ctx, path[0])
+		if records == nil {
+			records = []PresentationDefinitionRecord{}
+		}
+		return json.MarshalIndent(records, "", "  ")
+	case 2:
+		record, found := k.GetPresentationDefinition(ctx, path[0], path[1])
+		if !found {
+			return nil, fmt.Errorf("%s has no presentation definition %s", path[0], path[1])
+		}
+		return json.MarshalIndent(record, "", "  ")
+	default:
+		return nil, fmt.Errorf("expected presentation definitions query path <did>[/<definition-id>]")
+	}
+}
+
+func queryHashAnchors(ctx sdk.Context, path []string, k Keeper) ([]byte, error) {
+	if len(path) != 1 {
+		return nil, fmt.Errorf("expected hash anchors query path <hex-hash>")
+	}
+	hash, err := parseAnchorHash(path[0])
+	if err != nil {
+		return nil, err
+	}
+	anchors := k.GetHashAnchors(ctx, hash)
+	if len(anchors) == 0 {
+		return nil, fmt.Errorf("hash %s has not been anchored", path[0])
+	}
+	return json.MarshalIndent(anchors, "", "  ")
+}
+
+// queryVerifyDocument checks the document and salt in data, a JSON
+// QueryVerifyDocumentRequest, against the DID's commitment.
+func queryVerifyDocument(ctx sdk.Context, data []byte, k Keeper) ([]byte, error) {
+	var req QueryVerifyDocumentRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		return nil, fmt.Errorf("invalid verify-document request: %w", err)
+	}
+	commitment, err := k.GetDIDCommitment(ctx, req.Document.ID)
+	if err != nil {
+		return nil, err
+	}
+	valid, err := k.VerifyDocument(ctx, req.Document, req.Salt)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(QueryVerifyDocumentResponse{Valid: valid, Commitment: commitment}, "", "  ")
+}
+
+// queryVerifyCredentialInclusion checks the credential hash and Merkle path
+// in data, a JSON QueryVerifyCredentialInclusionRequest, against the
+// issuer's anchored root.
+func queryVerifyCredentialInclusion(ctx sdk.Context, data []byte, k Keeper) ([]byte, error) {
+	var req QueryVerifyCredentialInclusionRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		return nil, fmt.Errorf("invalid verify-credential-inclusion request: %w", err)
+	}
+	root, found := k.GetCredentialRoot(ctx, req.Issuer, req.Root)
+	if !found {
+		return nil, fmt.Errorf("%s has not anchored credential root %X", req.Issuer, req.Root)
+	}
+	valid, err := k.VerifyCredentialInclusion(ctx, req.Issuer, req.Root, req.CredentialHash, req.Proof)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(QueryVerifyCredentialInclusionResponse{Valid: valid, Root: root}, "", "  ")
+}
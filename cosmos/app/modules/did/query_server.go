@@ -0,0 +1,1560 @@
+package did
+
+import (
+	"context"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	grpc1 "github.com/gogo/protobuf/grpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// QueryServer is the server API for the aytch.did.v1.Query service, served
+// on the node's gRPC endpoint and, through the gRPC gateway, on the API server
+// under /aytch/did/v1. It mirrors the legacy querier; list queries take
+// standard pagination.
+type QueryServer interface {
+	DID(context.Context, *QueryDIDRequest) (*QueryDIDResponse, error)
+	DIDs(context.Context, *QueryDIDsRequest) (*QueryDIDsResponse, error)
+	CredentialStatus(context.Context, *QueryCredentialStatusRequest) (*QueryCredentialStatusResponse, error)
+	Resource(context.Context, *QueryResourceRequest) (*QueryResourceResponse, error)
+	Resources(context.Context, *QueryResourcesRequest) (*QueryResourcesResponse, error)
+	PendingChanges(context.Context, *QueryPendingChangesRequest) (*QueryPendingChangesResponse, error)
+	PendingChange(context.Context, *QueryPendingChangeRequest) (*QueryPendingChangeResponse, error)
+	Recovery(context.Context, *QueryRecoveryRequest) (*QueryRecoveryResponse, error)
+	KeyRotation(context.Context, *QueryKeyRotationRequest) (*QueryKeyRotationResponse, error)
+	Params(context.Context, *QueryParamsRequest) (*QueryParamsResponse, error)
+	VerifyDocument(context.Context, *QueryVerifyDocumentRequest) (*QueryVerifyDocumentResponse, error)
+	DIDByAddress(context.Context, *QueryDIDByAddressRequest) (*QueryDIDByAddressResponse, error)
+	Accounts(context.Context, *QueryAccountsRequest) (*QueryAccountsResponse, error)
+	Capabilities(context.Context, *QueryCapabilitiesRequest) (*QueryCapabilitiesResponse, error)
+	LinkedAssets(context.Context, *QueryLinkedAssetsRequest) (*QueryLinkedAssetsResponse, error)
+	Deposit(context.Context, *QueryDepositRequest) (*QueryDepositResponse, error)
+	ResourceVersions(context.Context, *QueryResourceVersionsRequest) (*QueryResourceVersionsResponse, error)
+	PresentationDefinitions(context.Context, *QueryPresentationDefinitionsRequest) (*QueryPresentationDefinitionsResponse, error)
+	PresentationDefinition(context.Context, *QueryPresentationDefinitionRequest) (*QueryPresentationDefinitionResponse, error)
+	ResourceByName(context.Context, *QueryResourceByNameRequest) (*QueryResourceByNameResponse, error)
+	TxHistory(context.Context, *QueryTxHistoryRequest) (*QueryTxHistoryResponse, error)
+	Activity(context.Context, *QueryActivityRequest) (*QueryActivityResponse, error)
+	Stats(context.Context, *QueryStatsRequest) (*QueryStatsResponse, error)
+	HashAnchors(context.Context, *QueryHashAnchorsRequest) (*QueryHashAnchorsResponse, error)
+	VerifyCredentialInclusion(context.Context, *QueryVerifyCredentialInclusionRequest) (*QueryVerifyCredentialInclusionResponse, error)
+}
+
+// QueryDIDRequest is the request type for Query/DID.
+type QueryDIDRequest struct {
+	ID string `protobuf:"bytes,1,opt,name=id,proto3" json:"id"`
+}
+
+func (m *QueryDIDRequest) Reset()         { *m = QueryDIDRequest{} }
+func (m *QueryDIDRequest) String() string { return "QueryDIDRequest" }
+func (*QueryDIDRequest) ProtoMessage()    {}
+
+// QueryDIDResponse is the response type for Query/DID.
+type QueryDIDResponse struct {
+	Document DIDDocument `protobuf:"bytes,1,opt,name=document,proto3" json:"document"`
+}
+
+func (m *QueryDIDResponse) Reset()         { *m = QueryDIDResponse{} }
+func (m *QueryDIDResponse) String() string { return "QueryDIDResponse" }
+func (*QueryDIDResponse) ProtoMessage()    {}
+
+// QueryDIDsRequest is the request type for Query/DIDs.
+type QueryDIDsRequest struct {
+	Pagination *query.PageRequest `protobuf:"bytes,1,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (m *QueryDIDsRequest) Reset()         { *m = QueryDIDsRequest{} }
+func (m *QueryDIDsRequest) String() string { return "QueryDIDsRequest" }
+func (*QueryDIDsRequest) ProtoMessage()    {}
+
+// QueryDIDsResponse is the response type for Query/DIDs.
+type QueryDIDsResponse struct {
+	DIDs       []DIDDocument       `protobuf:"bytes,1,rep,name=dids,proto3" json:"dids"`
+	Pagination *query.PageResponse `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (m *QueryDIDsResponse) Reset()         { *m = QueryDIDsResponse{} }
+func (m *QueryDIDsResponse) String() string { return "QueryDIDsResponse" }
+func (*QueryDIDsResponse) ProtoMessage()    {}
+
+// QueryCredentialStatusRequest is the request type for Query/CredentialStatus.
+type QueryCredentialStatusRequest struct {
+	ID string `protobuf:"bytes,1,opt,name=id,proto3" json:"id"`
+}
+
+func (m *QueryCredentialStatusRequest) Reset()         { *m = QueryCredentialStatusRequest{} }
+func (m *QueryCredentialStatusRequest) String() string { return "QueryCredentialStatusRequest" }
+func (*QueryCredentialStatusRequest) ProtoMessage()    {}
+
+// QueryCredentialStatusResponse is the response type for Query/CredentialStatus.
+// Entry is the credential's status list entry, if its issuer registered one.
+type QueryCredentialStatusResponse struct {
+	Status CredentialStatus `protobuf:"bytes,1,opt,name=status,proto3" json:"status"`
+	Entry  *StatusListEntry `protobuf:"bytes,2,opt,name=entry,proto3" json:"entry,omitempty"`
+}
+
+func (m *QueryCredentialStatusResponse) Reset()         { *m = QueryCredentialStatusResponse{} }
+func (m *QueryCredentialStatusResponse) String() string { return "QueryCredentialStatusResponse" }
+func (*QueryCredentialStatusResponse) ProtoMessage()    {}
+
+// QueryResourceRequest is the request type for Query/Resource.
+type QueryResourceRequest struct {
+	DID string `protobuf:"bytes,1,opt,name=did,proto3" json:"did"`
+	ID  string `protobuf:"bytes,2,opt,name=id,proto3" json:"id"`
+}
+
+func (m *QueryResourceRequest) Reset()         { *m = QueryResourceRequest{} }
+func (m *QueryResourceRequest) String() string { return "QueryResourceRequest" }
+func (*QueryResourceRequest) ProtoMessage()    {}
+
+// QueryResourceResponse is the response type for Query/Resource.
+type QueryResourceResponse struct {
+	Resource Resource `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource"`
+}
+
+func (m *QueryResourceResponse) Reset()         { *m = QueryResourceResponse{} }
+func (m *QueryResourceResponse) String() string { return "QueryResourceResponse" }
+func (*QueryResourceResponse) ProtoMessage()    {}
+
+// QueryResourceVersionsRequest is the request type for Query/ResourceVersions.
+type QueryResourceVersionsRequest struct {
+	DID string `protobuf:"bytes,1,opt,name=did,proto3" json:"did"`
+	ID  string `protobuf:"bytes,2,opt,name=id,proto3" json:"id"`
+}
+
+func (m *QueryResourceVersionsRequest) Reset()         { *m = QueryResourceVersionsRequest{} }
+func (m *QueryResourceVersionsRequest) String() string { return "QueryResourceVersionsRequest" }
+func (*QueryResourceVersionsRequest) ProtoMessage()    {}
+
+// QueryResourceVersionsResponse is the response type for Query/ResourceVersions.
+// Versions lists the metadata of the resource's version chain, oldest first.
+type QueryResourceVersionsResponse struct {
+	Versions []Resource `protobuf:"bytes,1,rep,name=versions,proto3" json:"versions"`
+}
+
+func (m *QueryResourceVersionsResponse) Reset()         { *m = QueryResourceVersionsResponse{} }
+func (m *QueryResourceVersionsResponse) String() string { return "QueryResourceVersionsResponse" }
+func (*QueryResourceVersionsResponse) ProtoMessage()    {}
+
+// QueryResourcesRequest is the request type for Query/Resources.
+type QueryResourcesRequest struct {
+	DID        string             `protobuf:"bytes,1,opt,name=did,proto3" json:"did"`
+	Pagination *query.PageRequest `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (m *QueryResourcesRequest) Reset()         { *m = QueryResourcesRequest{} }
+func (m *QueryResourcesRequest) String() string { return "QueryResourcesRequest" }
+func (*QueryResourcesRequest) ProtoMessage()    {}
+
+// QueryResourcesResponse is the response type for Query/Resources.
+type QueryResourcesResponse struct {
+	Resources  []Resource          `protobuf:"bytes,1,rep,name=resources,proto3" json:"resources"`
+	Pagination *query.PageResponse `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (m *QueryResourcesResponse) Reset()         { *m = QueryResourcesResponse{} }
+func (m *QueryResourcesResponse) String() string { return "QueryResourcesResponse" }
+func (*QueryResourcesResponse) ProtoMessage()    {}
+
+// QueryPendingChangesRequest is the request type for Query/PendingChanges.
+type QueryPendingChangesRequest struct {
+	DID        string             `protobuf:"bytes,1,opt,name=did,proto3" json:"did"`
+	Pagination *query.PageRequest `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (m *QueryPendingChangesRequest) Reset()         { *m = QueryPendingChangesRequest{} }
+func (m *QueryPendingChangesRequest) String() string { return "QueryPendingChangesRequest" }
+func (*QueryPendingChangesRequest) ProtoMessage()    {}
+
+// QueryPendingChangesResponse is the response type for Query/PendingChanges.
+type QueryPendingChangesResponse struct {
+	Changes    []PendingDIDChange  `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes"`
+	Pagination *query.PageResponse `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (m *QueryPendingChangesResponse) Reset()         { *m = QueryPendingChangesResponse{} }
+func (m *QueryPendingChangesResponse) String() string { return "QueryPendingChangesResponse" }
+func (*QueryPendingChangesResponse) ProtoMessage()    {}
+
+// QueryPendingChangeRequest is the request type for Query/PendingChange.
+type QueryPendingChangeRequest struct {
+	ChangeID uint64 `protobuf:"varint,1,opt,name=change_id,proto3" json:"change_id"`
+}
+
+func (m *QueryPendingChangeRequest) Reset()         { *m = QueryPendingChangeRequest{} }
+func (m *QueryPendingChangeRequest) String() string { return "QueryPendingChangeRequest" }
+func (*QueryPendingChangeRequest) ProtoMessage()    {}
+
+// QueryPendingChangeResponse is the response type for Query/PendingChange.
+type QueryPendingChangeResponse struct {
+	Change PendingDIDChange `protobuf:"bytes,1,opt,name=change,proto3" json:"change"`
+}
+
+func (m *QueryPendingChangeResponse) Reset()         { *m = QueryPendingChangeResponse{} }
+func (m *QueryPendingChangeResponse) String() string { return "QueryPendingChangeResponse" }
+func (*QueryPendingChangeResponse) ProtoMessage()    {}
+
+// QueryRecoveryRequest is the request type for Query/Recovery.
+type QueryRecoveryRequest struct {
+	DID string `protobuf:"bytes,1,opt,name=did,proto3" json:"did"`
+}
+
+func (m *QueryRecoveryRequest) Reset()         { *m = QueryRecoveryRequest{} }
+func (m *QueryRecoveryRequest) String() string { return "QueryRecoveryRequest" }
+func (*QueryRecoveryRequest) ProtoMessage()    {}
+
+// QueryRecoveryResponse is the response type for Query/Recovery.
+type QueryRecoveryResponse struct {
+	Config  RecoveryConfig   `protobuf:"bytes,1,opt,name=config,proto3" json:"config"`
+	Pending *PendingRecovery `protobuf:"bytes,2,opt,name=pending,proto3" json:"pending,omitempty"`
+}
+
+func (m *QueryRecoveryResponse) Reset()         { *m = QueryRecoveryResponse{} }
+func (m *QueryRecoveryResponse) String() string { return "QueryRecoveryResponse" }
+func (*QueryRecoveryResponse) ProtoMessage()    {}
+
+// QueryKeyRotationRequest is the request type for Query/KeyRotation.
+type QueryKeyRotationRequest struct {
+	DID string `protobuf:"bytes,1,opt,name=did,proto3" json:"did"`
+}
+
+func (m *QueryKeyRotationRequest) Reset()         { *m = QueryKeyRotationRequest{} }
+func (m *QueryKeyRotationRequest) String() string { return "QueryKeyRotationRequest" }
+func (*QueryKeyRotationRequest) ProtoMessage()    {}
+
+// QueryKeyRotationResponse is the response type for Query/KeyRotation.
+type QueryKeyRotationResponse struct {
+	Rotation PendingKeyRotation `protobuf:"bytes,1,opt,name=rotation,proto3" json:"rotation"`
+}
+
+func (m *QueryKeyRotationResponse) Reset()         { *m = QueryKeyRotationResponse{} }
+func (m *QueryKeyRotationResponse) String() string { return "QueryKeyRotationResponse" }
+func (*QueryKeyRotationResponse) ProtoMessage()    {}
+
+// QueryParamsRequest is the request type for Query/Params.
+type QueryParamsRequest struct{}
+
+func (m *QueryParamsRequest) Reset()         { *m = QueryParamsRequest{} }
+func (m *QueryParamsRequest) String() string { return "QueryParamsRequest" }
+func (*QueryParamsRequest) ProtoMessage()    {}
+
+// QueryParamsResponse is the response type for Query/Params.
+type QueryParamsResponse struct {
+	Params Params `protobuf:"bytes,1,opt,name=params,proto3" json:"params"`
+}
+
+func (m *QueryParamsResponse) Reset()         { *m = QueryParamsResponse{} }
+func (m *QueryParamsResponse) String() string { return "QueryParamsResponse" }
+func (*QueryParamsResponse) ProtoMessage()    {}
+
+// QueryVerifyDocumentRequest is the request type for Query/VerifyDocument: a
+// document disclosed off-chain and the salt it was committed under.
+type QueryVerifyDocumentRequest struct {
+	Document DIDDocument `protobuf:"bytes,1,opt,name=document,proto3" json:"document"`
+	Salt     []byte      `protobuf:"bytes,2,opt,name=salt,proto3" json:"salt"`
+}
+
+func (m *QueryVerifyDocumentRequest) Reset()         { *m = QueryVerifyDocumentRequest{} }
+func (m *QueryVerifyDocumentRequest) String() string { return "QueryVerifyDocumentRequest" }
+func (*QueryVerifyDocumentRequest) ProtoMessage()    {}
+
+// QueryVerifyDocumentResponse is the response type for Query/VerifyDocument.
+type QueryVerifyDocumentResponse struct {
+	Valid      bool          `protobuf:"varint,1,opt,name=valid,proto3" json:"valid"`
+	Commitment DIDCommitment `protobuf:"bytes,2,opt,name=commitment,proto3" json:"commitment"`
+}
+
+func (m *QueryVerifyDocumentResponse) Reset()         { *m = QueryVerifyDocumentResponse{} }
+func (m *QueryVerifyDocumentResponse) String() string { return "QueryVerifyDocumentResponse" }
+func (*QueryVerifyDocumentResponse) ProtoMessage()    {}
+
+// QueryDIDByAddressRequest is the request type for Query/DIDByAddress.
+type QueryDIDByAddressRequest struct {
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address"`
+}
+
+func (m *QueryDIDByAddressRequest) Reset()         { *m = QueryDIDByAddressRequest{} }
+func (m *QueryDIDByAddressRequest) String() string { return "QueryDIDByAddressRequest" }
+func (*QueryDIDByAddressRequest) ProtoMessage()    {}
+
+// QueryDIDByAddressResponse is the response type for Query/DIDByAddress.
+type QueryDIDByAddressResponse struct {
+	Link AccountLink `protobuf:"bytes,1,opt,name=link,proto3" json:"link"`
+}
+
+func (m *QueryDIDByAddressResponse) Reset()         { *m = QueryDIDByAddressResponse{} }
+func (m *QueryDIDByAddressResponse) String() string { return "QueryDIDByAddressResponse" }
+func (*QueryDIDByAddressResponse) ProtoMessage()    {}
+
+// QueryAccountsRequest is the request type for Query/Accounts.
+type QueryAccountsRequest struct {
+	DID string `protobuf:"bytes,1,opt,name=did,proto3" json:"did"`
+}
+
+func (m *QueryAccountsRequest) Reset()         { *m = QueryAccountsRequest{} }
+func (m *QueryAccountsRequest) String() string { return "QueryAccountsRequest" }
+func (*QueryAccountsRequest) ProtoMessage()    {}
+
+// QueryAccountsResponse is the response type for Query/Accounts.
+type QueryAccountsResponse struct {
+	Links []AccountLink `protobuf:"bytes,1,rep,name=links,proto3" json:"links"`
+}
+
+func (m *QueryAccountsResponse) Reset()         { *m = QueryAccountsResponse{} }
+func (m *QueryAccountsResponse) String() string { return "QueryAccountsResponse" }
+func (*QueryAccountsResponse) ProtoMessage()    {}
+
+// QueryCapabilitiesRequest is the request type for Query/Capabilities.
+type QueryCapabilitiesRequest struct {
+	DID string `protobuf:"bytes,1,opt,name=did,proto3" json:"did"`
+}
+
+func (m *QueryCapabilitiesRequest) Reset()         { *m = QueryCapabilitiesRequest{} }
+func (m *QueryCapabilitiesRequest) String() string { return "QueryCapabilitiesRequest" }
+func (*QueryCapabilitiesRequest) ProtoMessage()    {}
+
+// QueryCapabilitiesResponse is the response type for Query/Capabilities.
+type QueryCapabilitiesResponse struct {
+	Grants []CapabilityGrant `protobuf:"bytes,1,rep,name=grants,proto3" json:"grants"`
+}
+
+func (m *QueryCapabilitiesResponse) Reset()         { *m = QueryCapabilitiesResponse{} }
+func (m *QueryCapabilitiesResponse) String() string { return "QueryCapabilitiesResponse" }
+func (*QueryCapabilitiesResponse) ProtoMessage()    {}
+
+// QueryLinkedAssetsRequest is the request type for Query/LinkedAssets.
+type QueryLinkedAssetsRequest struct {
+	DID string `protobuf:"bytes,1,opt,name=did,proto3" json:"did"`
+}
+
+func (m *QueryLinkedAssetsRequest) Reset()         { *m = QueryLinkedAssetsRequest{} }
+func (m *QueryLinkedAssetsRequest) String() string { return "QueryLinkedAssetsRequest" }
+func (*QueryLinkedAssetsRequest) ProtoMessage()    {}
+
+// QueryLinkedAssetsResponse is the response type for Query/LinkedAssets.
+type QueryLinkedAssetsResponse struct {
+	Assets []LinkedAsset `protobuf:"bytes,1,rep,name=assets,proto3" json:"assets"`
+}
+
+func (m *QueryLinkedAssetsResponse) Reset()         { *m = QueryLinkedAssetsResponse{} }
+func (m *QueryLinkedAssetsResponse) String() string { return "QueryLinkedAssetsResponse" }
+func (*QueryLinkedAssetsResponse) ProtoMessage()    {}
+
+// QueryDepositRequest is the request type for Query/Deposit.
+type QueryDepositRequest struct {
+	DID string `protobuf:"bytes,1,opt,name=did,proto3" json:"did"`
+}
+
+func (m *QueryDepositRequest) Reset()         { *m = QueryDepositRequest{} }
+func (m *QueryDepositRequest) String() string { return "QueryDepositRequest" }
+func (*QueryDepositRequest) ProtoMessage()    {}
+
+// QueryDepositResponse is the response type for Query/Deposit.
+type QueryDepositResponse struct {
+	Deposit   DIDDeposit `protobuf:"bytes,1,opt,name=deposit,proto3" json:"deposit"`
+	FeeEscrow *FeeEscrow `protobuf:"bytes,2,opt,name=fee_escrow,proto3" json:"fee_escrow,omitempty"`
+}
+
+func (m *QueryDepositResponse) Reset()         { *m = QueryDepositResponse{} }
+func (m *QueryDepositResponse) String() string { return "QueryDepositResponse" }
+func (*QueryDepositResponse) ProtoMessage()    {}
+
+// QueryPresentationDefinitionsRequest is the request type for
+// Query/PresentationDefinitions.
+type QueryPresentationDefinitionsRequest struct {
+	Verifier string `protobuf:"bytes,1,opt,name=verifier,proto3" json:"verifier"`
+}
+
+func (m *QueryPresentationDefinitionsRequest) Reset() { *m = QueryPresentationDefinitionsRequest{} }
+func (m *QueryPresentationDefinitionsRequest) String() string {
+	return "QueryPresentationDefinitionsRequest"
+}
+func (*QueryPresentationDefinitionsRequest) ProtoMessage() {}
+
+// QueryPresentationDefinitionsResponse is the response type for
+// Query/PresentationDefinitions.
+type QueryPresentationDefinitionsResponse struct {
+	Definitions []PresentationDefinitionRecord `protobuf:"bytes,1,rep,name=definitions,proto3" json:"definitions"`
+}
+
+func (m *QueryPresentationDefinitionsResponse) Reset() { *m = QueryPresentationDefinitionsResponse{} }
+func (m *QueryPresentationDefinitionsResponse) String() string {
+	return "QueryPresentationDefinitionsResponse"
+}
+func (*QueryPresentationDefinitionsResponse) ProtoMessage() {}
+
+// QueryPresentationDefinitionRequest is the request type for
+// Query/PresentationDefinition.
+type QueryPresentationDefinitionRequest struct {
+	Verifier string `protobuf:"bytes,1,opt,name=verifier,proto3" json:"verifier"`
+	ID       string `protobuf:"bytes,2,opt,name=id,proto3" json:"id"`
+}
+
+func (m *QueryPresentationDefinitionRequest) Reset() { *m = QueryPresentationDefinitionRequest{} }
+func (m *QueryPresentationDefinitionRequest) String() string {
+	return "QueryPresentationDefinitionRequest"
+}
+func (*QueryPresentationDefinitionRequest) ProtoMessage() {}
+
+// QueryPresentationDefinitionResponse is the response type for
+// Query/PresentationDefinition.
+type QueryPresentationDefinitionResponse struct {
+	Definition PresentationDefinitionRecord `protobuf:"bytes,1,opt,name=definition,proto3" json:"definition"`
+}
+
+func (m *QueryPresentationDefinitionResponse) Reset() { *m = QueryPresentationDefinitionResponse{} }
+func (m *QueryPresentationDefinitionResponse) String() string {
+	return "QueryPresentationDefinitionResponse"
+}
+func (*QueryPresentationDefinitionResponse) ProtoMessage() {}
+
+// QueryResourceByNameRequest is the request type for Query/ResourceByName.
+// Time is the Unix time the returned version must have been current at; 0
+// selects the latest version.
+type QueryResourceByNameRequest struct {
+	DID          string `protobuf:"bytes,1,opt,name=did,proto3" json:"did"`
+	Name         string `protobuf:"bytes,2,opt,name=name,proto3" json:"name"`
+	ResourceType string `protobuf:"bytes,3,opt,name=resource_type,proto3" json:"resource_type"`
+	Time         int64  `protobuf:"varint,4,opt,name=time,proto3" json:"time,omitempty"`
+}
+
+func (m *QueryResourceByNameRequest) Reset()         { *m = QueryResourceByNameRequest{} }
+func (m *QueryResourceByNameRequest) String() string { return "QueryResourceByNameRequest" }
+func (*QueryResourceByNameRequest) ProtoMessage()    {}
+
+// QueryResourceByNameResponse is the response type for Query/ResourceByName.
+type QueryResourceByNameResponse struct {
+	Resource Resource `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource"`
+}
+
+func (m *QueryResourceByNameResponse) Reset()         { *m = QueryResourceByNameResponse{} }
+func (m *QueryResourceByNameResponse) String() string { return "QueryResourceByNameResponse" }
+func (*QueryResourceByNameResponse) ProtoMessage()    {}
+
+// QueryTxHistoryRequest is the request type for Query/TxHistory.
+type QueryTxHistoryRequest struct {
+	DID        string             `protobuf:"bytes,1,opt,name=did,proto3" json:"did"`
+	Pagination *query.PageRequest `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (m *QueryTxHistoryRequest) Reset()         { *m = QueryTxHistoryRequest{} }
+func (m *QueryTxHistoryRequest) String() string { return "QueryTxHistoryRequest" }
+func (*QueryTxHistoryRequest) ProtoMessage()    {}
+
+// QueryTxHistoryResponse is the response type for Query/TxHistory.
+type QueryTxHistoryResponse struct {
+	Records    []TxRecord          `protobuf:"bytes,1,rep,name=records,proto3" json:"records"`
+	Pagination *query.PageResponse `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (m *QueryTxHistoryResponse) Reset()         { *m = QueryTxHistoryResponse{} }
+func (m *QueryTxHistoryResponse) String() string { return "QueryTxHistoryResponse" }
+func (*QueryTxHistoryResponse) ProtoMessage()    {}
+
+// QueryActivityRequest is the request type for Query/Activity. A zero
+// ToHeight means no upper bound.
+type QueryActivityRequest struct {
+	Actor      string             `protobuf:"bytes,1,opt,name=actor,proto3" json:"actor"`
+	FromHeight int64              `protobuf:"varint,2,opt,name=from_height,proto3" json:"from_height,omitempty"`
+	ToHeight   int64              `protobuf:"varint,3,opt,name=to_height,proto3" json:"to_height,omitempty"`
+	Pagination *query.PageRequest `protobuf:"bytes,4,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (m *QueryActivityRequest) Reset()         { *m = QueryActivityRequest{} }
+func (m *QueryActivityRequest) String() string { return "QueryActivityRequest" }
+func (*QueryActivityRequest) ProtoMessage()    {}
+
+// QueryActivityResponse is the response type for Query/Activity.
+type QueryActivityResponse struct {
+	Records    []ActivityRecord    `protobuf:"bytes,1,rep,name=records,proto3" json:"records"`
+	Pagination *query.PageResponse `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (m *QueryActivityResponse) Reset()         { *m = QueryActivityResponse{} }
+func (m *QueryActivityResponse) String() string { return "QueryActivityResponse" }
+func (*QueryActivityResponse) ProtoMessage()    {}
+
+// QueryStatsRequest is the request type for Query/Stats.
+type QueryStatsRequest struct{}
+
+func (m *QueryStatsRequest) Reset()         { *m = QueryStatsRequest{} }
+func (m *QueryStatsRequest) String() string { return "QueryStatsRequest" }
+func (*QueryStatsRequest) ProtoMessage()    {}
+
+// QueryStatsResponse is the response type for Query/Stats.
+type QueryStatsResponse struct {
+	Stats RegistryStats `protobuf:"bytes,1,opt,name=stats,proto3" json:"stats"`
+}
+
+func (m *QueryStatsResponse) Reset()         { *m = QueryStatsResponse{} }
+func (m *QueryStatsResponse) String() string { return "QueryStatsResponse" }
+func (*QueryStatsResponse) ProtoMessage()    {}
+
+// QueryHashAnchorsRequest is the request type for Query/HashAnchors. Hash is
+// hex-encoded.
+type QueryHashAnchorsRequest struct {
+	Hash string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash"`
+}
+
+func (m *QueryHashAnchorsRequest) Reset()         { *m = QueryHashAnchorsRequest{} }
+func (m *QueryHashAnchorsRequest) String() string { return "QueryHashAnchorsRequest" }
+func (*QueryHashAnchorsRequest) ProtoMessage()    {}
+
+// QueryHashAnchorsResponse is the response type for Query/HashAnchors.
+type QueryHashAnchorsResponse struct {
+	Anchors []HashAnchor `protobuf:"bytes,1,rep,name=anchors,proto3" json:"anchors"`
+}
+
+func (m *QueryHashAnchorsResponse) Reset()         { *m = QueryHashAnchorsResponse{} }
+func (m *QueryHashAnchorsResponse) String() string { return "QueryHashAnchorsResponse" }
+func (*QueryHashAnchorsResponse) ProtoMessage()    {}
+
+// QueryVerifyCredentialInclusionRequest is the request type for
+// Query/VerifyCredentialInclusion: a credential hash and its Merkle path in
+// a batch under a root the issuer anchored.
+type QueryVerifyCredentialInclusionRequest struct {
+	Issuer         string                   `protobuf:"bytes,1,opt,name=issuer,proto3" json:"issuer"`
+	Root           []byte                   `protobuf:"bytes,2,opt,name=root,proto3" json:"root"`
+	CredentialHash []byte                   `protobuf:"bytes,3,opt,name=credential_hash,proto3" json:"credential_hash"`
+	Proof          CredentialInclusionProof `protobuf:"bytes,4,opt,name=proof,proto3" json:"proof"`
+}
+
+func (m *QueryVerifyCredentialInclusionRequest) Reset() {
+	*m = QueryVerifyCredentialInclusionRequest{}
+}
+func (m *QueryVerifyCredentialInclusionRequest) String() string {
+	return "QueryVerifyCredentialInclusionRequest"
+}
+func (*QueryVerifyCredentialInclusionRequest) ProtoMessage() {}
+
+// QueryVerifyCredentialInclusionResponse is the response type for
+// Query/VerifyCredentialInclusion.
+type QueryVerifyCredentialInclusionResponse struct {
+	Valid bool           `protobuf:"varint,1,opt,name=valid,proto3" json:"valid"`
+	Root  CredentialRoot `protobuf:"bytes,2,opt,name=root,proto3" json:"root"`
+}
+
+func (m *QueryVerifyCredentialInclusionResponse) Reset() {
+	*m = QueryVerifyCredentialInclusionResponse{}
+}
+func (m *QueryVerifyCredentialInclusionResponse) String() string {
+	return "QueryVerifyCredentialInclusionResponse"
+}
+func (*QueryVerifyCredentialInclusionResponse) ProtoMessage() {}
+
+type queryServer struct {
+	keeper Keeper
+}
+
+// NewQueryServerImpl returns an implementation of QueryServer backed by k.
+func NewQueryServerImpl(k Keeper) QueryServer {
+	return queryServer{keeper: k}
+}
+
+var _ QueryServer = queryServer{}
+
+func (s queryServer) DID(goCtx context.Context, req *QueryDIDRequest) (*QueryDIDResponse, error) {
+	defer measureResolve("did", time.Now())
+	if req == nil || req.ID == "" {
+		return nil, status.Error(codes.InvalidArgument, "DID cannot be empty")
+	}
+	if err := ValidateChecksum(req.ID); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	did, err := s.keeper.resolveDID(sdk.UnwrapSDKContext(goCtx), req.ID)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return &QueryDIDResponse{Document: did}, nil
+}
+
+func (s queryServer) DIDs(goCtx context.Context, req *QueryDIDsRequest) (*QueryDIDsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	store := prefix.NewStore(ctx.KVStore(s.keeper.storeKey), DocumentPrefix)
+	var dids []DIDDocument
+	pageRes, err := query.Paginate(store, req.Pagination, func(_, value []byte) error {
+		var did DIDDocument
+		s.keeper.cdc.MustUnmarshal(value, &did)
+		dids = append(dids, did)
+		return nil
+	})
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &QueryDIDsResponse{DIDs: dids, Pagination: pageRes}, nil
+}
+
+func (s queryServer) CredentialStatus(goCtx context.Context, req *QueryCredentialStatusRequest) (*QueryCredentialStatusResponse, error) {
+	defer measureResolve("credential_status", time.Now())
+	if req == nil || req.ID == "" {
+		return nil, status.Error(codes.InvalidArgument, "credential ID cannot be empty")
+	}
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	cs, err := s.keeper.GetCredentialStatus(ctx, req.ID)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	res := &QueryCredentialStatusResponse{Status: cs}
+	if entry, found := s.keeper.GetStatusListEntry(ctx, req.ID); found {
+		res.Entry = &entry
+	}
+	return res, nil
+}
+
+func (s queryServer) Resource(goCtx context.Context, req *QueryResourceRequest) (*QueryResourceResponse, error) {
+	if req == nil || req.DID == "" || req.ID == "" {
+		return nil, status.Error(codes.InvalidArgument, "DID and resource ID cannot be empty")
+	}
+	res, err := s.keeper.GetResource(sdk.UnwrapSDKContext(goCtx), req.DID, req.ID)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return &QueryResourceResponse{Resource: res}, nil
+}
+
+func (s queryServer) ResourceVersions(goCtx context.Context, req *QueryResourceVersionsRequest) (*QueryResourceVersionsResponse, error) {
+	if req == nil || req.DID == "" || req.ID == "" {
+		return nil, status.Error(codes.InvalidArgument, "DID and resource ID cannot be empty")
+	}
+	versions, err := s.keeper.ResourceVersions(sdk.UnwrapSDKContext(goCtx), req.DID, req.ID)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return &QueryResourceVersionsResponse{Versions: versions}, nil
+}
+
+func (s queryServer) Resources(goCtx context.Context, req *QueryResourcesRequest) (*QueryResourcesResponse, error) {
+	if req == nil || req.DID == "" {
+		return nil, status.Error(codes.InvalidArgument, "DID cannot be empty")
+	}
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	store := prefix.NewStore(ctx.KVStore(s.keeper.storeKey), resourceCollectionKey(req.DID))
+	var resources []Resource
+	pageRes, err := query.Paginate(store, req.Pagination, func(_, value []byte) error {
+		var res Resource
+		s.keeper.cdc.MustUnmarshal(value, &res)
+		resources = append(resources, res.Metadata())
+		return nil
+	})
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &QueryResourcesResponse{Resources: resources, Pagination: pageRes}, nil
+}
+
+func (s queryServer) PendingChanges(goCtx context.Context, req *QueryPendingChangesRequest) (*QueryPendingChangesResponse, error) {
+	if req == nil || req.DID == "" {
+		return nil, status.Error(codes.InvalidArgument, "DID cannot be empty")
+	}
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	store := prefix.NewStore(ctx.KVStore(s.keeper.storeKey), pendingChangesKey(req.DID))
+	var changes []PendingDIDChange
+	pageRes, err := query.Paginate(store, req.Pagination, func(_, value []byte) error {
+		var change PendingDIDChange
+		s.keeper.cdc.MustUnmarshal(value, &change)
+		changes = append(changes, change)
+		return nil
+	})
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &QueryPendingChangesResponse{Changes: changes, Pagination: pageRes}, nil
+}
+
+func (s queryServer) PendingChange(goCtx context.Context, req *QueryPendingChangeRequest) (*QueryPendingChangeResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	change, err := s.keeper.GetPendingDIDChange(sdk.UnwrapSDKContext(goCtx), req.ChangeID)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return &QueryPendingChangeResponse{Change: change}, nil
+}
+
+func (s queryServer) Recovery(goCtx context.Context, req *QueryRecoveryRequest) (*QueryRecoveryResponse, error) {
+	if req == nil || req.DID == "" {
+		return nil, status.Error(codes.InvalidArgument, "DID cannot be empty")
+	}
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	config, found := s.keeper.GetRecoveryConfig(ctx, req.DID)
+	if !found {
+		return nil, status.Errorf(codes.NotFound, "DID %s has no recovery guardians", req.DID)
+	}
+	res := &QueryRecoveryResponse{Config: config}
+	if rec, found := s.keeper.GetPendingRecovery(ctx, req.DID); found {
+		res.Pending = &rec
+	}
+	return res, nil
+}
+
+func (s queryServer) KeyRotation(goCtx context.Context, req *QueryKeyRotationRequest) (*QueryKeyRotationResponse, error) {
+	if req == nil || req.DID == "" {
+		return nil, status.Error(codes.InvalidArgument, "DID cannot be empty")
+	}
+	rot, found := s.keeper.GetPendingKeyRotation(sdk.UnwrapSDKContext(goCtx), req.DID)
+	if !found {
+		return nil, status.Errorf(codes.NotFound, "no key rotation of %s is pending", req.DID)
+	}
+	return &QueryKeyRotationResponse{Rotation: rot}, nil
+}
+
+func (s queryServer) Params(goCtx context.Context, _ *QueryParamsRequest) (*QueryParamsResponse, error) {
+	return &QueryParamsResponse{Params: s.keeper.GetParams(sdk.UnwrapSDKContext(goCtx))}, nil
+}
+
+func (s queryServer) VerifyDocument(goCtx context.Context, req *QueryVerifyDocumentRequest) (*QueryVerifyDocumentResponse, error) {
+	if req == nil || req.Document.ID == "" {
+		return nil, status.Error(codes.InvalidArgument, "DID cannot be empty")
+	}
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	commitment, err := s.keeper.GetDIDCommitment(ctx, req.Document.ID)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	valid, err := s.keeper.VerifyDocument(ctx, req.Document, req.Salt)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &QueryVerifyDocumentResponse{Valid: valid, Commitment: commitment}, nil
+}
+
+func (s queryServer) DIDByAddress(goCtx context.Context, req *QueryDIDByAddressRequest) (*QueryDIDByAddressResponse, error) {
+	if req == nil || req.Address == "" {
+		return nil, status.Error(codes.InvalidArgument, "address cannot be empty")
+	}
+	addr, err := sdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	link, found := s.keeper.GetAccountLink(sdk.UnwrapSDKContext(goCtx), addr)
+	if !found {
+		return nil, status.Errorf(codes.NotFound, "account %s is not linked to a DID", req.Address)
+	}
+	return &QueryDIDByAddressResponse{Link: link}, nil
+}
+
+func (s queryServer) Accounts(goCtx context.Context, req *QueryAccountsRequest) (*QueryAccountsResponse, error) {
+	if req == nil || req.DID == "" {
+		return nil, status.Error(codes.InvalidArgument, "DID cannot be empty")
+	}
+	return &QueryAccountsResponse{Links: s.keeper.GetAccountLinks(sdk.UnwrapSDKContext(goCtx), req.DID)}, nil
+}
+
+// Capabilities returns the active capability grants over a DID.
+func (s queryServer) Capabilities(goCtx context.Context, req *QueryCapabilitiesRequest) (*QueryCapabilitiesResponse, error) {
+	if req == nil || req.DID == "" {
+		return nil, status.Error(codes.InvalidArgument, "DID cannot be empty")
+	}
+	return &QueryCapabilitiesResponse{Grants: s.keeper.GetActiveCapabilityGrants(sdk.UnwrapSDKContext(goCtx), req.DID)}, nil
+}
+
+// LinkedAssets returns the verified assets linked to a DID.
+func (s queryServer) LinkedAssets(goCtx context.Context, req *QueryLinkedAssetsRequest) (*QueryLinkedAssetsResponse, error) {
+	if req == nil || req.DID == "" {
+		return nil, status.Error(codes.InvalidArgument, "DID cannot be empty")
+	}
+	return &QueryLinkedAssetsResponse{Assets: s.keeper.GetLinkedAssets(sdk.UnwrapSDKContext(goCtx), req.DID)}, nil
+}
+
+// Deposit returns the registration deposit locked for a DID and the part of
+// its creation fee held in escrow.
+func (s queryServer) Deposit(goCtx context.Context, req *QueryDepositRequest) (*QueryDepositResponse, error) {
+	if req == nil || req.DID == "" {
+		return nil, status.Error(codes.InvalidArgument, "DID cannot be empty")
+	}
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	deposit, found := s.keeper.GetDeposit(ctx, req.DID)
+	res := &QueryDepositResponse{Deposit: deposit}
+	if escrow, ok := s.keeper.GetFeeEscrow(ctx, req.DID); ok {
+		res.FeeEscrow = &escrow
+	}
+	if !found && res.FeeEscrow == nil {
+		return nil, status.Errorf(codes.NotFound, "DID %s has no locked deposit", req.DID)
+	}
+	return res, nil
+}
+
+// PresentationDefinitions returns the presentation definitions a verifier has
+// published.
+func (s queryServer) PresentationDefinitions(goCtx context.Context, req *QueryPresentationDefinitionsRequest) (*QueryPresentationDefinitionsResponse, error) {
+	if req == nil || req.Verifier == "" {
+		return nil, status.Error(codes.InvalidArgument, "verifier DID cannot be empty")
+	}
+	return &QueryPresentationDefinitionsResponse{Definitions: s.keeper.GetPresentationDefinitions(sdk.UnwrapSDKContext(goCtx), req.Verifier)}, nil
+}
+
+// PresentationDefinition returns a presentation definition of a verifier.
+func (s queryServer) PresentationDefinition(goCtx context.Context, req *QueryPresentationDefinitionRequest) (*QueryPresentationDefinitionResponse, error) {
+	if req == nil || req.Verifier == "" || req.ID == "" {
+		return nil, status.Error(codes.InvalidArgument, "verifier DID and definition ID cannot be empty")
+	}
+	record, found := s.keeper.GetPresentationDefinition(sdk.UnwrapSDKContext(goCtx), req.Verifier, req.ID)
+	if !found {
+		return nil, status.Errorf(codes.NotFound, "%s has no presentation definition %s", req.Verifier, req.ID)
+	}
+	return &QueryPresentationDefinitionResponse{Definition: record}, nil
+}
+
+func (s queryServer) ResourceByName(goCtx context.Context, req *QueryResourceByNameRequest) (*QueryResourceByNameResponse, error) {
+	if req == nil || req.DID == "" || req.Name == "" || req.ResourceType == "" {
+		return nil, status.Error(codes.InvalidArgument, "DID, resource name and resource type cannot be empty")
+	}
+	res, err := s.keeper.ResourceAtTime(sdk.UnwrapSDKContext(goCtx), req.DID, req.Name, req.ResourceType, req.Time)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return &QueryResourceByNameResponse{Resource: res}, nil
+}
+
+// TxHistory lists the transactions that affected a DID, oldest first unless
+// pagination.reverse is set.
+func (s queryServer) TxHistory(goCtx context.Context, req *QueryTxHistoryRequest) (*QueryTxHistoryResponse, error) {
+	if req == nil || req.DID == "" {
+		return nil, status.Error(codes.InvalidArgument, "DID cannot be empty")
+	}
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	store := prefix.NewStore(ctx.KVStore(s.keeper.storeKey), txHistoryPrefix(req.DID))
+	var records []TxRecord
+	pageRes, err := query.Paginate(store, req.Pagination, func(_, value []byte) error {
+		var record TxRecord
+		s.keeper.cdc.MustUnmarshal(value, &record)
+		records = append(records, record)
+		return nil
+	})
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &QueryTxHistoryResponse{Records: records, Pagination: pageRes}, nil
+}
+
+// Activity lists the transactions an account or controller performed
+// between two heights, inclusive, oldest first unless pagination.reverse is
+// set.
+func (s queryServer) Activity(goCtx context.Context, req *QueryActivityRequest) (*QueryActivityResponse, error) {
+	if req == nil || req.Actor == "" {
+		return nil, status.Error(codes.InvalidArgument, "actor cannot be empty")
+	}
+	if req.ToHeight != 0 && req.ToHeight < req.FromHeight {
+		return nil, status.Error(codes.InvalidArgument, "to_height is below from_height")
+	}
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	store := prefix.NewStore(ctx.KVStore(s.keeper.storeKey), activityPrefix(req.Actor))
+	var records []ActivityRecord
+	pageRes, err := query.FilteredPaginate(store, req.Pagination, func(key, value []byte, accumulate bool) (bool, error) {
+		// Keys start with the big-endian height, so the window is checked
+		// without decoding the record.
+		height := int64(sdk.BigEndianToUint64(key[:8]))
+		if height < req.FromHeight || (req.ToHeight != 0 && height > req.ToHeight) {
+			return false, nil
+		}
+		if accumulate {
+			var record ActivityRecord
+			s.keeper.cdc.MustUnmarshal(value, &record)
+			records = append(records, record)
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &QueryActivityResponse{Records: records, Pagination: pageRes}, nil
+}
+
+// Stats returns the registry counters.
+func (s queryServer) Stats(goCtx context.Context, _ *QueryStatsRequest) (*QueryStatsResponse, error) {
+	return &QueryStatsResponse{Stats: s.keeper.GetStats(sdk.UnwrapSDKContext(goCtx))}, nil
+}
+
+// HashAnchors returns the anchors of a hash.
+func (s queryServer) HashAnchors(goCtx context.Context, req *QueryHashAnchorsRequest) (*QueryHashAnchorsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	hash, err := parseAnchorHash(req.Hash)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	anchors := s.keeper.GetHashAnchors(sdk.UnwrapSDKContext(goCtx), hash)
+	if len(anchors) == 0 {
+		return nil, status.Errorf(codes.NotFound, "hash %s has not been anchored", req.Hash)
+	}
+	return &QueryHashAnchorsResponse{Anchors: anchors}, nil
+}
+
+// VerifyCredentialInclusion checks a credential hash and its Merkle path
+// against a credential root the issuer anchored.
+func (s queryServer) VerifyCredentialInclusion(goCtx context.Context, req *QueryVerifyCredentialInclusionRequest) (*QueryVerifyCredentialInclusionResponse, error) {
+	if req == nil || req.Issuer == "" {
+		return nil, status.Error(codes.InvalidArgument, "issuer DID cannot be empty")
+	}
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	root, found := s.keeper.GetCredentialRoot(ctx, req.Issuer, req.Root)
+	if !found {
+		return nil, status.Errorf(codes.NotFound, "%s has not anchored credential root %X", req.Issuer, req.Root)
+	}
+	valid, err := s.keeper.VerifyCredentialInclusion(ctx, req.Issuer, req.Root, req.CredentialHash, req.Proof)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &QueryVerifyCredentialInclusionResponse{Valid: valid, Root: root}, nil
+}
+
+// RegisterQueryServer registers srv as the aytch.did.v1.Query service.
+func RegisterQueryServer(s grpc1.Server, srv QueryServer) {
+	s.RegisterService(withInterceptors(&_Query_serviceDesc, traceInterceptor), srv)
+}
+
+// QueryClient is the client API for the aytch.did.v1.Query service.
+type QueryClient interface {
+	DID(ctx context.Context, in *QueryDIDRequest, opts ...grpc.CallOption) (*QueryDIDResponse, error)
+	DIDs(ctx context.Context, in *QueryDIDsRequest, opts ...grpc.CallOption) (*QueryDIDsResponse, error)
+	CredentialStatus(ctx context.Context, in *QueryCredentialStatusRequest, opts ...grpc.CallOption) (*QueryCredentialStatusResponse, error)
+	Resource(ctx context.Context, in *QueryResourceRequest, opts ...grpc.CallOption) (*QueryResourceResponse, error)
+	Resources(ctx context.Context, in *QueryResourcesRequest, opts ...grpc.CallOption) (*QueryResourcesResponse, error)
+	PendingChanges(ctx context.Context, in *QueryPendingChangesRequest, opts ...grpc.CallOption) (*QueryPendingChangesResponse, error)
+	PendingChange(ctx context.Context, in *QueryPendingChangeRequest, opts ...grpc.CallOption) (*QueryPendingChangeResponse, error)
+	Recovery(ctx context.Context, in *QueryRecoveryRequest, opts ...grpc.CallOption) (*QueryRecoveryResponse, error)
+	KeyRotation(ctx context.Context, in *QueryKeyRotationRequest, opts ...grpc.CallOption) (*QueryKeyRotationResponse, error)
+	Params(ctx context.Context, in *QueryParamsRequest, opts ...grpc.CallOption) (*QueryParamsResponse, error)
+	VerifyDocument(ctx context.Context, in *QueryVerifyDocumentRequest, opts ...grpc.CallOption) (*QueryVerifyDocumentResponse, error)
+	DIDByAddress(ctx context.Context, in *QueryDIDByAddressRequest, opts ...grpc.CallOption) (*QueryDIDByAddressResponse, error)
+	Accounts(ctx context.Context, in *QueryAccountsRequest, opts ...grpc.CallOption) (*QueryAccountsResponse, error)
+	Capabilities(ctx context.Context, in *QueryCapabilitiesRequest, opts ...grpc.CallOption) (*QueryCapabilitiesResponse, error)
+	LinkedAssets(ctx context.Context, in *QueryLinkedAssetsRequest, opts ...grpc.CallOption) (*QueryLinkedAssetsResponse, error)
+	Deposit(ctx context.Context, in *QueryDepositRequest, opts ...grpc.CallOption) (*QueryDepositResponse, error)
+	ResourceVersions(ctx context.Context, in *QueryResourceVersionsRequest, opts ...grpc.CallOption) (*QueryResourceVersionsResponse, error)
+	PresentationDefinitions(ctx context.Context, in *QueryPresentationDefinitionsRequest, opts ...grpc.CallOption) (*QueryPresentationDefinitionsResponse, error)
+	PresentationDefinition(ctx context.Context, in *QueryPresentationDefinitionRequest, opts ...grpc.CallOption) (*QueryPresentationDefinitionResponse, error)
+	ResourceByName(ctx context.Context, in *QueryResourceByNameRequest, opts ...grpc.CallOption) (*QueryResourceByNameResponse, error)
+	TxHistory(ctx context.Context, in *QueryTxHistoryRequest, opts ...grpc.CallOption) (*QueryTxHistoryResponse, error)
+	Activity(ctx context.Context, in *QueryActivityRequest, opts ...grpc.CallOption) (*QueryActivityResponse, error)
+	Stats(ctx context.Context, in *QueryStatsRequest, opts ...grpc.CallOption) (*QueryStatsResponse, error)
+	HashAnchors(ctx context.Context, in *QueryHashAnchorsRequest, opts ...grpc.CallOption) (*QueryHashAnchorsResponse, error)
+	VerifyCredentialInclusion(ctx context.Context, in *QueryVerifyCredentialInclusionRequest, opts ...grpc.CallOption) (*QueryVerifyCredentialInclusionResponse, error)
+}
+
+type queryClient struct {
+	cc grpc1.ClientConn
+}
+
+// NewQueryClient creates a Query service client over cc.
+func NewQueryClient(cc grpc1.ClientConn) QueryClient {
+	return &queryClient{cc: cc}
+}
+
+func (c *queryClient) DID(ctx context.Context, in *QueryDIDRequest, opts ...grpc.CallOption) (*QueryDIDResponse, error) {
+	out := new(QueryDIDResponse)
+	if err := c.cc.Invoke(ctx, "/aytch.did.v1.Query/DID", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) DIDs(ctx context.Context, in *QueryDIDsRequest, opts ...grpc.CallOption) (*QueryDIDsResponse, error) {
+	out := new(QueryDIDsResponse)
+	if err := c.cc.Invoke(ctx, "/aytch.did.v1.Query/DIDs", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) CredentialStatus(ctx context.Context, in *QueryCredentialStatusRequest, opts ...grpc.CallOption) (*QueryCredentialStatusResponse, error) {
+	out := new(QueryCredentialStatusResponse)
+	if err := c.cc.Invoke(ctx, "/aytch.did.v1.Query/CredentialStatus", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) Resource(ctx context.Context, in *QueryResourceRequest, opts ...grpc.CallOption) (*QueryResourceResponse, error) {
+	out := new(QueryResourceResponse)
+	if err := c.cc.Invoke(ctx, "/aytch.did.v1.Query/Resource", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) Resources(ctx context.Context, in *QueryResourcesRequest, opts ...grpc.CallOption) (*QueryResourcesResponse, error) {
+	out := new(QueryResourcesResponse)
+	if err := c.cc.Invoke(ctx, "/aytch.did.v1.Query/Resources", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) PendingChanges(ctx context.Context, in *QueryPendingChangesRequest, opts ...grpc.CallOption) (*QueryPendingChangesResponse, error) {
+	out := new(QueryPendingChangesResponse)
+	if err := c.cc.Invoke(ctx, "/aytch.did.v1.Query/PendingChanges", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) PendingChange(ctx context.Context, in *QueryPendingChangeRequest, opts ...grpc.CallOption) (*QueryPendingChangeResponse, error) {
+	out := new(QueryPendingChangeResponse)
+	if err := c.cc.Invoke(ctx, "/aytch.did.v1.Query/PendingChange", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) Recovery(ctx context.Context, in *QueryRecoveryRequest, opts ...grpc.CallOption) (*QueryRecoveryResponse, error) {
+	out := new(QueryRecoveryResponse)
+	if err := c.cc.Invoke(ctx, "/aytch.did.v1.Query/Recovery", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) KeyRotation(ctx context.Context, in *QueryKeyRotationRequest, opts ...grpc.CallOption) (*QueryKeyRotationResponse, error) {
+	out := new(QueryKeyRotationResponse)
+	if err := c.cc.Invoke(ctx, "/aytch.did.v1.Query/KeyRotation", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) Params(ctx context.Context, in *QueryParamsRequest, opts ...grpc.CallOption) (*QueryParamsResponse, error) {
+	out := new(QueryParamsResponse)
+	if err := c.cc.Invoke(ctx, "/aytch.did.v1.Query/Params", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) VerifyDocument(ctx context.Context, in *QueryVerifyDocumentRequest, opts ...grpc.CallOption) (*QueryVerifyDocumentResponse, error) {
+	out := new(QueryVerifyDocumentResponse)
+	if err := c.cc.Invoke(ctx, "/aytch.did.v1.Query/VerifyDocument", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) DIDByAddress(ctx context.Context, in *QueryDIDByAddressRequest, opts ...grpc.CallOption) (*QueryDIDByAddressResponse, error) {
+	out := new(QueryDIDByAddressResponse)
+	if err := c.cc.Invoke(ctx, "/aytch.did.v1.Query/DIDByAddress", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) Accounts(ctx context.Context, in *QueryAccountsRequest, opts ...grpc.CallOption) (*QueryAccountsResponse, error) {
+	out := new(QueryAccountsResponse)
+	if err := c.cc.Invoke(ctx, "/aytch.did.v1.Query/Accounts", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) Capabilities(ctx context.Context, in *QueryCapabilitiesRequest, opts ...grpc.CallOption) (*QueryCapabilitiesResponse, error) {
+	out := new(QueryCapabilitiesResponse)
+	if err := c.cc.Invoke(ctx, "/aytch.did.v1.Query/Capabilities", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) LinkedAssets(ctx context.Context, in *QueryLinkedAssetsRequest, opts ...grpc.CallOption) (*QueryLinkedAssetsResponse, error) {
+	out := new(QueryLinkedAssetsResponse)
+	if err := c.cc.Invoke(ctx, "/aytch.did.v1.Query/LinkedAssets", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) Deposit(ctx context.Context, in *QueryDepositRequest, opts ...grpc.CallOption) (*QueryDepositResponse, error) {
+	out := new(QueryDepositResponse)
+	if err := c.cc.Invoke(ctx, "/aytch.did.v1.Query/Deposit", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) ResourceVersions(ctx context.Context, in *QueryResourceVersionsRequest, opts ...grpc.CallOption) (*QueryResourceVersionsResponse, error) {
+	out := new(QueryResourceVersionsResponse)
+	if err := c.cc.Invoke(ctx, "/aytch.did.v1.Query/ResourceVersions", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) PresentationDefinitions(ctx context.Context, in *QueryPresentationDefinitionsRequest, opts ...grpc.CallOption) (*QueryPresentationDefinitionsResponse, error) {
+	out := new(QueryPresentationDefinitionsResponse)
+	if err := c.cc.Invoke(ctx, "/aytch.did.v1.Query/PresentationDefinitions", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) PresentationDefinition(ctx context.Context, in *QueryPresentationDefinitionRequest, opts ...grpc.CallOption) (*QueryPresentationDefinitionResponse, error) {
+	out := new(QueryPresentationDefinitionResponse)
+	if err := c.cc.Invoke(ctx, "/aytch.did.v1.Query/PresentationDefinition", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) ResourceByName(ctx context.Context, in *QueryResourceByNameRequest, opts ...grpc.CallOption) (*QueryResourceByNameResponse, error) {
+	out := new(QueryResourceByNameResponse)
+	if err := c.cc.Invoke(ctx, "/aytch.did.v1.Query/ResourceByName", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) TxHistory(ctx context.Context, in *QueryTxHistoryRequest, opts ...grpc.CallOption) (*QueryTxHistoryResponse, error) {
+	out := new(QueryTxHistoryResponse)
+	if err := c.cc.Invoke(ctx, "/aytch.did.v1.Query/TxHistory", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) Activity(ctx context.Context, in *QueryActivityRequest, opts ...grpc.CallOption) (*QueryActivityResponse, error) {
+	out := new(QueryActivityResponse)
+	if err := c.cc.Invoke(ctx, "/aytch.did.v1.Query/Activity", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) Stats(ctx context.Context, in *QueryStatsRequest, opts ...grpc.CallOption) (*QueryStatsResponse, error) {
+	out := new(QueryStatsResponse)
+	if err := c.cc.Invoke(ctx, "/aytch.did.v1.Query/Stats", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) HashAnchors(ctx context.Context, in *QueryHashAnchorsRequest, opts ...grpc.CallOption) (*QueryHashAnchorsResponse, error) {
+	out := new(QueryHashAnchorsResponse)
+	if err := c.cc.Invoke(ctx, "/aytch.did.v1.Query/HashAnchors", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) VerifyCredentialInclusion(ctx context.Context, in *QueryVerifyCredentialInclusionRequest, opts ...grpc.CallOption) (*QueryVerifyCredentialInclusionResponse, error) {
+	out := new(QueryVerifyCredentialInclusionResponse)
+	if err := c.cc.Invoke(ctx, "/aytch.did.v1.Query/VerifyCredentialInclusion", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func _Query_DID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryDIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).DID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Query/DID"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).DID(ctx, req.(*QueryDIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_DIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryDIDsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).DIDs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Query/DIDs"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).DIDs(ctx, req.(*QueryDIDsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_CredentialStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryCredentialStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).CredentialStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Query/CredentialStatus"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).CredentialStatus(ctx, req.(*QueryCredentialStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_Resource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryResourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).Resource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Query/Resource"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).Resource(ctx, req.(*QueryResourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_Resources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryResourcesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).Resources(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Query/Resources"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).Resources(ctx, req.(*QueryResourcesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_PendingChanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryPendingChangesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).PendingChanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Query/PendingChanges"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).PendingChanges(ctx, req.(*QueryPendingChangesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_PendingChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryPendingChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).PendingChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Query/PendingChange"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).PendingChange(ctx, req.(*QueryPendingChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_Recovery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryRecoveryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).Recovery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Query/Recovery"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).Recovery(ctx, req.(*QueryRecoveryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_KeyRotation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryKeyRotationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).KeyRotation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Query/KeyRotation"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).KeyRotation(ctx, req.(*QueryKeyRotationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_Params_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryParamsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).Params(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Query/Params"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).Params(ctx, req.(*QueryParamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_VerifyDocument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryVerifyDocumentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).VerifyDocument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Query/VerifyDocument"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).VerifyDocument(ctx, req.(*QueryVerifyDocumentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_DIDByAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryDIDByAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).DIDByAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Query/DIDByAddress"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).DIDByAddress(ctx, req.(*QueryDIDByAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_Accounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryAccountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).Accounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Query/Accounts"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).Accounts(ctx, req.(*QueryAccountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_Capabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryCapabilitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).Capabilities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Query/Capabilities"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).Capabilities(ctx, req.(*QueryCapabilitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_LinkedAssets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryLinkedAssetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).LinkedAssets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Query/LinkedAssets"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).LinkedAssets(ctx, req.(*QueryLinkedAssetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_Deposit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryDepositRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).Deposit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Query/Deposit"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).Deposit(ctx, req.(*QueryDepositRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_ResourceVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryResourceVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).ResourceVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Query/ResourceVersions"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).ResourceVersions(ctx, req.(*QueryResourceVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_PresentationDefinitions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryPresentationDefinitionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).PresentationDefinitions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Query/PresentationDefinitions"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).PresentationDefinitions(ctx, req.(*QueryPresentationDefinitionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_PresentationDefinition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryPresentationDefinitionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).PresentationDefinition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Query/PresentationDefinition"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).PresentationDefinition(ctx, req.(*QueryPresentationDefinitionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_ResourceByName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryResourceByNameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).ResourceByName(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Query/ResourceByName"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).ResourceByName(ctx, req.(*QueryResourceByNameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_TxHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryTxHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).TxHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Query/TxHistory"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).TxHistory(ctx, req.(*QueryTxHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_Activity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryActivityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).Activity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Query/Activity"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).Activity(ctx, req.(*QueryActivityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_Stats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).Stats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Query/Stats"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).Stats(ctx, req.(*QueryStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_HashAnchors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryHashAnchorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).HashAnchors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Query/HashAnchors"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).HashAnchors(ctx, req.(*QueryHashAnchorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_VerifyCredentialInclusion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryVerifyCredentialInclusionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).VerifyCredentialInclusion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/aytch.did.v1.Query/VerifyCredentialInclusion"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).VerifyCredentialInclusion(ctx, req.(*QueryVerifyCredentialInclusionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Query_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "aytch.did.v1.Query",
+	HandlerType: (*QueryServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "DID", Handler: _Query_DID_Handler},
+		{MethodName: "DIDs", Handler: _Query_DIDs_Handler},
+		{MethodName: "CredentialStatus", Handler: _Query_CredentialStatus_Handler},
+		{MethodName: "Resource", Handler: _Query_Resource_Handler},
+		{MethodName: "Resources", Handler: _Query_Resources_Handler},
+		{MethodName: "PendingChanges", Handler: _Query_PendingChanges_Handler},
+		{MethodName: "PendingChange", Handler: _Query_PendingChange_Handler},
+		{MethodName: "Recovery", Handler: _Query_Recovery_Handler},
+		{MethodName: "KeyRotation", Handler: _Query_KeyRotation_Handler},
+		{MethodName: "Params", Handler: _Query_Params_Handler},
+		{MethodName: "VerifyDocument", Handler: _Query_VerifyDocument_Handler},
+		{MethodName: "DIDByAddress", Handler: _Query_DIDByAddress_Handler},
+		{MethodName: "Accounts", Handler: _Query_Accounts_Handler},
+		{MethodName: "Capabilities", Handler: _Query_Capabilities_Handler},
+		{MethodName: "LinkedAssets", Handler: _Query_LinkedAssets_Handler},
+		{MethodName: "Deposit", Handler: _Query_Deposit_Handler},
+		{MethodName: "ResourceVersions", Handler: _Query_ResourceVersions_Handler},
+		{MethodName: "PresentationDefinitions", Handler: _Query_PresentationDefinitions_Handler},
+		{MethodName: "PresentationDefinition", Handler: _Query_PresentationDefinition_Handler},
+		{MethodName: "ResourceByName", Handler: _Query_ResourceByName_Handler},
+		{MethodName: "TxHistory", Handler: _Query_TxHistory_Handler},
+		{MethodName: "Activity", Handler: _Query_Activity_Handler},
+		{MethodName: "Stats", Handler: _Query_Stats_Handler},
+		{MethodName: "HashAnchors", Handler: _Query_HashAnchors_Handler},
+		{MethodName: "VerifyCredentialInclusion", Handler: _Query_VerifyCredentialInclusion_Handler},
+	},
+	Streams: []grpc.StreamDesc{},
+}
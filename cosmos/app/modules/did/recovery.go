@@ -0,0 +1,213 @@
+package did
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// RecoveryConfig lists the guardian DIDs that can jointly replace a DID's
+// authentication key if its owner loses it.
+type RecoveryConfig struct {
+	DID       string   `protobuf:"bytes,1,opt,name=did,proto3" json:"did"`
+	Guardians []string `protobuf:"bytes,2,rep,name=guardians,proto3" json:"guardians"`
+	Threshold uint32   `protobuf:"varint,3,opt,name=threshold,proto3" json:"threshold"`
+}
+
+// PendingRecovery is a guardian-initiated key reset. Guardians must reach the
+// threshold before ExpiresAt; the new key then takes effect at ExecutableAt
+// unless a controller cancels the recovery first.
+type PendingRecovery struct {
+	DID          string   `protobuf:"bytes,1,opt,name=did,proto3" json:"did"`
+	NewPublicKey string   `protobuf:"bytes,2,opt,name=new_public_key,proto3" json:"new_public_key"`
+	Approvals    []string `protobuf:"bytes,3,rep,name=approvals,proto3" json:"approvals"`
+	ExpiresAt    int64    `protobuf:"varint,4,opt,name=expires_at,proto3" json:"expires_at"`
+	ExecutableAt int64    `protobuf:"varint,5,opt,name=executable_at,proto3" json:"executable_at,omitempty"`
+}
+
+// RecoveryStatus is the result of a recovery query.
+type RecoveryStatus struct {
+	Config  RecoveryConfig   `json:"config"`
+	Pending *PendingRecovery `json:"pending,omitempty"`
+}
+
+// MsgSetGuardians sets or, with no guardians, clears a DID's recovery
+// guardians. It is authorized by a controller like MsgUpdateDID.
+type MsgSetGuardians struct {
+	DID       string         `protobuf:"bytes,1,opt,name=did,proto3" json:"did"`
+	Guardians []string       `protobuf:"bytes,2,rep,name=guardians,proto3" json:"guardians"`
+	Threshold uint32         `protobuf:"varint,3,opt,name=threshold,proto3" json:"threshold"`
+	Nonce     uint64         `protobuf:"varint,7,opt,name=nonce,proto3" json:"nonce"`
+	Signer    string         `protobuf:"bytes,4,opt,name=signer,proto3" json:"signer"`
+	Signature []byte         `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
+	Creator   sdk.AccAddress `protobuf:"bytes,6,opt,name=creator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"creator"`
+}
+
+// ValidateBasic performs basic validation of MsgSetGuardians.
+func (msg MsgSetGuardians) ValidateBasic() error {
+	if err := validateChecksums(msg); err != nil {
+		return err
+	}
+	if msg.DID == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("DID cannot be empty")
+	}
+	if msg.Signer == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Signer cannot be empty")
+	}
+	if len(msg.Guardians) == 0 {
+		if msg.Threshold != 0 {
+			return sdkerrors.ErrUnknownRequest.Wrap("Threshold must be zero when clearing guardians")
+		}
+		return nil
+	}
+	if msg.Threshold == 0 || int(msg.Threshold) > len(msg.Guardians) {
+		return sdkerrors.ErrUnknownRequest.Wrapf("Threshold must be between 1 and %d", len(msg.Guardians))
+	}
+	seen := make(map[string]bool)
+	for _, g := range msg.Guardians {
+		if g == "" || g == msg.DID {
+			return sdkerrors.ErrUnknownRequest.Wrap("Guardians must be other, non-empty DIDs")
+		}
+		if seen[g] {
+			return sdkerrors.ErrUnknownRequest.Wrapf("Duplicate guardian %s", g)
+		}
+		seen[g] = true
+	}
+	return nil
+}
+
+// ProofSignBytes returns the bytes a controller DID signs to authorize the
+// change of guardians.
+func (msg MsgSetGuardians) ProofSignBytes() []byte {
+	msg.Signature = nil
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// Route returns the message route.
+func (msg MsgSetGuardians) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgSetGuardians) Type() string { return "set_guardians" }
+
+// GetSignBytes returns the canonical bytes to sign over.
+func (msg MsgSetGuardians) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners returns the account that must sign the message.
+func (msg MsgSetGuardians) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
+
+// MsgRecoverDID is a guardian's approval to replace a DID's authentication
+// key with NewPublicKey. The guardian signs ProofSignBytes with its own DID
+// key.
+type MsgRecoverDID struct {
+	DID          string         `protobuf:"bytes,1,opt,name=did,proto3" json:"did"`
+	NewPublicKey string         `protobuf:"bytes,2,opt,name=new_public_key,proto3" json:"new_public_key"`
+	Nonce        uint64         `protobuf:"varint,6,opt,name=nonce,proto3" json:"nonce"`
+	Guardian     string         `protobuf:"bytes,3,opt,name=guardian,proto3" json:"guardian"`
+	Signature    []byte         `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	Creator      sdk.AccAddress `protobuf:"bytes,5,opt,name=creator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"creator"`
+}
+
+// ValidateBasic performs basic validation of MsgRecoverDID.
+func (msg MsgRecoverDID) ValidateBasic() error {
+	if err := validateChecksums(msg); err != nil {
+		return err
+	}
+	if msg.DID == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("DID cannot be empty")
+	}
+	if msg.Guardian == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Guardian cannot be empty")
+	}
+	bz, err := base64.StdEncoding.DecodeString(msg.NewPublicKey)
+	if err != nil || len(bz) != ed25519.PublicKeySize {
+		return sdkerrors.ErrUnknownRequest.Wrap("New public key must be a base64 Ed25519 public key")
+	}
+	return nil
+}
+
+// ProofSignBytes returns the bytes the guardian DID signs.
+func (msg MsgRecoverDID) ProofSignBytes() []byte {
+	msg.Signature = nil
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// Route returns the message route.
+func (msg MsgRecoverDID) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgRecoverDID) Type() string { return "recover_did" }
+
+// GetSignBytes returns the canonical bytes to sign over.
+func (msg MsgRecoverDID) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners returns the account that must sign the message.
+func (msg MsgRecoverDID) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
+
+// MsgCancelRecovery lets any controller of a DID veto a pending recovery.
+type MsgCancelRecovery struct {
+	DID       string         `protobuf:"bytes,1,opt,name=did,proto3" json:"did"`
+	Nonce     uint64         `protobuf:"varint,5,opt,name=nonce,proto3" json:"nonce"`
+	Signer    string         `protobuf:"bytes,2,opt,name=signer,proto3" json:"signer"`
+	Signature []byte         `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	Creator   sdk.AccAddress `protobuf:"bytes,4,opt,name=creator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"creator"`
+}
+
+// ValidateBasic performs basic validation of MsgCancelRecovery.
+func (msg MsgCancelRecovery) ValidateBasic() error {
+	if err := validateChecksums(msg); err != nil {
+		return err
+	}
+	if msg.DID == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("DID cannot be empty")
+	}
+	if msg.Signer == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Signer cannot be empty")
+	}
+	return nil
+}
+
+// ProofSignBytes returns the bytes a controller DID signs to cancel the
+// recovery.
+func (msg MsgCancelRecovery) ProofSignBytes() []byte {
+	msg.Signature = nil
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// Route returns the message route.
+func (msg MsgCancelRecovery) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgCancelRecovery) Type() string { return "cancel_recovery" }
+
+// GetSignBytes returns the canonical bytes to sign over.
+func (msg MsgCancelRecovery) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners returns the account that must sign the message.
+func (msg MsgCancelRecovery) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
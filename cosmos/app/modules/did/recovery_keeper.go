@@ -0,0 +1,209 @@
+package did
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// SetGuardians stores a DID's recovery guardians, or removes them if none are
+// given. Every guardian must be a registered DID.
+func (k Keeper) SetGuardians(ctx sdk.Context, config RecoveryConfig) error {
+	store := ctx.KVStore(k.storeKey)
+	if len(config.Guardians) == 0 {
+		store.Delete(recoveryConfigKey(config.DID))
+		return nil
+	}
+	for _, g := range config.Guardians {
+		if _, err := k.GetDID(ctx, g); err != nil {
+			return fmt.Errorf("guardian %s: %w", g, err)
+		}
+	}
+	k.setRecoveryConfig(ctx, config)
+	return nil
+}
+
+// GetRecoveryConfig returns a DID's recovery guardians.
+func (k Keeper) GetRecoveryConfig(ctx sdk.Context, did string) (RecoveryConfig, bool) {
+	value := ctx.KVStore(k.storeKey).Get(recoveryConfigKey(did))
+	if value == nil {
+		return RecoveryConfig{}, false
+	}
+	var config RecoveryConfig
+	k.cdc.MustUnmarshal(value, &config)
+	return config, true
+}
+
+// GetAllRecoveryConfigs returns the recovery guardians of every DID that has
+// them.
+func (k Keeper) GetAllRecoveryConfigs(ctx sdk.Context) []RecoveryConfig {
+	var configs []RecoveryConfig
+	iteratePrefix(ctx.KVStore(k.storeKey), RecoveryConfigPrefix, func(_, value []byte) bool {
+		var config RecoveryConfig
+		k.cdc.MustUnmarshal(value, &config)
+		configs = append(configs, config)
+		return false
+	})
+	return configs
+}
+
+func (k Keeper) setRecoveryConfig(ctx sdk.Context, config RecoveryConfig) {
+	ctx.KVStore(k.storeKey).Set(recoveryConfigKey(config.DID), k.cdc.MustMarshal(&config))
+}
+
+// GetPendingRecovery returns the recovery in progress for a DID.
+func (k Keeper) GetPendingRecovery(ctx sdk.Context, did string) (PendingRecovery, bool) {
+	value := ctx.KVStore(k.storeKey).Get(pendingRecoveryKey(did))
+	if value == nil {
+		return PendingRecovery{}, false
+	}
+	var rec PendingRecovery
+	k.cdc.MustUnmarshal(value, &rec)
+	return rec, true
+}
+
+// GetAllPendingRecoveries returns every recovery in progress, including
+// expired ones the end blocker has yet to drop.
+func (k Keeper) GetAllPendingRecoveries(ctx sdk.Context) []PendingRecovery {
+	var recoveries []PendingRecovery
+	iteratePrefix(ctx.KVStore(k.storeKey), PendingRecoveryPrefix, func(_, value []byte) bool {
+		var rec PendingRecovery
+		k.cdc.MustUnmarshal(value, &rec)
+		recoveries = append(recoveries, rec)
+		return false
+	})
+	return recoveries
+}
+
+// RecoverDID records a guardian's approval of a key reset. The first approval
+// opens the recovery window; once the threshold is reached the reset is
+// scheduled after RecoveryDelayBlocks, giving the owner time to cancel.
+// Approvals name the DID's nonce, which only advances once the recovery is
+// applied, so they can't be replayed against a later recovery.
+func (k Keeper) RecoverDID(ctx sdk.Context, id string, nonce uint64, newPublicKey, guardian string, signBytes, signature []byte) error {
+	config, ok := k.GetRecoveryConfig(ctx, id)
+	if !ok {
+		return fmt.Errorf("DID %s has no recovery guardians", id)
+	}
+	if !containsString(config.Guardians, guardian) {
+		return fmt.Errorf("%s is not a guardian of %s", guardian, id)
+	}
+	if err := k.verifyDIDSignature(ctx, guardian, signBytes, signature); err != nil {
+		return err
+	}
+	did, err := k.GetDID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if did.Deactivated {
+		return fmt.Errorf("DID %s is deactivated", id)
+	}
+	if err := checkNonce(did, nonce); err != nil {
+		return err
+	}
+
+	params := k.GetParams(ctx)
+	height := ctx.BlockHeight()
+	rec, found := k.GetPendingRecovery(ctx, id)
+	if found && rec.ExecutableAt == 0 && height > rec.ExpiresAt {
+		k.deletePendingRecovery(ctx, rec)
+		found = false
+	}
+	if !found {
+		rec = PendingRecovery{
+			DID:          id,
+			NewPublicKey: newPublicKey,
+			ExpiresAt:    height + int64(params.RecoveryWindowBlocks),
+		}
+	}
+	if rec.NewPublicKey != newPublicKey {
+		return fmt.Errorf("a recovery of %s to a different key is in progress", id)
+	}
+	if rec.ExecutableAt != 0 {
+		return fmt.Errorf("recovery of %s is already approved and takes effect at height %d", id, rec.ExecutableAt)
+	}
+	if containsString(rec.Approvals, guardian) {
+		return fmt.Errorf("%s already approved the recovery of %s", guardian, id)
+	}
+
+	k.deletePendingRecovery(ctx, rec)
+	rec.Approvals = append(rec.Approvals, guardian)
+	if len(rec.Approvals) >= int(config.Threshold) {
+		rec.ExecutableAt = height + int64(params.RecoveryDelayBlocks)
+	}
+	k.setPendingRecovery(ctx, rec)
+	return nil
+}
+
+// CancelRecovery lets a controller veto the recovery in progress for a DID.
+func (k Keeper) CancelRecovery(ctx sdk.Context, id string, nonce uint64, signer string, creator sdk.AccAddress, signBytes, signature []byte) error {
+	did, err := k.GetDID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := checkNonce(did, nonce); err != nil {
+		return err
+	}
+	if err := k.verifyControllerSignature(ctx, did, signer, creator, signBytes, signature); err != nil {
+		return err
+	}
+	rec, found := k.GetPendingRecovery(ctx, id)
+	if !found {
+		return fmt.Errorf("no recovery of %s is in progress", id)
+	}
+	k.deletePendingRecovery(ctx, rec)
+	return k.IncrementNonce(ctx, id)
+}
+
+// ProcessRecoveries applies recoveries whose delay has passed and drops those
+// that expired without reaching the guardian threshold.
+func (k Keeper) ProcessRecoveries(ctx sdk.Context) {
+	height := ctx.BlockHeight()
+	for _, e := range dueEntries(ctx.KVStore(k.storeKey), RecoveryQueuePrefix, height) {
+		id := string(e.suffix)
+		rec, found := k.GetPendingRecovery(ctx, id)
+		if !found {
+			continue
+		}
+		switch {
+		case rec.ExecutableAt != 0 && height >= rec.ExecutableAt:
+			k.deletePendingRecovery(ctx, rec)
+			did, err := k.GetDID(ctx, id)
+			if err != nil {
+				continue
+			}
+			did.PublicKey = rec.NewPublicKey
+			if err := k.UpdateDID(ctx, did); err != nil {
+				ctx.Logger().Error("failed to apply DID recovery", "did", id, "err", err)
+				continue
+			}
+			// A rotation queued with the lost key must not undo the recovery.
+			if rot, found := k.GetPendingKeyRotation(ctx, id); found {
+				k.deletePendingKeyRotation(ctx, rot)
+			}
+		case rec.ExecutableAt == 0 && height > rec.ExpiresAt:
+			k.deletePendingRecovery(ctx, rec)
+		}
+	}
+}
+
+// setPendingRecovery stores the recovery and queues it at the height it next
+// needs attention: its execution height once approved, otherwise its expiry.
+func (k Keeper) setPendingRecovery(ctx sdk.Context, rec PendingRecovery) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(pendingRecoveryKey(rec.DID), k.cdc.MustMarshal(&rec))
+	store.Set(recoveryQueueKey(recoveryDueHeight(rec), rec.DID), []byte{})
+}
+
+func (k Keeper) deletePendingRecovery(ctx sdk.Context, rec PendingRecovery) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(pendingRecoveryKey(rec.DID))
+	store.Delete(recoveryQueueKey(recoveryDueHeight(rec), rec.DID))
+}
+
+func recoveryDueHeight(rec PendingRecovery) int64 {
+	if rec.ExecutableAt != 0 {
+		return rec.ExecutableAt
+	}
+	return rec.ExpiresAt + 1
+}
@@ -0,0 +1,112 @@
+package did
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// recoveryKeeper returns a keeper with a DID recoverable by two of three
+// guardians, a recovery window of 10 blocks and a delay of 5.
+func recoveryKeeper(t *testing.T) (Keeper, sdk.Context, DIDDocument, []testSigner) {
+	t.Helper()
+	k, ctx, _, _ := testKeeper(t)
+	params := k.GetParams(ctx)
+	params.RecoveryWindowBlocks = 10
+	params.RecoveryDelayBlocks = 5
+	k.SetParams(ctx, params)
+
+	guardians := []testSigner{newTestSigner("g1"), newTestSigner("g2"), newTestSigner("g3")}
+	did := newTestSigner("alice").document()
+	mustCreate(t, k, ctx, did)
+	config := RecoveryConfig{DID: did.ID, Threshold: 2}
+	for _, g := range guardians {
+		mustCreate(t, k, ctx, g.document())
+		config.Guardians = append(config.Guardians, g.id)
+	}
+	if err := k.SetGuardians(ctx, config); err != nil {
+		t.Fatal(err)
+	}
+	return k, ctx, did, guardians
+}
+
+func TestProcessRecoveries(t *testing.T) {
+	k, ctx, did, guardians := recoveryKeeper(t)
+	newKey := newTestSigner("alice-2").document().PublicKey
+	signBytes := []byte("recover alice")
+	// A rotation queued with the lost key must not undo the recovery.
+	k.setPendingKeyRotation(ctx, PendingKeyRotation{DID: did.ID, NewPublicKey: did.PublicKey, EffectiveAt: 100})
+
+	if err := k.RecoverDID(ctx, did.ID, 0, newKey, guardians[0].id, signBytes, guardians[0].sign(signBytes)); err != nil {
+		t.Fatal(err)
+	}
+	if err := k.RecoverDID(ctx, did.ID, 0, newKey, guardians[0].id, signBytes, guardians[0].sign(signBytes)); err == nil {
+		t.Error("guardian approved twice")
+	}
+	if err := k.RecoverDID(ctx, did.ID, 0, newKey, guardians[1].id, signBytes, guardians[0].sign(signBytes)); err == nil {
+		t.Error("approval signed by another guardian accepted")
+	}
+	if err := k.RecoverDID(ctx, did.ID, 0, newKey, guardians[1].id, signBytes, guardians[1].sign(signBytes)); err != nil {
+		t.Fatal(err)
+	}
+	rec, found := k.GetPendingRecovery(ctx, did.ID)
+	if !found || rec.ExecutableAt != 6 {
+		t.Fatalf("pending recovery = %+v, %t; want executable at 6", rec, found)
+	}
+
+	k.ProcessRecoveries(ctx.WithBlockHeight(5))
+	if got, _ := k.GetDID(ctx, did.ID); got.PublicKey != did.PublicKey {
+		t.Fatal("recovery applied before its delay passed")
+	}
+
+	k.ProcessRecoveries(ctx.WithBlockHeight(6))
+	if got, _ := k.GetDID(ctx, did.ID); got.PublicKey != newKey {
+		t.Errorf("recovered key = %s, want %s", got.PublicKey, newKey)
+	}
+	if _, found := k.GetPendingRecovery(ctx, did.ID); found {
+		t.Error("applied recovery still pending")
+	}
+	if _, found := k.GetPendingKeyRotation(ctx, did.ID); found {
+		t.Error("rotation queued with the lost key survived the recovery")
+	}
+}
+
+func TestProcessRecoveriesDropsExpired(t *testing.T) {
+	k, ctx, did, guardians := recoveryKeeper(t)
+	signBytes := []byte("recover alice")
+	if err := k.RecoverDID(ctx, did.ID, 0, "new-key", guardians[0].id, signBytes, guardians[0].sign(signBytes)); err != nil {
+		t.Fatal(err)
+	}
+
+	k.ProcessRecoveries(ctx.WithBlockHeight(11))
+	if _, found := k.GetPendingRecovery(ctx, did.ID); !found {
+		t.Fatal("recovery dropped inside its window")
+	}
+	k.ProcessRecoveries(ctx.WithBlockHeight(12))
+	if _, found := k.GetPendingRecovery(ctx, did.ID); found {
+		t.Error("expired recovery still pending")
+	}
+}
+
+func TestCancelRecovery(t *testing.T) {
+	k, ctx, did, guardians := recoveryKeeper(t)
+	owner := testAddr("owner")
+	did.Controller = []string{owner.String()}
+	if err := k.UpdateDID(ctx, did); err != nil {
+		t.Fatal(err)
+	}
+	signBytes := []byte("recover alice")
+	for _, g := range guardians[:2] {
+		if err := k.RecoverDID(ctx, did.ID, 1, "new-key", g.id, signBytes, g.sign(signBytes)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := k.CancelRecovery(ctx, did.ID, 1, owner.String(), owner, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	k.ProcessRecoveries(ctx.WithBlockHeight(6))
+	if got, _ := k.GetDID(ctx, did.ID); got.PublicKey != did.PublicKey {
+		t.Error("cancelled recovery applied")
+	}
+}
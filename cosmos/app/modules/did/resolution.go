@@ -0,0 +1,182 @@
+package did
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Representation content types of DID resolution results.
+const (
+	ContentTypeDIDJSON   = "application/did+json"
+	ContentTypeDIDJSONLD = "application/did+ld+json"
+	ContentTypeDIDCBOR   = "application/did+cbor"
+)
+
+// DIDContextV1 is the JSON-LD context of DID documents.
+const DIDContextV1 = "https://www.w3.org/ns/did/v1"
+
+// W3CDocument is the W3C DID Core representation of a DID document, as
+// opposed to DIDDocument's on-chain storage layout.
+type W3CDocument struct {
+	Context            []string                `json:"@context,omitempty"`
+	ID                 string                  `json:"id"`
+	AlsoKnownAs        []string                `json:"alsoKnownAs,omitempty"`
+	Controller         []string                `json:"controller,omitempty"`
+	VerificationMethod []W3CVerificationMethod `json:"verificationMethod,omitempty"`
+	Authentication     []string                `json:"authentication,omitempty"`
+	KeyAgreement       []string                `json:"keyAgreement,omitempty"`
+	Service            []W3CService            `json:"service,omitempty"`
+}
+
+// W3CVerificationMethod is a verification method in W3C DID Core form.
+type W3CVerificationMethod struct {
+	ID                   string   `json:"id"`
+	Type                 string   `json:"type"`
+	Controller           string   `json:"controller"`
+	PublicKeyMultibase   string   `json:"publicKeyMultibase,omitempty"`
+	X509CertificateChain []string `json:"x509CertificateChain,omitempty"`
+	Threshold            uint32   `json:"threshold,omitempty"`
+	Participants         uint32   `json:"participants,omitempty"`
+}
+
+// W3CService is a service in W3C DID Core form.
+type W3CService struct {
+	ID              string   `json:"id"`
+	Type            string   `json:"type"`
+	ServiceEndpoint string   `json:"serviceEndpoint"`
+	RoutingKeys     []string `json:"routingKeys,omitempty"`
+	Accept          []string `json:"accept,omitempty"`
+}
+
+// ResolutionResult is a DID resolution result as defined by DID Resolution.
+type ResolutionResult struct {
+	Context               string             `json:"@context,omitempty"`
+	DIDDocument           *W3CDocument       `json:"didDocument"`
+	DIDResolutionMetadata ResolutionMetadata `json:"didResolutionMetadata"`
+	DIDDocumentMetadata   DocumentMetadata   `json:"didDocumentMetadata"`
+}
+
+// DereferencingResult is the result of dereferencing a DID URL with a
+// fragment, such as a verification method or service id.
+type DereferencingResult struct {
+	Context               string             `json:"@context,omitempty"`
+	ContentStream         interface{}        `json:"contentStream"`
+	DereferencingMetadata ResolutionMetadata `json:"dereferencingMetadata"`
+	ContentMetadata       DocumentMetadata   `json:"contentMetadata"`
+}
+
+// ResolutionMetadata describes the resolution process.
+type ResolutionMetadata struct {
+	ContentType string `json:"contentType,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// DocumentMetadata describes the resolved document. VersionID is the
+// document's nonce, which counts its writes. Digest is the hex integrity
+// digest of the document, see DIDDocument.Digest. ContentCID anchors the
+// part of the document kept in IPFS, if any. LinkedAssets lists the verified
+// on-chain assets linked to the DID, when the resolver looked them up.
+// DeactivatedByProposal is the ID of the governance proposal that
+// deactivated the DID, if any. NextVersionID is set when a historical
+// version was resolved and names the version that replaced it.
+type DocumentMetadata struct {
+	Deactivated           bool          `json:"deactivated,omitempty"`
+	VersionID             string        `json:"versionId"`
+	Expires               string        `json:"expires,omitempty"`
+	Digest                string        `json:"digest,omitempty"`
+	ContentCID            string        `json:"contentCid,omitempty"`
+	LinkedAssets          []LinkedAsset `json:"linkedAssets,omitempty"`
+	DeactivatedByProposal uint64        `json:"deactivatedByProposal,omitempty"`
+	NextVersionID         string        `json:"nextVersionId,omitempty"`
+}
+
+// resolutionContext is the JSON-LD context of resolution results.
+const resolutionContext = "https://w3id.org/did-resolution/v1"
+
+// W3C returns the document in W3C DID Core form, with the JSON-LD context
+// when jsonld is set. Legacy service endpoint URLs are listed as services
+// with generated ids.
+func (d DIDDocument) W3C(jsonld bool) W3CDocument {
+	doc := W3CDocument{ID: d.ID, AlsoKnownAs: d.AlsoKnownAs, Controller: d.Controller, KeyAgreement: d.KeyAgreement}
+	if jsonld {
+		doc.Context = append([]string{}, defaultContexts...)
+		for _, uri := range d.Context {
+			if !containsString(doc.Context, uri) {
+				doc.Context = append(doc.Context, uri)
+			}
+		}
+	}
+	for _, vm := range d.VerificationMethods {
+		doc.VerificationMethod = append(doc.VerificationMethod, W3CVerificationMethod(vm))
+	}
+	if d.Authentication != "" {
+		doc.Authentication = []string{d.Authentication}
+	}
+	for i, endpoint := range d.ServiceEndpoints {
+		doc.Service = append(doc.Service, W3CService{ID: d.ID + "#endpoint-" + strconv.Itoa(i+1), Type: "ServiceEndpoint", ServiceEndpoint: endpoint})
+	}
+	for _, svc := range d.Services {
+		doc.Service = append(doc.Service, W3CService(svc))
+	}
+	return doc
+}
+
+// Metadata returns the document metadata of d.
+func (d DIDDocument) Metadata() DocumentMetadata {
+	md := DocumentMetadata{
+		Deactivated:           d.Deactivated,
+		VersionID:             strconv.FormatUint(d.Nonce, 10),
+		Digest:                d.DigestHex(),
+		ContentCID:            d.ContentCID,
+		DeactivatedByProposal: d.DeactivatedByProposal,
+	}
+	if d.ExpiresAt != 0 {
+		md.Expires = time.Unix(d.ExpiresAt, 0).UTC().Format(time.RFC3339)
+	}
+	return md
+}
+
+// NewResolutionResult builds the resolution result of d in the representation
+// named by contentType.
+func NewResolutionResult(d DIDDocument, contentType string) ResolutionResult {
+	jsonld := contentType == ContentTypeDIDJSONLD
+	doc := d.W3C(jsonld)
+	res := ResolutionResult{
+		DIDDocument:           &doc,
+		DIDResolutionMetadata: ResolutionMetadata{ContentType: contentType},
+		DIDDocumentMetadata:   d.Metadata(),
+	}
+	if jsonld {
+		res.Context = resolutionContext
+	}
+	return res
+}
+
+// Dereference returns the verification method or service of d identified by
+// fragment (with or without the leading "#").
+func (d DIDDocument) Dereference(fragment string, contentType string) (DereferencingResult, error) {
+	id := d.ID + "#" + strings.TrimPrefix(fragment, "#")
+	doc := d.W3C(false)
+	res := DereferencingResult{
+		DereferencingMetadata: ResolutionMetadata{ContentType: contentType},
+		ContentMetadata:       d.Metadata(),
+	}
+	if contentType == ContentTypeDIDJSONLD {
+		res.Context = resolutionContext
+	}
+	for _, vm := range doc.VerificationMethod {
+		if vm.ID == id {
+			res.ContentStream = vm
+			return res, nil
+		}
+	}
+	for _, svc := range doc.Service {
+		if svc.ID == id {
+			res.ContentStream = svc
+			return res, nil
+		}
+	}
+	return DereferencingResult{}, fmt.Errorf("%s not found in DID document", id)
+}
@@ -0,0 +1,155 @@
+package did
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// ResourcePathSegment is the DID URL path segment under which DID-linked
+// resources are dereferenced, e.g. did:aytch:abc/resources/{resourceId}.
+const ResourcePathSegment = "/resources/"
+
+// Resource is an arbitrary piece of data (schema, status list, logo, trust
+// framework, ...) attached to a DID. Resources with the same name and type in
+// a collection form a version chain. Large resources can be kept in IPFS:
+// ContentCID then anchors the data, which is not stored on chain. Each version
+// declares its Compatibility with the previous one; CompatibleFrom is the ID of
+// the oldest version of the run of additive versions it ends, so credentials
+// under any version with the same CompatibleFrom remain acceptable.
+type Resource struct {
+	CollectionID      string `protobuf:"bytes,1,opt,name=collection_id,proto3" json:"collection_id"`
+	ID                string `protobuf:"bytes,2,opt,name=id,proto3" json:"id"`
+	Name              string `protobuf:"bytes,3,opt,name=name,proto3" json:"name"`
+	ResourceType      string `protobuf:"bytes,4,opt,name=resource_type,proto3" json:"resource_type"`
+	MediaType         string `protobuf:"bytes,5,opt,name=media_type,proto3" json:"media_type"`
+	Version           string `protobuf:"bytes,6,opt,name=version,proto3" json:"version"`
+	Checksum          string `protobuf:"bytes,7,opt,name=checksum,proto3" json:"checksum"`
+	Created           int64  `protobuf:"varint,8,opt,name=created,proto3" json:"created"`
+	PreviousVersionID string `protobuf:"bytes,9,opt,name=previous_version_id,proto3" json:"previous_version_id"`
+	NextVersionID     string `protobuf:"bytes,10,opt,name=next_version_id,proto3" json:"next_version_id"`
+	Data              []byte `protobuf:"bytes,11,opt,name=data,proto3" json:"data"`
+	ContentCID        string `protobuf:"bytes,12,opt,name=content_cid,proto3" json:"content_cid,omitempty"`
+	Compatibility     string `protobuf:"bytes,13,opt,name=compatibility,proto3" json:"compatibility,omitempty"`
+	CompatibleFrom    string `protobuf:"bytes,14,opt,name=compatible_from,proto3" json:"compatible_from,omitempty"`
+}
+
+// Metadata returns a copy of the resource without its data.
+func (r Resource) Metadata() Resource {
+	r.Data = nil
+	return r
+}
+
+// URL returns the DID URL that dereferences to the resource.
+func (r Resource) URL() string {
+	return r.CollectionID + ResourcePathSegment + r.ID
+}
+
+// ParseResourceURL splits a DID URL of the form did/resources/{id} into the
+// DID and resource ID.
+func ParseResourceURL(didURL string) (string, string, error) {
+	idx := strings.Index(didURL, ResourcePathSegment)
+	if idx <= 0 {
+		return "", "", fmt.Errorf("not a resource URL: %s", didURL)
+	}
+	did, id := didURL[:idx], didURL[idx+len(ResourcePathSegment):]
+	if id == "" || strings.Contains(id, "/") {
+		return "", "", fmt.Errorf("invalid resource ID in %s", didURL)
+	}
+	return did, id, nil
+}
+
+// MsgCreateResource represents a message for attaching a resource to a DID.
+// A controller of the collection DID authorizes it like a MsgUpdateDID.
+type MsgCreateResource struct {
+	CollectionID  string         `protobuf:"bytes,1,opt,name=collection_id,proto3" json:"collection_id"`
+	ID            string         `protobuf:"bytes,2,opt,name=id,proto3" json:"id"`
+	Name          string         `protobuf:"bytes,3,opt,name=name,proto3" json:"name"`
+	ResourceType  string         `protobuf:"bytes,4,opt,name=resource_type,proto3" json:"resource_type"`
+	MediaType     string         `protobuf:"bytes,5,opt,name=media_type,proto3" json:"media_type"`
+	Version       string         `protobuf:"bytes,6,opt,name=version,proto3" json:"version"`
+	Data          []byte         `protobuf:"bytes,7,opt,name=data,proto3" json:"data"`
+	Creator       sdk.AccAddress `protobuf:"bytes,8,opt,name=creator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"creator"`
+	ContentCID    string         `protobuf:"bytes,9,opt,name=content_cid,proto3" json:"content_cid,omitempty"`
+	Compatibility string         `protobuf:"bytes,10,opt,name=compatibility,proto3" json:"compatibility,omitempty"`
+	Nonce         uint64         `protobuf:"varint,11,opt,name=nonce,proto3" json:"nonce"`
+	Signer        string         `protobuf:"bytes,12,opt,name=signer,proto3" json:"signer"`
+	Signature     []byte         `protobuf:"bytes,13,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+// ValidateBasic performs basic validation of MsgCreateResource.
+func (msg MsgCreateResource) ValidateBasic() error {
+	if err := validateChecksums(msg); err != nil {
+		return err
+	}
+	if msg.CollectionID == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Collection DID cannot be empty")
+	}
+	if msg.ID == "" || strings.Contains(msg.ID, "/") {
+		return sdkerrors.ErrUnknownRequest.Wrap("Resource ID must be non-empty and cannot contain '/'")
+	}
+	if msg.Name == "" || msg.ResourceType == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Resource name and type cannot be empty")
+	}
+	if msg.MediaType == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Resource media type cannot be empty")
+	}
+	if (len(msg.Data) == 0) == (msg.ContentCID == "") {
+		return sdkerrors.ErrUnknownRequest.Wrap("Resource must have either data or a content CID")
+	}
+	if msg.ContentCID != "" {
+		if err := ValidateCID(msg.ContentCID); err != nil {
+			return sdkerrors.ErrUnknownRequest.Wrap(err.Error())
+		}
+	}
+	if err := validateCompatibility(msg.Compatibility); err != nil {
+		return sdkerrors.ErrUnknownRequest.Wrap(err.Error())
+	}
+	if isAnonCredsResourceType(msg.ResourceType) {
+		if len(msg.Data) == 0 {
+			return sdkerrors.ErrUnknownRequest.Wrap("AnonCreds objects must be stored on chain")
+		}
+		if err := validateAnonCredsObject(msg.ResourceType, msg.Data, msg.CollectionID); err != nil {
+			return sdkerrors.ErrUnknownRequest.Wrap(err.Error())
+		}
+	}
+	if msg.ResourceType == CredentialManifestResourceType && len(msg.Data) > 0 {
+		if _, err := ParseCredentialManifest(msg.Data, msg.CollectionID); err != nil {
+			return sdkerrors.ErrUnknownRequest.Wrap(err.Error())
+		}
+	}
+	if msg.Signer == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Signer cannot be empty")
+	}
+	return nil
+}
+
+// ProofSignBytes returns the bytes a controller DID signs to authorize the
+// resource.
+func (msg MsgCreateResource) ProofSignBytes() []byte {
+	msg.Signature = nil
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// Route returns the message route.
+func (msg MsgCreateResource) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgCreateResource) Type() string { return "create_resource" }
+
+// GetSignBytes returns the canonical bytes to sign over.
+func (msg MsgCreateResource) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners returns the account that must sign the message.
+func (msg MsgCreateResource) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
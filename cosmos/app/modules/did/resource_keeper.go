@@ -0,0 +1,171 @@
+package did
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// CreateResource attaches a resource to an existing DID, computing its
+// checksum and linking it to the previous version with the same name and type
+// after checking the compatibility it declares.
+// Resources of the IssuerResourceTypes param can only be attached to allowed
+// issuers. Callers must have authorized the resource on behalf of the DID.
+func (k Keeper) CreateResource(ctx sdk.Context, res Resource) error {
+	if _, err := k.GetDID(ctx, res.CollectionID); err != nil {
+		return err
+	}
+	if k.GetParams(ctx).IsIssuerResourceType(res.ResourceType) {
+		if err := k.CheckIssuer(ctx, res.CollectionID); err != nil {
+			return err
+		}
+	}
+	store := ctx.KVStore(k.storeKey)
+	key := resourceKey(res.CollectionID, res.ID)
+	if store.Has(key) {
+		return fmt.Errorf("resource already exists")
+	}
+	if isAnonCredsResourceType(res.ResourceType) {
+		if err := k.checkAnonCredsReference(ctx, res); err != nil {
+			return err
+		}
+	}
+
+	if res.ContentCID != "" {
+		checksum, err := cidChecksum(res.ContentCID)
+		if err != nil {
+			return err
+		}
+		res.Checksum = checksum
+	} else {
+		sum := sha256.Sum256(res.Data)
+		res.Checksum = hex.EncodeToString(sum[:])
+	}
+	res.Created = ctx.BlockTime().Unix()
+	res.PreviousVersionID = ""
+	res.NextVersionID = ""
+	res.CompatibleFrom = res.ID
+
+	latestKey := resourceLatestKey(res.CollectionID, res.Name, res.ResourceType)
+	if prevID := store.Get(latestKey); prevID != nil {
+		prev, err := k.GetResource(ctx, res.CollectionID, string(prevID))
+		if err != nil {
+			return err
+		}
+		if err := linkVersion(prev, &res); err != nil {
+			return err
+		}
+		prev.NextVersionID = res.ID
+		k.setResource(ctx, prev)
+	} else if res.Compatibility != "" {
+		return fmt.Errorf("the first version of a resource cannot declare compatibility")
+	}
+	k.setResource(ctx, res)
+	store.Set(latestKey, []byte(res.ID))
+	ctx.EventManager().EmitEvent(sdk.NewEvent("resource_created",
+		sdk.NewAttribute("did", res.CollectionID),
+		sdk.NewAttribute("resource_id", res.ID),
+		sdk.NewAttribute("name", res.Name),
+		sdk.NewAttribute("resource_type", res.ResourceType),
+		sdk.NewAttribute("media_type", res.MediaType),
+		sdk.NewAttribute("version", res.Version),
+	))
+	return nil
+}
+
+// GetResource retrieves a resource by collection DID and resource ID.
+func (k Keeper) GetResource(ctx sdk.Context, did, id string) (Resource, error) {
+	store := ctx.KVStore(k.storeKey)
+	value := store.Get(resourceKey(did, id))
+	if value == nil {
+		return Resource{}, fmt.Errorf("resource not found")
+	}
+	var res Resource
+	k.cdc.MustUnmarshal(value, &res)
+	return res, nil
+}
+
+// ResourceAtTime returns the version of the resource chain with the given
+// name and type that was current at the Unix time at, or the latest version
+// when at is 0.
+func (k Keeper) ResourceAtTime(ctx sdk.Context, did, name, resourceType string, at int64) (Resource, error) {
+	latest := ctx.KVStore(k.storeKey).Get(resourceLatestKey(did, name, resourceType))
+	if latest == nil {
+		return Resource{}, fmt.Errorf("resource not found")
+	}
+	res, err := k.GetResource(ctx, did, string(latest))
+	if err != nil {
+		return Resource{}, err
+	}
+	for at != 0 && res.Created > at {
+		if res.PreviousVersionID == "" {
+			return Resource{}, fmt.Errorf("no version of %s was current at %d", name, at)
+		}
+		if res, err = k.GetResource(ctx, did, res.PreviousVersionID); err != nil {
+			return Resource{}, err
+		}
+	}
+	return res, nil
+}
+
+// ResourceVersions returns the metadata of every version of a resource's
+// chain, oldest first.
+func (k Keeper) ResourceVersions(ctx sdk.Context, did, id string) ([]Resource, error) {
+	res, err := k.GetResource(ctx, did, id)
+	if err != nil {
+		return nil, err
+	}
+	for res.PreviousVersionID != "" {
+		if res, err = k.GetResource(ctx, did, res.PreviousVersionID); err != nil {
+			return nil, err
+		}
+	}
+	versions := []Resource{res.Metadata()}
+	for res.NextVersionID != "" {
+		if res, err = k.GetResource(ctx, did, res.NextVersionID); err != nil {
+			return nil, err
+		}
+		versions = append(versions, res.Metadata())
+	}
+	return versions, nil
+}
+
+// GetResourceCollection returns the metadata of every resource attached to a DID.
+func (k Keeper) GetResourceCollection(ctx sdk.Context, did string) []Resource {
+	var resources []Resource
+	iteratePrefix(ctx.KVStore(k.storeKey), resourceCollectionKey(did), func(_, value []byte) bool {
+		var res Resource
+		k.cdc.MustUnmarshal(value, &res)
+		resources = append(resources, res.Metadata())
+		return false
+	})
+	return resources
+}
+
+// GetAllResources returns every resource in the store, data included.
+func (k Keeper) GetAllResources(ctx sdk.Context) []Resource {
+	var resources []Resource
+	iteratePrefix(ctx.KVStore(k.storeKey), ResourcePrefix, func(_, value []byte) bool {
+		var res Resource
+		k.cdc.MustUnmarshal(value, &res)
+		resources = append(resources, res)
+		return false
+	})
+	return resources
+}
+
+// importResource writes a resource exported to genesis, indexing it as the
+// latest version of its chain if no version follows it.
+func (k Keeper) importResource(ctx sdk.Context, res Resource) {
+	k.setResource(ctx, res)
+	if res.NextVersionID == "" {
+		ctx.KVStore(k.storeKey).Set(resourceLatestKey(res.CollectionID, res.Name, res.ResourceType), []byte(res.ID))
+	}
+}
+
+func (k Keeper) setResource(ctx sdk.Context, res Resource) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(resourceKey(res.CollectionID, res.ID), k.cdc.MustMarshal(&res))
+}
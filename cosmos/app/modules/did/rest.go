@@ -1,38 +1,261 @@
 package did
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"strconv"
 
 	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/rest"
 	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
 )
 
 func RegisterRoutes(cliCtx client.Context, r *mux.Router) {
 	r.HandleFunc("/dids", createDIDHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/dids/sponsor", sponsorDIDHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/dids/update", updateDIDHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/dids/deactivate", deactivateDIDHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/dids/changes", proposeDIDChangeHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/dids/changes/approve", approveDIDChangeHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/dids/guardians", setGuardiansHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/dids/recover", recoverDIDHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/dids/recover/cancel", cancelRecoveryHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/dids/rotate-key", rotateKeyHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/dids/rotate-key/cancel", cancelKeyRotationHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/dids/renew", renewDIDHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/dids/from-key", createDIDFromKeyHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/dids/batch", createDIDBatchHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/dids/commit", commitDIDHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/dids/link-account", linkAccountHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/dids/unlink-account", unlinkAccountHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/dids/link-ethereum", linkEthereumAccountHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/dids/unlink-ethereum", unlinkEthereumAccountHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/dids/capabilities/grant", grantCapabilityHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/dids/capabilities/revoke", revokeCapabilityHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/dids/link-asset", linkAssetHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/dids/unlink-asset", unlinkAssetHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/dids/verify-document", verifyDocumentHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/dids/changes/{changeId}", queryPendingChangeHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/dids/watch", watchDIDHandler(cliCtx)).Methods("GET")
 	r.HandleFunc("/dids/{id}", queryDIDHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/dids/{id}/diff", queryDiffHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/dids/{id}/key-rotation", queryKeyRotationHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/dids/{id}/recovery", queryRecoveryHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/dids/{id}/pending-changes", queryPendingChangesHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/dids/{id}/resources", queryResourcesHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/dids/{id}/accounts", queryAccountsHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/dids/{id}/capabilities", queryCapabilitiesHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/dids/{id}/linked-assets", queryLinkedAssetsHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/dids/{id}/deposit", queryDepositHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/dids/{id}/presentation-definitions", queryPresentationDefinitionsHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/dids/{id}/presentation-definitions/{definitionId}", queryPresentationDefinitionsHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/dids/presentation-definitions", setPresentationDefinitionHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/dids/presentation-definitions/delete", deletePresentationDefinitionHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/accounts/{address}/did", queryByAddressHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/activity/{actor}", exportActivityHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/dids/{id}/resources/{resourceId}", queryResourceHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/dids/{id}/resources/{resourceId}/versions", queryResourceVersionsHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/resources", createResourceHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/credentials/revoke", revokeCredentialHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/credentials/revoke-batch", revokeCredentialsHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/credentials/register-status", registerCredentialStatusHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/credentials/status/{id:.+}", queryCredentialStatusHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/did/params", queryParamsHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/did/stats", queryStatsHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/hash-anchors", anchorHashHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/hash-anchors/{hash}", queryHashAnchorsHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/credentials/anchor-root", anchorCredentialRootHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/credentials/verify-inclusion", verifyCredentialInclusionHandler(cliCtx)).Methods("POST")
+}
+
+// CreateDIDRequest is the body of POST /dids. Without Tx, the handler
+// builds an unsigned transaction creating the DID, paid for by BaseReq.From,
+// and returns it for signing. Once signed, the same endpoint broadcasts the
+// transaction passed as Tx.
+type CreateDIDRequest struct {
+	BaseReq rest.BaseReq    `json:"base_req"`
+	Msg     MsgCreateDID    `json:"msg"`
+	Tx      json.RawMessage `json:"tx,omitempty"`
+}
+
+// BroadcastResponse is the result of broadcasting a signed transaction.
+type BroadcastResponse struct {
+	TxHash string `json:"txhash"`
+	Code   uint32 `json:"code"`
+	RawLog string `json:"raw_log,omitempty"`
 }
 
 func createDIDHandler(cliCtx client.Context) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		var msg MsgCreateDID
-		if err := cliCtx.Codec.UnmarshalJSON(r.Body, &msg); err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+		var req CreateDIDRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
 		}
-		_, err := cliCtx.BroadcastTxSync(msg)
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+		if len(req.Tx) > 0 {
+			broadcastSignedTx(cliCtx, w, r, req.Tx)
+			return
+		}
+
+		br := req.BaseReq.Sanitize()
+		if !br.ValidateBasic(w) {
+			return
+		}
+		creator, err := sdk.AccAddressFromBech32(br.From)
+		if rest.CheckBadRequestError(w, err) {
+			return
+		}
+		msg := req.Msg
+		msg.Creator = creator
+		if rest.CheckBadRequestError(w, msg.ValidateBasic()) {
+			return
+		}
+		if err := checkAuthenticatedSigner(r, &msg); err != nil {
+			rest.WriteErrorResponse(w, http.StatusForbidden, err.Error())
+			return
+		}
+		tx.WriteGeneratedTxResponse(cliCtx, w, br, &msg)
+	}
+}
+
+// TxRequest is the body of the module's other transaction endpoints, and of
+// those of the modules built on it. It works like CreateDIDRequest, with Msg
+// holding the endpoint's message.
+type TxRequest struct {
+	BaseReq rest.BaseReq    `json:"base_req"`
+	Msg     json.RawMessage `json:"msg"`
+	Tx      json.RawMessage `json:"tx,omitempty"`
+}
+
+// TxHandler serves a transaction endpoint the way createDIDHandler does.
+// decodeMsg decodes the request's message and sets its signer to from,
+// the account in BaseReq.From.
+func TxHandler(cliCtx client.Context, decodeMsg func(body json.RawMessage, from sdk.AccAddress) (sdk.Msg, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req TxRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if len(req.Tx) > 0 {
+			broadcastSignedTx(cliCtx, w, r, req.Tx)
+			return
+		}
+
+		br := req.BaseReq.Sanitize()
+		if !br.ValidateBasic(w) {
+			return
+		}
+		from, err := sdk.AccAddressFromBech32(br.From)
+		if rest.CheckBadRequestError(w, err) {
+			return
+		}
+		msg, err := decodeMsg(req.Msg, from)
+		if rest.CheckBadRequestError(w, err) {
 			return
 		}
-		w.WriteHeader(http.StatusOK)
+		if rest.CheckBadRequestError(w, msg.ValidateBasic()) {
+			return
+		}
+		if err := checkAuthenticatedSigner(r, msg); err != nil {
+			rest.WriteErrorResponse(w, http.StatusForbidden, err.Error())
+			return
+		}
+		tx.WriteGeneratedTxResponse(cliCtx, w, br, msg)
 	}
 }
 
+// broadcastSignedTx broadcasts a JSON-encoded signed transaction and writes
+// its hash and result code.
+func broadcastSignedTx(cliCtx client.Context, w http.ResponseWriter, r *http.Request, txJSON []byte) {
+	stdTx, err := cliCtx.TxConfig.TxJSONDecoder()(txJSON)
+	if rest.CheckBadRequestError(w, err) {
+		return
+	}
+	if err := checkAuthenticatedSigner(r, stdTx.GetMsgs()...); err != nil {
+		rest.WriteErrorResponse(w, http.StatusForbidden, err.Error())
+		return
+	}
+	txBytes, err := cliCtx.TxConfig.TxEncoder()(stdTx)
+	if rest.CheckBadRequestError(w, err) {
+		return
+	}
+	res, err := cliCtx.BroadcastTxSync(txBytes)
+	if rest.CheckInternalServerError(w, err) {
+		return
+	}
+	rest.PostProcessResponseBare(w, cliCtx, BroadcastResponse{TxHash: res.TxHash, Code: res.Code, RawLog: res.RawLog})
+}
+
+func updateDIDHandler(cliCtx client.Context) http.HandlerFunc {
+	return TxHandler(cliCtx, func(body json.RawMessage, from sdk.AccAddress) (sdk.Msg, error) {
+		var msg MsgUpdateDID
+		err := json.Unmarshal(body, &msg)
+		msg.Creator = from
+		return &msg, err
+	})
+}
+
+func deactivateDIDHandler(cliCtx client.Context) http.HandlerFunc {
+	return TxHandler(cliCtx, func(body json.RawMessage, from sdk.AccAddress) (sdk.Msg, error) {
+		var msg MsgDeactivateDID
+		err := json.Unmarshal(body, &msg)
+		msg.Creator = from
+		return &msg, err
+	})
+}
+
+// queryDIDHandler resolves a DID. With ?prove=true the document is returned
+// with its ICS-23 proof and app hash, at ?height if given.
 func queryDIDHandler(cliCtx client.Context) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
 		id := vars["id"]
+		var height int64
+		if h := r.URL.Query().Get("height"); h != "" {
+			var err error
+			if height, err = strconv.ParseInt(h, 10, 64); err != nil {
+				http.Error(w, "invalid height", http.StatusBadRequest)
+				return
+			}
+		}
+		if v := r.URL.Query().Get("versionId"); v != "" {
+			version, err := strconv.ParseUint(v, 10, 64)
+			if err != nil || height != 0 {
+				http.Error(w, "invalid versionId", http.StatusBadRequest)
+				return
+			}
+			if height, err = VersionHeight(cliCtx, id, version); err != nil {
+				http.Error(w, err.Error(), http.StatusNotFound)
+				return
+			}
+		}
+		if r.URL.Query().Get("prove") == "true" {
+			proven, err := ResolveProven(cliCtx, id, height)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(proven)
+			return
+		}
+		if height != 0 {
+			doc, err := ResolveAt(cliCtx, id, height)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(doc)
+			return
+		}
 		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/did/%s", id), nil)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -41,3 +264,658 @@ func queryDIDHandler(cliCtx client.Context) http.HandlerFunc {
 		w.Write(res)
 	}
 }
+
+// queryDiffHandler returns the JSON Patch between the versions in the from
+// and to query parameters.
+func queryDiffHandler(cliCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		from, err := strconv.ParseUint(r.URL.Query().Get("from"), 10, 64)
+		if err != nil {
+			http.Error(w, "invalid from", http.StatusBadRequest)
+			return
+		}
+		to, err := strconv.ParseUint(r.URL.Query().Get("to"), 10, 64)
+		if err != nil {
+			http.Error(w, "invalid to", http.StatusBadRequest)
+			return
+		}
+		diff, err := DiffVersions(cliCtx, mux.Vars(r)["id"], from, to)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(diff)
+	}
+}
+
+// exportActivityHandler streams the activity of an account or controller
+// between the from and to heights as JSON lines, or as CSV with format=csv.
+func exportActivityHandler(cliCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		var fromHeight, toHeight int64
+		var err error
+		if v := q.Get("from"); v != "" {
+			if fromHeight, err = strconv.ParseInt(v, 10, 64); err != nil {
+				http.Error(w, "invalid from", http.StatusBadRequest)
+				return
+			}
+		}
+		if v := q.Get("to"); v != "" {
+			if toHeight, err = strconv.ParseInt(v, 10, 64); err != nil {
+				http.Error(w, "invalid to", http.StatusBadRequest)
+				return
+			}
+		}
+		format := q.Get("format")
+		switch format {
+		case "", "json":
+			format = "json"
+			w.Header().Set("Content-Type", "application/x-ndjson")
+		case "csv":
+			w.Header().Set("Content-Type", "text/csv")
+		default:
+			http.Error(w, "format must be json or csv", http.StatusBadRequest)
+			return
+		}
+		// Read every page at one height, so the export is consistent.
+		node, err := cliCtx.GetNode()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		status, err := node.Status(r.Context())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		clientCtx := cliCtx.WithHeight(status.SyncInfo.LatestBlockHeight)
+		// Once records were written, an error can only cut the response short.
+		if _, err := ExportActivity(r.Context(), clientCtx, mux.Vars(r)["actor"], fromHeight, toHeight, format, w); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}
+}
+
+func revokeCredentialHandler(cliCtx client.Context) http.HandlerFunc {
+	return TxHandler(cliCtx, func(body json.RawMessage, from sdk.AccAddress) (sdk.Msg, error) {
+		var msg MsgRevokeCredential
+		err := json.Unmarshal(body, &msg)
+		msg.Creator = from
+		return &msg, err
+	})
+}
+
+func revokeCredentialsHandler(cliCtx client.Context) http.HandlerFunc {
+	return TxHandler(cliCtx, func(body json.RawMessage, from sdk.AccAddress) (sdk.Msg, error) {
+		var msg MsgRevokeCredentials
+		err := json.Unmarshal(body, &msg)
+		msg.Creator = from
+		return &msg, err
+	})
+}
+
+func registerCredentialStatusHandler(cliCtx client.Context) http.HandlerFunc {
+	return TxHandler(cliCtx, func(body json.RawMessage, from sdk.AccAddress) (sdk.Msg, error) {
+		var msg MsgRegisterCredentialStatus
+		err := json.Unmarshal(body, &msg)
+		msg.Creator = from
+		return &msg, err
+	})
+}
+
+func queryCredentialStatusHandler(cliCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		id := vars["id"]
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/did/%s/%s", QueryCredentialStatus, id), nil)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Write(res)
+	}
+}
+
+func createResourceHandler(cliCtx client.Context) http.HandlerFunc {
+	return TxHandler(cliCtx, func(body json.RawMessage, from sdk.AccAddress) (sdk.Msg, error) {
+		var msg MsgCreateResource
+		err := json.Unmarshal(body, &msg)
+		msg.Creator = from
+		return &msg, err
+	})
+}
+
+// queryResourcesHandler lists a DID's resources, or dereferences the AnonCreds
+// style query DID URL did?resourceName=..&resourceType=..[&resourceVersionTime=..]
+// when a resource name is given.
+func queryResourcesHandler(cliCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		q := r.URL.Query()
+		if q.Get(ResourceNameParam) != "" {
+			did, name, resourceType, at, err := ParseResourceQuery(vars["id"] + "?" + r.URL.RawQuery)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			path := fmt.Sprintf("custom/did/%s/%s/%s/%s", QueryResourceByName, did, name, resourceType)
+			if at != 0 {
+				path += fmt.Sprintf("/%d", at)
+			}
+			writeResource(w, r, cliCtx, path)
+			return
+		}
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/did/%s/%s", QueryResources, vars["id"]), nil)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Write(res)
+	}
+}
+
+// queryResourceHandler dereferences did/resources/{resourceId}. The raw
+// resource data is served with its media type unless ?metadata=true is given.
+func queryResourceHandler(cliCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		writeResource(w, r, cliCtx, fmt.Sprintf("custom/did/%s/%s/%s", QueryResource, vars["id"], vars["resourceId"]))
+	}
+}
+
+func writeResource(w http.ResponseWriter, r *http.Request, cliCtx client.Context, path string) {
+	res, _, err := cliCtx.QueryWithData(path, nil)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	var resource Resource
+	if err := json.Unmarshal(res, &resource); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if r.URL.Query().Get("metadata") == "true" {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resource.Metadata())
+		return
+	}
+	w.Header().Set("Content-Type", resource.MediaType)
+	w.Write(resource.Data)
+}
+
+func queryParamsHandler(cliCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/did/%s", QueryParams), nil)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Write(res)
+	}
+}
+
+func queryStatsHandler(cliCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/did/%s", QueryStats), nil)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Write(res)
+	}
+}
+
+func queryHashAnchorsHandler(cliCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		path := fmt.Sprintf("custom/did/%s/%s", QueryHashAnchors, mux.Vars(r)["hash"])
+		res, _, err := cliCtx.QueryWithData(path, nil)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		w.Write(res)
+	}
+}
+
+func anchorHashHandler(cliCtx client.Context) http.HandlerFunc {
+	return TxHandler(cliCtx, func(body json.RawMessage, from sdk.AccAddress) (sdk.Msg, error) {
+		var msg MsgAnchorHash
+		err := json.Unmarshal(body, &msg)
+		msg.Creator = from
+		return &msg, err
+	})
+}
+
+func anchorCredentialRootHandler(cliCtx client.Context) http.HandlerFunc {
+	return TxHandler(cliCtx, func(body json.RawMessage, from sdk.AccAddress) (sdk.Msg, error) {
+		var msg MsgAnchorCredentialRoot
+		err := json.Unmarshal(body, &msg)
+		msg.Creator = from
+		return &msg, err
+	})
+}
+
+func proposeDIDChangeHandler(cliCtx client.Context) http.HandlerFunc {
+	return TxHandler(cliCtx, func(body json.RawMessage, from sdk.AccAddress) (sdk.Msg, error) {
+		var msg MsgProposeDIDChange
+		err := json.Unmarshal(body, &msg)
+		msg.Creator = from
+		return &msg, err
+	})
+}
+
+func approveDIDChangeHandler(cliCtx client.Context) http.HandlerFunc {
+	return TxHandler(cliCtx, func(body json.RawMessage, from sdk.AccAddress) (sdk.Msg, error) {
+		var msg MsgApproveDIDChange
+		err := json.Unmarshal(body, &msg)
+		msg.Creator = from
+		return &msg, err
+	})
+}
+
+func queryPendingChangesHandler(cliCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/did/%s/%s", QueryPendingChanges, vars["id"]), nil)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Write(res)
+	}
+}
+
+func queryPendingChangeHandler(cliCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/did/%s/%s", QueryPendingChange, vars["changeId"]), nil)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		w.Write(res)
+	}
+}
+
+func sponsorDIDHandler(cliCtx client.Context) http.HandlerFunc {
+	return TxHandler(cliCtx, func(body json.RawMessage, from sdk.AccAddress) (sdk.Msg, error) {
+		var msg MsgSponsorDID
+		err := json.Unmarshal(body, &msg)
+		msg.Sponsor = from
+		return &msg, err
+	})
+}
+
+func setGuardiansHandler(cliCtx client.Context) http.HandlerFunc {
+	return TxHandler(cliCtx, func(body json.RawMessage, from sdk.AccAddress) (sdk.Msg, error) {
+		var msg MsgSetGuardians
+		err := json.Unmarshal(body, &msg)
+		msg.Creator = from
+		return &msg, err
+	})
+}
+
+func recoverDIDHandler(cliCtx client.Context) http.HandlerFunc {
+	return TxHandler(cliCtx, func(body json.RawMessage, from sdk.AccAddress) (sdk.Msg, error) {
+		var msg MsgRecoverDID
+		err := json.Unmarshal(body, &msg)
+		msg.Creator = from
+		return &msg, err
+	})
+}
+
+func cancelRecoveryHandler(cliCtx client.Context) http.HandlerFunc {
+	return TxHandler(cliCtx, func(body json.RawMessage, from sdk.AccAddress) (sdk.Msg, error) {
+		var msg MsgCancelRecovery
+		err := json.Unmarshal(body, &msg)
+		msg.Creator = from
+		return &msg, err
+	})
+}
+
+func queryRecoveryHandler(cliCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/did/%s/%s", QueryRecovery, vars["id"]), nil)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		w.Write(res)
+	}
+}
+
+func queryAccountsHandler(cliCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/did/%s/%s", QueryAccounts, vars["id"]), nil)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Write(res)
+	}
+}
+
+func queryCapabilitiesHandler(cliCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/did/%s/%s", QueryCapabilities, vars["id"]), nil)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Write(res)
+	}
+}
+
+func queryLinkedAssetsHandler(cliCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/did/%s/%s", QueryLinkedAssets, vars["id"]), nil)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Write(res)
+	}
+}
+
+func queryResourceVersionsHandler(cliCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/did/%s/%s/%s", QueryResourceVersions, vars["id"], vars["resourceId"]), nil)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		w.Write(res)
+	}
+}
+
+// queryPresentationDefinitionsHandler lists a verifier's presentation
+// definitions, or returns the one named by the route's definitionId.
+func queryPresentationDefinitionsHandler(cliCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		path := fmt.Sprintf("custom/did/%s/%s", QueryPresentationDefinitions, vars["id"])
+		if id := vars["definitionId"]; id != "" {
+			path += "/" + id
+		}
+		res, _, err := cliCtx.QueryWithData(path, nil)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		w.Write(res)
+	}
+}
+
+func setPresentationDefinitionHandler(cliCtx client.Context) http.HandlerFunc {
+	return TxHandler(cliCtx, func(body json.RawMessage, from sdk.AccAddress) (sdk.Msg, error) {
+		var msg MsgSetPresentationDefinition
+		err := json.Unmarshal(body, &msg)
+		msg.Creator = from
+		return &msg, err
+	})
+}
+
+func deletePresentationDefinitionHandler(cliCtx client.Context) http.HandlerFunc {
+	return TxHandler(cliCtx, func(body json.RawMessage, from sdk.AccAddress) (sdk.Msg, error) {
+		var msg MsgDeletePresentationDefinition
+		err := json.Unmarshal(body, &msg)
+		msg.Creator = from
+		return &msg, err
+	})
+}
+
+func queryDepositHandler(cliCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/did/%s/%s", QueryDeposit, vars["id"]), nil)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		w.Write(res)
+	}
+}
+
+func queryByAddressHandler(cliCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/did/%s/%s", QueryByAddress, vars["address"]), nil)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		w.Write(res)
+	}
+}
+
+func rotateKeyHandler(cliCtx client.Context) http.HandlerFunc {
+	return TxHandler(cliCtx, func(body json.RawMessage, from sdk.AccAddress) (sdk.Msg, error) {
+		var msg MsgRotateKey
+		err := json.Unmarshal(body, &msg)
+		msg.Creator = from
+		return &msg, err
+	})
+}
+
+func cancelKeyRotationHandler(cliCtx client.Context) http.HandlerFunc {
+	return TxHandler(cliCtx, func(body json.RawMessage, from sdk.AccAddress) (sdk.Msg, error) {
+		var msg MsgCancelKeyRotation
+		err := json.Unmarshal(body, &msg)
+		msg.Creator = from
+		return &msg, err
+	})
+}
+
+func queryKeyRotationHandler(cliCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/did/%s/%s", QueryKeyRotation, vars["id"]), nil)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		w.Write(res)
+	}
+}
+
+func renewDIDHandler(cliCtx client.Context) http.HandlerFunc {
+	return TxHandler(cliCtx, func(body json.RawMessage, from sdk.AccAddress) (sdk.Msg, error) {
+		var msg MsgRenewDID
+		err := json.Unmarshal(body, &msg)
+		msg.Creator = from
+		return &msg, err
+	})
+}
+
+func createDIDFromKeyHandler(cliCtx client.Context) http.HandlerFunc {
+	return TxHandler(cliCtx, func(body json.RawMessage, from sdk.AccAddress) (sdk.Msg, error) {
+		var msg MsgCreateDIDFromKey
+		err := json.Unmarshal(body, &msg)
+		msg.Creator = from
+		return &msg, err
+	})
+}
+
+func createDIDBatchHandler(cliCtx client.Context) http.HandlerFunc {
+	return TxHandler(cliCtx, func(body json.RawMessage, from sdk.AccAddress) (sdk.Msg, error) {
+		var msg MsgCreateDIDBatch
+		err := json.Unmarshal(body, &msg)
+		msg.Creator = from
+		return &msg, err
+	})
+}
+
+func commitDIDHandler(cliCtx client.Context) http.HandlerFunc {
+	return TxHandler(cliCtx, func(body json.RawMessage, from sdk.AccAddress) (sdk.Msg, error) {
+		var msg MsgCommitDID
+		err := json.Unmarshal(body, &msg)
+		msg.Creator = from
+		return &msg, err
+	})
+}
+
+func linkAccountHandler(cliCtx client.Context) http.HandlerFunc {
+	return TxHandler(cliCtx, func(body json.RawMessage, from sdk.AccAddress) (sdk.Msg, error) {
+		var msg MsgLinkAccount
+		err := json.Unmarshal(body, &msg)
+		msg.Creator = from
+		return &msg, err
+	})
+}
+
+func unlinkAccountHandler(cliCtx client.Context) http.HandlerFunc {
+	return TxHandler(cliCtx, func(body json.RawMessage, from sdk.AccAddress) (sdk.Msg, error) {
+		var msg MsgUnlinkAccount
+		err := json.Unmarshal(body, &msg)
+		msg.Creator = from
+		return &msg, err
+	})
+}
+
+func linkEthereumAccountHandler(cliCtx client.Context) http.HandlerFunc {
+	return TxHandler(cliCtx, func(body json.RawMessage, from sdk.AccAddress) (sdk.Msg, error) {
+		var msg MsgLinkEthereumAccount
+		err := json.Unmarshal(body, &msg)
+		msg.Creator = from
+		return &msg, err
+	})
+}
+
+func unlinkEthereumAccountHandler(cliCtx client.Context) http.HandlerFunc {
+	return TxHandler(cliCtx, func(body json.RawMessage, from sdk.AccAddress) (sdk.Msg, error) {
+		var msg MsgUnlinkEthereumAccount
+		err := json.Unmarshal(body, &msg)
+		msg.Creator = from
+		return &msg, err
+	})
+}
+
+// verifyDocumentHandler checks the document and salt in the body, a JSON
+// QueryVerifyDocumentRequest, against the DID's commitment.
+func verifyDocumentHandler(cliCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/did/%s", QueryVerifyDocument), data)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(res)
+	}
+}
+
+// verifyCredentialInclusionHandler checks the credential hash and Merkle path
+// in the body, a JSON QueryVerifyCredentialInclusionRequest, against the
+// issuer's anchored root.
+func verifyCredentialInclusionHandler(cliCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/did/%s", QueryVerifyCredentialInclusion), data)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(res)
+	}
+}
+
+var watchUpgrader = websocket.Upgrader{
+	// DID events are public chain data, so any origin may subscribe.
+	CheckOrigin: func(*http.Request) bool { return true },
+}
+
+// watchDIDHandler upgrades to a websocket and pushes a JSON DIDEvent for
+// every write to the DID in ?did= or to DIDs controlled by ?controller=.
+func watchDIDHandler(cliCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := WatchDIDRequest{DID: r.URL.Query().Get("did"), Controller: r.URL.Query().Get("controller")}
+		if rest.CheckBadRequestError(w, req.Validate()) {
+			return
+		}
+		if cliCtx.Client == nil {
+			rest.WriteErrorResponse(w, http.StatusServiceUnavailable, "no node connection")
+			return
+		}
+		conn, err := watchUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		ctx, cancel := context.WithCancel(r.Context())
+		defer cancel()
+		events, err := NewWatcher(cliCtx.Client).Watch(ctx, req)
+		if err != nil {
+			conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseInternalServerErr, err.Error()))
+			return
+		}
+		// The client never sends anything; reading only detects when it
+		// goes away.
+		go func() {
+			defer cancel()
+			for {
+				if _, _, err := conn.NextReader(); err != nil {
+					return
+				}
+			}
+		}()
+		for ev := range events {
+			if err := conn.WriteJSON(ev); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func grantCapabilityHandler(cliCtx client.Context) http.HandlerFunc {
+	return TxHandler(cliCtx, func(body json.RawMessage, from sdk.AccAddress) (sdk.Msg, error) {
+		var msg MsgGrantCapability
+		err := json.Unmarshal(body, &msg)
+		msg.Creator = from
+		return &msg, err
+	})
+}
+
+func revokeCapabilityHandler(cliCtx client.Context) http.HandlerFunc {
+	return TxHandler(cliCtx, func(body json.RawMessage, from sdk.AccAddress) (sdk.Msg, error) {
+		var msg MsgRevokeCapability
+		err := json.Unmarshal(body, &msg)
+		msg.Creator = from
+		return &msg, err
+	})
+}
+
+func linkAssetHandler(cliCtx client.Context) http.HandlerFunc {
+	return TxHandler(cliCtx, func(body json.RawMessage, from sdk.AccAddress) (sdk.Msg, error) {
+		var msg MsgLinkAsset
+		err := json.Unmarshal(body, &msg)
+		msg.Creator = from
+		return &msg, err
+	})
+}
+
+func unlinkAssetHandler(cliCtx client.Context) http.HandlerFunc {
+	return TxHandler(cliCtx, func(body json.RawMessage, from sdk.AccAddress) (sdk.Msg, error) {
+		var msg MsgUnlinkAsset
+		err := json.Unmarshal(body, &msg)
+		msg.Creator = from
+		return &msg, err
+	})
+}
@@ -0,0 +1,300 @@
+package did
+
+import (
+	"bytes"
+	"container/heap"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"cosmos-app/jws"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/rest"
+	"github.com/gorilla/mux"
+)
+
+// FlagRESTAuth makes the node's REST server require DID-Auth on mutating
+// requests.
+const FlagRESTAuth = "did.rest-auth"
+
+// AuthScheme is the Authorization scheme of DID-Auth tokens.
+const AuthScheme = "DIDAuth"
+
+// MaxAuthTokenLifetime bounds how long a DID-Auth token is valid, from its
+// iat to its exp.
+const MaxAuthTokenLifetime = 5 * time.Minute
+
+// authClockSkew is how far in the future a token's iat may lie.
+const authClockSkew = time.Minute
+
+// maxAuthBodySize bounds the body of a request the DID-Auth middleware
+// hashes.
+const maxAuthBodySize = 4 << 20
+
+// AuthClaims are the claims of a DID-Auth token: a JWS whose kid is a DID
+// URL and whose issuer is that DID. The token is bound to one request by its
+// method (htm), URL (htu), of which only the path is compared, and body
+// (htb, the unpadded base64url SHA-256 of the body), and can be used once:
+// Jti must be unique until the token expires.
+type AuthClaims struct {
+	Iss string `json:"iss"`
+	Htm string `json:"htm"`
+	Htu string `json:"htu"`
+	Htb string `json:"htb"`
+	Iat int64  `json:"iat"`
+	Exp int64  `json:"exp"`
+	Jti string `json:"jti"`
+}
+
+// AuthBodyHash returns the htb claim binding a DID-Auth token to body.
+func AuthBodyHash(body []byte) string {
+	sum := sha256.Sum256(body)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// NewAuthToken returns a DID-Auth token for a request with method to rawURL
+// carrying body, valid for lifetime, signed by sign as kid, a DID or DID URL.
+func NewAuthToken(kid, method, rawURL string, body []byte, lifetime time.Duration, sign func(signingInput []byte) ([]byte, error)) (string, error) {
+	jti := make([]byte, 16)
+	if _, err := rand.Read(jti); err != nil {
+		return "", err
+	}
+	now := time.Now()
+	claims := AuthClaims{
+		Iss: strings.SplitN(kid, "#", 2)[0],
+		Htm: strings.ToUpper(method),
+		Htu: rawURL,
+		Htb: AuthBodyHash(body),
+		Iat: now.Unix(),
+		Exp: now.Add(lifetime).Unix(),
+		Jti: hex.EncodeToString(jti),
+	}
+	return jws.SignWith(jws.Header{Kid: kid, Typ: "JWT"}, claims, sign)
+}
+
+type authContextKey struct{}
+
+// AuthenticatedDID returns the DID that authenticated r through the DID-Auth
+// middleware, if any.
+func AuthenticatedDID(r *http.Request) (string, bool) {
+	id, ok := r.Context().Value(authContextKey{}).(string)
+	return id, ok
+}
+
+// AuthMiddleware returns REST middleware that requires an
+// "Authorization: DIDAuth <jws>" header on every request that isn't a GET,
+// HEAD or OPTIONS request. The token must be signed with EdDSA by the key its
+// kid names in the issuer's current document, resolved through the DID
+// keeper: the document's public key when kid is the bare DID, otherwise the
+// Ed25519 verification method kid identifies, which must be the document's
+// authentication method if it names one. Deactivated DIDs can't
+// authenticate. The transaction endpoints further require the DID to sign
+// the messages they carry; see checkAuthenticatedSigner.
+func AuthMiddleware(cliCtx client.Context) mux.MiddlewareFunc {
+	seen := newAuthReplayCache()
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				next.ServeHTTP(w, r)
+				return
+			}
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxAuthBodySize))
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusRequestEntityTooLarge, err.Error())
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			id, err := authenticate(cliCtx, seen, r, body, time.Now())
+			if err != nil {
+				w.Header().Set("WWW-Authenticate", AuthScheme)
+				rest.WriteErrorResponse(w, http.StatusUnauthorized, err.Error())
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), authContextKey{}, id)))
+		})
+	}
+}
+
+func authenticate(cliCtx client.Context, seen *authReplayCache, r *http.Request, body []byte, now time.Time) (string, error) {
+	scheme, token, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+	if scheme != AuthScheme || token == "" {
+		return "", fmt.Errorf("missing %s authorization", AuthScheme)
+	}
+	parsed, err := jws.Parse(token)
+	if err != nil {
+		return "", err
+	}
+	var claims AuthClaims
+	if err := parsed.Claims(&claims); err != nil {
+		return "", fmt.Errorf("invalid DID-Auth claims: %w", err)
+	}
+	id := strings.SplitN(parsed.Header.Kid, "#", 2)[0]
+	if id == "" || id != claims.Iss {
+		return "", fmt.Errorf("kid %s does not belong to issuer %s", parsed.Header.Kid, claims.Iss)
+	}
+	if err := claims.check(r, body, now); err != nil {
+		return "", err
+	}
+
+	res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/did/%s", id), nil)
+	if err != nil {
+		return "", fmt.Errorf("resolve %s: %w", id, err)
+	}
+	var did DIDDocument
+	if err := json.Unmarshal(res, &did); err != nil {
+		return "", err
+	}
+	pub, err := authenticationKey(did, parsed.Header.Kid)
+	if err != nil {
+		return "", err
+	}
+	if err := parsed.Verify(pub); err != nil {
+		return "", err
+	}
+	if !seen.add(claims.Jti, claims.Exp, now.Unix()) {
+		return "", fmt.Errorf("DID-Auth token %s was already used", claims.Jti)
+	}
+	return id, nil
+}
+
+// check validates the token's lifetime and its binding to r and its body.
+func (c AuthClaims) check(r *http.Request, body []byte, now time.Time) error {
+	if c.Jti == "" {
+		return fmt.Errorf("DID-Auth token has no jti")
+	}
+	if c.Exp <= now.Unix() {
+		return fmt.Errorf("DID-Auth token expired")
+	}
+	if c.Iat > now.Add(authClockSkew).Unix() || c.Exp-c.Iat > int64(MaxAuthTokenLifetime/time.Second) {
+		return fmt.Errorf("DID-Auth token must be issued now and live at most %s", MaxAuthTokenLifetime)
+	}
+	if !strings.EqualFold(c.Htm, r.Method) {
+		return fmt.Errorf("DID-Auth token is for %s requests", c.Htm)
+	}
+	u, err := url.Parse(c.Htu)
+	if err != nil || u.Path != r.URL.Path {
+		return fmt.Errorf("DID-Auth token is not for %s", r.URL.Path)
+	}
+	if c.Htb != AuthBodyHash(body) {
+		return fmt.Errorf("DID-Auth token is not for this request body")
+	}
+	return nil
+}
+
+// checkAuthenticatedSigner checks that the DID that authenticated r through
+// AuthMiddleware, if any, is the one each of msgs is sent on behalf of.
+// Messages creating DIDs, which can't authenticate before they exist, and
+// messages an account signs alone are only bound to the token by the body
+// hash.
+func checkAuthenticatedSigner(r *http.Request, msgs ...sdk.Msg) error {
+	id, ok := AuthenticatedDID(r)
+	if !ok {
+		return nil
+	}
+	for _, msg := range msgs {
+		if acting := actingDID(msg); acting != "" && acting != id {
+			return fmt.Errorf("%s is signed by %s, not by the authenticated DID %s", sdk.MsgTypeURL(msg), acting, id)
+		}
+	}
+	return nil
+}
+
+// actingDID returns the DID msg is sent on behalf of: the DID of its Signer,
+// or of the guardian or DID signing the messages that have no Signer. It is
+// empty when msg names none.
+func actingDID(msg sdk.Msg) string {
+	signer := controllerSigner(msg)
+	switch msg := msg.(type) {
+	case *MsgRecoverDID:
+		signer = msg.Guardian
+	case *MsgCancelKeyRotation:
+		signer = msg.DID
+	}
+	return strings.SplitN(signer, "#", 2)[0]
+}
+
+// authenticationKey returns the Ed25519 key kid names in did.
+func authenticationKey(did DIDDocument, kid string) (ed25519.PublicKey, error) {
+	if did.Deactivated {
+		return nil, fmt.Errorf("DID %s is deactivated", did.ID)
+	}
+	if kid == did.ID {
+		return did.Ed25519PublicKey()
+	}
+	if did.Authentication != "" && kid != did.Authentication {
+		return nil, fmt.Errorf("%s is not the authentication method of %s", kid, did.ID)
+	}
+	vm, err := signingMethod(did, kid)
+	if err != nil {
+		return nil, err
+	}
+	if vm.Type != Ed25519VerificationKey2020 {
+		return nil, fmt.Errorf("verification method %s of type %s can't sign DID-Auth tokens", vm.ID, vm.Type)
+	}
+	key, err := vm.PublicKeyBytes()
+	if err != nil {
+		return nil, err
+	}
+	return ed25519.PublicKey(key), nil
+}
+
+// authReplayCache remembers the jti of accepted tokens until they expire.
+// Expiries are kept in a min-heap, so expired jtis are dropped without
+// scanning the ones still live.
+type authReplayCache struct {
+	mu     sync.Mutex
+	jtis   map[string]struct{}
+	expiry jtiExpiryHeap
+}
+
+func newAuthReplayCache() *authReplayCache {
+	return &authReplayCache{jtis: make(map[string]struct{})}
+}
+
+// add records jti, valid until exp, and reports whether it was new.
+func (c *authReplayCache) add(jti string, exp, now int64) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.expiry) > 0 && c.expiry[0].exp <= now {
+		delete(c.jtis, heap.Pop(&c.expiry).(jtiExpiry).jti)
+	}
+	if _, used := c.jtis[jti]; used {
+		return false
+	}
+	c.jtis[jti] = struct{}{}
+	heap.Push(&c.expiry, jtiExpiry{jti: jti, exp: exp})
+	return true
+}
+
+type jtiExpiry struct {
+	jti string
+	exp int64
+}
+
+// jtiExpiryHeap implements heap.Interface, earliest expiry first.
+type jtiExpiryHeap []jtiExpiry
+
+func (h jtiExpiryHeap) Len() int            { return len(h) }
+func (h jtiExpiryHeap) Less(i, j int) bool  { return h[i].exp < h[j].exp }
+func (h jtiExpiryHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *jtiExpiryHeap) Push(x interface{}) { *h = append(*h, x.(jtiExpiry)) }
+func (h *jtiExpiryHeap) Pop() interface{} {
+	old := *h
+	last := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return last
+}
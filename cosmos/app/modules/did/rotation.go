@@ -0,0 +1,127 @@
+package did
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// PendingKeyRotation is a key rotation waiting out its DID's RotationDelay.
+type PendingKeyRotation struct {
+	DID           string `protobuf:"bytes,1,opt,name=did,proto3" json:"did"`
+	NewPublicKey  string `protobuf:"bytes,2,opt,name=new_public_key,proto3" json:"new_public_key"`
+	RotationDelay uint64 `protobuf:"varint,3,opt,name=rotation_delay,proto3" json:"rotation_delay"`
+	EffectiveAt   int64  `protobuf:"varint,4,opt,name=effective_at,proto3" json:"effective_at"`
+}
+
+// MsgRotateKey replaces a DID's public key and sets its RotationDelay for
+// future rotations. If the DID currently has a RotationDelay, the rotation is
+// queued for that many blocks and can be cancelled with the current key.
+type MsgRotateKey struct {
+	DID           string         `protobuf:"bytes,1,opt,name=did,proto3" json:"did"`
+	NewPublicKey  string         `protobuf:"bytes,2,opt,name=new_public_key,proto3" json:"new_public_key"`
+	RotationDelay uint64         `protobuf:"varint,3,opt,name=rotation_delay,proto3" json:"rotation_delay"`
+	Nonce         uint64         `protobuf:"varint,7,opt,name=nonce,proto3" json:"nonce"`
+	Signer        string         `protobuf:"bytes,4,opt,name=signer,proto3" json:"signer"`
+	Signature     []byte         `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
+	Creator       sdk.AccAddress `protobuf:"bytes,6,opt,name=creator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"creator"`
+}
+
+// ValidateBasic performs basic validation of MsgRotateKey.
+func (msg MsgRotateKey) ValidateBasic() error {
+	if err := validateChecksums(msg); err != nil {
+		return err
+	}
+	if msg.DID == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("DID cannot be empty")
+	}
+	if msg.Signer == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Signer cannot be empty")
+	}
+	bz, err := base64.StdEncoding.DecodeString(msg.NewPublicKey)
+	if err != nil || len(bz) != ed25519.PublicKeySize {
+		return sdkerrors.ErrUnknownRequest.Wrap("New public key must be a base64 Ed25519 public key")
+	}
+	return nil
+}
+
+// ProofSignBytes returns the bytes a controller DID signs to authorize the
+// rotation.
+func (msg MsgRotateKey) ProofSignBytes() []byte {
+	msg.Signature = nil
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// Route returns the message route.
+func (msg MsgRotateKey) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgRotateKey) Type() string { return "rotate_key" }
+
+// GetSignBytes returns the canonical bytes to sign over.
+func (msg MsgRotateKey) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners returns the account that must sign the message.
+func (msg MsgRotateKey) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
+
+// MsgCancelKeyRotation cancels a pending key rotation. Signature must be made
+// over ProofSignBytes with the DID's current public key, so whoever still
+// holds the key can stop a hostile rotation.
+type MsgCancelKeyRotation struct {
+	DID       string         `protobuf:"bytes,1,opt,name=did,proto3" json:"did"`
+	Nonce     uint64         `protobuf:"varint,4,opt,name=nonce,proto3" json:"nonce"`
+	Signature []byte         `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	Creator   sdk.AccAddress `protobuf:"bytes,3,opt,name=creator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"creator"`
+}
+
+// ValidateBasic performs basic validation of MsgCancelKeyRotation.
+func (msg MsgCancelKeyRotation) ValidateBasic() error {
+	if err := validateChecksums(msg); err != nil {
+		return err
+	}
+	if msg.DID == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("DID cannot be empty")
+	}
+	if len(msg.Signature) == 0 {
+		return sdkerrors.ErrUnknownRequest.Wrap("Signature cannot be empty")
+	}
+	return nil
+}
+
+// ProofSignBytes returns the bytes the current key signs to cancel the
+// rotation.
+func (msg MsgCancelKeyRotation) ProofSignBytes() []byte {
+	msg.Signature = nil
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// Route returns the message route.
+func (msg MsgCancelKeyRotation) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgCancelKeyRotation) Type() string { return "cancel_key_rotation" }
+
+// GetSignBytes returns the canonical bytes to sign over.
+func (msg MsgCancelKeyRotation) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners returns the account that must sign the message.
+func (msg MsgCancelKeyRotation) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
@@ -0,0 +1,140 @@
+package did
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// CheckRotationLock rejects updates that would bypass a DID's RotationDelay:
+// replacing its public key directly, changing its authentication, adding or
+// replacing a verification method that can sign for it, or shortening the
+// delay. Signing methods can still be removed.
+func (k Keeper) CheckRotationLock(ctx sdk.Context, updated DIDDocument) error {
+	existing, err := k.GetDID(ctx, updated.ID)
+	if err != nil {
+		return err
+	}
+	if existing.RotationDelay == 0 {
+		return nil
+	}
+	if updated.PublicKey != existing.PublicKey {
+		return fmt.Errorf("DID %s has a rotation delay; use MsgRotateKey to change its key", updated.ID)
+	}
+	if updated.Authentication != existing.Authentication {
+		return fmt.Errorf("DID %s has a rotation delay; its authentication can't change", updated.ID)
+	}
+	for _, vm := range updated.VerificationMethods {
+		if !canSignDIDOperations(vm.Type) {
+			continue
+		}
+		if current, ok := existing.VerificationMethod(vm.ID); !ok || !sameJSON(current, vm) {
+			return fmt.Errorf("DID %s has a rotation delay; signing method %s can't be added or replaced", updated.ID, vm.ID)
+		}
+	}
+	if updated.RotationDelay < existing.RotationDelay {
+		return fmt.Errorf("DID %s rotation delay can only be shortened through MsgRotateKey", updated.ID)
+	}
+	return nil
+}
+
+// RotateKey replaces a DID's public key, immediately if it has no
+// RotationDelay and otherwise once the delay has passed.
+func (k Keeper) RotateKey(ctx sdk.Context, id, newPublicKey string, rotationDelay uint64) error {
+	did, err := k.GetDID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if did.RotationDelay == 0 {
+		did.PublicKey = newPublicKey
+		did.RotationDelay = rotationDelay
+		return k.UpdateDID(ctx, did)
+	}
+	if _, found := k.GetPendingKeyRotation(ctx, id); found {
+		return fmt.Errorf("a key rotation of %s is already pending", id)
+	}
+	k.setPendingKeyRotation(ctx, PendingKeyRotation{
+		DID:           id,
+		NewPublicKey:  newPublicKey,
+		RotationDelay: rotationDelay,
+		EffectiveAt:   ctx.BlockHeight() + int64(did.RotationDelay),
+	})
+	return k.IncrementNonce(ctx, id)
+}
+
+// CancelKeyRotation cancels a pending rotation. The signature must verify
+// against the DID's current key and nonce must be the DID's current nonce.
+func (k Keeper) CancelKeyRotation(ctx sdk.Context, id string, nonce uint64, signBytes, signature []byte) error {
+	rot, found := k.GetPendingKeyRotation(ctx, id)
+	if !found {
+		return fmt.Errorf("no key rotation of %s is pending", id)
+	}
+	did, err := k.GetDID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := checkNonce(did, nonce); err != nil {
+		return err
+	}
+	if err := k.verifyDIDSignature(ctx, id, signBytes, signature); err != nil {
+		return err
+	}
+	k.deletePendingKeyRotation(ctx, rot)
+	return k.IncrementNonce(ctx, id)
+}
+
+// GetPendingKeyRotation returns the rotation pending for a DID.
+func (k Keeper) GetPendingKeyRotation(ctx sdk.Context, did string) (PendingKeyRotation, bool) {
+	value := ctx.KVStore(k.storeKey).Get(pendingKeyRotationKey(did))
+	if value == nil {
+		return PendingKeyRotation{}, false
+	}
+	var rot PendingKeyRotation
+	k.cdc.MustUnmarshal(value, &rot)
+	return rot, true
+}
+
+// GetAllPendingKeyRotations returns every pending key rotation in the store.
+func (k Keeper) GetAllPendingKeyRotations(ctx sdk.Context) []PendingKeyRotation {
+	var rotations []PendingKeyRotation
+	iteratePrefix(ctx.KVStore(k.storeKey), PendingKeyRotationPrefix, func(_, value []byte) bool {
+		var rot PendingKeyRotation
+		k.cdc.MustUnmarshal(value, &rot)
+		rotations = append(rotations, rot)
+		return false
+	})
+	return rotations
+}
+
+// ApplyKeyRotations applies pending rotations whose delay has passed.
+func (k Keeper) ApplyKeyRotations(ctx sdk.Context) {
+	for _, e := range dueEntries(ctx.KVStore(k.storeKey), KeyRotationQueuePrefix, ctx.BlockHeight()) {
+		id := string(e.suffix)
+		rot, found := k.GetPendingKeyRotation(ctx, id)
+		if !found {
+			continue
+		}
+		k.deletePendingKeyRotation(ctx, rot)
+		did, err := k.GetDID(ctx, id)
+		if err != nil {
+			continue
+		}
+		did.PublicKey = rot.NewPublicKey
+		did.RotationDelay = rot.RotationDelay
+		if err := k.UpdateDID(ctx, did); err != nil {
+			ctx.Logger().Error("failed to apply key rotation", "did", id, "err", err)
+		}
+	}
+}
+
+func (k Keeper) setPendingKeyRotation(ctx sdk.Context, rot PendingKeyRotation) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(pendingKeyRotationKey(rot.DID), k.cdc.MustMarshal(&rot))
+	store.Set(keyRotationQueueKey(rot.EffectiveAt, rot.DID), []byte{})
+}
+
+func (k Keeper) deletePendingKeyRotation(ctx sdk.Context, rot PendingKeyRotation) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(pendingKeyRotationKey(rot.DID))
+	store.Delete(keyRotationQueueKey(rot.EffectiveAt, rot.DID))
+}
@@ -0,0 +1,101 @@
+package did
+
+import (
+	"testing"
+)
+
+func TestApplyKeyRotations(t *testing.T) {
+	k, ctx, _, _ := testKeeper(t)
+	did := newTestSigner("alice").document()
+	did.RotationDelay = 5
+	mustCreate(t, k, ctx, did)
+	newKey := newTestSigner("alice-2").document().PublicKey
+
+	if err := k.RotateKey(ctx, did.ID, newKey, 3); err != nil {
+		t.Fatal(err)
+	}
+	if err := k.RotateKey(ctx, did.ID, newKey, 3); err == nil {
+		t.Error("second rotation queued while one is pending")
+	}
+	rot, found := k.GetPendingKeyRotation(ctx, did.ID)
+	if !found || rot.EffectiveAt != 6 {
+		t.Fatalf("pending rotation = %+v, %t; want effective at 6", rot, found)
+	}
+
+	k.ApplyKeyRotations(ctx.WithBlockHeight(5))
+	if got, _ := k.GetDID(ctx, did.ID); got.PublicKey != did.PublicKey {
+		t.Fatal("rotation applied before its delay passed")
+	}
+
+	k.ApplyKeyRotations(ctx.WithBlockHeight(6))
+	got, err := k.GetDID(ctx, did.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.PublicKey != newKey || got.RotationDelay != 3 {
+		t.Errorf("after rotation: key %s delay %d, want %s and 3", got.PublicKey, got.RotationDelay, newKey)
+	}
+	if _, found := k.GetPendingKeyRotation(ctx, did.ID); found {
+		t.Error("applied rotation still pending")
+	}
+}
+
+func TestRotateKeyWithoutDelay(t *testing.T) {
+	k, ctx, _, _ := testKeeper(t)
+	did := newTestSigner("alice").document()
+	mustCreate(t, k, ctx, did)
+	newKey := newTestSigner("alice-2").document().PublicKey
+
+	if err := k.RotateKey(ctx, did.ID, newKey, 0); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := k.GetDID(ctx, did.ID); got.PublicKey != newKey {
+		t.Error("rotation of a DID without a delay not applied immediately")
+	}
+}
+
+func TestCancelKeyRotation(t *testing.T) {
+	k, ctx, _, _ := testKeeper(t)
+	alice := newTestSigner("alice")
+	did := alice.document()
+	did.RotationDelay = 5
+	mustCreate(t, k, ctx, did)
+	if err := k.RotateKey(ctx, did.ID, newTestSigner("thief").document().PublicKey, 0); err != nil {
+		t.Fatal(err)
+	}
+	signBytes := []byte("cancel")
+
+	if err := k.CancelKeyRotation(ctx, did.ID, 1, signBytes, newTestSigner("thief").sign(signBytes)); err == nil {
+		t.Error("rotation cancelled with the new key")
+	}
+	if err := k.CancelKeyRotation(ctx, did.ID, 1, signBytes, alice.sign(signBytes)); err != nil {
+		t.Fatal(err)
+	}
+	k.ApplyKeyRotations(ctx.WithBlockHeight(6))
+	if got, _ := k.GetDID(ctx, did.ID); got.PublicKey != did.PublicKey {
+		t.Error("cancelled rotation applied")
+	}
+}
+
+func TestCheckRotationLock(t *testing.T) {
+	k, ctx, _, _ := testKeeper(t)
+	did := newTestSigner("alice").document()
+	did.RotationDelay = 5
+	mustCreate(t, k, ctx, did)
+
+	updated := did
+	updated.PublicKey = newTestSigner("alice-2").document().PublicKey
+	if err := k.CheckRotationLock(ctx, updated); err == nil {
+		t.Error("direct key change allowed despite the rotation delay")
+	}
+	updated = did
+	updated.RotationDelay = 1
+	if err := k.CheckRotationLock(ctx, updated); err == nil {
+		t.Error("rotation delay shortened by an update")
+	}
+	updated = did
+	updated.ServiceEndpoints = []string{"https://example.com"}
+	if err := k.CheckRotationLock(ctx, updated); err != nil {
+		t.Errorf("update leaving the key alone rejected: %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+package did
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// Compatibility a new resource version declares with the previous version of
+// its chain. Credentials issued under an additive version's predecessors
+// remain valid under it; a breaking version starts a new compatible run.
+// Versions that declare nothing are breaking.
+const (
+	CompatibilityAdditive = "additive"
+	CompatibilityBreaking = "breaking"
+)
+
+// SchemaResourceType is the resource type of JSON schemas, whose additive
+// versions are checked on chain.
+const SchemaResourceType = "JsonSchema"
+
+func validateCompatibility(compatibility string) error {
+	switch compatibility {
+	case "", CompatibilityAdditive, CompatibilityBreaking:
+		return nil
+	default:
+		return fmt.Errorf("compatibility must be %s or %s", CompatibilityAdditive, CompatibilityBreaking)
+	}
+}
+
+// linkVersion links res as the version following prev, checking its declared
+// compatibility. An additive version shares prev's CompatibleFrom.
+func linkVersion(prev Resource, res *Resource) error {
+	res.PreviousVersionID = prev.ID
+	res.CompatibleFrom = res.ID
+	if res.Compatibility != CompatibilityAdditive {
+		return nil
+	}
+	if res.ResourceType != SchemaResourceType {
+		return fmt.Errorf("additive compatibility can only be checked for %s resources", SchemaResourceType)
+	}
+	if len(prev.Data) == 0 || len(res.Data) == 0 {
+		return fmt.Errorf("additive compatibility can only be checked for schemas stored on chain")
+	}
+	if err := checkAdditiveSchema(prev.Data, res.Data); err != nil {
+		return fmt.Errorf("version %s is not additive over %s: %w", res.ID, prev.ID, err)
+	}
+	res.CompatibleFrom = prev.CompatibleFrom
+	if res.CompatibleFrom == "" {
+		res.CompatibleFrom = prev.ID
+	}
+	return nil
+}
+
+// checkAdditiveSchema checks that every instance valid under the JSON schema
+// prev is valid under next: next keeps prev's type and properties unchanged,
+// requires no property prev didn't, and doesn't newly forbid additional
+// properties.
+func checkAdditiveSchema(prev, next []byte) error {
+	var p, n map[string]interface{}
+	if err := json.Unmarshal(prev, &p); err != nil {
+		return fmt.Errorf("previous schema: %w", err)
+	}
+	if err := json.Unmarshal(next, &n); err != nil {
+		return fmt.Errorf("schema: %w", err)
+	}
+	if !reflect.DeepEqual(p["type"], n["type"]) {
+		return fmt.Errorf("type changed")
+	}
+	prevProps, _ := p["properties"].(map[string]interface{})
+	nextProps, _ := n["properties"].(map[string]interface{})
+	for name, def := range prevProps {
+		if !reflect.DeepEqual(def, nextProps[name]) {
+			return fmt.Errorf("property %s changed or removed", name)
+		}
+	}
+	prevRequired := make(map[interface{}]bool)
+	if required, ok := p["required"].([]interface{}); ok {
+		for _, name := range required {
+			prevRequired[name] = true
+		}
+	}
+	if required, ok := n["required"].([]interface{}); ok {
+		for _, name := range required {
+			if !prevRequired[name] {
+				return fmt.Errorf("property %v is newly required", name)
+			}
+		}
+	}
+	if n["additionalProperties"] == false && p["additionalProperties"] != false {
+		return fmt.Errorf("additional properties are newly forbidden")
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package did
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// Service types with default endpoint rules.
+const (
+	ServiceTypeLinkedDomains                = "LinkedDomains"
+	ServiceTypeCredentialRegistry           = "CredentialRegistry"
+	ServiceTypeLinkedVerifiablePresentation = "LinkedVerifiablePresentation"
+)
+
+// ServiceTypeRule is a service type recognized by the registry and the shape
+// its endpoints must have: a URI with one of Schemes and, when OriginOnly is
+// set, nothing after the host, as DIF Well Known DID Configuration requires of
+// LinkedDomains. Services of types without a rule only get the checks every
+// endpoint gets.
+type ServiceTypeRule struct {
+	Type       string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type"`
+	Schemes    []string `protobuf:"bytes,2,rep,name=schemes,proto3" json:"schemes"`
+	OriginOnly bool     `protobuf:"varint,3,opt,name=origin_only,proto3" json:"origin_only,omitempty"`
+}
+
+func defaultServiceTypeRules() []ServiceTypeRule {
+	return []ServiceTypeRule{
+		{Type: ServiceTypeDIDCommMessaging, Schemes: []string{"https", "wss", "did"}},
+		{Type: ServiceTypeLinkedDomains, Schemes: []string{"https"}, OriginOnly: true},
+		{Type: ServiceTypeCredentialRegistry, Schemes: []string{"https"}},
+		{Type: ServiceTypeLinkedVerifiablePresentation, Schemes: []string{"https", "ipfs"}},
+	}
+}
+
+// forbiddenSchemes can run code or read local data in whatever dereferences
+// the endpoint, so no endpoint may use them whatever its type.
+var forbiddenSchemes = map[string]bool{
+	"javascript": true,
+	"vbscript":   true,
+	"data":       true,
+	"file":       true,
+	"blob":       true,
+}
+
+// hierarchicalSchemes are the schemes whose endpoints must name a host.
+var hierarchicalSchemes = map[string]bool{
+	"http":  true,
+	"https": true,
+	"ws":    true,
+	"wss":   true,
+}
+
+// ServiceTypeRule returns the registry's rule for a service type.
+func (p Params) ServiceTypeRule(serviceType string) (ServiceTypeRule, bool) {
+	for _, rule := range p.ServiceTypes {
+		if rule.Type == serviceType {
+			return rule, true
+		}
+	}
+	return ServiceTypeRule{}, false
+}
+
+// checkServiceEndpoints rejects documents with malformed or dangerous
+// endpoints, and services whose endpoint doesn't fit the rule registered for
+// their type. Encrypted endpoints can't be inspected and are skipped.
+func (k Keeper) checkServiceEndpoints(ctx sdk.Context, did DIDDocument) error {
+	params := k.GetParams(ctx)
+	for _, endpoint := range did.ServiceEndpoints {
+		if _, err := parseEndpoint(endpoint); err != nil {
+			return sdkerrors.Wrap(ErrInvalidServiceEndpoint, err.Error())
+		}
+	}
+	for _, svc := range did.Services {
+		if IsEncryptedEndpoint(svc.ServiceEndpoint) {
+			continue
+		}
+		u, err := parseEndpoint(svc.ServiceEndpoint)
+		if err != nil {
+			return sdkerrors.Wrapf(ErrInvalidServiceEndpoint, "service %s: %s", svc.ID, err)
+		}
+		rule, ok := params.ServiceTypeRule(svc.Type)
+		if !ok {
+			continue
+		}
+		if err := rule.check(u); err != nil {
+			return sdkerrors.Wrapf(ErrInvalidServiceEndpoint, "service %s: %s", svc.ID, err)
+		}
+	}
+	return nil
+}
+
+// parseEndpoint parses an endpoint as an absolute URI and rejects forbidden
+// schemes and URIs that browsers and HTTP clients could read differently.
+func parseEndpoint(endpoint string) (*url.URL, error) {
+	for _, r := range endpoint {
+		if r <= ' ' || r == 0x7f {
+			return nil, fmt.Errorf("endpoint contains whitespace or control characters")
+		}
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("endpoint is not a valid URI")
+	}
+	if u.Scheme == "" {
+		return nil, fmt.Errorf("endpoint must be an absolute URI")
+	}
+	scheme := strings.ToLower(u.Scheme)
+	if forbiddenSchemes[scheme] {
+		return nil, fmt.Errorf("endpoint scheme %s is not allowed", scheme)
+	}
+	if hierarchicalSchemes[scheme] && u.Host == "" {
+		return nil, fmt.Errorf("%s endpoint must have a host", scheme)
+	}
+	if u.User != nil {
+		return nil, fmt.Errorf("endpoint must not contain credentials")
+	}
+	return u, nil
+}
+
+func (rule ServiceTypeRule) check(u *url.URL) error {
+	scheme := strings.ToLower(u.Scheme)
+	if !containsString(rule.Schemes, scheme) {
+		return fmt.Errorf("%s endpoints must use %s, not %s", rule.Type, strings.Join(rule.Schemes, " or "), scheme)
+	}
+	if rule.OriginOnly && ((u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" || u.Opaque != "") {
+		return fmt.Errorf("%s endpoints must be an origin without path, query or fragment", rule.Type)
+	}
+	return nil
+}
+
+func validateServiceTypes(i interface{}) error {
+	rules, ok := i.([]ServiceTypeRule)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	seen := make(map[string]bool, len(rules))
+	for _, rule := range rules {
+		if rule.Type == "" {
+			return fmt.Errorf("service type cannot be empty")
+		}
+		if seen[rule.Type] {
+			return fmt.Errorf("service type %s is listed more than once", rule.Type)
+		}
+		seen[rule.Type] = true
+		if len(rule.Schemes) == 0 {
+			return fmt.Errorf("service type %s must allow at least one scheme", rule.Type)
+		}
+		for _, scheme := range rule.Schemes {
+			if scheme == "" || scheme != strings.ToLower(scheme) {
+				return fmt.Errorf("service type %s schemes must be lowercase and non-empty", rule.Type)
+			}
+			if forbiddenSchemes[scheme] {
+				return fmt.Errorf("service type %s cannot allow scheme %s", rule.Type, scheme)
+			}
+		}
+	}
+	return nil
+}
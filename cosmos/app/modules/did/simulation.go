@@ -0,0 +1,351 @@
+package did
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"math/rand"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	simappparams "github.com/cosmos/cosmos-sdk/simapp/params"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/kv"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	simtypes "github.com/cosmos/cosmos-sdk/types/simulation"
+	"github.com/cosmos/cosmos-sdk/x/simulation"
+)
+
+var _ module.AppModuleSimulation = AppModule{}
+
+// Simulation operation weight keys, overridable through the simulator's
+// params file.
+const (
+	OpWeightMsgCreateDID     = "op_weight_msg_create_did"
+	OpWeightMsgUpdateDID     = "op_weight_msg_update_did"
+	OpWeightMsgRotateKey     = "op_weight_msg_rotate_key"
+	OpWeightMsgDeactivateDID = "op_weight_msg_deactivate_did"
+)
+
+// Default simulation operation weights.
+const (
+	DefaultWeightMsgCreateDID     = 100
+	DefaultWeightMsgUpdateDID     = 60
+	DefaultWeightMsgRotateKey     = 40
+	DefaultWeightMsgDeactivateDID = 10
+)
+
+// GenerateGenesisState creates randomized params and a DID controlled by
+// roughly half of the simulation accounts.
+func (AppModule) GenerateGenesisState(simState *module.SimulationState) {
+	params := DefaultParams()
+	simState.AppParams.GetOrGenerate(simState.Cdc, string(KeyCreateDIDFee), &params.CreateDIDFee, simState.Rand,
+		func(r *rand.Rand) {
+			params.CreateDIDFee = sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, int64(r.Intn(1000))))
+		},
+	)
+	simState.AppParams.GetOrGenerate(simState.Cdc, string(KeyBurnCreateDIDFee), &params.BurnCreateDIDFee, simState.Rand,
+		func(r *rand.Rand) { params.BurnCreateDIDFee = r.Intn(2) == 0 },
+	)
+	simState.AppParams.GetOrGenerate(simState.Cdc, string(KeyFeeCommunityPoolShare), &params.FeeCommunityPoolShare, simState.Rand,
+		func(r *rand.Rand) { params.FeeCommunityPoolShare = sdk.NewDecWithPrec(int64(r.Intn(51)), 2) },
+	)
+	simState.AppParams.GetOrGenerate(simState.Cdc, string(KeyFeeBurnShare), &params.FeeBurnShare, simState.Rand,
+		func(r *rand.Rand) { params.FeeBurnShare = sdk.NewDecWithPrec(int64(r.Intn(51)), 2) },
+	)
+	simState.AppParams.GetOrGenerate(simState.Cdc, string(KeyMaxDocumentSize), &params.MaxDocumentSize, simState.Rand,
+		func(r *rand.Rand) { params.MaxDocumentSize = randomMaxDocumentSize(r) },
+	)
+	simState.AppParams.GetOrGenerate(simState.Cdc, string(KeyChangeExpiryBlocks), &params.ChangeExpiryBlocks, simState.Rand,
+		func(r *rand.Rand) { params.ChangeExpiryBlocks = uint64(simtypes.RandIntBetween(r, 10, 1000)) },
+	)
+
+	gs := GenesisState{Params: params}
+	for _, acc := range simState.Accounts {
+		if simState.Rand.Intn(2) == 0 {
+			gs.DIDs = append(gs.DIDs, randomDocument(simState.Rand, acc.Address))
+		}
+	}
+	simState.GenState[ModuleName] = simState.Cdc.MustMarshalJSON(&gs)
+}
+
+// ProposalContents returns no governance proposal contents; the DID module
+// defines no proposals.
+func (AppModule) ProposalContents(_ module.SimulationState) []simtypes.WeightedProposalContent {
+	return nil
+}
+
+// RandomizedParams returns param changes the simulator may propose through
+// governance.
+func (AppModule) RandomizedParams(_ *rand.Rand) []simtypes.ParamChange {
+	return []simtypes.ParamChange{
+		simulation.NewSimParamChange(ModuleName, string(KeyMaxDocumentSize), func(r *rand.Rand) string {
+			return fmt.Sprintf("\"%d\"", randomMaxDocumentSize(r))
+		}),
+		simulation.NewSimParamChange(ModuleName, string(KeyDocumentGasPerByte), func(r *rand.Rand) string {
+			return fmt.Sprintf("\"%d\"", simtypes.RandIntBetween(r, 1, 50))
+		}),
+		simulation.NewSimParamChange(ModuleName, string(KeyChangeExpiryBlocks), func(r *rand.Rand) string {
+			return fmt.Sprintf("\"%d\"", simtypes.RandIntBetween(r, 10, 1000))
+		}),
+	}
+}
+
+// RegisterStoreDecoder registers a decoder for the DID store so the
+// simulator can print differing values when comparing exported stores.
+func (am AppModule) RegisterStoreDecoder(sdr sdk.StoreDecoderRegistry) {
+	sdr[StoreKey] = func(kvA, kvB kv.Pair) string {
+		switch {
+		case bytes.HasPrefix(kvA.Key, DocumentPrefix):
+			var a, b DIDDocument
+			am.keeper.cdc.MustUnmarshal(kvA.Value, &a)
+			am.keeper.cdc.MustUnmarshal(kvB.Value, &b)
+			return fmt.Sprintf("%v\n%v", a, b)
+		case bytes.HasPrefix(kvA.Key, AuditPrefix):
+			var a, b AuditEntry
+			am.keeper.cdc.MustUnmarshal(kvA.Value, &a)
+			am.keeper.cdc.MustUnmarshal(kvB.Value, &b)
+			return fmt.Sprintf("%v\n%v", a, b)
+		case bytes.HasPrefix(kvA.Key, TxHistoryPrefix):
+			var a, b TxRecord
+			am.keeper.cdc.MustUnmarshal(kvA.Value, &a)
+			am.keeper.cdc.MustUnmarshal(kvB.Value, &b)
+			return fmt.Sprintf("%v\n%v", a, b)
+		case bytes.HasPrefix(kvA.Key, ActivityPrefix):
+			var a, b ActivityRecord
+			am.keeper.cdc.MustUnmarshal(kvA.Value, &a)
+			am.keeper.cdc.MustUnmarshal(kvB.Value, &b)
+			return fmt.Sprintf("%v\n%v", a, b)
+		case bytes.HasPrefix(kvA.Key, CredentialStatusPrefix):
+			var a, b CredentialStatus
+			am.keeper.cdc.MustUnmarshal(kvA.Value, &a)
+			am.keeper.cdc.MustUnmarshal(kvB.Value, &b)
+			return fmt.Sprintf("%v\n%v", a, b)
+		case bytes.HasPrefix(kvA.Key, StatusListEntryPrefix):
+			var a, b StatusListEntry
+			am.keeper.cdc.MustUnmarshal(kvA.Value, &a)
+			am.keeper.cdc.MustUnmarshal(kvB.Value, &b)
+			return fmt.Sprintf("%v\n%v", a, b)
+		case bytes.HasPrefix(kvA.Key, PresentationDefinitionPrefix):
+			var a, b PresentationDefinitionRecord
+			am.keeper.cdc.MustUnmarshal(kvA.Value, &a)
+			am.keeper.cdc.MustUnmarshal(kvB.Value, &b)
+			return fmt.Sprintf("%v\n%v", a, b)
+		case bytes.HasPrefix(kvA.Key, HashAnchorPrefix):
+			var a, b HashAnchor
+			am.keeper.cdc.MustUnmarshal(kvA.Value, &a)
+			am.keeper.cdc.MustUnmarshal(kvB.Value, &b)
+			return fmt.Sprintf("%v\n%v", a, b)
+		case bytes.HasPrefix(kvA.Key, CredentialRootPrefix):
+			var a, b CredentialRoot
+			am.keeper.cdc.MustUnmarshal(kvA.Value, &a)
+			am.keeper.cdc.MustUnmarshal(kvB.Value, &b)
+			return fmt.Sprintf("%v\n%v", a, b)
+		case bytes.Equal(kvA.Key, StatsKey):
+			var a, b RegistryStats
+			am.keeper.cdc.MustUnmarshal(kvA.Value, &a)
+			am.keeper.cdc.MustUnmarshal(kvB.Value, &b)
+			return fmt.Sprintf("%v\n%v", a, b)
+		case bytes.HasPrefix(kvA.Key, CommitmentPrefix):
+			var a, b DIDCommitment
+			am.keeper.cdc.MustUnmarshal(kvA.Value, &a)
+			am.keeper.cdc.MustUnmarshal(kvB.Value, &b)
+			return fmt.Sprintf("%v\n%v", a, b)
+		default:
+			return fmt.Sprintf("%X\n%X", kvA.Value, kvB.Value)
+		}
+	}
+}
+
+// WeightedOperations returns the DID module's simulation operations.
+func (am AppModule) WeightedOperations(simState module.SimulationState) []simtypes.WeightedOperation {
+	var weightCreate, weightUpdate, weightRotate, weightDeactivate int
+	simState.AppParams.GetOrGenerate(simState.Cdc, OpWeightMsgCreateDID, &weightCreate, nil,
+		func(_ *rand.Rand) { weightCreate = DefaultWeightMsgCreateDID },
+	)
+	simState.AppParams.GetOrGenerate(simState.Cdc, OpWeightMsgUpdateDID, &weightUpdate, nil,
+		func(_ *rand.Rand) { weightUpdate = DefaultWeightMsgUpdateDID },
+	)
+	simState.AppParams.GetOrGenerate(simState.Cdc, OpWeightMsgRotateKey, &weightRotate, nil,
+		func(_ *rand.Rand) { weightRotate = DefaultWeightMsgRotateKey },
+	)
+	simState.AppParams.GetOrGenerate(simState.Cdc, OpWeightMsgDeactivateDID, &weightDeactivate, nil,
+		func(_ *rand.Rand) { weightDeactivate = DefaultWeightMsgDeactivateDID },
+	)
+
+	return []simtypes.WeightedOperation{
+		simulation.NewWeightedOperation(weightCreate, SimulateMsgCreateDID(am.keeper, am.accountKeeper)),
+		simulation.NewWeightedOperation(weightUpdate, SimulateMsgUpdateDID(am.keeper, am.accountKeeper)),
+		simulation.NewWeightedOperation(weightRotate, SimulateMsgRotateKey(am.keeper, am.accountKeeper)),
+		simulation.NewWeightedOperation(weightDeactivate, SimulateMsgDeactivateDID(am.keeper, am.accountKeeper)),
+	}
+}
+
+// SimulateMsgCreateDID registers a new key-derived DID controlled by a random
+// account.
+func SimulateMsgCreateDID(k Keeper, ak AccountKeeper) simtypes.Operation {
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simtypes.Account, _ string) (simtypes.OperationMsg, []simtypes.FutureOperation, error) {
+		acc, _ := simtypes.RandomAcc(r, accs)
+		doc := randomDocument(r, acc.Address)
+		msg := &MsgCreateDID{
+			ID:                  doc.ID,
+			PublicKey:           doc.PublicKey,
+			Authentication:      doc.Authentication,
+			VerificationMethods: doc.VerificationMethods,
+			Services:            doc.Services,
+			Controller:          doc.Controller,
+			Creator:             acc.Address,
+		}
+		return deliverSimMsg(r, app, ctx, k, ak, acc, msg, k.GetParams(ctx).CreateDIDFee)
+	}
+}
+
+// SimulateMsgUpdateDID replaces the services of a random account-controlled
+// DID.
+func SimulateMsgUpdateDID(k Keeper, ak AccountKeeper) simtypes.Operation {
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simtypes.Account, _ string) (simtypes.OperationMsg, []simtypes.FutureOperation, error) {
+		msgType := sdk.MsgTypeURL(&MsgUpdateDID{})
+		did, acc, ok := randomControlledDID(r, ctx, k, accs)
+		if !ok {
+			return simtypes.NoOpMsg(ModuleName, msgType, "no account-controlled DID"), nil, nil
+		}
+		msg := &MsgUpdateDID{
+			ID:                  did.ID,
+			PublicKey:           did.PublicKey,
+			Authentication:      did.Authentication,
+			VerificationMethods: did.VerificationMethods,
+			KeyAgreement:        did.KeyAgreement,
+			Services:            randomServices(r, did.ID),
+			Controller:          did.Controller,
+			ControllerThreshold: did.ControllerThreshold,
+			RotationDelay:       did.RotationDelay,
+			ExpiresAt:           did.ExpiresAt,
+			Context:             did.Context,
+			ContentCID:          did.ContentCID,
+			Operations:          []string{AuthzOperationUpdateServices},
+			Nonce:               did.Nonce,
+			Signer:              acc.Address.String(),
+			Creator:             acc.Address,
+		}
+		return deliverSimMsg(r, app, ctx, k, ak, acc, msg, nil)
+	}
+}
+
+// SimulateMsgRotateKey rotates the key of a random account-controlled DID,
+// sometimes setting a rotation delay that time-locks later rotations.
+func SimulateMsgRotateKey(k Keeper, ak AccountKeeper) simtypes.Operation {
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simtypes.Account, _ string) (simtypes.OperationMsg, []simtypes.FutureOperation, error) {
+		msgType := sdk.MsgTypeURL(&MsgRotateKey{})
+		did, acc, ok := randomControlledDID(r, ctx, k, accs)
+		if !ok {
+			return simtypes.NoOpMsg(ModuleName, msgType, "no account-controlled DID"), nil, nil
+		}
+		if _, found := k.GetPendingKeyRotation(ctx, did.ID); found {
+			return simtypes.NoOpMsg(ModuleName, msgType, "key rotation already pending"), nil, nil
+		}
+		var delay uint64
+		if r.Intn(4) == 0 {
+			delay = uint64(simtypes.RandIntBetween(r, 1, 20))
+		}
+		msg := &MsgRotateKey{
+			DID:           did.ID,
+			NewPublicKey:  base64.StdEncoding.EncodeToString(randomEd25519Key(r)),
+			RotationDelay: delay,
+			Nonce:         did.Nonce,
+			Signer:        acc.Address.String(),
+			Creator:       acc.Address,
+		}
+		return deliverSimMsg(r, app, ctx, k, ak, acc, msg, nil)
+	}
+}
+
+// SimulateMsgDeactivateDID deactivates a random account-controlled DID.
+func SimulateMsgDeactivateDID(k Keeper, ak AccountKeeper) simtypes.Operation {
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simtypes.Account, _ string) (simtypes.OperationMsg, []simtypes.FutureOperation, error) {
+		msgType := sdk.MsgTypeURL(&MsgDeactivateDID{})
+		did, acc, ok := randomControlledDID(r, ctx, k, accs)
+		if !ok {
+			return simtypes.NoOpMsg(ModuleName, msgType, "no account-controlled DID"), nil, nil
+		}
+		msg := &MsgDeactivateDID{
+			ID:      did.ID,
+			Nonce:   did.Nonce,
+			Signer:  acc.Address.String(),
+			Creator: acc.Address,
+		}
+		return deliverSimMsg(r, app, ctx, k, ak, acc, msg, nil)
+	}
+}
+
+// deliverSimMsg signs msg with acc, pays a random fee from what the account
+// has left after spent and delivers it.
+func deliverSimMsg(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, k Keeper, ak AccountKeeper, acc simtypes.Account, msg sdk.Msg, spent sdk.Coins) (simtypes.OperationMsg, []simtypes.FutureOperation, error) {
+	return simulation.GenAndDeliverTxWithRandFees(simulation.OperationInput{
+		R:               r,
+		App:             app,
+		TxGen:           simappparams.MakeTestEncodingConfig().TxConfig,
+		Msg:             msg,
+		MsgType:         sdk.MsgTypeURL(msg),
+		CoinsSpentInMsg: spent,
+		Context:         ctx,
+		SimAccount:      acc,
+		AccountKeeper:   ak,
+		Bankkeeper:      k.bankKeeper,
+		ModuleName:      ModuleName,
+	})
+}
+
+// randomControlledDID picks an active, singly-controlled DID whose
+// controller is one of the simulation accounts.
+func randomControlledDID(r *rand.Rand, ctx sdk.Context, k Keeper, accs []simtypes.Account) (DIDDocument, simtypes.Account, bool) {
+	var candidates []DIDDocument
+	for _, did := range k.GetAllDIDs(ctx) {
+		if !did.Deactivated && len(did.Controller) == 1 && did.ControllerThreshold <= 1 {
+			candidates = append(candidates, did)
+		}
+	}
+	r.Shuffle(len(candidates), func(i, j int) { candidates[i], candidates[j] = candidates[j], candidates[i] })
+	for _, did := range candidates {
+		addr, err := sdk.AccAddressFromBech32(did.Controller[0])
+		if err != nil {
+			continue
+		}
+		if acc, found := simtypes.FindAccount(accs, addr); found {
+			return did, acc, true
+		}
+	}
+	return DIDDocument{}, simtypes.Account{}, false
+}
+
+// randomDocument returns a key-derived DID document controlled by addr.
+func randomDocument(r *rand.Rand, addr sdk.AccAddress) DIDDocument {
+	pub := randomEd25519Key(r)
+	multibase := EncodeMultibaseKey(MulticodecEd25519Pub, pub)
+	id := DIDMethodPrefix + multibase
+	doc := DocumentFromKey(id, multibase, pub)
+	doc.Services = randomServices(r, id)
+	doc.Controller = []string{addr.String()}
+	return doc
+}
+
+func randomServices(r *rand.Rand, id string) []Service {
+	services := make([]Service, r.Intn(3))
+	for i := range services {
+		services[i] = Service{
+			ID:              fmt.Sprintf("%s#service-%d", id, i),
+			Type:            "LinkedDomains",
+			ServiceEndpoint: "https://" + simtypes.RandStringOfLength(r, 10) + ".example",
+		}
+	}
+	return services
+}
+
+func randomEd25519Key(r *rand.Rand) ed25519.PublicKey {
+	seed := make([]byte, ed25519.SeedSize)
+	r.Read(seed)
+	return ed25519.NewKeyFromSeed(seed).Public().(ed25519.PublicKey)
+}
+
+func randomMaxDocumentSize(r *rand.Rand) uint64 {
+	return uint64(simtypes.RandIntBetween(r, 4*1024, 64*1024))
+}
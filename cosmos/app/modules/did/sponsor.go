@@ -0,0 +1,77 @@
+package did
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// MsgSponsorDID registers a DID on behalf of a user who holds no tokens. The
+// sponsor signs the transaction and pays the CreateDIDFee, while the user
+// consents to the document by signing ProofSignBytes with the document's
+// Ed25519 key, so a sponsor can't register documents the user didn't approve.
+type MsgSponsorDID struct {
+	Document  DIDDocument    `protobuf:"bytes,1,opt,name=document,proto3" json:"document"`
+	Signature []byte         `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	Sponsor   sdk.AccAddress `protobuf:"bytes,3,opt,name=sponsor,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"sponsor"`
+}
+
+// ValidateBasic performs basic validation of MsgSponsorDID, including the
+// user's signature over the document.
+func (msg MsgSponsorDID) ValidateBasic() error {
+	if err := validateChecksums(msg); err != nil {
+		return err
+	}
+	if msg.Document.ID == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("DID ID cannot be empty")
+	}
+	if msg.Document.Deactivated {
+		return sdkerrors.ErrUnknownRequest.Wrap("Cannot sponsor a deactivated DID")
+	}
+	if msg.Sponsor.Empty() {
+		return sdkerrors.ErrUnknownRequest.Wrap("Sponsor cannot be empty")
+	}
+	if err := validateDocumentContent(msg.Document); err != nil {
+		return err
+	}
+	if err := checkKeyDerivedID(msg.Document.ID, msg.Document.PublicKey); err != nil {
+		return sdkerrors.ErrUnknownRequest.Wrap(err.Error())
+	}
+	pub, err := msg.Document.Ed25519PublicKey()
+	if err != nil {
+		return sdkerrors.ErrUnknownRequest.Wrap(err.Error())
+	}
+	if !ed25519.Verify(pub, msg.ProofSignBytes(), msg.Signature) {
+		return sdkerrors.ErrUnauthorized.Wrap("invalid signature over the sponsored document")
+	}
+	return nil
+}
+
+// ProofSignBytes returns the bytes the user signs to consent to the document:
+// the sorted JSON encoding of the document and sponsor.
+func (msg MsgSponsorDID) ProofSignBytes() []byte {
+	msg.Signature = nil
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// Route returns the message route.
+func (msg MsgSponsorDID) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgSponsorDID) Type() string { return "sponsor_did" }
+
+// GetSignBytes returns the canonical bytes to sign over.
+func (msg MsgSponsorDID) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners returns the account that must sign the message.
+func (msg MsgSponsorDID) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sponsor}
+}
@@ -0,0 +1,13 @@
+package did
+
+// RegistryStats counts the registry's contents: every DID document stored,
+// split into active and deactivated ones, the credentials anchored with a
+// status list entry and the credentials revoked. The counters are updated
+// with the records they count, so reading them costs a single store read.
+type RegistryStats struct {
+	TotalDIDs           uint64 `protobuf:"varint,1,opt,name=total_dids,proto3" json:"total_dids"`
+	ActiveDIDs          uint64 `protobuf:"varint,2,opt,name=active_dids,proto3" json:"active_dids"`
+	DeactivatedDIDs     uint64 `protobuf:"varint,3,opt,name=deactivated_dids,proto3" json:"deactivated_dids"`
+	CredentialsAnchored uint64 `protobuf:"varint,4,opt,name=credentials_anchored,proto3" json:"credentials_anchored"`
+	Revocations         uint64 `protobuf:"varint,5,opt,name=revocations,proto3" json:"revocations"`
+}
@@ -0,0 +1,78 @@
+package did
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GetStats returns the registry counters.
+func (k Keeper) GetStats(ctx sdk.Context) RegistryStats {
+	var stats RegistryStats
+	if value := ctx.KVStore(k.storeKey).Get(StatsKey); value != nil {
+		k.cdc.MustUnmarshal(value, &stats)
+	}
+	return stats
+}
+
+func (k Keeper) setStats(ctx sdk.Context, stats RegistryStats) {
+	ctx.KVStore(k.storeKey).Set(StatsKey, k.cdc.MustMarshal(&stats))
+}
+
+func (k Keeper) updateStats(ctx sdk.Context, update func(*RegistryStats)) {
+	stats := k.GetStats(ctx)
+	update(&stats)
+	k.setStats(ctx, stats)
+}
+
+// countDIDWrite updates the DID counters for a document written over
+// existing, nil for a new document.
+func (k Keeper) countDIDWrite(ctx sdk.Context, existing *DIDDocument, did DIDDocument) {
+	if existing != nil && existing.Deactivated == did.Deactivated {
+		return
+	}
+	k.updateStats(ctx, func(stats *RegistryStats) {
+		switch {
+		case existing == nil:
+			stats.TotalDIDs++
+		case existing.Deactivated:
+			stats.DeactivatedDIDs--
+		default:
+			stats.ActiveDIDs--
+		}
+		if did.Deactivated {
+			stats.DeactivatedDIDs++
+		} else {
+			stats.ActiveDIDs++
+		}
+	})
+}
+
+// countStats counts the registry's records one by one, as the stats
+// migration and StatsInvariant do.
+func (k Keeper) countStats(ctx sdk.Context) RegistryStats {
+	var stats RegistryStats
+	store := ctx.KVStore(k.storeKey)
+	iteratePrefix(store, DocumentPrefix, func(_, value []byte) bool {
+		var did DIDDocument
+		k.cdc.MustUnmarshal(value, &did)
+		stats.TotalDIDs++
+		if did.Deactivated {
+			stats.DeactivatedDIDs++
+		} else {
+			stats.ActiveDIDs++
+		}
+		return false
+	})
+	iteratePrefix(store, StatusListEntryPrefix, func(_, _ []byte) bool {
+		stats.CredentialsAnchored++
+		return false
+	})
+	iteratePrefix(store, CredentialStatusPrefix, func(_, value []byte) bool {
+		var status CredentialStatus
+		k.cdc.MustUnmarshal(value, &status)
+		if status.Revoked {
+			stats.Revocations++
+		}
+		return false
+	})
+	return stats
+}
@@ -0,0 +1,117 @@
+package did
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// Status purposes of a status list entry.
+const (
+	StatusPurposeRevocation = "revocation"
+	StatusPurposeSuspension = "suspension"
+)
+
+// statusListResourceTypes are the resource types a status list entry can
+// point into.
+var statusListResourceTypes = []string{"StatusList2021", "BitstringStatusList", AnonCredsStatusListType}
+
+// StatusListEntry indexes a credential by ID: the bit at StatusListIndex of
+// the status list resource at StatusListCredential, a DID URL into the
+// issuer's resources, holds the credential's status for StatusPurpose.
+// Verifiers resolve it with the credential status query, without knowing the
+// status list URL and index themselves.
+type StatusListEntry struct {
+	CredentialID         string `protobuf:"bytes,1,opt,name=credential_id,proto3" json:"credential_id"`
+	Issuer               string `protobuf:"bytes,2,opt,name=issuer,proto3" json:"issuer"`
+	StatusListCredential string `protobuf:"bytes,3,opt,name=status_list_credential,proto3" json:"status_list_credential"`
+	StatusListIndex      uint64 `protobuf:"varint,4,opt,name=status_list_index,proto3" json:"status_list_index"`
+	StatusPurpose        string `protobuf:"bytes,5,opt,name=status_purpose,proto3" json:"status_purpose"`
+	Registered           int64  `protobuf:"varint,6,opt,name=registered,proto3" json:"registered"`
+}
+
+// validateStatusListEntry checks the fields shared by status list entries and
+// the messages that register them.
+func validateStatusListEntry(credentialID, issuer, statusList, purpose string) error {
+	if credentialID == "" || issuer == "" {
+		return fmt.Errorf("credential ID and issuer cannot be empty")
+	}
+	did, _, err := ParseResourceURL(statusList)
+	if err != nil {
+		return err
+	}
+	if did != issuer {
+		return fmt.Errorf("status list %s is not a resource of issuer %s", statusList, issuer)
+	}
+	if purpose != StatusPurposeRevocation && purpose != StatusPurposeSuspension {
+		return fmt.Errorf("status purpose must be %s or %s", StatusPurposeRevocation, StatusPurposeSuspension)
+	}
+	return nil
+}
+
+func isStatusListResourceType(resourceType string) bool {
+	for _, t := range statusListResourceTypes {
+		if t == resourceType {
+			return true
+		}
+	}
+	return false
+}
+
+// MsgRegisterCredentialStatus indexes a credential issued by Issuer under its
+// status list entry. A controller of Issuer authorizes it like a
+// MsgUpdateDID.
+type MsgRegisterCredentialStatus struct {
+	CredentialID         string         `protobuf:"bytes,1,opt,name=credential_id,proto3" json:"credential_id"`
+	Issuer               string         `protobuf:"bytes,2,opt,name=issuer,proto3" json:"issuer"`
+	StatusListCredential string         `protobuf:"bytes,3,opt,name=status_list_credential,proto3" json:"status_list_credential"`
+	StatusListIndex      uint64         `protobuf:"varint,4,opt,name=status_list_index,proto3" json:"status_list_index"`
+	StatusPurpose        string         `protobuf:"bytes,5,opt,name=status_purpose,proto3" json:"status_purpose"`
+	Nonce                uint64         `protobuf:"varint,6,opt,name=nonce,proto3" json:"nonce"`
+	Signer               string         `protobuf:"bytes,7,opt,name=signer,proto3" json:"signer"`
+	Signature            []byte         `protobuf:"bytes,8,opt,name=signature,proto3" json:"signature,omitempty"`
+	Creator              sdk.AccAddress `protobuf:"bytes,9,opt,name=creator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"creator"`
+}
+
+// ValidateBasic performs basic validation of MsgRegisterCredentialStatus.
+func (msg MsgRegisterCredentialStatus) ValidateBasic() error {
+	if err := validateChecksums(msg); err != nil {
+		return err
+	}
+	if err := validateStatusListEntry(msg.CredentialID, msg.Issuer, msg.StatusListCredential, msg.StatusPurpose); err != nil {
+		return sdkerrors.ErrUnknownRequest.Wrap(err.Error())
+	}
+	if msg.Signer == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Signer cannot be empty")
+	}
+	return nil
+}
+
+// ProofSignBytes returns the bytes a controller DID signs to authorize the
+// registration.
+func (msg MsgRegisterCredentialStatus) ProofSignBytes() []byte {
+	msg.Signature = nil
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// Route returns the message route.
+func (msg MsgRegisterCredentialStatus) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgRegisterCredentialStatus) Type() string { return "register_credential_status" }
+
+// GetSignBytes returns the canonical bytes to sign over.
+func (msg MsgRegisterCredentialStatus) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners returns the account that must sign the message.
+func (msg MsgRegisterCredentialStatus) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
@@ -0,0 +1,81 @@
+package did
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// RegisterStatusListEntry indexes a credential under its status list entry.
+// The status list must be a status list resource of the issuer, and a
+// credential and an index of a status list are registered once.
+func (k Keeper) RegisterStatusListEntry(ctx sdk.Context, entry StatusListEntry) error {
+	if err := validateStatusListEntry(entry.CredentialID, entry.Issuer, entry.StatusListCredential, entry.StatusPurpose); err != nil {
+		return err
+	}
+	if _, found := k.GetStatusListEntry(ctx, entry.CredentialID); found {
+		return fmt.Errorf("credential %s already has a status list entry", entry.CredentialID)
+	}
+	if other, found := k.CredentialAtStatusIndex(ctx, entry.StatusListCredential, entry.StatusListIndex); found {
+		return fmt.Errorf("index %d of %s is taken by credential %s", entry.StatusListIndex, entry.StatusListCredential, other)
+	}
+	did, id, _ := ParseResourceURL(entry.StatusListCredential)
+	res, err := k.GetResource(ctx, did, id)
+	if err != nil {
+		return fmt.Errorf("status list %s: %w", entry.StatusListCredential, err)
+	}
+	if !isStatusListResourceType(res.ResourceType) {
+		return fmt.Errorf("resource %s of type %s is not a status list", entry.StatusListCredential, res.ResourceType)
+	}
+	entry.Registered = ctx.BlockTime().Unix()
+	k.setStatusListEntry(ctx, entry)
+	ctx.EventManager().EmitEvent(sdk.NewEvent("credential_status_registered",
+		sdk.NewAttribute("credential_id", entry.CredentialID),
+		sdk.NewAttribute("issuer", entry.Issuer),
+		sdk.NewAttribute("status_list_credential", entry.StatusListCredential),
+		sdk.NewAttribute("status_list_index", fmt.Sprintf("%d", entry.StatusListIndex)),
+	))
+	return nil
+}
+
+// GetStatusListEntry returns the status list entry of a credential.
+func (k Keeper) GetStatusListEntry(ctx sdk.Context, credentialID string) (StatusListEntry, bool) {
+	value := ctx.KVStore(k.storeKey).Get(statusListEntryKey(credentialID))
+	if value == nil {
+		return StatusListEntry{}, false
+	}
+	var entry StatusListEntry
+	k.cdc.MustUnmarshal(value, &entry)
+	return entry, true
+}
+
+// GetAllStatusListEntries returns every status list entry in the store.
+func (k Keeper) GetAllStatusListEntries(ctx sdk.Context) []StatusListEntry {
+	var entries []StatusListEntry
+	iteratePrefix(ctx.KVStore(k.storeKey), StatusListEntryPrefix, func(_, value []byte) bool {
+		var entry StatusListEntry
+		k.cdc.MustUnmarshal(value, &entry)
+		entries = append(entries, entry)
+		return false
+	})
+	return entries
+}
+
+// CredentialAtStatusIndex returns the ID of the credential registered at index
+// of a status list.
+func (k Keeper) CredentialAtStatusIndex(ctx sdk.Context, statusList string, index uint64) (string, bool) {
+	value := ctx.KVStore(k.storeKey).Get(statusListIndexKey(statusList, index))
+	if value == nil {
+		return "", false
+	}
+	return string(value), true
+}
+
+func (k Keeper) setStatusListEntry(ctx sdk.Context, entry StatusListEntry) {
+	store := ctx.KVStore(k.storeKey)
+	if !store.Has(statusListEntryKey(entry.CredentialID)) {
+		k.updateStats(ctx, func(stats *RegistryStats) { stats.CredentialsAnchored++ })
+	}
+	store.Set(statusListEntryKey(entry.CredentialID), k.cdc.MustMarshal(&entry))
+	store.Set(statusListIndexKey(entry.StatusListCredential, entry.StatusListIndex), []byte(entry.CredentialID))
+}
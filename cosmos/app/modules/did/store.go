@@ -0,0 +1,340 @@
+package did
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// The DID store is split into four sections so no record can collide with
+// another kind of record, whatever its ID:
+//
+//	documents/  DID documents, keyed by DID
+//	metadata/   records attached to a DID or credential: credential status,
+//	            commitments, resources, recovery guardians, account links,
+//	            linked assets and pending operations
+//	indexes/    queues and lookups derived from documents and metadata
+//	history/    append-only logs
+var (
+	DocumentPrefix = []byte("documents/")
+	MetadataPrefix = []byte("metadata/")
+	IndexPrefix    = []byte("indexes/")
+	HistoryPrefix  = []byte("history/")
+)
+
+// Metadata prefixes.
+var (
+	CredentialStatusPrefix       = section(MetadataPrefix, "credential-status/")
+	CommitmentPrefix             = section(MetadataPrefix, "commitment/")
+	DigestPrefix                 = section(MetadataPrefix, "digest/")
+	ResourcePrefix               = section(MetadataPrefix, "resource/")
+	RecoveryConfigPrefix         = section(MetadataPrefix, "recovery-config/")
+	PendingRecoveryPrefix        = section(MetadataPrefix, "recovery/")
+	PendingKeyRotationPrefix     = section(MetadataPrefix, "key-rotation/")
+	PendingChangePrefix          = section(MetadataPrefix, "pending-change/")
+	PendingChangeSeqKey          = section(MetadataPrefix, "pending-change-seq")
+	AccountLinkPrefix            = section(MetadataPrefix, "account-link/")
+	CapabilityGrantPrefix        = section(MetadataPrefix, "capability/")
+	LinkedAssetPrefix            = section(MetadataPrefix, "linked-asset/")
+	DepositPrefix                = section(MetadataPrefix, "deposit/")
+	FeeEscrowPrefix              = section(MetadataPrefix, "fee-escrow/")
+	StatusListEntryPrefix        = section(MetadataPrefix, "status-list-entry/")
+	PresentationDefinitionPrefix = section(MetadataPrefix, "presentation-definition/")
+	HashAnchorPrefix             = section(MetadataPrefix, "hash-anchor/")
+	CredentialRootPrefix         = section(MetadataPrefix, "credential-root/")
+	// StatsKey holds the registry counters.
+	StatsKey = section(MetadataPrefix, "stats")
+)
+
+// Index prefixes. Queues are ordered by a big-endian height or unix time
+// following the prefix.
+var (
+	// ExpiryQueuePrefix indexes documents with an expiry by expiry time, so
+	// the end blocker only visits the DIDs that are due.
+	ExpiryQueuePrefix         = section(IndexPrefix, "did-expiry/")
+	KeyRotationQueuePrefix    = section(IndexPrefix, "key-rotation-queue/")
+	RecoveryQueuePrefix       = section(IndexPrefix, "recovery-queue/")
+	PendingChangeExpiryPrefix = section(IndexPrefix, "pending-change-expiry/")
+	// PendingChangeIDPrefix indexes pending changes, which are keyed by DID,
+	// by change ID, so a change can be approved by its ID alone.
+	PendingChangeIDPrefix = section(IndexPrefix, "pending-change-id/")
+	ResourceLatestPrefix  = section(IndexPrefix, "resource-latest/")
+	// Capability grants are queued by expiry height and by expiry time;
+	// a grant with both is in both queues.
+	CapabilityHeightQueuePrefix = section(IndexPrefix, "capability-height-queue/")
+	CapabilityTimeQueuePrefix   = section(IndexPrefix, "capability-time-queue/")
+	// DIDAccountsPrefix indexes account links by DID, the reverse of
+	// AccountLinkPrefix.
+	DIDAccountsPrefix = section(IndexPrefix, "did-accounts/")
+	// AssetLinksPrefix indexes linked assets by class and asset ID, so a
+	// change to an asset reaches the DIDs that linked it.
+	AssetLinksPrefix = section(IndexPrefix, "asset-links/")
+	// LinkedAssetCursorKey holds the key of the last linked asset the end
+	// blocker re-verified.
+	LinkedAssetCursorKey = section(IndexPrefix, "linked-asset-cursor")
+	// DepositQueuePrefix queues registration deposits by maturity height.
+	DepositQueuePrefix = section(IndexPrefix, "deposit-queue/")
+	// StatusListIndexPrefix indexes status list entries by status list and
+	// index, the reverse of StatusListEntryPrefix.
+	StatusListIndexPrefix = section(IndexPrefix, "status-list-index/")
+	// GovDeactivationPendingKey holds a passed DeactivateDIDProposal between
+	// its handler and the gov hook that applies it, within one end blocker.
+	GovDeactivationPendingKey = section(IndexPrefix, "gov-deactivation-pending")
+)
+
+// History prefixes.
+var (
+	AuditPrefix = section(HistoryPrefix, "audit/")
+	// TxHistoryPrefix logs the transactions affecting each DID by height
+	// and transaction hash.
+	TxHistoryPrefix = section(HistoryPrefix, "tx/")
+	// ActivityPrefix logs the transactions each account or controller
+	// performed by height and transaction hash.
+	ActivityPrefix = section(HistoryPrefix, "activity/")
+)
+
+func section(prefix []byte, name string) []byte {
+	return append(append([]byte{}, prefix...), name...)
+}
+
+func didKey(id string) []byte {
+	return section(DocumentPrefix, id)
+}
+
+func digestKey(did string) []byte {
+	return section(DigestPrefix, did)
+}
+
+func commitmentKey(did string) []byte {
+	return section(CommitmentPrefix, did)
+}
+
+func credentialStatusKey(id string) []byte {
+	return section(CredentialStatusPrefix, id)
+}
+
+func statusListEntryKey(credentialID string) []byte {
+	return section(StatusListEntryPrefix, credentialID)
+}
+
+func statusListIndexKey(statusList string, index uint64) []byte {
+	return append(section(StatusListIndexPrefix, statusList+"/"), sdk.Uint64ToBigEndian(index)...)
+}
+
+func presentationDefinitionsPrefix(verifier string) []byte {
+	return section(PresentationDefinitionPrefix, verifier+"/")
+}
+
+func presentationDefinitionKey(verifier, id string) []byte {
+	return section(PresentationDefinitionPrefix, verifier+"/"+id)
+}
+
+func hashAnchorsPrefix(hash []byte) []byte {
+	return section(HashAnchorPrefix, hex.EncodeToString(hash)+"/")
+}
+
+func hashAnchorKey(hash []byte, did string) []byte {
+	return section(HashAnchorPrefix, hex.EncodeToString(hash)+"/"+did)
+}
+
+func credentialRootKey(issuer string, root []byte) []byte {
+	return section(CredentialRootPrefix, issuer+"/"+hex.EncodeToString(root))
+}
+
+func resourceCollectionKey(did string) []byte {
+	return section(ResourcePrefix, did+"/")
+}
+
+func resourceKey(did, id string) []byte {
+	return append(resourceCollectionKey(did), []byte(id)...)
+}
+
+func resourceLatestKey(did, name, resourceType string) []byte {
+	return section(ResourceLatestPrefix, did+"/"+name+"/"+resourceType)
+}
+
+func recoveryConfigKey(did string) []byte {
+	return section(RecoveryConfigPrefix, did)
+}
+
+func pendingRecoveryKey(did string) []byte {
+	return section(PendingRecoveryPrefix, did)
+}
+
+func pendingKeyRotationKey(did string) []byte {
+	return section(PendingKeyRotationPrefix, did)
+}
+
+func pendingChangesKey(did string) []byte {
+	return section(PendingChangePrefix, did+"/")
+}
+
+func pendingChangeKey(did string, id uint64) []byte {
+	return append(pendingChangesKey(did), sdk.Uint64ToBigEndian(id)...)
+}
+
+func pendingChangeIDKey(id uint64) []byte {
+	return append(append([]byte{}, PendingChangeIDPrefix...), sdk.Uint64ToBigEndian(id)...)
+}
+
+func accountLinkKey(addr sdk.AccAddress) []byte {
+	return append(append([]byte{}, AccountLinkPrefix...), addr...)
+}
+
+func didAccountsPrefix(did string) []byte {
+	return section(DIDAccountsPrefix, did+"/")
+}
+
+func didAccountKey(did string, addr sdk.AccAddress) []byte {
+	return append(didAccountsPrefix(did), addr...)
+}
+
+func capabilityGrantsPrefix(did string) []byte {
+	return section(CapabilityGrantPrefix, did+"/")
+}
+
+func granteeCapabilitiesPrefix(did, grantee string) []byte {
+	return section(CapabilityGrantPrefix, did+"/"+grantee+"/")
+}
+
+// capabilityGrantKey keys grants by DID, grantee and capability. None of
+// them can contain '/', so the key splits unambiguously.
+func capabilityGrantKey(did, grantee, capability string) []byte {
+	return section(CapabilityGrantPrefix, capabilityGrantSuffix(did, grantee, capability))
+}
+
+func capabilityGrantSuffix(did, grantee, capability string) string {
+	return did + "/" + grantee + "/" + capability
+}
+
+func capabilityHeightQueueKey(grant CapabilityGrant) []byte {
+	return queueKey(CapabilityHeightQueuePrefix, grant.ExpiresHeight, []byte(capabilityGrantSuffix(grant.DID, grant.Grantee, grant.Capability)))
+}
+
+func capabilityTimeQueueKey(grant CapabilityGrant) []byte {
+	return queueKey(CapabilityTimeQueuePrefix, grant.ExpiresAt, []byte(capabilityGrantSuffix(grant.DID, grant.Grantee, grant.Capability)))
+}
+
+func linkedAssetsPrefix(did string) []byte {
+	return section(LinkedAssetPrefix, did+"/")
+}
+
+// linkedAssetKey keys linked assets by DID, class and asset ID. Neither the
+// DID nor the class can contain '/'; asset IDs such as IBC denoms can, so
+// they come last.
+func linkedAssetKey(did, class, assetID string) []byte {
+	return section(LinkedAssetPrefix, did+"/"+class+"/"+assetID)
+}
+
+func assetLinksPrefix(class, assetID string) []byte {
+	return section(AssetLinksPrefix, class+"/"+assetID+"/")
+}
+
+func assetLinkKey(class, assetID, did string) []byte {
+	return append(assetLinksPrefix(class, assetID), did...)
+}
+
+func depositKey(did string) []byte {
+	return section(DepositPrefix, did)
+}
+
+func feeEscrowKey(did string) []byte {
+	return section(FeeEscrowPrefix, did)
+}
+
+func depositQueueKey(height int64, did string) []byte {
+	return queueKey(DepositQueuePrefix, height, []byte(did))
+}
+
+// auditPrefix returns the prefix of a DID's audit entries. Entries are keyed
+// by document version, which every write advances, so they iterate oldest
+// first.
+func auditPrefix(did string) []byte {
+	return section(AuditPrefix, did+"/")
+}
+
+func auditKey(did string, version uint64) []byte {
+	return append(auditPrefix(did), sdk.Uint64ToBigEndian(version)...)
+}
+
+func txHistoryPrefix(did string) []byte {
+	return section(TxHistoryPrefix, did+"/")
+}
+
+func txHistoryKey(did string, height int64, txHash string) []byte {
+	key := append(txHistoryPrefix(did), sdk.Uint64ToBigEndian(uint64(height))...)
+	return append(key, txHash...)
+}
+
+func activityPrefix(actor string) []byte {
+	return section(ActivityPrefix, actor+"/")
+}
+
+func activityKey(actor string, height int64, txHash string) []byte {
+	key := append(activityPrefix(actor), sdk.Uint64ToBigEndian(uint64(height))...)
+	return append(key, txHash...)
+}
+
+func queueKey(prefix []byte, at int64, suffix []byte) []byte {
+	key := append(append([]byte{}, prefix...), sdk.Uint64ToBigEndian(uint64(at))...)
+	return append(key, suffix...)
+}
+
+func expiryQueueKey(expiresAt int64, did string) []byte {
+	return queueKey(ExpiryQueuePrefix, expiresAt, []byte(did))
+}
+
+func keyRotationQueueKey(height int64, did string) []byte {
+	return queueKey(KeyRotationQueuePrefix, height, []byte(did))
+}
+
+func recoveryQueueKey(height int64, did string) []byte {
+	return queueKey(RecoveryQueuePrefix, height, []byte(did))
+}
+
+func pendingChangeExpiryKey(height int64, id uint64) []byte {
+	return queueKey(PendingChangeExpiryPrefix, height, sdk.Uint64ToBigEndian(id))
+}
+
+// iteratePrefix calls f with the key, stripped of prefix, and value of each
+// entry under prefix in key order until f returns true. f must not write to
+// the store.
+func iteratePrefix(store sdk.KVStore, prefix []byte, f func(key, value []byte) (stop bool)) {
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		if f(iterator.Key()[len(prefix):], iterator.Value()) {
+			return
+		}
+	}
+}
+
+// queueEntry is a queue key split into its height or time and the rest of
+// the key.
+type queueEntry struct {
+	at     int64
+	suffix []byte
+}
+
+// dueEntries returns the entries of the queue under prefix ordered at or
+// before due. The iterator is closed before it returns, so callers are free
+// to modify the store while processing them.
+func dueEntries(store sdk.KVStore, prefix []byte, due int64) []queueEntry {
+	iterator := store.Iterator(prefix, queueKey(prefix, due+1, nil))
+	defer iterator.Close()
+
+	var entries []queueEntry
+	for ; iterator.Valid(); iterator.Next() {
+		entries = append(entries, splitQueueKey(iterator.Key()[len(prefix):]))
+	}
+	return entries
+}
+
+// splitQueueKey splits a queue key stripped of its prefix.
+func splitQueueKey(key []byte) queueEntry {
+	return queueEntry{
+		at:     int64(binary.BigEndian.Uint64(key[:8])),
+		suffix: append([]byte{}, key[8:]...),
+	}
+}
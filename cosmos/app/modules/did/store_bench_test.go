@@ -0,0 +1,96 @@
+package did
+
+import (
+	"fmt"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// benchSizes are the registry sizes the store benchmarks prefill. The
+// largest is skipped with -short.
+var benchSizes = []int{1_000, 10_000, 100_000, 1_000_000}
+
+// benchKeeper returns a keeper over an in-memory IAVL store holding n DID
+// documents.
+func benchKeeper(b *testing.B, n int) (Keeper, sdk.Context) {
+	b.Helper()
+	k, ctx, cms := setupKeeper(b, nil, nil)
+
+	// Prefill directly rather than through CreateDID so the setup cost of
+	// the larger sizes stays in seconds.
+	kv := ctx.KVStore(k.storeKey)
+	for i := 0; i < n; i++ {
+		did := benchDocument(i)
+		kv.Set(didKey(did.ID), k.cdc.MustMarshal(&did))
+	}
+	cms.Commit()
+	return k, ctx
+}
+
+func benchDocument(i int) DIDDocument {
+	id := fmt.Sprintf("%sbench%d", DIDMethodPrefix, i)
+	return DIDDocument{
+		ID:             id,
+		PublicKey:      "z6MkhaXgBZDvotDkL5257faiztiGiC2QtKLGpbnnEGta2doK",
+		Authentication: id + "#key-1",
+		Controller:     []string{id},
+	}
+}
+
+func BenchmarkGetDID(b *testing.B) {
+	for _, n := range benchSizes {
+		b.Run(fmt.Sprintf("dids=%d", n), func(b *testing.B) {
+			if n >= 1_000_000 && testing.Short() {
+				b.Skip("skipping the largest registry in short mode")
+			}
+			k, ctx := benchKeeper(b, n)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := k.GetDID(ctx, benchDocument(i%n).ID); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkCreateDID(b *testing.B) {
+	for _, n := range benchSizes {
+		b.Run(fmt.Sprintf("dids=%d", n), func(b *testing.B) {
+			if n >= 1_000_000 && testing.Short() {
+				b.Skip("skipping the largest registry in short mode")
+			}
+			k, ctx := benchKeeper(b, n)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := k.CreateDID(ctx, benchDocument(n+i)); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkIterateDocuments(b *testing.B) {
+	for _, n := range benchSizes[:3] {
+		b.Run(fmt.Sprintf("dids=%d", n), func(b *testing.B) {
+			k, ctx := benchKeeper(b, n)
+			kv := ctx.KVStore(k.storeKey)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				seen := 0
+				iteratePrefix(kv, DocumentPrefix, func(_, _ []byte) bool {
+					seen++
+					return false
+				})
+				if seen != n {
+					b.Fatalf("iterated %d documents, want %d", seen, n)
+				}
+			}
+		})
+	}
+}
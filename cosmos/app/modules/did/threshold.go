@@ -0,0 +1,127 @@
+package did
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+
+	"golang.org/x/crypto/bn256"
+)
+
+// MulticodecBN256G2Pub prefixes the G2 public keys of BN256BLSThresholdKey
+// methods. No multicodec is registered for the curve, so it is taken from the
+// private-use range.
+const MulticodecBN256G2Pub uint64 = 0x30b256
+
+// blsDST separates the module's BLS message hashes from other uses of the
+// curve.
+const blsDST = "AYTCH-DID-V01-BN256G1-SHA256-TAI"
+
+// bn256P is the base field modulus of bn256. It is 3 mod 4, so square roots
+// are a single exponentiation.
+var (
+	bn256P       = mustBigInt("65000549695646603732796438742359905742825358107623003571877145026864184071783")
+	bn256SqrtExp = new(big.Int).Rsh(new(big.Int).Add(bn256P, big.NewInt(1)), 2)
+)
+
+func mustBigInt(s string) *big.Int {
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		panic("invalid big integer " + s)
+	}
+	return n
+}
+
+// isThresholdKey reports whether a verification method type is the group
+// key of a threshold signing scheme.
+func isThresholdKey(keyType string) bool {
+	return keyType == FROSTEd25519Key || keyType == BN256BLSThresholdKey
+}
+
+// checkThreshold checks the threshold and participant counts of a method.
+// Only threshold keys carry them; the chain can't see how many participants
+// took part in an aggregated signature, so they describe the signing group
+// to verifiers rather than being enforced.
+func (vm VerificationMethod) checkThreshold() error {
+	if !isThresholdKey(vm.Type) {
+		if vm.Threshold != 0 || vm.Participants != 0 {
+			return fmt.Errorf("verification method %s: only threshold keys have a threshold", vm.ID)
+		}
+		return nil
+	}
+	if vm.Threshold == 0 || vm.Threshold > vm.Participants {
+		return fmt.Errorf("verification method %s: threshold must be between 1 and the number of participants", vm.ID)
+	}
+	return nil
+}
+
+// VerifyThresholdSignature checks an aggregated signature over msg by the
+// group key of a threshold method. FROST(Ed25519, SHA-512) signatures are
+// plain Ed25519 signatures; BLS signatures are G1 points.
+func VerifyThresholdSignature(vm VerificationMethod, msg, sig []byte) error {
+	key, err := vm.PublicKeyBytes()
+	if err != nil {
+		return err
+	}
+	switch vm.Type {
+	case FROSTEd25519Key:
+		if !ed25519.Verify(key, msg, sig) {
+			return fmt.Errorf("invalid FROST signature from %s", vm.ID)
+		}
+		return nil
+	case BN256BLSThresholdKey:
+		return verifyBLS(key, msg, sig)
+	}
+	return fmt.Errorf("verification method %s is not a threshold key", vm.ID)
+}
+
+// verifyBLS checks that sig is a BLS signature over msg by the G2 key pub:
+// e(sig, g2) = e(H(msg), pub).
+func verifyBLS(pub, msg, sig []byte) error {
+	pk, ok := new(bn256.G2).Unmarshal(pub)
+	if !ok {
+		return fmt.Errorf("invalid BLS public key")
+	}
+	s, ok := new(bn256.G1).Unmarshal(sig)
+	if !ok {
+		return fmt.Errorf("invalid BLS signature")
+	}
+	h, err := hashToG1(msg)
+	if err != nil {
+		return err
+	}
+	g2 := new(bn256.G2).ScalarBaseMult(big.NewInt(1))
+	if !bytes.Equal(bn256.Pair(s, g2).Marshal(), bn256.Pair(h, pk).Marshal()) {
+		return fmt.Errorf("invalid BLS signature")
+	}
+	return nil
+}
+
+// hashToG1 hashes msg onto G1 by try-and-increment: the first counter for
+// which SHA-256(dst || counter || msg) is the x coordinate of a curve point
+// y² = x³ + 3 gives the point with the smaller y. G1 has cofactor one, so
+// every curve point is in the group. Signers must hash the same way.
+func hashToG1(msg []byte) (*bn256.G1, error) {
+	for ctr := 0; ctr < 256; ctr++ {
+		sum := sha256.Sum256(append(append([]byte(blsDST), byte(ctr)), msg...))
+		x := new(big.Int).Mod(new(big.Int).SetBytes(sum[:]), bn256P)
+		rhs := new(big.Int).Exp(x, big.NewInt(3), bn256P)
+		rhs.Add(rhs, big.NewInt(3)).Mod(rhs, bn256P)
+		y := new(big.Int).Exp(rhs, bn256SqrtExp, bn256P)
+		if new(big.Int).Exp(y, big.NewInt(2), bn256P).Cmp(rhs) != 0 {
+			continue
+		}
+		if neg := new(big.Int).Sub(bn256P, y); neg.Cmp(y) < 0 {
+			y = neg
+		}
+		var point [64]byte
+		x.FillBytes(point[:32])
+		y.FillBytes(point[32:])
+		if g, ok := new(bn256.G1).Unmarshal(point[:]); ok {
+			return g, nil
+		}
+	}
+	return nil, fmt.Errorf("message does not hash to G1")
+}
@@ -0,0 +1,75 @@
+package did
+
+import (
+	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// tracer is a no-op until the node installs a tracer provider, see the
+// tracing package.
+var tracer = otel.Tracer("cosmos-app/modules/did")
+
+// startSpan starts a span as a child of any span carried by ctx and returns
+// ctx with the new span attached so keeper calls nest beneath it.
+func startSpan(ctx sdk.Context, name string, attrs ...attribute.KeyValue) (sdk.Context, trace.Span) {
+	spanCtx, span := tracer.Start(ctx.Context(), name, trace.WithAttributes(attrs...))
+	return ctx.WithContext(spanCtx), span
+}
+
+// endSpan records err on span, if any, and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// traceInterceptor runs next in a span named after the method. Requests
+// arriving over gRPC continue the trace context in their metadata, and the
+// span is attached to the SDK context so handler and keeper spans nest
+// beneath it.
+func traceInterceptor(goCtx context.Context, req interface{}, info *grpc.UnaryServerInfo, next grpc.UnaryHandler) (interface{}, error) {
+	ctx, ok := goCtx.Value(sdk.SdkContextKey).(sdk.Context)
+	if !ok {
+		return next(goCtx, req)
+	}
+	parent := ctx.Context()
+	if md, ok := metadata.FromIncomingContext(goCtx); ok {
+		parent = otel.GetTextMapPropagator().Extract(parent, metadataCarrier(md))
+	}
+	spanCtx, span := tracer.Start(parent, info.FullMethod,
+		trace.WithAttributes(attribute.Int64("block.height", ctx.BlockHeight())))
+	res, err := next(context.WithValue(goCtx, sdk.SdkContextKey, ctx.WithContext(spanCtx)), req)
+	endSpan(span, err)
+	return res, err
+}
+
+// metadataCarrier adapts incoming gRPC metadata to a propagation carrier.
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	if v := metadata.MD(c).Get(key); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
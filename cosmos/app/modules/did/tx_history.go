@@ -0,0 +1,22 @@
+package did
+
+// TxRecord lists a transaction that affected a DID: one that wrote its
+// document or emitted an event naming it as the DID, issuer or verifier of
+// the operation. Msgs are the type URLs of the transaction's messages that
+// did so. Unlike the audit log, it also covers operations that leave the
+// document untouched, such as resources, account links and revocations, but
+// not EndBlocker writes, which belong to no transaction.
+type TxRecord struct {
+	DID    string   `protobuf:"bytes,1,opt,name=did,proto3" json:"did"`
+	Height int64    `protobuf:"varint,2,opt,name=height,proto3" json:"height"`
+	TxHash string   `protobuf:"bytes,3,opt,name=tx_hash,proto3" json:"tx_hash"`
+	Msgs   []string `protobuf:"bytes,4,rep,name=msgs,proto3" json:"msgs"`
+}
+
+// txHistoryAttributes are the event attributes whose values are DIDs
+// affected by the operation emitting the event.
+var txHistoryAttributes = map[string]bool{
+	AttributeKeyDID: true,
+	"issuer":        true,
+	"verifier":      true,
+}
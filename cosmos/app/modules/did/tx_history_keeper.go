@@ -0,0 +1,79 @@
+package did
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	"google.golang.org/grpc"
+)
+
+// recordTxHistory adds the transaction being executed to the history of
+// every DID named by the events the current message emitted, and to the
+// activity of the accounts and controller that performed the message. It is
+// called once per successfully handled message.
+func (k Keeper) recordTxHistory(ctx sdk.Context) {
+	txBytes := ctx.TxBytes()
+	if len(txBytes) == 0 {
+		return
+	}
+	hash := fmt.Sprintf("%X", tmhash.Sum(txBytes))
+	msg, hasMsg := auditMsg(ctx)
+	msgType := ""
+	if hasMsg {
+		msgType = sdk.MsgTypeURL(msg)
+	}
+	var dids []string
+	seen := make(map[string]bool)
+	store := ctx.KVStore(k.storeKey)
+	for _, e := range ctx.EventManager().Events() {
+		for _, attr := range e.Attributes {
+			did := string(attr.Value)
+			if !txHistoryAttributes[string(attr.Key)] || !strings.HasPrefix(did, DIDMethodPrefix) || seen[did] {
+				continue
+			}
+			seen[did] = true
+			dids = append(dids, did)
+			key := txHistoryKey(did, ctx.BlockHeight(), hash)
+			record := TxRecord{DID: did, Height: ctx.BlockHeight(), TxHash: hash}
+			if value := store.Get(key); value != nil {
+				k.cdc.MustUnmarshal(value, &record)
+			}
+			if msgType != "" && !containsString(record.Msgs, msgType) {
+				record.Msgs = append(record.Msgs, msgType)
+			}
+			store.Set(key, k.cdc.MustMarshal(&record))
+		}
+	}
+	if hasMsg {
+		k.recordActivity(ctx, msg, hash, dids)
+	}
+}
+
+// GetTxHistory returns the transactions that affected a DID, oldest first.
+func (k Keeper) GetTxHistory(ctx sdk.Context, did string) []TxRecord {
+	var records []TxRecord
+	iteratePrefix(ctx.KVStore(k.storeKey), txHistoryPrefix(did), func(_, value []byte) bool {
+		var record TxRecord
+		k.cdc.MustUnmarshal(value, &record)
+		records = append(records, record)
+		return false
+	})
+	return records
+}
+
+// txHistoryInterceptor records the transaction history of each Msg service
+// request that succeeds. It runs inside auditInterceptor, which attaches the
+// message, and after the msg router gave the request its own event manager.
+func (k Keeper) txHistoryInterceptor(goCtx context.Context, req interface{}, _ *grpc.UnaryServerInfo, next grpc.UnaryHandler) (interface{}, error) {
+	res, err := next(goCtx, req)
+	if err != nil {
+		return res, err
+	}
+	if ctx, ok := goCtx.Value(sdk.SdkContextKey).(sdk.Context); ok {
+		k.recordTxHistory(ctx)
+	}
+	return res, nil
+}
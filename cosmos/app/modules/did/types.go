@@ -1,33 +1,385 @@
 package did
 
 import (
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"golang.org/x/crypto/bn256"
+)
+
+// Module identifiers.
+const (
+	ModuleName = "did"
+	StoreKey   = ModuleName
+	RouterKey  = ModuleName
+)
+
+// Verification method and service types understood by the DID module.
+const (
+	Ed25519VerificationKey2020 = "Ed25519VerificationKey2020"
+	X25519KeyAgreementKey2020  = "X25519KeyAgreementKey2020"
+	X509CertificateChainKey    = "X509CertificateChainKey"
+	WebAuthnP256Key            = "WebAuthnP256Key"
+	FROSTEd25519Key            = "FROSTEd25519Key"
+	BN256BLSThresholdKey       = "BN256BLSThresholdKey"
+
+	ServiceTypeDIDCommMessaging = "DIDCommMessaging"
+	DIDCommV2Profile            = "didcomm/v2"
 )
 
 // DIDDocument defines a decentralized identifier document structure.
+// Controller lists the DIDs and account addresses allowed to update or
+// deactivate the document; when empty the DID controls itself. A
+// ControllerThreshold above one requires that many controllers to approve
+// each change. A non-zero RotationDelay time-locks the document's public key:
+// it can only be replaced through MsgRotateKey, which takes effect after that
+// many blocks. A non-zero ExpiresAt (unix seconds) deactivates the DID once
+// that time passes unless it is renewed first. Nonce counts the writes to the
+// document; controller-signed operations must name the current nonce so a
+// signed operation can't be replayed. AlsoKnownAs lists the accounts of other
+// chains, as CAIP-10 account IDs, whose control the DID has proven; only
+// MsgLinkEthereumAccount and MsgUnlinkEthereumAccount change it.
+// DeactivatedByProposal is the ID of the DeactivateDIDProposal that
+// deactivated the DID, if governance did.
 type DIDDocument struct {
-	ID               string   `json:"id"`
-	PublicKey        string   `json:"public_key"`
-	ServiceEndpoints []string `json:"service_endpoints"`
-	Authentication   string   `json:"authentication"`
+	ID                    string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id"`
+	PublicKey             string               `protobuf:"bytes,2,opt,name=public_key,proto3" json:"public_key"`
+	ServiceEndpoints      []string             `protobuf:"bytes,3,rep,name=service_endpoints,proto3" json:"service_endpoints"`
+	Authentication        string               `protobuf:"bytes,4,opt,name=authentication,proto3" json:"authentication"`
+	VerificationMethods   []VerificationMethod `protobuf:"bytes,5,rep,name=verification_methods,proto3" json:"verification_methods"`
+	KeyAgreement          []string             `protobuf:"bytes,6,rep,name=key_agreement,proto3" json:"key_agreement"`
+	Services              []Service            `protobuf:"bytes,7,rep,name=services,proto3" json:"services"`
+	Controller            []string             `protobuf:"bytes,8,rep,name=controller,proto3" json:"controller,omitempty"`
+	Deactivated           bool                 `protobuf:"varint,9,opt,name=deactivated,proto3" json:"deactivated,omitempty"`
+	ControllerThreshold   uint32               `protobuf:"varint,10,opt,name=controller_threshold,proto3" json:"controller_threshold,omitempty"`
+	RotationDelay         uint64               `protobuf:"varint,11,opt,name=rotation_delay,proto3" json:"rotation_delay,omitempty"`
+	ExpiresAt             int64                `protobuf:"varint,12,opt,name=expires_at,proto3" json:"expires_at,omitempty"`
+	Nonce                 uint64               `protobuf:"varint,13,opt,name=nonce,proto3" json:"nonce"`
+	Context               []string             `protobuf:"bytes,14,rep,name=context,proto3" json:"context,omitempty"`
+	ContentCID            string               `protobuf:"bytes,15,opt,name=content_cid,proto3" json:"content_cid,omitempty"`
+	AlsoKnownAs           []string             `protobuf:"bytes,16,rep,name=also_known_as,proto3" json:"also_known_as,omitempty"`
+	DeactivatedByProposal uint64               `protobuf:"varint,17,opt,name=deactivated_by_proposal,proto3" json:"deactivated_by_proposal,omitempty"`
+}
+
+// VerificationMethod defines a public key bound to a DID. WebAuthnP256Key
+// methods hold the compressed P-256 key of a passkey, which can then sign
+// the DID's operations through WebAuthn assertions. Methods of type
+// X509CertificateChainKey carry no multibase key: their key is that of the
+// first certificate of X509CertificateChain, a chain of base64 DER
+// certificates ordered leaf first as in a JWK x5c. FROSTEd25519Key and
+// BN256BLSThresholdKey methods hold the group key of a key split across
+// Participants signers, Threshold of whom produce an aggregated signature.
+type VerificationMethod struct {
+	ID                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id"`
+	Type                 string   `protobuf:"bytes,2,opt,name=type,proto3" json:"type"`
+	Controller           string   `protobuf:"bytes,3,opt,name=controller,proto3" json:"controller"`
+	PublicKeyMultibase   string   `protobuf:"bytes,4,opt,name=public_key_multibase,proto3" json:"public_key_multibase"`
+	X509CertificateChain []string `protobuf:"bytes,5,rep,name=x509_certificate_chain,proto3" json:"x509_certificate_chain,omitempty"`
+	Threshold            uint32   `protobuf:"varint,6,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	Participants         uint32   `protobuf:"varint,7,opt,name=participants,proto3" json:"participants,omitempty"`
+}
+
+// PublicKeyBytes decodes the method's multibase key and checks that its
+// multicodec matches the method type. For X.509 methods it returns the DER
+// SubjectPublicKeyInfo of the leaf certificate.
+func (vm VerificationMethod) PublicKeyBytes() ([]byte, error) {
+	if vm.Type == X509CertificateChainKey {
+		return vm.x509PublicKey()
+	}
+	if len(vm.X509CertificateChain) > 0 {
+		return nil, fmt.Errorf("verification method %s: only %s methods carry a certificate chain", vm.ID, X509CertificateChainKey)
+	}
+	codec, key, err := DecodeMultibaseKey(vm.PublicKeyMultibase)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case vm.Type == Ed25519VerificationKey2020 && codec == MulticodecEd25519Pub && len(key) == ed25519.PublicKeySize:
+		return key, nil
+	case vm.Type == X25519KeyAgreementKey2020 && codec == MulticodecX25519Pub && len(key) == 32:
+		return key, nil
+	case vm.Type == WebAuthnP256Key && codec == MulticodecP256Pub && len(key) == 33:
+		if x, _ := elliptic.UnmarshalCompressed(elliptic.P256(), key); x != nil {
+			return key, nil
+		}
+	case vm.Type == FROSTEd25519Key && codec == MulticodecEd25519Pub && len(key) == ed25519.PublicKeySize:
+		return key, nil
+	case vm.Type == BN256BLSThresholdKey && codec == MulticodecBN256G2Pub && len(key) == 128:
+		if _, ok := new(bn256.G2).Unmarshal(key); ok {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("verification method %s: key does not match type %s", vm.ID, vm.Type)
+}
+
+// Service defines a service endpoint advertised by a DID. RoutingKeys and
+// Accept are only meaningful for DIDCommMessaging services.
+type Service struct {
+	ID              string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id"`
+	Type            string   `protobuf:"bytes,2,opt,name=type,proto3" json:"type"`
+	ServiceEndpoint string   `protobuf:"bytes,3,opt,name=service_endpoint,proto3" json:"service_endpoint"`
+	RoutingKeys     []string `protobuf:"bytes,4,rep,name=routing_keys,proto3" json:"routing_keys,omitempty"`
+	Accept          []string `protobuf:"bytes,5,rep,name=accept,proto3" json:"accept,omitempty"`
+}
+
+// VerificationMethod looks up a verification method by its ID.
+func (d DIDDocument) VerificationMethod(id string) (VerificationMethod, bool) {
+	for _, vm := range d.VerificationMethods {
+		if vm.ID == id {
+			return vm, true
+		}
+	}
+	return VerificationMethod{}, false
+}
+
+// KeyAgreementMethods returns the verification methods referenced by the
+// document's keyAgreement relationship.
+func (d DIDDocument) KeyAgreementMethods() []VerificationMethod {
+	var methods []VerificationMethod
+	for _, id := range d.KeyAgreement {
+		if vm, ok := d.VerificationMethod(id); ok {
+			methods = append(methods, vm)
+		}
+	}
+	return methods
+}
+
+// DIDCommServices returns the document's DIDComm v2 messaging services.
+func (d DIDDocument) DIDCommServices() []Service {
+	var services []Service
+	for _, svc := range d.Services {
+		if svc.Type == ServiceTypeDIDCommMessaging {
+			services = append(services, svc)
+		}
+	}
+	return services
+}
+
+// Controllers returns the DIDs and addresses that control the document.
+func (d DIDDocument) Controllers() []string {
+	if len(d.Controller) == 0 {
+		return []string{d.ID}
+	}
+	return d.Controller
+}
+
+// Ed25519PublicKey decodes the document's base64-encoded Ed25519 public key.
+func (d DIDDocument) Ed25519PublicKey() (ed25519.PublicKey, error) {
+	bz, err := base64.StdEncoding.DecodeString(d.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key encoding: %w", err)
+	}
+	if len(bz) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid public key length: %d", len(bz))
+	}
+	return ed25519.PublicKey(bz), nil
+}
+
+// CredentialStatus records the revocation state of a verifiable credential.
+type CredentialStatus struct {
+	ID        string `protobuf:"bytes,1,opt,name=id,proto3" json:"id"`
+	Issuer    string `protobuf:"bytes,2,opt,name=issuer,proto3" json:"issuer"`
+	Revoked   bool   `protobuf:"varint,3,opt,name=revoked,proto3" json:"revoked"`
+	Reason    string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason"`
+	RevokedAt int64  `protobuf:"varint,5,opt,name=revoked_at,proto3" json:"revoked_at"`
 }
 
 // MsgCreateDID represents a message for creating a DID.
 type MsgCreateDID struct {
-	ID               string   `json:"id"`
-	PublicKey        string   `json:"public_key"`
-	ServiceEndpoints []string `json:"service_endpoints"`
-	Authentication   string   `json:"authentication"`
-	Creator          sdk.AccAddress `json:"creator"`
+	ID                  string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id"`
+	PublicKey           string               `protobuf:"bytes,2,opt,name=public_key,proto3" json:"public_key"`
+	ServiceEndpoints    []string             `protobuf:"bytes,3,rep,name=service_endpoints,proto3" json:"service_endpoints"`
+	Authentication      string               `protobuf:"bytes,4,opt,name=authentication,proto3" json:"authentication"`
+	VerificationMethods []VerificationMethod `protobuf:"bytes,5,rep,name=verification_methods,proto3" json:"verification_methods"`
+	KeyAgreement        []string             `protobuf:"bytes,6,rep,name=key_agreement,proto3" json:"key_agreement"`
+	Services            []Service            `protobuf:"bytes,7,rep,name=services,proto3" json:"services"`
+	Controller          []string             `protobuf:"bytes,9,rep,name=controller,proto3" json:"controller,omitempty"`
+	ControllerThreshold uint32               `protobuf:"varint,10,opt,name=controller_threshold,proto3" json:"controller_threshold,omitempty"`
+	RotationDelay       uint64               `protobuf:"varint,11,opt,name=rotation_delay,proto3" json:"rotation_delay,omitempty"`
+	ExpiresAt           int64                `protobuf:"varint,12,opt,name=expires_at,proto3" json:"expires_at,omitempty"`
+	Context             []string             `protobuf:"bytes,13,rep,name=context,proto3" json:"context,omitempty"`
+	ContentCID          string               `protobuf:"bytes,14,opt,name=content_cid,proto3" json:"content_cid,omitempty"`
+	Creator             sdk.AccAddress       `protobuf:"bytes,8,opt,name=creator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"creator"`
+}
+
+// Document returns the DID document the message creates.
+func (msg MsgCreateDID) Document() DIDDocument {
+	return DIDDocument{
+		ID:                  msg.ID,
+		PublicKey:           msg.PublicKey,
+		ServiceEndpoints:    msg.ServiceEndpoints,
+		Authentication:      msg.Authentication,
+		VerificationMethods: msg.VerificationMethods,
+		KeyAgreement:        msg.KeyAgreement,
+		Services:            msg.Services,
+		Controller:          msg.Controller,
+		ControllerThreshold: msg.ControllerThreshold,
+		RotationDelay:       msg.RotationDelay,
+		ExpiresAt:           msg.ExpiresAt,
+		Context:             msg.Context,
+		ContentCID:          msg.ContentCID,
+	}
 }
 
 // ValidateBasic performs basic validation of MsgCreateDID.
 func (msg MsgCreateDID) ValidateBasic() error {
+	if err := validateChecksums(msg); err != nil {
+		return err
+	}
 	if msg.ID == "" {
-		return sdk.ErrUnknownRequest("DID ID cannot be empty")
+		return sdkerrors.ErrUnknownRequest.Wrap("DID ID cannot be empty")
 	}
 	if msg.PublicKey == "" {
-		return sdk.ErrUnknownRequest("Public Key cannot be empty")
+		return sdkerrors.ErrUnknownRequest.Wrap("Public Key cannot be empty")
+	}
+	if err := checkKeyDerivedID(msg.ID, msg.PublicKey); err != nil {
+		return sdkerrors.ErrUnknownRequest.Wrap(err.Error())
+	}
+	return validateDocumentContent(DIDDocument{
+		ID:                  msg.ID,
+		VerificationMethods: msg.VerificationMethods,
+		KeyAgreement:        msg.KeyAgreement,
+		Services:            msg.Services,
+		Controller:          msg.Controller,
+		ControllerThreshold: msg.ControllerThreshold,
+		Context:             msg.Context,
+		ContentCID:          msg.ContentCID,
+	})
+}
+
+// validateDocumentContent checks the parts of a document supplied by a create
+// or update message.
+func validateDocumentContent(doc DIDDocument) error {
+	if len(doc.AlsoKnownAs) > 0 {
+		return sdkerrors.ErrUnknownRequest.Wrap("alsoKnownAs can only be changed by linking accounts")
+	}
+	if doc.ContentCID != "" {
+		if err := ValidateCID(doc.ContentCID); err != nil {
+			return sdkerrors.ErrUnknownRequest.Wrap(err.Error())
+		}
+	}
+	for _, uri := range doc.Context {
+		if uri == "" {
+			return sdkerrors.ErrUnknownRequest.Wrap("JSON-LD context cannot be empty")
+		}
+	}
+	for _, vm := range doc.VerificationMethods {
+		if _, err := vm.PublicKeyBytes(); err != nil {
+			return sdkerrors.ErrUnknownRequest.Wrap(err.Error())
+		}
+		if err := vm.checkThreshold(); err != nil {
+			return sdkerrors.ErrUnknownRequest.Wrap(err.Error())
+		}
+	}
+	for _, id := range doc.KeyAgreement {
+		vm, ok := doc.VerificationMethod(id)
+		if !ok {
+			return sdkerrors.ErrUnknownRequest.Wrapf("key agreement method %s is not defined", id)
+		}
+		if vm.Type != X25519KeyAgreementKey2020 {
+			return sdkerrors.ErrUnknownRequest.Wrapf("key agreement method %s must be of type %s", id, X25519KeyAgreementKey2020)
+		}
+	}
+	for _, svc := range doc.Services {
+		if svc.ID == "" || svc.Type == "" || svc.ServiceEndpoint == "" {
+			return sdkerrors.ErrUnknownRequest.Wrap("Service ID, type and endpoint cannot be empty")
+		}
+		if svc.Type == ServiceTypeDIDCommMessaging && len(doc.KeyAgreementMethods()) == 0 {
+			return sdkerrors.ErrUnknownRequest.Wrap("DIDCommMessaging services require a key agreement method")
+		}
+		if IsEncryptedEndpoint(svc.ServiceEndpoint) {
+			if err := checkEncryptedEndpoint(doc, svc.ServiceEndpoint); err != nil {
+				return sdkerrors.ErrUnknownRequest.Wrapf("service %s: %s", svc.ID, err)
+			}
+		}
+	}
+	for _, c := range doc.Controller {
+		if c == "" {
+			return sdkerrors.ErrUnknownRequest.Wrap("Controller cannot be empty")
+		}
+	}
+	if int(doc.ControllerThreshold) > len(doc.Controllers()) {
+		return sdkerrors.ErrUnknownRequest.Wrapf("Controller threshold %d exceeds number of controllers", doc.ControllerThreshold)
 	}
 	return nil
 }
+
+// Route returns the message route.
+func (msg MsgCreateDID) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgCreateDID) Type() string { return "create_did" }
+
+// GetSignBytes returns the canonical bytes to sign over: a readable summary
+// of the document that hardware wallets can display, bound to the full
+// message by its digest.
+func (msg MsgCreateDID) GetSignBytes() []byte {
+	sd := newDocumentSignDoc(msg.Document(), msg.Creator, sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg)))
+	return documentSignBytes("did/CreateDID", sd)
+}
+
+// GetSigners returns the account that must sign the message.
+func (msg MsgCreateDID) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
+
+// MsgRevokeCredential represents a message for revoking a verifiable credential.
+// A controller of Issuer authorizes it like a MsgUpdateDID.
+type MsgRevokeCredential struct {
+	ID        string         `protobuf:"bytes,1,opt,name=id,proto3" json:"id"`
+	Issuer    string         `protobuf:"bytes,2,opt,name=issuer,proto3" json:"issuer"`
+	Reason    string         `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason"`
+	Creator   sdk.AccAddress `protobuf:"bytes,4,opt,name=creator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"creator"`
+	Nonce     uint64         `protobuf:"varint,5,opt,name=nonce,proto3" json:"nonce"`
+	Signer    string         `protobuf:"bytes,6,opt,name=signer,proto3" json:"signer"`
+	Signature []byte         `protobuf:"bytes,7,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+// ValidateBasic performs basic validation of MsgRevokeCredential.
+func (msg MsgRevokeCredential) ValidateBasic() error {
+	if err := validateChecksums(msg); err != nil {
+		return err
+	}
+	if msg.ID == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Credential ID cannot be empty")
+	}
+	if msg.Issuer == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Issuer DID cannot be empty")
+	}
+	if msg.Signer == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Signer cannot be empty")
+	}
+	return nil
+}
+
+// ProofSignBytes returns the bytes a controller DID signs to authorize the
+// revocation.
+func (msg MsgRevokeCredential) ProofSignBytes() []byte {
+	msg.Signature = nil
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// Route returns the message route.
+func (msg MsgRevokeCredential) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgRevokeCredential) Type() string { return "revoke_credential" }
+
+// GetSignBytes returns the canonical bytes to sign over.
+func (msg MsgRevokeCredential) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners returns the account that must sign the message.
+func (msg MsgRevokeCredential) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
@@ -0,0 +1,311 @@
+package did
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	grpc1 "github.com/gogo/protobuf/grpc"
+	"github.com/gogo/protobuf/proto"
+	abci "github.com/tendermint/tendermint/abci/types"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DIDEvent reports a write to a DID document. TxHash is empty for writes made
+// by the EndBlocker, such as applied key rotations and expiries.
+type DIDEvent struct {
+	DID         string   `protobuf:"bytes,1,opt,name=did,proto3" json:"did"`
+	Operation   string   `protobuf:"bytes,2,opt,name=operation,proto3" json:"operation"`
+	Version     uint64   `protobuf:"varint,3,opt,name=version,proto3" json:"version"`
+	Controllers []string `protobuf:"bytes,4,rep,name=controllers,proto3" json:"controllers,omitempty"`
+	Height      int64    `protobuf:"varint,5,opt,name=height,proto3" json:"height"`
+	TxHash      string   `protobuf:"bytes,6,opt,name=tx_hash,proto3" json:"tx_hash,omitempty"`
+}
+
+func (m *DIDEvent) Reset()         { *m = DIDEvent{} }
+func (m *DIDEvent) String() string { return proto.CompactTextString(m) }
+func (*DIDEvent) ProtoMessage()    {}
+
+// WatchDIDRequest selects the events of one DID, of every DID listing
+// Controller as a controller, or both.
+type WatchDIDRequest struct {
+	DID        string `protobuf:"bytes,1,opt,name=did,proto3" json:"did"`
+	Controller string `protobuf:"bytes,2,opt,name=controller,proto3" json:"controller"`
+}
+
+func (m *WatchDIDRequest) Reset()         { *m = WatchDIDRequest{} }
+func (m *WatchDIDRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchDIDRequest) ProtoMessage()    {}
+
+// Validate checks that the request selects something and that its values
+// can be embedded in a Tendermint event query.
+func (m WatchDIDRequest) Validate() error {
+	if m.DID == "" && m.Controller == "" {
+		return fmt.Errorf("a DID or a controller is required")
+	}
+	if strings.ContainsRune(m.DID, '\'') || strings.ContainsRune(m.Controller, '\'') {
+		return fmt.Errorf("invalid DID or controller")
+	}
+	return nil
+}
+
+func (m WatchDIDRequest) matches(ev DIDEvent) bool {
+	if m.DID != "" && ev.DID != m.DID {
+		return false
+	}
+	if m.Controller == "" {
+		return true
+	}
+	for _, c := range ev.Controllers {
+		if c == m.Controller {
+			return true
+		}
+	}
+	return false
+}
+
+// Watcher streams DID events from a node's Tendermint event bus. Events are
+// pushed as soon as the block containing them is committed, so relying
+// parties can drop cached documents right after a key rotation.
+type Watcher struct {
+	events rpcclient.EventsClient
+}
+
+// NewWatcher creates a watcher over a Tendermint RPC client.
+func NewWatcher(events rpcclient.EventsClient) *Watcher {
+	return &Watcher{events: events}
+}
+
+var watchSubscribers uint64
+
+// Watch subscribes to the events selected by req. The channel is closed once
+// ctx is done.
+func (w *Watcher) Watch(ctx context.Context, req WatchDIDRequest) (<-chan DIDEvent, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	filter := fmt.Sprintf("%s.%s='%s'", EventTypeDIDChanged, AttributeKeyDID, req.DID)
+	if req.DID == "" {
+		filter = fmt.Sprintf("%s.%s='%s'", EventTypeDIDChanged, AttributeKeyController, req.Controller)
+	}
+	return w.subscribe(ctx, filter, req.matches)
+}
+
+// WatchAll subscribes to the events of every DID, for caches fronting the
+// whole registry. The channel is closed once ctx is done, or if the node
+// drops the subscription.
+func (w *Watcher) WatchAll(ctx context.Context) (<-chan DIDEvent, error) {
+	filter := fmt.Sprintf("%s.%s EXISTS", EventTypeDIDChanged, AttributeKeyDID)
+	return w.subscribe(ctx, filter, func(DIDEvent) bool { return true })
+}
+
+// subscribe streams the events of the Tendermint event filter for which match
+// holds.
+func (w *Watcher) subscribe(ctx context.Context, filter string, match func(DIDEvent) bool) (<-chan DIDEvent, error) {
+	// Transactions and the EndBlocker both write documents; their events
+	// arrive on different Tendermint events.
+	queries := []string{
+		fmt.Sprintf("%s='%s' AND %s", tmtypes.EventTypeKey, tmtypes.EventTx, filter),
+		fmt.Sprintf("%s='%s' AND %s", tmtypes.EventTypeKey, tmtypes.EventNewBlock, filter),
+	}
+	subscriber := fmt.Sprintf("did-watch-%d", atomic.AddUint64(&watchSubscribers, 1))
+
+	var subs []<-chan ctypes.ResultEvent
+	for _, q := range queries {
+		sub, err := w.events.Subscribe(ctx, subscriber, q)
+		if err != nil {
+			w.events.UnsubscribeAll(context.Background(), subscriber)
+			return nil, err
+		}
+		subs = append(subs, sub)
+	}
+
+	out := make(chan DIDEvent, 16)
+	go func() {
+		defer close(out)
+		defer w.events.UnsubscribeAll(context.Background(), subscriber)
+		for {
+			var (
+				res ctypes.ResultEvent
+				ok  bool
+			)
+			select {
+			case <-ctx.Done():
+				return
+			case res, ok = <-subs[0]:
+			case res, ok = <-subs[1]:
+			}
+			if !ok {
+				return
+			}
+			for _, ev := range didEvents(res.Data) {
+				if !match(ev) {
+					continue
+				}
+				select {
+				case out <- ev:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out, nil
+}
+
+// didEvents extracts the DID events from a Tendermint event payload.
+func didEvents(data interface{}) []DIDEvent {
+	var (
+		events []abci.Event
+		height int64
+		txHash string
+	)
+	switch d := data.(type) {
+	case tmtypes.EventDataTx:
+		events, height = d.Result.Events, d.Height
+		txHash = fmt.Sprintf("%X", tmtypes.Tx(d.Tx).Hash())
+	case tmtypes.EventDataNewBlock:
+		if d.Block != nil {
+			height = d.Block.Height
+		}
+		events = append(d.ResultBeginBlock.Events, d.ResultEndBlock.Events...)
+	}
+
+	var out []DIDEvent
+	for _, e := range events {
+		if e.Type != EventTypeDIDChanged {
+			continue
+		}
+		ev := DIDEvent{Height: height, TxHash: txHash}
+		for _, attr := range e.Attributes {
+			v := string(attr.Value)
+			switch string(attr.Key) {
+			case AttributeKeyDID:
+				ev.DID = v
+			case AttributeKeyOperation:
+				ev.Operation = v
+			case AttributeKeyVersion:
+				ev.Version, _ = strconv.ParseUint(v, 10, 64)
+			case AttributeKeyController:
+				ev.Controllers = append(ev.Controllers, v)
+			}
+		}
+		out = append(out, ev)
+	}
+	return out
+}
+
+// WatchServer is the server API for the aytch.did.v1.Watch service. Unlike
+// Query it is streaming, so it's registered directly on the node's gRPC
+// server rather than through the query router.
+type WatchServer interface {
+	WatchDID(*WatchDIDRequest, Watch_WatchDIDServer) error
+}
+
+// WatchDID implements WatchServer.
+func (w *Watcher) WatchDID(req *WatchDIDRequest, stream Watch_WatchDIDServer) error {
+	if err := req.Validate(); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	events, err := w.Watch(stream.Context(), *req)
+	if err != nil {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	for ev := range events {
+		ev := ev
+		if err := stream.Send(&ev); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Watch_WatchDIDServer is the server side of a WatchDID stream.
+type Watch_WatchDIDServer interface {
+	Send(*DIDEvent) error
+	grpc.ServerStream
+}
+
+type watchWatchDIDServer struct {
+	grpc.ServerStream
+}
+
+func (x *watchWatchDIDServer) Send(m *DIDEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// RegisterWatchServer registers srv on s.
+func RegisterWatchServer(s grpc1.Server, srv WatchServer) {
+	s.RegisterService(&_Watch_serviceDesc, srv)
+}
+
+// WatchClient is the client API for the aytch.did.v1.Watch service.
+type WatchClient interface {
+	WatchDID(ctx context.Context, in *WatchDIDRequest, opts ...grpc.CallOption) (Watch_WatchDIDClient, error)
+}
+
+type watchClient struct {
+	cc grpc1.ClientConn
+}
+
+// NewWatchClient creates a Watch service client over cc.
+func NewWatchClient(cc grpc1.ClientConn) WatchClient {
+	return &watchClient{cc: cc}
+}
+
+func (c *watchClient) WatchDID(ctx context.Context, in *WatchDIDRequest, opts ...grpc.CallOption) (Watch_WatchDIDClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Watch_serviceDesc.Streams[0], "/aytch.did.v1.Watch/WatchDID", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &watchWatchDIDClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// Watch_WatchDIDClient is the client side of a WatchDID stream.
+type Watch_WatchDIDClient interface {
+	Recv() (*DIDEvent, error)
+	grpc.ClientStream
+}
+
+type watchWatchDIDClient struct {
+	grpc.ClientStream
+}
+
+func (x *watchWatchDIDClient) Recv() (*DIDEvent, error) {
+	m := new(DIDEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Watch_WatchDID_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchDIDRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WatchServer).WatchDID(m, &watchWatchDIDServer{stream})
+}
+
+var _Watch_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "aytch.did.v1.Watch",
+	HandlerType: (*WatchServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{StreamName: "WatchDID", Handler: _Watch_WatchDID_Handler, ServerStreams: true},
+	},
+}
@@ -0,0 +1,179 @@
+package did
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// webAuthnUserPresent is the user present flag of WebAuthn authenticator
+// data.
+const webAuthnUserPresent = 0x01
+
+// WebAuthnAssertion is the MethodSignature of a WebAuthnP256Key method: a
+// WebAuthn assertion in place of a raw signature. The three byte fields are those of the AuthenticatorAssertionResponse the
+// browser returns; the challenge it was asked for is WebAuthnChallenge of the
+// operation's sign bytes.
+type WebAuthnAssertion struct {
+	VerificationMethod string `json:"verificationMethod"`
+	AuthenticatorData  []byte `json:"authenticatorData"`
+	ClientDataJSON     []byte `json:"clientDataJSON"`
+	Signature          []byte `json:"signature"`
+}
+
+// webAuthnClientData holds the fields of the client data the chain checks.
+type webAuthnClientData struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+}
+
+// WebAuthnChallenge returns the challenge a passkey is asked to sign to
+// authorize an operation with the given sign bytes.
+func WebAuthnChallenge(signBytes []byte) []byte {
+	sum := sha256.Sum256(signBytes)
+	return sum[:]
+}
+
+// VerifyWebAuthnAssertion checks that a is an assertion by the passkey of vm
+// over the challenge for signBytes, made with the user present.
+func VerifyWebAuthnAssertion(vm VerificationMethod, signBytes []byte, a WebAuthnAssertion) error {
+	if vm.Type != WebAuthnP256Key {
+		return fmt.Errorf("verification method %s is not a %s", vm.ID, WebAuthnP256Key)
+	}
+	key, err := vm.PublicKeyBytes()
+	if err != nil {
+		return err
+	}
+	x, y := elliptic.UnmarshalCompressed(elliptic.P256(), key)
+	pub := &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}
+
+	var clientData webAuthnClientData
+	if err := json.Unmarshal(a.ClientDataJSON, &clientData); err != nil {
+		return fmt.Errorf("invalid WebAuthn client data: %w", err)
+	}
+	if clientData.Type != "webauthn.get" {
+		return fmt.Errorf("WebAuthn client data type is %q, not webauthn.get", clientData.Type)
+	}
+	if clientData.Challenge != base64.RawURLEncoding.EncodeToString(WebAuthnChallenge(signBytes)) {
+		return fmt.Errorf("WebAuthn assertion is for another challenge")
+	}
+	// rpIdHash (32 bytes), flags (1 byte) and signCount (4 bytes).
+	if len(a.AuthenticatorData) < 37 {
+		return fmt.Errorf("WebAuthn authenticator data too short")
+	}
+	if a.AuthenticatorData[32]&webAuthnUserPresent == 0 {
+		return fmt.Errorf("WebAuthn assertion was made without user presence")
+	}
+	clientDataHash := sha256.Sum256(a.ClientDataJSON)
+	digest := sha256.Sum256(append(append([]byte{}, a.AuthenticatorData...), clientDataHash[:]...))
+	if !ecdsa.VerifyASN1(pub, digest[:], a.Signature) {
+		return fmt.Errorf("invalid WebAuthn signature from %s", vm.ID)
+	}
+	return nil
+}
+
+// P256KeyFromCOSE converts the COSE_Key of a WebAuthn credential, as found
+// in its attested credential data, into the multibase form of a
+// WebAuthnP256Key method. Only ES256 keys on P-256 are accepted.
+func P256KeyFromCOSE(cose []byte) (string, error) {
+	m, err := decodeCOSEKey(cose)
+	if err != nil {
+		return "", err
+	}
+	// kty 2 (EC2), alg -7 (ES256), crv 1 (P-256).
+	if m[1] != int64(2) || m[3] != int64(-7) || m[-1] != int64(1) {
+		return "", fmt.Errorf("COSE key is not an ES256 P-256 key")
+	}
+	xb, _ := m[-2].([]byte)
+	yb, _ := m[-3].([]byte)
+	if len(xb) != 32 || len(yb) != 32 {
+		return "", fmt.Errorf("COSE key coordinates must be 32 bytes")
+	}
+	uncompressed := append(append([]byte{4}, xb...), yb...)
+	x, y := elliptic.Unmarshal(elliptic.P256(), uncompressed)
+	if x == nil {
+		return "", fmt.Errorf("COSE key is not a point on P-256")
+	}
+	return EncodeMultibaseKey(MulticodecP256Pub, elliptic.MarshalCompressed(elliptic.P256(), x, y)), nil
+}
+
+// decodeCOSEKey decodes the CBOR map of a COSE_Key, whose labels are
+// integers and whose values are integers, byte strings or text strings.
+func decodeCOSEKey(bz []byte) (map[int64]interface{}, error) {
+	major, n, rest, err := readCBORHead(bz)
+	if err != nil {
+		return nil, err
+	}
+	if major != cborMap {
+		return nil, fmt.Errorf("COSE key is not a CBOR map")
+	}
+	m := make(map[int64]interface{}, n)
+	for i := uint64(0); i < n; i++ {
+		var label, value interface{}
+		if label, rest, err = readCOSEValue(rest); err != nil {
+			return nil, err
+		}
+		if value, rest, err = readCOSEValue(rest); err != nil {
+			return nil, err
+		}
+		l, ok := label.(int64)
+		if !ok {
+			return nil, fmt.Errorf("COSE key label must be an integer")
+		}
+		m[l] = value
+	}
+	return m, nil
+}
+
+func readCOSEValue(bz []byte) (interface{}, []byte, error) {
+	major, arg, rest, err := readCBORHead(bz)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch major {
+	case cborUnsigned:
+		return int64(arg), rest, nil
+	case cborNegative:
+		return -1 - int64(arg), rest, nil
+	case cborBytes, cborText:
+		if uint64(len(rest)) < arg {
+			return nil, nil, fmt.Errorf("truncated CBOR string")
+		}
+		if major == cborText {
+			return string(rest[:arg]), rest[arg:], nil
+		}
+		return rest[:arg], rest[arg:], nil
+	}
+	return nil, nil, fmt.Errorf("unsupported CBOR major type %d in COSE key", major>>5)
+}
+
+// readCBORHead reads the head of a CBOR data item, returning its major type
+// and argument.
+func readCBORHead(bz []byte) (byte, uint64, []byte, error) {
+	if len(bz) == 0 {
+		return 0, 0, nil, fmt.Errorf("truncated CBOR")
+	}
+	major, info := bz[0]&0xe0, bz[0]&0x1f
+	bz = bz[1:]
+	if info < 24 {
+		return major, uint64(info), bz, nil
+	}
+	if info > 27 {
+		return 0, 0, nil, fmt.Errorf("unsupported CBOR length encoding")
+	}
+	size := 1 << (info - 24)
+	if len(bz) < size {
+		return 0, 0, nil, fmt.Errorf("truncated CBOR")
+	}
+	var arg uint64
+	for _, b := range bz[:size] {
+		arg = arg<<8 | uint64(b)
+	}
+	if arg > 1<<62 {
+		return 0, 0, nil, fmt.Errorf("CBOR argument too large")
+	}
+	return major, arg, bz[size:], nil
+}
@@ -0,0 +1,137 @@
+package did
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// X509TrustRoot is a root certificate authority whose certificates may be
+// bound to DIDs, such as a qualified trust service provider on an eIDAS
+// trusted list. Certificate is the DER encoding of the root.
+type X509TrustRoot struct {
+	Name        string `protobuf:"bytes,1,opt,name=name,proto3" json:"name"`
+	Certificate []byte `protobuf:"bytes,2,opt,name=certificate,proto3" json:"certificate"`
+}
+
+// Certificates decodes the method's x509CertificateChain, leaf first.
+func (vm VerificationMethod) Certificates() ([]*x509.Certificate, error) {
+	if len(vm.X509CertificateChain) == 0 {
+		return nil, fmt.Errorf("verification method %s: x509CertificateChain cannot be empty", vm.ID)
+	}
+	certs := make([]*x509.Certificate, len(vm.X509CertificateChain))
+	for i, enc := range vm.X509CertificateChain {
+		der, err := base64.StdEncoding.DecodeString(enc)
+		if err != nil {
+			return nil, fmt.Errorf("verification method %s: certificate %d is not base64: %w", vm.ID, i, err)
+		}
+		if certs[i], err = x509.ParseCertificate(der); err != nil {
+			return nil, fmt.Errorf("verification method %s: certificate %d: %w", vm.ID, i, err)
+		}
+	}
+	return certs, nil
+}
+
+// x509PublicKey returns the DER SubjectPublicKeyInfo of the method's leaf
+// certificate. The key comes from the chain, so the method may not carry a
+// multibase key of its own.
+func (vm VerificationMethod) x509PublicKey() ([]byte, error) {
+	if vm.PublicKeyMultibase != "" {
+		return nil, fmt.Errorf("verification method %s: %s methods take their key from the certificate chain", vm.ID, X509CertificateChainKey)
+	}
+	certs, err := vm.Certificates()
+	if err != nil {
+		return nil, err
+	}
+	return certs[0].RawSubjectPublicKeyInfo, nil
+}
+
+// trustedRootPool returns the X509TrustedRoots as a certificate pool.
+func (p Params) trustedRootPool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	for _, root := range p.X509TrustedRoots {
+		if cert, err := x509.ParseCertificate(root.Certificate); err == nil {
+			pool.AddCert(cert)
+		}
+	}
+	return pool
+}
+
+// checkX509Chains verifies the certificate chain of every X.509 method a
+// write registers or changes against the X509TrustedRoots param at the
+// block time. Chains already on the document aren't checked again, so a
+// certificate that later expires or loses its root doesn't block unrelated
+// updates; verifiers check its validity when they use it.
+func (k Keeper) checkX509Chains(ctx sdk.Context, existing *DIDDocument, did DIDDocument) error {
+	var roots *x509.CertPool
+	for _, vm := range did.VerificationMethods {
+		if vm.Type != X509CertificateChainKey {
+			continue
+		}
+		if existing != nil {
+			if old, ok := existing.VerificationMethod(vm.ID); ok && old.Type == vm.Type && equalStrings(old.X509CertificateChain, vm.X509CertificateChain) {
+				continue
+			}
+		}
+		certs, err := vm.Certificates()
+		if err != nil {
+			return sdkerrors.Wrap(ErrUntrustedCertificate, err.Error())
+		}
+		if roots == nil {
+			roots = k.GetParams(ctx).trustedRootPool()
+		}
+		intermediates := x509.NewCertPool()
+		for _, cert := range certs[1:] {
+			intermediates.AddCert(cert)
+		}
+		if _, err := certs[0].Verify(x509.VerifyOptions{
+			Roots:         roots,
+			Intermediates: intermediates,
+			CurrentTime:   ctx.BlockTime(),
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+		}); err != nil {
+			return sdkerrors.Wrapf(ErrUntrustedCertificate, "verification method %s: %s", vm.ID, err)
+		}
+	}
+	return nil
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func validateX509TrustedRoots(i interface{}) error {
+	roots, ok := i.([]X509TrustRoot)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	seen := make(map[string]bool, len(roots))
+	for _, root := range roots {
+		if root.Name == "" {
+			return fmt.Errorf("trusted root name cannot be empty")
+		}
+		if seen[root.Name] {
+			return fmt.Errorf("trusted root %s is listed more than once", root.Name)
+		}
+		seen[root.Name] = true
+		cert, err := x509.ParseCertificate(root.Certificate)
+		if err != nil {
+			return fmt.Errorf("trusted root %s: %w", root.Name, err)
+		}
+		if !cert.IsCA {
+			return fmt.Errorf("trusted root %s is not a CA certificate", root.Name)
+		}
+	}
+	return nil
+}
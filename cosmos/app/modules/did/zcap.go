@@ -0,0 +1,270 @@
+package did
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cosmos/btcutil/base58"
+)
+
+// Authorization capabilities (ZCAP-LD) let a DID's controllers hand an agent
+// a capability to act on the DID, which the agent can delegate further. An
+// agent invokes its capability on a DID operation by putting a
+// ZcapInvocation, instead of a raw signature, in the message's Signature.
+//
+// Capabilities and invocations are secured with Data Integrity proofs using
+// the eddsa-jcs-2022 cryptosuite, so they can be checked without JSON-LD
+// processing. The root capability of a DID is urn:zcap:root:<DID>, controlled
+// by the DID's controllers; each delegated capability embeds its parent in
+// the last entry of its proof's capabilityChain.
+const (
+	ZcapContext            = "https://w3id.org/zcap/v1"
+	ZcapRootPrefix         = "urn:zcap:root:"
+	DataIntegrityProofType = "DataIntegrityProof"
+	CryptosuiteEdDSAJCS    = "eddsa-jcs-2022"
+
+	ProofPurposeCapabilityDelegation = "capabilityDelegation"
+	ProofPurposeCapabilityInvocation = "capabilityInvocation"
+
+	// ZcapActionUpdate and ZcapActionDeactivate are the actions invoked by
+	// MsgUpdateDID and MsgDeactivateDID. A capability whose allowedAction
+	// lists delegable capabilities such as update-service-endpoints instead
+	// of update only allows updates confined to those fields.
+	ZcapActionUpdate     = "update"
+	ZcapActionDeactivate = "deactivate"
+
+	// MaxZcapChainLength bounds the delegations between the root capability
+	// and the invoked one.
+	MaxZcapChainLength = 10
+)
+
+// ZcapRootID returns the ID of the root capability of a DID, which is
+// URI-component encoded like JavaScript's encodeURIComponent. DIDs hold no
+// spaces, so QueryEscape encodes them the same way.
+func ZcapRootID(did string) string {
+	return ZcapRootPrefix + url.QueryEscape(did)
+}
+
+// ZcapInvocation invokes a capability on a DID operation. Operation is the
+// hex SHA-256 of the operation's proof sign bytes; Proof is the invocation
+// proof, with purpose capabilityInvocation, whose capability is the invoked
+// capability embedded in full.
+type ZcapInvocation struct {
+	Context   interface{}     `json:"@context,omitempty"`
+	Operation string          `json:"operation"`
+	Proof     json.RawMessage `json:"proof"`
+}
+
+// Zcap is an authorization capability.
+type Zcap struct {
+	Context          interface{}     `json:"@context,omitempty"`
+	ID               string          `json:"id"`
+	Controller       string          `json:"controller"`
+	ParentCapability string          `json:"parentCapability"`
+	InvocationTarget string          `json:"invocationTarget"`
+	Expires          string          `json:"expires,omitempty"`
+	AllowedAction    interface{}     `json:"allowedAction,omitempty"`
+	Proof            json.RawMessage `json:"proof,omitempty"`
+}
+
+// zcapProof holds the fields of delegation and invocation proofs.
+type zcapProof struct {
+	Type               string            `json:"type"`
+	Cryptosuite        string            `json:"cryptosuite"`
+	VerificationMethod string            `json:"verificationMethod"`
+	ProofPurpose       string            `json:"proofPurpose"`
+	CapabilityChain    []json.RawMessage `json:"capabilityChain,omitempty"`
+	Capability         json.RawMessage   `json:"capability,omitempty"`
+	CapabilityAction   string            `json:"capabilityAction,omitempty"`
+	InvocationTarget   string            `json:"invocationTarget,omitempty"`
+	ProofValue         string            `json:"proofValue"`
+}
+
+// parseZcapInvocation decodes signature as a ZcapInvocation. Like method
+// signatures, invocations are JSON objects, told apart by their proof.
+func parseZcapInvocation(signature []byte) (ZcapInvocation, bool) {
+	var inv ZcapInvocation
+	if len(signature) == 0 || signature[0] != '{' {
+		return inv, false
+	}
+	if err := json.Unmarshal(signature, &inv); err != nil || len(inv.Proof) == 0 {
+		return inv, false
+	}
+	return inv, true
+}
+
+// allowedActions returns a capability's allowedAction as a list; nil means
+// any action.
+func (z Zcap) allowedActions() ([]string, error) {
+	switch v := z.AllowedAction.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{v}, nil
+	case []interface{}:
+		actions := make([]string, 0, len(v))
+		for _, a := range v {
+			s, ok := a.(string)
+			if !ok {
+				return nil, fmt.Errorf("capability %s: allowedAction must list strings", z.ID)
+			}
+			actions = append(actions, s)
+		}
+		return actions, nil
+	}
+	return nil, fmt.Errorf("capability %s: invalid allowedAction", z.ID)
+}
+
+// allowsAction reports whether a capability allows an action confined to
+// scopes: either the action itself or every scope must be allowed.
+func (z Zcap) allowsAction(action string, scopes []string) (bool, error) {
+	actions, err := z.allowedActions()
+	if err != nil || actions == nil {
+		return err == nil, err
+	}
+	if containsString(actions, action) {
+		return true, nil
+	}
+	if len(scopes) == 0 {
+		return false, nil
+	}
+	for _, scope := range scopes {
+		if !containsString(actions, scope) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// expiry parses the capability's expires time.
+func (z Zcap) expiry() (time.Time, error) {
+	if z.Expires == "" {
+		return time.Time{}, fmt.Errorf("delegated capability %s has no expiry", z.ID)
+	}
+	t, err := time.Parse(time.RFC3339, z.Expires)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("capability %s: invalid expires: %w", z.ID, err)
+	}
+	return t, nil
+}
+
+// controlledBy reports whether the verification method vm speaks for
+// controller, a DID or a verification method ID.
+func controlledBy(controller, vm string) bool {
+	return vm == controller || didOfURL(vm) == controller
+}
+
+// didOfURL strips the fragment, path and query of a DID URL.
+func didOfURL(didURL string) string {
+	if i := strings.IndexAny(didURL, "#/?"); i >= 0 {
+		return didURL[:i]
+	}
+	return didURL
+}
+
+// dataIntegrityProof splits a secured document into the document without its
+// proof and the proof itself.
+func dataIntegrityProof(secured []byte) (map[string]interface{}, map[string]interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(secured))
+	dec.UseNumber()
+	var doc map[string]interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return nil, nil, err
+	}
+	proof, ok := doc["proof"].(map[string]interface{})
+	if !ok {
+		return nil, nil, fmt.Errorf("document has no single proof")
+	}
+	delete(doc, "proof")
+	return doc, proof, nil
+}
+
+// VerifyEdDSAJCS verifies the eddsa-jcs-2022 Data Integrity proof of a
+// secured JSON document against an Ed25519 public key.
+func VerifyEdDSAJCS(secured []byte, pub ed25519.PublicKey) error {
+	doc, proof, err := dataIntegrityProof(secured)
+	if err != nil {
+		return err
+	}
+	if proof["type"] != DataIntegrityProofType || proof["cryptosuite"] != CryptosuiteEdDSAJCS {
+		return fmt.Errorf("proof must be a %s using %s", DataIntegrityProofType, CryptosuiteEdDSAJCS)
+	}
+	proofValue, _ := proof["proofValue"].(string)
+	if len(proofValue) < 2 || proofValue[0] != 'z' {
+		return fmt.Errorf("proofValue must be base58btc multibase")
+	}
+	sig := base58.Decode(proofValue[1:])
+	hash, err := edDSAJCSHash(doc, proof)
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(pub, hash, sig) {
+		return fmt.Errorf("invalid %s proof by %v", CryptosuiteEdDSAJCS, proof["verificationMethod"])
+	}
+	return nil
+}
+
+// edDSAJCSHash returns the data an eddsa-jcs-2022 proof signs: the SHA-256
+// of the canonical proof configuration followed by that of the canonical
+// document. The proof configuration is the proof without its value, taking
+// the document's context.
+func edDSAJCSHash(doc, proof map[string]interface{}) ([]byte, error) {
+	config := make(map[string]interface{}, len(proof))
+	for k, v := range proof {
+		if k != "proofValue" {
+			config[k] = v
+		}
+	}
+	if ctx, ok := doc["@context"]; ok {
+		config["@context"] = ctx
+	}
+	configBytes, err := CanonicalJSON(config)
+	if err != nil {
+		return nil, err
+	}
+	docBytes, err := CanonicalJSON(doc)
+	if err != nil {
+		return nil, err
+	}
+	configHash := sha256.Sum256(configBytes)
+	docHash := sha256.Sum256(docBytes)
+	return append(configHash[:], docHash[:]...), nil
+}
+
+// SignEdDSAJCS adds an eddsa-jcs-2022 proof with the given options to doc,
+// a JSON object, for clients and agents building capabilities and
+// invocations.
+func SignEdDSAJCS(doc []byte, options map[string]interface{}, key ed25519.PrivateKey) ([]byte, error) {
+	var unsecured map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(doc))
+	dec.UseNumber()
+	if err := dec.Decode(&unsecured); err != nil {
+		return nil, err
+	}
+	delete(unsecured, "proof")
+	proof := map[string]interface{}{"type": DataIntegrityProofType, "cryptosuite": CryptosuiteEdDSAJCS}
+	for k, v := range options {
+		proof[k] = v
+	}
+	hash, err := edDSAJCSHash(unsecured, proof)
+	if err != nil {
+		return nil, err
+	}
+	proof["proofValue"] = "z" + base58.Encode(ed25519.Sign(key, hash))
+	unsecured["proof"] = proof
+	return json.Marshal(unsecured)
+}
+
+// ZcapOperation returns the Operation of an invocation of the operation with
+// the given proof sign bytes.
+func ZcapOperation(signBytes []byte) string {
+	sum := sha256.Sum256(signBytes)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,197 @@
+package did
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// AuthorizeInvocation authorizes an operation on did submitted with a
+// ZcapInvocation as its signature. The invocation must be for the operation
+// with signBytes and invoke action on the DID with a capability that chains
+// back, through valid unexpired delegations each allowing action confined to
+// scopes, to the DID's root capability. signer must be the invoking DID.
+func (k Keeper) AuthorizeInvocation(ctx sdk.Context, did DIDDocument, nonce uint64, signer, action string, scopes []string, signBytes, signature []byte) error {
+	if did.Deactivated {
+		return fmt.Errorf("DID %s is deactivated", did.ID)
+	}
+	if err := checkNonce(did, nonce); err != nil {
+		return err
+	}
+	inv, ok := parseZcapInvocation(signature)
+	if !ok {
+		return fmt.Errorf("signature is not a capability invocation")
+	}
+	if inv.Operation != ZcapOperation(signBytes) {
+		return fmt.Errorf("capability invocation is for another operation")
+	}
+	var proof zcapProof
+	if err := json.Unmarshal(inv.Proof, &proof); err != nil {
+		return fmt.Errorf("invalid invocation proof: %w", err)
+	}
+	switch {
+	case proof.ProofPurpose != ProofPurposeCapabilityInvocation:
+		return fmt.Errorf("invocation proof purpose must be %s", ProofPurposeCapabilityInvocation)
+	case proof.InvocationTarget != did.ID:
+		return fmt.Errorf("capability invoked on %s, not %s", proof.InvocationTarget, did.ID)
+	case proof.CapabilityAction != action:
+		return fmt.Errorf("capability invoked for %q, not %q", proof.CapabilityAction, action)
+	case didOfURL(proof.VerificationMethod) != signer:
+		return fmt.Errorf("signer %s did not invoke the capability", signer)
+	}
+	pub, err := k.zcapKey(ctx, proof.VerificationMethod)
+	if err != nil {
+		return err
+	}
+	if err := VerifyEdDSAJCS(signature, pub); err != nil {
+		return err
+	}
+
+	var rootID string
+	if json.Unmarshal(proof.Capability, &rootID) == nil {
+		if rootID != ZcapRootID(did.ID) || !isController(did, signer) {
+			return fmt.Errorf("%s cannot invoke capability %s", signer, rootID)
+		}
+		return nil
+	}
+	var capability Zcap
+	if err := json.Unmarshal(proof.Capability, &capability); err != nil {
+		return fmt.Errorf("invalid invoked capability: %w", err)
+	}
+	if !controlledBy(capability.Controller, proof.VerificationMethod) {
+		return fmt.Errorf("capability %s is not controlled by %s", capability.ID, proof.VerificationMethod)
+	}
+	return k.verifyZcapChain(ctx, did, proof.Capability, action, scopes)
+}
+
+// verifyZcapChain walks a delegated capability up to the root capability of
+// did, checking each delegation proof, expiry and allowedAction. A
+// capability can't outlive its parent.
+func (k Keeper) verifyZcapChain(ctx sdk.Context, did DIDDocument, raw json.RawMessage, action string, scopes []string) error {
+	root := ZcapRootID(did.ID)
+	var childExpiry time.Time
+	for depth := 0; ; depth++ {
+		if depth >= MaxZcapChainLength {
+			return fmt.Errorf("capability chain longer than %d delegations", MaxZcapChainLength)
+		}
+		var capability Zcap
+		if err := json.Unmarshal(raw, &capability); err != nil {
+			return fmt.Errorf("invalid capability: %w", err)
+		}
+		if capability.ID == "" || capability.ID == root {
+			return fmt.Errorf("embedded capabilities must be delegated capabilities with an id")
+		}
+		if capability.InvocationTarget != did.ID {
+			return fmt.Errorf("capability %s targets %s, not %s", capability.ID, capability.InvocationTarget, did.ID)
+		}
+		expiry, err := capability.expiry()
+		if err != nil {
+			return err
+		}
+		if !ctx.BlockTime().Before(expiry) {
+			return fmt.Errorf("capability %s expired at %s", capability.ID, capability.Expires)
+		}
+		if depth > 0 && expiry.Before(childExpiry) {
+			return fmt.Errorf("capability %s expires before a capability delegated from it", capability.ID)
+		}
+		childExpiry = expiry
+		if ok, err := capability.allowsAction(action, scopes); err != nil || !ok {
+			if err == nil {
+				err = fmt.Errorf("capability %s does not allow %s", capability.ID, action)
+			}
+			return err
+		}
+
+		var proof zcapProof
+		if err := json.Unmarshal(capability.Proof, &proof); err != nil {
+			return fmt.Errorf("capability %s: invalid delegation proof: %w", capability.ID, err)
+		}
+		if proof.ProofPurpose != ProofPurposeCapabilityDelegation {
+			return fmt.Errorf("capability %s: proof purpose must be %s", capability.ID, ProofPurposeCapabilityDelegation)
+		}
+		chain, err := zcapChainIDs(proof.CapabilityChain)
+		if err != nil || len(chain) == 0 || chain[0] != root {
+			return fmt.Errorf("capability %s: capability chain must start at %s", capability.ID, root)
+		}
+		if chain[len(chain)-1] != capability.ParentCapability {
+			return fmt.Errorf("capability %s: capability chain must end at its parent", capability.ID)
+		}
+		pub, err := k.zcapKey(ctx, proof.VerificationMethod)
+		if err != nil {
+			return err
+		}
+		if err := VerifyEdDSAJCS(raw, pub); err != nil {
+			return fmt.Errorf("capability %s: %w", capability.ID, err)
+		}
+
+		if capability.ParentCapability == root {
+			if len(chain) != 1 {
+				return fmt.Errorf("capability %s: capability chain must only hold the root", capability.ID)
+			}
+			if !isController(did, didOfURL(proof.VerificationMethod)) {
+				return fmt.Errorf("capability %s was not delegated by a controller of %s", capability.ID, did.ID)
+			}
+			return nil
+		}
+		var parent Zcap
+		if err := json.Unmarshal(proof.CapabilityChain[len(chain)-1], &parent); err != nil {
+			return fmt.Errorf("capability %s: parent capability must be embedded", capability.ID)
+		}
+		if !controlledBy(parent.Controller, proof.VerificationMethod) {
+			return fmt.Errorf("capability %s was not delegated by the controller of %s", capability.ID, parent.ID)
+		}
+		var parentProof zcapProof
+		if err := json.Unmarshal(parent.Proof, &parentProof); err != nil {
+			return fmt.Errorf("capability %s: invalid delegation proof: %w", parent.ID, err)
+		}
+		parentChain, err := zcapChainIDs(parentProof.CapabilityChain)
+		if err != nil || !equalStrings(parentChain, chain[:len(chain)-1]) {
+			return fmt.Errorf("capability %s: capability chain does not extend its parent's", capability.ID)
+		}
+		raw = proof.CapabilityChain[len(chain)-1]
+	}
+}
+
+// zcapChainIDs returns the IDs of a capabilityChain, whose entries are IDs
+// except the last, which may be the embedded parent capability.
+func zcapChainIDs(chain []json.RawMessage) ([]string, error) {
+	ids := make([]string, len(chain))
+	for i, entry := range chain {
+		if json.Unmarshal(entry, &ids[i]) == nil {
+			continue
+		}
+		var capability Zcap
+		if i != len(chain)-1 || json.Unmarshal(entry, &capability) != nil {
+			return nil, fmt.Errorf("invalid capability chain entry %d", i)
+		}
+		ids[i] = capability.ID
+	}
+	return ids, nil
+}
+
+// zcapKey returns the Ed25519 key of a verification method of an active DID
+// for checking capability proofs. Besides Ed25519 methods it accepts the
+// DID's authentication ID for the document's own public key.
+func (k Keeper) zcapKey(ctx sdk.Context, vmID string) (ed25519.PublicKey, error) {
+	did, err := k.GetDID(ctx, didOfURL(vmID))
+	if err != nil {
+		return nil, fmt.Errorf("DID of %s not found", vmID)
+	}
+	if did.Deactivated {
+		return nil, fmt.Errorf("DID %s is deactivated", did.ID)
+	}
+	if vm, ok := did.VerificationMethod(vmID); ok {
+		if vm.Type != Ed25519VerificationKey2020 && vm.Type != FROSTEd25519Key {
+			return nil, fmt.Errorf("verification method %s of type %s can't sign capabilities", vmID, vm.Type)
+		}
+		key, err := vm.PublicKeyBytes()
+		return ed25519.PublicKey(key), err
+	}
+	if vmID != did.Authentication {
+		return nil, fmt.Errorf("verification method %s not found", vmID)
+	}
+	return did.Ed25519PublicKey()
+}
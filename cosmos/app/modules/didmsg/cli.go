@@ -0,0 +1,254 @@
+package didmsg
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"cosmos-app/didcomm"
+	didmodule "cosmos-app/modules/did"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/spf13/cobra"
+)
+
+// FlagEncrypted marks the message file as an already encrypted JWE.
+const FlagEncrypted = "encrypted"
+
+// GetTxCmd returns the root tx command for the mailbox.
+func GetTxCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        ModuleName,
+		Short:                      "DID mailbox transaction subcommands",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+	cmd.AddCommand(
+		CmdSendMessage(),
+		CmdDeleteMessage(),
+	)
+	return cmd
+}
+
+// GetQueryCmd returns the root query command for the mailbox.
+func GetQueryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        ModuleName,
+		Short:                      "Querying commands for the DID mailbox",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+	cmd.AddCommand(
+		CmdQueryMailbox(),
+		CmdQueryMessage(),
+		CmdQueryParams(),
+	)
+	return cmd
+}
+
+// CmdSendMessage returns the command to post a message to a DID's mailbox.
+func CmdSendMessage() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "send [sender-did] [recipient-did] [message-file]",
+		Short: "Post an encrypted message to a DID's on-chain mailbox",
+		Long: `Post a message to the on-chain mailbox of a DID, paying the message fee from
+the --from account. The message is read from message-file, or stdin if it is
+-, and encrypted to every keyAgreement key of the recipient's current
+document; with --encrypted the file must already hold a JWE addressed to
+those keys. A controller of the sending DID authorizes the message as for a
+DID update. With --offline or --generate-only, --encrypted and --nonce are
+required.`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			payload, err := readPayload(cmd, clientCtx, args[1], args[2])
+			if err != nil {
+				return err
+			}
+			signer, nonce, err := didmodule.ControllerProof(cmd, clientCtx, args[0])
+			if err != nil {
+				return err
+			}
+			msg := &MsgSendMessage{
+				Sender:    args[0],
+				Recipient: args[1],
+				Payload:   payload,
+				Nonce:     nonce,
+				Signer:    signer,
+				Creator:   clientCtx.GetFromAddress(),
+			}
+			if msg.Signature, err = didmodule.ProofSignature(cmd, clientCtx, msg.ProofSignBytes()); err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+	cmd.Flags().Bool(FlagEncrypted, false, "The message file already holds a JWE encrypted to the recipient's keyAgreement keys")
+	didmodule.AddControllerProofFlags(cmd)
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// readPayload reads the message file and, unless --encrypted is set,
+// encrypts it to the recipient's keyAgreement keys.
+func readPayload(cmd *cobra.Command, clientCtx client.Context, recipient, path string) (string, error) {
+	var bz []byte
+	var err error
+	if path == "-" {
+		bz, err = io.ReadAll(cmd.InOrStdin())
+	} else {
+		bz, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return "", err
+	}
+	if encrypted, _ := cmd.Flags().GetBool(FlagEncrypted); encrypted {
+		return string(bz), nil
+	}
+	if clientCtx.Offline || clientCtx.GenerateOnly {
+		return "", fmt.Errorf("--%s is required with --offline or --generate-only", FlagEncrypted)
+	}
+	res, _, err := clientCtx.QueryWithData(fmt.Sprintf("custom/did/%s", recipient), nil)
+	if err != nil {
+		return "", err
+	}
+	var doc didmodule.DIDDocument
+	if err := json.Unmarshal(res, &doc); err != nil {
+		return "", err
+	}
+	envelope, err := didcomm.Encrypt(didcomm.MediaTypeEncrypted, bz, doc)
+	if err != nil {
+		return "", err
+	}
+	return string(envelope), nil
+}
+
+// CmdDeleteMessage returns the command to remove a message from a mailbox.
+func CmdDeleteMessage() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete [recipient-did] [id]",
+		Short: "Remove a message from a DID's mailbox",
+		Long: `Remove a message from the mailbox of a DID, typically once it has been read.
+A controller of the recipient DID authorizes the deletion as for a DID
+update.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			id, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid message ID %q", args[1])
+			}
+			signer, nonce, err := didmodule.ControllerProof(cmd, clientCtx, args[0])
+			if err != nil {
+				return err
+			}
+			msg := &MsgDeleteMessage{
+				Recipient: args[0],
+				ID:        id,
+				Nonce:     nonce,
+				Signer:    signer,
+				Creator:   clientCtx.GetFromAddress(),
+			}
+			if msg.Signature, err = didmodule.ProofSignature(cmd, clientCtx, msg.ProofSignBytes()); err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+	didmodule.AddControllerProofFlags(cmd)
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdQueryMailbox returns the command to list the messages of a DID.
+func CmdQueryMailbox() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mailbox [did]",
+		Short: "List the messages waiting for a DID, oldest first",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			pageReq, err := client.ReadPageRequest(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			data, err := json.Marshal(pageReq)
+			if err != nil {
+				return err
+			}
+			res, _, err := clientCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", ModuleName, QueryMailbox, args[0]), data)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintBytes(res)
+		},
+	}
+	flags.AddPaginationFlagsToCmd(cmd, "mailbox")
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdQueryMessage returns the command to query a single message.
+func CmdQueryMessage() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "message [did] [id]",
+		Short: "Query a message in a DID's mailbox",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return printQuery(cmd, QueryMessage, args[0], args[1])
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdQueryParams returns the command to query the mailbox parameters.
+func CmdQueryParams() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "params",
+		Short: "Query the DID mailbox parameters",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return printQuery(cmd, QueryParams)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func printQuery(cmd *cobra.Command, path ...string) error {
+	clientCtx, err := client.GetClientQueryContext(cmd)
+	if err != nil {
+		return err
+	}
+	route := "custom/" + ModuleName
+	for _, p := range path {
+		route += "/" + p
+	}
+	res, _, err := clientCtx.QueryWithData(route, nil)
+	if err != nil {
+		return err
+	}
+	return clientCtx.PrintBytes(res)
+}
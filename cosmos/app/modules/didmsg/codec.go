@@ -0,0 +1,60 @@
+package didmsg
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	cdctypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/gogo/protobuf/proto"
+)
+
+var (
+	amino = codec.NewLegacyAmino()
+
+	// ModuleCdc is the amino codec used for the mailbox's legacy sign bytes.
+	ModuleCdc = codec.NewAminoCodec(amino)
+)
+
+func init() {
+	RegisterLegacyAminoCodec(amino)
+	amino.Seal()
+
+	// Like the DID module's, the mailbox's types are hand-written and
+	// registered under the names a generated aytch/didmsg/v1 package would
+	// use.
+	proto.RegisterType((*Message)(nil), "aytch.didmsg.v1.Message")
+	proto.RegisterType((*MsgSendMessage)(nil), "aytch.didmsg.v1.MsgSendMessage")
+	proto.RegisterType((*MsgDeleteMessage)(nil), "aytch.didmsg.v1.MsgDeleteMessage")
+	proto.RegisterType((*GenesisState)(nil), "aytch.didmsg.v1.GenesisState")
+}
+
+// RegisterLegacyAminoCodec registers the mailbox's messages on the given
+// LegacyAmino codec.
+func RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) {
+	cdc.RegisterConcrete(MsgSendMessage{}, "didmsg/SendMessage", nil)
+	cdc.RegisterConcrete(MsgDeleteMessage{}, "didmsg/DeleteMessage", nil)
+}
+
+// RegisterInterfaces registers the mailbox's messages as sdk.Msg
+// implementations. They are routed by the legacy handler.
+func RegisterInterfaces(registry cdctypes.InterfaceRegistry) {
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgSendMessage{},
+		&MsgDeleteMessage{},
+	)
+}
+
+func (m *Message) Reset()         { *m = Message{} }
+func (m *Message) String() string { return proto.CompactTextString(m) }
+func (*Message) ProtoMessage()    {}
+
+func (m *MsgSendMessage) Reset()         { *m = MsgSendMessage{} }
+func (m *MsgSendMessage) String() string { return proto.CompactTextString(m) }
+func (*MsgSendMessage) ProtoMessage()    {}
+
+func (m *MsgDeleteMessage) Reset()         { *m = MsgDeleteMessage{} }
+func (m *MsgDeleteMessage) String() string { return proto.CompactTextString(m) }
+func (*MsgDeleteMessage) ProtoMessage()    {}
+
+func (m *GenesisState) Reset()         { *m = GenesisState{} }
+func (m *GenesisState) String() string { return proto.CompactTextString(m) }
+func (*GenesisState) ProtoMessage()    {}
@@ -0,0 +1,17 @@
+package didmsg
+
+import (
+	didmodule "cosmos-app/modules/did"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// DIDKeeper defines the DID registry functionality used to authorize senders
+// and recipients, to check that payloads are encrypted to the recipient and
+// to collect message fees.
+type DIDKeeper interface {
+	GetDID(ctx sdk.Context, id string) (didmodule.DIDDocument, error)
+	AuthorizeController(ctx sdk.Context, id string, nonce uint64, signer string, creator sdk.AccAddress, signBytes, signature []byte) error
+	IncrementNonce(ctx sdk.Context, id string) error
+	CollectFee(ctx sdk.Context, payer sdk.AccAddress, fee sdk.Coins) error
+}
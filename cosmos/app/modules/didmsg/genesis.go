@@ -0,0 +1,64 @@
+package didmsg
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState defines the mailbox's genesis state.
+type GenesisState struct {
+	Params   Params    `protobuf:"bytes,1,opt,name=params,proto3" json:"params"`
+	Messages []Message `protobuf:"bytes,2,rep,name=messages,proto3" json:"messages"`
+	NextID   uint64    `protobuf:"varint,3,opt,name=next_id,proto3" json:"next_id"`
+}
+
+// DefaultGenesis returns the default genesis state.
+func DefaultGenesis() *GenesisState {
+	return &GenesisState{Params: DefaultParams(), NextID: 1}
+}
+
+// ValidateGenesis performs basic genesis state validation.
+func ValidateGenesis(gs GenesisState) error {
+	if err := gs.Params.Validate(); err != nil {
+		return err
+	}
+	seen := make(map[uint64]bool, len(gs.Messages))
+	for _, m := range gs.Messages {
+		if m.ID == 0 || m.ID >= gs.NextID {
+			return fmt.Errorf("message %d: ID must be positive and below next_id %d", m.ID, gs.NextID)
+		}
+		if seen[m.ID] {
+			return fmt.Errorf("duplicate message %d", m.ID)
+		}
+		seen[m.ID] = true
+		if err := validateDID(m.Sender); err != nil {
+			return fmt.Errorf("message %d sender: %w", m.ID, err)
+		}
+		if err := validateDID(m.Recipient); err != nil {
+			return fmt.Errorf("message %d recipient: %w", m.ID, err)
+		}
+		if _, err := PayloadKeyIDs(m.Payload); err != nil {
+			return fmt.Errorf("message %d: %w", m.ID, err)
+		}
+	}
+	return nil
+}
+
+// InitGenesis initializes the mailbox's state from a genesis state.
+func InitGenesis(ctx sdk.Context, k Keeper, gs GenesisState) {
+	k.SetParams(ctx, gs.Params)
+	k.SetNextID(ctx, gs.NextID)
+	for _, m := range gs.Messages {
+		k.setMessage(ctx, m)
+	}
+}
+
+// ExportGenesis exports the mailbox's state.
+func ExportGenesis(ctx sdk.Context, k Keeper) *GenesisState {
+	return &GenesisState{
+		Params:   k.GetParams(ctx),
+		Messages: k.GetAllMessages(ctx),
+		NextID:   k.GetNextID(ctx),
+	}
+}
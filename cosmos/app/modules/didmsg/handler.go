@@ -0,0 +1,68 @@
+package didmsg
+
+import (
+	"fmt"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// NewHandler creates a handler for mailbox messages.
+func NewHandler(k Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+		ctx = ctx.WithEventManager(sdk.NewEventManager())
+		switch msg := msg.(type) {
+		case *MsgSendMessage:
+			return handleMsgSendMessage(ctx, k, *msg)
+		case *MsgDeleteMessage:
+			return handleMsgDeleteMessage(ctx, k, *msg)
+		default:
+			return nil, fmt.Errorf("unrecognized DID mailbox message type: %T", msg)
+		}
+	}
+}
+
+// authorizeDID checks a controller proof for id and consumes its nonce, so
+// the proof can't be replayed.
+func authorizeDID(ctx sdk.Context, k Keeper, id string, nonce uint64, signer string, creator sdk.AccAddress, signBytes, signature []byte) error {
+	if err := k.didKeeper.AuthorizeController(ctx, id, nonce, signer, creator, signBytes, signature); err != nil {
+		return err
+	}
+	return k.didKeeper.IncrementNonce(ctx, id)
+}
+
+func handleMsgSendMessage(ctx sdk.Context, k Keeper, msg MsgSendMessage) (*sdk.Result, error) {
+	if err := authorizeDID(ctx, k, msg.Sender, msg.Nonce, msg.Signer, msg.Creator, msg.ProofSignBytes(), msg.Signature); err != nil {
+		return nil, err
+	}
+	m, err := k.SendMessage(ctx, msg.Sender, msg.Recipient, msg.Payload)
+	if err != nil {
+		return nil, err
+	}
+	if err := k.ChargeMessageFee(ctx, msg.Creator); err != nil {
+		return nil, err
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		"send_message",
+		sdk.NewAttribute("id", strconv.FormatUint(m.ID, 10)),
+		sdk.NewAttribute("sender", m.Sender),
+		sdk.NewAttribute("recipient", m.Recipient),
+		sdk.NewAttribute("expires_at", strconv.FormatInt(m.ExpiresAt, 10)),
+	))
+	return &sdk.Result{Events: ctx.EventManager().ABCIEvents()}, nil
+}
+
+func handleMsgDeleteMessage(ctx sdk.Context, k Keeper, msg MsgDeleteMessage) (*sdk.Result, error) {
+	if err := authorizeDID(ctx, k, msg.Recipient, msg.Nonce, msg.Signer, msg.Creator, msg.ProofSignBytes(), msg.Signature); err != nil {
+		return nil, err
+	}
+	if err := k.DeleteMessage(ctx, msg.Recipient, msg.ID); err != nil {
+		return nil, err
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		"delete_message",
+		sdk.NewAttribute("id", strconv.FormatUint(msg.ID, 10)),
+		sdk.NewAttribute("recipient", msg.Recipient),
+	))
+	return &sdk.Result{Events: ctx.EventManager().ABCIEvents()}, nil
+}
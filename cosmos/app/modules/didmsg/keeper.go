@@ -0,0 +1,259 @@
+package didmsg
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
+)
+
+// Store prefixes. Messages are stored by recipient and ID under
+// MailboxPrefix, so a mailbox can be paginated in the order messages
+// arrived, and indexed by expiry height under ExpiryPrefix for pruning.
+// MailboxSizePrefix holds the number of messages in each mailbox.
+var (
+	MailboxPrefix     = []byte("mailbox/")
+	MailboxSizePrefix = []byte("size/")
+	ExpiryPrefix      = []byte("expiry/")
+	NextIDKey         = []byte("next_id")
+)
+
+func mailboxPrefix(did string) []byte {
+	return append(append([]byte{}, MailboxPrefix...), did+"/"...)
+}
+
+func messageKey(did string, id uint64) []byte {
+	return append(mailboxPrefix(did), sdk.Uint64ToBigEndian(id)...)
+}
+
+func mailboxSizeKey(did string) []byte {
+	return append(append([]byte{}, MailboxSizePrefix...), did...)
+}
+
+func expiryHeightPrefix(height int64) []byte {
+	return append(append([]byte{}, ExpiryPrefix...), sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+func expiryKey(height int64, id uint64) []byte {
+	return append(expiryHeightPrefix(height), sdk.Uint64ToBigEndian(id)...)
+}
+
+// Keeper maintains the DID mailboxes.
+type Keeper struct {
+	storeKey   sdk.StoreKey
+	cdc        *codec.LegacyAmino
+	paramSpace paramtypes.Subspace
+	didKeeper  DIDKeeper
+}
+
+// NewKeeper creates a new mailbox Keeper.
+func NewKeeper(storeKey sdk.StoreKey, cdc *codec.LegacyAmino, paramSpace paramtypes.Subspace, didKeeper DIDKeeper) Keeper {
+	if !paramSpace.HasKeyTable() {
+		paramSpace = paramSpace.WithKeyTable(ParamKeyTable())
+	}
+	return Keeper{
+		storeKey:   storeKey,
+		cdc:        cdc,
+		paramSpace: paramSpace,
+		didKeeper:  didKeeper,
+	}
+}
+
+// GetParams returns the mailbox parameters.
+func (k Keeper) GetParams(ctx sdk.Context) Params {
+	var params Params
+	k.paramSpace.GetParamSet(ctx, &params)
+	return params
+}
+
+// SetParams sets the mailbox parameters.
+func (k Keeper) SetParams(ctx sdk.Context, params Params) {
+	k.paramSpace.SetParamSet(ctx, &params)
+}
+
+// ChargeMessageFee collects the fee for one message from the payer, split
+// like the DID module's fees.
+func (k Keeper) ChargeMessageFee(ctx sdk.Context, payer sdk.AccAddress) error {
+	fee := k.GetParams(ctx).MessageFee
+	if fee.IsZero() {
+		return nil
+	}
+	return k.didKeeper.CollectFee(ctx, payer, fee)
+}
+
+// SendMessage puts payload into recipient's mailbox and returns the stored
+// message. The recipient must be an active DID and every key the payload is
+// encrypted to must be one of its keyAgreement keys, so the chain doesn't
+// carry messages nobody can read.
+func (k Keeper) SendMessage(ctx sdk.Context, sender, recipient, payload string) (Message, error) {
+	params := k.GetParams(ctx)
+	if uint64(len(payload)) > params.MaxPayloadSize {
+		return Message{}, fmt.Errorf("payload is %d bytes, the maximum is %d", len(payload), params.MaxPayloadSize)
+	}
+	if err := k.checkRecipientKeys(ctx, recipient, payload); err != nil {
+		return Message{}, err
+	}
+	if k.mailboxSize(ctx, recipient) >= params.MaxMailboxSize {
+		return Message{}, fmt.Errorf("mailbox of %s is full", recipient)
+	}
+	m := Message{
+		ID:        k.nextID(ctx),
+		Sender:    sender,
+		Recipient: recipient,
+		Payload:   payload,
+		Created:   ctx.BlockHeight(),
+		ExpiresAt: ctx.BlockHeight() + int64(params.MessageTTL),
+	}
+	k.setMessage(ctx, m)
+	return m, nil
+}
+
+func (k Keeper) checkRecipientKeys(ctx sdk.Context, recipient, payload string) error {
+	doc, err := k.didKeeper.GetDID(ctx, recipient)
+	if err != nil {
+		return fmt.Errorf("recipient %s: %w", recipient, err)
+	}
+	if doc.Deactivated {
+		return fmt.Errorf("recipient %s is deactivated", recipient)
+	}
+	kids, err := PayloadKeyIDs(payload)
+	if err != nil {
+		return err
+	}
+	agreement := make(map[string]bool, len(doc.KeyAgreement))
+	for _, vm := range doc.KeyAgreementMethods() {
+		agreement[vm.ID] = true
+	}
+	if len(agreement) == 0 {
+		return fmt.Errorf("recipient %s has no keyAgreement keys", recipient)
+	}
+	for _, kid := range kids {
+		if !agreement[kid] {
+			return fmt.Errorf("payload is encrypted to %s, which is not a keyAgreement key of %s", kid, recipient)
+		}
+	}
+	return nil
+}
+
+// GetMessage returns message id from recipient's mailbox.
+func (k Keeper) GetMessage(ctx sdk.Context, recipient string, id uint64) (Message, error) {
+	value := ctx.KVStore(k.storeKey).Get(messageKey(recipient, id))
+	if value == nil {
+		return Message{}, fmt.Errorf("message %d not found in the mailbox of %s", id, recipient)
+	}
+	var m Message
+	k.cdc.MustUnmarshal(value, &m)
+	return m, nil
+}
+
+// DeleteMessage removes message id from recipient's mailbox.
+func (k Keeper) DeleteMessage(ctx sdk.Context, recipient string, id uint64) error {
+	m, err := k.GetMessage(ctx, recipient, id)
+	if err != nil {
+		return err
+	}
+	k.deleteMessage(ctx, m)
+	return nil
+}
+
+// Mailbox returns a page of recipient's messages, oldest first.
+func (k Keeper) Mailbox(ctx sdk.Context, recipient string, pageReq *query.PageRequest) ([]Message, *query.PageResponse, error) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), mailboxPrefix(recipient))
+	messages := []Message{}
+	pageRes, err := query.Paginate(store, pageReq, func(_, value []byte) error {
+		var m Message
+		k.cdc.MustUnmarshal(value, &m)
+		messages = append(messages, m)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return messages, pageRes, nil
+}
+
+// PruneExpired deletes the messages that expire at or before the current
+// height and returns how many were deleted.
+func (k Keeper) PruneExpired(ctx sdk.Context) int {
+	store := ctx.KVStore(k.storeKey)
+	iter := store.Iterator(ExpiryPrefix, expiryHeightPrefix(ctx.BlockHeight()+1))
+	var expired []Message
+	for ; iter.Valid(); iter.Next() {
+		id := binary.BigEndian.Uint64(iter.Key()[len(ExpiryPrefix)+8:])
+		if m, err := k.GetMessage(ctx, string(iter.Value()), id); err == nil {
+			expired = append(expired, m)
+		}
+	}
+	iter.Close()
+	for _, m := range expired {
+		k.deleteMessage(ctx, m)
+	}
+	return len(expired)
+}
+
+// GetAllMessages returns every message in the store.
+func (k Keeper) GetAllMessages(ctx sdk.Context) []Message {
+	var messages []Message
+	iter := prefix.NewStore(ctx.KVStore(k.storeKey), MailboxPrefix).Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var m Message
+		k.cdc.MustUnmarshal(iter.Value(), &m)
+		messages = append(messages, m)
+	}
+	return messages
+}
+
+// GetNextID returns the ID the next message will get.
+func (k Keeper) GetNextID(ctx sdk.Context) uint64 {
+	bz := ctx.KVStore(k.storeKey).Get(NextIDKey)
+	if bz == nil {
+		return 1
+	}
+	return binary.BigEndian.Uint64(bz)
+}
+
+// SetNextID sets the ID the next message will get.
+func (k Keeper) SetNextID(ctx sdk.Context, id uint64) {
+	ctx.KVStore(k.storeKey).Set(NextIDKey, sdk.Uint64ToBigEndian(id))
+}
+
+func (k Keeper) nextID(ctx sdk.Context) uint64 {
+	id := k.GetNextID(ctx)
+	k.SetNextID(ctx, id+1)
+	return id
+}
+
+func (k Keeper) mailboxSize(ctx sdk.Context, did string) uint64 {
+	bz := ctx.KVStore(k.storeKey).Get(mailboxSizeKey(did))
+	if bz == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(bz)
+}
+
+func (k Keeper) setMailboxSize(ctx sdk.Context, did string, size uint64) {
+	if size == 0 {
+		ctx.KVStore(k.storeKey).Delete(mailboxSizeKey(did))
+		return
+	}
+	ctx.KVStore(k.storeKey).Set(mailboxSizeKey(did), sdk.Uint64ToBigEndian(size))
+}
+
+func (k Keeper) setMessage(ctx sdk.Context, m Message) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(messageKey(m.Recipient, m.ID), k.cdc.MustMarshal(&m))
+	store.Set(expiryKey(m.ExpiresAt, m.ID), []byte(m.Recipient))
+	k.setMailboxSize(ctx, m.Recipient, k.mailboxSize(ctx, m.Recipient)+1)
+}
+
+func (k Keeper) deleteMessage(ctx sdk.Context, m Message) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(messageKey(m.Recipient, m.ID))
+	store.Delete(expiryKey(m.ExpiresAt, m.ID))
+	k.setMailboxSize(ctx, m.Recipient, k.mailboxSize(ctx, m.Recipient)-1)
+}
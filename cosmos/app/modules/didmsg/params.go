@@ -0,0 +1,97 @@
+package didmsg
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
+	"github.com/gogo/protobuf/proto"
+)
+
+// Parameter store keys.
+var (
+	KeyMessageFee     = []byte("MessageFee")
+	KeyMaxPayloadSize = []byte("MaxPayloadSize")
+	KeyMessageTTL     = []byte("MessageTTL")
+	KeyMaxMailboxSize = []byte("MaxMailboxSize")
+)
+
+// Params defines the governance-controlled parameters of the mailbox.
+// MessageFee is charged for every message sent and is split like the DID
+// module's fees. Payloads are limited to MaxPayloadSize bytes and kept for
+// MessageTTL blocks unless the recipient deletes them first. A mailbox holds
+// at most MaxMailboxSize messages, so a flood of messages can't make a DID's
+// mailbox unreadable.
+type Params struct {
+	MessageFee     sdk.Coins `protobuf:"bytes,1,rep,name=message_fee,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"message_fee"`
+	MaxPayloadSize uint64    `protobuf:"varint,2,opt,name=max_payload_size,proto3" json:"max_payload_size"`
+	MessageTTL     uint64    `protobuf:"varint,3,opt,name=message_ttl,proto3" json:"message_ttl"`
+	MaxMailboxSize uint64    `protobuf:"varint,4,opt,name=max_mailbox_size,proto3" json:"max_mailbox_size"`
+}
+
+func init() {
+	proto.RegisterType((*Params)(nil), "aytch.didmsg.v1.Params")
+}
+
+func (m *Params) Reset()         { *m = Params{} }
+func (m *Params) String() string { return proto.CompactTextString(m) }
+func (*Params) ProtoMessage()    {}
+
+// ParamKeyTable returns the key table for the mailbox's parameters.
+func ParamKeyTable() paramtypes.KeyTable {
+	return paramtypes.NewKeyTable().RegisterParamSet(&Params{})
+}
+
+// DefaultParams returns the default parameters: messages are free, up to
+// 4 KiB, and kept for about a week at 6 second blocks.
+func DefaultParams() Params {
+	return Params{
+		MessageFee:     sdk.NewCoins(),
+		MaxPayloadSize: 4096,
+		MessageTTL:     100800,
+		MaxMailboxSize: 100,
+	}
+}
+
+// ParamSetPairs implements paramtypes.ParamSet.
+func (p *Params) ParamSetPairs() paramtypes.ParamSetPairs {
+	return paramtypes.ParamSetPairs{
+		paramtypes.NewParamSetPair(KeyMessageFee, &p.MessageFee, validateMessageFee),
+		paramtypes.NewParamSetPair(KeyMaxPayloadSize, &p.MaxPayloadSize, validatePositiveUint64),
+		paramtypes.NewParamSetPair(KeyMessageTTL, &p.MessageTTL, validatePositiveUint64),
+		paramtypes.NewParamSetPair(KeyMaxMailboxSize, &p.MaxMailboxSize, validatePositiveUint64),
+	}
+}
+
+// Validate performs basic validation of the parameters.
+func (p Params) Validate() error {
+	if err := validateMessageFee(p.MessageFee); err != nil {
+		return err
+	}
+	if err := validatePositiveUint64(p.MaxPayloadSize); err != nil {
+		return err
+	}
+	if err := validatePositiveUint64(p.MessageTTL); err != nil {
+		return err
+	}
+	return validatePositiveUint64(p.MaxMailboxSize)
+}
+
+func validateMessageFee(i interface{}) error {
+	fee, ok := i.(sdk.Coins)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return fee.Validate()
+}
+
+func validatePositiveUint64(i interface{}) error {
+	v, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if v == 0 {
+		return fmt.Errorf("parameter must be positive")
+	}
+	return nil
+}
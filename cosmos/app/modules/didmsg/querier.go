@@ -0,0 +1,65 @@
+package didmsg
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// Query endpoints supported by the mailbox querier.
+const (
+	QueryMailbox = "mailbox"
+	QueryMessage = "message"
+	QueryParams  = "params"
+)
+
+// MailboxPage is the answer to a QueryMailbox. The query's data is an
+// optional JSON query.PageRequest.
+type MailboxPage struct {
+	Messages   []Message           `json:"messages"`
+	Pagination *query.PageResponse `json:"pagination,omitempty"`
+}
+
+// NewQuerier creates a legacy querier for the mailbox. Results are encoded as
+// plain JSON, like the DID module's.
+func NewQuerier(k Keeper, _ *codec.LegacyAmino) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, error) {
+		if len(path) == 0 {
+			return nil, fmt.Errorf("empty DID mailbox query path")
+		}
+		switch {
+		case path[0] == QueryMailbox && len(path) == 2:
+			var pageReq *query.PageRequest
+			if len(req.Data) > 0 {
+				pageReq = &query.PageRequest{}
+				if err := json.Unmarshal(req.Data, pageReq); err != nil {
+					return nil, fmt.Errorf("invalid pagination: %w", err)
+				}
+			}
+			messages, pageRes, err := k.Mailbox(ctx, path[1], pageReq)
+			if err != nil {
+				return nil, err
+			}
+			return json.MarshalIndent(MailboxPage{Messages: messages, Pagination: pageRes}, "", "  ")
+		case path[0] == QueryMessage && len(path) == 3:
+			id, err := strconv.ParseUint(path[2], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid message ID %q", path[2])
+			}
+			m, err := k.GetMessage(ctx, path[1], id)
+			if err != nil {
+				return nil, err
+			}
+			return json.MarshalIndent(m, "", "  ")
+		case path[0] == QueryParams:
+			return json.MarshalIndent(k.GetParams(ctx), "", "  ")
+		default:
+			return nil, fmt.Errorf("unknown DID mailbox query path %v", path)
+		}
+	}
+}
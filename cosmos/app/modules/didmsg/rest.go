@@ -0,0 +1,96 @@
+package didmsg
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/gorilla/mux"
+)
+
+// RegisterRoutes registers the mailbox's REST routes. The mailbox route
+// accepts the pagination.key, pagination.offset, pagination.limit and
+// pagination.count_total query parameters.
+func RegisterRoutes(cliCtx client.Context, r *mux.Router) {
+	r.HandleFunc("/didmsg/dids/{did}/messages", mailboxHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/didmsg/dids/{did}/messages/{id}", messageHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/didmsg/params", paramsHandler(cliCtx)).Methods("GET")
+}
+
+func mailboxHandler(cliCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		pageReq, err := pageRequest(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		data, err := json.Marshal(pageReq)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", ModuleName, QueryMailbox, mux.Vars(r)["did"]), data)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(res)
+	}
+}
+
+func messageHandler(cliCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s/%s", ModuleName, QueryMessage, vars["did"], vars["id"]), nil)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(res)
+	}
+}
+
+func paramsHandler(cliCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", ModuleName, QueryParams), nil)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(res)
+	}
+}
+
+func pageRequest(r *http.Request) (*query.PageRequest, error) {
+	q := r.URL.Query()
+	pageReq := &query.PageRequest{}
+	var err error
+	if v := q.Get("pagination.key"); v != "" {
+		if pageReq.Key, err = base64.StdEncoding.DecodeString(v); err != nil {
+			return nil, fmt.Errorf("invalid pagination.key: %w", err)
+		}
+	}
+	if v := q.Get("pagination.offset"); v != "" {
+		if pageReq.Offset, err = strconv.ParseUint(v, 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid pagination.offset: %w", err)
+		}
+	}
+	if v := q.Get("pagination.limit"); v != "" {
+		if pageReq.Limit, err = strconv.ParseUint(v, 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid pagination.limit: %w", err)
+		}
+	}
+	if v := q.Get("pagination.count_total"); v != "" {
+		if pageReq.CountTotal, err = strconv.ParseBool(v); err != nil {
+			return nil, fmt.Errorf("invalid pagination.count_total: %w", err)
+		}
+	}
+	return pageReq, nil
+}
@@ -0,0 +1,188 @@
+package didmsg
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	didmodule "cosmos-app/modules/did"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// Module identifiers for the DID mailbox.
+const (
+	ModuleName = "didmsg"
+	StoreKey   = ModuleName
+	RouterKey  = ModuleName
+)
+
+// Message is an encrypted payload waiting in a recipient DID's mailbox. The
+// payload is a JWE in general JSON serialization whose recipients are
+// keyAgreement keys of Recipient, so only its controllers can read it; the
+// chain only checks who it is addressed to. Messages are deleted by the
+// recipient once read or pruned at ExpiresAt.
+type Message struct {
+	ID        uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id"`
+	Sender    string `protobuf:"bytes,2,opt,name=sender,proto3" json:"sender"`
+	Recipient string `protobuf:"bytes,3,opt,name=recipient,proto3" json:"recipient"`
+	Payload   string `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload"`
+	Created   int64  `protobuf:"varint,5,opt,name=created,proto3" json:"created"`
+	ExpiresAt int64  `protobuf:"varint,6,opt,name=expires_at,proto3" json:"expires_at"`
+}
+
+// envelope is the part of a JWE the mailbox inspects.
+type envelope struct {
+	Protected  string `json:"protected"`
+	Ciphertext string `json:"ciphertext"`
+	Recipients []struct {
+		Header struct {
+			KID string `json:"kid"`
+		} `json:"header"`
+	} `json:"recipients"`
+}
+
+// PayloadKeyIDs returns the key IDs a JWE payload is encrypted to.
+func PayloadKeyIDs(payload string) ([]string, error) {
+	var env envelope
+	if err := json.Unmarshal([]byte(payload), &env); err != nil {
+		return nil, fmt.Errorf("payload must be a JWE in general JSON serialization: %w", err)
+	}
+	if env.Protected == "" || env.Ciphertext == "" || len(env.Recipients) == 0 {
+		return nil, fmt.Errorf("payload must be a JWE with protected header, ciphertext and recipients")
+	}
+	kids := make([]string, len(env.Recipients))
+	for i, r := range env.Recipients {
+		if r.Header.KID == "" {
+			return nil, fmt.Errorf("payload recipient %d has no kid", i)
+		}
+		kids[i] = r.Header.KID
+	}
+	return kids, nil
+}
+
+// validateDID checks that id is a DID of this chain. Recipients are part of
+// store keys, so DIDs can't contain '/'.
+func validateDID(id string) error {
+	if !strings.HasPrefix(id, didmodule.DIDMethodPrefix) || id == didmodule.DIDMethodPrefix {
+		return fmt.Errorf("DID must start with %s", didmodule.DIDMethodPrefix)
+	}
+	if strings.Contains(id, "/") {
+		return fmt.Errorf("DID cannot contain '/'")
+	}
+	return didmodule.ValidateChecksum(id)
+}
+
+// proofSignBytes returns the sorted JSON of a message with its controller
+// signature cleared, which the controller signs.
+func proofSignBytes(msg interface{}) []byte {
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// MsgSendMessage posts an encrypted payload to a recipient DID's mailbox.
+// Signer proves control of the sending DID as for a DID document change, so
+// recipients know who a message is from, and Creator pays the message fee.
+type MsgSendMessage struct {
+	Sender    string         `protobuf:"bytes,1,opt,name=sender,proto3" json:"sender"`
+	Recipient string         `protobuf:"bytes,2,opt,name=recipient,proto3" json:"recipient"`
+	Payload   string         `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload"`
+	Nonce     uint64         `protobuf:"varint,4,opt,name=nonce,proto3" json:"nonce"`
+	Signer    string         `protobuf:"bytes,5,opt,name=signer,proto3" json:"signer"`
+	Signature []byte         `protobuf:"bytes,6,opt,name=signature,proto3" json:"signature,omitempty"`
+	Creator   sdk.AccAddress `protobuf:"bytes,7,opt,name=creator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"creator"`
+}
+
+// ValidateBasic performs basic validation of MsgSendMessage. The payload size
+// is a parameter and is checked by the keeper.
+func (msg MsgSendMessage) ValidateBasic() error {
+	if err := validateDID(msg.Sender); err != nil {
+		return sdkerrors.ErrUnknownRequest.Wrap(err.Error())
+	}
+	if err := validateDID(msg.Recipient); err != nil {
+		return sdkerrors.ErrUnknownRequest.Wrap(err.Error())
+	}
+	if _, err := PayloadKeyIDs(msg.Payload); err != nil {
+		return sdkerrors.ErrUnknownRequest.Wrap(err.Error())
+	}
+	if msg.Signer == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Signer cannot be empty")
+	}
+	if msg.Creator.Empty() {
+		return sdkerrors.ErrUnknownRequest.Wrap("Creator cannot be empty")
+	}
+	return nil
+}
+
+// ProofSignBytes returns the bytes the sending DID's controller signs.
+func (msg MsgSendMessage) ProofSignBytes() []byte {
+	msg.Signature = nil
+	return proofSignBytes(msg)
+}
+
+// Route returns the message route.
+func (msg MsgSendMessage) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgSendMessage) Type() string { return "send_message" }
+
+// GetSignBytes returns the canonical bytes to sign over.
+func (msg MsgSendMessage) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners returns the account that must sign the message.
+func (msg MsgSendMessage) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
+
+// MsgDeleteMessage removes a message from a mailbox, typically once it has
+// been read. Signer proves control of the recipient DID.
+type MsgDeleteMessage struct {
+	Recipient string         `protobuf:"bytes,1,opt,name=recipient,proto3" json:"recipient"`
+	ID        uint64         `protobuf:"varint,2,opt,name=id,proto3" json:"id"`
+	Nonce     uint64         `protobuf:"varint,3,opt,name=nonce,proto3" json:"nonce"`
+	Signer    string         `protobuf:"bytes,4,opt,name=signer,proto3" json:"signer"`
+	Signature []byte         `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
+	Creator   sdk.AccAddress `protobuf:"bytes,6,opt,name=creator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"creator"`
+}
+
+// ValidateBasic performs basic validation of MsgDeleteMessage.
+func (msg MsgDeleteMessage) ValidateBasic() error {
+	if err := validateDID(msg.Recipient); err != nil {
+		return sdkerrors.ErrUnknownRequest.Wrap(err.Error())
+	}
+	if msg.Signer == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Signer cannot be empty")
+	}
+	if msg.Creator.Empty() {
+		return sdkerrors.ErrUnknownRequest.Wrap("Creator cannot be empty")
+	}
+	return nil
+}
+
+// ProofSignBytes returns the bytes the recipient DID's controller signs.
+func (msg MsgDeleteMessage) ProofSignBytes() []byte {
+	msg.Signature = nil
+	return proofSignBytes(msg)
+}
+
+// Route returns the message route.
+func (msg MsgDeleteMessage) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgDeleteMessage) Type() string { return "delete_message" }
+
+// GetSignBytes returns the canonical bytes to sign over.
+func (msg MsgDeleteMessage) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners returns the account that must sign the message.
+func (msg MsgDeleteMessage) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
@@ -0,0 +1,304 @@
+package didname
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	didmodule "cosmos-app/modules/did"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/spf13/cobra"
+)
+
+// Flags for the name service's CLI commands.
+const (
+	FlagRecipientSigner    = "recipient-signer"
+	FlagRecipientKey       = "recipient-key"
+	FlagRecipientSignature = "recipient-signature"
+	FlagRecipientNonce     = "recipient-nonce"
+)
+
+// GetTxCmd returns the root tx command for the name service.
+func GetTxCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        ModuleName,
+		Short:                      "DID name service transaction subcommands",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+	cmd.AddCommand(
+		CmdRegisterName(),
+		CmdRenewName(),
+		CmdTransferName(),
+	)
+	return cmd
+}
+
+// GetQueryCmd returns the root query command for the name service.
+func GetQueryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        ModuleName,
+		Short:                      "Querying commands for the DID name service",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+	cmd.AddCommand(
+		CmdResolveName(),
+		CmdQueryName(),
+		CmdNamesByDID(),
+		CmdQueryParams(),
+	)
+	return cmd
+}
+
+// CmdRegisterName returns the command to register a name for a DID.
+func CmdRegisterName() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "register [name] [did]",
+		Short: "Register a name, e.g. alice.aytch, resolving to a DID",
+		Long: `Register a name resolving to a DID for one registration period, paying the
+registration fee from the --from account. A controller of the DID authorizes
+the registration as for a DID update: an account controller by signing the
+transaction, a DID controller with --key or --signature.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			signer, nonce, err := didmodule.ControllerProof(cmd, clientCtx, args[1])
+			if err != nil {
+				return err
+			}
+			msg := &MsgRegisterName{
+				Name:    args[0],
+				DID:     args[1],
+				Nonce:   nonce,
+				Signer:  signer,
+				Creator: clientCtx.GetFromAddress(),
+			}
+			if msg.Signature, err = didmodule.ProofSignature(cmd, clientCtx, msg.ProofSignBytes()); err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+	didmodule.AddControllerProofFlags(cmd)
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdRenewName returns the command to renew a name.
+func CmdRenewName() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "renew [name]",
+		Short: "Extend a name by one registration period, paying the registration fee",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			msg := &MsgRenewName{Name: args[0], Creator: clientCtx.GetFromAddress()}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdTransferName returns the command to move a name to another DID.
+func CmdTransferName() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "transfer [name] [recipient-did]",
+		Short: "Point a name at another DID",
+		Long: `Point a name at another DID. Controllers of both DIDs must authorize the
+transfer: the current DID's with --signer, --key or --signature as for
+register, the recipient's with --recipient-signer and --recipient-key or
+--recipient-signature. Both sign the same proof sign bytes, so a signature
+made elsewhere must be over the message with both signatures cleared. With
+--offline or --generate-only, --signer, --nonce and --recipient-nonce are
+required.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			var current string
+			if !clientCtx.Offline && !clientCtx.GenerateOnly {
+				if current, err = queryNameDID(clientCtx, args[0]); err != nil {
+					return err
+				}
+			}
+			signer, nonce, err := didmodule.ControllerProof(cmd, clientCtx, current)
+			if err != nil {
+				return err
+			}
+			recipientSigner, _ := cmd.Flags().GetString(FlagRecipientSigner)
+			if recipientSigner == "" {
+				recipientSigner = args[1]
+			}
+			recipientNonce, err := recipientDocumentNonce(cmd, clientCtx, args[1])
+			if err != nil {
+				return err
+			}
+			msg := &MsgTransferName{
+				Name:            args[0],
+				Recipient:       args[1],
+				Nonce:           nonce,
+				Signer:          signer,
+				RecipientNonce:  recipientNonce,
+				RecipientSigner: recipientSigner,
+				Creator:         clientCtx.GetFromAddress(),
+			}
+			signBytes := msg.ProofSignBytes()
+			if msg.Signature, err = didmodule.ProofSignature(cmd, clientCtx, signBytes); err != nil {
+				return err
+			}
+			if msg.RecipientSignature, err = recipientSignature(cmd, clientCtx, signBytes); err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+	didmodule.AddControllerProofFlags(cmd)
+	cmd.Flags().String(FlagRecipientSigner, "", "Controller of the recipient DID authorizing the transfer; defaults to the recipient DID itself")
+	cmd.Flags().String(FlagRecipientKey, "", "Name of the Ed25519 keyring key that signs for the recipient's controller DID")
+	cmd.Flags().String(FlagRecipientSignature, "", "Base64 recipient controller signature over the proof sign bytes, made elsewhere")
+	cmd.Flags().Uint64(FlagRecipientNonce, 0, "Current nonce of the recipient DID document")
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+func queryNameDID(clientCtx client.Context, name string) (string, error) {
+	res, _, err := clientCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", ModuleName, QueryName, name), nil)
+	if err != nil {
+		return "", err
+	}
+	var r NameRecord
+	if err := json.Unmarshal(res, &r); err != nil {
+		return "", err
+	}
+	return r.DID, nil
+}
+
+func recipientDocumentNonce(cmd *cobra.Command, clientCtx client.Context, id string) (uint64, error) {
+	if cmd.Flags().Changed(FlagRecipientNonce) {
+		return cmd.Flags().GetUint64(FlagRecipientNonce)
+	}
+	if clientCtx.Offline || clientCtx.GenerateOnly {
+		return 0, fmt.Errorf("--%s is required with --offline or --generate-only", FlagRecipientNonce)
+	}
+	res, _, err := clientCtx.QueryWithData(fmt.Sprintf("custom/did/%s", id), nil)
+	if err != nil {
+		return 0, err
+	}
+	var doc didmodule.DIDDocument
+	if err := json.Unmarshal(res, &doc); err != nil {
+		return 0, err
+	}
+	return doc.Nonce, nil
+}
+
+func recipientSignature(cmd *cobra.Command, clientCtx client.Context, signBytes []byte) ([]byte, error) {
+	if sig, _ := cmd.Flags().GetString(FlagRecipientSignature); sig != "" {
+		bz, err := base64.StdEncoding.DecodeString(sig)
+		if err != nil {
+			return nil, fmt.Errorf("invalid recipient signature: %w", err)
+		}
+		return bz, nil
+	}
+	keyName, _ := cmd.Flags().GetString(FlagRecipientKey)
+	if keyName == "" {
+		return nil, nil
+	}
+	sig, _, err := clientCtx.Keyring.Sign(keyName, signBytes)
+	return sig, err
+}
+
+// CmdResolveName returns the command to resolve a name to its DID.
+func CmdResolveName() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "resolve [name]",
+		Short: "Resolve an active name to its DID",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return printQuery(cmd, QueryResolve, args[0])
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdQueryName returns the command to query a name's registration.
+func CmdQueryName() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "name [name]",
+		Short: "Query the registration of a name, including its expiry",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return printQuery(cmd, QueryName, args[0])
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdNamesByDID returns the command to look up the names of a DID.
+func CmdNamesByDID() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "names [did]",
+		Short: "List the active names resolving to a DID",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return printQuery(cmd, QueryNamesByID, args[0])
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdQueryParams returns the command to query the name service parameters.
+func CmdQueryParams() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "params",
+		Short: "Query the DID name service parameters",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return printQuery(cmd, QueryParams)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func printQuery(cmd *cobra.Command, path ...string) error {
+	clientCtx, err := client.GetClientQueryContext(cmd)
+	if err != nil {
+		return err
+	}
+	route := "custom/" + ModuleName
+	for _, p := range path {
+		route += "/" + p
+	}
+	res, _, err := clientCtx.QueryWithData(route, nil)
+	if err != nil {
+		return err
+	}
+	return clientCtx.PrintBytes(res)
+}
@@ -0,0 +1,68 @@
+package didname
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	cdctypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/gogo/protobuf/proto"
+)
+
+var (
+	amino = codec.NewLegacyAmino()
+
+	// ModuleCdc is the amino codec used for the name service's legacy sign
+	// bytes.
+	ModuleCdc = codec.NewAminoCodec(amino)
+)
+
+func init() {
+	RegisterLegacyAminoCodec(amino)
+	amino.Seal()
+
+	// Like the DID module's, the name service's types are hand-written and
+	// registered under the names a generated aytch/didname/v1 package would
+	// use.
+	proto.RegisterType((*NameRecord)(nil), "aytch.didname.v1.NameRecord")
+	proto.RegisterType((*MsgRegisterName)(nil), "aytch.didname.v1.MsgRegisterName")
+	proto.RegisterType((*MsgRenewName)(nil), "aytch.didname.v1.MsgRenewName")
+	proto.RegisterType((*MsgTransferName)(nil), "aytch.didname.v1.MsgTransferName")
+	proto.RegisterType((*GenesisState)(nil), "aytch.didname.v1.GenesisState")
+}
+
+// RegisterLegacyAminoCodec registers the name service's messages on the given
+// LegacyAmino codec.
+func RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) {
+	cdc.RegisterConcrete(MsgRegisterName{}, "didname/RegisterName", nil)
+	cdc.RegisterConcrete(MsgRenewName{}, "didname/RenewName", nil)
+	cdc.RegisterConcrete(MsgTransferName{}, "didname/TransferName", nil)
+}
+
+// RegisterInterfaces registers the name service's messages as sdk.Msg
+// implementations. They are routed by the legacy handler.
+func RegisterInterfaces(registry cdctypes.InterfaceRegistry) {
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgRegisterName{},
+		&MsgRenewName{},
+		&MsgTransferName{},
+	)
+}
+
+func (m *NameRecord) Reset()         { *m = NameRecord{} }
+func (m *NameRecord) String() string { return proto.CompactTextString(m) }
+func (*NameRecord) ProtoMessage()    {}
+
+func (m *MsgRegisterName) Reset()         { *m = MsgRegisterName{} }
+func (m *MsgRegisterName) String() string { return proto.CompactTextString(m) }
+func (*MsgRegisterName) ProtoMessage()    {}
+
+func (m *MsgRenewName) Reset()         { *m = MsgRenewName{} }
+func (m *MsgRenewName) String() string { return proto.CompactTextString(m) }
+func (*MsgRenewName) ProtoMessage()    {}
+
+func (m *MsgTransferName) Reset()         { *m = MsgTransferName{} }
+func (m *MsgTransferName) String() string { return proto.CompactTextString(m) }
+func (*MsgTransferName) ProtoMessage()    {}
+
+func (m *GenesisState) Reset()         { *m = GenesisState{} }
+func (m *GenesisState) String() string { return proto.CompactTextString(m) }
+func (*GenesisState) ProtoMessage()    {}
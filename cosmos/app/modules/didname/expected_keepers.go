@@ -0,0 +1,14 @@
+package didname
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// DIDKeeper defines the DID registry functionality used to check that names
+// are registered and moved only with the consent of their DIDs, and to
+// collect registration fees.
+type DIDKeeper interface {
+	AuthorizeController(ctx sdk.Context, id string, nonce uint64, signer string, creator sdk.AccAddress, signBytes, signature []byte) error
+	IncrementNonce(ctx sdk.Context, id string) error
+	CollectFee(ctx sdk.Context, payer sdk.AccAddress, fee sdk.Coins) error
+}
@@ -0,0 +1,56 @@
+package didname
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState defines the name service's genesis state.
+type GenesisState struct {
+	Params Params       `protobuf:"bytes,1,opt,name=params,proto3" json:"params"`
+	Names  []NameRecord `protobuf:"bytes,2,rep,name=names,proto3" json:"names"`
+}
+
+// DefaultGenesis returns the default genesis state.
+func DefaultGenesis() *GenesisState {
+	return &GenesisState{Params: DefaultParams()}
+}
+
+// ValidateGenesis performs basic genesis state validation.
+func ValidateGenesis(gs GenesisState) error {
+	if err := gs.Params.Validate(); err != nil {
+		return err
+	}
+	seen := make(map[string]bool, len(gs.Names))
+	for _, r := range gs.Names {
+		if err := ValidateName(r.Name); err != nil {
+			return err
+		}
+		if seen[r.Name] {
+			return fmt.Errorf("duplicate name %s", r.Name)
+		}
+		seen[r.Name] = true
+		if err := validateDID(r.DID); err != nil {
+			return fmt.Errorf("name %s: %w", r.Name, err)
+		}
+	}
+	return nil
+}
+
+// InitGenesis initializes the name service's state from a genesis state.
+func InitGenesis(ctx sdk.Context, k Keeper, gs GenesisState) {
+	k.SetParams(ctx, gs.Params)
+	for _, r := range gs.Names {
+		k.setName(ctx, r)
+	}
+}
+
+// ExportGenesis exports the name service's state, including names that have
+// expired but haven't been registered again.
+func ExportGenesis(ctx sdk.Context, k Keeper) *GenesisState {
+	return &GenesisState{
+		Params: k.GetParams(ctx),
+		Names:  k.GetAllNames(ctx),
+	}
+}
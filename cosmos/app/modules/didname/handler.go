@@ -0,0 +1,92 @@
+package didname
+
+import (
+	"fmt"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// NewHandler creates a handler for name service messages.
+func NewHandler(k Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+		ctx = ctx.WithEventManager(sdk.NewEventManager())
+		switch msg := msg.(type) {
+		case *MsgRegisterName:
+			return handleMsgRegisterName(ctx, k, *msg)
+		case *MsgRenewName:
+			return handleMsgRenewName(ctx, k, *msg)
+		case *MsgTransferName:
+			return handleMsgTransferName(ctx, k, *msg)
+		default:
+			return nil, fmt.Errorf("unrecognized DID name message type: %T", msg)
+		}
+	}
+}
+
+// authorizeDID checks a controller proof for id and consumes its nonce, so
+// the proof can't be replayed.
+func authorizeDID(ctx sdk.Context, k Keeper, id string, nonce uint64, signer string, creator sdk.AccAddress, signBytes, signature []byte) error {
+	if err := k.didKeeper.AuthorizeController(ctx, id, nonce, signer, creator, signBytes, signature); err != nil {
+		return err
+	}
+	return k.didKeeper.IncrementNonce(ctx, id)
+}
+
+func handleMsgRegisterName(ctx sdk.Context, k Keeper, msg MsgRegisterName) (*sdk.Result, error) {
+	if err := authorizeDID(ctx, k, msg.DID, msg.Nonce, msg.Signer, msg.Creator, msg.ProofSignBytes(), msg.Signature); err != nil {
+		return nil, err
+	}
+	if err := k.RegisterName(ctx, msg.Name, msg.DID); err != nil {
+		return nil, err
+	}
+	if err := k.ChargeRegistrationFee(ctx, msg.Creator); err != nil {
+		return nil, err
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		"register_name",
+		sdk.NewAttribute("name", msg.Name),
+		sdk.NewAttribute("did", msg.DID),
+	))
+	return &sdk.Result{Events: ctx.EventManager().ABCIEvents()}, nil
+}
+
+func handleMsgRenewName(ctx sdk.Context, k Keeper, msg MsgRenewName) (*sdk.Result, error) {
+	r, err := k.RenewName(ctx, msg.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := k.ChargeRegistrationFee(ctx, msg.Creator); err != nil {
+		return nil, err
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		"renew_name",
+		sdk.NewAttribute("name", msg.Name),
+		sdk.NewAttribute("expires_at", strconv.FormatInt(r.ExpiresAt, 10)),
+	))
+	return &sdk.Result{Events: ctx.EventManager().ABCIEvents()}, nil
+}
+
+func handleMsgTransferName(ctx sdk.Context, k Keeper, msg MsgTransferName) (*sdk.Result, error) {
+	r, err := k.GetName(ctx, msg.Name)
+	if err != nil {
+		return nil, err
+	}
+	signBytes := msg.ProofSignBytes()
+	if err := authorizeDID(ctx, k, r.DID, msg.Nonce, msg.Signer, msg.Creator, signBytes, msg.Signature); err != nil {
+		return nil, err
+	}
+	if err := authorizeDID(ctx, k, msg.Recipient, msg.RecipientNonce, msg.RecipientSigner, msg.Creator, signBytes, msg.RecipientSignature); err != nil {
+		return nil, fmt.Errorf("recipient: %w", err)
+	}
+	if err := k.TransferName(ctx, msg.Name, msg.Recipient); err != nil {
+		return nil, err
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		"transfer_name",
+		sdk.NewAttribute("name", msg.Name),
+		sdk.NewAttribute("from", r.DID),
+		sdk.NewAttribute("to", msg.Recipient),
+	))
+	return &sdk.Result{Events: ctx.EventManager().ABCIEvents()}, nil
+}
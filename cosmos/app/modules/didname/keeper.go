@@ -0,0 +1,189 @@
+package didname
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
+)
+
+// Store prefixes. Names are stored under NamePrefix and indexed by the DID
+// they resolve to under DIDIndexPrefix for reverse lookups.
+var (
+	NamePrefix     = []byte("name/")
+	DIDIndexPrefix = []byte("did/")
+)
+
+func nameKey(name string) []byte {
+	return append(append([]byte{}, NamePrefix...), name...)
+}
+
+func didIndexPrefix(did string) []byte {
+	return append(append([]byte{}, DIDIndexPrefix...), did+"/"...)
+}
+
+func didIndexKey(did, name string) []byte {
+	return append(didIndexPrefix(did), name...)
+}
+
+// Keeper maintains the name registry.
+type Keeper struct {
+	storeKey   sdk.StoreKey
+	cdc        *codec.LegacyAmino
+	paramSpace paramtypes.Subspace
+	didKeeper  DIDKeeper
+}
+
+// NewKeeper creates a new name service Keeper.
+func NewKeeper(storeKey sdk.StoreKey, cdc *codec.LegacyAmino, paramSpace paramtypes.Subspace, didKeeper DIDKeeper) Keeper {
+	if !paramSpace.HasKeyTable() {
+		paramSpace = paramSpace.WithKeyTable(ParamKeyTable())
+	}
+	return Keeper{
+		storeKey:   storeKey,
+		cdc:        cdc,
+		paramSpace: paramSpace,
+		didKeeper:  didKeeper,
+	}
+}
+
+// GetParams returns the name service parameters.
+func (k Keeper) GetParams(ctx sdk.Context) Params {
+	var params Params
+	k.paramSpace.GetParamSet(ctx, &params)
+	return params
+}
+
+// SetParams sets the name service parameters.
+func (k Keeper) SetParams(ctx sdk.Context, params Params) {
+	k.paramSpace.SetParamSet(ctx, &params)
+}
+
+// ChargeRegistrationFee collects the fee for one registration period from
+// the payer, split like the DID module's fees.
+func (k Keeper) ChargeRegistrationFee(ctx sdk.Context, payer sdk.AccAddress) error {
+	fee := k.GetParams(ctx).RegistrationFee
+	if fee.IsZero() {
+		return nil
+	}
+	return k.didKeeper.CollectFee(ctx, payer, fee)
+}
+
+// RegisterName registers name for did for one registration period. The name
+// must never have been registered or be past its grace period, in which case
+// the previous registration is dropped.
+func (k Keeper) RegisterName(ctx sdk.Context, name, did string) error {
+	params := k.GetParams(ctx)
+	if existing, err := k.GetName(ctx, name); err == nil {
+		if ctx.BlockHeight() < existing.ExpiresAt+int64(params.GracePeriod) {
+			return fmt.Errorf("name %s is already registered", name)
+		}
+		k.deleteName(ctx, existing)
+	}
+	k.setName(ctx, NameRecord{
+		Name:      name,
+		DID:       did,
+		ExpiresAt: ctx.BlockHeight() + int64(params.RegistrationPeriod),
+		Created:   ctx.BlockHeight(),
+	})
+	return nil
+}
+
+// RenewName extends name by one registration period from its current expiry,
+// so renewing during the grace period doesn't gain the name extra time.
+func (k Keeper) RenewName(ctx sdk.Context, name string) (NameRecord, error) {
+	params := k.GetParams(ctx)
+	r, err := k.GetName(ctx, name)
+	if err != nil {
+		return NameRecord{}, err
+	}
+	if ctx.BlockHeight() >= r.ExpiresAt+int64(params.GracePeriod) {
+		return NameRecord{}, fmt.Errorf("name %s expired and its grace period has passed", name)
+	}
+	r.ExpiresAt += int64(params.RegistrationPeriod)
+	k.setName(ctx, r)
+	return r, nil
+}
+
+// TransferName points an active name at recipient.
+func (k Keeper) TransferName(ctx sdk.Context, name, recipient string) error {
+	r, err := k.GetName(ctx, name)
+	if err != nil {
+		return err
+	}
+	if !r.Active(ctx.BlockHeight()) {
+		return fmt.Errorf("name %s has expired", name)
+	}
+	if r.DID == recipient {
+		return fmt.Errorf("name %s already belongs to %s", name, recipient)
+	}
+	k.deleteName(ctx, r)
+	r.DID = recipient
+	k.setName(ctx, r)
+	return nil
+}
+
+// GetName returns the registration of name, whether or not it has expired.
+func (k Keeper) GetName(ctx sdk.Context, name string) (NameRecord, error) {
+	value := ctx.KVStore(k.storeKey).Get(nameKey(name))
+	if value == nil {
+		return NameRecord{}, fmt.Errorf("name %s not found", name)
+	}
+	var r NameRecord
+	k.cdc.MustUnmarshal(value, &r)
+	return r, nil
+}
+
+// ResolveName returns the DID an active name resolves to.
+func (k Keeper) ResolveName(ctx sdk.Context, name string) (string, error) {
+	r, err := k.GetName(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	if !r.Active(ctx.BlockHeight()) {
+		return "", fmt.Errorf("name %s has expired", name)
+	}
+	return r.DID, nil
+}
+
+// NamesByDID returns the active names that resolve to did, in name order.
+func (k Keeper) NamesByDID(ctx sdk.Context, did string) []NameRecord {
+	var names []NameRecord
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), didIndexPrefix(did))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		r, err := k.GetName(ctx, string(iter.Key()[len(didIndexPrefix(did)):]))
+		if err == nil && r.Active(ctx.BlockHeight()) {
+			names = append(names, r)
+		}
+	}
+	return names
+}
+
+// GetAllNames returns every registration in the store, including expired
+// ones.
+func (k Keeper) GetAllNames(ctx sdk.Context) []NameRecord {
+	var names []NameRecord
+	iter := prefix.NewStore(ctx.KVStore(k.storeKey), NamePrefix).Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var r NameRecord
+		k.cdc.MustUnmarshal(iter.Value(), &r)
+		names = append(names, r)
+	}
+	return names
+}
+
+func (k Keeper) setName(ctx sdk.Context, r NameRecord) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(nameKey(r.Name), k.cdc.MustMarshal(&r))
+	store.Set(didIndexKey(r.DID, r.Name), []byte{})
+}
+
+func (k Keeper) deleteName(ctx sdk.Context, r NameRecord) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(nameKey(r.Name))
+	store.Delete(didIndexKey(r.DID, r.Name))
+}
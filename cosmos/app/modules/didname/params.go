@@ -0,0 +1,96 @@
+package didname
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
+	"github.com/gogo/protobuf/proto"
+)
+
+// Parameter store keys.
+var (
+	KeyRegistrationFee    = []byte("RegistrationFee")
+	KeyRegistrationPeriod = []byte("RegistrationPeriod")
+	KeyGracePeriod        = []byte("GracePeriod")
+)
+
+// Params defines the governance-controlled parameters of the name service.
+// RegistrationFee is charged for every RegistrationPeriod blocks a name is
+// registered or renewed for and is split like the DID module's fees. An
+// expired name can still be renewed for GracePeriod blocks before anyone may
+// register it.
+type Params struct {
+	RegistrationFee    sdk.Coins `protobuf:"bytes,1,rep,name=registration_fee,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"registration_fee"`
+	RegistrationPeriod uint64    `protobuf:"varint,2,opt,name=registration_period,proto3" json:"registration_period"`
+	GracePeriod        uint64    `protobuf:"varint,3,opt,name=grace_period,proto3" json:"grace_period"`
+}
+
+func init() {
+	proto.RegisterType((*Params)(nil), "aytch.didname.v1.Params")
+}
+
+func (m *Params) Reset()         { *m = Params{} }
+func (m *Params) String() string { return proto.CompactTextString(m) }
+func (*Params) ProtoMessage()    {}
+
+// ParamKeyTable returns the key table for the name service's parameters.
+func ParamKeyTable() paramtypes.KeyTable {
+	return paramtypes.NewKeyTable().RegisterParamSet(&Params{})
+}
+
+// DefaultParams returns the default parameters: names are free and are
+// registered for about a year at 6 second blocks, with a month of grace.
+func DefaultParams() Params {
+	return Params{
+		RegistrationFee:    sdk.NewCoins(),
+		RegistrationPeriod: 5256000,
+		GracePeriod:        432000,
+	}
+}
+
+// ParamSetPairs implements paramtypes.ParamSet.
+func (p *Params) ParamSetPairs() paramtypes.ParamSetPairs {
+	return paramtypes.ParamSetPairs{
+		paramtypes.NewParamSetPair(KeyRegistrationFee, &p.RegistrationFee, validateRegistrationFee),
+		paramtypes.NewParamSetPair(KeyRegistrationPeriod, &p.RegistrationPeriod, validatePositiveUint64),
+		paramtypes.NewParamSetPair(KeyGracePeriod, &p.GracePeriod, validateUint64),
+	}
+}
+
+// Validate performs basic validation of the parameters.
+func (p Params) Validate() error {
+	if err := validateRegistrationFee(p.RegistrationFee); err != nil {
+		return err
+	}
+	if err := validatePositiveUint64(p.RegistrationPeriod); err != nil {
+		return err
+	}
+	return validateUint64(p.GracePeriod)
+}
+
+func validateRegistrationFee(i interface{}) error {
+	fee, ok := i.(sdk.Coins)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return fee.Validate()
+}
+
+func validateUint64(i interface{}) error {
+	if _, ok := i.(uint64); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil
+}
+
+func validatePositiveUint64(i interface{}) error {
+	v, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if v == 0 {
+		return fmt.Errorf("parameter must be positive")
+	}
+	return nil
+}
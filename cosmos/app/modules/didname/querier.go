@@ -0,0 +1,60 @@
+package didname
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// Query endpoints supported by the name service querier.
+const (
+	QueryName      = "name"
+	QueryResolve   = "resolve"
+	QueryNamesByID = "names"
+	QueryParams    = "params"
+)
+
+// NameResolution is the answer to a QueryResolve.
+type NameResolution struct {
+	Name      string `json:"name"`
+	DID       string `json:"did"`
+	ExpiresAt int64  `json:"expires_at"`
+}
+
+// NewQuerier creates a legacy querier for the name service. Results are
+// encoded as plain JSON, like the DID module's.
+func NewQuerier(k Keeper, _ *codec.LegacyAmino) sdk.Querier {
+	return func(ctx sdk.Context, path []string, _ abci.RequestQuery) ([]byte, error) {
+		if len(path) == 0 {
+			return nil, fmt.Errorf("empty DID name query path")
+		}
+		switch {
+		case path[0] == QueryName && len(path) == 2:
+			r, err := k.GetName(ctx, path[1])
+			if err != nil {
+				return nil, err
+			}
+			return json.MarshalIndent(r, "", "  ")
+		case path[0] == QueryResolve && len(path) == 2:
+			did, err := k.ResolveName(ctx, path[1])
+			if err != nil {
+				return nil, err
+			}
+			r, _ := k.GetName(ctx, path[1])
+			return json.MarshalIndent(NameResolution{Name: r.Name, DID: did, ExpiresAt: r.ExpiresAt}, "", "  ")
+		case path[0] == QueryNamesByID && len(path) == 2:
+			names := k.NamesByDID(ctx, path[1])
+			if names == nil {
+				names = []NameRecord{}
+			}
+			return json.MarshalIndent(names, "", "  ")
+		case path[0] == QueryParams:
+			return json.MarshalIndent(k.GetParams(ctx), "", "  ")
+		default:
+			return nil, fmt.Errorf("unknown DID name query path %v", path)
+		}
+	}
+}
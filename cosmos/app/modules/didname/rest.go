@@ -0,0 +1,40 @@
+package didname
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/gorilla/mux"
+)
+
+// RegisterRoutes registers the name service's REST routes.
+func RegisterRoutes(cliCtx client.Context, r *mux.Router) {
+	r.HandleFunc("/didname/names/{name}", queryHandler(cliCtx, QueryName)).Methods("GET")
+	r.HandleFunc("/didname/resolve/{name}", queryHandler(cliCtx, QueryResolve)).Methods("GET")
+	r.HandleFunc("/didname/dids/{did}/names", namesByDIDHandler(cliCtx)).Methods("GET")
+}
+
+func queryHandler(cliCtx client.Context, query string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", ModuleName, query, mux.Vars(r)["name"]), nil)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(res)
+	}
+}
+
+func namesByDIDHandler(cliCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", ModuleName, QueryNamesByID, mux.Vars(r)["did"]), nil)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(res)
+	}
+}
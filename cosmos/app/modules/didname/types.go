@@ -0,0 +1,222 @@
+package didname
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	didmodule "cosmos-app/modules/did"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// Module identifiers for the DID name service.
+const (
+	ModuleName = "didname"
+	StoreKey   = ModuleName
+	RouterKey  = ModuleName
+
+	// NameSuffix ends every registered name.
+	NameSuffix = ".aytch"
+
+	minLabelLength = 3
+	maxLabelLength = 63
+)
+
+// NameRecord maps a human-readable name to a DID. The name belongs to the
+// DID it resolves to: registering, renewing onto a new DID and transferring
+// it need the proof of control the DID module requires for document changes.
+// A name stops resolving at ExpiresAt and can be registered by anyone once
+// the grace period after it has passed, unless it is renewed first.
+type NameRecord struct {
+	Name      string `protobuf:"bytes,1,opt,name=name,proto3" json:"name"`
+	DID       string `protobuf:"bytes,2,opt,name=did,proto3" json:"did"`
+	ExpiresAt int64  `protobuf:"varint,3,opt,name=expires_at,proto3" json:"expires_at"`
+	Created   int64  `protobuf:"varint,4,opt,name=created,proto3" json:"created"`
+}
+
+// Active reports whether the name resolves at height.
+func (r NameRecord) Active(height int64) bool {
+	return height < r.ExpiresAt
+}
+
+// ValidateName checks that name is a single lowercase DNS-style label
+// followed by NameSuffix, e.g. alice.aytch. Restricting names to ASCII keeps
+// look-alike names from impersonating each other.
+func ValidateName(name string) error {
+	if !strings.HasSuffix(name, NameSuffix) {
+		return fmt.Errorf("name %q must end in %s", name, NameSuffix)
+	}
+	label := strings.TrimSuffix(name, NameSuffix)
+	if len(label) < minLabelLength || len(label) > maxLabelLength {
+		return fmt.Errorf("name %q must have between %d and %d characters before %s", name, minLabelLength, maxLabelLength, NameSuffix)
+	}
+	for i, r := range label {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+		case r == '-' && i != 0 && i != len(label)-1:
+		default:
+			return fmt.Errorf("name %q may only contain lowercase letters, digits and inner hyphens", name)
+		}
+	}
+	return nil
+}
+
+func validateDID(id string) error {
+	if !strings.HasPrefix(id, didmodule.DIDMethodPrefix) || id == didmodule.DIDMethodPrefix {
+		return fmt.Errorf("DID must start with %s", didmodule.DIDMethodPrefix)
+	}
+	return didmodule.ValidateChecksum(id)
+}
+
+// proofSignBytes returns the sorted JSON of a message with its controller
+// signatures cleared, which the controllers sign.
+func proofSignBytes(msg interface{}) []byte {
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// MsgRegisterName registers an available name for a DID. Signer proves
+// control of the DID as for a DID document change, and Creator pays the
+// registration fee for one period.
+type MsgRegisterName struct {
+	Name      string         `protobuf:"bytes,1,opt,name=name,proto3" json:"name"`
+	DID       string         `protobuf:"bytes,2,opt,name=did,proto3" json:"did"`
+	Nonce     uint64         `protobuf:"varint,3,opt,name=nonce,proto3" json:"nonce"`
+	Signer    string         `protobuf:"bytes,4,opt,name=signer,proto3" json:"signer"`
+	Signature []byte         `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
+	Creator   sdk.AccAddress `protobuf:"bytes,6,opt,name=creator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"creator"`
+}
+
+// ValidateBasic performs basic validation of MsgRegisterName.
+func (msg MsgRegisterName) ValidateBasic() error {
+	if err := ValidateName(msg.Name); err != nil {
+		return sdkerrors.ErrUnknownRequest.Wrap(err.Error())
+	}
+	if err := validateDID(msg.DID); err != nil {
+		return sdkerrors.ErrUnknownRequest.Wrap(err.Error())
+	}
+	if msg.Signer == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Signer cannot be empty")
+	}
+	if msg.Creator.Empty() {
+		return sdkerrors.ErrUnknownRequest.Wrap("Creator cannot be empty")
+	}
+	return nil
+}
+
+// ProofSignBytes returns the bytes the DID controller signs.
+func (msg MsgRegisterName) ProofSignBytes() []byte {
+	msg.Signature = nil
+	return proofSignBytes(msg)
+}
+
+// Route returns the message route.
+func (msg MsgRegisterName) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgRegisterName) Type() string { return "register_name" }
+
+// GetSignBytes returns the canonical bytes to sign over.
+func (msg MsgRegisterName) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners returns the account that must sign the message.
+func (msg MsgRegisterName) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
+
+// MsgRenewName extends a name by one registration period. Anyone may pay for
+// a renewal, up to the end of the name's grace period.
+type MsgRenewName struct {
+	Name    string         `protobuf:"bytes,1,opt,name=name,proto3" json:"name"`
+	Creator sdk.AccAddress `protobuf:"bytes,2,opt,name=creator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"creator"`
+}
+
+// ValidateBasic performs basic validation of MsgRenewName.
+func (msg MsgRenewName) ValidateBasic() error {
+	if err := ValidateName(msg.Name); err != nil {
+		return sdkerrors.ErrUnknownRequest.Wrap(err.Error())
+	}
+	if msg.Creator.Empty() {
+		return sdkerrors.ErrUnknownRequest.Wrap("Creator cannot be empty")
+	}
+	return nil
+}
+
+// Route returns the message route.
+func (msg MsgRenewName) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgRenewName) Type() string { return "renew_name" }
+
+// GetSignBytes returns the canonical bytes to sign over.
+func (msg MsgRenewName) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners returns the account that must sign the message.
+func (msg MsgRenewName) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
+
+// MsgTransferName points a name at another DID. Both DIDs must consent: Signer
+// proves control of the DID the name belongs to and RecipientSigner of the
+// DID it moves to, so nobody can attach a name to a DID that didn't ask for
+// it. Both sign the same proof sign bytes.
+type MsgTransferName struct {
+	Name               string         `protobuf:"bytes,1,opt,name=name,proto3" json:"name"`
+	Recipient          string         `protobuf:"bytes,2,opt,name=recipient,proto3" json:"recipient"`
+	Nonce              uint64         `protobuf:"varint,3,opt,name=nonce,proto3" json:"nonce"`
+	Signer             string         `protobuf:"bytes,4,opt,name=signer,proto3" json:"signer"`
+	Signature          []byte         `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
+	RecipientNonce     uint64         `protobuf:"varint,6,opt,name=recipient_nonce,proto3" json:"recipient_nonce"`
+	RecipientSigner    string         `protobuf:"bytes,7,opt,name=recipient_signer,proto3" json:"recipient_signer"`
+	RecipientSignature []byte         `protobuf:"bytes,8,opt,name=recipient_signature,proto3" json:"recipient_signature,omitempty"`
+	Creator            sdk.AccAddress `protobuf:"bytes,9,opt,name=creator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"creator"`
+}
+
+// ValidateBasic performs basic validation of MsgTransferName.
+func (msg MsgTransferName) ValidateBasic() error {
+	if err := ValidateName(msg.Name); err != nil {
+		return sdkerrors.ErrUnknownRequest.Wrap(err.Error())
+	}
+	if err := validateDID(msg.Recipient); err != nil {
+		return sdkerrors.ErrUnknownRequest.Wrap(err.Error())
+	}
+	if msg.Signer == "" || msg.RecipientSigner == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Signer and recipient signer cannot be empty")
+	}
+	if msg.Creator.Empty() {
+		return sdkerrors.ErrUnknownRequest.Wrap("Creator cannot be empty")
+	}
+	return nil
+}
+
+// ProofSignBytes returns the bytes both DID controllers sign.
+func (msg MsgTransferName) ProofSignBytes() []byte {
+	msg.Signature = nil
+	msg.RecipientSignature = nil
+	return proofSignBytes(msg)
+}
+
+// Route returns the message route.
+func (msg MsgTransferName) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgTransferName) Type() string { return "transfer_name" }
+
+// GetSignBytes returns the canonical bytes to sign over.
+func (msg MsgTransferName) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners returns the account that must sign the message.
+func (msg MsgTransferName) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
@@ -0,0 +1,42 @@
+package didresolution
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	cdctypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/gogo/protobuf/proto"
+)
+
+var (
+	amino = codec.NewLegacyAmino()
+
+	// ModuleCdc is the amino codec used for the module's legacy sign bytes.
+	ModuleCdc = codec.NewAminoCodec(amino)
+)
+
+func init() {
+	RegisterLegacyAminoCodec(amino)
+	amino.Seal()
+
+	// Like the DID module's, MsgQueryDID is hand-written and registered
+	// under the name a generated aytch/didresolution/v1 package would use.
+	proto.RegisterType((*MsgQueryDID)(nil), "aytch.didresolution.v1.MsgQueryDID")
+}
+
+// RegisterLegacyAminoCodec registers the module's messages on the given
+// LegacyAmino codec.
+func RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) {
+	cdc.RegisterConcrete(MsgQueryDID{}, "didresolution/QueryDID", nil)
+}
+
+// RegisterInterfaces registers the module's messages as sdk.Msg
+// implementations. They are routed by the legacy handler.
+func RegisterInterfaces(registry cdctypes.InterfaceRegistry) {
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgQueryDID{},
+	)
+}
+
+func (m *MsgQueryDID) Reset()         { *m = MsgQueryDID{} }
+func (m *MsgQueryDID) String() string { return proto.CompactTextString(m) }
+func (*MsgQueryDID) ProtoMessage()    {}
@@ -0,0 +1,21 @@
+package didresolution
+
+import "fmt"
+
+// GenesisState defines the DID resolution module's genesis state.
+type GenesisState struct {
+	PortID string `json:"port_id"`
+}
+
+// DefaultGenesis returns the default genesis state.
+func DefaultGenesis() *GenesisState {
+	return &GenesisState{PortID: PortID}
+}
+
+// ValidateGenesis performs basic genesis state validation.
+func ValidateGenesis(gs GenesisState) error {
+	if gs.PortID == "" {
+		return fmt.Errorf("port ID cannot be empty")
+	}
+	return nil
+}
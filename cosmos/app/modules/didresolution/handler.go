@@ -0,0 +1,33 @@
+package didresolution
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// NewHandler creates a handler for DID resolution messages.
+func NewHandler(k Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+		switch msg := msg.(type) {
+		case *MsgQueryDID:
+			return handleMsgQueryDID(ctx, k, *msg)
+		default:
+			return nil, fmt.Errorf("unrecognized DID resolution message type: %T", msg)
+		}
+	}
+}
+
+func handleMsgQueryDID(ctx sdk.Context, k Keeper, msg MsgQueryDID) (*sdk.Result, error) {
+	sequence, err := k.SendQueryDID(ctx, msg.SourcePort, msg.SourceChannel, msg.DID, msg.TimeoutTimestamp)
+	if err != nil {
+		return nil, err
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		"query_did",
+		sdk.NewAttribute("did", msg.DID),
+		sdk.NewAttribute("channel", msg.SourceChannel),
+		sdk.NewAttribute("sequence", fmt.Sprintf("%d", sequence)),
+	))
+	return &sdk.Result{Events: ctx.EventManager().ABCIEvents()}, nil
+}
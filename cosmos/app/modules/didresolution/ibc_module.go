@@ -0,0 +1,123 @@
+package didresolution
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	capabilitytypes "github.com/cosmos/cosmos-sdk/x/capability/types"
+	channeltypes "github.com/cosmos/ibc-go/v4/modules/core/04-channel/types"
+	porttypes "github.com/cosmos/ibc-go/v4/modules/core/05-port/types"
+	host "github.com/cosmos/ibc-go/v4/modules/core/24-host"
+	ibcexported "github.com/cosmos/ibc-go/v4/modules/core/exported"
+)
+
+var _ porttypes.IBCModule = IBCModule{}
+
+// IBCModule implements the ICS-26 callbacks for the didresolution port.
+type IBCModule struct {
+	keeper Keeper
+}
+
+// NewIBCModule creates a new IBCModule for the given keeper.
+func NewIBCModule(k Keeper) IBCModule {
+	return IBCModule{keeper: k}
+}
+
+func validateChannel(order channeltypes.Order, portID string) error {
+	if order != channeltypes.UNORDERED {
+		return fmt.Errorf("expected %s channel, got %s", channeltypes.UNORDERED, order)
+	}
+	if portID != PortID {
+		return fmt.Errorf("invalid port %s, expected %s", portID, PortID)
+	}
+	return nil
+}
+
+// OnChanOpenInit implements the IBCModule interface. An empty version
+// proposes Version.
+func (im IBCModule) OnChanOpenInit(ctx sdk.Context, order channeltypes.Order, connectionHops []string, portID string, channelID string, chanCap *capabilitytypes.Capability, counterparty channeltypes.Counterparty, version string) (string, error) {
+	if err := validateChannel(order, portID); err != nil {
+		return "", err
+	}
+	if version == "" {
+		version = Version
+	}
+	if version != Version {
+		return "", fmt.Errorf("invalid version %s, expected %s", version, Version)
+	}
+	if err := im.keeper.ClaimCapability(ctx, chanCap, host.ChannelCapabilityPath(portID, channelID)); err != nil {
+		return "", err
+	}
+	return version, nil
+}
+
+// OnChanOpenTry implements the IBCModule interface.
+func (im IBCModule) OnChanOpenTry(ctx sdk.Context, order channeltypes.Order, connectionHops []string, portID, channelID string, chanCap *capabilitytypes.Capability, counterparty channeltypes.Counterparty, counterpartyVersion string) (string, error) {
+	if err := validateChannel(order, portID); err != nil {
+		return "", err
+	}
+	if counterpartyVersion != Version {
+		return "", fmt.Errorf("invalid counterparty version %s, expected %s", counterpartyVersion, Version)
+	}
+	if err := im.keeper.ClaimCapability(ctx, chanCap, host.ChannelCapabilityPath(portID, channelID)); err != nil {
+		return "", err
+	}
+	return Version, nil
+}
+
+// OnChanOpenAck implements the IBCModule interface.
+func (im IBCModule) OnChanOpenAck(ctx sdk.Context, portID, channelID string, counterpartyChannelID string, counterpartyVersion string) error {
+	if counterpartyVersion != Version {
+		return fmt.Errorf("invalid counterparty version %s, expected %s", counterpartyVersion, Version)
+	}
+	return nil
+}
+
+// OnChanOpenConfirm implements the IBCModule interface.
+func (im IBCModule) OnChanOpenConfirm(ctx sdk.Context, portID, channelID string) error {
+	return nil
+}
+
+// OnChanCloseInit implements the IBCModule interface.
+func (im IBCModule) OnChanCloseInit(ctx sdk.Context, portID, channelID string) error {
+	return fmt.Errorf("didresolution channels cannot be closed by users")
+}
+
+// OnChanCloseConfirm implements the IBCModule interface.
+func (im IBCModule) OnChanCloseConfirm(ctx sdk.Context, portID, channelID string) error {
+	return nil
+}
+
+// OnRecvPacket resolves the requested DID and returns the document in the
+// acknowledgement.
+func (im IBCModule) OnRecvPacket(ctx sdk.Context, packet channeltypes.Packet, relayer sdk.AccAddress) ibcexported.Acknowledgement {
+	var data QueryDIDPacketData
+	if err := json.Unmarshal(packet.GetData(), &data); err != nil {
+		return channeltypes.NewErrorAcknowledgement(sdkerrors.ErrInvalidRequest.Wrapf("cannot unmarshal query packet: %s", err))
+	}
+	result, err := im.keeper.OnRecvQueryDID(ctx, data)
+	if err != nil {
+		return channeltypes.NewErrorAcknowledgement(err)
+	}
+	bz, err := json.Marshal(result)
+	if err != nil {
+		return channeltypes.NewErrorAcknowledgement(err)
+	}
+	return channeltypes.NewResultAcknowledgement(bz)
+}
+
+// OnAcknowledgementPacket stores the resolution result of an outgoing query.
+func (im IBCModule) OnAcknowledgementPacket(ctx sdk.Context, packet channeltypes.Packet, acknowledgement []byte, relayer sdk.AccAddress) error {
+	var ack channeltypes.Acknowledgement
+	if err := channeltypes.SubModuleCdc.UnmarshalJSON(acknowledgement, &ack); err != nil {
+		return fmt.Errorf("cannot unmarshal acknowledgement: %w", err)
+	}
+	return im.keeper.OnAcknowledgeQueryDID(ctx, packet, ack)
+}
+
+// OnTimeoutPacket marks an outgoing query as timed out.
+func (im IBCModule) OnTimeoutPacket(ctx sdk.Context, packet channeltypes.Packet, relayer sdk.AccAddress) error {
+	return im.keeper.OnTimeoutQueryDID(ctx, packet)
+}
@@ -0,0 +1,167 @@
+package didresolution
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	capabilitytypes "github.com/cosmos/cosmos-sdk/x/capability/types"
+	clienttypes "github.com/cosmos/ibc-go/v4/modules/core/02-client/types"
+	channeltypes "github.com/cosmos/ibc-go/v4/modules/core/04-channel/types"
+	host "github.com/cosmos/ibc-go/v4/modules/core/24-host"
+)
+
+// ResolutionPrefix is the store prefix for results of outgoing queries.
+var ResolutionPrefix = []byte("resolution/")
+
+func resolutionKey(channelID string, sequence uint64) []byte {
+	seq := make([]byte, 8)
+	binary.BigEndian.PutUint64(seq, sequence)
+	key := append(append([]byte{}, ResolutionPrefix...), []byte(channelID+"/")...)
+	return append(key, seq...)
+}
+
+// Keeper handles IBC DID resolution requests and results.
+type Keeper struct {
+	storeKey      sdk.StoreKey
+	cdc           *codec.LegacyAmino
+	channelKeeper ChannelKeeper
+	portKeeper    PortKeeper
+	scopedKeeper  ScopedKeeper
+	didKeeper     DIDKeeper
+}
+
+// NewKeeper creates a new DID resolution Keeper.
+func NewKeeper(storeKey sdk.StoreKey, cdc *codec.LegacyAmino, channelKeeper ChannelKeeper, portKeeper PortKeeper, scopedKeeper ScopedKeeper, didKeeper DIDKeeper) Keeper {
+	return Keeper{
+		storeKey:      storeKey,
+		cdc:           cdc,
+		channelKeeper: channelKeeper,
+		portKeeper:    portKeeper,
+		scopedKeeper:  scopedKeeper,
+		didKeeper:     didKeeper,
+	}
+}
+
+// BindPort binds the module's port and claims its capability.
+func (k Keeper) BindPort(ctx sdk.Context, portID string) error {
+	if _, ok := k.scopedKeeper.GetCapability(ctx, host.PortPath(portID)); ok {
+		return nil
+	}
+	cap := k.portKeeper.BindPort(ctx, portID)
+	return k.ClaimCapability(ctx, cap, host.PortPath(portID))
+}
+
+// ClaimCapability claims a capability passed to the module by core IBC.
+func (k Keeper) ClaimCapability(ctx sdk.Context, cap *capabilitytypes.Capability, name string) error {
+	return k.scopedKeeper.ClaimCapability(ctx, cap, name)
+}
+
+// SendQueryDID sends a QueryDIDPacketData and returns its sequence.
+func (k Keeper) SendQueryDID(ctx sdk.Context, sourcePort, sourceChannel, did string, timeoutTimestamp uint64) (uint64, error) {
+	channel, found := k.channelKeeper.GetChannel(ctx, sourcePort, sourceChannel)
+	if !found {
+		return 0, fmt.Errorf("channel %s/%s not found", sourcePort, sourceChannel)
+	}
+	sequence, found := k.channelKeeper.GetNextSequenceSend(ctx, sourcePort, sourceChannel)
+	if !found {
+		return 0, fmt.Errorf("next send sequence for %s/%s not found", sourcePort, sourceChannel)
+	}
+	chanCap, ok := k.scopedKeeper.GetCapability(ctx, host.ChannelCapabilityPath(sourcePort, sourceChannel))
+	if !ok {
+		return 0, fmt.Errorf("module does not own channel capability")
+	}
+
+	data := QueryDIDPacketData{DID: did}
+	packet := channeltypes.NewPacket(
+		data.GetBytes(),
+		sequence,
+		sourcePort,
+		sourceChannel,
+		channel.Counterparty.PortId,
+		channel.Counterparty.ChannelId,
+		clienttypes.ZeroHeight(),
+		timeoutTimestamp,
+	)
+	if err := k.channelKeeper.SendPacket(ctx, chanCap, packet); err != nil {
+		return 0, err
+	}
+	k.setResolution(ctx, Resolution{DID: did, ChannelID: sourceChannel, Sequence: sequence})
+	return sequence, nil
+}
+
+// OnRecvQueryDID resolves the requested DID for a counterparty chain.
+func (k Keeper) OnRecvQueryDID(ctx sdk.Context, data QueryDIDPacketData) (QueryDIDAcknowledgement, error) {
+	if err := data.ValidateBasic(); err != nil {
+		return QueryDIDAcknowledgement{}, err
+	}
+	doc, err := k.didKeeper.GetDID(ctx, data.DID)
+	if err != nil {
+		return QueryDIDAcknowledgement{}, err
+	}
+	bz, err := json.Marshal(doc)
+	if err != nil {
+		return QueryDIDAcknowledgement{}, err
+	}
+	sum := sha256.Sum256(bz)
+	return QueryDIDAcknowledgement{
+		Document:     doc,
+		DocumentHash: hex.EncodeToString(sum[:]),
+		Height:       ctx.BlockHeight(),
+	}, nil
+}
+
+// OnAcknowledgeQueryDID records the outcome of an outgoing query.
+func (k Keeper) OnAcknowledgeQueryDID(ctx sdk.Context, packet channeltypes.Packet, ack channeltypes.Acknowledgement) error {
+	res, err := k.GetResolution(ctx, packet.SourceChannel, packet.Sequence)
+	if err != nil {
+		return err
+	}
+	if !ack.Success() {
+		res.Error = ack.GetError()
+		k.setResolution(ctx, res)
+		return nil
+	}
+	var result QueryDIDAcknowledgement
+	if err := json.Unmarshal(ack.GetResult(), &result); err != nil {
+		return fmt.Errorf("cannot unmarshal query acknowledgement: %w", err)
+	}
+	res.Resolved = true
+	res.Document = result.Document
+	res.DocumentHash = result.DocumentHash
+	res.HostHeight = result.Height
+	k.setResolution(ctx, res)
+	return nil
+}
+
+// OnTimeoutQueryDID marks an outgoing query as timed out.
+func (k Keeper) OnTimeoutQueryDID(ctx sdk.Context, packet channeltypes.Packet) error {
+	res, err := k.GetResolution(ctx, packet.SourceChannel, packet.Sequence)
+	if err != nil {
+		return err
+	}
+	res.Error = "packet timed out"
+	k.setResolution(ctx, res)
+	return nil
+}
+
+// GetResolution returns the stored result of an outgoing query.
+func (k Keeper) GetResolution(ctx sdk.Context, channelID string, sequence uint64) (Resolution, error) {
+	store := ctx.KVStore(k.storeKey)
+	value := store.Get(resolutionKey(channelID, sequence))
+	if value == nil {
+		return Resolution{}, fmt.Errorf("resolution not found")
+	}
+	var res Resolution
+	k.cdc.MustUnmarshal(value, &res)
+	return res, nil
+}
+
+func (k Keeper) setResolution(ctx sdk.Context, res Resolution) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(resolutionKey(res.ChannelID, res.Sequence), k.cdc.MustMarshal(&res))
+}
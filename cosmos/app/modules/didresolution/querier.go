@@ -0,0 +1,32 @@
+package didresolution
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// QueryResolution returns the result of an outgoing query by channel and sequence.
+const QueryResolution = "resolution"
+
+// NewQuerier creates a legacy querier for the DID resolution module.
+func NewQuerier(k Keeper, _ *codec.LegacyAmino) sdk.Querier {
+	return func(ctx sdk.Context, path []string, _ abci.RequestQuery) ([]byte, error) {
+		if len(path) != 3 || path[0] != QueryResolution {
+			return nil, fmt.Errorf("expected query path %s/<channel>/<sequence>", QueryResolution)
+		}
+		sequence, err := strconv.ParseUint(path[2], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sequence: %w", err)
+		}
+		res, err := k.GetResolution(ctx, path[1], sequence)
+		if err != nil {
+			return nil, err
+		}
+		return json.MarshalIndent(res, "", "  ")
+	}
+}
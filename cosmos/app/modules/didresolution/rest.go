@@ -0,0 +1,41 @@
+package didresolution
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	didmodule "cosmos-app/modules/did"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/gorilla/mux"
+)
+
+func RegisterRoutes(cliCtx client.Context, r *mux.Router) {
+	r.HandleFunc("/didresolution/query", queryDIDHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/didresolution/resolutions/{channel}/{sequence}", resolutionHandler(cliCtx)).Methods("GET")
+}
+
+// queryDIDHandler builds or broadcasts a MsgQueryDID transaction; the body
+// is a did.TxRequest.
+func queryDIDHandler(cliCtx client.Context) http.HandlerFunc {
+	return didmodule.TxHandler(cliCtx, func(body json.RawMessage, from sdk.AccAddress) (sdk.Msg, error) {
+		var msg MsgQueryDID
+		err := json.Unmarshal(body, &msg)
+		msg.Creator = from
+		return &msg, err
+	})
+}
+
+func resolutionHandler(cliCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s/%s", ModuleName, QueryResolution, vars["channel"], vars["sequence"]), nil)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		w.Write(res)
+	}
+}
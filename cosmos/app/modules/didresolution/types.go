@@ -0,0 +1,107 @@
+package didresolution
+
+import (
+	"encoding/json"
+	"fmt"
+
+	didmodule "cosmos-app/modules/did"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// Module identifiers for the DID resolution IBC application.
+const (
+	ModuleName = "didresolution"
+	StoreKey   = ModuleName
+	PortID     = "didresolution"
+	Version    = "didresolution-1"
+)
+
+// QueryDIDPacketData is sent by a counterparty chain to resolve a did:aytch
+// document.
+type QueryDIDPacketData struct {
+	DID string `json:"did"`
+}
+
+// ValidateBasic performs basic validation of the packet data.
+func (p QueryDIDPacketData) ValidateBasic() error {
+	if p.DID == "" {
+		return fmt.Errorf("DID cannot be empty")
+	}
+	return nil
+}
+
+// GetBytes returns the sorted JSON encoding of the packet data.
+func (p QueryDIDPacketData) GetBytes() []byte {
+	bz, err := json.Marshal(p)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// QueryDIDAcknowledgement is returned in the result acknowledgement of a
+// QueryDIDPacketData. The acknowledgement is committed to the host chain's
+// state, so the relayer has to prove it against the host's light client on
+// the requesting chain, which makes the document as trustworthy as the
+// client. DocumentHash lets the requester pin the exact document version.
+type QueryDIDAcknowledgement struct {
+	Document     didmodule.DIDDocument `json:"document"`
+	DocumentHash string                `json:"document_hash"`
+	Height       int64                 `json:"height"`
+}
+
+// Resolution is a stored result of a cross-chain DID query.
+type Resolution struct {
+	DID          string                `json:"did"`
+	ChannelID    string                `json:"channel_id"`
+	Sequence     uint64                `json:"sequence"`
+	Resolved     bool                  `json:"resolved"`
+	Error        string                `json:"error,omitempty"`
+	Document     didmodule.DIDDocument `json:"document"`
+	DocumentHash string                `json:"document_hash"`
+	HostHeight   int64                 `json:"host_height"`
+}
+
+// MsgQueryDID sends a QueryDIDPacketData over the given channel.
+type MsgQueryDID struct {
+	SourcePort       string         `protobuf:"bytes,1,opt,name=source_port,proto3" json:"source_port"`
+	SourceChannel    string         `protobuf:"bytes,2,opt,name=source_channel,proto3" json:"source_channel"`
+	DID              string         `protobuf:"bytes,3,opt,name=did,proto3" json:"did"`
+	TimeoutTimestamp uint64         `protobuf:"varint,4,opt,name=timeout_timestamp,proto3" json:"timeout_timestamp"`
+	Creator          sdk.AccAddress `protobuf:"bytes,5,opt,name=creator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"creator"`
+}
+
+// ValidateBasic performs basic validation of MsgQueryDID.
+func (msg MsgQueryDID) ValidateBasic() error {
+	if msg.SourcePort == "" || msg.SourceChannel == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Source port and channel cannot be empty")
+	}
+	if msg.DID == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("DID cannot be empty")
+	}
+	if msg.TimeoutTimestamp == 0 {
+		return sdkerrors.ErrUnknownRequest.Wrap("Timeout timestamp cannot be zero")
+	}
+	if msg.Creator.Empty() {
+		return sdkerrors.ErrUnknownRequest.Wrap("Creator cannot be empty")
+	}
+	return nil
+}
+
+// Route returns the message route.
+func (msg MsgQueryDID) Route() string { return ModuleName }
+
+// Type returns the message type.
+func (msg MsgQueryDID) Type() string { return "query_did" }
+
+// GetSignBytes returns the canonical bytes to sign over.
+func (msg MsgQueryDID) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners returns the account that must sign the message.
+func (msg MsgQueryDID) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
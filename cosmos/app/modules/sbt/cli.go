@@ -0,0 +1,199 @@
+package sbt
+
+import (
+	"strconv"
+
+	didmodule "cosmos-app/modules/did"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/spf13/cobra"
+)
+
+// Flags for the soulbound token module's CLI commands.
+const (
+	FlagCredentialID = "credential-id"
+	FlagURI          = "uri"
+)
+
+// GetTxCmd returns the root tx command for the soulbound token module.
+func GetTxCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        ModuleName,
+		Short:                      "Soulbound token transaction subcommands",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+	cmd.AddCommand(
+		CmdIssueToken(),
+		CmdBurnToken(),
+	)
+	return cmd
+}
+
+// GetQueryCmd returns the root query command for the soulbound token module.
+func GetQueryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        ModuleName,
+		Short:                      "Querying commands for soulbound tokens",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+	cmd.AddCommand(
+		CmdQueryToken(),
+		CmdTokensByHolder(),
+		CmdTokensByIssuer(),
+	)
+	return cmd
+}
+
+// CmdIssueToken returns the command to issue a soulbound token.
+func CmdIssueToken() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "issue [issuer-did] [holder-did] [class]",
+		Short: "Issue a non-transferable token of a class to a holder DID",
+		Long: `Issue a soulbound token of the given class, e.g. membership, by the issuer
+DID to the holder DID. With --credential-id the token is backed by a
+verifiable credential and stops being valid once the credential is revoked;
+every token stops being valid while its holder DID is deactivated. --uri
+points at the token's metadata. A controller of the issuer DID authorizes the
+issuance as for a DID update: an account controller by signing the
+transaction, a DID controller with --key or --signature.`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			signer, nonce, err := didmodule.ControllerProof(cmd, clientCtx, args[0])
+			if err != nil {
+				return err
+			}
+			credentialID, _ := cmd.Flags().GetString(FlagCredentialID)
+			uri, _ := cmd.Flags().GetString(FlagURI)
+			msg := &MsgIssueToken{
+				Issuer:       args[0],
+				Holder:       args[1],
+				Class:        args[2],
+				CredentialID: credentialID,
+				URI:          uri,
+				Nonce:        nonce,
+				Signer:       signer,
+				Creator:      clientCtx.GetFromAddress(),
+			}
+			if msg.Signature, err = didmodule.ProofSignature(cmd, clientCtx, msg.ProofSignBytes()); err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+	cmd.Flags().String(FlagCredentialID, "", "ID of the verifiable credential backing the token")
+	cmd.Flags().String(FlagURI, "", "URI of the token's metadata")
+	didmodule.AddControllerProofFlags(cmd)
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdBurnToken returns the command to burn a soulbound token.
+func CmdBurnToken() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "burn [token-id] [burner-did]",
+		Short: "Burn a token as its issuer or holder DID, authorized as for issue",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			id, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+			signer, nonce, err := didmodule.ControllerProof(cmd, clientCtx, args[1])
+			if err != nil {
+				return err
+			}
+			msg := &MsgBurnToken{
+				ID:      id,
+				Burner:  args[1],
+				Nonce:   nonce,
+				Signer:  signer,
+				Creator: clientCtx.GetFromAddress(),
+			}
+			if msg.Signature, err = didmodule.ProofSignature(cmd, clientCtx, msg.ProofSignBytes()); err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+	didmodule.AddControllerProofFlags(cmd)
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdQueryToken returns the command to query a token and its validity.
+func CmdQueryToken() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "token [token-id]",
+		Short: "Query a soulbound token and whether it is still valid",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return printQuery(cmd, QueryToken, args[0])
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdTokensByHolder returns the command to list the tokens of a DID.
+func CmdTokensByHolder() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "holder [holder-did]",
+		Short: "List the soulbound tokens held by a DID with their validity",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return printQuery(cmd, QueryByHolder, args[0])
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// CmdTokensByIssuer returns the command to list the tokens a DID issued.
+func CmdTokensByIssuer() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "issuer [issuer-did]",
+		Short: "List the soulbound tokens a DID has issued with their validity",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return printQuery(cmd, QueryByIssuer, args[0])
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func printQuery(cmd *cobra.Command, path ...string) error {
+	clientCtx, err := client.GetClientQueryContext(cmd)
+	if err != nil {
+		return err
+	}
+	route := "custom/" + ModuleName
+	for _, p := range path {
+		route += "/" + p
+	}
+	res, _, err := clientCtx.QueryWithData(route, nil)
+	if err != nil {
+		return err
+	}
+	return clientCtx.PrintBytes(res)
+}
@@ -0,0 +1,61 @@
+package sbt
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	cdctypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/gogo/protobuf/proto"
+)
+
+var (
+	amino = codec.NewLegacyAmino()
+
+	// ModuleCdc is the amino codec used for the soulbound token module's
+	// legacy sign bytes.
+	ModuleCdc = codec.NewAminoCodec(amino)
+)
+
+func init() {
+	RegisterLegacyAminoCodec(amino)
+	amino.Seal()
+
+	// Like the DID module's, the soulbound token module's types are
+	// hand-written and registered under the names a generated aytch/sbt/v1
+	// package would use.
+	proto.RegisterType((*Token)(nil), "aytch.sbt.v1.Token")
+	proto.RegisterType((*MsgIssueToken)(nil), "aytch.sbt.v1.MsgIssueToken")
+	proto.RegisterType((*MsgBurnToken)(nil), "aytch.sbt.v1.MsgBurnToken")
+	proto.RegisterType((*GenesisState)(nil), "aytch.sbt.v1.GenesisState")
+}
+
+// RegisterLegacyAminoCodec registers the soulbound token module's messages on
+// the given LegacyAmino codec.
+func RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) {
+	cdc.RegisterConcrete(MsgIssueToken{}, "sbt/IssueToken", nil)
+	cdc.RegisterConcrete(MsgBurnToken{}, "sbt/BurnToken", nil)
+}
+
+// RegisterInterfaces registers the soulbound token module's messages as
+// sdk.Msg implementations. They are routed by the legacy handler.
+func RegisterInterfaces(registry cdctypes.InterfaceRegistry) {
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgIssueToken{},
+		&MsgBurnToken{},
+	)
+}
+
+func (m *Token) Reset()         { *m = Token{} }
+func (m *Token) String() string { return proto.CompactTextString(m) }
+func (*Token) ProtoMessage()    {}
+
+func (m *MsgIssueToken) Reset()         { *m = MsgIssueToken{} }
+func (m *MsgIssueToken) String() string { return proto.CompactTextString(m) }
+func (*MsgIssueToken) ProtoMessage()    {}
+
+func (m *MsgBurnToken) Reset()         { *m = MsgBurnToken{} }
+func (m *MsgBurnToken) String() string { return proto.CompactTextString(m) }
+func (*MsgBurnToken) ProtoMessage()    {}
+
+func (m *GenesisState) Reset()         { *m = GenesisState{} }
+func (m *GenesisState) String() string { return proto.CompactTextString(m) }
+func (*GenesisState) ProtoMessage()    {}
@@ -0,0 +1,19 @@
+package sbt
+
+import (
+	didmodule "cosmos-app/modules/did"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// DIDKeeper defines the DID registry functionality used to allow and
+// authorize issuers and burners, to tell whether tokens are still valid and to keep
+// tokens linked as DID assets in sync.
+type DIDKeeper interface {
+	GetDID(ctx sdk.Context, id string) (didmodule.DIDDocument, error)
+	GetCredentialStatus(ctx sdk.Context, id string) (didmodule.CredentialStatus, error)
+	AuthorizeController(ctx sdk.Context, id string, nonce uint64, signer string, creator sdk.AccAddress, signBytes, signature []byte) error
+	IncrementNonce(ctx sdk.Context, id string) error
+	AfterAssetChanged(ctx sdk.Context, class, assetID string)
+	CheckIssuer(ctx sdk.Context, did string) error
+}
@@ -0,0 +1,57 @@
+package sbt
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState defines the soulbound token module's genesis state.
+type GenesisState struct {
+	Tokens []Token `protobuf:"bytes,1,rep,name=tokens,proto3" json:"tokens"`
+	NextID uint64  `protobuf:"varint,2,opt,name=next_id,proto3" json:"next_id"`
+}
+
+// DefaultGenesis returns the default genesis state.
+func DefaultGenesis() *GenesisState {
+	return &GenesisState{NextID: 1}
+}
+
+// ValidateGenesis performs basic genesis state validation.
+func ValidateGenesis(gs GenesisState) error {
+	seen := make(map[uint64]bool, len(gs.Tokens))
+	for _, t := range gs.Tokens {
+		if err := t.Validate(); err != nil {
+			return fmt.Errorf("token %d: %w", t.ID, err)
+		}
+		if t.ID == 0 || t.ID >= gs.NextID {
+			return fmt.Errorf("token %d: ID must be between 1 and the next ID %d", t.ID, gs.NextID)
+		}
+		if seen[t.ID] {
+			return fmt.Errorf("duplicate token %d", t.ID)
+		}
+		seen[t.ID] = true
+	}
+	return nil
+}
+
+// InitGenesis initializes the soulbound token module's state from a genesis
+// state.
+func InitGenesis(ctx sdk.Context, k Keeper, gs GenesisState) {
+	for _, t := range gs.Tokens {
+		k.setToken(ctx, t)
+	}
+	if gs.NextID == 0 {
+		gs.NextID = 1
+	}
+	k.setNextID(ctx, gs.NextID)
+}
+
+// ExportGenesis exports the soulbound token module's state, including
+// tokens that are no longer valid.
+func ExportGenesis(ctx sdk.Context, k Keeper) *GenesisState {
+	return &GenesisState{
+		Tokens: k.GetAllTokens(ctx),
+		NextID: k.GetNextID(ctx),
+	}
+}
@@ -0,0 +1,68 @@
+package sbt
+
+import (
+	"fmt"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// NewHandler creates a handler for soulbound token messages.
+func NewHandler(k Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+		ctx = ctx.WithEventManager(sdk.NewEventManager())
+		switch msg := msg.(type) {
+		case *MsgIssueToken:
+			return handleMsgIssueToken(ctx, k, *msg)
+		case *MsgBurnToken:
+			return handleMsgBurnToken(ctx, k, *msg)
+		default:
+			return nil, fmt.Errorf("unrecognized soulbound token message type: %T", msg)
+		}
+	}
+}
+
+// authorizeDID checks a controller proof for id and consumes its nonce, so
+// the proof can't be replayed.
+func authorizeDID(ctx sdk.Context, k Keeper, id string, nonce uint64, signer string, creator sdk.AccAddress, signBytes, signature []byte) error {
+	if err := k.didKeeper.AuthorizeController(ctx, id, nonce, signer, creator, signBytes, signature); err != nil {
+		return err
+	}
+	return k.didKeeper.IncrementNonce(ctx, id)
+}
+
+func handleMsgIssueToken(ctx sdk.Context, k Keeper, msg MsgIssueToken) (*sdk.Result, error) {
+	if err := authorizeDID(ctx, k, msg.Issuer, msg.Nonce, msg.Signer, msg.Creator, msg.ProofSignBytes(), msg.Signature); err != nil {
+		return nil, err
+	}
+	id, err := k.IssueToken(ctx, msg.Token())
+	if err != nil {
+		return nil, err
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		"issue_token",
+		sdk.NewAttribute("id", strconv.FormatUint(id, 10)),
+		sdk.NewAttribute("class", msg.Class),
+		sdk.NewAttribute("issuer", msg.Issuer),
+		sdk.NewAttribute("holder", msg.Holder),
+	))
+	return &sdk.Result{Events: ctx.EventManager().ABCIEvents()}, nil
+}
+
+func handleMsgBurnToken(ctx sdk.Context, k Keeper, msg MsgBurnToken) (*sdk.Result, error) {
+	if err := authorizeDID(ctx, k, msg.Burner, msg.Nonce, msg.Signer, msg.Creator, msg.ProofSignBytes(), msg.Signature); err != nil {
+		return nil, err
+	}
+	t, err := k.BurnToken(ctx, msg.ID, msg.Burner)
+	if err != nil {
+		return nil, err
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		"burn_token",
+		sdk.NewAttribute("id", strconv.FormatUint(t.ID, 10)),
+		sdk.NewAttribute("class", t.Class),
+		sdk.NewAttribute("holder", t.Holder),
+		sdk.NewAttribute("burner", msg.Burner),
+	))
+	return &sdk.Result{Events: ctx.EventManager().ABCIEvents()}, nil
+}
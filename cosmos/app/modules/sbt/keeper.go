@@ -0,0 +1,211 @@
+package sbt
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Store prefixes. Tokens are stored by ID under TokenPrefix and indexed by
+// holder and by issuer for listing; NextIDKey holds the ID of the next token.
+var (
+	TokenPrefix       = []byte("token/")
+	HolderIndexPrefix = []byte("holder/")
+	IssuerIndexPrefix = []byte("issuer/")
+	NextIDKey         = []byte("next-id")
+)
+
+func tokenKey(id uint64) []byte {
+	return append(append([]byte{}, TokenPrefix...), sdk.Uint64ToBigEndian(id)...)
+}
+
+func indexPrefix(p []byte, did string) []byte {
+	return append(append([]byte{}, p...), did+"/"...)
+}
+
+func indexKey(p []byte, did string, id uint64) []byte {
+	return append(indexPrefix(p, did), sdk.Uint64ToBigEndian(id)...)
+}
+
+// Keeper maintains the soulbound tokens.
+type Keeper struct {
+	storeKey  sdk.StoreKey
+	cdc       *codec.LegacyAmino
+	didKeeper DIDKeeper
+}
+
+// NewKeeper creates a new soulbound token Keeper.
+func NewKeeper(storeKey sdk.StoreKey, cdc *codec.LegacyAmino, didKeeper DIDKeeper) Keeper {
+	return Keeper{
+		storeKey:  storeKey,
+		cdc:       cdc,
+		didKeeper: didKeeper,
+	}
+}
+
+// IssueToken stores a new token for an active holder DID and returns its ID.
+// A backing credential must not be revoked already, and only allowed issuers
+// of the DID registry may issue credential-backed tokens while it restricts
+// issuers. Callers must have authorized the issuer.
+func (k Keeper) IssueToken(ctx sdk.Context, t Token) (uint64, error) {
+	if err := t.Validate(); err != nil {
+		return 0, err
+	}
+	holder, err := k.didKeeper.GetDID(ctx, t.Holder)
+	if err != nil {
+		return 0, err
+	}
+	if holder.Deactivated {
+		return 0, fmt.Errorf("DID %s is deactivated", t.Holder)
+	}
+	if t.CredentialID != "" {
+		if err := k.didKeeper.CheckIssuer(ctx, t.Issuer); err != nil {
+			return 0, err
+		}
+		status, err := k.didKeeper.GetCredentialStatus(ctx, t.CredentialID)
+		if err != nil {
+			return 0, err
+		}
+		if status.Revoked {
+			return 0, fmt.Errorf("credential %s is revoked", t.CredentialID)
+		}
+	}
+	t.ID = k.GetNextID(ctx)
+	t.Issued = ctx.BlockHeight()
+	k.setToken(ctx, t)
+	k.setNextID(ctx, t.ID+1)
+	return t.ID, nil
+}
+
+// BurnToken deletes a token on behalf of burner, which must be its issuer
+// or its holder, and prunes the token from the linked assets of its holder.
+func (k Keeper) BurnToken(ctx sdk.Context, id uint64, burner string) (Token, error) {
+	t, err := k.GetToken(ctx, id)
+	if err != nil {
+		return Token{}, err
+	}
+	if burner != t.Issuer && burner != t.Holder {
+		return Token{}, fmt.Errorf("only the issuer or holder of token %d can burn it", id)
+	}
+	k.deleteToken(ctx, t)
+	k.didKeeper.AfterAssetChanged(ctx, ModuleName, strconv.FormatUint(id, 10))
+	return t, nil
+}
+
+// GetToken returns a token, valid or not.
+func (k Keeper) GetToken(ctx sdk.Context, id uint64) (Token, error) {
+	value := ctx.KVStore(k.storeKey).Get(tokenKey(id))
+	if value == nil {
+		return Token{}, fmt.Errorf("token %d not found", id)
+	}
+	var t Token
+	k.cdc.MustUnmarshal(value, &t)
+	return t, nil
+}
+
+// Status returns a token with its current validity: a token is invalid
+// while its holder DID is missing or deactivated or once its backing
+// credential is revoked.
+func (k Keeper) Status(ctx sdk.Context, t Token) TokenStatus {
+	status := TokenStatus{Token: t, Valid: true}
+	if holder, err := k.didKeeper.GetDID(ctx, t.Holder); err != nil || holder.Deactivated {
+		status.Valid, status.Reason = false, fmt.Sprintf("holder %s is not an active DID", t.Holder)
+		return status
+	}
+	if t.CredentialID != "" {
+		cs, err := k.didKeeper.GetCredentialStatus(ctx, t.CredentialID)
+		if err != nil || cs.Revoked {
+			status.Valid, status.Reason = false, fmt.Sprintf("credential %s is revoked", t.CredentialID)
+		}
+	}
+	return status
+}
+
+// OwnsAsset reports whether owner holds the valid token with the decimal ID
+// assetID, so DIDs can link their tokens as assets of class ModuleName.
+func (k Keeper) OwnsAsset(ctx sdk.Context, owner, assetID string) bool {
+	id, err := strconv.ParseUint(assetID, 10, 64)
+	if err != nil {
+		return false
+	}
+	t, err := k.GetToken(ctx, id)
+	return err == nil && t.Holder == owner && k.Status(ctx, t).Valid
+}
+
+// HasValidToken reports whether holder holds a valid token of class from
+// issuer, for modules gating actions on memberships or certifications.
+func (k Keeper) HasValidToken(ctx sdk.Context, holder, issuer, class string) bool {
+	for _, t := range k.TokensByHolder(ctx, holder) {
+		if t.Issuer == issuer && t.Class == class && k.Status(ctx, t).Valid {
+			return true
+		}
+	}
+	return false
+}
+
+// TokensByHolder returns the tokens held by a DID, valid or not, in ID
+// order.
+func (k Keeper) TokensByHolder(ctx sdk.Context, holder string) []Token {
+	return k.indexedTokens(ctx, indexPrefix(HolderIndexPrefix, holder))
+}
+
+// TokensByIssuer returns the tokens a DID has issued, valid or not, in ID
+// order.
+func (k Keeper) TokensByIssuer(ctx sdk.Context, issuer string) []Token {
+	return k.indexedTokens(ctx, indexPrefix(IssuerIndexPrefix, issuer))
+}
+
+func (k Keeper) indexedTokens(ctx sdk.Context, p []byte) []Token {
+	var tokens []Token
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), p)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		if t, err := k.GetToken(ctx, sdk.BigEndianToUint64(iter.Key()[len(p):])); err == nil {
+			tokens = append(tokens, t)
+		}
+	}
+	return tokens
+}
+
+// GetAllTokens returns every token in the store.
+func (k Keeper) GetAllTokens(ctx sdk.Context) []Token {
+	var tokens []Token
+	iter := prefix.NewStore(ctx.KVStore(k.storeKey), TokenPrefix).Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var t Token
+		k.cdc.MustUnmarshal(iter.Value(), &t)
+		tokens = append(tokens, t)
+	}
+	return tokens
+}
+
+// GetNextID returns the ID the next token will get. IDs start at 1.
+func (k Keeper) GetNextID(ctx sdk.Context) uint64 {
+	bz := ctx.KVStore(k.storeKey).Get(NextIDKey)
+	if bz == nil {
+		return 1
+	}
+	return sdk.BigEndianToUint64(bz)
+}
+
+func (k Keeper) setNextID(ctx sdk.Context, id uint64) {
+	ctx.KVStore(k.storeKey).Set(NextIDKey, sdk.Uint64ToBigEndian(id))
+}
+
+func (k Keeper) setToken(ctx sdk.Context, t Token) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(tokenKey(t.ID), k.cdc.MustMarshal(&t))
+	store.Set(indexKey(HolderIndexPrefix, t.Holder, t.ID), []byte{})
+	store.Set(indexKey(IssuerIndexPrefix, t.Issuer, t.ID), []byte{})
+}
+
+func (k Keeper) deleteToken(ctx sdk.Context, t Token) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(tokenKey(t.ID))
+	store.Delete(indexKey(HolderIndexPrefix, t.Holder, t.ID))
+	store.Delete(indexKey(IssuerIndexPrefix, t.Issuer, t.ID))
+}
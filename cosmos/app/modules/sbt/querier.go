@@ -0,0 +1,58 @@
+package sbt
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// Query endpoints supported by the soulbound token querier. Tokens are
+// returned as TokenStatus, with their current validity.
+const (
+	// QueryToken takes a token ID.
+	QueryToken = "token"
+	// QueryByHolder takes a holder DID.
+	QueryByHolder = "holder"
+	// QueryByIssuer takes an issuer DID.
+	QueryByIssuer = "issuer"
+)
+
+// NewQuerier creates a legacy querier for the soulbound token module.
+// Results are encoded as plain JSON, like the DID module's.
+func NewQuerier(k Keeper, _ *codec.LegacyAmino) sdk.Querier {
+	return func(ctx sdk.Context, path []string, _ abci.RequestQuery) ([]byte, error) {
+		if len(path) == 0 {
+			return nil, fmt.Errorf("empty soulbound token query path")
+		}
+		switch {
+		case path[0] == QueryToken && len(path) == 2:
+			id, err := strconv.ParseUint(path[1], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid token ID %s", path[1])
+			}
+			t, err := k.GetToken(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			return json.MarshalIndent(k.Status(ctx, t), "", "  ")
+		case path[0] == QueryByHolder && len(path) == 2:
+			return marshalStatuses(ctx, k, k.TokensByHolder(ctx, path[1]))
+		case path[0] == QueryByIssuer && len(path) == 2:
+			return marshalStatuses(ctx, k, k.TokensByIssuer(ctx, path[1]))
+		default:
+			return nil, fmt.Errorf("unknown soulbound token query path %v", path)
+		}
+	}
+}
+
+func marshalStatuses(ctx sdk.Context, k Keeper, tokens []Token) ([]byte, error) {
+	statuses := make([]TokenStatus, 0, len(tokens))
+	for _, t := range tokens {
+		statuses = append(statuses, k.Status(ctx, t))
+	}
+	return json.MarshalIndent(statuses, "", "  ")
+}
@@ -0,0 +1,28 @@
+package sbt
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/gorilla/mux"
+)
+
+// RegisterRoutes registers the soulbound token module's REST routes.
+func RegisterRoutes(cliCtx client.Context, r *mux.Router) {
+	r.HandleFunc("/sbt/tokens/{id}", queryHandler(cliCtx, QueryToken, "id")).Methods("GET")
+	r.HandleFunc("/sbt/holders/{did}", queryHandler(cliCtx, QueryByHolder, "did")).Methods("GET")
+	r.HandleFunc("/sbt/issuers/{did}", queryHandler(cliCtx, QueryByIssuer, "did")).Methods("GET")
+}
+
+func queryHandler(cliCtx client.Context, query, v string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", ModuleName, query, mux.Vars(r)[v]), nil)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(res)
+	}
+}
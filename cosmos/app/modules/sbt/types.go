@@ -0,0 +1,209 @@
+package sbt
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	didmodule "cosmos-app/modules/did"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// Module identifiers for the soulbound token module.
+const (
+	ModuleName = "sbt"
+	StoreKey   = ModuleName
+	RouterKey  = ModuleName
+
+	maxClassLength = 64
+	maxURILength   = 512
+)
+
+// Token is a soulbound token: a non-transferable token the Issuer DID gives
+// the Holder DID, e.g. a membership or certification. It is bound to the
+// holder's DID rather than an account, so it survives key rotation, and no
+// message moves it to another holder. A token backed by a credential
+// (CredentialID) is only valid while the credential isn't revoked, and no
+// token is valid while its holder DID is deactivated.
+type Token struct {
+	ID           uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id"`
+	Class        string `protobuf:"bytes,2,opt,name=class,proto3" json:"class"`
+	Issuer       string `protobuf:"bytes,3,opt,name=issuer,proto3" json:"issuer"`
+	Holder       string `protobuf:"bytes,4,opt,name=holder,proto3" json:"holder"`
+	CredentialID string `protobuf:"bytes,5,opt,name=credential_id,proto3" json:"credential_id,omitempty"`
+	URI          string `protobuf:"bytes,6,opt,name=uri,proto3" json:"uri,omitempty"`
+	Issued       int64  `protobuf:"varint,7,opt,name=issued,proto3" json:"issued"`
+}
+
+// Validate checks the fields of a token other than its ID.
+func (t Token) Validate() error {
+	if err := validateDID(t.Issuer); err != nil {
+		return fmt.Errorf("issuer: %w", err)
+	}
+	if err := validateDID(t.Holder); err != nil {
+		return fmt.Errorf("holder: %w", err)
+	}
+	if err := ValidateClass(t.Class); err != nil {
+		return err
+	}
+	if len(t.URI) > maxURILength {
+		return fmt.Errorf("token URI holds at most %d characters", maxURILength)
+	}
+	return nil
+}
+
+// TokenStatus is a token with its validity at query time.
+type TokenStatus struct {
+	Token
+	Valid bool `json:"valid"`
+	// Reason says why an invalid token is invalid.
+	Reason string `json:"reason,omitempty"`
+}
+
+// ValidateClass checks that a token class is a short identifier such as
+// membership or org.example.certified-auditor.
+func ValidateClass(class string) error {
+	if class == "" || len(class) > maxClassLength {
+		return fmt.Errorf("token class must have between 1 and %d characters", maxClassLength)
+	}
+	for _, r := range class {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+		default:
+			return fmt.Errorf("token class %q may only contain letters, digits, '-', '_' and '.'", class)
+		}
+	}
+	return nil
+}
+
+// validateDID checks that id is a DID of this chain. DIDs are part of store
+// keys, so they can't contain '/'.
+func validateDID(id string) error {
+	if !strings.HasPrefix(id, didmodule.DIDMethodPrefix) || id == didmodule.DIDMethodPrefix {
+		return fmt.Errorf("DID must start with %s", didmodule.DIDMethodPrefix)
+	}
+	if strings.Contains(id, "/") {
+		return fmt.Errorf("DID cannot contain '/'")
+	}
+	return didmodule.ValidateChecksum(id)
+}
+
+// proofSignBytes returns the sorted JSON of a message with its controller
+// signature cleared, which the controller of the acting DID signs.
+func proofSignBytes(msg interface{}) []byte {
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// MsgIssueToken issues a soulbound token of Class to Holder. Signer proves
+// control of the issuer DID as for a DID document change.
+type MsgIssueToken struct {
+	Issuer       string         `protobuf:"bytes,1,opt,name=issuer,proto3" json:"issuer"`
+	Holder       string         `protobuf:"bytes,2,opt,name=holder,proto3" json:"holder"`
+	Class        string         `protobuf:"bytes,3,opt,name=class,proto3" json:"class"`
+	CredentialID string         `protobuf:"bytes,4,opt,name=credential_id,proto3" json:"credential_id,omitempty"`
+	URI          string         `protobuf:"bytes,5,opt,name=uri,proto3" json:"uri,omitempty"`
+	Nonce        uint64         `protobuf:"varint,6,opt,name=nonce,proto3" json:"nonce"`
+	Signer       string         `protobuf:"bytes,7,opt,name=signer,proto3" json:"signer"`
+	Signature    []byte         `protobuf:"bytes,8,opt,name=signature,proto3" json:"signature,omitempty"`
+	Creator      sdk.AccAddress `protobuf:"bytes,9,opt,name=creator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"creator"`
+}
+
+// Token returns the token the message issues, without ID.
+func (msg MsgIssueToken) Token() Token {
+	return Token{
+		Class:        msg.Class,
+		Issuer:       msg.Issuer,
+		Holder:       msg.Holder,
+		CredentialID: msg.CredentialID,
+		URI:          msg.URI,
+	}
+}
+
+// ValidateBasic performs basic validation of MsgIssueToken.
+func (msg MsgIssueToken) ValidateBasic() error {
+	if err := msg.Token().Validate(); err != nil {
+		return sdkerrors.ErrUnknownRequest.Wrap(err.Error())
+	}
+	if msg.Signer == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Signer cannot be empty")
+	}
+	if msg.Creator.Empty() {
+		return sdkerrors.ErrUnknownRequest.Wrap("Creator cannot be empty")
+	}
+	return nil
+}
+
+// ProofSignBytes returns the bytes the issuer's controller signs.
+func (msg MsgIssueToken) ProofSignBytes() []byte {
+	msg.Signature = nil
+	return proofSignBytes(msg)
+}
+
+// Route returns the message route.
+func (msg MsgIssueToken) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgIssueToken) Type() string { return "issue_token" }
+
+// GetSignBytes returns the canonical bytes to sign over.
+func (msg MsgIssueToken) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners returns the account that must sign the message.
+func (msg MsgIssueToken) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
+
+// MsgBurnToken destroys a token. Either its issuer or its holder may burn
+// it: Burner names which, and Signer proves control of that DID.
+type MsgBurnToken struct {
+	ID        uint64         `protobuf:"varint,1,opt,name=id,proto3" json:"id"`
+	Burner    string         `protobuf:"bytes,2,opt,name=burner,proto3" json:"burner"`
+	Nonce     uint64         `protobuf:"varint,3,opt,name=nonce,proto3" json:"nonce"`
+	Signer    string         `protobuf:"bytes,4,opt,name=signer,proto3" json:"signer"`
+	Signature []byte         `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
+	Creator   sdk.AccAddress `protobuf:"bytes,6,opt,name=creator,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"creator"`
+}
+
+// ValidateBasic performs basic validation of MsgBurnToken.
+func (msg MsgBurnToken) ValidateBasic() error {
+	if err := validateDID(msg.Burner); err != nil {
+		return sdkerrors.ErrUnknownRequest.Wrap(err.Error())
+	}
+	if msg.Signer == "" {
+		return sdkerrors.ErrUnknownRequest.Wrap("Signer cannot be empty")
+	}
+	if msg.Creator.Empty() {
+		return sdkerrors.ErrUnknownRequest.Wrap("Creator cannot be empty")
+	}
+	return nil
+}
+
+// ProofSignBytes returns the bytes the burner's controller signs.
+func (msg MsgBurnToken) ProofSignBytes() []byte {
+	msg.Signature = nil
+	return proofSignBytes(msg)
+}
+
+// Route returns the message route.
+func (msg MsgBurnToken) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgBurnToken) Type() string { return "burn_token" }
+
+// GetSignBytes returns the canonical bytes to sign over.
+func (msg MsgBurnToken) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// GetSigners returns the account that must sign the message.
+func (msg MsgBurnToken) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
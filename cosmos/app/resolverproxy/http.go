@@ -0,0 +1,153 @@
+package resolverproxy
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"cosmos-app/client/resolver"
+	didmodule "cosmos-app/modules/did"
+
+	"github.com/gorilla/mux"
+)
+
+// ContentTypeResolution is the media type of a full DID resolution result,
+// as opposed to a bare document representation.
+const ContentTypeResolution = `application/ld+json;profile="https://w3id.org/did-resolution"`
+
+// Resolution errors reported in didResolutionMetadata.
+const (
+	errInvalidDID                 = "invalidDid"
+	errNotFound                   = "notFound"
+	errRepresentationNotSupported = "representationNotSupported"
+	errInternal                   = "internalError"
+)
+
+// RegisterRoutes registers the Universal Resolver endpoints and a health
+// check.
+func (p *Proxy) RegisterRoutes(r *mux.Router) {
+	r.HandleFunc("/1.0/identifiers/{did}", p.identifiersHandler).Methods("GET")
+	r.HandleFunc("/health", p.healthHandler).Methods("GET")
+}
+
+// identifiersHandler resolves a DID, or dereferences a DID URL whose fragment
+// was sent percent-encoded. The Accept header picks the result: a DID
+// document media type returns the bare document in that representation, and
+// anything else the full resolution result.
+func (p *Proxy) identifiersHandler(w http.ResponseWriter, r *http.Request) {
+	id, fragment := mux.Vars(r)["did"], ""
+	if i := strings.Index(id, "#"); i >= 0 {
+		id, fragment = id[:i], id[i+1:]
+	}
+	contentType, bare := negotiate(r.Header.Get("Accept"))
+	if contentType == "" {
+		writeError(w, http.StatusNotAcceptable, errRepresentationNotSupported)
+		return
+	}
+	if !strings.HasPrefix(id, didmodule.DIDMethodPrefix) || didmodule.ValidateChecksum(id) != nil {
+		writeError(w, http.StatusBadRequest, errInvalidDID)
+		return
+	}
+
+	doc, err := p.ResolveDID(r.Context(), id)
+	switch {
+	case errors.Is(err, resolver.ErrNotFound):
+		writeError(w, http.StatusNotFound, errNotFound)
+		return
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, errInternal)
+		return
+	}
+
+	// Deactivated documents are still returned, flagged in their metadata,
+	// with 410 Gone as the Universal Resolver does.
+	status := http.StatusOK
+	if doc.Deactivated {
+		status = http.StatusGone
+	}
+	var result interface{}
+	switch {
+	case fragment != "":
+		deref, err := doc.Dereference(fragment, contentType)
+		if err != nil {
+			writeError(w, http.StatusNotFound, errNotFound)
+			return
+		}
+		result = deref
+		if bare {
+			result = deref.ContentStream
+		}
+	case bare:
+		result = doc.W3C(contentType == didmodule.ContentTypeDIDJSONLD)
+	default:
+		result = didmodule.NewResolutionResult(doc, contentType)
+	}
+
+	if contentType == didmodule.ContentTypeDIDCBOR {
+		bz, err := didmodule.MarshalCBOR(result)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, errInternal)
+			return
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(status)
+		w.Write(bz)
+		return
+	}
+	if bare {
+		w.Header().Set("Content-Type", contentType)
+	} else {
+		w.Header().Set("Content-Type", ContentTypeResolution)
+	}
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(result)
+}
+
+// negotiate returns the document representation to resolve to for accept,
+// and whether the bare document was asked for. It returns an empty content
+// type if nothing acceptable is supported. Quality values are ignored; the
+// first supported media type wins.
+func negotiate(accept string) (contentType string, bare bool) {
+	if accept == "" {
+		return didmodule.ContentTypeDIDJSONLD, false
+	}
+	for _, part := range strings.Split(accept, ",") {
+		mediaType := strings.TrimSpace(strings.SplitN(part, ";", 2)[0])
+		switch mediaType {
+		case didmodule.ContentTypeDIDJSON, didmodule.ContentTypeDIDJSONLD, didmodule.ContentTypeDIDCBOR:
+			return mediaType, true
+		case "application/ld+json", "application/json", "*/*":
+			return didmodule.ContentTypeDIDJSONLD, false
+		}
+	}
+	return "", false
+}
+
+// writeError writes a resolution result carrying only an error.
+func writeError(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", ContentTypeResolution)
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(didmodule.ResolutionResult{
+		DIDResolutionMetadata: didmodule.ResolutionMetadata{Error: code},
+	})
+}
+
+// healthHandler reports whether the event subscription is live, with the
+// cache counters. Without the subscription the proxy still answers, but
+// every request goes to the node, so it reports 503 for load balancers.
+func (p *Proxy) healthHandler(w http.ResponseWriter, _ *http.Request) {
+	hits, misses, entries := p.Stats()
+	status := http.StatusOK
+	if !p.Watching() {
+		status = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"watching": p.Watching(),
+		"hits":     hits,
+		"misses":   misses,
+		"entries":  entries,
+	})
+}
@@ -0,0 +1,179 @@
+// Package resolverproxy serves the Universal Resolver HTTP API for
+// did:aytch in front of a full node. Resolved documents are cached until the
+// chain reports a newer version of them: the proxy follows every DID change
+// event, so a key rotation is visible as soon as its block is committed while
+// unchanged documents are served from memory.
+package resolverproxy
+
+import (
+	"context"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"cosmos-app/client/resolver"
+	didmodule "cosmos-app/modules/did"
+)
+
+// ResubscribeDelay is how long the proxy waits before subscribing again after
+// losing its event subscription.
+const ResubscribeDelay = 5 * time.Second
+
+// Proxy resolves did:aytch documents through a node and caches them by
+// version.
+type Proxy struct {
+	next       resolver.Resolver
+	watcher    *didmodule.Watcher
+	ttl        time.Duration
+	maxEntries int
+
+	// watching is set while the event subscription is live. Without it
+	// the cache can't be trusted and every request goes to the node.
+	watching int32
+	hits     uint64
+	misses   uint64
+
+	mu      sync.RWMutex
+	entries map[string]entry
+	// latest holds the newest version reported by events for each DID,
+	// so a lagging node's older answer isn't cached.
+	latest map[string]uint64
+}
+
+type entry struct {
+	doc     didmodule.DIDDocument
+	expires time.Time
+}
+
+// New creates a proxy resolving through next and invalidating through
+// watcher. Entries are kept at most ttl, as a bound on staleness should an
+// event be missed, and at most maxEntries are held.
+func New(next resolver.Resolver, watcher *didmodule.Watcher, ttl time.Duration, maxEntries int) *Proxy {
+	return &Proxy{
+		next:       next,
+		watcher:    watcher,
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[string]entry),
+		latest:     make(map[string]uint64),
+	}
+}
+
+// Run follows DID change events until ctx is done, resubscribing whenever the
+// subscription is lost. The cache is flushed on every resubscription, since
+// events may have been missed in between.
+func (p *Proxy) Run(ctx context.Context) error {
+	for {
+		events, err := p.watcher.WatchAll(ctx)
+		if err != nil {
+			log.Printf("subscribe to DID events: %v", err)
+		} else {
+			p.flush()
+			atomic.StoreInt32(&p.watching, 1)
+			for ev := range events {
+				p.apply(ev)
+			}
+			atomic.StoreInt32(&p.watching, 0)
+			p.flush()
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(ResubscribeDelay):
+		}
+	}
+}
+
+// Watching reports whether the event subscription is live.
+func (p *Proxy) Watching() bool {
+	return atomic.LoadInt32(&p.watching) == 1
+}
+
+// Stats returns the number of cache hits and misses so far and the number of
+// cached documents.
+func (p *Proxy) Stats() (hits, misses uint64, entries int) {
+	p.mu.RLock()
+	entries = len(p.entries)
+	p.mu.RUnlock()
+	return atomic.LoadUint64(&p.hits), atomic.LoadUint64(&p.misses), entries
+}
+
+// ResolveDID returns the cached document for id or resolves and caches it.
+func (p *Proxy) ResolveDID(ctx context.Context, id string) (didmodule.DIDDocument, error) {
+	now := time.Now()
+	if p.Watching() {
+		p.mu.RLock()
+		e, ok := p.entries[id]
+		p.mu.RUnlock()
+		if ok && now.Before(e.expires) {
+			atomic.AddUint64(&p.hits, 1)
+			return e.doc, nil
+		}
+	}
+	atomic.AddUint64(&p.misses, 1)
+
+	doc, err := p.next.ResolveDID(ctx, id)
+	if err != nil {
+		return didmodule.DIDDocument{}, err
+	}
+	if !p.Watching() {
+		return doc, nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if doc.Nonce < p.latest[id] {
+		// The node hasn't caught up with the event yet; serve its
+		// answer but don't keep it.
+		return doc, nil
+	}
+	if _, ok := p.entries[id]; !ok && len(p.entries) >= p.maxEntries {
+		p.evict(now)
+	}
+	p.entries[id] = entry{doc: doc, expires: now.Add(p.ttl)}
+	return doc, nil
+}
+
+// apply drops the cached document of ev's DID if the event reports a newer
+// version of it.
+func (p *Proxy) apply(ev didmodule.DIDEvent) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if e, ok := p.entries[ev.DID]; ok && e.doc.Nonce < ev.Version {
+		delete(p.entries, ev.DID)
+	}
+	if ev.Version > p.latest[ev.DID] {
+		p.latest[ev.DID] = ev.Version
+	}
+	if len(p.latest) > p.maxEntries {
+		// Versions only matter while a fetch may be in flight;
+		// forgetting them at worst caches a lagging answer for ttl.
+		p.latest = make(map[string]uint64)
+	}
+}
+
+// flush drops every cached document.
+func (p *Proxy) flush() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.entries = make(map[string]entry)
+	p.latest = make(map[string]uint64)
+}
+
+// evict drops expired entries, or the entry closest to expiry if none have
+// expired. p.mu must be held.
+func (p *Proxy) evict(now time.Time) {
+	oldest := ""
+	for id, e := range p.entries {
+		if !now.Before(e.expires) {
+			delete(p.entries, id)
+			continue
+		}
+		if oldest == "" || e.expires.Before(p.entries[oldest].expires) {
+			oldest = id
+		}
+	}
+	if len(p.entries) >= p.maxEntries && oldest != "" {
+		delete(p.entries, oldest)
+	}
+}
@@ -0,0 +1,191 @@
+package signer
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AWSKMSSigner signs with an ECC_NIST_EDWARDS25519 key of AWS KMS. Requests
+// are signed with Signature Version 4 using static credentials.
+type AWSKMSSigner struct {
+	KeyID           string
+	Region          string
+	Endpoint        string
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	Client          *http.Client
+
+	once sync.Once
+	pub  ed25519.PublicKey
+	err  error
+}
+
+// openAWSKMS opens awskms://<key-id>, where the key ID may be a key ID,
+// alias/<name>, or, as awskms:///<arn>, a key or alias ARN. The region comes
+// from the region query parameter, the ARN, or AWS_REGION; credentials come
+// from AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN. The
+// endpoint query parameter overrides the regional endpoint.
+func openAWSKMS(u *url.URL) (Signer, error) {
+	keyID := strings.Trim(u.Host+u.Path, "/")
+	if keyID == "" {
+		return nil, fmt.Errorf("signer: awskms URI must name a key")
+	}
+	q := u.Query()
+	region := q.Get("region")
+	if region == "" && strings.HasPrefix(keyID, "arn:") {
+		if parts := strings.Split(keyID, ":"); len(parts) > 3 {
+			region = parts[3]
+		}
+	}
+	region = firstNonEmpty(region, os.Getenv("AWS_REGION"), os.Getenv("AWS_DEFAULT_REGION"))
+	if region == "" {
+		return nil, fmt.Errorf("signer: AWS region is required (region parameter or AWS_REGION)")
+	}
+	s := &AWSKMSSigner{
+		KeyID:           keyID,
+		Region:          region,
+		Endpoint:        firstNonEmpty(q.Get("endpoint"), "https://kms."+region+".amazonaws.com"),
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+		Client:          &http.Client{Timeout: 30 * time.Second},
+	}
+	if s.AccessKeyID == "" || s.SecretAccessKey == "" {
+		return nil, fmt.Errorf("signer: AWS credentials are required (AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY)")
+	}
+	return s, nil
+}
+
+// PublicKey returns the public key of the KMS key.
+func (s *AWSKMSSigner) PublicKey() (ed25519.PublicKey, error) {
+	s.once.Do(func() {
+		var res struct {
+			KeySpec   string `json:"KeySpec"`
+			PublicKey []byte `json:"PublicKey"`
+		}
+		if s.err = s.call("GetPublicKey", map[string]string{"KeyId": s.KeyID}, &res); s.err != nil {
+			return
+		}
+		pub, err := x509.ParsePKIXPublicKey(res.PublicKey)
+		key, ok := pub.(ed25519.PublicKey)
+		if err != nil || !ok {
+			s.err = fmt.Errorf("signer: KMS key %s is a %s key, not Ed25519", s.KeyID, res.KeySpec)
+			return
+		}
+		s.pub = key
+	})
+	return s.pub, s.err
+}
+
+// Sign signs msg with the KMS key using pure EdDSA.
+func (s *AWSKMSSigner) Sign(msg []byte) ([]byte, error) {
+	req := map[string]interface{}{
+		"KeyId":            s.KeyID,
+		"Message":          msg,
+		"MessageType":      "RAW",
+		"SigningAlgorithm": "ED25519_SHA_512",
+	}
+	var res struct {
+		Signature []byte `json:"Signature"`
+	}
+	if err := s.call("Sign", req, &res); err != nil {
+		return nil, err
+	}
+	return res.Signature, nil
+}
+
+// call invokes a KMS JSON API action.
+func (s *AWSKMSSigner) call(action string, body, out interface{}) error {
+	bz, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", s.Endpoint+"/", bytes.NewReader(bz))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "TrentService."+action)
+	s.signRequest(req, bz, time.Now().UTC())
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("signer: KMS %s: %w", action, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		var e struct {
+			Type    string `json:"__type"`
+			Message string `json:"message"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&e)
+		return fmt.Errorf("signer: KMS %s returned %s: %s %s", action, resp.Status, e.Type, e.Message)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// signRequest adds the Signature Version 4 authorization of req.
+func (s *AWSKMSSigner) signRequest(req *http.Request, body []byte, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+	if s.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.SessionToken)
+	}
+
+	headers := map[string]string{
+		"content-type": req.Header.Get("Content-Type"),
+		"host":         req.URL.Host,
+		"x-amz-date":   amzDate,
+		"x-amz-target": req.Header.Get("X-Amz-Target"),
+	}
+	if s.SessionToken != "" {
+		headers["x-amz-security-token"] = s.SessionToken
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	payloadHash := sha256.Sum256(body)
+	canonicalRequest := strings.Join([]string{
+		"POST", "/", "", canonicalHeaders.String(), signedHeaders, hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	scope := date + "/" + s.Region + "/kms/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+s.SecretAccessKey), date)
+	key = hmacSHA256(key, s.Region)
+	key = hmacSHA256(key, "kms")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
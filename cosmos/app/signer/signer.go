@@ -0,0 +1,100 @@
+// Package signer abstracts the Ed25519 keys that sign DID operations so they
+// can live outside the process, in a remote key management service. Signers
+// are opened from URIs whose scheme selects the backend:
+//
+//	vault://transit/issuer-key     HashiCorp Vault transit engine
+//	awskms://alias/issuer-key      AWS KMS
+//
+// Further backends are added with Register.
+package signer
+
+import (
+	"crypto/ed25519"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Signer signs with an Ed25519 key it never reveals.
+type Signer interface {
+	// PublicKey returns the public half of the signing key.
+	PublicKey() (ed25519.PublicKey, error)
+	// Sign returns the Ed25519 signature of msg.
+	Sign(msg []byte) ([]byte, error)
+}
+
+// Backend opens the signer a URI of its scheme names.
+type Backend func(u *url.URL) (Signer, error)
+
+var (
+	mu       sync.RWMutex
+	backends = map[string]Backend{}
+)
+
+func init() {
+	Register("vault", openVault)
+	Register("awskms", openAWSKMS)
+}
+
+// Register makes a backend available under a URI scheme, replacing any
+// backend registered under it before.
+func Register(scheme string, backend Backend) {
+	mu.Lock()
+	defer mu.Unlock()
+	backends[strings.ToLower(scheme)] = backend
+}
+
+// Schemes returns the registered URI schemes.
+func Schemes() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	schemes := make([]string, 0, len(backends))
+	for scheme := range backends {
+		schemes = append(schemes, scheme)
+	}
+	sort.Strings(schemes)
+	return schemes
+}
+
+// Open opens the signer named by uri. The returned signer checks every
+// signature its backend makes against the public key before returning it.
+func Open(uri string) (Signer, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, fmt.Errorf("signer: invalid URI %q: %w", uri, err)
+	}
+	mu.RLock()
+	backend, ok := backends[strings.ToLower(u.Scheme)]
+	mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("signer: unknown scheme %q; registered schemes are %s", u.Scheme, strings.Join(Schemes(), ", "))
+	}
+	s, err := backend(u)
+	if err != nil {
+		return nil, err
+	}
+	return verifying{s}, nil
+}
+
+// verifying wraps a signer to catch backends that sign with another key
+// than the one they report, which would otherwise only surface on chain.
+type verifying struct {
+	Signer
+}
+
+func (v verifying) Sign(msg []byte) ([]byte, error) {
+	pub, err := v.Signer.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	sig, err := v.Signer.Sign(msg)
+	if err != nil {
+		return nil, err
+	}
+	if !ed25519.Verify(pub, msg, sig) {
+		return nil, fmt.Errorf("signer: backend returned a signature that doesn't verify against its public key")
+	}
+	return sig, nil
+}
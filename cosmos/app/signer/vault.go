@@ -0,0 +1,154 @@
+package signer
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// VaultSigner signs with an ed25519 key of a HashiCorp Vault transit secrets
+// engine. It pins the key version it read the public key of, so a rotation
+// in Vault doesn't silently change the key signatures are made with.
+type VaultSigner struct {
+	Addr      string
+	Token     string
+	Namespace string
+	Mount     string
+	Key       string
+	Client    *http.Client
+
+	once    sync.Once
+	pub     ed25519.PublicKey
+	version int
+	err     error
+}
+
+// openVault opens vault://<mount>/<key>. The server and token come from the
+// addr and token query parameters or the standard VAULT_ADDR, VAULT_TOKEN and
+// VAULT_NAMESPACE variables.
+func openVault(u *url.URL) (Signer, error) {
+	key := strings.Trim(u.Path, "/")
+	if u.Host == "" || key == "" {
+		return nil, fmt.Errorf("signer: vault URI must be vault://<mount>/<key>")
+	}
+	q := u.Query()
+	s := &VaultSigner{
+		Addr:      firstNonEmpty(q.Get("addr"), os.Getenv("VAULT_ADDR")),
+		Token:     firstNonEmpty(q.Get("token"), os.Getenv("VAULT_TOKEN")),
+		Namespace: firstNonEmpty(q.Get("namespace"), os.Getenv("VAULT_NAMESPACE")),
+		Mount:     u.Host,
+		Key:       key,
+		Client:    &http.Client{Timeout: 30 * time.Second},
+	}
+	if s.Addr == "" || s.Token == "" {
+		return nil, fmt.Errorf("signer: vault address and token are required (VAULT_ADDR, VAULT_TOKEN)")
+	}
+	return s, nil
+}
+
+// PublicKey returns the public key of the latest version of the transit key.
+func (s *VaultSigner) PublicKey() (ed25519.PublicKey, error) {
+	s.once.Do(func() { s.pub, s.version, s.err = s.readKey() })
+	return s.pub, s.err
+}
+
+func (s *VaultSigner) readKey() (ed25519.PublicKey, int, error) {
+	var res struct {
+		Data struct {
+			Type          string `json:"type"`
+			LatestVersion int    `json:"latest_version"`
+			Keys          map[string]struct {
+				PublicKey string `json:"public_key"`
+			} `json:"keys"`
+		} `json:"data"`
+	}
+	if err := s.do("GET", "keys/"+url.PathEscape(s.Key), nil, &res); err != nil {
+		return nil, 0, err
+	}
+	if res.Data.Type != "ed25519" {
+		return nil, 0, fmt.Errorf("signer: vault key %s is a %s key, not ed25519", s.Key, res.Data.Type)
+	}
+	version := res.Data.LatestVersion
+	pub, err := base64.StdEncoding.DecodeString(res.Data.Keys[strconv.Itoa(version)].PublicKey)
+	if err != nil || len(pub) != ed25519.PublicKeySize {
+		return nil, 0, fmt.Errorf("signer: vault key %s has no valid public key for version %d", s.Key, version)
+	}
+	return ed25519.PublicKey(pub), version, nil
+}
+
+// Sign signs msg with the pinned version of the transit key.
+func (s *VaultSigner) Sign(msg []byte) ([]byte, error) {
+	if _, err := s.PublicKey(); err != nil {
+		return nil, err
+	}
+	req := map[string]interface{}{
+		"input":       base64.StdEncoding.EncodeToString(msg),
+		"key_version": s.version,
+	}
+	var res struct {
+		Data struct {
+			Signature string `json:"signature"`
+		} `json:"data"`
+	}
+	if err := s.do("POST", "sign/"+url.PathEscape(s.Key), req, &res); err != nil {
+		return nil, err
+	}
+	// Signatures are returned as vault:v<version>:<base64>.
+	parts := strings.SplitN(res.Data.Signature, ":", 3)
+	if len(parts) != 3 || parts[0] != "vault" {
+		return nil, fmt.Errorf("signer: unexpected vault signature format")
+	}
+	return base64.StdEncoding.DecodeString(parts[2])
+}
+
+func (s *VaultSigner) do(method, path string, body, out interface{}) error {
+	var bz []byte
+	if body != nil {
+		var err error
+		if bz, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+	req, err := http.NewRequest(method, strings.TrimRight(s.Addr, "/")+"/v1/"+s.Mount+"/"+path, bytes.NewReader(bz))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Vault-Token", s.Token)
+	if s.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", s.Namespace)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("signer: vault: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		var e struct {
+			Errors []string `json:"errors"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&e)
+		return fmt.Errorf("signer: vault returned %s: %s", resp.Status, strings.Join(e.Errors, "; "))
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
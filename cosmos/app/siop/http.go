@@ -0,0 +1,151 @@
+package siop
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+var authorizePage = template.Must(template.New("authorize").Parse(`<!DOCTYPE html>
+<html>
+<head><title>Sign in with your DID</title></head>
+<body>
+<h1>Sign in with your DID</h1>
+<p>Sign the following challenge with a key from your did:aytch document and paste the resulting JWS below.</p>
+<pre id="challenge">{{.Challenge}}</pre>
+<p>The JWS payload must contain <code>iss</code> (your DID), <code>aud</code> ({{.Issuer}}) and <code>nonce</code> (the challenge).</p>
+<form method="POST" action="{{.Issuer}}/authorize/complete">
+<textarea name="proof" rows="6" cols="80"></textarea><br>
+<button type="submit">Sign in</button>
+</form>
+</body>
+</html>
+`))
+
+// RegisterRoutes registers the OIDC endpoints served by the bridge.
+func (p *Provider) RegisterRoutes(r *mux.Router) {
+	r.HandleFunc("/.well-known/openid-configuration", p.discoveryHandler).Methods("GET")
+	r.HandleFunc("/jwks.json", p.jwksHandler).Methods("GET")
+	r.HandleFunc("/authorize", p.authorizeHandler).Methods("GET")
+	r.HandleFunc("/authorize/complete", p.completeHandler).Methods("POST")
+	r.HandleFunc("/token", p.tokenHandler).Methods("POST")
+	r.HandleFunc("/userinfo", p.userInfoHandler).Methods("GET", "POST")
+}
+
+func (p *Provider) discoveryHandler(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                p.issuer,
+		"authorization_endpoint":                p.issuer + "/authorize",
+		"token_endpoint":                        p.issuer + "/token",
+		"userinfo_endpoint":                     p.issuer + "/userinfo",
+		"jwks_uri":                              p.issuer + "/jwks.json",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"EdDSA"},
+		"scopes_supported":                      []string{"openid"},
+		"token_endpoint_auth_methods_supported": []string{"client_secret_basic", "client_secret_post"},
+		"grant_types_supported":                 []string{"authorization_code"},
+		"claims_supported":                      []string{"sub", "iss", "aud", "exp", "iat", "auth_time", "nonce"},
+	})
+}
+
+func (p *Provider) jwksHandler(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{p.JWK()},
+	})
+}
+
+func (p *Provider) authorizeHandler(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	req, err := p.Authorize(q.Get("client_id"), q.Get("redirect_uri"), q.Get("response_type"), q.Get("scope"), q.Get("state"), q.Get("nonce"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if strings.Contains(r.Header.Get("Accept"), "application/json") {
+		writeJSON(w, http.StatusOK, map[string]string{
+			"challenge": req.Challenge,
+			"audience":  p.issuer,
+		})
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	authorizePage.Execute(w, map[string]string{
+		"Challenge": req.Challenge,
+		"Issuer":    p.issuer,
+	})
+}
+
+func (p *Provider) completeHandler(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	req, code, err := p.CompleteAuth(r.Context(), strings.TrimSpace(r.PostForm.Get("proof")))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	redirect, err := url.Parse(req.RedirectURI)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	params := redirect.Query()
+	params.Set("code", code)
+	if req.State != "" {
+		params.Set("state", req.State)
+	}
+	redirect.RawQuery = params.Encode()
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+func (p *Provider) tokenHandler(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeOAuthError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+	if r.PostForm.Get("grant_type") != "authorization_code" {
+		writeOAuthError(w, http.StatusBadRequest, "unsupported_grant_type", "only authorization_code is supported")
+		return
+	}
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok {
+		clientID, clientSecret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	resp, err := p.Exchange(clientID, clientSecret, r.PostForm.Get("code"), r.PostForm.Get("redirect_uri"))
+	if err != nil {
+		writeOAuthError(w, http.StatusBadRequest, "invalid_grant", err.Error())
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (p *Provider) userInfoHandler(w http.ResponseWriter, r *http.Request) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	sub, err := p.UserInfo(token)
+	if err != nil {
+		w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"sub": sub})
+}
+
+func writeOAuthError(w http.ResponseWriter, status int, code, description string) {
+	writeJSON(w, status, map[string]string{
+		"error":             code,
+		"error_description": description,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
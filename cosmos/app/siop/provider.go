@@ -0,0 +1,318 @@
+// Package siop implements an OpenID Connect provider bridge that
+// authenticates users with a DID-Auth challenge signed by a key from their
+// did:aytch document, so existing relying parties can accept DID logins.
+package siop
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"cosmos-app/jws"
+	didmodule "cosmos-app/modules/did"
+)
+
+// Lifetimes of the artifacts issued by the provider.
+const (
+	ChallengeTTL = 5 * time.Minute
+	CodeTTL      = time.Minute
+	TokenTTL     = time.Hour
+)
+
+// Resolver resolves DID documents from the on-chain registry.
+type Resolver interface {
+	ResolveDID(ctx context.Context, id string) (didmodule.DIDDocument, error)
+}
+
+// Client is a relying party registered with the bridge.
+type Client struct {
+	ID           string   `json:"client_id"`
+	Secret       string   `json:"client_secret"`
+	RedirectURIs []string `json:"redirect_uris"`
+}
+
+func (c Client) allowsRedirect(uri string) bool {
+	for _, u := range c.RedirectURIs {
+		if u == uri {
+			return true
+		}
+	}
+	return false
+}
+
+// AuthRequest is a pending OIDC authorization request awaiting DID-Auth.
+type AuthRequest struct {
+	ClientID    string
+	RedirectURI string
+	State       string
+	Nonce       string
+	Challenge   string
+	createdAt   time.Time
+}
+
+type grant struct {
+	clientID    string
+	redirectURI string
+	subject     string
+	nonce       string
+	authTime    time.Time
+	createdAt   time.Time
+}
+
+// TokenResponse is returned from the token endpoint.
+type TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+	IDToken     string `json:"id_token"`
+}
+
+// Provider is the OIDC provider state.
+type Provider struct {
+	issuer   string
+	key      ed25519.PrivateKey
+	keyID    string
+	resolver Resolver
+	clients  map[string]Client
+
+	mu       sync.Mutex
+	requests map[string]*AuthRequest
+	codes    map[string]*grant
+	tokens   map[string]*grant
+}
+
+// NewProvider creates a provider that signs id_tokens with key.
+func NewProvider(issuer string, key ed25519.PrivateKey, resolver Resolver, clients []Client) *Provider {
+	pub := key.Public().(ed25519.PublicKey)
+	sum := sha256.Sum256(pub)
+	p := &Provider{
+		issuer:   strings.TrimRight(issuer, "/"),
+		key:      key,
+		keyID:    hex.EncodeToString(sum[:8]),
+		resolver: resolver,
+		clients:  make(map[string]Client, len(clients)),
+		requests: make(map[string]*AuthRequest),
+		codes:    make(map[string]*grant),
+		tokens:   make(map[string]*grant),
+	}
+	for _, c := range clients {
+		p.clients[c.ID] = c
+	}
+	return p
+}
+
+// Authorize validates an authorization request from a relying party and
+// returns the DID-Auth challenge the user has to sign.
+func (p *Provider) Authorize(clientID, redirectURI, responseType, scope, state, nonce string) (*AuthRequest, error) {
+	client, ok := p.clients[clientID]
+	if !ok {
+		return nil, fmt.Errorf("unknown client %s", clientID)
+	}
+	if !client.allowsRedirect(redirectURI) {
+		return nil, fmt.Errorf("redirect_uri not registered for client")
+	}
+	if responseType != "code" {
+		return nil, fmt.Errorf("unsupported response_type %q", responseType)
+	}
+	if !hasScope(scope, "openid") {
+		return nil, fmt.Errorf("scope must include openid")
+	}
+	challenge, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	req := &AuthRequest{
+		ClientID:    clientID,
+		RedirectURI: redirectURI,
+		State:       state,
+		Nonce:       nonce,
+		Challenge:   challenge,
+		createdAt:   time.Now(),
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pruneLocked()
+	p.requests[challenge] = req
+	return req, nil
+}
+
+// CompleteAuth verifies the signed DID-Auth challenge and returns the pending
+// request together with a one-time authorization code.
+func (p *Provider) CompleteAuth(ctx context.Context, proof string) (*AuthRequest, string, error) {
+	parsed, err := jws.Parse(proof)
+	if err != nil {
+		return nil, "", err
+	}
+	var claims struct {
+		Iss   string `json:"iss"`
+		Aud   string `json:"aud"`
+		Nonce string `json:"nonce"`
+	}
+	if err := parsed.Claims(&claims); err != nil {
+		return nil, "", fmt.Errorf("invalid proof claims: %w", err)
+	}
+	if claims.Aud != p.issuer {
+		return nil, "", fmt.Errorf("proof audience mismatch")
+	}
+
+	p.mu.Lock()
+	req, ok := p.requests[claims.Nonce]
+	if ok {
+		delete(p.requests, claims.Nonce)
+	}
+	p.mu.Unlock()
+	if !ok || time.Since(req.createdAt) > ChallengeTTL {
+		return nil, "", fmt.Errorf("unknown or expired challenge")
+	}
+
+	did := strings.SplitN(parsed.Header.Kid, "#", 2)[0]
+	if did == "" || did != claims.Iss {
+		return nil, "", fmt.Errorf("proof kid does not belong to %s", claims.Iss)
+	}
+	doc, err := p.resolver.ResolveDID(ctx, did)
+	if err != nil {
+		return nil, "", err
+	}
+	pub, err := doc.Ed25519PublicKey()
+	if err != nil {
+		return nil, "", err
+	}
+	if err := parsed.Verify(pub); err != nil {
+		return nil, "", err
+	}
+
+	code, err := randomToken()
+	if err != nil {
+		return nil, "", err
+	}
+	now := time.Now()
+	p.mu.Lock()
+	p.codes[code] = &grant{
+		clientID:    req.ClientID,
+		redirectURI: req.RedirectURI,
+		subject:     did,
+		nonce:       req.Nonce,
+		authTime:    now,
+		createdAt:   now,
+	}
+	p.mu.Unlock()
+	return req, code, nil
+}
+
+// Exchange redeems an authorization code for an access token and id_token.
+func (p *Provider) Exchange(clientID, clientSecret, code, redirectURI string) (*TokenResponse, error) {
+	client, ok := p.clients[clientID]
+	if !ok || client.Secret != clientSecret {
+		return nil, fmt.Errorf("invalid client credentials")
+	}
+
+	p.mu.Lock()
+	g, ok := p.codes[code]
+	delete(p.codes, code)
+	p.mu.Unlock()
+	if !ok || time.Since(g.createdAt) > CodeTTL {
+		return nil, fmt.Errorf("invalid or expired code")
+	}
+	if g.clientID != clientID || g.redirectURI != redirectURI {
+		return nil, fmt.Errorf("code was not issued to this client")
+	}
+
+	now := time.Now()
+	claims := map[string]interface{}{
+		"iss":       p.issuer,
+		"sub":       g.subject,
+		"aud":       clientID,
+		"iat":       now.Unix(),
+		"exp":       now.Add(TokenTTL).Unix(),
+		"auth_time": g.authTime.Unix(),
+	}
+	if g.nonce != "" {
+		claims["nonce"] = g.nonce
+	}
+	idToken, err := jws.Sign(jws.Header{Kid: p.keyID, Typ: "JWT"}, claims, p.key)
+	if err != nil {
+		return nil, err
+	}
+	accessToken, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	g.createdAt = now
+	p.tokens[accessToken] = g
+	p.mu.Unlock()
+	return &TokenResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(TokenTTL.Seconds()),
+		IDToken:     idToken,
+	}, nil
+}
+
+// UserInfo returns the DID bound to an access token.
+func (p *Provider) UserInfo(accessToken string) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	g, ok := p.tokens[accessToken]
+	if !ok || time.Since(g.createdAt) > TokenTTL {
+		return "", fmt.Errorf("invalid or expired access token")
+	}
+	return g.subject, nil
+}
+
+// JWK returns the provider's public signing key in JWK form.
+func (p *Provider) JWK() map[string]string {
+	pub := p.key.Public().(ed25519.PublicKey)
+	return map[string]string{
+		"kty": "OKP",
+		"crv": "Ed25519",
+		"use": "sig",
+		"alg": jws.AlgEdDSA,
+		"kid": p.keyID,
+		"x":   base64.RawURLEncoding.EncodeToString(pub),
+	}
+}
+
+func (p *Provider) pruneLocked() {
+	for k, r := range p.requests {
+		if time.Since(r.createdAt) > ChallengeTTL {
+			delete(p.requests, k)
+		}
+	}
+	for k, g := range p.codes {
+		if time.Since(g.createdAt) > CodeTTL {
+			delete(p.codes, k)
+		}
+	}
+	for k, g := range p.tokens {
+		if time.Since(g.createdAt) > TokenTTL {
+			delete(p.tokens, k)
+		}
+	}
+}
+
+func hasScope(scope, want string) bool {
+	for _, s := range strings.Fields(scope) {
+		if s == want {
+			return true
+		}
+	}
+	return false
+}
+
+func randomToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
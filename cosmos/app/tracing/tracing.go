@@ -0,0 +1,110 @@
+// Package tracing wires OpenTelemetry into the node. Tracing is off unless
+// --tracing.endpoint points at an OTLP/HTTP collector; when it is, spans are
+// started for API server requests, DID Msg and Query service calls and the
+// DID keeper's store reads and writes. A W3C traceparent header on an API
+// request is continued by its server span, and one sent as gRPC metadata to
+// the node's gRPC server is continued by the query's span.
+package tracing
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Start command flags.
+const (
+	FlagEndpoint    = "tracing.endpoint"
+	FlagInsecure    = "tracing.insecure"
+	FlagSampleRatio = "tracing.sample-ratio"
+)
+
+var enabled bool
+
+// AddFlags adds the tracing flags to the start command.
+func AddFlags(cmd *cobra.Command) {
+	cmd.Flags().String(FlagEndpoint, "", "OTLP/HTTP collector host:port to export traces to; tracing is disabled when empty")
+	cmd.Flags().Bool(FlagInsecure, false, "Export traces over plain HTTP")
+	cmd.Flags().Float64(FlagSampleRatio, 1, "Fraction of root traces to sample")
+}
+
+// Setup installs a global tracer provider exporting to endpoint and the W3C
+// trace context propagator. The returned func flushes pending spans.
+func Setup(serviceName, endpoint string, insecure bool, ratio float64) (func(context.Context) error, error) {
+	opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(endpoint)}
+	if insecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	exporter, err := otlptracehttp.New(context.Background(), opts...)
+	if err != nil {
+		return nil, err
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", serviceName))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	enabled = true
+	return provider.Shutdown, nil
+}
+
+// Enabled reports whether Setup has installed an exporting tracer provider.
+func Enabled() bool {
+	return enabled
+}
+
+// Middleware starts a server span for each HTTP request, continuing any
+// trace context carried in the request headers.
+func Middleware(next http.Handler) http.Handler {
+	tracer := otel.Tracer("cosmos-app/api")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := tracer.Start(ctx, r.Method+" "+r.URL.Path,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(attribute.String("http.method", r.Method), attribute.String("http.target", r.URL.RequestURI())),
+		)
+		defer span.End()
+
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r.WithContext(ctx))
+		span.SetAttributes(attribute.Int("http.status_code", rec.status))
+		if rec.status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(rec.status))
+		}
+	})
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Hijack lets websocket upgrades, such as /dids/watch, pass through the
+// middleware.
+func (r *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := r.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer does not support hijacking")
+	}
+	r.status = http.StatusSwitchingProtocols
+	return h.Hijack()
+}
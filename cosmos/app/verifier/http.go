@@ -0,0 +1,95 @@
+package verifier
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// RegisterRoutes registers the verifier's HTTP endpoints.
+func (v *Verifier) RegisterRoutes(r *mux.Router) {
+	r.HandleFunc("/requests", v.createRequestHandler).Methods("POST")
+	r.HandleFunc("/requests/{state}", v.resultHandler).Methods("GET")
+	r.HandleFunc("/requests/{verifier}/{definition}", v.createRegisteredRequestHandler).Methods("POST")
+	r.HandleFunc("/response", v.directPostHandler).Methods("POST")
+}
+
+func (v *Verifier) createRequestHandler(w http.ResponseWriter, r *http.Request) {
+	var pd PresentationDefinition
+	if err := json.NewDecoder(r.Body).Decode(&pd); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	req, err := v.CreateRequest(pd)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	v.writeRequest(w, req)
+}
+
+func (v *Verifier) writeRequest(w http.ResponseWriter, req AuthorizationRequest) {
+	uri, err := req.URI()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"request":     req,
+		"request_uri": uri,
+	})
+}
+
+// createRegisteredRequestHandler starts a session for a presentation
+// definition published on chain.
+func (v *Verifier) createRegisteredRequestHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	req, err := v.CreateRequestFor(r.Context(), vars["verifier"], vars["definition"])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	v.writeRequest(w, req)
+}
+
+func (v *Verifier) resultHandler(w http.ResponseWriter, r *http.Request) {
+	result, err := v.Result(mux.Vars(r)["state"])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if result == nil {
+		writeJSON(w, http.StatusAccepted, map[string]string{"status": "pending"})
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// directPostHandler receives the wallet's authorization response
+// (response_mode=direct_post).
+func (v *Verifier) directPostHandler(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	result, err := v.HandleResponse(r.Context(), r.PostForm.Get("state"), r.PostForm.Get("vp_token"), r.PostForm.Get("presentation_submission"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !result.Verified {
+		writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error":             "invalid_request",
+			"error_description": result.Error,
+		})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,324 @@
+package verifier
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// FormatJWTVC is the Presentation Exchange format of the JWT credentials
+// presented by this module's wallets.
+const FormatJWTVC = "jwt_vc"
+
+// Constraints restrict which credentials satisfy an input descriptor.
+type Constraints struct {
+	LimitDisclosure string  `json:"limit_disclosure,omitempty"`
+	Fields          []Field `json:"fields,omitempty"`
+}
+
+// Field requires a value at one of Path, optionally matching Filter.
+type Field struct {
+	ID       string   `json:"id,omitempty"`
+	Path     []string `json:"path"`
+	Purpose  string   `json:"purpose,omitempty"`
+	Filter   *Filter  `json:"filter,omitempty"`
+	Optional bool     `json:"optional,omitempty"`
+}
+
+// Filter is the subset of JSON Schema supported by field filters.
+type Filter struct {
+	Type     string        `json:"type,omitempty"`
+	Const    interface{}   `json:"const,omitempty"`
+	Enum     []interface{} `json:"enum,omitempty"`
+	Pattern  string        `json:"pattern,omitempty"`
+	Minimum  *float64      `json:"minimum,omitempty"`
+	Maximum  *float64      `json:"maximum,omitempty"`
+	Contains *Filter       `json:"contains,omitempty"`
+}
+
+// Candidate is a credential considered for a presentation definition.
+type Candidate struct {
+	ID     string
+	Format string
+	Claims map[string]interface{}
+}
+
+// ParseConstraints decodes the descriptor's constraints. A descriptor without
+// constraints is satisfied by any credential.
+func (d InputDescriptor) ParseConstraints() (Constraints, error) {
+	var c Constraints
+	if len(d.Constraints) == 0 {
+		return c, nil
+	}
+	if err := json.Unmarshal(d.Constraints, &c); err != nil {
+		return c, fmt.Errorf("input descriptor %s: invalid constraints: %w", d.ID, err)
+	}
+	for _, f := range c.Fields {
+		if len(f.Path) == 0 {
+			return c, fmt.Errorf("input descriptor %s: field without path", d.ID)
+		}
+	}
+	return c, nil
+}
+
+// Matches reports whether credential claims satisfy the descriptor's
+// constraints.
+func (d InputDescriptor) Matches(claims map[string]interface{}) (bool, error) {
+	c, err := d.ParseConstraints()
+	if err != nil {
+		return false, err
+	}
+	for _, f := range c.Fields {
+		ok, err := f.matches(claims)
+		if err != nil {
+			return false, fmt.Errorf("input descriptor %s: %w", d.ID, err)
+		}
+		if !ok && !f.Optional {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// Match selects, for each input descriptor of pd, the first candidate that
+// satisfies it. It returns the selected candidates in presentation order,
+// each listed once, and the submission mapping descriptors to them.
+func Match(pd PresentationDefinition, candidates []Candidate) ([]Candidate, PresentationSubmission, error) {
+	ps := PresentationSubmission{ID: pd.ID + "-submission", DefinitionID: pd.ID}
+	var selected []Candidate
+	position := make(map[string]int)
+	for _, d := range pd.InputDescriptors {
+		found := false
+		for _, c := range candidates {
+			ok, err := d.Matches(c.Claims)
+			if err != nil {
+				return nil, PresentationSubmission{}, err
+			}
+			if !ok {
+				continue
+			}
+			i, seen := position[c.ID]
+			if !seen {
+				i = len(selected)
+				position[c.ID] = i
+				selected = append(selected, c)
+			}
+			format := c.Format
+			if format == "" {
+				format = FormatJWTVC
+			}
+			ps.DescriptorMap = append(ps.DescriptorMap, DescriptorMap{
+				ID:     d.ID,
+				Format: format,
+				Path:   fmt.Sprintf("$.verifiableCredential[%d]", i),
+			})
+			found = true
+			break
+		}
+		if !found {
+			return nil, PresentationSubmission{}, fmt.Errorf("no credential satisfies input descriptor %s", d.ID)
+		}
+	}
+	return selected, ps, nil
+}
+
+// credentialIndex returns the position a descriptor map path points to in
+// the presentation's verifiableCredential array.
+func credentialIndex(path string) (int, error) {
+	for _, prefix := range []string{"$.verifiableCredential[", "$.vp.verifiableCredential["} {
+		if strings.HasPrefix(path, prefix) && strings.HasSuffix(path, "]") {
+			return strconv.Atoi(path[len(prefix) : len(path)-1])
+		}
+	}
+	return 0, fmt.Errorf("unsupported descriptor path %q", path)
+}
+
+func (f Field) matches(claims map[string]interface{}) (bool, error) {
+	for _, p := range f.Path {
+		values, err := evalPath(p, claims)
+		if err != nil {
+			return false, err
+		}
+		for _, v := range values {
+			if f.Filter == nil {
+				return true, nil
+			}
+			ok, err := f.Filter.matches(v)
+			if err != nil {
+				return false, err
+			}
+			if ok {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func (f *Filter) matches(v interface{}) (bool, error) {
+	if f.Type != "" && !hasType(v, f.Type) {
+		return false, nil
+	}
+	if f.Const != nil && !reflect.DeepEqual(normalize(f.Const), normalize(v)) {
+		return false, nil
+	}
+	if len(f.Enum) > 0 {
+		found := false
+		for _, e := range f.Enum {
+			if reflect.DeepEqual(normalize(e), normalize(v)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false, nil
+		}
+	}
+	if f.Pattern != "" {
+		s, ok := v.(string)
+		if !ok {
+			return false, nil
+		}
+		re, err := regexp.Compile(f.Pattern)
+		if err != nil {
+			return false, fmt.Errorf("invalid filter pattern: %w", err)
+		}
+		if !re.MatchString(s) {
+			return false, nil
+		}
+	}
+	if f.Minimum != nil || f.Maximum != nil {
+		n, ok := v.(float64)
+		if !ok {
+			return false, nil
+		}
+		if (f.Minimum != nil && n < *f.Minimum) || (f.Maximum != nil && n > *f.Maximum) {
+			return false, nil
+		}
+	}
+	if f.Contains != nil {
+		items, ok := v.([]interface{})
+		if !ok {
+			return false, nil
+		}
+		for _, item := range items {
+			ok, err := f.Contains.matches(item)
+			if err != nil || ok {
+				return ok, err
+			}
+		}
+		return false, nil
+	}
+	return true, nil
+}
+
+func hasType(v interface{}, typ string) bool {
+	switch typ {
+	case "string":
+		_, ok := v.(string)
+		return ok
+	case "number":
+		_, ok := v.(float64)
+		return ok
+	case "integer":
+		n, ok := v.(float64)
+		return ok && n == float64(int64(n))
+	case "boolean":
+		_, ok := v.(bool)
+		return ok
+	case "array":
+		_, ok := v.([]interface{})
+		return ok
+	case "object":
+		_, ok := v.(map[string]interface{})
+		return ok
+	}
+	return false
+}
+
+// normalize round-trips a value through JSON so filter operands and decoded
+// claims compare with the same Go types.
+func normalize(v interface{}) interface{} {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var out interface{}
+	if err := json.Unmarshal(b, &out); err != nil {
+		return v
+	}
+	return out
+}
+
+// evalPath evaluates the JSONPath subset used by Presentation Exchange
+// fields: $ followed by .name, ['name'], [index] and [*] segments.
+func evalPath(path string, root interface{}) ([]interface{}, error) {
+	segments, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	current := []interface{}{root}
+	for _, seg := range segments {
+		var next []interface{}
+		for _, v := range current {
+			switch node := v.(type) {
+			case map[string]interface{}:
+				if seg == "*" {
+					for _, child := range node {
+						next = append(next, child)
+					}
+				} else if child, ok := node[seg]; ok {
+					next = append(next, child)
+				}
+			case []interface{}:
+				if seg == "*" {
+					next = append(next, node...)
+				} else if i, err := strconv.Atoi(seg); err == nil && i >= 0 && i < len(node) {
+					next = append(next, node[i])
+				}
+			}
+		}
+		current = next
+	}
+	return current, nil
+}
+
+func parsePath(path string) ([]string, error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("invalid path %q: must start with $", path)
+	}
+	var segments []string
+	rest := path[1:]
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("invalid path %q", path)
+			}
+			segments = append(segments, rest[:end])
+			rest = rest[end:]
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid path %q: unterminated bracket", path)
+			}
+			seg := strings.Trim(rest[1:end], `'"`)
+			if seg == "" {
+				return nil, fmt.Errorf("invalid path %q", path)
+			}
+			segments = append(segments, seg)
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("invalid path %q", path)
+		}
+	}
+	return segments, nil
+}
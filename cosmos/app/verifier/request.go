@@ -0,0 +1,67 @@
+package verifier
+
+import (
+	"encoding/json"
+	"net/url"
+)
+
+// PresentationDefinition is a DIF Presentation Exchange definition describing
+// the credentials a verifier requires.
+type PresentationDefinition struct {
+	ID               string            `json:"id"`
+	Name             string            `json:"name,omitempty"`
+	Purpose          string            `json:"purpose,omitempty"`
+	InputDescriptors []InputDescriptor `json:"input_descriptors"`
+}
+
+// InputDescriptor describes a single required credential.
+type InputDescriptor struct {
+	ID          string          `json:"id"`
+	Name        string          `json:"name,omitempty"`
+	Purpose     string          `json:"purpose,omitempty"`
+	Constraints json.RawMessage `json:"constraints,omitempty"`
+}
+
+// PresentationSubmission maps presented credentials to input descriptors.
+type PresentationSubmission struct {
+	ID            string          `json:"id"`
+	DefinitionID  string          `json:"definition_id"`
+	DescriptorMap []DescriptorMap `json:"descriptor_map"`
+}
+
+// DescriptorMap links an input descriptor to a path within the vp_token.
+type DescriptorMap struct {
+	ID     string `json:"id"`
+	Format string `json:"format"`
+	Path   string `json:"path"`
+}
+
+// AuthorizationRequest holds the OpenID4VP parameters sent to the wallet.
+type AuthorizationRequest struct {
+	ResponseType           string                 `json:"response_type"`
+	ResponseMode           string                 `json:"response_mode"`
+	ClientID               string                 `json:"client_id"`
+	ClientIDScheme         string                 `json:"client_id_scheme"`
+	ResponseURI            string                 `json:"response_uri"`
+	Nonce                  string                 `json:"nonce"`
+	State                  string                 `json:"state"`
+	PresentationDefinition PresentationDefinition `json:"presentation_definition"`
+}
+
+// URI encodes the request by value as an openid4vp:// URI for the wallet.
+func (r AuthorizationRequest) URI() (string, error) {
+	pd, err := json.Marshal(r.PresentationDefinition)
+	if err != nil {
+		return "", err
+	}
+	q := url.Values{}
+	q.Set("response_type", r.ResponseType)
+	q.Set("response_mode", r.ResponseMode)
+	q.Set("client_id", r.ClientID)
+	q.Set("client_id_scheme", r.ClientIDScheme)
+	q.Set("response_uri", r.ResponseURI)
+	q.Set("nonce", r.Nonce)
+	q.Set("state", r.State)
+	q.Set("presentation_definition", string(pd))
+	return "openid4vp://?" + q.Encode(), nil
+}
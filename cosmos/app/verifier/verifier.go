@@ -0,0 +1,354 @@
+// Package verifier implements an OpenID for Verifiable Presentations verifier
+// that checks presented credentials against the on-chain DID and revocation
+// registries.
+package verifier
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"cosmos-app/jws"
+	didmodule "cosmos-app/modules/did"
+)
+
+// RequestTTL bounds how long a wallet has to answer an authorization request.
+const RequestTTL = 10 * time.Minute
+
+// Registry provides read access to the on-chain DID and revocation registries.
+type Registry interface {
+	ResolveDID(ctx context.Context, id string) (didmodule.DIDDocument, error)
+	CredentialStatus(ctx context.Context, id string) (didmodule.CredentialStatus, error)
+}
+
+// DefinitionRegistry is implemented by registries that also serve the
+// presentation definitions verifiers publish on chain.
+type DefinitionRegistry interface {
+	PresentationDefinition(ctx context.Context, verifier, id string) (didmodule.PresentationDefinitionRecord, error)
+}
+
+// Result is the outcome of a presentation verification.
+type Result struct {
+	Verified    bool     `json:"verified"`
+	Holder      string   `json:"holder,omitempty"`
+	Credentials []string `json:"credentials,omitempty"`
+	Error       string   `json:"error,omitempty"`
+}
+
+type session struct {
+	request   AuthorizationRequest
+	createdAt time.Time
+	result    *Result
+}
+
+// Verifier issues authorization requests and validates direct_post responses.
+type Verifier struct {
+	clientID    string
+	responseURI string
+	registry    Registry
+
+	mu       sync.Mutex
+	sessions map[string]*session
+}
+
+// NewVerifier creates a verifier identified by clientID that receives wallet
+// responses at responseURI.
+func NewVerifier(clientID, responseURI string, registry Registry) *Verifier {
+	return &Verifier{
+		clientID:    clientID,
+		responseURI: responseURI,
+		registry:    registry,
+		sessions:    make(map[string]*session),
+	}
+}
+
+// CreateRequest starts a new presentation session for the given definition.
+func (v *Verifier) CreateRequest(pd PresentationDefinition) (AuthorizationRequest, error) {
+	if len(pd.InputDescriptors) == 0 {
+		return AuthorizationRequest{}, fmt.Errorf("presentation definition has no input descriptors")
+	}
+	for _, d := range pd.InputDescriptors {
+		if _, err := d.ParseConstraints(); err != nil {
+			return AuthorizationRequest{}, err
+		}
+	}
+	state, err := randomToken()
+	if err != nil {
+		return AuthorizationRequest{}, err
+	}
+	nonce, err := randomToken()
+	if err != nil {
+		return AuthorizationRequest{}, err
+	}
+	req := AuthorizationRequest{
+		ResponseType:           "vp_token",
+		ResponseMode:           "direct_post",
+		ClientID:               v.clientID,
+		ClientIDScheme:         "redirect_uri",
+		ResponseURI:            v.responseURI,
+		Nonce:                  nonce,
+		State:                  state,
+		PresentationDefinition: pd,
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.pruneLocked()
+	v.sessions[state] = &session{request: req, createdAt: time.Now()}
+	return req, nil
+}
+
+// CreateRequestFor starts a new presentation session for a definition the
+// verifier DID published on chain. The verifier's registry must implement
+// DefinitionRegistry.
+func (v *Verifier) CreateRequestFor(ctx context.Context, verifierDID, id string) (AuthorizationRequest, error) {
+	definitions, ok := v.registry.(DefinitionRegistry)
+	if !ok {
+		return AuthorizationRequest{}, fmt.Errorf("registry does not serve presentation definitions")
+	}
+	record, err := definitions.PresentationDefinition(ctx, verifierDID, id)
+	if err != nil {
+		return AuthorizationRequest{}, err
+	}
+	var pd PresentationDefinition
+	if err := json.Unmarshal([]byte(record.Definition), &pd); err != nil {
+		return AuthorizationRequest{}, fmt.Errorf("presentation definition %s: %w", id, err)
+	}
+	return v.CreateRequest(pd)
+}
+
+// Result returns the verification result for a session, or nil if the wallet
+// has not responded yet.
+func (v *Verifier) Result(state string) (*Result, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	s, ok := v.sessions[state]
+	if !ok {
+		return nil, fmt.Errorf("unknown or expired state")
+	}
+	return s.result, nil
+}
+
+// HandleResponse validates a direct_post response and records its result.
+func (v *Verifier) HandleResponse(ctx context.Context, state, vpToken, submission string) (*Result, error) {
+	v.mu.Lock()
+	s, ok := v.sessions[state]
+	if ok && (s.result != nil || time.Since(s.createdAt) > RequestTTL) {
+		ok = false
+	}
+	v.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown, expired or completed state")
+	}
+
+	result := &Result{}
+	holder, creds, err := v.verify(ctx, s.request, vpToken, submission)
+	if err != nil {
+		result.Error = err.Error()
+	} else {
+		result.Verified = true
+		result.Holder = holder
+		result.Credentials = creds
+	}
+
+	v.mu.Lock()
+	s.result = result
+	v.mu.Unlock()
+	return result, nil
+}
+
+type vpClaims struct {
+	Iss   string `json:"iss"`
+	Aud   string `json:"aud"`
+	Nonce string `json:"nonce"`
+	Exp   int64  `json:"exp"`
+	VP    struct {
+		VerifiableCredential []string `json:"verifiableCredential"`
+	} `json:"vp"`
+}
+
+type vcClaims struct {
+	Iss string `json:"iss"`
+	Sub string `json:"sub"`
+	Jti string `json:"jti"`
+	Nbf int64  `json:"nbf"`
+	Exp int64  `json:"exp"`
+}
+
+func (v *Verifier) verify(ctx context.Context, req AuthorizationRequest, vpToken, submission string) (string, []string, error) {
+	var ps PresentationSubmission
+	if err := json.Unmarshal([]byte(submission), &ps); err != nil {
+		return "", nil, fmt.Errorf("invalid presentation_submission: %w", err)
+	}
+	if ps.DefinitionID != req.PresentationDefinition.ID {
+		return "", nil, fmt.Errorf("presentation_submission does not match definition %s", req.PresentationDefinition.ID)
+	}
+
+	vp, err := v.verifySigned(ctx, vpToken)
+	if err != nil {
+		return "", nil, fmt.Errorf("vp_token: %w", err)
+	}
+	var claims vpClaims
+	if err := vp.Claims(&claims); err != nil {
+		return "", nil, fmt.Errorf("vp_token: invalid claims: %w", err)
+	}
+	if claims.Aud != req.ClientID {
+		return "", nil, fmt.Errorf("vp_token: audience mismatch")
+	}
+	if claims.Nonce != req.Nonce {
+		return "", nil, fmt.Errorf("vp_token: nonce mismatch")
+	}
+	if claims.Exp != 0 && time.Now().Unix() > claims.Exp {
+		return "", nil, fmt.Errorf("vp_token: expired")
+	}
+	if len(claims.VP.VerifiableCredential) == 0 {
+		return "", nil, fmt.Errorf("vp_token: no credentials presented")
+	}
+
+	mapped := make(map[string]bool, len(ps.DescriptorMap))
+	for _, dm := range ps.DescriptorMap {
+		mapped[dm.ID] = true
+	}
+	for _, d := range req.PresentationDefinition.InputDescriptors {
+		if !mapped[d.ID] {
+			return "", nil, fmt.Errorf("input descriptor %s not satisfied", d.ID)
+		}
+	}
+
+	var ids []string
+	for i, token := range claims.VP.VerifiableCredential {
+		id, err := v.verifyCredential(ctx, token, claims.Iss)
+		if err != nil {
+			return "", nil, fmt.Errorf("credential %d: %w", i, err)
+		}
+		ids = append(ids, id)
+	}
+	if err := checkDescriptors(req.PresentationDefinition, ps, claims.VP.VerifiableCredential); err != nil {
+		return "", nil, err
+	}
+	return claims.Iss, ids, nil
+}
+
+// checkDescriptors ensures every credential the submission maps to an input
+// descriptor satisfies that descriptor's constraints.
+func checkDescriptors(pd PresentationDefinition, ps PresentationSubmission, tokens []string) error {
+	descriptors := make(map[string]InputDescriptor, len(pd.InputDescriptors))
+	for _, d := range pd.InputDescriptors {
+		descriptors[d.ID] = d
+	}
+	for _, dm := range ps.DescriptorMap {
+		d, ok := descriptors[dm.ID]
+		if !ok {
+			continue
+		}
+		i, err := credentialIndex(dm.Path)
+		if err != nil {
+			return fmt.Errorf("input descriptor %s: %w", dm.ID, err)
+		}
+		if i < 0 || i >= len(tokens) {
+			return fmt.Errorf("input descriptor %s: path %s out of range", dm.ID, dm.Path)
+		}
+		parsed, err := jws.Parse(tokens[i])
+		if err != nil {
+			return fmt.Errorf("credential %d: %w", i, err)
+		}
+		var claims map[string]interface{}
+		if err := parsed.Claims(&claims); err != nil {
+			return fmt.Errorf("credential %d: invalid claims: %w", i, err)
+		}
+		ok, err = d.Matches(claims)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("credential %d does not satisfy input descriptor %s", i, dm.ID)
+		}
+	}
+	return nil
+}
+
+func (v *Verifier) verifyCredential(ctx context.Context, token, holder string) (string, error) {
+	vc, err := v.verifySigned(ctx, token)
+	if err != nil {
+		return "", err
+	}
+	var claims vcClaims
+	if err := vc.Claims(&claims); err != nil {
+		return "", fmt.Errorf("invalid claims: %w", err)
+	}
+	if claims.Sub != holder {
+		return "", fmt.Errorf("subject %s is not the presenting holder", claims.Sub)
+	}
+	now := time.Now().Unix()
+	if claims.Nbf != 0 && now < claims.Nbf {
+		return "", fmt.Errorf("not yet valid")
+	}
+	if claims.Exp != 0 && now > claims.Exp {
+		return "", fmt.Errorf("expired")
+	}
+	if claims.Jti != "" {
+		status, err := v.registry.CredentialStatus(ctx, claims.Jti)
+		if err != nil {
+			return "", err
+		}
+		if status.Revoked {
+			return "", fmt.Errorf("revoked by %s", status.Issuer)
+		}
+	}
+	return claims.Jti, nil
+}
+
+// verifySigned checks a JWT against the key of the DID named in its kid and
+// ensures the DID is also the token's issuer.
+func (v *Verifier) verifySigned(ctx context.Context, token string) (*jws.JWS, error) {
+	parsed, err := jws.Parse(token)
+	if err != nil {
+		return nil, err
+	}
+	did := strings.SplitN(parsed.Header.Kid, "#", 2)[0]
+	if did == "" {
+		return nil, fmt.Errorf("missing kid")
+	}
+	var claims struct {
+		Iss string `json:"iss"`
+	}
+	if err := parsed.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("invalid claims: %w", err)
+	}
+	if claims.Iss != did {
+		return nil, fmt.Errorf("kid %s does not belong to issuer %s", parsed.Header.Kid, claims.Iss)
+	}
+	doc, err := v.registry.ResolveDID(ctx, did)
+	if err != nil {
+		return nil, err
+	}
+	pub, err := doc.Ed25519PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	if err := parsed.Verify(pub); err != nil {
+		return nil, err
+	}
+	return parsed, nil
+}
+
+func (v *Verifier) pruneLocked() {
+	for state, s := range v.sessions {
+		if time.Since(s.createdAt) > 2*RequestTTL {
+			delete(v.sessions, state)
+		}
+	}
+}
+
+func randomToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
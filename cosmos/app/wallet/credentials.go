@@ -0,0 +1,196 @@
+package wallet
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"cosmos-app/jws"
+)
+
+// Credential is a JWT verifiable credential held by the wallet.
+type Credential struct {
+	ID         string    `json:"id"`
+	Issuer     string    `json:"issuer"`
+	Subject    string    `json:"subject"`
+	Token      string    `json:"token"`
+	ExpiresAt  int64     `json:"expires_at,omitempty"`
+	ReceivedAt time.Time `json:"received_at"`
+}
+
+// CredentialStore persists the wallet's credentials.
+type CredentialStore interface {
+	Put(Credential) error
+	Get(id string) (Credential, error)
+	List() ([]Credential, error)
+	Delete(id string) error
+}
+
+// ParseCredential reads the claims of a JWT credential without verifying its
+// signature. Credentials without a jti cannot be checked for revocation by
+// verifiers and are rejected.
+func ParseCredential(token string) (Credential, error) {
+	parsed, err := jws.Parse(token)
+	if err != nil {
+		return Credential{}, err
+	}
+	var claims struct {
+		Iss string `json:"iss"`
+		Sub string `json:"sub"`
+		Jti string `json:"jti"`
+		Exp int64  `json:"exp"`
+	}
+	if err := parsed.Claims(&claims); err != nil {
+		return Credential{}, fmt.Errorf("invalid credential claims: %w", err)
+	}
+	if claims.Jti == "" {
+		return Credential{}, fmt.Errorf("credential has no jti")
+	}
+	return Credential{
+		ID:         claims.Jti,
+		Issuer:     claims.Iss,
+		Subject:    claims.Sub,
+		Token:      token,
+		ExpiresAt:  claims.Exp,
+		ReceivedAt: time.Now().UTC(),
+	}, nil
+}
+
+// StoreCredential parses and stores a JWT credential issued to one of the
+// wallet's DIDs.
+func (w *Wallet) StoreCredential(token string) (Credential, error) {
+	cred, err := ParseCredential(token)
+	if err != nil {
+		return Credential{}, err
+	}
+	if err := w.credentials.Put(cred); err != nil {
+		return Credential{}, err
+	}
+	return cred, nil
+}
+
+// Credentials lists the stored credentials.
+func (w *Wallet) Credentials() ([]Credential, error) {
+	return w.credentials.List()
+}
+
+// MemoryStore is an in-memory CredentialStore.
+type MemoryStore struct {
+	mu    sync.RWMutex
+	creds map[string]Credential
+}
+
+// NewMemoryStore creates an empty in-memory credential store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{creds: make(map[string]Credential)}
+}
+
+func (s *MemoryStore) Put(c Credential) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.creds[c.ID] = c
+	return nil
+}
+
+func (s *MemoryStore) Get(id string) (Credential, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	c, ok := s.creds[id]
+	if !ok {
+		return Credential{}, fmt.Errorf("credential %s not found", id)
+	}
+	return c, nil
+}
+
+func (s *MemoryStore) List() ([]Credential, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]Credential, 0, len(s.creds))
+	for _, c := range s.creds {
+		out = append(out, c)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out, nil
+}
+
+func (s *MemoryStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.creds, id)
+	return nil
+}
+
+// FileStore is a CredentialStore keeping one JSON file per credential in a
+// directory.
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore creates a file credential store in dir, creating it if needed.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+// path names credential files by the hash of their id, since ids are often
+// URLs.
+func (s *FileStore) path(id string) string {
+	sum := sha256.Sum256([]byte(id))
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+func (s *FileStore) Put(c Credential) error {
+	bz, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path(c.ID), bz, 0o600)
+}
+
+func (s *FileStore) Get(id string) (Credential, error) {
+	bz, err := os.ReadFile(s.path(id))
+	if os.IsNotExist(err) {
+		return Credential{}, fmt.Errorf("credential %s not found", id)
+	}
+	if err != nil {
+		return Credential{}, err
+	}
+	var c Credential
+	return c, json.Unmarshal(bz, &c)
+}
+
+func (s *FileStore) List() ([]Credential, error) {
+	files, err := filepath.Glob(filepath.Join(s.dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Credential, 0, len(files))
+	for _, f := range files {
+		bz, err := os.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		var c Credential
+		if err := json.Unmarshal(bz, &c); err != nil {
+			return nil, fmt.Errorf("%s: %w", f, err)
+		}
+		out = append(out, c)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out, nil
+}
+
+func (s *FileStore) Delete(id string) error {
+	if err := os.Remove(s.path(id)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package wallet
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	didmodule "cosmos-app/modules/did"
+	"cosmos-app/verifier"
+)
+
+// CredentialManifestSpecVersion is the DIF Credential Manifest version of the
+// applications the wallet constructs.
+const CredentialManifestSpecVersion = "https://identity.foundation/credential-manifest/spec/v1.0.0/"
+
+// ResourceSource reads DID-linked resources, e.g. a resolver.REST client.
+type ResourceSource interface {
+	Resources(ctx context.Context, did string) ([]didmodule.Resource, error)
+	ResourceData(ctx context.Context, did, id string) ([]byte, error)
+}
+
+// CredentialApplication is a DIF Credential Application: a holder's request
+// for the credentials of a manifest, carrying a presentation of the inputs
+// the manifest's presentation definition requires.
+type CredentialApplication struct {
+	Application            ApplicationDescriptor            `json:"credential_application"`
+	PresentationSubmission *verifier.PresentationSubmission `json:"presentation_submission,omitempty"`
+	VPToken                string                           `json:"vp_token,omitempty"`
+}
+
+// ApplicationDescriptor names the manifest an application applies to.
+type ApplicationDescriptor struct {
+	ID          string          `json:"id"`
+	SpecVersion string          `json:"spec_version"`
+	ManifestID  string          `json:"manifest_id"`
+	Applicant   string          `json:"applicant"`
+	Format      json.RawMessage `json:"format,omitempty"`
+}
+
+// FetchCredentialManifest returns the latest version of the credential
+// manifest issuer published under name, or of its only manifest when name is
+// empty.
+func FetchCredentialManifest(ctx context.Context, src ResourceSource, issuer, name string) (didmodule.CredentialManifest, error) {
+	resources, err := src.Resources(ctx, issuer)
+	if err != nil {
+		return didmodule.CredentialManifest{}, err
+	}
+	var found []didmodule.Resource
+	for _, res := range resources {
+		if res.ResourceType != didmodule.CredentialManifestResourceType || res.NextVersionID != "" {
+			continue
+		}
+		if name == "" || res.Name == name {
+			found = append(found, res)
+		}
+	}
+	switch {
+	case len(found) == 0:
+		return didmodule.CredentialManifest{}, fmt.Errorf("%s has no credential manifest %q", issuer, name)
+	case len(found) > 1:
+		return didmodule.CredentialManifest{}, fmt.Errorf("%s publishes several credential manifests, name one", issuer)
+	}
+	if found[0].ContentCID != "" {
+		return didmodule.CredentialManifest{}, fmt.Errorf("credential manifest %s is stored off chain at %s", found[0].ID, found[0].ContentCID)
+	}
+	data, err := src.ResourceData(ctx, issuer, found[0].ID)
+	if err != nil {
+		return didmodule.CredentialManifest{}, err
+	}
+	return didmodule.ParseCredentialManifest(data, issuer)
+}
+
+// CreateCredentialApplication applies for the credentials of manifest as the
+// DID of the key stored under uid. If the manifest requires inputs, matching
+// stored credentials are presented to the issuer, bound to nonce.
+func (w *Wallet) CreateCredentialApplication(uid string, manifest didmodule.CredentialManifest, nonce string) (CredentialApplication, error) {
+	did, err := w.DID(uid)
+	if err != nil {
+		return CredentialApplication{}, err
+	}
+	id, err := applicationID()
+	if err != nil {
+		return CredentialApplication{}, err
+	}
+	app := CredentialApplication{Application: ApplicationDescriptor{
+		ID:          id,
+		SpecVersion: CredentialManifestSpecVersion,
+		ManifestID:  manifest.ID,
+		Applicant:   did,
+		Format:      manifest.Format,
+	}}
+	if len(manifest.PresentationDefinition) == 0 {
+		return app, nil
+	}
+
+	var pd verifier.PresentationDefinition
+	if err := json.Unmarshal(manifest.PresentationDefinition, &pd); err != nil {
+		return CredentialApplication{}, fmt.Errorf("manifest %s: invalid presentation definition: %w", manifest.ID, err)
+	}
+	ids, submission, err := w.MatchDefinition(uid, pd)
+	if err != nil {
+		return CredentialApplication{}, fmt.Errorf("manifest %s: %w", manifest.ID, err)
+	}
+	vp, err := w.CreatePresentation(uid, manifest.Issuer.ID, nonce, ids)
+	if err != nil {
+		return CredentialApplication{}, err
+	}
+	app.PresentationSubmission = &submission
+	app.VPToken = vp
+	return app, nil
+}
+
+func applicationID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,104 @@
+package wallet
+
+import (
+	"fmt"
+	"time"
+
+	"cosmos-app/jws"
+	"cosmos-app/verifier"
+)
+
+// PresentationTTL is how long presentations created by the wallet are valid.
+const PresentationTTL = 5 * time.Minute
+
+type vpClaims struct {
+	Iss   string `json:"iss"`
+	Aud   string `json:"aud"`
+	Nonce string `json:"nonce"`
+	Iat   int64  `json:"iat"`
+	Exp   int64  `json:"exp"`
+	VP    struct {
+		Context              []string `json:"@context"`
+		Type                 []string `json:"type"`
+		VerifiableCredential []string `json:"verifiableCredential"`
+	} `json:"vp"`
+}
+
+// CreatePresentation creates a JWT verifiable presentation of the stored
+// credentials with the given ids for a verifier identified by audience,
+// signed by the key stored under uid. Every credential must have been issued
+// to that key's DID.
+func (w *Wallet) CreatePresentation(uid, audience, nonce string, credentialIDs []string) (string, error) {
+	did, err := w.DID(uid)
+	if err != nil {
+		return "", err
+	}
+	kid, err := w.KeyID(uid)
+	if err != nil {
+		return "", err
+	}
+	if len(credentialIDs) == 0 {
+		return "", fmt.Errorf("no credentials to present")
+	}
+
+	now := time.Now()
+	claims := vpClaims{Iss: did, Aud: audience, Nonce: nonce, Iat: now.Unix(), Exp: now.Add(PresentationTTL).Unix()}
+	claims.VP.Context = []string{"https://www.w3.org/2018/credentials/v1"}
+	claims.VP.Type = []string{"VerifiablePresentation"}
+	for _, id := range credentialIDs {
+		cred, err := w.credentials.Get(id)
+		if err != nil {
+			return "", err
+		}
+		if cred.Subject != did {
+			return "", fmt.Errorf("credential %s was issued to %s, not %s", id, cred.Subject, did)
+		}
+		claims.VP.VerifiableCredential = append(claims.VP.VerifiableCredential, cred.Token)
+	}
+
+	return jws.SignWith(jws.Header{Kid: kid, Typ: "JWT"}, claims, func(signingInput []byte) ([]byte, error) {
+		return w.Sign(uid, signingInput)
+	})
+}
+
+// MatchDefinition selects stored credentials issued to the DID of the key
+// stored under uid that satisfy a Presentation Exchange definition. The
+// returned ids, passed to CreatePresentation in order, produce a presentation
+// the returned submission describes.
+func (w *Wallet) MatchDefinition(uid string, pd verifier.PresentationDefinition) ([]string, verifier.PresentationSubmission, error) {
+	did, err := w.DID(uid)
+	if err != nil {
+		return nil, verifier.PresentationSubmission{}, err
+	}
+	creds, err := w.credentials.List()
+	if err != nil {
+		return nil, verifier.PresentationSubmission{}, err
+	}
+
+	now := time.Now().Unix()
+	var candidates []verifier.Candidate
+	for _, cred := range creds {
+		if cred.Subject != did || (cred.ExpiresAt != 0 && now > cred.ExpiresAt) {
+			continue
+		}
+		parsed, err := jws.Parse(cred.Token)
+		if err != nil {
+			return nil, verifier.PresentationSubmission{}, fmt.Errorf("credential %s: %w", cred.ID, err)
+		}
+		var claims map[string]interface{}
+		if err := parsed.Claims(&claims); err != nil {
+			return nil, verifier.PresentationSubmission{}, fmt.Errorf("credential %s: invalid claims: %w", cred.ID, err)
+		}
+		candidates = append(candidates, verifier.Candidate{ID: cred.ID, Format: verifier.FormatJWTVC, Claims: claims})
+	}
+
+	selected, submission, err := verifier.Match(pd, candidates)
+	if err != nil {
+		return nil, verifier.PresentationSubmission{}, err
+	}
+	ids := make([]string, len(selected))
+	for i, c := range selected {
+		ids[i] = c.ID
+	}
+	return ids, submission, nil
+}
@@ -0,0 +1,33 @@
+package wallet
+
+import (
+	"cosmos-app/didcomm"
+	didmodule "cosmos-app/modules/did"
+)
+
+// PackServiceEndpoint encrypts endpoint to every keyAgreement key of doc. The
+// result is stored as the ServiceEndpoint of one of doc's services, so only
+// the holders of those keys can read it from the public document.
+func PackServiceEndpoint(doc didmodule.DIDDocument, endpoint string) (string, error) {
+	bz, err := didcomm.Encrypt(didmodule.MediaTypeEncryptedEndpoint, []byte(endpoint), doc)
+	if err != nil {
+		return "", err
+	}
+	return string(bz), nil
+}
+
+// UnpackServiceEndpoint returns the endpoint of svc, decrypting it with a
+// keyAgreement key from keys if it is encrypted.
+func UnpackServiceEndpoint(svc didmodule.Service, keys didcomm.KeyStore) (string, error) {
+	if !didmodule.IsEncryptedEndpoint(svc.ServiceEndpoint) {
+		return svc.ServiceEndpoint, nil
+	}
+	if _, err := didmodule.ParseEncryptedEndpoint(svc.ServiceEndpoint); err != nil {
+		return "", err
+	}
+	bz, err := didcomm.Decrypt([]byte(svc.ServiceEndpoint), keys)
+	if err != nil {
+		return "", err
+	}
+	return string(bz), nil
+}
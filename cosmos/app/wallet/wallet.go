@@ -0,0 +1,178 @@
+// Package wallet is a minimal holder wallet for the aytch chain. It keeps
+// Ed25519 DID keys in a Cosmos SDK keyring, or uses keys held by an external
+// signer such as a KMS, builds and signs DID module messages, stores received
+// credentials and creates presentations for the OpenID4VP verifier.
+package wallet
+
+import (
+	"crypto/ed25519"
+	"fmt"
+
+	didmodule "cosmos-app/modules/did"
+	"cosmos-app/signer"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/cosmos/cosmos-sdk/crypto"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdked25519 "github.com/cosmos/cosmos-sdk/crypto/keys/ed25519"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// importPassphrase only protects the armored key between generation and
+// import; the keyring applies its own protection once the key is stored.
+const importPassphrase = "aytch-wallet-import"
+
+// Wallet manages the DID keys and credentials of a holder.
+type Wallet struct {
+	kr          keyring.Keyring
+	signers     map[string]signer.Signer
+	credentials CredentialStore
+	network     string
+}
+
+// New creates a wallet over a keyring and credential store.
+func New(kr keyring.Keyring, credentials CredentialStore) *Wallet {
+	return &Wallet{kr: kr, signers: map[string]signer.Signer{}, credentials: credentials}
+}
+
+// AddSigner makes the key of an external signer available under uid, in
+// place of any keyring key of that name. The wallet then uses it like a
+// keyring key without ever holding the private key.
+func (w *Wallet) AddSigner(uid string, s signer.Signer) {
+	w.signers[uid] = s
+}
+
+// AddSignerURI opens the external signer named by uri, such as
+// vault://transit/issuer-key, and adds it under uid.
+func (w *Wallet) AddSignerURI(uid, uri string) error {
+	s, err := signer.Open(uri)
+	if err != nil {
+		return err
+	}
+	w.AddSigner(uid, s)
+	return nil
+}
+
+// SetNetwork sets the network namespace of the DIDs the wallet derives, for
+// chains whose Network param is set, such as did:aytch:testnet:...
+func (w *Wallet) SetNetwork(network string) {
+	w.network = network
+}
+
+// Keyring returns the wallet's keyring.
+func (w *Wallet) Keyring() keyring.Keyring {
+	return w.kr
+}
+
+// CreateKey generates a new Ed25519 DID key and stores it in the keyring
+// under uid. It returns the key's canonical did:aytch identifier.
+func (w *Wallet) CreateKey(uid string) (string, error) {
+	armor := crypto.EncryptArmorPrivKey(sdked25519.GenPrivKey(), importPassphrase, string(hd.Ed25519Type))
+	if err := w.kr.ImportPrivKey(uid, armor, importPassphrase); err != nil {
+		return "", err
+	}
+	return w.DID(uid)
+}
+
+// PublicKey returns the Ed25519 public key stored under uid.
+func (w *Wallet) PublicKey(uid string) (ed25519.PublicKey, error) {
+	if s, ok := w.signers[uid]; ok {
+		return s.PublicKey()
+	}
+	info, err := w.kr.Key(uid)
+	if err != nil {
+		return nil, err
+	}
+	pub := info.GetPubKey()
+	if _, ok := pub.(*sdked25519.PubKey); !ok {
+		return nil, fmt.Errorf("key %s is not an Ed25519 key", uid)
+	}
+	return ed25519.PublicKey(pub.Bytes()), nil
+}
+
+// DID returns the canonical did:aytch identifier of the key stored under uid.
+func (w *Wallet) DID(uid string) (string, error) {
+	pub, err := w.PublicKey(uid)
+	if err != nil {
+		return "", err
+	}
+	return didmodule.NetworkDIDFromKey(w.network, pub), nil
+}
+
+// KeyID returns the verification method id of the key stored under uid in its
+// key-derived document.
+func (w *Wallet) KeyID(uid string) (string, error) {
+	pub, err := w.PublicKey(uid)
+	if err != nil {
+		return "", err
+	}
+	return didmodule.NetworkDIDFromKey(w.network, pub) + "#" + didmodule.EncodeMultibaseKey(didmodule.MulticodecEd25519Pub, pub), nil
+}
+
+// Sign signs msg with the key stored under uid.
+func (w *Wallet) Sign(uid string, msg []byte) ([]byte, error) {
+	if s, ok := w.signers[uid]; ok {
+		return s.Sign(msg)
+	}
+	if _, err := w.PublicKey(uid); err != nil {
+		return nil, err
+	}
+	sig, _, err := w.kr.Sign(uid, msg)
+	return sig, err
+}
+
+// CreateDIDMsg builds a MsgCreateDID registering the key-derived DID of the
+// key stored under uid, paid for by creator.
+func (w *Wallet) CreateDIDMsg(uid string, services []didmodule.Service, creator sdk.AccAddress) (didmodule.MsgCreateDID, error) {
+	pub, err := w.PublicKey(uid)
+	if err != nil {
+		return didmodule.MsgCreateDID{}, err
+	}
+	mb := didmodule.EncodeMultibaseKey(didmodule.MulticodecEd25519Pub, pub)
+	doc := didmodule.DocumentFromKey(didmodule.NetworkDIDFromKey(w.network, pub), mb, pub)
+	return didmodule.MsgCreateDID{
+		ID:                  doc.ID,
+		PublicKey:           doc.PublicKey,
+		Authentication:      doc.Authentication,
+		VerificationMethods: doc.VerificationMethods,
+		Services:            services,
+		Creator:             creator,
+	}, nil
+}
+
+// UpdateDIDMsg builds a MsgUpdateDID replacing doc's stored contents, signed
+// by the key stored under uid as the controller DID signer. nonce must be the
+// document's current nonce.
+func (w *Wallet) UpdateDIDMsg(uid, signer string, doc didmodule.DIDDocument, nonce uint64, creator sdk.AccAddress) (didmodule.MsgUpdateDID, error) {
+	msg := didmodule.MsgUpdateDID{
+		ID:                  doc.ID,
+		PublicKey:           doc.PublicKey,
+		ServiceEndpoints:    doc.ServiceEndpoints,
+		Authentication:      doc.Authentication,
+		VerificationMethods: doc.VerificationMethods,
+		KeyAgreement:        doc.KeyAgreement,
+		Services:            doc.Services,
+		Controller:          doc.Controller,
+		ControllerThreshold: doc.ControllerThreshold,
+		RotationDelay:       doc.RotationDelay,
+		ExpiresAt:           doc.ExpiresAt,
+		Nonce:               nonce,
+		Signer:              signer,
+		Creator:             creator,
+	}
+	sig, err := w.Sign(uid, msg.ProofSignBytes())
+	if err != nil {
+		return didmodule.MsgUpdateDID{}, err
+	}
+	msg.Signature = sig
+	return msg, nil
+}
+
+// Broadcast signs msgs with the account key named by clientCtx.From and
+// broadcasts them. txf supplies chain, gas and fee settings; its keyring is
+// replaced by the wallet's.
+func (w *Wallet) Broadcast(clientCtx client.Context, txf tx.Factory, msgs ...sdk.Msg) error {
+	return tx.BroadcastTx(clientCtx.WithKeyring(w.kr), txf.WithKeybase(w.kr), msgs...)
+}
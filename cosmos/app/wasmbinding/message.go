@@ -0,0 +1,96 @@
+package wasmbinding
+
+import (
+	"encoding/json"
+	"fmt"
+
+	didmodule "cosmos-app/modules/did"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// DIDMsg is the custom message a contract emits to act on the DID module.
+// Exactly one field must be set. The contract itself is always the creator,
+// so a contract can only manage identities it controls; any creator in the
+// payload is ignored.
+type DIDMsg struct {
+	CreateDID         *didmodule.MsgCreateDID         `json:"create_did,omitempty"`
+	UpdateDID         *didmodule.MsgUpdateDID         `json:"update_did,omitempty"`
+	DeactivateDID     *didmodule.MsgDeactivateDID     `json:"deactivate_did,omitempty"`
+	CreateResource    *didmodule.MsgCreateResource    `json:"create_resource,omitempty"`
+	RevokeCredential  *didmodule.MsgRevokeCredential  `json:"revoke_credential,omitempty"`
+	ProposeDIDChange  *didmodule.MsgProposeDIDChange  `json:"propose_did_change,omitempty"`
+	ApproveDIDChange  *didmodule.MsgApproveDIDChange  `json:"approve_did_change,omitempty"`
+	SponsorDID        *didmodule.MsgSponsorDID        `json:"sponsor_did,omitempty"`
+	SetGuardians      *didmodule.MsgSetGuardians      `json:"set_guardians,omitempty"`
+	RecoverDID        *didmodule.MsgRecoverDID        `json:"recover_did,omitempty"`
+	CancelRecovery    *didmodule.MsgCancelRecovery    `json:"cancel_recovery,omitempty"`
+	RotateKey         *didmodule.MsgRotateKey         `json:"rotate_key,omitempty"`
+	CancelKeyRotation *didmodule.MsgCancelKeyRotation `json:"cancel_key_rotation,omitempty"`
+	RenewDID          *didmodule.MsgRenewDID          `json:"renew_did,omitempty"`
+	CreateDIDFromKey  *didmodule.MsgCreateDIDFromKey  `json:"create_did_from_key,omitempty"`
+}
+
+// CustomEncoder converts DIDMsg payloads into DID module messages signed by
+// the sending contract.
+func CustomEncoder(sender sdk.AccAddress, msg json.RawMessage) ([]sdk.Msg, error) {
+	var didMsg DIDMsg
+	if err := json.Unmarshal(msg, &didMsg); err != nil {
+		return nil, fmt.Errorf("invalid DID message: %w", err)
+	}
+
+	var out sdk.Msg
+	switch {
+	case didMsg.CreateDID != nil:
+		didMsg.CreateDID.Creator = sender
+		out = didMsg.CreateDID
+	case didMsg.UpdateDID != nil:
+		didMsg.UpdateDID.Creator = sender
+		out = didMsg.UpdateDID
+	case didMsg.DeactivateDID != nil:
+		didMsg.DeactivateDID.Creator = sender
+		out = didMsg.DeactivateDID
+	case didMsg.CreateResource != nil:
+		didMsg.CreateResource.Creator = sender
+		out = didMsg.CreateResource
+	case didMsg.RevokeCredential != nil:
+		didMsg.RevokeCredential.Creator = sender
+		out = didMsg.RevokeCredential
+	case didMsg.ProposeDIDChange != nil:
+		didMsg.ProposeDIDChange.Creator = sender
+		out = didMsg.ProposeDIDChange
+	case didMsg.ApproveDIDChange != nil:
+		didMsg.ApproveDIDChange.Creator = sender
+		out = didMsg.ApproveDIDChange
+	case didMsg.SponsorDID != nil:
+		didMsg.SponsorDID.Sponsor = sender
+		out = didMsg.SponsorDID
+	case didMsg.SetGuardians != nil:
+		didMsg.SetGuardians.Creator = sender
+		out = didMsg.SetGuardians
+	case didMsg.RecoverDID != nil:
+		didMsg.RecoverDID.Creator = sender
+		out = didMsg.RecoverDID
+	case didMsg.CancelRecovery != nil:
+		didMsg.CancelRecovery.Creator = sender
+		out = didMsg.CancelRecovery
+	case didMsg.RotateKey != nil:
+		didMsg.RotateKey.Creator = sender
+		out = didMsg.RotateKey
+	case didMsg.CancelKeyRotation != nil:
+		didMsg.CancelKeyRotation.Creator = sender
+		out = didMsg.CancelKeyRotation
+	case didMsg.RenewDID != nil:
+		didMsg.RenewDID.Creator = sender
+		out = didMsg.RenewDID
+	case didMsg.CreateDIDFromKey != nil:
+		didMsg.CreateDIDFromKey.Creator = sender
+		out = didMsg.CreateDIDFromKey
+	default:
+		return nil, fmt.Errorf("unknown DID message variant")
+	}
+	if err := out.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	return []sdk.Msg{out}, nil
+}
@@ -0,0 +1,67 @@
+package wasmbinding
+
+import (
+	"encoding/json"
+	"fmt"
+
+	didmodule "cosmos-app/modules/did"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// DIDQuery is the custom query a contract sends to the DID module. Exactly
+// one field must be set.
+type DIDQuery struct {
+	ResolveDID       *ResolveDID       `json:"resolve_did,omitempty"`
+	CredentialStatus *CredentialStatus `json:"credential_status,omitempty"`
+}
+
+// ResolveDID resolves a did:aytch identifier.
+type ResolveDID struct {
+	DID string `json:"did"`
+}
+
+// ResolveDIDResponse is returned for ResolveDID. Found is false when the DID
+// is not registered.
+type ResolveDIDResponse struct {
+	Found    bool                   `json:"found"`
+	Document *didmodule.DIDDocument `json:"document,omitempty"`
+}
+
+// CredentialStatus looks up the revocation state of a credential.
+type CredentialStatus struct {
+	ID string `json:"id"`
+}
+
+// CredentialStatusResponse is returned for CredentialStatus.
+type CredentialStatusResponse struct {
+	Status didmodule.CredentialStatus `json:"status"`
+}
+
+// CustomQuerier handles DIDQuery requests from contracts.
+func CustomQuerier(k didmodule.Keeper) func(ctx sdk.Context, request json.RawMessage) ([]byte, error) {
+	return func(ctx sdk.Context, request json.RawMessage) ([]byte, error) {
+		var query DIDQuery
+		if err := json.Unmarshal(request, &query); err != nil {
+			return nil, fmt.Errorf("invalid DID query: %w", err)
+		}
+
+		switch {
+		case query.ResolveDID != nil:
+			res := ResolveDIDResponse{}
+			if doc, err := k.GetDID(ctx, query.ResolveDID.DID); err == nil {
+				res.Found = true
+				res.Document = &doc
+			}
+			return json.Marshal(res)
+		case query.CredentialStatus != nil:
+			status, err := k.GetCredentialStatus(ctx, query.CredentialStatus.ID)
+			if err != nil {
+				return nil, err
+			}
+			return json.Marshal(CredentialStatusResponse{Status: status})
+		default:
+			return nil, fmt.Errorf("unknown DID query variant")
+		}
+	}
+}
@@ -0,0 +1,29 @@
+// Package wasmbinding exposes the DID module to CosmWasm contracts through
+// custom query and message bindings.
+//
+// Contracts query with {"resolve_did":{"did":"did:aytch:..."}} or
+// {"credential_status":{"id":"..."}} and emit {"create_did":{...}},
+// {"update_did":{...}}, {"deactivate_did":{...}}, {"create_resource":{...}}
+// or {"revoke_credential":{...}} as custom messages. A contract updates a DID
+// by listing its own address as a controller. Pass RegisterCustomPlugins to wasmkeeper.NewKeeper when wiring
+// x/wasm into the app.
+package wasmbinding
+
+import (
+	didmodule "cosmos-app/modules/did"
+
+	wasmkeeper "github.com/CosmWasm/wasmd/x/wasm/keeper"
+)
+
+// RegisterCustomPlugins returns the wasm keeper options that install the DID
+// query and message bindings.
+func RegisterCustomPlugins(k didmodule.Keeper) []wasmkeeper.Option {
+	return []wasmkeeper.Option{
+		wasmkeeper.WithQueryPlugins(&wasmkeeper.QueryPlugins{
+			Custom: CustomQuerier(k),
+		}),
+		wasmkeeper.WithMessageEncoders(&wasmkeeper.MessageEncoders{
+			Custom: CustomEncoder,
+		}),
+	}
+}
@@ -0,0 +1,200 @@
+// Package webhook delivers DID change events to registered HTTP endpoints,
+// for integrations that can't run a node or hold a websocket open.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"cosmos-app/client/resolver"
+	didmodule "cosmos-app/modules/did"
+)
+
+// Delivery headers. The signature is the hex HMAC-SHA256, keyed with the
+// subscription secret, of the timestamp, a '.' and the request body.
+const (
+	HeaderID        = "X-Aytch-Webhook-Id"
+	HeaderTimestamp = "X-Aytch-Webhook-Timestamp"
+	HeaderSignature = "X-Aytch-Webhook-Signature"
+)
+
+// DefaultBackoff retries a failed delivery for about two minutes.
+var DefaultBackoff = resolver.Backoff{Attempts: 8, Base: time.Second, Max: time.Minute}
+
+// Subscription registers a URL for the events of some DIDs and of every DID
+// controlled by some controllers.
+type Subscription struct {
+	URL         string   `json:"url"`
+	Secret      string   `json:"secret"`
+	DIDs        []string `json:"dids"`
+	Controllers []string `json:"controllers"`
+}
+
+// Payload is the JSON body POSTed for each event.
+type Payload struct {
+	ID      string             `json:"id"`
+	Type    string             `json:"type"`
+	Created time.Time          `json:"created"`
+	Event   didmodule.DIDEvent `json:"event"`
+}
+
+// Sign returns the signature header value for a delivery.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte{'.'})
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks a delivery's signature and that its timestamp is within
+// tolerance of now. Receivers should call it before trusting a payload.
+func Verify(secret string, r *http.Request, body []byte, tolerance time.Duration) error {
+	ts := r.Header.Get(HeaderTimestamp)
+	sec, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp")
+	}
+	if d := time.Since(time.Unix(sec, 0)); d > tolerance || d < -tolerance {
+		return fmt.Errorf("timestamp outside tolerance")
+	}
+	if !hmac.Equal([]byte(r.Header.Get(HeaderSignature)), []byte(Sign(secret, ts, body))) {
+		return fmt.Errorf("invalid signature")
+	}
+	return nil
+}
+
+// Notifier watches the chain and delivers events to subscriptions. Each
+// subscription is delivered to in order; a failing endpoint only delays its
+// own events.
+type Notifier struct {
+	watcher *didmodule.Watcher
+	client  *http.Client
+	backoff resolver.Backoff
+}
+
+// NewNotifier creates a notifier reading events from watcher. A nil client
+// uses a client with a 10 second timeout.
+func NewNotifier(watcher *didmodule.Watcher, client *http.Client) *Notifier {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &Notifier{watcher: watcher, client: client, backoff: DefaultBackoff}
+}
+
+// Run delivers events to subs until ctx is done.
+func (n *Notifier) Run(ctx context.Context, subs []Subscription) error {
+	var wg sync.WaitGroup
+	for _, sub := range subs {
+		events, err := n.watch(ctx, sub)
+		if err != nil {
+			return fmt.Errorf("subscribe %s: %w", sub.URL, err)
+		}
+		wg.Add(1)
+		go func(sub Subscription) {
+			defer wg.Done()
+			n.deliverAll(ctx, sub, events)
+		}(sub)
+	}
+	wg.Wait()
+	return ctx.Err()
+}
+
+// watch merges the event streams of every DID and controller in sub.
+func (n *Notifier) watch(ctx context.Context, sub Subscription) (<-chan didmodule.DIDEvent, error) {
+	var reqs []didmodule.WatchDIDRequest
+	for _, id := range sub.DIDs {
+		reqs = append(reqs, didmodule.WatchDIDRequest{DID: id})
+	}
+	for _, c := range sub.Controllers {
+		reqs = append(reqs, didmodule.WatchDIDRequest{Controller: c})
+	}
+	if len(reqs) == 0 {
+		return nil, fmt.Errorf("no DIDs or controllers")
+	}
+
+	out := make(chan didmodule.DIDEvent, 64)
+	var wg sync.WaitGroup
+	for _, req := range reqs {
+		events, err := n.watcher.Watch(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ev := range events {
+				out <- ev
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out, nil
+}
+
+func (n *Notifier) deliverAll(ctx context.Context, sub Subscription, events <-chan didmodule.DIDEvent) {
+	// A DID matching both a DID and a controller filter arrives twice;
+	// versions only grow, so anything not newer was already delivered.
+	delivered := map[string]uint64{}
+	for ev := range events {
+		if v, ok := delivered[ev.DID]; ok && ev.Version <= v {
+			continue
+		}
+		delivered[ev.DID] = ev.Version
+		if err := n.deliver(ctx, sub, ev); err != nil {
+			log.Printf("webhook %s: dropping %s version %d: %v", sub.URL, ev.DID, ev.Version, err)
+		}
+	}
+}
+
+// deliver POSTs one event, retrying network errors and 5xx and 429
+// responses.
+func (n *Notifier) deliver(ctx context.Context, sub Subscription, ev didmodule.DIDEvent) error {
+	p := Payload{
+		ID:      fmt.Sprintf("%s#%d", ev.DID, ev.Version),
+		Type:    "did." + ev.Operation,
+		Created: time.Now().UTC(),
+		Event:   ev,
+	}
+	body, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	return n.backoff.Retry(ctx, func() error {
+		ts := strconv.FormatInt(time.Now().Unix(), 10)
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(HeaderID, p.ID)
+		req.Header.Set(HeaderTimestamp, ts)
+		req.Header.Set(HeaderSignature, Sign(sub.Secret, ts, body))
+		resp, err := n.client.Do(req)
+		if err != nil {
+			return resolver.Temporary(err)
+		}
+		resp.Body.Close()
+		switch {
+		case resp.StatusCode < 300:
+			return nil
+		case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+			return resolver.Temporary(fmt.Errorf("status %s", resp.Status))
+		default:
+			return fmt.Errorf("status %s", resp.Status)
+		}
+	})
+}